@@ -57,12 +57,17 @@ type Config struct {
 	SSLDHParamsFile         string `json:"irods_ssl_dh_params_file,omitempty" yaml:"irods_ssl_dh_params_file,omitempty" envconfig:"IRODS_SSL_DH_PARAMS_FILE"`
 
 	// go-irodsclient only
-	Password      string `json:"irods_user_password,omitempty" yaml:"irods_user_password,omitempty" envconfig:"IRODS_USER_PASSWORD"`
-	Ticket        string `json:"irods_ticket,omitempty" yaml:"irods_ticket,omitempty" envconfig:"IRODS_TICKET"`
-	PAMToken      string `json:"irods_pam_token,omitempty" yaml:"irods_pam_token,omitempty" envconfig:"IRODS_PAM_TOKEN"`
-	PAMTTL        int    `json:"irods_pam_ttl,omitempty" yaml:"irods_pam_ttl,omitempty" envconfig:"IRODS_PAM_TTL"`
-	SSLServerName string `json:"irods_ssl_server_name,omitempty" yaml:"irods_ssl_server_name,omitempty" envconfig:"IRODS_SSL_SERVER_NAME"`
-	WebDAVBaseURL string `json:"irods_webdav_base_url,omitempty" yaml:"irods_webdav_base_url,omitempty" envconfig:"IRODS_WEBDAV_BASE_URL"`
+	Password                 string   `json:"irods_user_password,omitempty" yaml:"irods_user_password,omitempty" envconfig:"IRODS_USER_PASSWORD"`
+	Ticket                   string   `json:"irods_ticket,omitempty" yaml:"irods_ticket,omitempty" envconfig:"IRODS_TICKET"`
+	PAMToken                 string   `json:"irods_pam_token,omitempty" yaml:"irods_pam_token,omitempty" envconfig:"IRODS_PAM_TOKEN"`
+	PAMTTL                   int      `json:"irods_pam_ttl,omitempty" yaml:"irods_pam_ttl,omitempty" envconfig:"IRODS_PAM_TTL"`
+	SSLServerName            string   `json:"irods_ssl_server_name,omitempty" yaml:"irods_ssl_server_name,omitempty" envconfig:"IRODS_SSL_SERVER_NAME"`
+	SSLClientCertificateFile string   `json:"irods_ssl_client_certificate_file,omitempty" yaml:"irods_ssl_client_certificate_file,omitempty" envconfig:"IRODS_SSL_CLIENT_CERTIFICATE_FILE"`
+	SSLClientKeyFile         string   `json:"irods_ssl_client_key_file,omitempty" yaml:"irods_ssl_client_key_file,omitempty" envconfig:"IRODS_SSL_CLIENT_KEY_FILE"`
+	WebDAVBaseURL            string   `json:"irods_webdav_base_url,omitempty" yaml:"irods_webdav_base_url,omitempty" envconfig:"IRODS_WEBDAV_BASE_URL"`
+	ProxyURL                 string   `json:"irods_proxy_url,omitempty" yaml:"irods_proxy_url,omitempty" envconfig:"IRODS_PROXY_URL"`
+	FailoverHosts            []string `json:"irods_failover_hosts,omitempty" yaml:"irods_failover_hosts,omitempty" envconfig:"IRODS_FAILOVER_HOSTS"`
+	SRVDomain                string   `json:"irods_srv_domain,omitempty" yaml:"irods_srv_domain,omitempty" envconfig:"IRODS_SRV_DOMAIN"`
 
 	// not used
 	GSIServerDN string `json:"irods_gsi_server_dn,omitempty" yaml:"irods_gsi_server_dn,omitempty" envconfig:"IRODS_GSI_SERVER_DN"`
@@ -267,6 +272,9 @@ func (cfg *Config) ToIRODSAccount() *types.IRODSAccount {
 		Ticket:                  cfg.Ticket,
 		PamTTL:                  cfg.PAMTTL,
 		PAMToken:                cfg.PAMToken,
+		ProxyURL:                cfg.ProxyURL,
+		FailoverHosts:           cfg.FailoverHosts,
+		SRVDomain:               cfg.SRVDomain,
 		SSLConfiguration: &types.IRODSSSLConfig{
 			CACertificateFile:       cfg.SSLCACertificateFile,
 			CACertificatePath:       cfg.SSLCACertificatePath,
@@ -277,6 +285,8 @@ func (cfg *Config) ToIRODSAccount() *types.IRODSAccount {
 			VerifyServer:            verifyServer,
 			DHParamsFile:            cfg.SSLDHParamsFile,
 			ServerName:              cfg.SSLServerName,
+			ClientCertificateFile:   cfg.SSLClientCertificateFile,
+			ClientKeyFile:           cfg.SSLClientKeyFile,
 		},
 	}
 
@@ -294,6 +304,11 @@ func (cfg *Config) ClearICommandsIncompatibleFields() *Config {
 	cfg2.PAMToken = ""
 	cfg2.PAMTTL = 0
 	cfg2.SSLServerName = ""
+	cfg2.SSLClientCertificateFile = ""
+	cfg2.SSLClientKeyFile = ""
+	cfg2.ProxyURL = ""
+	cfg2.FailoverHosts = nil
+	cfg2.SRVDomain = ""
 
 	return &cfg2
 }
@@ -17,6 +17,11 @@ const (
 	environmentDirDefault      string = "~/.irods"
 	passwordFilenameDefault    string = ".irodsA"
 	environmentFilenameDefault string = "irods_environment.json"
+
+	// environment variables that icommands honor
+	environmentFileEnvVar string = "IRODS_ENVIRONMENT_FILE"
+	passwordEnvVar        string = "IRODS_USER_PASSWORD"
+	pamTokenEnvVar        string = "IRODS_PAM_TOKEN"
 )
 
 // GetDefaultEnvironmentDirPath returns default environment dir path
@@ -53,6 +58,7 @@ type ICommandsEnvironmentManager struct {
 }
 
 // NewICommandsEnvironmentManager creates ICommandsEnvironmentManager
+// the environment file given by IRODS_ENVIRONMENT_FILE is used if set, as icommands do
 func NewICommandsEnvironmentManager() (*ICommandsEnvironmentManager, error) {
 	environmentDirPath := GetDefaultEnvironmentDirPath()
 	environmentFilePath := GetDefaultEnvironmentFilePath()
@@ -61,7 +67,7 @@ func NewICommandsEnvironmentManager() (*ICommandsEnvironmentManager, error) {
 	sessionFilePath := filepath.Join(environmentDirPath, sessionFilename)
 	passwordFilePath := GetDefaultPasswordFilePath()
 
-	return &ICommandsEnvironmentManager{
+	manager := &ICommandsEnvironmentManager{
 		EnvironmentDirPath:  environmentDirPath,
 		EnvironmentFilePath: environmentFilePath,
 		SessionFilePath:     sessionFilePath,
@@ -72,7 +78,16 @@ func NewICommandsEnvironmentManager() (*ICommandsEnvironmentManager, error) {
 
 		Environment: GetDefaultConfig(),
 		Session:     &Config{},
-	}, nil
+	}
+
+	if envFilePath, ok := os.LookupEnv(environmentFileEnvVar); ok && len(envFilePath) > 0 {
+		err := manager.SetEnvironmentFilePath(envFilePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return manager, nil
 }
 
 // SetPPID sets ppid of environment, used to obfuscate password
@@ -122,6 +137,9 @@ func (manager *ICommandsEnvironmentManager) FromIRODSAccount(account *types.IROD
 	manager.Environment.Ticket = account.Ticket
 	manager.Environment.PAMToken = account.PAMToken
 	manager.Environment.PAMTTL = account.PamTTL
+	manager.Environment.ProxyURL = account.ProxyURL
+	manager.Environment.FailoverHosts = account.FailoverHosts
+	manager.Environment.SRVDomain = account.SRVDomain
 
 	manager.Environment.DefaultResource = account.DefaultResource
 	manager.Environment.DefaultHashScheme = account.DefaultHashScheme
@@ -136,6 +154,8 @@ func (manager *ICommandsEnvironmentManager) FromIRODSAccount(account *types.IROD
 		manager.Environment.SSLVerifyServer = string(account.SSLConfiguration.VerifyServer)
 		manager.Environment.SSLDHParamsFile = account.SSLConfiguration.DHParamsFile
 		manager.Environment.SSLServerName = account.SSLConfiguration.ServerName
+		manager.Environment.SSLClientCertificateFile = account.SSLConfiguration.ClientCertificateFile
+		manager.Environment.SSLClientKeyFile = account.SSLConfiguration.ClientKeyFile
 	}
 
 	manager.FixAuthConfiguration()
@@ -187,11 +207,24 @@ func (manager *ICommandsEnvironmentManager) Load() error {
 			}
 
 			manager.Environment = cfg
+		}
+	}
 
-			if len(manager.Environment.AuthenticationFile) > 0 {
-				manager.PasswordFilePath = manager.Environment.AuthenticationFile
-			}
+	// IRODS_* environment variables override values in the file
+	cfg, err := NewConfigFromEnv(manager.Environment)
+	if err != nil {
+		return errors.Wrapf(err, "failed to override icommands configuration with environmental variables")
+	}
+
+	manager.Environment = cfg
+
+	if len(manager.Environment.AuthenticationFile) > 0 {
+		passwordFilePath, err := util.ExpandHomeDir(manager.Environment.AuthenticationFile)
+		if err != nil {
+			return errors.Wrapf(err, "failed to expand home dir %q", manager.Environment.AuthenticationFile)
 		}
+
+		manager.PasswordFilePath = passwordFilePath
 	}
 
 	// read session
@@ -208,8 +241,10 @@ func (manager *ICommandsEnvironmentManager) Load() error {
 		}
 	}
 
-	// read password (.irodsA)
-	if len(manager.PasswordFilePath) > 0 {
+	// read password (.irodsA), password given by environmental variables takes precedence
+	_, hasPasswordEnv := os.LookupEnv(passwordEnvVar)
+	_, hasPAMTokenEnv := os.LookupEnv(pamTokenEnvVar)
+	if len(manager.PasswordFilePath) > 0 && !hasPasswordEnv && !hasPAMTokenEnv {
 		if util.ExistFile(manager.PasswordFilePath) {
 			logger.Debugf("reading icommands password file %q", manager.PasswordFilePath)
 
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"bytes"
 	"os"
 	"time"
 
@@ -54,6 +55,74 @@ func (obf *PasswordObfuscator) SetUID(uid int) {
 	obf.UID = uid
 }
 
+// ReadPasswordFile reads an icommands password file (.irodsA) and returns the password in plaintext
+// the file is decoded using the current user's UID, as iinit does
+func ReadPasswordFile(path string) (string, error) {
+	return ReadPasswordFileWithUID(path, os.Getuid())
+}
+
+// ReadPasswordFileWithUID reads an icommands password file (.irodsA) written for the given UID
+func ReadPasswordFileWithUID(path string, uid int) (string, error) {
+	obfuscator := NewPasswordObfuscator()
+	obfuscator.SetUID(uid)
+
+	passwordBytes, err := obfuscator.DecodeFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return string(passwordBytes), nil
+}
+
+// WritePasswordFile writes the password to an icommands password file (.irodsA)
+// the file is encoded using the current user's UID, so icommands can read it
+func WritePasswordFile(path string, password string) error {
+	return WritePasswordFileWithUID(path, password, os.Getuid())
+}
+
+// WritePasswordFileWithUID writes the password to an icommands password file (.irodsA) for the given UID
+// useful to generate credentials for other users, icommands run by the user of the UID can read it
+func WritePasswordFileWithUID(path string, password string, uid int) error {
+	obfuscator := NewPasswordObfuscator()
+	obfuscator.SetUID(uid)
+
+	return obfuscator.EncodeToFile(path, []byte(password))
+}
+
+// ObfuscatePassword obfuscates the password in the icommands password file (.irodsA) format for the given UID
+func ObfuscatePassword(password string, uid int) string {
+	obfuscator := NewPasswordObfuscator()
+	obfuscator.SetUID(uid)
+
+	return string(obfuscator.Encode([]byte(password)))
+}
+
+// DeobfuscatePassword de-obfuscates the password in the icommands password file (.irodsA) format for the given UID
+func DeobfuscatePassword(obfuscatedPassword string, uid int) (string, error) {
+	obfuscator := NewPasswordObfuscator()
+	obfuscator.SetUID(uid)
+
+	if !obfuscator.IsValidEncoding([]byte(obfuscatedPassword)) {
+		return "", errors.Errorf("failed to decode password, invalid password format")
+	}
+
+	return string(obfuscator.Decode([]byte(obfuscatedPassword))), nil
+}
+
+// IsValidEncoding checks if the encoded password has a valid format
+func (obf *PasswordObfuscator) IsValidEncoding(encodedPassword []byte) bool {
+	if len(encodedPassword) < 7 {
+		return false
+	}
+
+	if encodedPassword[0] != '.' {
+		return false
+	}
+
+	seqIndex := int(encodedPassword[6]) - 'e'
+	return seqIndex >= 0 && seqIndex < len(seqList)
+}
+
 // DecodeFile decodes password string in a file
 func (obf *PasswordObfuscator) DecodeFile(path string) ([]byte, error) {
 	content, err := os.ReadFile(path)
@@ -61,6 +130,13 @@ func (obf *PasswordObfuscator) DecodeFile(path string) ([]byte, error) {
 		return nil, errors.Wrapf(err, "failed to read file %q", path)
 	}
 
+	// files edited by hand may have a trailing newline
+	content = bytes.TrimRight(content, "\r\n")
+
+	if !obf.IsValidEncoding(content) {
+		return nil, errors.Errorf("failed to decode file %q, invalid password file format", path)
+	}
+
 	return obf.Decode(content), nil
 }
 
@@ -72,11 +148,22 @@ func (obf *PasswordObfuscator) EncodeToFile(path string, password []byte) error
 	if err != nil {
 		return errors.Wrapf(err, "failed to write file %q", path)
 	}
+
+	// icommands refuse to read a password file that is accessible by others
+	err = os.Chmod(path, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to change mode of file %q", path)
+	}
 	return nil
 }
 
 // Decode decodes password
+// returns an empty password if the encoded password is malformed
 func (obf *PasswordObfuscator) Decode(encodedPassword []byte) []byte {
+	if !obf.IsValidEncoding(encodedPassword) {
+		return []byte{}
+	}
+
 	// This value lets us know which seq value to use
 	// Referred to as "rval" in the C code
 	seqIndex := encodedPassword[6] - 'e'
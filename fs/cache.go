@@ -11,10 +11,12 @@ import (
 )
 
 // MetadataCacheTimeoutSetting defines cache timeout for path
+// if Inherit is set, the setting also applies to sub-entries of the path, the setting for the longest path wins
 type MetadataCacheTimeoutSetting struct {
 	Path    string         `yaml:"path" json:"path"`
 	Timeout types.Duration `yaml:"timeout" json:"timeout"`
 	Inherit bool           `yaml:"inherit,omitempty" json:"inherit,omitempty"`
+	NoCache bool           `yaml:"no_cache,omitempty" json:"no_cache,omitempty"` // if true, do not cache the path, useful for paths written by external writers
 }
 
 // CacheConfig defines cache config
@@ -30,6 +32,12 @@ type CacheConfig struct {
 	// for postgresql iCAT backend, this can be false.
 	StartNewTransaction bool `yaml:"start_new_transaction,omitempty" json:"start_new_transaction,omitempty"`
 	NoCache             bool `yaml:"no_cache,omitempty" json:"no_cache,omitempty"` // if true, do not use cache
+
+	// negative entry caches remember paths not found, so Stat fails fast
+	// services that create objects right after a failed Stat via other clients may want a short timeout or no negative cache
+	NegativeEntryTimeout   types.Duration `yaml:"negative_entry_timeout,omitempty" json:"negative_entry_timeout,omitempty"`       // 0 uses Timeout, path-specific timeout settings take precedence
+	NegativeEntryMaxNumber int            `yaml:"negative_entry_max_number,omitempty" json:"negative_entry_max_number,omitempty"` // 0 is unbounded, ignored if SharedCache is used
+	NoNegativeCache        bool           `yaml:"no_negative_cache,omitempty" json:"no_negative_cache,omitempty"`                 // if true, do not cache paths not found
 }
 
 // NewDefaultCacheConfig creates a new default CacheConfig
@@ -50,15 +58,15 @@ type FileSystemCache struct {
 
 	cacheTimeoutPathMap map[string]MetadataCacheTimeoutSetting
 
-	entryCache         *gocache.Cache
-	negativeEntryCache *gocache.Cache
-	dirCache           *gocache.Cache
-	metadataCache      *gocache.Cache
-	userCache          map[string]*gocache.Cache // zone is key
-	userListCache      map[string]*gocache.Cache // zone is key
-	groupMemberCache   map[string]*gocache.Cache // zone is key
-	userGroupCache     map[string]*gocache.Cache // zone is key
-	aclCache           *gocache.Cache
+	store     *cacheStore // entry, negative entry, dir, metadata and ACLs caches
+	namespace string      // prefix of keys in store, empty if store is not shared
+
+	userCache        map[string]*gocache.Cache // zone is key
+	userListCache    map[string]*gocache.Cache // zone is key
+	groupMemberCache map[string]*gocache.Cache // zone is key
+	userGroupCache   map[string]*gocache.Cache // zone is key
+
+	entryEventHandlerMap *CacheEntryEventHandlerMap
 }
 
 // NewFileSystemCache creates a new FileSystemCache
@@ -68,52 +76,115 @@ func NewFileSystemCache(config *CacheConfig) *FileSystemCache {
 		config = &cacheConfig
 	}
 
-	timeout := time.Duration(config.Timeout)
-	cleanupTime := time.Duration(config.CleanupTime)
+	store := newCacheStore(time.Duration(config.Timeout), time.Duration(config.CleanupTime), 0)
+	if config.NegativeEntryMaxNumber > 0 {
+		store.maxEntries[NegativeEntryCacheKind] = config.NegativeEntryMaxNumber
+	}
+
+	return newFileSystemCache(config, store, "")
+}
+
+// NewFileSystemCacheWithSharedCache creates a new FileSystemCache that stores path caches in the shared cache
+// caches are separated by the user and zone, FileSystemCaches of the same user share caches
+func NewFileSystemCacheWithSharedCache(config *CacheConfig, sharedCache *SharedCache, user string, zone string) *FileSystemCache {
+	if config == nil {
+		cacheConfig := NewDefaultCacheConfig()
+		config = &cacheConfig
+	}
 
-	entryCache := gocache.New(timeout, cleanupTime)
-	negativeEntryCache := gocache.New(timeout, cleanupTime)
-	dirCache := gocache.New(timeout, cleanupTime)
-	metadataCache := gocache.New(timeout, cleanupTime)
-	userCache := map[string]*gocache.Cache{}
-	userListCache := map[string]*gocache.Cache{}
-	groupUserCache := map[string]*gocache.Cache{}
-	userGroupCache := map[string]*gocache.Cache{}
-	aclCache := gocache.New(timeout, cleanupTime)
+	namespace := fmt.Sprintf("%s#%s", user, zone)
+	return newFileSystemCache(config, sharedCache.store, namespace)
+}
 
+func newFileSystemCache(config *CacheConfig, store *cacheStore, namespace string) *FileSystemCache {
 	// build a map for quick search
 	cacheTimeoutSettingMap := map[string]MetadataCacheTimeoutSetting{}
 	for _, timeoutSetting := range config.MetadataTimeoutSettings {
 		cacheTimeoutSettingMap[timeoutSetting.Path] = timeoutSetting
 	}
 
-	return &FileSystemCache{
+	cache := &FileSystemCache{
 		config: config,
 
 		cacheTimeoutPathMap: cacheTimeoutSettingMap,
 
-		entryCache:         entryCache,
-		negativeEntryCache: negativeEntryCache,
-		dirCache:           dirCache,
-		metadataCache:      metadataCache,
-		groupMemberCache:   groupUserCache,
-		userGroupCache:     userGroupCache,
-		userCache:          userCache,
-		userListCache:      userListCache,
-		aclCache:           aclCache,
+		store:     store,
+		namespace: namespace,
+
+		userCache:        map[string]*gocache.Cache{},
+		userListCache:    map[string]*gocache.Cache{},
+		groupMemberCache: map[string]*gocache.Cache{},
+		userGroupCache:   map[string]*gocache.Cache{},
+
+		entryEventHandlerMap: NewCacheEntryEventHandlerMap(),
 	}
+
+	store.addListener(namespace, cache)
+
+	return cache
+}
+
+// AddEntryEventHandler adds a handler that receives events when path caches are added, invalidated or expired
+func (cache *FileSystemCache) AddEntryEventHandler(handler CacheEntryEventHandler) string {
+	return cache.entryEventHandlerMap.AddEventHandler(handler)
 }
 
-func (cache *FileSystemCache) getCacheTTLForPath(path string) time.Duration {
+// RemoveEntryEventHandler removes cache entry event handler
+func (cache *FileSystemCache) RemoveEntryEventHandler(handlerID string) {
+	cache.entryEventHandlerMap.RemoveEventHandler(handlerID)
+}
+
+// Release releases resources, caches in the shared cache are kept for other FileSystemCaches
+func (cache *FileSystemCache) Release() {
+	cache.store.removeListener(cache.namespace, cache)
+	cache.entryEventHandlerMap.Release()
+}
+
+// key returns a key in store for the path
+func (cache *FileSystemCache) key(path string) string {
+	return cache.namespace + path
+}
+
+// set adds a cache to store, ttl 0 uses the cache timeout of the config
+func (cache *FileSystemCache) set(kind CacheKind, path string, value interface{}, ttl time.Duration) {
+	if ttl == 0 {
+		ttl = time.Duration(cache.config.Timeout)
+	}
+
+	cache.store.set(kind, cache.key(path), value, ttl)
+}
+
+// invalidateAllForPath removes caches for the given path and its sub-entries
+func (cache *FileSystemCache) invalidateAllForPath(kind CacheKind, path string) {
+	prefix := fmt.Sprintf("%s/", strings.TrimSuffix(cache.key(path), "/"))
+	cache.store.invalidateAll(kind, cache.key(path), prefix)
+}
+
+// getCacheTTLForPath returns cache ttl for the path, returns false if the path must not be cached
+func (cache *FileSystemCache) getCacheTTLForPath(path string) (time.Duration, bool) {
+	timeoutSetting := cache.getCacheTimeoutSettingForPath(path)
+	if timeoutSetting == nil {
+		// use default
+		return 0, true
+	}
+
+	if timeoutSetting.NoCache {
+		return 0, false
+	}
+
+	return time.Duration(timeoutSetting.Timeout), true
+}
+
+func (cache *FileSystemCache) getCacheTimeoutSettingForPath(path string) *MetadataCacheTimeoutSetting {
 	if len(cache.cacheTimeoutPathMap) == 0 {
 		// no data
-		return 0
+		return nil
 	}
 
 	// check map first
 	if timeoutSetting, ok := cache.cacheTimeoutPathMap[path]; ok {
 		// exact match
-		return time.Duration(timeoutSetting.Timeout)
+		return &timeoutSetting
 	}
 
 	// check inherit
@@ -125,13 +196,30 @@ func (cache *FileSystemCache) getCacheTTLForPath(path string) time.Duration {
 			// parent match
 			if timeoutSetting.Inherit {
 				// inherit
-				return time.Duration(timeoutSetting.Timeout)
+				return &timeoutSetting
 			}
 		}
 	}
 
-	// use default
-	return 0
+	return nil
+}
+
+// GetCacheTimeoutForPath returns cache timeout applied to the path, returns 0 if the path is not cached
+func (cache *FileSystemCache) GetCacheTimeoutForPath(path string) time.Duration {
+	if cache.config.NoCache {
+		return 0
+	}
+
+	ttl, cacheable := cache.getCacheTTLForPath(path)
+	if !cacheable {
+		return 0
+	}
+
+	if ttl == 0 {
+		return time.Duration(cache.config.Timeout)
+	}
+
+	return ttl
 }
 
 // AddEntryCache adds an entry cache
@@ -140,8 +228,12 @@ func (cache *FileSystemCache) AddEntryCache(entry *Entry) {
 		return
 	}
 
-	ttl := cache.getCacheTTLForPath(entry.Path)
-	cache.entryCache.Set(entry.Path, entry, ttl)
+	ttl, cacheable := cache.getCacheTTLForPath(entry.Path)
+	if !cacheable {
+		return
+	}
+
+	cache.set(EntryCacheKind, entry.Path, entry, ttl)
 }
 
 // RemoveEntryCache removes an entry cache
@@ -150,7 +242,7 @@ func (cache *FileSystemCache) RemoveEntryCache(path string) {
 		return
 	}
 
-	cache.entryCache.Delete(path)
+	cache.store.invalidate(EntryCacheKind, cache.key(path))
 }
 
 // RemoveDirEntryCache removes an entry cache for dir
@@ -159,20 +251,13 @@ func (cache *FileSystemCache) RemoveDirEntryCache(path string, recurse bool) {
 		return
 	}
 
-	cache.entryCache.Delete(path)
-
 	if recurse {
-		prefix := strings.TrimSuffix(path, "/") + "/"
-
-		// recursive
-		items := cache.entryCache.Items()
-		for k := range items {
-			if strings.HasPrefix(k, prefix) {
-				// entries under k dir
-				cache.entryCache.Delete(k)
-			}
-		}
+		// entries under the dir
+		cache.invalidateAllForPath(EntryCacheKind, path)
+		return
 	}
+
+	cache.store.invalidate(EntryCacheKind, cache.key(path))
 }
 
 // RemoveParentDirCache removes an entry cache for the parent path of the given path
@@ -193,7 +278,7 @@ func (cache *FileSystemCache) GetEntryCache(path string) *Entry {
 		return nil
 	}
 
-	if entry, exist := cache.entryCache.Get(path); exist {
+	if entry, exist := cache.store.get(EntryCacheKind, cache.key(path)); exist {
 		if fsentry, ok := entry.(*Entry); ok {
 			return fsentry
 		}
@@ -207,17 +292,40 @@ func (cache *FileSystemCache) ClearEntryCache() {
 		return
 	}
 
-	cache.entryCache.Flush()
+	cache.store.flush(EntryCacheKind, cache.namespace)
+}
+
+// IsNegativeEntryCacheEnabled returns true if paths not found are cached
+func (cache *FileSystemCache) IsNegativeEntryCacheEnabled() bool {
+	return !cache.config.NoCache && !cache.config.NoNegativeCache
+}
+
+// IsDirCacheNegativeEntryEnabled returns true if dir caches tell absence of paths like negative entry caches
+// dir caches are not used if negative entries expire earlier, names missing in dir caches would be cached longer
+func (cache *FileSystemCache) IsDirCacheNegativeEntryEnabled() bool {
+	if !cache.IsNegativeEntryCacheEnabled() {
+		return false
+	}
+
+	return cache.config.NegativeEntryTimeout <= 0 || cache.config.NegativeEntryTimeout >= cache.config.Timeout
 }
 
 // AddNegativeEntryCache adds a negative entry cache
 func (cache *FileSystemCache) AddNegativeEntryCache(path string) {
-	if cache.config.NoCache {
+	if !cache.IsNegativeEntryCacheEnabled() {
 		return
 	}
 
-	ttl := cache.getCacheTTLForPath(path)
-	cache.negativeEntryCache.Set(path, true, ttl)
+	ttl, cacheable := cache.getCacheTTLForPath(path)
+	if !cacheable {
+		return
+	}
+
+	if ttl == 0 && cache.config.NegativeEntryTimeout > 0 {
+		ttl = time.Duration(cache.config.NegativeEntryTimeout)
+	}
+
+	cache.set(NegativeEntryCacheKind, path, true, ttl)
 }
 
 // RemoveNegativeEntryCache removes a negative entry cache
@@ -226,7 +334,7 @@ func (cache *FileSystemCache) RemoveNegativeEntryCache(path string) {
 		return
 	}
 
-	cache.negativeEntryCache.Delete(path)
+	cache.store.invalidate(NegativeEntryCacheKind, cache.key(path))
 }
 
 // RemoveAllNegativeEntryCacheForPath removes all negative entry caches
@@ -235,26 +343,16 @@ func (cache *FileSystemCache) RemoveAllNegativeEntryCacheForPath(path string) {
 		return
 	}
 
-	prefix := fmt.Sprintf("%s/", path)
-	deleteKey := []string{}
-	for k := range cache.negativeEntryCache.Items() {
-		if k == path || strings.HasPrefix(k, prefix) {
-			deleteKey = append(deleteKey, k)
-		}
-	}
-
-	for _, k := range deleteKey {
-		cache.negativeEntryCache.Delete(k)
-	}
+	cache.invalidateAllForPath(NegativeEntryCacheKind, path)
 }
 
 // HasNegativeEntryCache checks the existence of a negative entry cache
 func (cache *FileSystemCache) HasNegativeEntryCache(path string) bool {
-	if cache.config.NoCache {
+	if !cache.IsNegativeEntryCacheEnabled() {
 		return false
 	}
 
-	if exist, existOk := cache.negativeEntryCache.Get(path); existOk {
+	if exist, existOk := cache.store.get(NegativeEntryCacheKind, cache.key(path)); existOk {
 		if bexist, ok := exist.(bool); ok {
 			return bexist
 		}
@@ -268,7 +366,7 @@ func (cache *FileSystemCache) ClearNegativeEntryCache() {
 		return
 	}
 
-	cache.negativeEntryCache.Flush()
+	cache.store.flush(NegativeEntryCacheKind, cache.namespace)
 }
 
 // AddDirCache adds a dir cache
@@ -277,8 +375,12 @@ func (cache *FileSystemCache) AddDirCache(path string, entries []string) {
 		return
 	}
 
-	ttl := cache.getCacheTTLForPath(path)
-	cache.dirCache.Set(path, entries, ttl)
+	ttl, cacheable := cache.getCacheTTLForPath(path)
+	if !cacheable {
+		return
+	}
+
+	cache.set(DirCacheKind, path, entries, ttl)
 }
 
 // RemoveDirCache removes a dir cache
@@ -287,7 +389,16 @@ func (cache *FileSystemCache) RemoveDirCache(path string) {
 		return
 	}
 
-	cache.dirCache.Delete(path)
+	cache.store.invalidate(DirCacheKind, cache.key(path))
+}
+
+// RemoveAllDirCacheForPath removes all dir caches for the given path and its sub-entries
+func (cache *FileSystemCache) RemoveAllDirCacheForPath(path string) {
+	if cache.config.NoCache {
+		return
+	}
+
+	cache.invalidateAllForPath(DirCacheKind, path)
 }
 
 // GetDirCache retrives a dir cache
@@ -296,7 +407,7 @@ func (cache *FileSystemCache) GetDirCache(path string) []string {
 		return nil
 	}
 
-	data, exist := cache.dirCache.Get(path)
+	data, exist := cache.store.get(DirCacheKind, cache.key(path))
 	if exist {
 		if entries, ok := data.([]string); ok {
 			return entries
@@ -311,7 +422,7 @@ func (cache *FileSystemCache) ClearDirCache() {
 		return
 	}
 
-	cache.dirCache.Flush()
+	cache.store.flush(DirCacheKind, cache.namespace)
 }
 
 // AddMetadataCache adds a metadata cache
@@ -320,8 +431,12 @@ func (cache *FileSystemCache) AddMetadataCache(path string, metas []*types.IRODS
 		return
 	}
 
-	ttl := cache.getCacheTTLForPath(path)
-	cache.metadataCache.Set(path, metas, ttl)
+	ttl, cacheable := cache.getCacheTTLForPath(path)
+	if !cacheable {
+		return
+	}
+
+	cache.set(MetadataCacheKind, path, metas, ttl)
 }
 
 // RemoveMetadataCache removes a metadata cache
@@ -330,7 +445,16 @@ func (cache *FileSystemCache) RemoveMetadataCache(path string) {
 		return
 	}
 
-	cache.metadataCache.Delete(path)
+	cache.store.invalidate(MetadataCacheKind, cache.key(path))
+}
+
+// RemoveAllMetadataCacheForPath removes all metadata caches for the given path and its sub-entries
+func (cache *FileSystemCache) RemoveAllMetadataCacheForPath(path string) {
+	if cache.config.NoCache {
+		return
+	}
+
+	cache.invalidateAllForPath(MetadataCacheKind, path)
 }
 
 // GetMetadataCache retrieves a metadata cache
@@ -339,7 +463,7 @@ func (cache *FileSystemCache) GetMetadataCache(path string) []*types.IRODSMeta {
 		return nil
 	}
 
-	data, exist := cache.metadataCache.Get(path)
+	data, exist := cache.store.get(MetadataCacheKind, cache.key(path))
 	if exist {
 		if metas, ok := data.([]*types.IRODSMeta); ok {
 			return metas
@@ -354,7 +478,7 @@ func (cache *FileSystemCache) ClearMetadataCache() {
 		return
 	}
 
-	cache.metadataCache.Flush()
+	cache.store.flush(MetadataCacheKind, cache.namespace)
 }
 
 // AddUserCache adds a user cache (cache of a user)
@@ -678,8 +802,12 @@ func (cache *FileSystemCache) AddAclCache(path string, accesses []*types.IRODSAc
 		return
 	}
 
-	ttl := cache.getCacheTTLForPath(path)
-	cache.aclCache.Set(path, accesses, ttl)
+	ttl, cacheable := cache.getCacheTTLForPath(path)
+	if !cacheable {
+		return
+	}
+
+	cache.set(AclCacheKind, path, accesses, ttl)
 }
 
 // AddAclCacheMulti adds multiple ACLs caches
@@ -702,8 +830,12 @@ func (cache *FileSystemCache) AddAclCacheMulti(accesses []*types.IRODSAccess) {
 	}
 
 	for path, access := range m {
-		ttl := cache.getCacheTTLForPath(path)
-		cache.aclCache.Set(path, access, ttl)
+		ttl, cacheable := cache.getCacheTTLForPath(path)
+		if !cacheable {
+			continue
+		}
+
+		cache.set(AclCacheKind, path, access, ttl)
 	}
 }
 
@@ -713,7 +845,16 @@ func (cache *FileSystemCache) RemoveAclCache(path string) {
 		return
 	}
 
-	cache.aclCache.Delete(path)
+	cache.store.invalidate(AclCacheKind, cache.key(path))
+}
+
+// RemoveAllAclCacheForPath removes all ACLs caches for the given path and its sub-entries
+func (cache *FileSystemCache) RemoveAllAclCacheForPath(path string) {
+	if cache.config.NoCache {
+		return
+	}
+
+	cache.invalidateAllForPath(AclCacheKind, path)
 }
 
 // GetAclCache retrives a ACLs cache
@@ -722,7 +863,7 @@ func (cache *FileSystemCache) GetAclCache(path string) []*types.IRODSAccess {
 		return nil
 	}
 
-	data, exist := cache.aclCache.Get(path)
+	data, exist := cache.store.get(AclCacheKind, cache.key(path))
 	if exist {
 		if entries, ok := data.([]*types.IRODSAccess); ok {
 			return entries
@@ -737,5 +878,5 @@ func (cache *FileSystemCache) ClearAclCache() {
 		return
 	}
 
-	cache.aclCache.Flush()
+	cache.store.flush(AclCacheKind, cache.namespace)
 }
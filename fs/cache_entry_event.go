@@ -0,0 +1,102 @@
+package fs
+
+import (
+	"sync"
+
+	"github.com/rs/xid"
+)
+
+// CacheKind is a kind of path caches in FileSystemCache
+type CacheKind string
+
+const (
+	// EntryCacheKind is a kind of entry caches
+	EntryCacheKind CacheKind = "entry"
+	// NegativeEntryCacheKind is a kind of negative entry caches
+	NegativeEntryCacheKind CacheKind = "negative entry"
+	// DirCacheKind is a kind of dir caches
+	DirCacheKind CacheKind = "dir"
+	// MetadataCacheKind is a kind of metadata caches
+	MetadataCacheKind CacheKind = "metadata"
+	// AclCacheKind is a kind of ACLs caches
+	AclCacheKind CacheKind = "acl"
+)
+
+// CacheEntryEventType is a type of cache entry events
+type CacheEntryEventType string
+
+const (
+	// CacheEntryAddEvent is an event type for adding or updating a cache entry
+	CacheEntryAddEvent CacheEntryEventType = "add"
+	// CacheEntryInvalidateEvent is an event type for removing a cache entry
+	CacheEntryInvalidateEvent CacheEntryEventType = "invalidate"
+	// CacheEntryExpireEvent is an event type for an expired cache entry, sent when expired entries are cleaned up
+	CacheEntryExpireEvent CacheEntryEventType = "expire"
+)
+
+// CacheEntryEventHandler is a handler of cache entry events
+type CacheEntryEventHandler func(kind CacheKind, path string, eventType CacheEntryEventType)
+
+// CacheEntryEventHandlerMap manages CacheEntryEventHandler
+type CacheEntryEventHandlerMap struct {
+	mutex    sync.RWMutex
+	handlers map[string]CacheEntryEventHandler // ID-handler mapping
+}
+
+// NewCacheEntryEventHandlerMap creates a new CacheEntryEventHandlerMap
+func NewCacheEntryEventHandlerMap() *CacheEntryEventHandlerMap {
+	return &CacheEntryEventHandlerMap{
+		mutex:    sync.RWMutex{},
+		handlers: map[string]CacheEntryEventHandler{},
+	}
+}
+
+// Release releases resources
+func (handlerMap *CacheEntryEventHandlerMap) Release() {
+	handlerMap.mutex.Lock()
+	defer handlerMap.mutex.Unlock()
+
+	handlerMap.handlers = map[string]CacheEntryEventHandler{}
+}
+
+// AddEventHandler adds cache entry event handler
+func (handlerMap *CacheEntryEventHandlerMap) AddEventHandler(handler CacheEntryEventHandler) string {
+	handlerID := xid.New().String()
+
+	handlerMap.mutex.Lock()
+	defer handlerMap.mutex.Unlock()
+
+	handlerMap.handlers[handlerID] = handler
+
+	return handlerID
+}
+
+// RemoveEventHandler removes cache entry event handler
+func (handlerMap *CacheEntryEventHandlerMap) RemoveEventHandler(handlerID string) {
+	handlerMap.mutex.Lock()
+	defer handlerMap.mutex.Unlock()
+
+	delete(handlerMap.handlers, handlerID)
+}
+
+// HasEventHandler returns true if there is any event handler
+func (handlerMap *CacheEntryEventHandlerMap) HasEventHandler() bool {
+	handlerMap.mutex.RLock()
+	defer handlerMap.mutex.RUnlock()
+
+	return len(handlerMap.handlers) > 0
+}
+
+// SendEvent sends event, handlers are called without holding the lock so they can add or remove handlers
+func (handlerMap *CacheEntryEventHandlerMap) SendEvent(kind CacheKind, path string, eventType CacheEntryEventType) {
+	handlerMap.mutex.RLock()
+	handlers := make([]CacheEntryEventHandler, 0, len(handlerMap.handlers))
+	for _, handler := range handlerMap.handlers {
+		handlers = append(handlers, handler)
+	}
+	handlerMap.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		handler(kind, path, eventType)
+	}
+}
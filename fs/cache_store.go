@@ -0,0 +1,268 @@
+package fs
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gocache "github.com/patrickmn/go-cache"
+)
+
+var (
+	cacheStoreKinds = []CacheKind{
+		EntryCacheKind,
+		NegativeEntryCacheKind,
+		DirCacheKind,
+		MetadataCacheKind,
+		AclCacheKind,
+	}
+)
+
+// cacheStore holds path caches, a store can be shared by multiple FileSystemCaches
+// keys are namespace + path, namespace must not contain '/'
+type cacheStore struct {
+	caches     map[CacheKind]*gocache.Cache
+	maxEntries map[CacheKind]int // max number of caches of each kind, 0 is unbounded
+
+	invalidatingKeys  map[string]int // keys being removed, to tell invalidation from expiration on eviction
+	invalidatingMutex sync.Mutex
+
+	listeners      map[string]map[*FileSystemCache]bool // namespace -> caches
+	listenersMutex sync.RWMutex
+
+	evictionMutex sync.Mutex
+}
+
+func newCacheStore(timeout time.Duration, cleanupTime time.Duration, maxEntries int) *cacheStore {
+	store := &cacheStore{
+		caches:           map[CacheKind]*gocache.Cache{},
+		maxEntries:       map[CacheKind]int{},
+		invalidatingKeys: map[string]int{},
+		listeners:        map[string]map[*FileSystemCache]bool{},
+	}
+
+	for _, kind := range cacheStoreKinds {
+		c := gocache.New(timeout, cleanupTime)
+		store.watchEviction(kind, c)
+		store.caches[kind] = c
+		store.maxEntries[kind] = maxEntries
+	}
+
+	return store
+}
+
+// splitCacheKey splits a key into namespace and path
+func splitCacheKey(key string) (string, string) {
+	idx := strings.Index(key, "/")
+	if idx < 0 {
+		return "", key
+	}
+
+	return key[:idx], key[idx:]
+}
+
+func getInvalidatingKey(kind CacheKind, key string) string {
+	return string(kind) + "\x00" + key
+}
+
+func (store *cacheStore) addListener(namespace string, cache *FileSystemCache) {
+	store.listenersMutex.Lock()
+	defer store.listenersMutex.Unlock()
+
+	caches, ok := store.listeners[namespace]
+	if !ok {
+		caches = map[*FileSystemCache]bool{}
+		store.listeners[namespace] = caches
+	}
+
+	caches[cache] = true
+}
+
+func (store *cacheStore) removeListener(namespace string, cache *FileSystemCache) {
+	store.listenersMutex.Lock()
+	defer store.listenersMutex.Unlock()
+
+	if caches, ok := store.listeners[namespace]; ok {
+		delete(caches, cache)
+		if len(caches) == 0 {
+			delete(store.listeners, namespace)
+		}
+	}
+}
+
+func (store *cacheStore) getListeners(namespace string) []*FileSystemCache {
+	store.listenersMutex.RLock()
+	defer store.listenersMutex.RUnlock()
+
+	caches := []*FileSystemCache{}
+	for cache := range store.listeners[namespace] {
+		caches = append(caches, cache)
+	}
+
+	return caches
+}
+
+// hasEventHandler returns true if any cache has event handlers
+func (store *cacheStore) hasEventHandler() bool {
+	store.listenersMutex.RLock()
+	defer store.listenersMutex.RUnlock()
+
+	for _, caches := range store.listeners {
+		for cache := range caches {
+			if cache.entryEventHandlerMap.HasEventHandler() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// notify sends the event to all caches of the namespace of the key
+func (store *cacheStore) notify(kind CacheKind, key string, eventType CacheEntryEventType) {
+	namespace, path := splitCacheKey(key)
+
+	for _, cache := range store.getListeners(namespace) {
+		cache.entryEventHandlerMap.SendEvent(kind, path, eventType)
+	}
+}
+
+// watchEviction sends invalidate or expire events when caches are evicted
+func (store *cacheStore) watchEviction(kind CacheKind, c *gocache.Cache) {
+	c.OnEvicted(func(key string, _ interface{}) {
+		store.invalidatingMutex.Lock()
+		_, invalidating := store.invalidatingKeys[getInvalidatingKey(kind, key)]
+		store.invalidatingMutex.Unlock()
+
+		if invalidating {
+			store.notify(kind, key, CacheEntryInvalidateEvent)
+		} else {
+			store.notify(kind, key, CacheEntryExpireEvent)
+		}
+	})
+}
+
+func (store *cacheStore) set(kind CacheKind, key string, value interface{}, ttl time.Duration) {
+	c := store.caches[kind]
+	c.Set(key, value, ttl)
+
+	store.notify(kind, key, CacheEntryAddEvent)
+
+	maxEntries := store.maxEntries[kind]
+	if maxEntries > 0 && c.ItemCount() > maxEntries {
+		store.evict(c, maxEntries)
+	}
+}
+
+// evict removes expired caches, then caches expiring soonest until the number of caches drops under 90% of max
+// evicted caches are notified as expired
+func (store *cacheStore) evict(c *gocache.Cache, maxEntries int) {
+	store.evictionMutex.Lock()
+	defer store.evictionMutex.Unlock()
+
+	if c.ItemCount() <= maxEntries {
+		return
+	}
+
+	c.DeleteExpired()
+
+	items := c.Items()
+	if len(items) <= maxEntries {
+		return
+	}
+
+	type expiringKey struct {
+		key        string
+		expiration int64
+	}
+
+	keys := make([]expiringKey, 0, len(items))
+	for key, item := range items {
+		keys = append(keys, expiringKey{
+			key:        key,
+			expiration: item.Expiration,
+		})
+	}
+
+	// caches that never expire go last
+	sort.Slice(keys, func(i int, j int) bool {
+		if keys[i].expiration == 0 || keys[j].expiration == 0 {
+			return keys[j].expiration == 0 && keys[i].expiration != 0
+		}
+		return keys[i].expiration < keys[j].expiration
+	})
+
+	target := maxEntries * 9 / 10
+	for idx := 0; idx < len(keys)-target; idx++ {
+		c.Delete(keys[idx].key)
+	}
+}
+
+func (store *cacheStore) get(kind CacheKind, key string) (interface{}, bool) {
+	return store.caches[kind].Get(key)
+}
+
+// invalidate removes a cache, an invalidate event is sent if the cache existed
+func (store *cacheStore) invalidate(kind CacheKind, key string) {
+	invalidatingKey := getInvalidatingKey(kind, key)
+
+	store.invalidatingMutex.Lock()
+	store.invalidatingKeys[invalidatingKey]++
+	store.invalidatingMutex.Unlock()
+
+	store.caches[kind].Delete(key)
+
+	store.invalidatingMutex.Lock()
+	store.invalidatingKeys[invalidatingKey]--
+	if store.invalidatingKeys[invalidatingKey] <= 0 {
+		delete(store.invalidatingKeys, invalidatingKey)
+	}
+	store.invalidatingMutex.Unlock()
+}
+
+// invalidateAll removes the cache for the key and caches with keys starting with the prefix
+func (store *cacheStore) invalidateAll(kind CacheKind, key string, prefix string) {
+	deleteKeys := []string{}
+	for k := range store.caches[kind].Items() {
+		if k == key || strings.HasPrefix(k, prefix) {
+			deleteKeys = append(deleteKeys, k)
+		}
+	}
+
+	for _, k := range deleteKeys {
+		store.invalidate(kind, k)
+	}
+}
+
+// flush removes all caches of the namespace, or all caches if namespace is empty
+// invalidate events are sent for caches being removed
+func (store *cacheStore) flush(kind CacheKind, namespace string) {
+	if len(namespace) > 0 {
+		store.invalidateAll(kind, namespace, namespace+"/")
+		return
+	}
+
+	c := store.caches[kind]
+	if !store.hasEventHandler() {
+		c.Flush()
+		return
+	}
+
+	items := c.Items()
+	c.Flush()
+
+	for key := range items {
+		store.notify(kind, key, CacheEntryInvalidateEvent)
+	}
+}
+
+// count returns the number of caches of all kinds
+func (store *cacheStore) count() int {
+	total := 0
+	for _, c := range store.caches {
+		total += c.ItemCount()
+	}
+
+	return total
+}
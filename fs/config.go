@@ -1,8 +1,11 @@
 package fs
 
 import (
+	"io"
+	"net"
 	"time"
 
+	"github.com/cyverse/go-irodsclient/irods/connection"
 	"github.com/cyverse/go-irodsclient/irods/session"
 	"github.com/cyverse/go-irodsclient/irods/types"
 )
@@ -24,6 +27,12 @@ const (
 	FileSystemOperationTimeout time.Duration = session.IRODSSessionOperationTimeoutDefault
 	// FileSystemLongOperationTimeout is a default value of long operation timeout
 	FileSystemLongOperationTimeout time.Duration = session.IRODSSessionLongOperationTimeoutDefault
+	// FileSystemRetryMaxAttemptsDefault is a default number of attempts of operations failed with transient errors, set 0 to retry without limit
+	FileSystemRetryMaxAttemptsDefault int = connection.RetryMaxAttemptsDefault
+	// FileSystemRetryInitialBackoffDefault is a default backoff before the first retry
+	FileSystemRetryInitialBackoffDefault time.Duration = connection.RetryInitialBackoffDefault
+	// FileSystemRetryMaxBackoffDefault is a default max backoff between retries
+	FileSystemRetryMaxBackoffDefault time.Duration = connection.RetryMaxBackoffDefault
 	// FileSystemCacheTimeout is a default value of cache timeout
 	FileSystemCacheTimeout time.Duration = 1 * time.Minute
 
@@ -48,16 +57,33 @@ const (
 
 // ConnectionConfig is a struct that stores configuration for connections
 type ConnectionConfig struct {
-	CreationTimeout      types.Duration `yaml:"creation_timeout,omitempty" json:"creation_timeout,omitempty"`             // timeout for creating a new connection
-	InitNumber           int            `yaml:"init_number,omitempty" json:"init_number,omitempty"`                       // number of connections created when init
-	MaxNumber            int            `yaml:"max_number,omitempty" json:"max_number,omitempty"`                         // max number of connections
-	MaxIdleNumber        int            `yaml:"max_idle_number,omitempty" json:"max_idle_number,omitempty"`               // max number of idle connections
-	Lifespan             types.Duration `yaml:"lifespan,omitempty" json:"lifespan,omitempty"`                             // connection's lifespan (max time to be reused)
-	IdleTimeout          types.Duration `yaml:"idle_timeout,omitempty" json:"idle_timeout,omitempty"`                     // time out for being idle, after this point the connection will be disposed
-	OperationTimeout     types.Duration `yaml:"operation_timeout,omitempty" json:"operation_timeout,omitempty"`           // timeout for iRODS operations
-	LongOperationTimeout types.Duration `yaml:"long_operation_timeout,omitempty" json:"long_operation_timeout,omitempty"` // timeout for long iRODS operations
-	TcpBufferSize        int            `yaml:"tcp_buffer_size,omitempty" json:"tcp_buffer_size,omitempty"`               // buffer size
-	WaitConnection       bool           `yaml:"wait_connection,omitempty" json:"wait_connection,omitempty"`               // whether to wait for a connection to be available
+	CreationTimeout         types.Duration `yaml:"creation_timeout,omitempty" json:"creation_timeout,omitempty"`                   // timeout for creating a new connection
+	InitNumber              int            `yaml:"init_number,omitempty" json:"init_number,omitempty"`                             // number of connections created when init
+	MaxNumber               int            `yaml:"max_number,omitempty" json:"max_number,omitempty"`                               // max number of connections
+	MaxIdleNumber           int            `yaml:"max_idle_number,omitempty" json:"max_idle_number,omitempty"`                     // max number of idle connections
+	Lifespan                types.Duration `yaml:"lifespan,omitempty" json:"lifespan,omitempty"`                                   // connection's lifespan (max time to be reused)
+	IdleTimeout             types.Duration `yaml:"idle_timeout,omitempty" json:"idle_timeout,omitempty"`                           // time out for being idle, after this point the connection will be disposed
+	OperationTimeout        types.Duration `yaml:"operation_timeout,omitempty" json:"operation_timeout,omitempty"`                 // timeout for iRODS operations
+	LongOperationTimeout    types.Duration `yaml:"long_operation_timeout,omitempty" json:"long_operation_timeout,omitempty"`       // timeout for long iRODS operations
+	AuthTimeout             types.Duration `yaml:"auth_timeout,omitempty" json:"auth_timeout,omitempty"`                           // timeout for authentication, operation timeout is used if not set
+	DataTransferTimeout     types.Duration `yaml:"data_transfer_timeout,omitempty" json:"data_transfer_timeout,omitempty"`         // timeout for each data transfer request, operation timeout is used if not set
+	TcpBufferSize           int            `yaml:"tcp_buffer_size,omitempty" json:"tcp_buffer_size,omitempty"`                     // buffer size
+	WaitConnection          bool           `yaml:"wait_connection,omitempty" json:"wait_connection,omitempty"`                     // whether to wait for a connection to be available
+	WaitInsteadOfSharing    bool           `yaml:"wait_instead_of_sharing,omitempty" json:"wait_instead_of_sharing,omitempty"`     // whether to wait for a free connection instead of sharing an in-use connection
+	WaitTimeout             types.Duration `yaml:"wait_timeout,omitempty" json:"wait_timeout,omitempty"`                           // max time to wait for a connection to be available, 0 waits forever
+	KeepAliveInterval       types.Duration `yaml:"keep_alive_interval,omitempty" json:"keep_alive_interval,omitempty"`             // interval to ping idle connections, 0 disables keepalive
+	ControlKeepAlive        types.Duration `yaml:"control_keep_alive,omitempty" json:"control_keep_alive,omitempty"`               // interval to ping connections controlling parallel transfers, 0 uses default, negative disables
+	ValidationMode          string         `yaml:"validation_mode,omitempty" json:"validation_mode,omitempty"`                     // how to validate idle connections on checkout, "age" or "ping", empty disables validation
+	ValidationIdleThreshold types.Duration `yaml:"validation_idle_threshold,omitempty" json:"validation_idle_threshold,omitempty"` // idle connections not used for this period are validated on checkout
+	MaintenanceInterval     types.Duration `yaml:"maintenance_interval,omitempty" json:"maintenance_interval,omitempty"`           // interval to close expired idle connections
+	DeferIdleShrink         bool           `yaml:"defer_idle_shrink,omitempty" json:"defer_idle_shrink,omitempty"`                 // whether to keep idle connections over max idle number until they expire idle timeout
+	TcpSendBufferSize       int            `yaml:"tcp_send_buffer_size,omitempty" json:"tcp_send_buffer_size,omitempty"`           // SO_SNDBUF, overrides tcp buffer size if set
+	TcpReceiveBufferSize    int            `yaml:"tcp_receive_buffer_size,omitempty" json:"tcp_receive_buffer_size,omitempty"`     // SO_RCVBUF, overrides tcp buffer size if set
+	TcpKeepAlivePeriod      types.Duration `yaml:"tcp_keep_alive_period,omitempty" json:"tcp_keep_alive_period,omitempty"`         // TCP keepalive interval, 0 uses default, negative disables TCP keepalive
+	TcpDelay                bool           `yaml:"tcp_delay,omitempty" json:"tcp_delay,omitempty"`                                 // if true, Nagle's algorithm is enabled (TCP_NODELAY is not set)
+	RetryMaxAttempts        int            `yaml:"retry_max_attempts,omitempty" json:"retry_max_attempts,omitempty"`               // number of attempts of operations failed with transient errors, 1 disables retry, 0 retries without limit
+	RetryInitialBackoff     types.Duration `yaml:"retry_initial_backoff,omitempty" json:"retry_initial_backoff,omitempty"`         // backoff before the first retry
+	RetryMaxBackoff         types.Duration `yaml:"retry_max_backoff,omitempty" json:"retry_max_backoff,omitempty"`                 // max backoff between retries
 }
 
 // NewDefaultMetadataConnectionConfig creates a default ConnectionConfig for metadata
@@ -73,6 +99,9 @@ func NewDefaultMetadataConnectionConfig() ConnectionConfig {
 		LongOperationTimeout: types.Duration(FileSystemLongOperationTimeout),
 		TcpBufferSize:        FileSystemTcpBufferSizeDefault,
 		WaitConnection:       true,
+		RetryMaxAttempts:     FileSystemRetryMaxAttemptsDefault,
+		RetryInitialBackoff:  types.Duration(FileSystemRetryInitialBackoffDefault),
+		RetryMaxBackoff:      types.Duration(FileSystemRetryMaxBackoffDefault),
 	}
 }
 
@@ -89,9 +118,31 @@ func NewDefaultIOConnectionConfig() ConnectionConfig {
 		LongOperationTimeout: types.Duration(FileSystemLongOperationTimeout),
 		TcpBufferSize:        FileSystemTcpBufferSizeDefault,
 		WaitConnection:       true,
+		RetryMaxAttempts:     FileSystemRetryMaxAttemptsDefault,
+		RetryInitialBackoff:  types.Duration(FileSystemRetryInitialBackoffDefault),
+		RetryMaxBackoff:      types.Duration(FileSystemRetryMaxBackoffDefault),
 	}
 }
 
+// GetRetryPolicy returns a retry policy from the config
+func (config *ConnectionConfig) GetRetryPolicy() *connection.RetryPolicy {
+	policy := connection.NewDefaultRetryPolicy()
+
+	if config.RetryMaxAttempts >= 0 {
+		policy.MaxAttempts = config.RetryMaxAttempts
+	}
+
+	if config.RetryInitialBackoff > 0 {
+		policy.InitialBackoff = time.Duration(config.RetryInitialBackoff)
+	}
+
+	if config.RetryMaxBackoff > 0 {
+		policy.MaxBackoff = time.Duration(config.RetryMaxBackoff)
+	}
+
+	return policy
+}
+
 // FileSystemConfig is a struct for file system configuration
 type FileSystemConfig struct {
 	ApplicationName string `yaml:"application_name,omitempty" json:"application_name,omitempty"`
@@ -99,9 +150,38 @@ type FileSystemConfig struct {
 	MetadataConnection ConnectionConfig `yaml:"metadata_connection,omitempty" json:"metadata_connection,omitempty"`
 	IOConnection       ConnectionConfig `yaml:"io_connection,omitempty" json:"io_connection,omitempty"`
 
-	Cache CacheConfig `yaml:"cache,omitempty" json:"cache,omitempty"`
+	Cache       CacheConfig  `yaml:"cache,omitempty" json:"cache,omitempty"`
+	SharedCache *SharedCache `yaml:"-" json:"-"` // if set, entries, dirs, metadata and ACLs are cached in the cache shared with other FileSystems
+
+	LazyInit bool `yaml:"lazy_init,omitempty" json:"lazy_init,omitempty"` // if true, connections are made on first use, the server doesn't need to be reachable on creation
 
 	AddressResolver session.AddressResolver
+
+	AddressFamily      string         `yaml:"address_family,omitempty" json:"address_family,omitempty"`             // preference of IP address families of servers, "prefer_ipv4", "prefer_ipv6", "ipv4" or "ipv6", empty uses addresses in the order resolved
+	HappyEyeballsDelay types.Duration `yaml:"happy_eyeballs_delay,omitempty" json:"happy_eyeballs_delay,omitempty"` // delay before connecting to the next address of a host in parallel, 0 uses default, negative tries addresses one at a time
+	Resolver           *net.Resolver  `yaml:"-" json:"-"`                                                           // resolves host names and SRV records, uses net.DefaultResolver if nil
+
+	WireDebugWriter      io.Writer `yaml:"-" json:"-"`                                                                 // dumps sent/received iRODS messages with credentials redacted if set
+	WireDebugBinaryLimit int       `yaml:"wire_debug_binary_limit,omitempty" json:"wire_debug_binary_limit,omitempty"` // max binary bytes dumped per message
+
+	FaultInjectionPolicy *connection.FaultInjectionPolicy `yaml:"-" json:"-"` // injects failures to sockets for testing retry and resume, never set in production
+
+	AuditSink AuditSink `yaml:"-" json:"-"` // records mutating operations if set
+
+	LocalChecksumCache *LocalChecksumCache `yaml:"-" json:"-"` // if set, checksums of local files not changed since they are hashed are reused
+
+	RejectConcurrentPathOperations bool `yaml:"reject_concurrent_path_operations,omitempty" json:"reject_concurrent_path_operations,omitempty"` // if true, operations modifying a path in use by another operation fail with PathInUseError instead of waiting
+
+	TransferReport bool `yaml:"transfer_report,omitempty" json:"transfer_report,omitempty"` // if true, results of file transfers have a TransferReport
+
+	PreserveDownloadModifyTime bool                 `yaml:"preserve_download_modify_time,omitempty" json:"preserve_download_modify_time,omitempty"` // sets the modify time of downloaded local files to the modify time of data objects, or the local modify time recorded on upload
+	PreserveUploadModifyTime   UploadModifyTimeMode `yaml:"preserve_upload_modify_time,omitempty" json:"preserve_upload_modify_time,omitempty"`     // preserves the modify time of uploaded local files, empty does not preserve
+
+	MapXattrs      bool   `yaml:"map_xattrs,omitempty" json:"map_xattrs,omitempty"`             // maps extended attributes of local files to AVUs on upload, and AVUs to extended attributes on download
+	XattrAVUPrefix string `yaml:"xattr_avu_prefix,omitempty" json:"xattr_avu_prefix,omitempty"` // prefix of AVU names mapped from extended attributes, empty uses XattrAVUPrefixDefault
+
+	PAMInteractivePromptHandler connection.PAMInteractivePromptHandler // answers pam_interactive prompts, reads from stdin if nil
+	OpenIDTokenSource           connection.OpenIDTokenSource           // supplies OIDC access tokens for openid auth, uses password if nil
 }
 
 // NewFileSystemConfig create a FileSystemConfig with a default settings
@@ -114,6 +194,9 @@ func NewFileSystemConfig(applicationName string) *FileSystemConfig {
 		Cache:              NewDefaultCacheConfig(),
 
 		AddressResolver: nil,
+
+		PAMInteractivePromptHandler: nil,
+		OpenIDTokenSource:           nil,
 	}
 }
 
@@ -122,18 +205,43 @@ func (config *FileSystemConfig) ToMetadataSessionConfig() *session.IRODSSessionC
 	return &session.IRODSSessionConfig{
 		ApplicationName: config.ApplicationName,
 
-		ConnectionCreationTimeout: time.Duration(config.MetadataConnection.CreationTimeout),
-		ConnectionInitNumber:      config.MetadataConnection.InitNumber,
-		ConnectionMaxNumber:       config.MetadataConnection.MaxNumber,
-		ConnectionLifespan:        time.Duration(config.MetadataConnection.Lifespan),
-		ConnectionIdleTimeout:     time.Duration(config.MetadataConnection.IdleTimeout),
-		ConnectionMaxIdleNumber:   config.MetadataConnection.MaxIdleNumber,
-		OperationTimeout:          time.Duration(config.MetadataConnection.OperationTimeout),
-		LongOperationTimeout:      time.Duration(config.MetadataConnection.LongOperationTimeout),
-		TcpBufferSize:             config.MetadataConnection.TcpBufferSize,
-		StartNewTransaction:       config.Cache.StartNewTransaction,
-		WaitConnection:            config.MetadataConnection.WaitConnection,
-		AddressResolver:           config.AddressResolver,
+		ConnectionCreationTimeout:         time.Duration(config.MetadataConnection.CreationTimeout),
+		ConnectionInitNumber:              config.MetadataConnection.InitNumber,
+		ConnectionMaxNumber:               config.MetadataConnection.MaxNumber,
+		ConnectionLifespan:                time.Duration(config.MetadataConnection.Lifespan),
+		ConnectionIdleTimeout:             time.Duration(config.MetadataConnection.IdleTimeout),
+		ConnectionMaxIdleNumber:           config.MetadataConnection.MaxIdleNumber,
+		OperationTimeout:                  time.Duration(config.MetadataConnection.OperationTimeout),
+		LongOperationTimeout:              time.Duration(config.MetadataConnection.LongOperationTimeout),
+		AuthTimeout:                       time.Duration(config.MetadataConnection.AuthTimeout),
+		DataTransferTimeout:               time.Duration(config.MetadataConnection.DataTransferTimeout),
+		TcpBufferSize:                     config.MetadataConnection.TcpBufferSize,
+		TcpSendBufferSize:                 config.MetadataConnection.TcpSendBufferSize,
+		TcpReceiveBufferSize:              config.MetadataConnection.TcpReceiveBufferSize,
+		TcpKeepAlivePeriod:                time.Duration(config.MetadataConnection.TcpKeepAlivePeriod),
+		TcpDelay:                          config.MetadataConnection.TcpDelay,
+		AddressFamily:                     connection.AddressFamily(config.AddressFamily),
+		HappyEyeballsDelay:                time.Duration(config.HappyEyeballsDelay),
+		Resolver:                          config.Resolver,
+		StartNewTransaction:               config.Cache.StartNewTransaction,
+		WaitConnection:                    config.MetadataConnection.WaitConnection,
+		WaitConnectionInsteadOfSharing:    config.MetadataConnection.WaitInsteadOfSharing,
+		ConnectionWaitTimeout:             time.Duration(config.MetadataConnection.WaitTimeout),
+		ConnectionKeepAliveInterval:       time.Duration(config.MetadataConnection.KeepAliveInterval),
+		ControlKeepAliveInterval:          time.Duration(config.MetadataConnection.ControlKeepAlive),
+		ConnectionValidationMode:          session.ConnectionValidationMode(config.MetadataConnection.ValidationMode),
+		ConnectionValidationIdleThreshold: time.Duration(config.MetadataConnection.ValidationIdleThreshold),
+		ConnectionMaintenanceInterval:     time.Duration(config.MetadataConnection.MaintenanceInterval),
+		ConnectionDeferIdleShrink:         config.MetadataConnection.DeferIdleShrink,
+		LazyConnectionInit:                config.LazyInit,
+		RetryPolicy:                       config.MetadataConnection.GetRetryPolicy(),
+		AddressResolver:                   config.AddressResolver,
+
+		WireDebugWriter:             config.WireDebugWriter,
+		WireDebugBinaryLimit:        config.WireDebugBinaryLimit,
+		FaultInjectionPolicy:        config.FaultInjectionPolicy,
+		PAMInteractivePromptHandler: config.PAMInteractivePromptHandler,
+		OpenIDTokenSource:           config.OpenIDTokenSource,
 	}
 }
 
@@ -142,17 +250,42 @@ func (config *FileSystemConfig) ToIOSessionConfig() *session.IRODSSessionConfig
 	return &session.IRODSSessionConfig{
 		ApplicationName: config.ApplicationName,
 
-		ConnectionCreationTimeout: time.Duration(config.IOConnection.CreationTimeout),
-		ConnectionInitNumber:      config.IOConnection.InitNumber,
-		ConnectionMaxNumber:       config.IOConnection.MaxNumber,
-		ConnectionLifespan:        time.Duration(config.IOConnection.Lifespan),
-		ConnectionIdleTimeout:     time.Duration(config.IOConnection.IdleTimeout),
-		ConnectionMaxIdleNumber:   config.IOConnection.MaxIdleNumber,
-		OperationTimeout:          time.Duration(config.IOConnection.OperationTimeout),
-		LongOperationTimeout:      time.Duration(config.IOConnection.LongOperationTimeout),
-		TcpBufferSize:             config.IOConnection.TcpBufferSize,
-		StartNewTransaction:       config.Cache.StartNewTransaction,
-		WaitConnection:            config.IOConnection.WaitConnection,
-		AddressResolver:           config.AddressResolver,
+		ConnectionCreationTimeout:         time.Duration(config.IOConnection.CreationTimeout),
+		ConnectionInitNumber:              config.IOConnection.InitNumber,
+		ConnectionMaxNumber:               config.IOConnection.MaxNumber,
+		ConnectionLifespan:                time.Duration(config.IOConnection.Lifespan),
+		ConnectionIdleTimeout:             time.Duration(config.IOConnection.IdleTimeout),
+		ConnectionMaxIdleNumber:           config.IOConnection.MaxIdleNumber,
+		OperationTimeout:                  time.Duration(config.IOConnection.OperationTimeout),
+		LongOperationTimeout:              time.Duration(config.IOConnection.LongOperationTimeout),
+		AuthTimeout:                       time.Duration(config.IOConnection.AuthTimeout),
+		DataTransferTimeout:               time.Duration(config.IOConnection.DataTransferTimeout),
+		TcpBufferSize:                     config.IOConnection.TcpBufferSize,
+		TcpSendBufferSize:                 config.IOConnection.TcpSendBufferSize,
+		TcpReceiveBufferSize:              config.IOConnection.TcpReceiveBufferSize,
+		TcpKeepAlivePeriod:                time.Duration(config.IOConnection.TcpKeepAlivePeriod),
+		TcpDelay:                          config.IOConnection.TcpDelay,
+		AddressFamily:                     connection.AddressFamily(config.AddressFamily),
+		HappyEyeballsDelay:                time.Duration(config.HappyEyeballsDelay),
+		Resolver:                          config.Resolver,
+		StartNewTransaction:               config.Cache.StartNewTransaction,
+		WaitConnection:                    config.IOConnection.WaitConnection,
+		WaitConnectionInsteadOfSharing:    config.IOConnection.WaitInsteadOfSharing,
+		ConnectionWaitTimeout:             time.Duration(config.IOConnection.WaitTimeout),
+		ConnectionKeepAliveInterval:       time.Duration(config.IOConnection.KeepAliveInterval),
+		ControlKeepAliveInterval:          time.Duration(config.IOConnection.ControlKeepAlive),
+		ConnectionValidationMode:          session.ConnectionValidationMode(config.IOConnection.ValidationMode),
+		ConnectionValidationIdleThreshold: time.Duration(config.IOConnection.ValidationIdleThreshold),
+		ConnectionMaintenanceInterval:     time.Duration(config.IOConnection.MaintenanceInterval),
+		ConnectionDeferIdleShrink:         config.IOConnection.DeferIdleShrink,
+		LazyConnectionInit:                config.LazyInit,
+		RetryPolicy:                       config.IOConnection.GetRetryPolicy(),
+		AddressResolver:                   config.AddressResolver,
+
+		WireDebugWriter:             config.WireDebugWriter,
+		WireDebugBinaryLimit:        config.WireDebugBinaryLimit,
+		FaultInjectionPolicy:        config.FaultInjectionPolicy,
+		PAMInteractivePromptHandler: config.PAMInteractivePromptHandler,
+		OpenIDTokenSource:           config.OpenIDTokenSource,
 	}
 }
@@ -3,6 +3,7 @@ package fs
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/cyverse/go-irodsclient/irods/common"
@@ -21,6 +22,10 @@ type FileHandle struct {
 	entry               *Entry
 	offset              int64
 	openMode            types.FileOpenMode
+	openTime            time.Time
+	writtenBytes        int64 // bytes written via the handle, for audit
+	replicaAccess       bool  // opened with a replica token of another handle, closed without finalizing the replica
+	resourceHierarchy   string
 	mutex               sync.Mutex
 }
 
@@ -99,12 +104,23 @@ func (handle *FileHandle) Close() error {
 
 	defer handle.filesystem.ioSession.ReturnConnection(handle.connection) //nolint
 
-	err := irods_fs.CloseDataObject(handle.connection, handle.irodsFileHandle)
+	var err error
+	if handle.replicaAccess {
+		err = irods_fs.CloseDataObjectReplica(handle.connection, handle.irodsFileHandle)
+	} else {
+		err = irods_fs.CloseDataObject(handle.connection, handle.irodsFileHandle)
+	}
 	handle.filesystem.fileHandleMap.Remove(handle.id)
 
 	if handle.IsWriteMode() {
 		handle.filesystem.InvalidateCacheForFileUpdate(handle.entry.Path)
 		handle.filesystem.cachePropagation.PropagateFileUpdate(handle.entry.Path)
+
+		handle.filesystem.recordAudit(handle.openTime, err, &AuditRecord{
+			Operation: AuditWriteFile,
+			Path:      handle.entry.Path,
+			Bytes:     handle.writtenBytes,
+		})
 	}
 
 	return err
@@ -129,11 +145,17 @@ func (handle *FileHandle) Truncate(size int64) error {
 	handle.mutex.Lock()
 	defer handle.mutex.Unlock()
 
+	startTime := time.Now()
 	err := irods_fs.TruncateDataObjectHandle(handle.connection, handle.irodsFileHandle, size)
+	handle.filesystem.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditTruncateFile,
+		Path:      handle.entry.Path,
+	})
 	if err != nil {
 		return err
 	}
 
+	handle.entry.Size = size
 	return nil
 }
 
@@ -195,16 +217,28 @@ func (handle *FileHandle) Write(data []byte) (int, error) {
 		return 0, errors.Errorf("file is opened with %q mode", handle.openMode)
 	}
 
+	if handle.openMode.SeekToEnd() {
+		// append mode writes at the end even after seek or writes by others
+		newOffset, err := irods_fs.SeekDataObject(handle.connection, handle.irodsFileHandle, 0, types.SeekEnd)
+		if err != nil {
+			return 0, err
+		}
+
+		handle.offset = newOffset
+		handle.entry.Size = newOffset
+	}
+
 	err := irods_fs.WriteDataObject(handle.connection, handle.irodsFileHandle, data)
 	if err != nil {
 		return 0, err
 	}
 
 	handle.offset += int64(len(data))
+	handle.writtenBytes += int64(len(data))
 
 	// update
-	if handle.entry.Size < handle.offset+int64(len(data)) {
-		handle.entry.Size = handle.offset + int64(len(data))
+	if handle.entry.Size < handle.offset {
+		handle.entry.Size = handle.offset
 	}
 
 	return len(data), nil
@@ -238,10 +272,11 @@ func (handle *FileHandle) WriteAt(data []byte, offset int64) (int, error) {
 	}
 
 	handle.offset += int64(len(data))
+	handle.writtenBytes += int64(len(data))
 
 	// update
-	if handle.entry.Size < handle.offset+int64(len(data)) {
-		handle.entry.Size = handle.offset + int64(len(data))
+	if handle.entry.Size < handle.offset {
+		handle.entry.Size = handle.offset
 	}
 
 	return len(data), nil
@@ -306,6 +341,63 @@ func (handle *FileHandle) UnlockDataObject() error {
 	return nil
 }
 
+// GetReplicaAccessInfo returns replica access info of the file opened for write, other processes can open the file with FileSystem.OpenDataObjectWithReplicaToken
+// the file must stay open until all of them close the file, threadNum and dataSize are the number of writers and the final size of the file, zero if unknown
+func (handle *FileHandle) GetReplicaAccessInfo(threadNum int, dataSize int64) (*types.IRODSReplicaAccessInfo, error) {
+	handle.mutex.Lock()
+	defer handle.mutex.Unlock()
+
+	return irods_fs.GetDataObjectReplicaAccessInfo(handle.connection, handle.irodsFileHandle, threadNum, dataSize)
+}
+
+// GetResourceHierarchy returns the resource hierarchy (root;child;leaf) of the replica the server chose for the file
+// servers older than iRODS 4.2.9 do not report it, the replica in catalog on the resource the file is opened with is used
+func (handle *FileHandle) GetResourceHierarchy() (string, error) {
+	handle.mutex.Lock()
+	defer handle.mutex.Unlock()
+
+	if len(handle.resourceHierarchy) > 0 {
+		return handle.resourceHierarchy, nil
+	}
+
+	var resourceHierarchy string
+	var err error
+	if handle.connection.SupportParallelUpload() {
+		_, resourceHierarchy, err = irods_fs.GetReplicaAccessInfo(handle.connection, handle.irodsFileHandle)
+	} else {
+		// descriptor info is not available, find the replica in catalog
+		resourceHierarchy, err = handle.getResourceHierarchyFromCatalog()
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	handle.resourceHierarchy = resourceHierarchy
+	return resourceHierarchy, nil
+}
+
+// getResourceHierarchyFromCatalog returns the resource hierarchy of the replica on the resource the file is opened with
+func (handle *FileHandle) getResourceHierarchyFromCatalog() (string, error) {
+	entry, err := handle.filesystem.getDataObjectWithConnectionNoCache(handle.connection, handle.irodsFileHandle.Path)
+	if err != nil {
+		return "", err
+	}
+
+	resource := handle.irodsFileHandle.Resource
+	for _, replica := range entry.IRODSReplicas {
+		if len(resource) == 0 {
+			return replica.ResourceHierarchy, nil
+		}
+
+		if replica.ResourceHierarchy == resource || types.GetResourceHierarchyRoot(replica.ResourceHierarchy) == resource || types.GetResourceHierarchyLeaf(replica.ResourceHierarchy) == resource {
+			return replica.ResourceHierarchy, nil
+		}
+	}
+
+	return "", errors.Errorf("failed to find a replica of %q on resource %q", handle.irodsFileHandle.Path, resource)
+}
+
 // preprocessRename should be called before the file is renamed
 func (handle *FileHandle) preprocessRename() error {
 	// first, we need to close the file
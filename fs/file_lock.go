@@ -119,6 +119,60 @@ func (mgr *FileLocks) Lock(path string) {
 	fileLock.mutex.Lock() // write lock
 }
 
+// TryLock tries to lock a file, returns false without waiting if the file is locked
+func (mgr *FileLocks) TryLock(path string) bool {
+	mgr.mutex.Lock()
+	defer mgr.mutex.Unlock()
+
+	fileLock, ok := mgr.locks[path]
+	if !ok {
+		// create a new
+		fileLock = &FileLock{
+			path:       path,
+			references: 0,
+			mutex:      sync.RWMutex{},
+		}
+		mgr.locks[path] = fileLock
+	}
+
+	if !fileLock.mutex.TryLock() {
+		if fileLock.references == 0 {
+			delete(mgr.locks, path)
+		}
+		return false
+	}
+
+	fileLock.references++
+	return true
+}
+
+// TryRLock tries to lock a file with read mode, returns false without waiting if the file is locked
+func (mgr *FileLocks) TryRLock(path string) bool {
+	mgr.mutex.Lock()
+	defer mgr.mutex.Unlock()
+
+	fileLock, ok := mgr.locks[path]
+	if !ok {
+		// create a new
+		fileLock = &FileLock{
+			path:       path,
+			references: 0,
+			mutex:      sync.RWMutex{},
+		}
+		mgr.locks[path] = fileLock
+	}
+
+	if !fileLock.mutex.TryRLock() {
+		if fileLock.references == 0 {
+			delete(mgr.locks, path)
+		}
+		return false
+	}
+
+	fileLock.references++
+	return true
+}
+
 // RLock locks a file with read mode
 func (mgr *FileLocks) RLock(path string) {
 	var fileLock *FileLock
@@ -2,6 +2,8 @@ package fs
 
 import (
 	"path"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -27,6 +29,17 @@ type FileSystem struct {
 	cachePropagation     *FileSystemCachePropagation
 	cacheEventHandlerMap *FilesystemCacheEventHandlerMap
 	fileHandleMap        *FileHandleMap
+	pathLocks            *FileLocks          // serializes operations modifying the same path
+	watchers             map[string]*Watcher // ID-watcher mapping
+	watchersMutex        sync.Mutex
+
+	clientHints       *types.IRODSClientHints
+	clientHintsErr    error
+	clientHintsLoaded bool
+	clientHintsMutex  sync.Mutex
+
+	resourceHierarchies      map[string]*resourceHierarchyCacheEntry // resource name -> resource hierarchy
+	resourceHierarchiesMutex sync.Mutex
 }
 
 // NewFileSystem creates a new FileSystem
@@ -66,15 +79,24 @@ func NewFileSystem(account *types.IRODSAccount, config *FileSystemConfig) (*File
 	ioSession.SetTransactionFailureHandler(ioTransactionFailureHandler)
 	metaSession.SetTransactionFailureHandler(metaTransactionFailureHandler)
 
+	var cache *FileSystemCache
+	if config.SharedCache != nil {
+		cache = NewFileSystemCacheWithSharedCache(&config.Cache, config.SharedCache, account.ClientUser, account.ClientZone)
+	} else {
+		cache = NewFileSystemCache(&config.Cache)
+	}
+
 	fs := &FileSystem{
 		id:                   xid.New().String(), // generate a new ID
 		account:              account,
 		config:               config,
 		ioSession:            ioSession,
 		metadataSession:      metaSession,
-		cache:                NewFileSystemCache(&config.Cache),
+		cache:                cache,
 		cacheEventHandlerMap: NewFilesystemCacheEventHandlerMap(),
 		fileHandleMap:        NewFileHandleMap(),
+		pathLocks:            NewFileLocks(),
+		watchers:             map[string]*Watcher{},
 	}
 
 	cachePropagation := NewFileSystemCachePropagation(fs)
@@ -89,10 +111,41 @@ func NewFileSystemWithDefault(account *types.IRODSAccount, applicationName strin
 	return NewFileSystem(account, config)
 }
 
+// NewFileSystemWithTicket creates a new FileSystem for anonymous access with a ticket, e.g., to download public data
+func NewFileSystemWithTicket(host string, port int, zone string, ticket string) (*FileSystem, error) {
+	config := NewFileSystemConfig(connection.ApplicationNameDefault)
+	return NewFileSystemWithTicketAndConfig(host, port, zone, ticket, config)
+}
+
+// NewFileSystemWithTicketAndConfig creates a new FileSystem for anonymous access with a ticket with the given configurations
+// anonymous users have no home dir and can't end transactions, so transactions are not started
+func NewFileSystemWithTicketAndConfig(host string, port int, zone string, ticket string, config *FileSystemConfig) (*FileSystem, error) {
+	if len(ticket) == 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return nil, errors.Wrapf(newErr, "empty ticket")
+	}
+
+	account, err := types.CreateIRODSAnonymousAccountForTicket(host, port, zone, ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	if config == nil {
+		config = NewFileSystemConfig(connection.ApplicationNameDefault)
+	}
+
+	newConfig := *config
+	newConfig.Cache.StartNewTransaction = false
+
+	return NewFileSystem(account, &newConfig)
+}
+
 // Release releases all resources
 func (fs *FileSystem) Release() {
 	logger := log.WithFields(log.Fields{})
 
+	fs.stopWatchers()
+
 	handles := fs.fileHandleMap.PopAll()
 	for _, handle := range handles {
 		err := handle.Close()
@@ -103,6 +156,7 @@ func (fs *FileSystem) Release() {
 
 	fs.cacheEventHandlerMap.Release()
 	fs.cachePropagation.Release()
+	fs.cache.Release()
 
 	fs.ioSession.Release()
 	fs.metadataSession.Release()
@@ -143,6 +197,23 @@ func (fs *FileSystem) ReturnIOConnection(conn *connection.IRODSConnection) error
 	return fs.ioSession.ReturnConnection(conn)
 }
 
+// WithFreshView runs fn with a metadata connection that sees all changes made via other connections
+func (fs *FileSystem) WithFreshView(fn func(conn *connection.IRODSConnection) error) error {
+	return fs.metadataSession.WithFreshView(fn)
+}
+
+// WithClientUser runs fn with a dedicated metadata connection acting as the given client user
+// the account of the file system must be a proxy (rodsadmin) account
+func (fs *FileSystem) WithClientUser(clientUser string, clientZone string, fn func(conn *connection.IRODSConnection) error) error {
+	conn, err := fs.metadataSession.AcquireConnectionAsClientUser(clientUser, clientZone)
+	if err != nil {
+		return err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	return fn(conn)
+}
+
 // GetMetadataConnection returns irods connection for metadata operations
 func (fs *FileSystem) GetMetadataConnection(allowShared bool) (*connection.IRODSConnection, error) {
 	return fs.metadataSession.AcquireConnection(allowShared)
@@ -173,6 +244,20 @@ func (fs *FileSystem) GetServerVersion() (*types.IRODSVersion, error) {
 	return conn.GetVersion(), nil
 }
 
+// GetServerInfo returns information of the server, e.g., server type, release version, boot time and zone
+func (fs *FileSystem) GetServerInfo() (*types.IRODSServerInfo, error) {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	conn.Lock()
+	defer conn.Unlock()
+
+	return conn.GetServerInfo()
+}
+
 // GetHomeDirPath returns the home directory path
 func (fs *FileSystem) GetHomeDirPath() string {
 	return fs.account.GetHomeDirPath()
@@ -183,6 +268,66 @@ func (fs *FileSystem) SupportParallelUpload() bool {
 	return fs.metadataSession.SupportParallelUpload()
 }
 
+// GetServerCapabilities returns features supported by the server
+func (fs *FileSystem) GetServerCapabilities() (*types.IRODSServerCapabilities, error) {
+	return fs.metadataSession.GetServerCapabilities()
+}
+
+// GetClientHints returns hints for clients reported by the server, e.g., hash scheme, specific queries and plugins
+// the result is cached for the life time of the file system
+func (fs *FileSystem) GetClientHints() (*types.IRODSClientHints, error) {
+	fs.clientHintsMutex.Lock()
+	defer fs.clientHintsMutex.Unlock()
+
+	if fs.clientHintsLoaded {
+		return fs.clientHints, fs.clientHintsErr
+	}
+
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		// do not cache connection errors
+		return nil, err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	fs.clientHints, fs.clientHintsErr = irods_fs.GetClientHints(conn)
+	fs.clientHintsLoaded = true
+
+	return fs.clientHints, fs.clientHintsErr
+}
+
+// GetDefaultChecksumAlgorithm returns checksum algorithm the server computes by default
+// the default hash scheme of the account is used, then the hash scheme in client hints
+func (fs *FileSystem) GetDefaultChecksumAlgorithm() types.ChecksumAlgorithm {
+	if algorithm := types.GetChecksumAlgorithm(fs.account.DefaultHashScheme); algorithm != types.ChecksumAlgorithmUnknown {
+		return algorithm
+	}
+
+	hints, err := fs.GetClientHints()
+	if err != nil {
+		log.WithError(err).Debug("failed to get client hints, using the default checksum algorithm")
+	} else if algorithm := hints.GetChecksumAlgorithm(); algorithm != types.ChecksumAlgorithmUnknown {
+		return algorithm
+	}
+
+	return defaultChecksumAlgorithm
+}
+
+// GetGridConfigurationValue returns the value of the option in the namespace of the grid configuration
+// the server must be 4.3.1 or higher and the user must be a rodsadmin
+func (fs *FileSystem) GetGridConfigurationValue(namespace string, optionName string) (string, error) {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return "", err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	conn.Lock()
+	defer conn.Unlock()
+
+	return conn.GetGridConfigurationValue(namespace, optionName)
+}
+
 // IsTicketAccess returns if the access is authenticated using ticket
 func (fs *FileSystem) IsTicketAccess() bool {
 	return fs.account.UseTicket()
@@ -217,6 +362,11 @@ func (fs *FileSystem) Stat(irodsPath string) (*Entry, error) {
 	}
 
 	// check if a cached dir Entry for the given path exists
+	// dir caches tell absence of the path like negative entry caches
+	if !fs.cache.IsDirCacheNegativeEntryEnabled() {
+		return fs.statNoCache(irodsCorrectPath)
+	}
+
 	parentPath := path.Dir(irodsCorrectPath)
 	cachedDirEntryPaths := fs.cache.GetDirCache(parentPath)
 	dirEntryExist := false
@@ -236,9 +386,47 @@ func (fs *FileSystem) Stat(irodsPath string) (*Entry, error) {
 		}
 	}
 
-	// if cache does not exist,
+	// if cache does not exist
+	return fs.statNoCache(irodsCorrectPath)
+}
+
+// StatNoCache returns file status retrieved from the server, bypassing cache
+// cache is refreshed with the result
+func (fs *FileSystem) StatNoCache(irodsPath string) (*Entry, error) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	return fs.statNoCache(irodsCorrectPath)
+}
+
+// StatNoNegativeCache returns file status, ignoring cached absence of the path
+// use this to see objects created by other clients right after a failed Stat
+func (fs *FileSystem) StatNoNegativeCache(irodsPath string) (*Entry, error) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	cachedEntry := fs.cache.GetEntryCache(irodsCorrectPath)
+	if cachedEntry != nil {
+		return cachedEntry, nil
+	}
+
+	return fs.statNoCache(irodsCorrectPath)
+}
+
+// StatReplicas returns all replicas of a file retrieved from the server, bypassing cache
+// use this to check replication health, cached entries may have outdated replica status
+func (fs *FileSystem) StatReplicas(irodsPath string) ([]*EntryReplica, error) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	entry, err := fs.getDataObjectNoCache(irodsCorrectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.GetReplicas(), nil
+}
+
+func (fs *FileSystem) statNoCache(irodsPath string) (*Entry, error) {
 	// check dir first
-	dirStat, err := fs.getCollectionNoCache(irodsCorrectPath)
+	dirStat, err := fs.getCollectionNoCache(irodsPath)
 	if err != nil {
 		if !types.IsFileNotFoundError(err) {
 			return nil, err
@@ -248,7 +436,7 @@ func (fs *FileSystem) Stat(irodsPath string) (*Entry, error) {
 	}
 
 	// if it's not dir, check file
-	fileStat, err := fs.getDataObjectNoCache(irodsCorrectPath)
+	fileStat, err := fs.getDataObjectNoCache(irodsPath)
 	if err != nil {
 		if !types.IsFileNotFoundError(err) {
 			return nil, err
@@ -258,9 +446,10 @@ func (fs *FileSystem) Stat(irodsPath string) (*Entry, error) {
 	}
 
 	// not a collection, not a data object
-	fs.cache.AddNegativeEntryCache(irodsCorrectPath)
-	newErr := types.NewFileNotFoundError(irodsCorrectPath)
-	return nil, errors.Wrapf(newErr, "failed to find the data object or the collection for path %q", irodsCorrectPath)
+	fs.cache.RemoveEntryCache(irodsPath)
+	fs.cache.AddNegativeEntryCache(irodsPath)
+	newErr := types.NewFileNotFoundError(irodsPath)
+	return nil, errors.Wrapf(newErr, "failed to find the data object or the collection for path %q", irodsPath)
 }
 
 // StatDir returns status of a directory
@@ -277,17 +466,33 @@ func (fs *FileSystem) StatFile(irodsPath string) (*Entry, error) {
 	return fs.getDataObject(irodsCorrectPath)
 }
 
+// GetDirStatistics returns total size and number of files of a directory, same as GetCollectionSize
 func (fs *FileSystem) GetDirStatistics(irodsPath string, recurse bool) (*DirStat, error) {
+	return fs.GetCollectionSize(irodsPath, recurse)
+}
+
+// GetCollectionSize returns total size and number of files and sub dirs of a directory
+// they are aggregated on the server without listing entries, only replica 0 of each file is counted
+func (fs *FileSystem) GetCollectionSize(irodsPath string, recurse bool) (*DirStat, error) {
 	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
 
-	// we use ioSession to acquire connection as it can take a long time
-	conn, err := fs.ioSession.AcquireConnection(true)
+	// aggregation returns zero for a missing collection
+	entry, err := fs.StatDir(irodsCorrectPath)
 	if err != nil {
 		return nil, err
 	}
-	defer fs.ioSession.ReturnConnection(conn) //nolint
 
-	stat, err := irods_fs.GetCollectionStat(conn, irodsCorrectPath, recurse)
+	if entry.ID <= 0 {
+		return nil, types.NewFileNotFoundError(irodsCorrectPath)
+	}
+
+	// we use ioSession to acquire connection as it can take a long time
+	var stat *types.IRODSCollectionStat
+	err = fs.ioSession.RunIdempotentOperation(true, func(conn *connection.IRODSConnection) error {
+		var statErr error
+		stat, statErr = irods_fs.GetCollectionStat(conn, irodsCorrectPath, recurse)
+		return statErr
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -328,52 +533,66 @@ func (fs *FileSystem) List(irodsPath string) ([]*Entry, error) {
 	return fs.listEntries(irodsCorrectPath)
 }
 
+// ListNoCache lists all file system entries under the given path retrieved from the server, bypassing cache
+// cache is refreshed with the result
+func (fs *FileSystem) ListNoCache(irodsPath string) ([]*Entry, error) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+	return fs.listEntriesNoCache(irodsCorrectPath)
+}
+
 func (fs *FileSystem) SearchUnixWildcard(pathUnixWildcard string) ([]*Entry, error) {
-	// we use ioSession to acquire connection as it can take a long time
-	conn, err := fs.ioSession.AcquireConnection(true)
-	if err != nil {
-		return nil, err
-	}
-	defer fs.ioSession.ReturnConnection(conn) //nolint
+	return fs.SearchUnixWildcardWithOptions(pathUnixWildcard, nil)
+}
 
+// SearchUnixWildcardWithOptions searches dirs and files matching the unix-style wildcard using the search options
+func (fs *FileSystem) SearchUnixWildcardWithOptions(pathUnixWildcard string, options *SearchOptions) ([]*Entry, error) {
 	results := []*Entry{}
 
-	collEntries, err := irods_fs.SearchCollectionsUnixWildcard(conn, pathUnixWildcard)
-	if err != nil {
-		return nil, err
-	}
+	// we use ioSession to acquire connection as it can take a long time
+	err := fs.ioSession.RunIdempotentOperation(true, func(conn *connection.IRODSConnection) error {
+		results = []*Entry{}
 
-	for _, entry := range collEntries {
-		results = append(results, NewEntryFromCollection(entry))
-	}
+		collEntries, err := irods_fs.SearchCollectionsUnixWildcardWithOptions(conn, pathUnixWildcard, options)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range collEntries {
+			results = append(results, NewEntryFromCollection(entry))
+		}
+
+		objectEntries, err := irods_fs.SearchDataObjectsUnixWildcardWithOptions(conn, pathUnixWildcard, options)
+		if err != nil {
+			return err
+		}
 
-	objectEntries, err := irods_fs.SearchDataObjectsUnixWildcard(conn, pathUnixWildcard)
+		for _, entry := range objectEntries {
+			results = append(results, NewEntryFromDataObject(entry))
+		}
+
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	for _, entry := range objectEntries {
-		results = append(results, NewEntryFromDataObject(entry))
-	}
-
 	return results, nil
 }
 
 func (fs *FileSystem) SearchDirUnixWildcard(pathUnixWildcard string) ([]*Entry, error) {
 	// we use ioSession to acquire connection as it can take a long time
-	conn, err := fs.ioSession.AcquireConnection(true)
+	var collEntries []*types.IRODSCollection
+	err := fs.ioSession.RunIdempotentOperation(true, func(conn *connection.IRODSConnection) error {
+		var searchErr error
+		collEntries, searchErr = irods_fs.SearchCollectionsUnixWildcard(conn, pathUnixWildcard)
+		return searchErr
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer fs.ioSession.ReturnConnection(conn) //nolint
 
 	results := []*Entry{}
 
-	collEntries, err := irods_fs.SearchCollectionsUnixWildcard(conn, pathUnixWildcard)
-	if err != nil {
-		return nil, err
-	}
-
 	for _, entry := range collEntries {
 		results = append(results, NewEntryFromCollection(entry))
 	}
@@ -383,19 +602,18 @@ func (fs *FileSystem) SearchDirUnixWildcard(pathUnixWildcard string) ([]*Entry,
 
 func (fs *FileSystem) SearchFileUnixWildcard(pathUnixWildcard string) ([]*Entry, error) {
 	// we use ioSession to acquire connection as it can take a long time
-	conn, err := fs.ioSession.AcquireConnection(true)
+	var objectEntries []*types.IRODSDataObject
+	err := fs.ioSession.RunIdempotentOperation(true, func(conn *connection.IRODSConnection) error {
+		var searchErr error
+		objectEntries, searchErr = irods_fs.SearchDataObjectsUnixWildcard(conn, pathUnixWildcard)
+		return searchErr
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer fs.ioSession.ReturnConnection(conn) //nolint
 
 	results := []*Entry{}
 
-	objectEntries, err := irods_fs.SearchDataObjectsUnixWildcard(conn, pathUnixWildcard)
-	if err != nil {
-		return nil, err
-	}
-
 	for _, entry := range objectEntries {
 		results = append(results, NewEntryFromDataObject(entry))
 	}
@@ -405,8 +623,33 @@ func (fs *FileSystem) SearchFileUnixWildcard(pathUnixWildcard string) ([]*Entry,
 
 // RemoveDir deletes a directory
 func (fs *FileSystem) RemoveDir(irodsPath string, recurse bool, force bool) error {
+	startTime := time.Now()
+	err := fs.removeDir(irodsPath, recurse, force)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditRemoveDir,
+		Path:      irodsPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) removeDir(irodsPath string, recurse bool, force bool) error {
+	return fs.removeDirWithPolicy(irodsPath, recurse, NewDeletionPolicy(force))
+}
+
+func (fs *FileSystem) removeDirWithPolicy(irodsPath string, recurse bool, policy *DeletionPolicy) error {
 	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
 
+	err := policy.validateForDir()
+	if err != nil {
+		return err
+	}
+
+	unlockPaths, err := fs.lockPaths(irodsCorrectPath)
+	if err != nil {
+		return err
+	}
+	defer unlockPaths()
+
 	// we use ioSession to acquire connection as it can take a long time
 	conn, err := fs.ioSession.AcquireConnection(true)
 	if err != nil {
@@ -414,7 +657,7 @@ func (fs *FileSystem) RemoveDir(irodsPath string, recurse bool, force bool) erro
 	}
 	defer fs.ioSession.ReturnConnection(conn) //nolint
 
-	err = irods_fs.DeleteCollection(conn, irodsCorrectPath, recurse, force)
+	err = irods_fs.DeleteCollectionWithKeywords(conn, irodsCorrectPath, recurse, policy.isForce(), policy.isUnregister(), policy.getKeywords())
 	if err != nil {
 		if types.IsFileNotFoundError(err) {
 			fs.InvalidateCacheForFileRemove(irodsCorrectPath)
@@ -423,6 +666,12 @@ func (fs *FileSystem) RemoveDir(irodsPath string, recurse bool, force bool) erro
 		return err
 	}
 
+	if policy.isFiltered() {
+		// entries may be kept by the filters
+		fs.InvalidateCache(irodsCorrectPath, true)
+		return nil
+	}
+
 	fs.InvalidateCacheForDirRemove(irodsCorrectPath, recurse)
 	fs.cachePropagation.PropagateDirRemove(irodsCorrectPath)
 	return nil
@@ -430,8 +679,29 @@ func (fs *FileSystem) RemoveDir(irodsPath string, recurse bool, force bool) erro
 
 // RemoveFile deletes a file
 func (fs *FileSystem) RemoveFile(irodsPath string, force bool) error {
+	startTime := time.Now()
+	err := fs.withPathsLocked(func() error {
+		return fs.removeFile(irodsPath, force)
+	}, irodsPath)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditRemoveFile,
+		Path:      irodsPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) removeFile(irodsPath string, force bool) error {
+	return fs.removeFileWithPolicy(irodsPath, NewDeletionPolicy(force))
+}
+
+func (fs *FileSystem) removeFileWithPolicy(irodsPath string, policy *DeletionPolicy) error {
 	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
 
+	err := policy.validate()
+	if err != nil {
+		return err
+	}
+
 	// we use ioSession to acquire connection as it can take a long time
 	conn, err := fs.ioSession.AcquireConnection(true)
 	if err != nil {
@@ -457,7 +727,7 @@ func (fs *FileSystem) RemoveFile(irodsPath string, force bool) error {
 	}
 
 	// wait done
-	err = irods_fs.DeleteDataObject(conn, irodsCorrectPath, force)
+	err = irods_fs.DeleteDataObjectWithKeywords(conn, irodsCorrectPath, policy.isForce(), policy.isUnregister(), policy.getKeywords())
 	if err != nil {
 		if types.IsFileNotFoundError(err) {
 			fs.InvalidateCacheForFileRemove(irodsCorrectPath)
@@ -466,6 +736,14 @@ func (fs *FileSystem) RemoveFile(irodsPath string, force bool) error {
 		return err
 	}
 
+	if policy.isFiltered() {
+		// the file or its other replicas may be kept by the filters
+		fs.InvalidateCache(irodsCorrectPath, false)
+		fs.InvalidateCacheForFileUpdate(irodsCorrectPath)
+		fs.cachePropagation.PropagateFileUpdate(irodsCorrectPath)
+		return nil
+	}
+
 	fs.InvalidateCacheForFileRemove(irodsCorrectPath)
 	fs.cachePropagation.PropagateFileRemove(irodsCorrectPath)
 	return nil
@@ -473,6 +751,27 @@ func (fs *FileSystem) RemoveFile(irodsPath string, force bool) error {
 
 // RenameDir renames a dir
 func (fs *FileSystem) RenameDir(srcPath string, destPath string) error {
+	return fs.RenameDirWithOptions(srcPath, destPath, nil)
+}
+
+// RenameDirWithOptions renames a dir, the dir is moved into destPath if it is an existing dir
+// FileAlreadyExistError is returned if the destination exists and overwrite is not set or the destination dir is not empty
+func (fs *FileSystem) RenameDirWithOptions(srcPath string, destPath string, options *RenameOptions) error {
+	if options == nil {
+		options = &RenameOptions{}
+	}
+
+	startTime := time.Now()
+	err := fs.renameDir(srcPath, destPath, options.Overwrite)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditRenameDir,
+		Path:      srcPath,
+		DestPath:  destPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) renameDir(srcPath string, destPath string, overwrite bool) error {
 	irodsSrcPath := util.GetCorrectIRODSPath(srcPath)
 	irodsDestPath := util.GetCorrectIRODSPath(destPath)
 
@@ -483,14 +782,35 @@ func (fs *FileSystem) RenameDir(srcPath string, destPath string) error {
 		destDirPath = util.MakeIRODSPath(irodsDestPath, srcFileName)
 	}
 
-	return fs.RenameDirToDir(irodsSrcPath, destDirPath)
+	return fs.renameDirToDir(irodsSrcPath, destDirPath, overwrite)
 }
 
 // RenameDirToDir renames a dir
 func (fs *FileSystem) RenameDirToDir(srcPath string, destPath string) error {
+	startTime := time.Now()
+	err := fs.renameDirToDir(srcPath, destPath, false)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditRenameDir,
+		Path:      srcPath,
+		DestPath:  destPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) renameDirToDir(srcPath string, destPath string, overwrite bool) error {
 	irodsSrcPath := util.GetCorrectIRODSPath(srcPath)
 	irodsDestPath := util.GetCorrectIRODSPath(destPath)
 
+	if strings.HasPrefix(irodsDestPath, irodsSrcPath+"/") {
+		return types.NewCrossCollectionRenameError(irodsSrcPath, irodsDestPath, "a dir cannot be moved into itself")
+	}
+
+	unlockPaths, err := fs.lockPaths(irodsSrcPath, irodsDestPath)
+	if err != nil {
+		return err
+	}
+	defer unlockPaths()
+
 	// we use ioSession to acquire connection as it can take a long time
 	conn, err := fs.ioSession.AcquireConnection(true)
 	if err != nil {
@@ -504,9 +824,21 @@ func (fs *FileSystem) RenameDirToDir(srcPath string, destPath string) error {
 		return err
 	}
 
+	// the destination is removed right before the move, after all checks pass
+	destRemoved := false
+	if overwrite && irodsDestPath != irodsSrcPath {
+		destRemoved, err = fs.removeRenameDestDir(conn, irodsDestPath)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = irods_fs.MoveCollection(conn, irodsSrcPath, irodsDestPath)
 	if err != nil {
-		return err
+		if destRemoved {
+			fs.restoreRenameDestDir(conn, irodsDestPath)
+		}
+		return fs.getRenameError(irodsSrcPath, irodsDestPath, err)
 	}
 
 	fs.InvalidateCacheForDirRemove(irodsSrcPath, true)
@@ -522,8 +854,73 @@ func (fs *FileSystem) RenameDirToDir(srcPath string, destPath string) error {
 	return nil
 }
 
+// removeRenameDestDir removes the destination dir of rename to be replaced, the server does not replace collections
+// only empty dirs are removed, like rename(2), returns true if the dir is removed
+func (fs *FileSystem) removeRenameDestDir(conn *connection.IRODSConnection, destPath string) (bool, error) {
+	_, err := irods_fs.GetCollection(conn, destPath)
+	if err != nil {
+		if types.IsFileNotFoundError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	err = irods_fs.DeleteCollection(conn, destPath, false, true)
+	if err != nil {
+		if types.IsCollectionNotEmptyError(err) {
+			newErr := errors.Join(err, types.NewFileAlreadyExistError(destPath))
+			return false, errors.Wrapf(newErr, "failed to replace the destination dir %q", destPath)
+		}
+		return false, err
+	}
+
+	fs.InvalidateCacheForDirRemove(destPath, false)
+	fs.cachePropagation.PropagateDirRemove(destPath)
+	return true, nil
+}
+
+// restoreRenameDestDir creates the destination dir removed to be replaced again, if the move fails
+func (fs *FileSystem) restoreRenameDestDir(conn *connection.IRODSConnection, destPath string) {
+	err := irods_fs.CreateCollection(conn, destPath, false)
+	if err != nil {
+		log.WithError(err).Warnf("failed to restore the destination dir %q", destPath)
+		return
+	}
+
+	fs.InvalidateCacheForDirCreate(destPath)
+	fs.cachePropagation.PropagateDirCreate(destPath)
+}
+
+// RenameOptions is options for RenameFileWithOptions and RenameDirWithOptions
+type RenameOptions struct {
+	Overwrite bool // replace an existing destination, files are replaced with the force flag, dirs only if empty
+}
+
 // RenameFile renames a file
 func (fs *FileSystem) RenameFile(srcPath string, destPath string) error {
+	return fs.RenameFileWithOptions(srcPath, destPath, nil)
+}
+
+// RenameFileWithOptions renames a file, the file is moved into destPath if it is an existing dir
+// FileAlreadyExistError is returned if the destination exists and overwrite is not set,
+// FileNotFoundError for the destination dir is returned if it does not exist,
+// CrossCollectionRenameError is returned if the file cannot be moved to the destination collection
+func (fs *FileSystem) RenameFileWithOptions(srcPath string, destPath string, options *RenameOptions) error {
+	if options == nil {
+		options = &RenameOptions{}
+	}
+
+	startTime := time.Now()
+	err := fs.renameFile(srcPath, destPath, options.Overwrite)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditRenameFile,
+		Path:      srcPath,
+		DestPath:  destPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) renameFile(srcPath string, destPath string, overwrite bool) error {
 	irodsSrcPath := util.GetCorrectIRODSPath(srcPath)
 	irodsDestPath := util.GetCorrectIRODSPath(destPath)
 
@@ -534,14 +931,31 @@ func (fs *FileSystem) RenameFile(srcPath string, destPath string) error {
 		destFilePath = util.MakeIRODSPath(irodsDestPath, srcFileName)
 	}
 
-	return fs.RenameFileToFile(irodsSrcPath, destFilePath)
+	return fs.renameFileToFile(irodsSrcPath, destFilePath, overwrite)
 }
 
 // RenameFileToFile renames a file
 func (fs *FileSystem) RenameFileToFile(srcPath string, destPath string) error {
+	startTime := time.Now()
+	err := fs.renameFileToFile(srcPath, destPath, false)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditRenameFile,
+		Path:      srcPath,
+		DestPath:  destPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) renameFileToFile(srcPath string, destPath string, overwrite bool) error {
 	irodsSrcPath := util.GetCorrectIRODSPath(srcPath)
 	irodsDestPath := util.GetCorrectIRODSPath(destPath)
 
+	unlockPaths, err := fs.lockPaths(irodsSrcPath, irodsDestPath)
+	if err != nil {
+		return err
+	}
+	defer unlockPaths()
+
 	// we use ioSession to acquire connection as it can take a long time
 	conn, err := fs.ioSession.AcquireConnection(true)
 	if err != nil {
@@ -556,13 +970,17 @@ func (fs *FileSystem) RenameFileToFile(srcPath string, destPath string) error {
 	}
 
 	// rename
-	err = irods_fs.MoveDataObject(conn, irodsSrcPath, irodsDestPath)
+	err = irods_fs.MoveDataObjectWithForce(conn, irodsSrcPath, irodsDestPath, overwrite)
 	if err != nil {
-		return err
+		return fs.getRenameError(irodsSrcPath, irodsDestPath, err)
 	}
 
 	fs.InvalidateCacheForFileRemove(irodsSrcPath)
 	fs.cachePropagation.PropagateFileRemove(irodsSrcPath)
+	if overwrite {
+		// the dest may have been replaced
+		fs.InvalidateCacheForFileRemove(irodsDestPath)
+	}
 	fs.InvalidateCacheForFileCreate(irodsDestPath)
 	fs.cachePropagation.PropagateFileCreate(irodsDestPath)
 
@@ -575,6 +993,23 @@ func (fs *FileSystem) RenameFileToFile(srcPath string, destPath string) error {
 	return nil
 }
 
+// getRenameError tells a missing destination collection from a missing source, the server returns the same error for both
+func (fs *FileSystem) getRenameError(srcPath string, destPath string, err error) error {
+	if !types.IsFileNotFoundError(err) {
+		return err
+	}
+
+	destDirPath := util.GetIRODSPathDirname(destPath)
+	_, statErr := fs.StatNoCache(destDirPath)
+	if statErr != nil && types.IsFileNotFoundError(statErr) {
+		// the parent comes first, so errors.As finds it rather than the source
+		newErr := errors.Join(types.NewFileNotFoundError(destDirPath), err)
+		return errors.Wrapf(newErr, "failed to find the destination dir %q", destDirPath)
+	}
+
+	return err
+}
+
 func (fs *FileSystem) preprocessRenameFileHandle(srcPath string) ([]*FileHandle, error) {
 	handles := fs.fileHandleMap.PopByPath(srcPath)
 	handlesLocked := []*FileHandle{}
@@ -691,10 +1126,52 @@ func (fs *FileSystem) postprocessRenameFileHandleForDir(handles []*FileHandle, c
 	return nil
 }
 
+// MakeDirOptions is options for MakeDirWithOptions
+type MakeDirOptions struct {
+	Recurse  bool                 // create parent directories, existing directory is not an error
+	Inherit  bool                 // enable ACL inheritance of the directory
+	ACLs     []*types.IRODSAccess // initial ACLs, UserName, UserZone, and AccessLevel are used, empty zone uses the client zone
+	Metadata []*types.IRODSMeta   // initial AVUs
+}
+
 // MakeDir creates a directory
 func (fs *FileSystem) MakeDir(irodsPath string, recurse bool) error {
+	return fs.MakeDirWithOptions(irodsPath, &MakeDirOptions{
+		Recurse: recurse,
+	})
+}
+
+// MakeDirWithOptions creates a directory and sets ACL inheritance, ACLs, and AVUs with a single connection
+// the options are applied only if the directory is created, the directory is left if applying them fails
+func (fs *FileSystem) MakeDirWithOptions(irodsPath string, options *MakeDirOptions) error {
+	startTime := time.Now()
+	err := fs.makeDirWithOptions(irodsPath, options)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditMakeDir,
+		Path:      irodsPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) makeDir(irodsPath string, recurse bool) error {
+	return fs.makeDirWithOptions(irodsPath, &MakeDirOptions{
+		Recurse: recurse,
+	})
+}
+
+func (fs *FileSystem) makeDirWithOptions(irodsPath string, options *MakeDirOptions) error {
 	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
 
+	if options == nil {
+		options = &MakeDirOptions{}
+	}
+
+	unlockPaths, err := fs.lockPaths(irodsCorrectPath)
+	if err != nil {
+		return err
+	}
+	defer unlockPaths()
+
 	// we use ioSession to acquire connection as it can take a long time
 	conn, err := fs.ioSession.AcquireConnection(true)
 	if err != nil {
@@ -706,14 +1183,14 @@ func (fs *FileSystem) MakeDir(irodsPath string, recurse bool) error {
 	if err == nil {
 		if dirEntry.ID > 0 {
 			// already exists
-			if recurse {
+			if options.Recurse {
 				return nil
 			}
 			return types.NewFileAlreadyExistError(irodsPath)
 		}
 	}
 
-	err = irods_fs.CreateCollection(conn, irodsCorrectPath, recurse)
+	err = irods_fs.CreateCollection(conn, irodsCorrectPath, options.Recurse)
 	if err != nil {
 		return err
 	}
@@ -721,11 +1198,64 @@ func (fs *FileSystem) MakeDir(irodsPath string, recurse bool) error {
 	fs.InvalidateCacheForDirCreate(irodsCorrectPath)
 	fs.cachePropagation.PropagateDirCreate(irodsCorrectPath)
 	fs.cache.AddDirCache(irodsCorrectPath, []string{})
+
+	return fs.applyMakeDirOptions(conn, irodsCorrectPath, options)
+}
+
+// applyMakeDirOptions sets ACL inheritance, ACLs, and AVUs of the directory created
+func (fs *FileSystem) applyMakeDirOptions(conn *connection.IRODSConnection, irodsPath string, options *MakeDirOptions) error {
+	if options.Inherit {
+		err := irods_fs.ChangeAccessInherit(conn, irodsPath, true, false, false)
+		if err != nil {
+			return errors.Wrapf(err, "failed to enable ACL inheritance of %q", irodsPath)
+		}
+
+		fs.cache.RemoveDirEntryCache(irodsPath, false)
+	}
+
+	for _, access := range options.ACLs {
+		zoneName := access.UserZone
+		if len(zoneName) == 0 {
+			zoneName = fs.account.ClientZone
+		}
+
+		err := irods_fs.ChangeAccess(conn, irodsPath, access.AccessLevel, access.UserName, zoneName, false, false)
+		if err != nil {
+			return errors.Wrapf(err, "failed to set ACL %q for user %q of %q", access.AccessLevel, access.UserName, irodsPath)
+		}
+	}
+
+	if len(options.ACLs) > 0 {
+		fs.cache.RemoveAclCache(irodsPath)
+	}
+
+	for _, metadata := range options.Metadata {
+		err := irods_fs.AddCollectionMeta(conn, irodsPath, metadata)
+		if err != nil {
+			return errors.Wrapf(err, "failed to add metadata %q to %q", metadata.Name, irodsPath)
+		}
+	}
+
+	if len(options.Metadata) > 0 {
+		fs.cache.RemoveMetadataCache(irodsPath)
+	}
+
 	return nil
 }
 
 // CopyFile copies a file
 func (fs *FileSystem) CopyFile(srcPath string, destPath string, force bool) error {
+	startTime := time.Now()
+	err := fs.copyFile(srcPath, destPath, force)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditCopyFile,
+		Path:      srcPath,
+		DestPath:  destPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) copyFile(srcPath string, destPath string, force bool) error {
 	irodsSrcPath := util.GetCorrectIRODSPath(srcPath)
 	irodsDestPath := util.GetCorrectIRODSPath(destPath)
 
@@ -736,14 +1266,31 @@ func (fs *FileSystem) CopyFile(srcPath string, destPath string, force bool) erro
 		destFilePath = util.MakeIRODSPath(irodsDestPath, srcFileName)
 	}
 
-	return fs.CopyFileToFile(irodsSrcPath, destFilePath, force)
+	return fs.copyFileToFile(irodsSrcPath, destFilePath, force)
 }
 
 // CopyFileToFile copies a file
 func (fs *FileSystem) CopyFileToFile(srcPath string, destPath string, force bool) error {
+	startTime := time.Now()
+	err := fs.copyFileToFile(srcPath, destPath, force)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditCopyFile,
+		Path:      srcPath,
+		DestPath:  destPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) copyFileToFile(srcPath string, destPath string, force bool) error {
 	irodsSrcPath := util.GetCorrectIRODSPath(srcPath)
 	irodsDestPath := util.GetCorrectIRODSPath(destPath)
 
+	unlockPaths, err := fs.lockPathsForWrite(irodsSrcPath, irodsDestPath)
+	if err != nil {
+		return err
+	}
+	defer unlockPaths()
+
 	// we use ioSession to acquire connection as it can take a long time
 	conn, err := fs.ioSession.AcquireConnection(true)
 	if err != nil {
@@ -763,6 +1310,18 @@ func (fs *FileSystem) CopyFileToFile(srcPath string, destPath string, force bool
 
 // TruncateFile truncates a file
 func (fs *FileSystem) TruncateFile(irodsPath string, size int64) error {
+	startTime := time.Now()
+	err := fs.withPathsLockedForWrite(func() error {
+		return fs.truncateFile(irodsPath, size)
+	}, irodsPath)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditTruncateFile,
+		Path:      irodsPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) truncateFile(irodsPath string, size int64) error {
 	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
 
 	if size < 0 {
@@ -786,8 +1345,88 @@ func (fs *FileSystem) TruncateFile(irodsPath string, size int64) error {
 	return nil
 }
 
+// TruncateFileReplica truncates a replica of a file, resource is a leaf resource name of the replica
+// this requires iRODS 4.3.2 or higher, adminFlag requires rodsadmin privilege
+func (fs *FileSystem) TruncateFileReplica(irodsPath string, size int64, resource string, replicaNumber *int, adminFlag bool) error {
+	startTime := time.Now()
+	err := fs.truncateFileReplica(irodsPath, size, resource, replicaNumber, adminFlag)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditTruncateFile,
+		Path:      irodsPath,
+		Target:    resource,
+	})
+	return err
+}
+
+func (fs *FileSystem) truncateFileReplica(irodsPath string, size int64, resource string, replicaNumber *int, adminFlag bool) error {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	if size < 0 {
+		size = 0
+	}
+
+	// we use ioSession to acquire connection as it can take a long time
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.TruncateDataObjectReplica(conn, irodsCorrectPath, size, resource, replicaNumber, adminFlag)
+	if err != nil {
+		return err
+	}
+
+	fs.InvalidateCacheForFileUpdate(irodsCorrectPath)
+	fs.cachePropagation.PropagateFileUpdate(irodsCorrectPath)
+	return nil
+}
+
 // ReplicateFile replicates a file
 func (fs *FileSystem) ReplicateFile(irodsPath string, resource string, update bool) error {
+	_, err := fs.ReplicateFileWithOptions(irodsPath, &ReplicateFileOptions{
+		Resources: []string{resource},
+		Update:    update,
+	})
+	return err
+}
+
+func (fs *FileSystem) replicateFile(irodsPath string, resource string, update bool, adminFlag bool) error {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	// we use ioSession to acquire connection as it can take a long time
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.ReplicateDataObject(conn, irodsCorrectPath, resource, update, adminFlag)
+	if err != nil {
+		return err
+	}
+
+	fs.InvalidateCacheForFileUpdate(irodsCorrectPath)
+	fs.cachePropagation.PropagateFileUpdate(irodsCorrectPath)
+	return nil
+}
+
+// PhysicalMoveFile moves a replica of a file to another resource on the server side
+// srcResource and destResource can be resource names or resource hierarchies (e.g., "root;leaf")
+// replicaNumber selects the replica to move if given, otherwise srcResource selects it
+// adminFlag requires rodsadmin privilege
+func (fs *FileSystem) PhysicalMoveFile(irodsPath string, srcResource string, destResource string, replicaNumber *int, adminFlag bool) error {
+	startTime := time.Now()
+	err := fs.physicalMoveFile(irodsPath, srcResource, destResource, replicaNumber, adminFlag)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditPhysicalMoveFile,
+		Path:      irodsPath,
+		Target:    destResource,
+	})
+	return err
+}
+
+func (fs *FileSystem) physicalMoveFile(irodsPath string, srcResource string, destResource string, replicaNumber *int, adminFlag bool) error {
 	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
 
 	// we use ioSession to acquire connection as it can take a long time
@@ -797,7 +1436,70 @@ func (fs *FileSystem) ReplicateFile(irodsPath string, resource string, update bo
 	}
 	defer fs.ioSession.ReturnConnection(conn) //nolint
 
-	err = irods_fs.ReplicateDataObject(conn, irodsCorrectPath, resource, update, false)
+	err = irods_fs.PhysicalMoveDataObject(conn, irodsCorrectPath, srcResource, destResource, replicaNumber, adminFlag)
+	if err != nil {
+		return err
+	}
+
+	fs.InvalidateCacheForFileUpdate(irodsCorrectPath)
+	fs.cachePropagation.PropagateFileUpdate(irodsCorrectPath)
+	return nil
+}
+
+// SetReplicaStatus marks a replica of a file stale or good
+// adminFlag requires rodsadmin privilege
+func (fs *FileSystem) SetReplicaStatus(irodsPath string, replicaNumber int, status types.ReplicaStatus, adminFlag bool) error {
+	startTime := time.Now()
+	err := fs.setReplicaStatus(irodsPath, replicaNumber, status, adminFlag)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditSetReplicaStatus,
+		Path:      irodsPath,
+		Target:    string(status),
+	})
+	return err
+}
+
+func (fs *FileSystem) setReplicaStatus(irodsPath string, replicaNumber int, status types.ReplicaStatus, adminFlag bool) error {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.SetReplicaStatus(conn, irodsCorrectPath, replicaNumber, status, adminFlag)
+	if err != nil {
+		return err
+	}
+
+	fs.InvalidateCacheForFileUpdate(irodsCorrectPath)
+	fs.cachePropagation.PropagateFileUpdate(irodsCorrectPath)
+	return nil
+}
+
+// ModifyFileSystemMetadata modifies system metadata of a replica of a file, e.g., size or modify time after the physical file is changed out-of-band
+// nil fields of metadata are not modified, adminFlag requires rodsadmin privilege
+func (fs *FileSystem) ModifyFileSystemMetadata(irodsPath string, replicaNumber int, metadata *types.IRODSDataObjectSystemMetadata, adminFlag bool) error {
+	startTime := time.Now()
+	err := fs.modifyFileSystemMetadata(irodsPath, replicaNumber, metadata, adminFlag)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditModifySystemMetadata,
+		Path:      irodsPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) modifyFileSystemMetadata(irodsPath string, replicaNumber int, metadata *types.IRODSDataObjectSystemMetadata, adminFlag bool) error {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.ModifyDataObjectSystemMetadata(conn, irodsCorrectPath, replicaNumber, metadata, adminFlag)
 	if err != nil {
 		return err
 	}
@@ -810,6 +1512,16 @@ func (fs *FileSystem) ReplicateFile(irodsPath string, resource string, update bo
 // OpenFile opens an existing file for read/write
 func (fs *FileSystem) OpenFile(irodsPath string, resource string, mode string) (*FileHandle, error) {
 	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+	targetResource := fs.getTargetResource(resource)
+
+	if types.FileOpenMode(mode).IsWrite() {
+		// not to open while other operations modify the file
+		unlockPath, err := fs.lockPaths(irodsCorrectPath)
+		if err != nil {
+			return nil, err
+		}
+		defer unlockPath()
+	}
 
 	conn, err := fs.ioSession.AcquireConnection(true)
 	if err != nil {
@@ -817,7 +1529,7 @@ func (fs *FileSystem) OpenFile(irodsPath string, resource string, mode string) (
 	}
 
 	keywords := map[common.KeyWord]string{}
-	handle, offset, err := irods_fs.OpenDataObject(conn, irodsCorrectPath, resource, mode, keywords)
+	handle, offset, err := irods_fs.OpenDataObject(conn, irodsCorrectPath, targetResource, mode, keywords)
 	if err != nil {
 		fs.ioSession.ReturnConnection(conn) //nolint
 		return nil, err
@@ -859,15 +1571,108 @@ func (fs *FileSystem) OpenFile(irodsPath string, resource string, mode string) (
 		entry:           entry,
 		offset:          offset,
 		openMode:        types.FileOpenMode(mode),
+		openTime:        time.Now(),
 	}
 
 	fs.fileHandleMap.Add(fileHandle)
 	return fileHandle, nil
 }
 
+// OpenDataObjectWithReplicaToken opens a file being written by another client with replica access info from FileHandle.GetReplicaAccessInfo
+// this allows separate processes to write distinct ranges of the same new file, closing the handle does not finalize the file
+func (fs *FileSystem) OpenDataObjectWithReplicaToken(accessInfo *types.IRODSReplicaAccessInfo, mode string) (*FileHandle, error) {
+	err := accessInfo.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	irodsCorrectPath := util.GetCorrectIRODSPath(accessInfo.Path)
+
+	openMode := types.FileOpenMode(mode)
+	if !openMode.IsWrite() {
+		return nil, errors.Errorf("mode %q is not for write", mode)
+	}
+
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return nil, err
+	}
+
+	correctAccessInfo := *accessInfo
+	correctAccessInfo.Path = irodsCorrectPath
+
+	keywords := map[common.KeyWord]string{}
+	handle, offset, err := irods_fs.OpenDataObjectWithReplicaAccessInfo(conn, &correctAccessInfo, mode, keywords)
+	if err != nil {
+		fs.ioSession.ReturnConnection(conn) //nolint
+		return nil, err
+	}
+
+	// we don't use cache to use fresh data object info
+	entry, err := fs.getDataObjectWithConnectionNoCache(conn, irodsCorrectPath)
+	if err != nil {
+		irods_fs.CloseDataObjectReplica(conn, handle) //nolint
+		fs.ioSession.ReturnConnection(conn)           //nolint
+		return nil, err
+	}
+
+	// do not return connection here
+	fileHandle := &FileHandle{
+		id:              xid.New().String(),
+		filesystem:      fs,
+		connection:      conn,
+		irodsFileHandle: handle,
+		entry:           entry,
+		offset:          offset,
+		openMode:        openMode,
+		openTime:        time.Now(),
+		replicaAccess:   true,
+	}
+
+	fs.fileHandleMap.Add(fileHandle)
+	return fileHandle, nil
+}
+
+// CreateFileOptions is options for CreateFileWithOptions
+type CreateFileOptions struct {
+	Exclusive bool // fail with FileAlreadyExistError if the file exists, like O_EXCL
+}
+
 // CreateFile opens a new file for write
 func (fs *FileSystem) CreateFile(irodsPath string, resource string, mode string) (*FileHandle, error) {
+	return fs.CreateFileWithOptions(irodsPath, resource, mode, nil)
+}
+
+// CreateFileWithOptions opens a new file for write following the options, nil opts overwrites an existing file
+func (fs *FileSystem) CreateFileWithOptions(irodsPath string, resource string, mode string, opts *CreateFileOptions) (*FileHandle, error) {
+	// not to create while other operations modify the file
+	unlockPath, err := fs.lockPaths(irodsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer unlockPath()
+
+	return fs.createFileWithOptions(irodsPath, resource, mode, opts)
+}
+
+// createFileWithOptions is CreateFileWithOptions for callers locking the path
+func (fs *FileSystem) createFileWithOptions(irodsPath string, resource string, mode string, opts *CreateFileOptions) (*FileHandle, error) {
+	startTime := time.Now()
+	result, err := fs.createFile(irodsPath, resource, mode, opts)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditCreateFile,
+		Path:      irodsPath,
+	})
+	return result, err
+}
+
+func (fs *FileSystem) createFile(irodsPath string, resource string, mode string, opts *CreateFileOptions) (*FileHandle, error) {
 	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+	targetResource := fs.getTargetResource(resource)
+
+	if opts == nil {
+		opts = &CreateFileOptions{}
+	}
 
 	conn, err := fs.ioSession.AcquireConnection(true)
 	if err != nil {
@@ -876,7 +1681,12 @@ func (fs *FileSystem) CreateFile(irodsPath string, resource string, mode string)
 
 	// create
 	keywords := map[common.KeyWord]string{}
-	handle, err := irods_fs.CreateDataObject(conn, irodsCorrectPath, resource, mode, true, keywords)
+	var handle *types.IRODSFileHandle
+	if opts.Exclusive {
+		handle, err = irods_fs.CreateDataObjectExclusive(conn, irodsCorrectPath, targetResource, mode, keywords)
+	} else {
+		handle, err = irods_fs.CreateDataObject(conn, irodsCorrectPath, targetResource, mode, true, keywords)
+	}
 	if err != nil {
 		fs.ioSession.ReturnConnection(conn) //nolint
 		return nil, err
@@ -896,7 +1706,7 @@ func (fs *FileSystem) CreateFile(irodsPath string, resource string, mode string)
 	}
 
 	// re-open
-	handle, offset, err := irods_fs.OpenDataObject(conn, irodsCorrectPath, resource, mode, keywords)
+	handle, offset, err := irods_fs.OpenDataObject(conn, irodsCorrectPath, targetResource, mode, keywords)
 	if err != nil {
 		fs.ioSession.ReturnConnection(conn) //nolint
 		return nil, err
@@ -911,6 +1721,7 @@ func (fs *FileSystem) CreateFile(irodsPath string, resource string, mode string)
 		entry:           entry,
 		offset:          offset,
 		openMode:        types.FileOpenMode(mode),
+		openTime:        time.Now(),
 	}
 
 	fs.fileHandleMap.Add(fileHandle)
@@ -920,16 +1731,47 @@ func (fs *FileSystem) CreateFile(irodsPath string, resource string, mode string)
 	return fileHandle, nil
 }
 
-// getCollectionNoCache returns collection entry
-func (fs *FileSystem) getCollectionNoCache(irodsPath string) (*Entry, error) {
-	// retrieve it and add it to cache
-	conn, err := fs.metadataSession.AcquireConnection(true)
+// Append writes data at the end of the file, creates the file if it does not exist, returns the new size of the file
+func (fs *FileSystem) Append(irodsPath string, resource string, data []byte) (int64, error) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	created := !fs.ExistsFile(irodsCorrectPath)
+
+	handle, err := fs.OpenFile(irodsCorrectPath, resource, string(types.FileOpenModeAppend))
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer fs.metadataSession.ReturnConnection(conn) //nolint
 
-	collection, err := irods_fs.GetCollection(conn, irodsPath)
+	_, err = handle.Write(data)
+	if err != nil {
+		handle.Close() //nolint
+		return 0, err
+	}
+
+	size := handle.GetOffset()
+
+	err = handle.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	if created {
+		fs.InvalidateCacheForFileCreate(irodsCorrectPath)
+		fs.cachePropagation.PropagateFileCreate(irodsCorrectPath)
+	}
+
+	return size, nil
+}
+
+// getCollectionNoCache returns collection entry
+func (fs *FileSystem) getCollectionNoCache(irodsPath string) (*Entry, error) {
+	// retrieve it and add it to cache
+	var collection *types.IRODSCollection
+	err := fs.metadataSession.RunIdempotentOperation(true, func(conn *connection.IRODSConnection) error {
+		var getErr error
+		collection, getErr = irods_fs.GetCollection(conn, irodsPath)
+		return getErr
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -993,13 +1835,23 @@ func (fs *FileSystem) listEntries(collPath string) ([]*Entry, error) {
 	}
 
 	// otherwise, retrieve it and add it to cache
-	conn, err := fs.metadataSession.AcquireConnection(true)
-	if err != nil {
-		return nil, err
-	}
-	defer fs.metadataSession.ReturnConnection(conn) //nolint
+	return fs.listEntriesNoCache(collPath)
+}
 
-	collections, err := irods_fs.ListSubCollections(conn, collPath)
+// listEntriesNoCache lists entries in a collection and adds them to cache
+func (fs *FileSystem) listEntriesNoCache(collPath string) ([]*Entry, error) {
+	var collections []*types.IRODSCollection
+	var dataobjects []*types.IRODSDataObject
+	err := fs.metadataSession.RunIdempotentOperation(true, func(conn *connection.IRODSConnection) error {
+		var listErr error
+		collections, listErr = irods_fs.ListSubCollections(conn, collPath)
+		if listErr != nil {
+			return listErr
+		}
+
+		dataobjects, listErr = irods_fs.ListDataObjects(conn, collPath)
+		return listErr
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1015,11 +1867,6 @@ func (fs *FileSystem) listEntries(collPath string) ([]*Entry, error) {
 		fs.cache.AddEntryCache(entry)
 	}
 
-	dataobjects, err := irods_fs.ListDataObjects(conn, collPath)
-	if err != nil {
-		return nil, err
-	}
-
 	for _, dataobject := range dataobjects {
 		if len(dataobject.Replicas) == 0 {
 			continue
@@ -1035,9 +1882,19 @@ func (fs *FileSystem) listEntries(collPath string) ([]*Entry, error) {
 
 	// cache dir entries
 	dirEntryPaths := []string{}
+	dirEntryPathMap := map[string]bool{}
 	for _, entry := range entries {
 		dirEntryPaths = append(dirEntryPaths, entry.Path)
+		dirEntryPathMap[entry.Path] = true
+	}
+
+	// entries removed since last listing are stale
+	for _, cachedDirEntryPath := range fs.cache.GetDirCache(collPath) {
+		if !dirEntryPathMap[cachedDirEntryPath] {
+			fs.cache.RemoveEntryCache(cachedDirEntryPath)
+		}
 	}
+
 	fs.cache.AddDirCache(collPath, dirEntryPaths)
 
 	return entries, nil
@@ -1083,13 +1940,12 @@ func (fs *FileSystem) getDataObjectWithConnection(conn *connection.IRODSConnecti
 // getDataObjectNoCache returns an entry for data object
 func (fs *FileSystem) getDataObjectNoCache(irodsPath string) (*Entry, error) {
 	// retrieve it and add it to cache
-	conn, err := fs.metadataSession.AcquireConnection(true)
-	if err != nil {
-		return nil, err
-	}
-	defer fs.metadataSession.ReturnConnection(conn) //nolint
-
-	dataobject, err := irods_fs.GetDataObject(conn, irodsPath)
+	var dataobject *types.IRODSDataObject
+	err := fs.metadataSession.RunIdempotentOperation(true, func(conn *connection.IRODSConnection) error {
+		var getErr error
+		dataobject, getErr = irods_fs.GetDataObject(conn, irodsPath)
+		return getErr
+	})
 	if err != nil {
 		return nil, err
 	}
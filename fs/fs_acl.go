@@ -2,6 +2,8 @@ package fs
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
@@ -64,7 +66,16 @@ func (fs *FileSystem) ListACLsWithGroupUsers(path string) ([]*types.IRODSAccess,
 func (fs *FileSystem) GetDirACLInheritance(path string) (*types.IRODSAccessInheritance, error) {
 	irodsPath := util.GetCorrectIRODSPath(path)
 
-	// retrieve it
+	// check cache first
+	cachedEntry := fs.cache.GetEntryCache(irodsPath)
+	if cachedEntry != nil && cachedEntry.IsDir() {
+		return &types.IRODSAccessInheritance{
+			Path:        irodsPath,
+			Inheritance: cachedEntry.Inheritance,
+		}, nil
+	}
+
+	// otherwise, retrieve it
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return nil, err
@@ -192,7 +203,7 @@ func (fs *FileSystem) ListFileACLsWithGroupMembers(path string) ([]*types.IRODSA
 	for _, access := range accesses {
 		if access.UserType == types.IRODSUserRodsGroup {
 			// retrieve all members in the group
-			users, err := fs.ListGroupMembers(access.UserName, access.UserName)
+			users, err := fs.ListGroupMembers(access.UserZone, access.UserName)
 			if err != nil {
 				return nil, err
 			}
@@ -222,8 +233,109 @@ func (fs *FileSystem) ListFileACLsWithGroupMembers(path string) ([]*types.IRODSA
 	return newAccesses, nil
 }
 
+// ListAccessWithGroups returns ACLs of a file or directory with group ACLs expanded to members
+// each returned entry tells whether the access is granted directly or via a group
+// CAUTION: this can fail if a group contains a lot of users
+func (fs *FileSystem) ListAccessWithGroups(path string) ([]*types.IRODSAccessGrant, error) {
+	accesses, err := fs.ListACLs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	grants := []*types.IRODSAccessGrant{}
+	for _, access := range accesses {
+		if access.UserType == types.IRODSUserRodsGroup {
+			// retrieve all members in the group
+			users, err := fs.ListGroupMembers(access.UserZone, access.UserName)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, user := range users {
+				grants = append(grants, &types.IRODSAccessGrant{
+					Path:        access.Path,
+					UserName:    user.Name,
+					UserZone:    user.Zone,
+					UserType:    user.Type,
+					AccessLevel: access.AccessLevel,
+					ViaGroup:    true,
+					GroupName:   access.UserName,
+					GroupZone:   access.UserZone,
+				})
+			}
+		} else {
+			grants = append(grants, &types.IRODSAccessGrant{
+				Path:        access.Path,
+				UserName:    access.UserName,
+				UserZone:    access.UserZone,
+				UserType:    access.UserType,
+				AccessLevel: access.AccessLevel,
+				ViaGroup:    false,
+			})
+		}
+	}
+
+	return grants, nil
+}
+
+// GetEffectiveAccess returns the highest access level that the given user has on a file or directory
+// accesses granted to groups that the user belongs to are taken into account
+func (fs *FileSystem) GetEffectiveAccess(path string, userName string, zoneName string) (types.IRODSAccessLevelType, error) {
+	if len(zoneName) == 0 {
+		zoneName = fs.account.ClientZone
+	}
+
+	accesses, err := fs.ListACLs(path)
+	if err != nil {
+		return types.IRODSAccessLevelNull, err
+	}
+
+	groupNames, err := fs.ListUserGroupNames(zoneName, userName)
+	if err != nil {
+		return types.IRODSAccessLevelNull, err
+	}
+
+	groupNameMap := map[string]bool{}
+	for _, groupName := range groupNames {
+		groupNameMap[groupName] = true
+	}
+
+	effectiveAccess := types.IRODSAccessLevelNull
+	for _, access := range accesses {
+		if access.UserZone != zoneName {
+			continue
+		}
+
+		matched := false
+		if access.UserType == types.IRODSUserRodsGroup {
+			matched = groupNameMap[access.UserName]
+		} else {
+			matched = access.UserName == userName
+		}
+
+		if matched && access.AccessLevel.IsHigherThan(effectiveAccess) {
+			effectiveAccess = access.AccessLevel
+		}
+	}
+
+	return effectiveAccess, nil
+}
+
 // ChangeACLs changes ACLs of a file or directory
 func (fs *FileSystem) ChangeACLs(path string, access types.IRODSAccessLevelType, userName string, zoneName string, recurse bool, adminFlag bool) error {
+	startTime := time.Now()
+	err := fs.changeACLs(path, access, userName, zoneName, recurse, adminFlag)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditChangeACL,
+		Path:      path,
+		Target:    userName,
+	})
+	return err
+}
+
+func (fs *FileSystem) changeACLs(path string, access types.IRODSAccessLevelType, userName string, zoneName string, recurse bool, adminFlag bool) error {
+	irodsPath := util.GetCorrectIRODSPath(path)
+
 	// we use ioSession to acquire connection as it can take a long time
 	conn, err := fs.ioSession.AcquireConnection(true)
 	if err != nil {
@@ -231,16 +343,60 @@ func (fs *FileSystem) ChangeACLs(path string, access types.IRODSAccessLevelType,
 	}
 	defer fs.ioSession.ReturnConnection(conn) //nolint
 
-	err = irods_fs.ChangeAccess(conn, path, access, userName, zoneName, recurse, adminFlag)
+	err = irods_fs.ChangeAccess(conn, irodsPath, access, userName, zoneName, recurse, adminFlag)
 	if err != nil {
 		return err
 	}
 
+	// invalidate cached ACLs
+	if recurse {
+		fs.cache.RemoveAllAclCacheForPath(irodsPath)
+	} else {
+		fs.cache.RemoveAclCache(irodsPath)
+	}
+
 	return nil
 }
 
+// SetAccessRecursive changes ACLs of a file or directory and all entries under it
+// the change is applied server-side in a single request
+// if zone is not given in userName (user#zone), the client zone is used
+// adminFlag requires rodsadmin privilege
+func (fs *FileSystem) SetAccessRecursive(path string, userName string, access types.IRODSAccessLevelType, adminFlag bool) error {
+	startTime := time.Now()
+	err := fs.setAccessRecursive(path, userName, access, adminFlag)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditChangeACL,
+		Path:      path,
+		Target:    userName,
+	})
+	return err
+}
+
+func (fs *FileSystem) setAccessRecursive(path string, userName string, access types.IRODSAccessLevelType, adminFlag bool) error {
+	zoneName := fs.account.ClientZone
+	if idx := strings.LastIndex(userName, "#"); idx >= 0 {
+		zoneName = userName[idx+1:]
+		userName = userName[:idx]
+	}
+
+	return fs.changeACLs(path, access, userName, zoneName, true, adminFlag)
+}
+
 // ChangeDirACLInheritance changes ACL inheritance of a directory
 func (fs *FileSystem) ChangeDirACLInheritance(path string, inherit bool, recurse bool, adminFlag bool) error {
+	startTime := time.Now()
+	err := fs.changeDirACLInheritance(path, inherit, recurse, adminFlag)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditChangeDirACLInheritance,
+		Path:      path,
+	})
+	return err
+}
+
+func (fs *FileSystem) changeDirACLInheritance(path string, inherit bool, recurse bool, adminFlag bool) error {
+	irodsPath := util.GetCorrectIRODSPath(path)
+
 	// we use ioSession to acquire connection as it can take a long time
 	conn, err := fs.ioSession.AcquireConnection(true)
 	if err != nil {
@@ -248,14 +404,32 @@ func (fs *FileSystem) ChangeDirACLInheritance(path string, inherit bool, recurse
 	}
 	defer fs.ioSession.ReturnConnection(conn) //nolint
 
-	err = irods_fs.ChangeAccessInherit(conn, path, inherit, recurse, adminFlag)
+	err = irods_fs.ChangeAccessInherit(conn, irodsPath, inherit, recurse, adminFlag)
 	if err != nil {
 		return err
 	}
 
+	// entries have inheritance flag
+	fs.cache.RemoveDirEntryCache(irodsPath, recurse)
+
 	return nil
 }
 
+// SetDirACLInheritanceRecursive changes ACL inheritance of a directory and all sub-directories under it
+func (fs *FileSystem) SetDirACLInheritanceRecursive(path string, inherit bool, adminFlag bool) error {
+	startTime := time.Now()
+	err := fs.setDirACLInheritanceRecursive(path, inherit, adminFlag)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditChangeDirACLInheritance,
+		Path:      path,
+	})
+	return err
+}
+
+func (fs *FileSystem) setDirACLInheritanceRecursive(path string, inherit bool, adminFlag bool) error {
+	return fs.changeDirACLInheritance(path, inherit, true, adminFlag)
+}
+
 // listACLsForEntries lists ACLs for entries in a collection
 func (fs *FileSystem) listACLsForEntries(collPath string) ([]*types.IRODSAccess, error) {
 	// check cache first
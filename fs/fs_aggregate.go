@@ -0,0 +1,98 @@
+package fs
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+// CountFiles returns the number of files in the dir, counted on the server without listing entries
+func (fs *FileSystem) CountFiles(irodsPath string, recurse bool) (int64, error) {
+	value, err := fs.getFileAggregate(irodsPath, recurse, common.ICAT_SELECT_FUNC_COUNT, common.ICAT_COLUMN_D_DATA_ID)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse file count %q", value)
+	}
+
+	return count, nil
+}
+
+// GetMaxFileModifyTime returns the latest modification time of files in the dir, zero time if there is no file
+func (fs *FileSystem) GetMaxFileModifyTime(irodsPath string, recurse bool) (time.Time, error) {
+	return fs.getFileModifyTimeAggregate(irodsPath, recurse, common.ICAT_SELECT_FUNC_MAX)
+}
+
+// GetMinFileModifyTime returns the earliest modification time of files in the dir, zero time if there is no file
+func (fs *FileSystem) GetMinFileModifyTime(irodsPath string, recurse bool) (time.Time, error) {
+	return fs.getFileModifyTimeAggregate(irodsPath, recurse, common.ICAT_SELECT_FUNC_MIN)
+}
+
+// GetAverageFileSize returns the average size of files in the dir, zero if there is no file
+func (fs *FileSystem) GetAverageFileSize(irodsPath string, recurse bool) (float64, error) {
+	value, err := fs.getFileAggregate(irodsPath, recurse, common.ICAT_SELECT_FUNC_AVG, common.ICAT_COLUMN_DATA_SIZE)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(value) == 0 {
+		return 0, nil
+	}
+
+	avg, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse average file size %q", value)
+	}
+
+	return avg, nil
+}
+
+func (fs *FileSystem) getFileModifyTimeAggregate(irodsPath string, recurse bool, selectFunc common.ICATSelectFunction) (time.Time, error) {
+	value, err := fs.getFileAggregate(irodsPath, recurse, selectFunc, common.ICAT_COLUMN_D_MODIFY_TIME)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if len(value) == 0 {
+		return time.Time{}, nil
+	}
+
+	return util.GetIRODSDateTime(value)
+}
+
+// getFileAggregate returns the aggregate of the column over files in the dir, only replica 0 of each file is aggregated
+func (fs *FileSystem) getFileAggregate(irodsPath string, recurse bool, selectFunc common.ICATSelectFunction, column common.ICATColumnNumber) (string, error) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	// aggregation returns nothing for a missing collection
+	entry, err := fs.StatDir(irodsCorrectPath)
+	if err != nil {
+		return "", err
+	}
+
+	if entry.ID <= 0 {
+		return "", types.NewFileNotFoundError(irodsCorrectPath)
+	}
+
+	// we use ioSession to acquire connection as it can take a long time
+	value := ""
+	err = fs.ioSession.RunIdempotentOperation(true, func(conn *connection.IRODSConnection) error {
+		var aggregateErr error
+		value, aggregateErr = irods_fs.GetDataObjectAggregate(conn, irodsCorrectPath, recurse, selectFunc, column)
+		return aggregateErr
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
@@ -0,0 +1,207 @@
+package fs
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cyverse/go-irodsclient/irods/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// AuditOperation is a type of mutating FileSystem operations
+type AuditOperation string
+
+const (
+	// AuditMakeDir is an operation creating a collection
+	AuditMakeDir AuditOperation = "make_dir"
+	// AuditRemoveDir is an operation removing a collection
+	AuditRemoveDir AuditOperation = "remove_dir"
+	// AuditRemoveFile is an operation removing a data object
+	AuditRemoveFile AuditOperation = "remove_file"
+	// AuditRenameDir is an operation renaming a collection
+	AuditRenameDir AuditOperation = "rename_dir"
+	// AuditRenameFile is an operation renaming a data object
+	AuditRenameFile AuditOperation = "rename_file"
+	// AuditCopyFile is an operation copying a data object
+	AuditCopyFile AuditOperation = "copy_file"
+	// AuditCopyDir is an operation copying a collection recursively, bytes are the size of files copied
+	AuditCopyDir AuditOperation = "copy_dir"
+	// AuditCreateFile is an operation creating a data object
+	AuditCreateFile AuditOperation = "create_file"
+	// AuditWriteFile is an operation writing to a data object via a file handle, recorded on close
+	AuditWriteFile AuditOperation = "write_file"
+	// AuditTruncateFile is an operation truncating a data object
+	AuditTruncateFile AuditOperation = "truncate_file"
+	// AuditReplicateFile is an operation replicating a data object
+	AuditReplicateFile AuditOperation = "replicate_file"
+	// AuditPhysicalMoveFile is an operation moving a replica of a data object to another resource
+	AuditPhysicalMoveFile AuditOperation = "physical_move_file"
+	// AuditSetReplicaStatus is an operation setting the status of a replica, target is the new status
+	AuditSetReplicaStatus AuditOperation = "set_replica_status"
+	// AuditModifySystemMetadata is an operation modifying system metadata of a replica, such as size or modify time
+	AuditModifySystemMetadata AuditOperation = "modify_system_metadata"
+	// AuditTouch is an operation creating a data object or updating its timestamp
+	AuditTouch AuditOperation = "touch"
+	// AuditUploadFile is an operation uploading a data object
+	AuditUploadFile AuditOperation = "upload_file"
+	// AuditUploadDir is an operation uploading a local dir recursively, bytes are the size of files uploaded
+	AuditUploadDir AuditOperation = "upload_dir"
+	// AuditExtractStructFile is an operation extracting a struct file
+	AuditExtractStructFile AuditOperation = "extract_struct_file"
+	// AuditBundleStructFile is an operation creating a struct file from a collection, dest path is the struct file
+	AuditBundleStructFile AuditOperation = "bundle_struct_file"
+	// AuditPhysicalBundleDir is an operation bundling files of a collection on a bundle resource, target is the resource
+	AuditPhysicalBundleDir AuditOperation = "physical_bundle_dir"
+	// AuditRegisterFile is an operation registering a physical file as a data object or a replica, target is the resource
+	AuditRegisterFile AuditOperation = "register_file"
+	// AuditRegisterDir is an operation registering a physical directory as a collection recursively, target is the resource
+	AuditRegisterDir AuditOperation = "register_dir"
+	// AuditUnregisterFile is an operation unregistering a data object without deleting the physical file
+	AuditUnregisterFile AuditOperation = "unregister_file"
+	// AuditUnregisterDir is an operation unregistering a collection without deleting the physical files
+	AuditUnregisterDir AuditOperation = "unregister_dir"
+	// AuditMountDir is an operation mounting a special collection, target is the type of special collection
+	AuditMountDir AuditOperation = "mount_dir"
+	// AuditUnmountDir is an operation unmounting a special collection
+	AuditUnmountDir AuditOperation = "unmount_dir"
+	// AuditRestoreTrash is an operation moving an entry in trash back to its original path or to dest path
+	AuditRestoreTrash AuditOperation = "restore_trash"
+	// AuditEmptyTrash is an operation removing entries in trash permanently, target is the user whose trash is emptied
+	AuditEmptyTrash AuditOperation = "empty_trash"
+	// AuditChangeACL is an operation changing ACLs
+	AuditChangeACL AuditOperation = "change_acl"
+	// AuditChangeDirACLInheritance is an operation changing ACL inheritance of a collection
+	AuditChangeDirACLInheritance AuditOperation = "change_dir_acl_inheritance"
+	// AuditAddMetadata is an operation adding metadata to a data object or a collection
+	AuditAddMetadata AuditOperation = "add_metadata"
+	// AuditDeleteMetadata is an operation deleting metadata of a data object or a collection
+	AuditDeleteMetadata AuditOperation = "delete_metadata"
+	// AuditAddUserMetadata is an operation adding metadata to a user
+	AuditAddUserMetadata AuditOperation = "add_user_metadata"
+	// AuditDeleteUserMetadata is an operation deleting metadata of a user
+	AuditDeleteUserMetadata AuditOperation = "delete_user_metadata"
+	// AuditAddResourceMetadata is an operation adding metadata to a resource
+	AuditAddResourceMetadata AuditOperation = "add_resource_metadata"
+	// AuditDeleteResourceMetadata is an operation deleting metadata of a resource
+	AuditDeleteResourceMetadata AuditOperation = "delete_resource_metadata"
+	// AuditCreateTicket is an operation creating a ticket
+	AuditCreateTicket AuditOperation = "create_ticket"
+	// AuditDeleteTicket is an operation deleting a ticket
+	AuditDeleteTicket AuditOperation = "delete_ticket"
+	// AuditModifyTicket is an operation modifying a ticket
+	AuditModifyTicket AuditOperation = "modify_ticket"
+	// AuditCreateUser is an operation creating a user or a group
+	AuditCreateUser AuditOperation = "create_user"
+	// AuditModifyUser is an operation changing password or type of a user
+	AuditModifyUser AuditOperation = "modify_user"
+	// AuditRemoveUser is an operation removing a user or a group
+	AuditRemoveUser AuditOperation = "remove_user"
+	// AuditAddGroupMember is an operation adding a user to a group
+	AuditAddGroupMember AuditOperation = "add_group_member"
+	// AuditRemoveGroupMember is an operation removing a user from a group
+	AuditRemoveGroupMember AuditOperation = "remove_group_member"
+)
+
+// AuditRecord is a record of a mutating FileSystem operation
+type AuditRecord struct {
+	Time         time.Time      `json:"time"` // start time of the operation
+	FileSystemID string         `json:"filesystem_id"`
+	User         string         `json:"user"`
+	Zone         string         `json:"zone"`
+	Operation    AuditOperation `json:"operation"`
+	Path         string         `json:"path,omitempty"`      // iRODS path the operation applies to
+	DestPath     string         `json:"dest_path,omitempty"` // destination iRODS path of rename, copy, upload and extract
+	Target       string         `json:"target,omitempty"`    // user, group, resource or ticket the operation applies to
+	Bytes        int64          `json:"bytes,omitempty"`     // bytes written
+	Success      bool           `json:"success"`
+	Error        string         `json:"error,omitempty"`
+	Duration     time.Duration  `json:"duration"`
+}
+
+// AuditSink receives audit records, Record is called synchronously after each operation, so it should return quickly
+type AuditSink interface {
+	Record(record *AuditRecord)
+}
+
+// AuditSinkFunc is a function implementing AuditSink
+type AuditSinkFunc func(record *AuditRecord)
+
+// Record calls the function
+func (sinkFunc AuditSinkFunc) Record(record *AuditRecord) {
+	sinkFunc(record)
+}
+
+// JSONAuditSink writes audit records to a writer, one JSON object per line
+type JSONAuditSink struct {
+	writer io.Writer
+	mutex  sync.Mutex
+}
+
+// NewJSONAuditSink creates a new JSONAuditSink
+func NewJSONAuditSink(writer io.Writer) *JSONAuditSink {
+	return &JSONAuditSink{
+		writer: writer,
+	}
+}
+
+// Record writes the record
+func (sink *JSONAuditSink) Record(record *AuditRecord) {
+	logger := log.WithFields(log.Fields{
+		"operation": record.Operation,
+		"path":      record.Path,
+	})
+
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		logger.WithError(err).Error("failed to marshal audit record")
+		return
+	}
+
+	recordBytes = append(recordBytes, '\n')
+
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	_, err = sink.writer.Write(recordBytes)
+	if err != nil {
+		logger.WithError(err).Error("failed to write audit record")
+	}
+}
+
+// recordAudit fills the record with the account, the result and the duration of the operation and sends it to the audit sink
+func (fs *FileSystem) recordAudit(startTime time.Time, err error, record *AuditRecord) {
+	if fs.config == nil || fs.config.AuditSink == nil {
+		return
+	}
+
+	record.Time = startTime
+	record.Duration = time.Since(startTime)
+	record.FileSystemID = fs.id
+	record.User = fs.account.ClientUser
+	record.Zone = fs.account.ClientZone
+
+	if len(record.Path) > 0 {
+		record.Path = util.GetCorrectIRODSPath(record.Path)
+	}
+
+	if len(record.DestPath) > 0 {
+		record.DestPath = util.GetCorrectIRODSPath(record.DestPath)
+	}
+
+	record.Success = err == nil
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	fs.config.AuditSink.Record(record)
+}
+
+func getTransferResultSize(result *FileTransferResult) int64 {
+	if result == nil {
+		return 0
+	}
+
+	return result.IRODSSize
+}
@@ -3,6 +3,7 @@ package fs
 import (
 	"bytes"
 	"encoding/hex"
+	"hash"
 	"os"
 	"path/filepath"
 	"time"
@@ -13,6 +14,8 @@ import (
 	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
 	"github.com/cyverse/go-irodsclient/irods/types"
 	"github.com/cyverse/go-irodsclient/irods/util"
+
+	log "github.com/sirupsen/logrus"
 )
 
 const (
@@ -31,6 +34,8 @@ type FileTransferResult struct {
 	LocalSize              int64                   `json:"local_size"`
 	StartTime              time.Time               `json:"start_time"`
 	EndTime                time.Time               `json:"end_time"`
+	Compression            TransferCompression     `json:"compression,omitempty"`
+	Report                 *TransferReport         `json:"report,omitempty"` // set if TransferReport is enabled in config
 }
 
 // DownloadFile downloads a file to local
@@ -43,6 +48,8 @@ func (fs *FileSystem) DownloadFile(irodsPath string, resource string, localPath
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.IRODSPath = irodsSrcPath
 	fileTransferResult.StartTime = time.Now()
+	transferStats := &irods_fs.DataObjectTransferStats{}
+	defer fs.reportTransfer(fileTransferResult, transferStats, verifyChecksum)
 
 	entry, err := fs.Stat(irodsSrcPath)
 	if err != nil {
@@ -87,7 +94,7 @@ func (fs *FileSystem) DownloadFile(irodsPath string, resource string, localPath
 		keywords[common.VERIFY_CHKSUM_KW] = ""
 	}
 
-	err = irods_fs.DownloadDataObject(fs.ioSession, entry.ToDataObject(), resource, localFilePath, keywords, transferCallback)
+	err = irods_fs.DownloadDataObjectParallelWithStats(fs.ioSession, entry.ToDataObject(), resource, localFilePath, 1, keywords, transferCallback, transferStats)
 	if err != nil {
 		return fileTransferResult, errors.Wrapf(err, "failed to download a data object for path %q", irodsSrcPath)
 	}
@@ -101,7 +108,7 @@ func (fs *FileSystem) DownloadFile(irodsPath string, resource string, localPath
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
+		_, hash, err := fs.calculateDownloadedFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
 		if err != nil {
 			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
 		}
@@ -114,6 +121,12 @@ func (fs *FileSystem) DownloadFile(irodsPath string, resource string, localPath
 		}
 	}
 
+	// attributes are applied after verification, not to mark a corrupted file as downloaded
+	err = fs.applyDownloadedFileAttributes(entry, localFilePath)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
 	fileTransferResult.EndTime = time.Now()
 
 	return fileTransferResult, nil
@@ -129,6 +142,8 @@ func (fs *FileSystem) DownloadFileWithConnection(conn *connection.IRODSConnectio
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.IRODSPath = irodsSrcPath
 	fileTransferResult.StartTime = time.Now()
+	transferStats := &irods_fs.DataObjectTransferStats{}
+	defer fs.reportTransfer(fileTransferResult, transferStats, verifyChecksum)
 
 	entry, err := fs.Stat(irodsSrcPath)
 	if err != nil {
@@ -173,7 +188,7 @@ func (fs *FileSystem) DownloadFileWithConnection(conn *connection.IRODSConnectio
 		keywords[common.VERIFY_CHKSUM_KW] = ""
 	}
 
-	err = irods_fs.DownloadDataObjectWithConnection(conn, entry.ToDataObject(), resource, localFilePath, keywords, transferCallback)
+	err = irods_fs.DownloadDataObjectParallelWithConnectionsAndStats([]*connection.IRODSConnection{conn}, entry.ToDataObject(), resource, localFilePath, keywords, transferCallback, transferStats)
 	if err != nil {
 		return fileTransferResult, errors.Wrapf(err, "failed to download a data object for path %q", irodsSrcPath)
 	}
@@ -187,7 +202,7 @@ func (fs *FileSystem) DownloadFileWithConnection(conn *connection.IRODSConnectio
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
+		_, hash, err := fs.calculateDownloadedFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
 		if err != nil {
 			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
 		}
@@ -200,6 +215,12 @@ func (fs *FileSystem) DownloadFileWithConnection(conn *connection.IRODSConnectio
 		}
 	}
 
+	// attributes are applied after verification, not to mark a corrupted file as downloaded
+	err = fs.applyDownloadedFileAttributes(entry, localFilePath)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
 	fileTransferResult.EndTime = time.Now()
 
 	return fileTransferResult, nil
@@ -215,6 +236,8 @@ func (fs *FileSystem) DownloadFileResumable(irodsPath string, resource string, l
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.IRODSPath = irodsSrcPath
 	fileTransferResult.StartTime = time.Now()
+	transferStats := &irods_fs.DataObjectTransferStats{}
+	defer fs.reportTransfer(fileTransferResult, transferStats, verifyChecksum)
 
 	entry, err := fs.Stat(irodsSrcPath)
 	if err != nil {
@@ -259,7 +282,7 @@ func (fs *FileSystem) DownloadFileResumable(irodsPath string, resource string, l
 		keywords[common.VERIFY_CHKSUM_KW] = ""
 	}
 
-	err = irods_fs.DownloadDataObjectResumable(fs.ioSession, entry.ToDataObject(), resource, localFilePath, keywords, transferCallback)
+	rangeStatuses, err := irods_fs.DownloadDataObjectResumableWithRangesAndStats(fs.ioSession, entry.ToDataObject(), resource, localFilePath, keywords, transferCallback, transferStats)
 	if err != nil {
 		return fileTransferResult, errors.Wrapf(err, "failed to download a data object for path %q", irodsSrcPath)
 	}
@@ -273,7 +296,7 @@ func (fs *FileSystem) DownloadFileResumable(irodsPath string, resource string, l
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
+		_, hash, err := fs.calculateDownloadedFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
 		if err != nil {
 			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
 		}
@@ -282,10 +305,26 @@ func (fs *FileSystem) DownloadFileResumable(irodsPath string, resource string, l
 		fileTransferResult.LocalCheckSum = hash
 
 		if !bytes.Equal(entry.CheckSum, hash) {
-			return fileTransferResult, errors.Errorf("checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+			// re-download corrupted ranges only
+			repairedHash, repairErr := fs.repairDownloadedFile(nil, entry, resource, localFilePath, rangeStatuses, keywords, transferCallback)
+			if repairErr != nil {
+				return fileTransferResult, errors.Wrapf(repairErr, "checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+			}
+
+			fileTransferResult.LocalCheckSum = repairedHash
+
+			if !bytes.Equal(entry.CheckSum, repairedHash) {
+				return fileTransferResult, errors.Errorf("checksum verification failed after repairing corrupted ranges, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(repairedHash))
+			}
 		}
 	}
 
+	// attributes are applied after verification, not to mark a corrupted file as downloaded
+	err = fs.applyDownloadedFileAttributes(entry, localFilePath)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
 	fileTransferResult.EndTime = time.Now()
 
 	return fileTransferResult, nil
@@ -301,6 +340,8 @@ func (fs *FileSystem) DownloadFileResumableWithConnection(conn *connection.IRODS
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.IRODSPath = irodsSrcPath
 	fileTransferResult.StartTime = time.Now()
+	transferStats := &irods_fs.DataObjectTransferStats{}
+	defer fs.reportTransfer(fileTransferResult, transferStats, verifyChecksum)
 
 	entry, err := fs.Stat(irodsSrcPath)
 	if err != nil {
@@ -345,7 +386,7 @@ func (fs *FileSystem) DownloadFileResumableWithConnection(conn *connection.IRODS
 		keywords[common.VERIFY_CHKSUM_KW] = ""
 	}
 
-	err = irods_fs.DownloadDataObjectResumableWithConnection(conn, entry.ToDataObject(), resource, localFilePath, keywords, transferCallback)
+	rangeStatuses, err := irods_fs.DownloadDataObjectResumableWithConnectionRangesAndStats(conn, entry.ToDataObject(), resource, localFilePath, keywords, transferCallback, transferStats)
 	if err != nil {
 		return fileTransferResult, errors.Wrapf(err, "failed to download a data object for path %q", irodsSrcPath)
 	}
@@ -359,7 +400,7 @@ func (fs *FileSystem) DownloadFileResumableWithConnection(conn *connection.IRODS
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
+		_, hash, err := fs.calculateDownloadedFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
 		if err != nil {
 			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
 		}
@@ -368,10 +409,26 @@ func (fs *FileSystem) DownloadFileResumableWithConnection(conn *connection.IRODS
 		fileTransferResult.LocalCheckSum = hash
 
 		if !bytes.Equal(entry.CheckSum, hash) {
-			return fileTransferResult, errors.Errorf("checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+			// re-download corrupted ranges only
+			repairedHash, repairErr := fs.repairDownloadedFile(conn, entry, resource, localFilePath, rangeStatuses, keywords, transferCallback)
+			if repairErr != nil {
+				return fileTransferResult, errors.Wrapf(repairErr, "checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+			}
+
+			fileTransferResult.LocalCheckSum = repairedHash
+
+			if !bytes.Equal(entry.CheckSum, repairedHash) {
+				return fileTransferResult, errors.Errorf("checksum verification failed after repairing corrupted ranges, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(repairedHash))
+			}
 		}
 	}
 
+	// attributes are applied after verification, not to mark a corrupted file as downloaded
+	err = fs.applyDownloadedFileAttributes(entry, localFilePath)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
 	fileTransferResult.EndTime = time.Now()
 
 	return fileTransferResult, nil
@@ -384,6 +441,7 @@ func (fs *FileSystem) DownloadFileToBuffer(irodsPath string, resource string, bu
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.IRODSPath = irodsSrcPath
 	fileTransferResult.StartTime = time.Now()
+	defer fs.reportTransfer(fileTransferResult, nil, verifyChecksum)
 
 	entry, err := fs.Stat(irodsSrcPath)
 	if err != nil {
@@ -444,6 +502,7 @@ func (fs *FileSystem) DownloadFileToBufferWithConnection(conn *connection.IRODSC
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.IRODSPath = irodsSrcPath
 	fileTransferResult.StartTime = time.Now()
+	defer fs.reportTransfer(fileTransferResult, nil, verifyChecksum)
 
 	entry, err := fs.Stat(irodsSrcPath)
 	if err != nil {
@@ -507,6 +566,8 @@ func (fs *FileSystem) DownloadFileParallel(irodsPath string, resource string, lo
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.IRODSPath = irodsSrcPath
 	fileTransferResult.StartTime = time.Now()
+	transferStats := &irods_fs.DataObjectTransferStats{}
+	defer fs.reportTransfer(fileTransferResult, transferStats, verifyChecksum)
 
 	entry, err := fs.Stat(irodsSrcPath)
 	if err != nil {
@@ -551,7 +612,7 @@ func (fs *FileSystem) DownloadFileParallel(irodsPath string, resource string, lo
 		keywords[common.VERIFY_CHKSUM_KW] = ""
 	}
 
-	err = irods_fs.DownloadDataObjectParallel(fs.ioSession, entry.ToDataObject(), resource, localFilePath, taskNum, keywords, transferCallback)
+	err = irods_fs.DownloadDataObjectParallelWithStats(fs.ioSession, entry.ToDataObject(), resource, localFilePath, taskNum, keywords, transferCallback, transferStats)
 	if err != nil {
 		return fileTransferResult, errors.Wrapf(err, "failed to download a data object for path %q", irodsSrcPath)
 	}
@@ -565,7 +626,7 @@ func (fs *FileSystem) DownloadFileParallel(irodsPath string, resource string, lo
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
+		_, hash, err := fs.calculateDownloadedFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
 		if err != nil {
 			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
 		}
@@ -578,6 +639,12 @@ func (fs *FileSystem) DownloadFileParallel(irodsPath string, resource string, lo
 		}
 	}
 
+	// attributes are applied after verification, not to mark a corrupted file as downloaded
+	err = fs.applyDownloadedFileAttributes(entry, localFilePath)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
 	fileTransferResult.EndTime = time.Now()
 
 	return fileTransferResult, nil
@@ -593,6 +660,8 @@ func (fs *FileSystem) DownloadFileParallelWithConnections(conns []*connection.IR
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.IRODSPath = irodsSrcPath
 	fileTransferResult.StartTime = time.Now()
+	transferStats := &irods_fs.DataObjectTransferStats{}
+	defer fs.reportTransfer(fileTransferResult, transferStats, verifyChecksum)
 
 	entry, err := fs.Stat(irodsSrcPath)
 	if err != nil {
@@ -637,7 +706,7 @@ func (fs *FileSystem) DownloadFileParallelWithConnections(conns []*connection.IR
 		keywords[common.VERIFY_CHKSUM_KW] = ""
 	}
 
-	err = irods_fs.DownloadDataObjectParallelWithConnections(conns, entry.ToDataObject(), resource, localFilePath, keywords, transferCallback)
+	err = irods_fs.DownloadDataObjectParallelWithConnectionsAndStats(conns, entry.ToDataObject(), resource, localFilePath, keywords, transferCallback, transferStats)
 	if err != nil {
 		return fileTransferResult, errors.Wrapf(err, "failed to download a data object for path %q", irodsSrcPath)
 	}
@@ -651,7 +720,7 @@ func (fs *FileSystem) DownloadFileParallelWithConnections(conns []*connection.IR
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
+		_, hash, err := fs.calculateDownloadedFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
 		if err != nil {
 			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
 		}
@@ -664,6 +733,12 @@ func (fs *FileSystem) DownloadFileParallelWithConnections(conns []*connection.IR
 		}
 	}
 
+	// attributes are applied after verification, not to mark a corrupted file as downloaded
+	err = fs.applyDownloadedFileAttributes(entry, localFilePath)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
 	fileTransferResult.EndTime = time.Now()
 
 	return fileTransferResult, nil
@@ -679,6 +754,8 @@ func (fs *FileSystem) DownloadFileParallelResumable(irodsPath string, resource s
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.IRODSPath = irodsSrcPath
 	fileTransferResult.StartTime = time.Now()
+	transferStats := &irods_fs.DataObjectTransferStats{}
+	defer fs.reportTransfer(fileTransferResult, transferStats, verifyChecksum)
 
 	entry, err := fs.Stat(irodsSrcPath)
 	if err != nil {
@@ -723,7 +800,7 @@ func (fs *FileSystem) DownloadFileParallelResumable(irodsPath string, resource s
 		keywords[common.VERIFY_CHKSUM_KW] = ""
 	}
 
-	err = irods_fs.DownloadDataObjectParallelResumable(fs.ioSession, entry.ToDataObject(), resource, localFilePath, taskNum, keywords, transferCallback)
+	rangeStatuses, err := irods_fs.DownloadDataObjectParallelResumableWithRangesAndStats(fs.ioSession, entry.ToDataObject(), resource, localFilePath, taskNum, keywords, transferCallback, transferStats)
 	if err != nil {
 		return fileTransferResult, errors.Wrapf(err, "failed to download a data object for path %q", irodsSrcPath)
 	}
@@ -737,7 +814,7 @@ func (fs *FileSystem) DownloadFileParallelResumable(irodsPath string, resource s
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
+		_, hash, err := fs.calculateDownloadedFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
 		if err != nil {
 			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
 		}
@@ -746,10 +823,26 @@ func (fs *FileSystem) DownloadFileParallelResumable(irodsPath string, resource s
 		fileTransferResult.LocalCheckSum = hash
 
 		if !bytes.Equal(entry.CheckSum, hash) {
-			return fileTransferResult, errors.Errorf("checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+			// re-download corrupted ranges only
+			repairedHash, repairErr := fs.repairDownloadedFile(nil, entry, resource, localFilePath, rangeStatuses, keywords, transferCallback)
+			if repairErr != nil {
+				return fileTransferResult, errors.Wrapf(repairErr, "checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+			}
+
+			fileTransferResult.LocalCheckSum = repairedHash
+
+			if !bytes.Equal(entry.CheckSum, repairedHash) {
+				return fileTransferResult, errors.Errorf("checksum verification failed after repairing corrupted ranges, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(repairedHash))
+			}
 		}
 	}
 
+	// attributes are applied after verification, not to mark a corrupted file as downloaded
+	err = fs.applyDownloadedFileAttributes(entry, localFilePath)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
 	fileTransferResult.EndTime = time.Now()
 
 	return fileTransferResult, nil
@@ -765,6 +858,8 @@ func (fs *FileSystem) DownloadFileParallelResumableWithConnections(conns []*conn
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.IRODSPath = irodsSrcPath
 	fileTransferResult.StartTime = time.Now()
+	transferStats := &irods_fs.DataObjectTransferStats{}
+	defer fs.reportTransfer(fileTransferResult, transferStats, verifyChecksum)
 
 	entry, err := fs.Stat(irodsSrcPath)
 	if err != nil {
@@ -809,7 +904,7 @@ func (fs *FileSystem) DownloadFileParallelResumableWithConnections(conns []*conn
 		keywords[common.VERIFY_CHKSUM_KW] = ""
 	}
 
-	err = irods_fs.DownloadDataObjectParallelResumableWithConnections(conns, entry.ToDataObject(), resource, localFilePath, keywords, transferCallback)
+	rangeStatuses, err := irods_fs.DownloadDataObjectParallelResumableWithConnectionsRangesAndStats(conns, entry.ToDataObject(), resource, localFilePath, keywords, transferCallback, transferStats)
 	if err != nil {
 		return fileTransferResult, errors.Wrapf(err, "failed to download a data object for path %q", irodsSrcPath)
 	}
@@ -823,7 +918,7 @@ func (fs *FileSystem) DownloadFileParallelResumableWithConnections(conns []*conn
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
+		_, hash, err := fs.calculateDownloadedFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
 		if err != nil {
 			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
 		}
@@ -832,10 +927,26 @@ func (fs *FileSystem) DownloadFileParallelResumableWithConnections(conns []*conn
 		fileTransferResult.LocalCheckSum = hash
 
 		if !bytes.Equal(entry.CheckSum, hash) {
-			return fileTransferResult, errors.Errorf("checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+			// re-download corrupted ranges only
+			repairedHash, repairErr := fs.repairDownloadedFile(conns[0], entry, resource, localFilePath, rangeStatuses, keywords, transferCallback)
+			if repairErr != nil {
+				return fileTransferResult, errors.Wrapf(repairErr, "checksum verification failed, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
+			}
+
+			fileTransferResult.LocalCheckSum = repairedHash
+
+			if !bytes.Equal(entry.CheckSum, repairedHash) {
+				return fileTransferResult, errors.Errorf("checksum verification failed after repairing corrupted ranges, download failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(repairedHash))
+			}
 		}
 	}
 
+	// attributes are applied after verification, not to mark a corrupted file as downloaded
+	err = fs.applyDownloadedFileAttributes(entry, localFilePath)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
 	fileTransferResult.EndTime = time.Now()
 
 	return fileTransferResult, nil
@@ -851,6 +962,8 @@ func (fs *FileSystem) DownloadFileRedirectToResource(irodsPath string, resource
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.IRODSPath = irodsSrcPath
 	fileTransferResult.StartTime = time.Now()
+	// number of tasks is decided by the server
+	defer fs.reportTransfer(fileTransferResult, &irods_fs.DataObjectTransferStats{}, verifyChecksum)
 
 	entry, err := fs.Stat(irodsSrcPath)
 	if err != nil {
@@ -902,7 +1015,7 @@ func (fs *FileSystem) DownloadFileRedirectToResource(irodsPath string, resource
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
+		_, hash, err := fs.calculateDownloadedFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
 		if err != nil {
 			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
 		}
@@ -915,6 +1028,12 @@ func (fs *FileSystem) DownloadFileRedirectToResource(irodsPath string, resource
 		}
 	}
 
+	// attributes are applied after verification, not to mark a corrupted file as downloaded
+	err = fs.applyDownloadedFileAttributes(entry, localFilePath)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
 	fileTransferResult.EndTime = time.Now()
 
 	return fileTransferResult, nil
@@ -930,6 +1049,8 @@ func (fs *FileSystem) DownloadFileRedirectToResourceWithConnection(controlConn *
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.IRODSPath = irodsSrcPath
 	fileTransferResult.StartTime = time.Now()
+	// number of tasks is decided by the server
+	defer fs.reportTransfer(fileTransferResult, &irods_fs.DataObjectTransferStats{}, verifyChecksum)
 
 	entry, err := fs.Stat(irodsSrcPath)
 	if err != nil {
@@ -981,7 +1102,7 @@ func (fs *FileSystem) DownloadFileRedirectToResourceWithConnection(controlConn *
 
 	if verifyChecksum {
 		// verify checksum
-		_, hash, err := fs.calculateLocalFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
+		_, hash, err := fs.calculateDownloadedFileHash(localFilePath, entry.CheckSumAlgorithm, transferCallback)
 		if err != nil {
 			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localFilePath)
 		}
@@ -994,6 +1115,12 @@ func (fs *FileSystem) DownloadFileRedirectToResourceWithConnection(controlConn *
 		}
 	}
 
+	// attributes are applied after verification, not to mark a corrupted file as downloaded
+	err = fs.applyDownloadedFileAttributes(entry, localFilePath)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
 	fileTransferResult.EndTime = time.Now()
 
 	return fileTransferResult, nil
@@ -1001,14 +1128,28 @@ func (fs *FileSystem) DownloadFileRedirectToResourceWithConnection(controlConn *
 
 // UploadFile uploads a local file to irods
 func (fs *FileSystem) UploadFile(localPath string, irodsPath string, resource string, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+	startTime := time.Now()
+	result, err := fs.uploadFile(localPath, irodsPath, resource, replicate, verifyChecksum, transferCallback)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditUploadFile,
+		Path:      irodsPath,
+		Target:    resource,
+		Bytes:     getTransferResultSize(result),
+	})
+	return result, err
+}
+
+func (fs *FileSystem) uploadFile(localPath string, irodsPath string, resource string, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
 	localSrcPath := util.GetCorrectLocalPath(localPath)
 	irodsDestPath := util.GetCorrectIRODSPath(irodsPath)
+	targetResource := fs.getTargetResource(resource)
 
 	irodsFilePath := irodsDestPath
 
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.LocalPath = localSrcPath
 	fileTransferResult.StartTime = time.Now()
+	defer fs.reportTransfer(fileTransferResult, nil, verifyChecksum)
 
 	stat, err := os.Stat(localSrcPath)
 	if err != nil {
@@ -1025,6 +1166,12 @@ func (fs *FileSystem) UploadFile(localPath string, irodsPath string, resource st
 		return fileTransferResult, errors.Wrapf(newErr, "failed to find a file for local path %q, the path is for a directory", localSrcPath)
 	}
 
+	unlockPaths, err := fs.lockPathsForWrite(fs.getUploadTargetPath(localSrcPath, irodsDestPath))
+	if err != nil {
+		return fileTransferResult, err
+	}
+	defer unlockPaths()
+
 	entry, err := fs.Stat(irodsDestPath)
 	if err != nil {
 		if !types.IsFileNotFoundError(err) {
@@ -1049,36 +1196,35 @@ func (fs *FileSystem) UploadFile(localPath string, irodsPath string, resource st
 	fileTransferResult.IRODSPath = irodsFilePath
 
 	keywords := map[common.KeyWord]string{}
+	var hashAlg hash.Hash
 	if verifyChecksum {
 		keywords[common.REG_CHKSUM_KW] = ""
 
-		// verify checksum
+		// the local file is hashed while it is uploaded, and the hash is compared with the checksum registered by the server
 		alg := types.ChecksumAlgorithmUnknown
 		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown {
 			alg = entry.CheckSumAlgorithm
 		}
 
-		checksumAlgorithm, hashBytes, err := fs.calculateLocalFileHash(localSrcPath, alg, transferCallback)
+		checksumAlgorithm, newHashAlg, err := fs.newLocalFileHash(alg)
 		if err != nil {
-			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localSrcPath)
-		}
-
-		hashString, err := types.MakeIRODSChecksumString(checksumAlgorithm, hashBytes)
-		if err != nil {
-			return fileTransferResult, errors.Wrapf(err, "failed to get irods checksum string from algorithm %q", checksumAlgorithm)
+			return fileTransferResult, err
 		}
 
 		fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
-		fileTransferResult.LocalCheckSum = hashBytes
-
-		keywords[common.VERIFY_CHKSUM_KW] = hashString
+		hashAlg = newHashAlg
 	}
 
-	err = irods_fs.UploadDataObject(fs.ioSession, localSrcPath, irodsFilePath, resource, replicate, keywords, transferCallback)
+	err = irods_fs.UploadDataObjectWithHash(fs.ioSession, localSrcPath, irodsFilePath, targetResource, replicate, keywords, hashAlg, transferCallback)
 	if err != nil {
 		return fileTransferResult, err
 	}
 
+	if hashAlg != nil {
+		fileTransferResult.LocalCheckSum = hashAlg.Sum(nil)
+		fs.putLocalFileHashToCache(localSrcPath, stat, fileTransferResult.LocalCheckSumAlgorithm, fileTransferResult.LocalCheckSum)
+	}
+
 	if entry == nil {
 		// create
 		fs.InvalidateCacheForFileCreate(irodsFilePath)
@@ -1089,6 +1235,11 @@ func (fs *FileSystem) UploadFile(localPath string, irodsPath string, resource st
 		fs.cachePropagation.PropagateFileUpdate(irodsFilePath)
 	}
 
+	err = fs.applyUploadedFileAttributes(localSrcPath, irodsFilePath, stat)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
 	entry, err = fs.Stat(irodsFilePath)
 	if err != nil {
 		return fileTransferResult, err
@@ -1108,10 +1259,11 @@ func (fs *FileSystem) UploadFile(localPath string, irodsPath string, resource st
 
 			fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
 			fileTransferResult.LocalCheckSum = hash
+		}
 
-			if !bytes.Equal(entry.CheckSum, hash) {
-				return fileTransferResult, errors.Errorf("checksum verification failed, upload failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
-			}
+		err = fs.verifyUploadedChecksum(irodsFilePath, entry, fileTransferResult.LocalCheckSum)
+		if err != nil {
+			return fileTransferResult, err
 		}
 	}
 
@@ -1122,14 +1274,28 @@ func (fs *FileSystem) UploadFile(localPath string, irodsPath string, resource st
 
 // UploadFileWithConnection uploads a local file to irods
 func (fs *FileSystem) UploadFileWithConnection(conn *connection.IRODSConnection, localPath string, irodsPath string, resource string, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+	startTime := time.Now()
+	result, err := fs.uploadFileWithConnection(conn, localPath, irodsPath, resource, replicate, verifyChecksum, transferCallback)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditUploadFile,
+		Path:      irodsPath,
+		Target:    resource,
+		Bytes:     getTransferResultSize(result),
+	})
+	return result, err
+}
+
+func (fs *FileSystem) uploadFileWithConnection(conn *connection.IRODSConnection, localPath string, irodsPath string, resource string, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
 	localSrcPath := util.GetCorrectLocalPath(localPath)
 	irodsDestPath := util.GetCorrectIRODSPath(irodsPath)
+	targetResource := fs.getTargetResource(resource)
 
 	irodsFilePath := irodsDestPath
 
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.LocalPath = localSrcPath
 	fileTransferResult.StartTime = time.Now()
+	defer fs.reportTransfer(fileTransferResult, nil, verifyChecksum)
 
 	stat, err := os.Stat(localSrcPath)
 	if err != nil {
@@ -1146,6 +1312,12 @@ func (fs *FileSystem) UploadFileWithConnection(conn *connection.IRODSConnection,
 		return fileTransferResult, errors.Wrapf(newErr, "failed to find a file for local path %q, the path is for a directory", localSrcPath)
 	}
 
+	unlockPaths, err := fs.lockPathsForWrite(fs.getUploadTargetPath(localSrcPath, irodsDestPath))
+	if err != nil {
+		return fileTransferResult, err
+	}
+	defer unlockPaths()
+
 	entry, err := fs.Stat(irodsDestPath)
 	if err != nil {
 		if !types.IsFileNotFoundError(err) {
@@ -1170,36 +1342,35 @@ func (fs *FileSystem) UploadFileWithConnection(conn *connection.IRODSConnection,
 	fileTransferResult.IRODSPath = irodsFilePath
 
 	keywords := map[common.KeyWord]string{}
+	var hashAlg hash.Hash
 	if verifyChecksum {
 		keywords[common.REG_CHKSUM_KW] = ""
 
-		// verify checksum
+		// the local file is hashed while it is uploaded, and the hash is compared with the checksum registered by the server
 		alg := types.ChecksumAlgorithmUnknown
 		if entry != nil && entry.CheckSumAlgorithm != types.ChecksumAlgorithmUnknown {
 			alg = entry.CheckSumAlgorithm
 		}
 
-		checksumAlgorithm, hashBytes, err := fs.calculateLocalFileHash(localSrcPath, alg, transferCallback)
+		checksumAlgorithm, newHashAlg, err := fs.newLocalFileHash(alg)
 		if err != nil {
-			return fileTransferResult, errors.Wrapf(err, "failed to get hash of %q", localSrcPath)
-		}
-
-		hashString, err := types.MakeIRODSChecksumString(checksumAlgorithm, hashBytes)
-		if err != nil {
-			return fileTransferResult, errors.Wrapf(err, "failed to get irods checksum string from algorithm %q", checksumAlgorithm)
+			return fileTransferResult, err
 		}
 
 		fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
-		fileTransferResult.LocalCheckSum = hashBytes
-
-		keywords[common.VERIFY_CHKSUM_KW] = hashString
+		hashAlg = newHashAlg
 	}
 
-	err = irods_fs.UploadDataObjectWithConnection(conn, localSrcPath, irodsFilePath, resource, replicate, keywords, transferCallback)
+	err = irods_fs.UploadDataObjectWithConnectionAndHash(conn, localSrcPath, irodsFilePath, targetResource, replicate, keywords, hashAlg, transferCallback)
 	if err != nil {
 		return fileTransferResult, err
 	}
 
+	if hashAlg != nil {
+		fileTransferResult.LocalCheckSum = hashAlg.Sum(nil)
+		fs.putLocalFileHashToCache(localSrcPath, stat, fileTransferResult.LocalCheckSumAlgorithm, fileTransferResult.LocalCheckSum)
+	}
+
 	if entry == nil {
 		// create
 		fs.InvalidateCacheForFileCreate(irodsFilePath)
@@ -1210,6 +1381,11 @@ func (fs *FileSystem) UploadFileWithConnection(conn *connection.IRODSConnection,
 		fs.cachePropagation.PropagateFileUpdate(irodsFilePath)
 	}
 
+	err = fs.applyUploadedFileAttributes(localSrcPath, irodsFilePath, stat)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
 	entry, err = fs.Stat(irodsFilePath)
 	if err != nil {
 		return fileTransferResult, err
@@ -1229,10 +1405,11 @@ func (fs *FileSystem) UploadFileWithConnection(conn *connection.IRODSConnection,
 
 			fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
 			fileTransferResult.LocalCheckSum = hash
+		}
 
-			if !bytes.Equal(entry.CheckSum, hash) {
-				return fileTransferResult, errors.Errorf("checksum verification failed, upload failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
-			}
+		err = fs.verifyUploadedChecksum(irodsFilePath, entry, fileTransferResult.LocalCheckSum)
+		if err != nil {
+			return fileTransferResult, err
 		}
 	}
 
@@ -1243,12 +1420,32 @@ func (fs *FileSystem) UploadFileWithConnection(conn *connection.IRODSConnection,
 
 // UploadFileFromBuffer uploads buffer data to irods
 func (fs *FileSystem) UploadFileFromBuffer(buffer *bytes.Buffer, irodsPath string, resource string, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+	startTime := time.Now()
+	result, err := fs.uploadFileFromBuffer(buffer, irodsPath, resource, replicate, verifyChecksum, transferCallback)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditUploadFile,
+		Path:      irodsPath,
+		Target:    resource,
+		Bytes:     getTransferResultSize(result),
+	})
+	return result, err
+}
+
+func (fs *FileSystem) uploadFileFromBuffer(buffer *bytes.Buffer, irodsPath string, resource string, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
 	irodsDestPath := util.GetCorrectIRODSPath(irodsPath)
+	targetResource := fs.getTargetResource(resource)
 
 	irodsFilePath := irodsDestPath
 
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.StartTime = time.Now()
+	defer fs.reportTransfer(fileTransferResult, nil, verifyChecksum)
+
+	unlockPaths, err := fs.lockPathsForWrite(irodsDestPath)
+	if err != nil {
+		return fileTransferResult, err
+	}
+	defer unlockPaths()
 
 	entry, err := fs.Stat(irodsDestPath)
 	if err != nil {
@@ -1298,7 +1495,7 @@ func (fs *FileSystem) UploadFileFromBuffer(buffer *bytes.Buffer, irodsPath strin
 		keywords[common.VERIFY_CHKSUM_KW] = hashString
 	}
 
-	err = irods_fs.UploadDataObjectFromBuffer(fs.ioSession, buffer, irodsFilePath, resource, replicate, keywords, transferCallback)
+	err = irods_fs.UploadDataObjectFromBuffer(fs.ioSession, buffer, irodsFilePath, targetResource, replicate, keywords, transferCallback)
 	if err != nil {
 		return fileTransferResult, err
 	}
@@ -1332,10 +1529,11 @@ func (fs *FileSystem) UploadFileFromBuffer(buffer *bytes.Buffer, irodsPath strin
 
 			fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
 			fileTransferResult.LocalCheckSum = hash
+		}
 
-			if !bytes.Equal(entry.CheckSum, hash) {
-				return fileTransferResult, errors.Errorf("checksum verification failed, upload failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
-			}
+		err = fs.verifyUploadedChecksum(irodsFilePath, entry, fileTransferResult.LocalCheckSum)
+		if err != nil {
+			return fileTransferResult, err
 		}
 	}
 
@@ -1346,12 +1544,32 @@ func (fs *FileSystem) UploadFileFromBuffer(buffer *bytes.Buffer, irodsPath strin
 
 // UploadFileFromBufferWithConnection uploads buffer data to irods
 func (fs *FileSystem) UploadFileFromBufferWithConnection(conn *connection.IRODSConnection, buffer *bytes.Buffer, irodsPath string, resource string, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+	startTime := time.Now()
+	result, err := fs.uploadFileFromBufferWithConnection(conn, buffer, irodsPath, resource, replicate, verifyChecksum, transferCallback)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditUploadFile,
+		Path:      irodsPath,
+		Target:    resource,
+		Bytes:     getTransferResultSize(result),
+	})
+	return result, err
+}
+
+func (fs *FileSystem) uploadFileFromBufferWithConnection(conn *connection.IRODSConnection, buffer *bytes.Buffer, irodsPath string, resource string, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
 	irodsDestPath := util.GetCorrectIRODSPath(irodsPath)
+	targetResource := fs.getTargetResource(resource)
 
 	irodsFilePath := irodsDestPath
 
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.StartTime = time.Now()
+	defer fs.reportTransfer(fileTransferResult, nil, verifyChecksum)
+
+	unlockPaths, err := fs.lockPathsForWrite(irodsDestPath)
+	if err != nil {
+		return fileTransferResult, err
+	}
+	defer unlockPaths()
 
 	entry, err := fs.Stat(irodsDestPath)
 	if err != nil {
@@ -1401,7 +1619,7 @@ func (fs *FileSystem) UploadFileFromBufferWithConnection(conn *connection.IRODSC
 		keywords[common.VERIFY_CHKSUM_KW] = hashString
 	}
 
-	err = irods_fs.UploadDataObjectFromBufferWithConnection(conn, buffer, irodsFilePath, resource, replicate, keywords, transferCallback)
+	err = irods_fs.UploadDataObjectFromBufferWithConnection(conn, buffer, irodsFilePath, targetResource, replicate, keywords, transferCallback)
 	if err != nil {
 		return fileTransferResult, err
 	}
@@ -1435,10 +1653,11 @@ func (fs *FileSystem) UploadFileFromBufferWithConnection(conn *connection.IRODSC
 
 			fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
 			fileTransferResult.LocalCheckSum = hash
+		}
 
-			if !bytes.Equal(entry.CheckSum, hash) {
-				return fileTransferResult, errors.Errorf("checksum verification failed, upload failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
-			}
+		err = fs.verifyUploadedChecksum(irodsFilePath, entry, fileTransferResult.LocalCheckSum)
+		if err != nil {
+			return fileTransferResult, err
 		}
 	}
 
@@ -1449,14 +1668,29 @@ func (fs *FileSystem) UploadFileFromBufferWithConnection(conn *connection.IRODSC
 
 // UploadFileParallel uploads a local file to irods in parallel
 func (fs *FileSystem) UploadFileParallel(localPath string, irodsPath string, resource string, taskNum int, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+	startTime := time.Now()
+	result, err := fs.uploadFileParallel(localPath, irodsPath, resource, taskNum, replicate, verifyChecksum, transferCallback)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditUploadFile,
+		Path:      irodsPath,
+		Target:    resource,
+		Bytes:     getTransferResultSize(result),
+	})
+	return result, err
+}
+
+func (fs *FileSystem) uploadFileParallel(localPath string, irodsPath string, resource string, taskNum int, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
 	localSrcPath := util.GetCorrectLocalPath(localPath)
 	irodsDestPath := util.GetCorrectIRODSPath(irodsPath)
+	targetResource := fs.getTargetResource(resource)
 
 	irodsFilePath := irodsDestPath
 
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.LocalPath = localSrcPath
 	fileTransferResult.StartTime = time.Now()
+	transferStats := &irods_fs.DataObjectTransferStats{}
+	defer fs.reportTransfer(fileTransferResult, transferStats, verifyChecksum)
 
 	stat, err := os.Stat(localSrcPath)
 	if err != nil {
@@ -1473,6 +1707,12 @@ func (fs *FileSystem) UploadFileParallel(localPath string, irodsPath string, res
 		return fileTransferResult, errors.Wrapf(newErr, "failed to find a file for local path %q, the path is for a directory", localSrcPath)
 	}
 
+	unlockPaths, err := fs.lockPathsForWrite(fs.getUploadTargetPath(localSrcPath, irodsDestPath))
+	if err != nil {
+		return fileTransferResult, err
+	}
+	defer unlockPaths()
+
 	entry, err := fs.Stat(irodsDestPath)
 	if err != nil {
 		if !types.IsFileNotFoundError(err) {
@@ -1522,7 +1762,7 @@ func (fs *FileSystem) UploadFileParallel(localPath string, irodsPath string, res
 		keywords[common.VERIFY_CHKSUM_KW] = hashString
 	}
 
-	err = irods_fs.UploadDataObjectParallel(fs.ioSession, localSrcPath, irodsFilePath, resource, taskNum, replicate, keywords, transferCallback)
+	err = irods_fs.UploadDataObjectParallelWithStats(fs.ioSession, localSrcPath, irodsFilePath, targetResource, taskNum, replicate, keywords, transferCallback, transferStats)
 	if err != nil {
 		return fileTransferResult, err
 	}
@@ -1537,6 +1777,11 @@ func (fs *FileSystem) UploadFileParallel(localPath string, irodsPath string, res
 		fs.cachePropagation.PropagateFileUpdate(irodsFilePath)
 	}
 
+	err = fs.applyUploadedFileAttributes(localSrcPath, irodsFilePath, stat)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
 	entry, err = fs.Stat(irodsFilePath)
 	if err != nil {
 		return fileTransferResult, err
@@ -1556,10 +1801,11 @@ func (fs *FileSystem) UploadFileParallel(localPath string, irodsPath string, res
 
 			fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
 			fileTransferResult.LocalCheckSum = hash
+		}
 
-			if !bytes.Equal(entry.CheckSum, hash) {
-				return fileTransferResult, errors.Errorf("checksum verification failed, upload failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
-			}
+		err = fs.verifyUploadedChecksum(irodsFilePath, entry, fileTransferResult.LocalCheckSum)
+		if err != nil {
+			return fileTransferResult, err
 		}
 	}
 
@@ -1570,14 +1816,29 @@ func (fs *FileSystem) UploadFileParallel(localPath string, irodsPath string, res
 
 // UploadFileParallelWithConnections uploads a local file to irods in parallel
 func (fs *FileSystem) UploadFileParallelWithConnections(conns []*connection.IRODSConnection, localPath string, irodsPath string, resource string, taskNum int, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+	startTime := time.Now()
+	result, err := fs.uploadFileParallelWithConnections(conns, localPath, irodsPath, resource, taskNum, replicate, verifyChecksum, transferCallback)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditUploadFile,
+		Path:      irodsPath,
+		Target:    resource,
+		Bytes:     getTransferResultSize(result),
+	})
+	return result, err
+}
+
+func (fs *FileSystem) uploadFileParallelWithConnections(conns []*connection.IRODSConnection, localPath string, irodsPath string, resource string, taskNum int, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
 	localSrcPath := util.GetCorrectLocalPath(localPath)
 	irodsDestPath := util.GetCorrectIRODSPath(irodsPath)
+	targetResource := fs.getTargetResource(resource)
 
 	irodsFilePath := irodsDestPath
 
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.LocalPath = localSrcPath
 	fileTransferResult.StartTime = time.Now()
+	transferStats := &irods_fs.DataObjectTransferStats{}
+	defer fs.reportTransfer(fileTransferResult, transferStats, verifyChecksum)
 
 	stat, err := os.Stat(localSrcPath)
 	if err != nil {
@@ -1594,6 +1855,12 @@ func (fs *FileSystem) UploadFileParallelWithConnections(conns []*connection.IROD
 		return fileTransferResult, errors.Wrapf(newErr, "failed to find a file for local path %q, the path is for a directory", localSrcPath)
 	}
 
+	unlockPaths, err := fs.lockPathsForWrite(fs.getUploadTargetPath(localSrcPath, irodsDestPath))
+	if err != nil {
+		return fileTransferResult, err
+	}
+	defer unlockPaths()
+
 	entry, err := fs.Stat(irodsDestPath)
 	if err != nil {
 		if !types.IsFileNotFoundError(err) {
@@ -1643,7 +1910,7 @@ func (fs *FileSystem) UploadFileParallelWithConnections(conns []*connection.IROD
 		keywords[common.VERIFY_CHKSUM_KW] = hashString
 	}
 
-	err = irods_fs.UploadDataObjectParallelWithConnections(conns, localSrcPath, irodsFilePath, resource, replicate, keywords, transferCallback)
+	err = irods_fs.UploadDataObjectParallelWithConnectionsAndStats(conns, localSrcPath, irodsFilePath, targetResource, replicate, keywords, transferCallback, transferStats)
 	if err != nil {
 		return fileTransferResult, err
 	}
@@ -1658,6 +1925,11 @@ func (fs *FileSystem) UploadFileParallelWithConnections(conns []*connection.IROD
 		fs.cachePropagation.PropagateFileUpdate(irodsFilePath)
 	}
 
+	err = fs.applyUploadedFileAttributes(localSrcPath, irodsFilePath, stat)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
 	entry, err = fs.Stat(irodsFilePath)
 	if err != nil {
 		return fileTransferResult, err
@@ -1677,10 +1949,11 @@ func (fs *FileSystem) UploadFileParallelWithConnections(conns []*connection.IROD
 
 			fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
 			fileTransferResult.LocalCheckSum = hash
+		}
 
-			if !bytes.Equal(entry.CheckSum, hash) {
-				return fileTransferResult, errors.Errorf("checksum verification failed, upload failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
-			}
+		err = fs.verifyUploadedChecksum(irodsFilePath, entry, fileTransferResult.LocalCheckSum)
+		if err != nil {
+			return fileTransferResult, err
 		}
 	}
 
@@ -1691,6 +1964,18 @@ func (fs *FileSystem) UploadFileParallelWithConnections(conns []*connection.IROD
 
 // UploadFileRedirectToResource uploads a file from local to resource server in parallel
 func (fs *FileSystem) UploadFileRedirectToResource(localPath string, irodsPath string, resource string, taskNum int, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+	startTime := time.Now()
+	result, err := fs.uploadFileRedirectToResource(localPath, irodsPath, resource, taskNum, replicate, verifyChecksum, transferCallback)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditUploadFile,
+		Path:      irodsPath,
+		Target:    resource,
+		Bytes:     getTransferResultSize(result),
+	})
+	return result, err
+}
+
+func (fs *FileSystem) uploadFileRedirectToResource(localPath string, irodsPath string, resource string, taskNum int, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
 	localSrcPath := util.GetCorrectLocalPath(localPath)
 	irodsDestPath := util.GetCorrectIRODSPath(irodsPath)
 
@@ -1699,6 +1984,8 @@ func (fs *FileSystem) UploadFileRedirectToResource(localPath string, irodsPath s
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.LocalPath = localSrcPath
 	fileTransferResult.StartTime = time.Now()
+	// number of tasks is decided by the server
+	defer fs.reportTransfer(fileTransferResult, &irods_fs.DataObjectTransferStats{}, verifyChecksum)
 
 	stat, err := os.Stat(localSrcPath)
 	if err != nil {
@@ -1715,6 +2002,12 @@ func (fs *FileSystem) UploadFileRedirectToResource(localPath string, irodsPath s
 		return fileTransferResult, errors.Wrapf(newErr, "failed to find a file for local path %q, the path is for a directory", localSrcPath)
 	}
 
+	unlockPaths, err := fs.lockPathsForWrite(fs.getUploadTargetPath(localSrcPath, irodsDestPath))
+	if err != nil {
+		return fileTransferResult, err
+	}
+	defer unlockPaths()
+
 	entry, err := fs.Stat(irodsDestPath)
 	if err != nil {
 		if !types.IsFileNotFoundError(err) {
@@ -1779,6 +2072,11 @@ func (fs *FileSystem) UploadFileRedirectToResource(localPath string, irodsPath s
 		fs.cachePropagation.PropagateFileUpdate(irodsFilePath)
 	}
 
+	err = fs.applyUploadedFileAttributes(localSrcPath, irodsFilePath, stat)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
 	entry, err = fs.Stat(irodsFilePath)
 	if err != nil {
 		return fileTransferResult, err
@@ -1798,10 +2096,11 @@ func (fs *FileSystem) UploadFileRedirectToResource(localPath string, irodsPath s
 
 			fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
 			fileTransferResult.LocalCheckSum = hash
+		}
 
-			if !bytes.Equal(entry.CheckSum, hash) {
-				return fileTransferResult, errors.Errorf("checksum verification failed, upload failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
-			}
+		err = fs.verifyUploadedChecksum(irodsFilePath, entry, fileTransferResult.LocalCheckSum)
+		if err != nil {
+			return fileTransferResult, err
 		}
 	}
 
@@ -1812,6 +2111,18 @@ func (fs *FileSystem) UploadFileRedirectToResource(localPath string, irodsPath s
 
 // UploadFileRedirectToResourceWithConnection uploads a file from local to resource server in parallel
 func (fs *FileSystem) UploadFileRedirectToResourceWithConnection(controlConn *connection.IRODSConnection, localPath string, irodsPath string, resource string, taskNum int, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
+	startTime := time.Now()
+	result, err := fs.uploadFileRedirectToResourceWithConnection(controlConn, localPath, irodsPath, resource, taskNum, replicate, verifyChecksum, transferCallback)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditUploadFile,
+		Path:      irodsPath,
+		Target:    resource,
+		Bytes:     getTransferResultSize(result),
+	})
+	return result, err
+}
+
+func (fs *FileSystem) uploadFileRedirectToResourceWithConnection(controlConn *connection.IRODSConnection, localPath string, irodsPath string, resource string, taskNum int, replicate bool, verifyChecksum bool, transferCallback common.TransferTrackerCallback) (*FileTransferResult, error) {
 	localSrcPath := util.GetCorrectLocalPath(localPath)
 	irodsDestPath := util.GetCorrectIRODSPath(irodsPath)
 
@@ -1820,6 +2131,8 @@ func (fs *FileSystem) UploadFileRedirectToResourceWithConnection(controlConn *co
 	fileTransferResult := &FileTransferResult{}
 	fileTransferResult.LocalPath = localSrcPath
 	fileTransferResult.StartTime = time.Now()
+	// number of tasks is decided by the server
+	defer fs.reportTransfer(fileTransferResult, &irods_fs.DataObjectTransferStats{}, verifyChecksum)
 
 	stat, err := os.Stat(localSrcPath)
 	if err != nil {
@@ -1836,6 +2149,12 @@ func (fs *FileSystem) UploadFileRedirectToResourceWithConnection(controlConn *co
 		return fileTransferResult, errors.Wrapf(newErr, "failed to find a file for local path %q, the path is for a directory", localSrcPath)
 	}
 
+	unlockPaths, err := fs.lockPathsForWrite(fs.getUploadTargetPath(localSrcPath, irodsDestPath))
+	if err != nil {
+		return fileTransferResult, err
+	}
+	defer unlockPaths()
+
 	entry, err := fs.Stat(irodsDestPath)
 	if err != nil {
 		if !types.IsFileNotFoundError(err) {
@@ -1900,6 +2219,11 @@ func (fs *FileSystem) UploadFileRedirectToResourceWithConnection(controlConn *co
 		fs.cachePropagation.PropagateFileUpdate(irodsFilePath)
 	}
 
+	err = fs.applyUploadedFileAttributes(localSrcPath, irodsFilePath, stat)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
 	entry, err = fs.Stat(irodsFilePath)
 	if err != nil {
 		return fileTransferResult, err
@@ -1919,10 +2243,11 @@ func (fs *FileSystem) UploadFileRedirectToResourceWithConnection(controlConn *co
 
 			fileTransferResult.LocalCheckSumAlgorithm = entry.CheckSumAlgorithm
 			fileTransferResult.LocalCheckSum = hash
+		}
 
-			if !bytes.Equal(entry.CheckSum, hash) {
-				return fileTransferResult, errors.Errorf("checksum verification failed, upload failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(hash))
-			}
+		err = fs.verifyUploadedChecksum(irodsFilePath, entry, fileTransferResult.LocalCheckSum)
+		if err != nil {
+			return fileTransferResult, err
 		}
 	}
 
@@ -1931,14 +2256,60 @@ func (fs *FileSystem) UploadFileRedirectToResourceWithConnection(controlConn *co
 	return fileTransferResult, nil
 }
 
+// repairDownloadedFile re-downloads corrupted ranges of the downloaded local file and returns the hash of the repaired file
+// the connection is used if given, otherwise a connection of io session is used
+func (fs *FileSystem) repairDownloadedFile(conn *connection.IRODSConnection, entry *Entry, resource string, localPath string, rangeStatuses []*irods_fs.DataObjectTransferStatusEntry, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) ([]byte, error) {
+	var repairedRanges []*irods_fs.DataObjectTransferStatusEntry
+	var err error
+	if conn != nil {
+		repairedRanges, err = irods_fs.RepairDataObjectRangesWithConnection(conn, entry.ToDataObject(), resource, localPath, rangeStatuses, keywords, transferCallback)
+	} else {
+		repairedRanges, err = irods_fs.RepairDataObjectRanges(fs.ioSession, entry.ToDataObject(), resource, localPath, rangeStatuses, keywords, transferCallback)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to repair corrupted ranges of %q", localPath)
+	}
+
+	if len(repairedRanges) == 0 {
+		return nil, errors.Errorf("failed to find corrupted ranges of %q, the data object may not match its checksum", localPath)
+	}
+
+	log.Debugf("repaired %d corrupted ranges of %q", len(repairedRanges), localPath)
+
+	_, hash, err := fs.calculateDownloadedFileHash(localPath, entry.CheckSumAlgorithm, transferCallback)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get hash of %q", localPath)
+	}
+
+	return hash, nil
+}
+
+// GetLocalFileHash returns hash of a local file, the default checksum algorithm of the server is used if algorithm is unknown
+// the local checksum cache is used if it is set in the config
+func (fs *FileSystem) GetLocalFileHash(localPath string, algorithm types.ChecksumAlgorithm, processCallback common.TransferTrackerCallback) (types.ChecksumAlgorithm, []byte, error) {
+	localPath = util.GetCorrectLocalPath(localPath)
+	return fs.calculateLocalFileHash(localPath, algorithm, processCallback)
+}
+
 // calculateLocalFileHash calculates local file hash
 func (fs *FileSystem) calculateLocalFileHash(localPath string, algorithm types.ChecksumAlgorithm, processCallback common.TransferTrackerCallback) (types.ChecksumAlgorithm, []byte, error) {
 	if algorithm == types.ChecksumAlgorithmUnknown {
-		algorithm = types.GetChecksumAlgorithm(fs.account.DefaultHashScheme)
+		algorithm = fs.GetDefaultChecksumAlgorithm()
 	}
 
-	if algorithm == types.ChecksumAlgorithmUnknown {
-		algorithm = defaultChecksumAlgorithm
+	checksumCache := fs.getLocalChecksumCache()
+
+	var stat os.FileInfo
+	if checksumCache != nil {
+		var err error
+		stat, err = os.Stat(localPath)
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "failed to stat file %q", localPath)
+		}
+
+		if hashBytes, ok := checksumCache.Get(localPath, stat.Size(), stat.ModTime(), algorithm); ok {
+			return algorithm, hashBytes, nil
+		}
 	}
 
 	hashCallback := func(name string, current int64, total int64) {
@@ -1953,17 +2324,91 @@ func (fs *FileSystem) calculateLocalFileHash(localPath string, algorithm types.C
 		return "", nil, errors.Wrapf(err, "failed to get %q hash of %q", algorithm, localPath)
 	}
 
+	if checksumCache != nil {
+		fs.putLocalFileHashToCache(localPath, stat, algorithm, hashBytes)
+	}
+
 	return algorithm, hashBytes, nil
 }
 
-// calculateBufferHash calculates buffer hash
-func (fs *FileSystem) calculateBufferHash(buffer *bytes.Buffer, algorithm types.ChecksumAlgorithm, processCallback common.TransferTrackerCallback) (types.ChecksumAlgorithm, []byte, error) {
+// calculateDownloadedFileHash calculates hash of a downloaded file from the bytes on disk
+// a cached hash is not used as the file may be written with the same size and modify time
+func (fs *FileSystem) calculateDownloadedFileHash(localPath string, algorithm types.ChecksumAlgorithm, processCallback common.TransferTrackerCallback) (types.ChecksumAlgorithm, []byte, error) {
+	checksumCache := fs.getLocalChecksumCache()
+	if checksumCache != nil {
+		checksumCache.Remove(localPath)
+	}
+
+	return fs.calculateLocalFileHash(localPath, algorithm, processCallback)
+}
+
+func (fs *FileSystem) getLocalChecksumCache() *LocalChecksumCache {
+	if fs.config == nil {
+		return nil
+	}
+
+	return fs.config.LocalChecksumCache
+}
+
+// putLocalFileHashToCache caches the hash of the local file if the file is not changed since stat was taken
+func (fs *FileSystem) putLocalFileHashToCache(localPath string, stat os.FileInfo, algorithm types.ChecksumAlgorithm, hashBytes []byte) {
+	checksumCache := fs.getLocalChecksumCache()
+	if checksumCache == nil || stat == nil {
+		return
+	}
+
+	newStat, err := os.Stat(localPath)
+	if err != nil {
+		return
+	}
+
+	if newStat.Size() != stat.Size() || !newStat.ModTime().Equal(stat.ModTime()) {
+		// changed while hashing
+		checksumCache.Remove(localPath)
+		return
+	}
+
+	checksumCache.Put(localPath, stat.Size(), stat.ModTime(), algorithm, hashBytes)
+}
+
+// verifyUploadedChecksum compares the checksum registered by the server with the local hash
+// the data object is removed if the checksum is missing or does not match, not to leave a corrupted replica
+func (fs *FileSystem) verifyUploadedChecksum(irodsPath string, entry *Entry, localHash []byte) error {
+	var verifyErr error
+	if len(entry.CheckSum) == 0 {
+		verifyErr = errors.Errorf("checksum verification failed, upload failed (no checksum registered for %q)", irodsPath)
+	} else if !bytes.Equal(entry.CheckSum, localHash) {
+		verifyErr = errors.Errorf("checksum verification failed, upload failed (%s vs %s)", hex.EncodeToString(entry.CheckSum), hex.EncodeToString(localHash))
+	} else {
+		return nil
+	}
+
+	err := fs.removeFile(irodsPath, true)
+	if err != nil {
+		log.WithError(err).Warnf("failed to remove data object %q failed checksum verification", irodsPath)
+	}
+
+	return verifyErr
+}
+
+// newLocalFileHash returns a hash to calculate local file hash while uploading the file
+func (fs *FileSystem) newLocalFileHash(algorithm types.ChecksumAlgorithm) (types.ChecksumAlgorithm, hash.Hash, error) {
 	if algorithm == types.ChecksumAlgorithmUnknown {
-		algorithm = types.GetChecksumAlgorithm(fs.account.DefaultHashScheme)
+		algorithm = fs.GetDefaultChecksumAlgorithm()
+	}
+
+	hashAlg, err := util.NewHashAlgorithm(string(algorithm))
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to get %q hash", algorithm)
 	}
 
+	return algorithm, hashAlg, nil
+}
+
+// calculateBufferHash calculates buffer hash
+func (fs *FileSystem) calculateBufferHash(buffer *bytes.Buffer, algorithm types.ChecksumAlgorithm, processCallback common.TransferTrackerCallback) (types.ChecksumAlgorithm, []byte, error) {
 	if algorithm == types.ChecksumAlgorithmUnknown {
-		algorithm = defaultChecksumAlgorithm
+		algorithm = fs.GetDefaultChecksumAlgorithm()
 	}
 
 	hashCallback := func(name string, current int64, total int64) {
@@ -1981,8 +2426,28 @@ func (fs *FileSystem) calculateBufferHash(buffer *bytes.Buffer, algorithm types.
 	return algorithm, hashBytes, nil
 }
 
+// applyDownloadedFileAttributes copies extended attributes and modify time of the data object to the downloaded local file following the config
+func (fs *FileSystem) applyDownloadedFileAttributes(entry *Entry, localPath string) error {
+	err := fs.mapAVUsToXattrs(entry.Path, localPath)
+	if err != nil {
+		return err
+	}
+
+	return fs.preserveDownloadModifyTime(entry, localPath)
+}
+
+// applyUploadedFileAttributes copies extended attributes and modify time of the local file to the uploaded data object following the config
+func (fs *FileSystem) applyUploadedFileAttributes(localPath string, irodsPath string, stat os.FileInfo) error {
+	err := fs.mapXattrsToAVUs(localPath, irodsPath)
+	if err != nil {
+		return err
+	}
+
+	return fs.preserveUploadModifyTime(irodsPath, stat)
+}
+
 func (fs *FileSystem) prepareOverwriteFile(irodsPath string, size int64) error {
-	err := fs.TruncateFile(irodsPath, size)
+	err := fs.truncateFile(irodsPath, size)
 	if err == nil {
 		return nil
 	}
@@ -1994,7 +2459,7 @@ func (fs *FileSystem) prepareOverwriteFile(irodsPath string, size int64) error {
 		return errors.Errorf("failed to overwrite a file %q with a smaller file", irodsPath)
 	}
 
-	err = fs.RemoveFile(irodsPath, true)
+	err = fs.removeFile(irodsPath, true)
 	if err != nil {
 		return errors.Wrapf(err, "failed to remove data object %q for overwrite", irodsPath)
 	}
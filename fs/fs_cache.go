@@ -1,6 +1,8 @@
 package fs
 
 import (
+	"time"
+
 	"github.com/cyverse/go-irodsclient/irods/util"
 )
 
@@ -11,6 +13,7 @@ func (fs *FileSystem) ClearCache() {
 	fs.cache.ClearEntryCache()
 	fs.cache.ClearNegativeEntryCache()
 	fs.cache.ClearDirCache()
+	fs.InvalidateResourceHierarchyCache()
 }
 
 // InvalidateCacheForPath invalidates cache for the given path (general purpose)
@@ -46,6 +49,28 @@ func (fs *FileSystem) InvalidateCacheForPath(path string) {
 	fs.cache.RemoveDirCache(parentPath)
 }
 
+// InvalidateCache invalidates cache for the given path, applications with external writers can call this to drop stale caches
+// if recurse is true, caches for all sub-entries of the path are also invalidated
+func (fs *FileSystem) InvalidateCache(irodsPath string, recurse bool) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	fs.InvalidateCacheForPath(irodsCorrectPath)
+
+	if recurse {
+		fs.cache.RemoveDirEntryCache(irodsCorrectPath, true)
+		fs.cache.RemoveAllNegativeEntryCacheForPath(irodsCorrectPath)
+		fs.cache.RemoveAllDirCacheForPath(irodsCorrectPath)
+		fs.cache.RemoveAllMetadataCacheForPath(irodsCorrectPath)
+		fs.cache.RemoveAllAclCacheForPath(irodsCorrectPath)
+	}
+}
+
+// GetCacheTimeoutForPath returns cache timeout applied to the path, returns 0 if the path is not cached
+func (fs *FileSystem) GetCacheTimeoutForPath(irodsPath string) time.Duration {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+	return fs.cache.GetCacheTimeoutForPath(irodsCorrectPath)
+}
+
 // AddCacheEventHandler adds cache event handler
 func (fs *FileSystem) AddCacheEventHandler(handler FilesystemCacheEventHandler) string {
 	return fs.cacheEventHandlerMap.AddEventHandler(handler)
@@ -56,6 +81,17 @@ func (fs *FileSystem) RemoveCacheEventHandler(handlerID string) {
 	fs.cacheEventHandlerMap.RemoveEventHandler(handlerID)
 }
 
+// AddCacheEntryEventHandler adds a handler that receives events when cache entries are added, invalidated or expired
+// this is useful to keep layered caches coherent, e.g., FUSE kernel caches
+func (fs *FileSystem) AddCacheEntryEventHandler(handler CacheEntryEventHandler) string {
+	return fs.cache.AddEntryEventHandler(handler)
+}
+
+// RemoveCacheEntryEventHandler removes cache entry event handler
+func (fs *FileSystem) RemoveCacheEntryEventHandler(handlerID string) {
+	fs.cache.RemoveEntryEventHandler(handlerID)
+}
+
 // invalidateCacheForRemoveInternal invalidates cache for removal of the given file/dir
 func (fs *FileSystem) invalidateCacheForRemoveInternal(path string, recurse bool) {
 	var entry *Entry
@@ -0,0 +1,330 @@
+package fs
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+	"github.com/rs/xid"
+	log "github.com/sirupsen/logrus"
+)
+
+// StartUploadOptions is options for StartUpload
+type StartUploadOptions struct {
+	Resource  string // resource to upload the file, empty uses the default resource
+	Size      int64  // final size of the file, zero if unknown
+	Exclusive bool   // fail with FileAlreadyExistError if the file exists
+}
+
+// ChunkedUpload is an upload session of a file written in chunks in arbitrary order, possibly concurrently.
+// Chunks are written to a temporary file next to the file, which replaces the file only when CompleteUpload is called,
+// so readers never see a partially uploaded file and the existing file is kept until then.
+// Each session holds a connection of the io session and a write lock on the path until CompleteUpload or AbortUpload is called,
+// the connection is pinged while the session is idle, so it is not dropped between chunks.
+type ChunkedUpload struct {
+	id            string
+	filesystem    *FileSystem
+	path          string
+	tempPath      string // path of the temporary file chunks are written to
+	exclusive     bool
+	connection    *connection.IRODSConnection
+	handle        *types.IRODSFileHandle
+	unlockPath    func()                        // releases the path lock held until the upload is finished
+	stopKeepAlive func()                        // stops pinging the connection
+	releaseOnce   sync.Once                     // releases the path lock and the connection once
+	accessInfo    *types.IRODSReplicaAccessInfo // nil if the server does not support replica tokens, chunks are written via the handle
+	size          int64                         // final size of the file, zero if unknown
+	startTime     time.Time
+	uploadedBytes int64
+	finished      bool
+	mutex         sync.RWMutex // write-locked to finish the upload, read-locked while chunks are uploaded
+	handleMutex   sync.Mutex   // guards the handle when chunks are written via the handle
+}
+
+// StartUpload starts a chunked upload of a file, chunks are uploaded with UploadChunk and the file is finalized with CompleteUpload
+// The path is locked and a connection is held until the upload is completed or aborted
+func (fs *FileSystem) StartUpload(irodsPath string, opts *StartUploadOptions) (*ChunkedUpload, error) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	if opts == nil {
+		opts = &StartUploadOptions{}
+	}
+
+	if opts.Size < 0 {
+		return nil, errors.Errorf("size %d is invalid", opts.Size)
+	}
+
+	unlockPath, err := fs.lockPathsForWrite(irodsCorrectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		unlockPath()
+		return nil, err
+	}
+
+	if opts.Exclusive {
+		_, err = irods_fs.GetDataObject(conn, irodsCorrectPath)
+		if err == nil {
+			fs.ioSession.ReturnConnection(conn) //nolint
+			unlockPath()
+			newErr := types.NewFileAlreadyExistError(irodsCorrectPath)
+			return nil, errors.Wrapf(newErr, "data object for path %q already exists", irodsCorrectPath)
+		} else if !types.IsFileNotFoundError(err) {
+			fs.ioSession.ReturnConnection(conn) //nolint
+			unlockPath()
+			return nil, err
+		}
+	}
+
+	id := xid.New().String()
+	tempPath := getChunkedUploadTempPath(irodsCorrectPath, id)
+
+	keywords := map[common.KeyWord]string{}
+	handle, err := irods_fs.CreateDataObjectExclusive(conn, tempPath, opts.Resource, "w", keywords)
+	if err != nil {
+		fs.ioSession.ReturnConnection(conn) //nolint
+		unlockPath()
+		return nil, err
+	}
+
+	fs.InvalidateCacheForFileCreate(tempPath)
+	fs.cachePropagation.PropagateFileCreate(tempPath)
+
+	upload := &ChunkedUpload{
+		id:            id,
+		filesystem:    fs,
+		path:          irodsCorrectPath,
+		tempPath:      tempPath,
+		exclusive:     opts.Exclusive,
+		connection:    conn,
+		handle:        handle,
+		unlockPath:    unlockPath,
+		stopKeepAlive: irods_fs.KeepConnectionAlive(conn),
+		size:          opts.Size,
+		startTime:     time.Now(),
+	}
+
+	accessInfo, err := irods_fs.GetDataObjectReplicaAccessInfo(conn, handle, 0, opts.Size)
+	if err != nil {
+		if !types.IsAPINotSupportedError(err) {
+			upload.AbortUpload() //nolint
+			return nil, errors.Wrapf(err, "failed to get replica access info of %q", tempPath)
+		}
+
+		log.Debugf("replica token is not supported, chunks of %q are uploaded serially", irodsCorrectPath)
+		accessInfo = nil
+	}
+
+	upload.accessInfo = accessInfo
+	return upload, nil
+}
+
+// getChunkedUploadTempPath returns the path of the temporary file in the same collection
+func getChunkedUploadTempPath(irodsPath string, id string) string {
+	dirPath := util.GetIRODSPathDirname(irodsPath)
+	fileName := util.GetIRODSPathFileName(irodsPath)
+	return util.MakeIRODSPath(dirPath, fmt.Sprintf(".%s.%s.upload", fileName, id))
+}
+
+// GetID returns ID of the upload
+func (upload *ChunkedUpload) GetID() string {
+	return upload.id
+}
+
+// GetPath returns the path of the file uploaded
+func (upload *ChunkedUpload) GetPath() string {
+	return upload.path
+}
+
+// GetUploadedBytes returns bytes of chunks uploaded so far, chunks uploaded again are counted again
+func (upload *ChunkedUpload) GetUploadedBytes() int64 {
+	return atomic.LoadInt64(&upload.uploadedBytes)
+}
+
+// UploadChunk writes a chunk at the offset, chunks can be uploaded in any order and concurrently
+func (upload *ChunkedUpload) UploadChunk(offset int64, data []byte) error {
+	upload.mutex.RLock()
+	defer upload.mutex.RUnlock()
+
+	if upload.finished {
+		return errors.Errorf("upload of %q is already finished", upload.path)
+	}
+
+	if offset < 0 {
+		return errors.Errorf("offset %d is invalid", offset)
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	var err error
+	if upload.accessInfo != nil {
+		err = upload.uploadChunkWithReplicaToken(offset, data)
+	} else {
+		upload.handleMutex.Lock()
+		err = irods_fs.WriteAtDataObject(upload.connection, upload.handle, data, offset)
+		upload.handleMutex.Unlock()
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "failed to upload chunk at offset %d of %q", offset, upload.path)
+	}
+
+	atomic.AddInt64(&upload.uploadedBytes, int64(len(data)))
+	return nil
+}
+
+// uploadChunkWithReplicaToken writes a chunk with a new connection opening the replica with the replica token
+func (upload *ChunkedUpload) uploadChunkWithReplicaToken(offset int64, data []byte) error {
+	fs := upload.filesystem
+
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	keywords := map[common.KeyWord]string{}
+	handle, _, err := irods_fs.OpenDataObjectWithReplicaAccessInfo(conn, upload.accessInfo, "w", keywords)
+	if err != nil {
+		return err
+	}
+
+	err = irods_fs.WriteAtDataObject(conn, handle, data, offset)
+	if err != nil {
+		irods_fs.CloseDataObjectReplica(conn, handle) //nolint
+		return err
+	}
+
+	return irods_fs.CloseDataObjectReplica(conn, handle)
+}
+
+// finish closes the replica, waits for chunks being uploaded
+func (upload *ChunkedUpload) finish() error {
+	upload.mutex.Lock()
+	defer upload.mutex.Unlock()
+
+	if upload.finished {
+		return errors.Errorf("upload of %q is already finished", upload.path)
+	}
+
+	upload.finished = true
+
+	return irods_fs.CloseDataObject(upload.connection, upload.handle)
+}
+
+// release releases the path lock and returns the connection
+func (upload *ChunkedUpload) release() {
+	upload.releaseOnce.Do(func() {
+		upload.stopKeepAlive()
+		upload.filesystem.ioSession.ReturnConnection(upload.connection) //nolint
+		upload.unlockPath()
+	})
+}
+
+// removeTempFile removes the temporary file, a missing temporary file is not an error
+func (upload *ChunkedUpload) removeTempFile() error {
+	fs := upload.filesystem
+
+	err := fs.removeFile(upload.tempPath, true)
+	if err != nil && !types.IsFileNotFoundError(err) {
+		return err
+	}
+
+	fs.InvalidateCacheForFileRemove(upload.tempPath)
+	fs.cachePropagation.PropagateFileRemove(upload.tempPath)
+	return nil
+}
+
+// CompleteUpload finalizes the file, returns the entry of the file uploaded
+// The temporary file is removed and the existing file is kept if the upload fails
+func (upload *ChunkedUpload) CompleteUpload() (*Entry, error) {
+	fs := upload.filesystem
+
+	err := upload.complete()
+	fs.recordAudit(upload.startTime, err, &AuditRecord{
+		Operation: AuditUploadFile,
+		Path:      upload.path,
+		Bytes:     upload.GetUploadedBytes(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to complete upload of %q", upload.path)
+	}
+
+	entry, err := fs.Stat(upload.path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to stat %q", upload.path)
+	}
+
+	return entry, nil
+}
+
+func (upload *ChunkedUpload) complete() error {
+	fs := upload.filesystem
+	defer upload.release()
+
+	err := upload.finish()
+	if err == nil {
+		err = upload.replaceFile()
+	}
+
+	if err != nil {
+		removeErr := upload.removeTempFile()
+		if removeErr != nil {
+			log.WithError(removeErr).Warnf("failed to remove temporary file %q", upload.tempPath)
+		}
+		return err
+	}
+
+	if !upload.exclusive {
+		// the file may have been replaced
+		fs.InvalidateCacheForFileRemove(upload.path)
+	}
+	fs.InvalidateCacheForFileRemove(upload.tempPath)
+	fs.cachePropagation.PropagateFileRemove(upload.tempPath)
+	fs.InvalidateCacheForFileCreate(upload.path)
+	fs.cachePropagation.PropagateFileCreate(upload.path)
+	return nil
+}
+
+// replaceFile checks the size of the temporary file and moves it to the path of the file
+func (upload *ChunkedUpload) replaceFile() error {
+	tempObj, err := irods_fs.GetDataObject(upload.connection, upload.tempPath)
+	if err != nil {
+		return err
+	}
+
+	if upload.size > 0 && tempObj.Size != upload.size {
+		return errors.Errorf("size of %q is %d, expected %d", upload.path, tempObj.Size, upload.size)
+	}
+
+	// exclusive upload does not replace a file created meanwhile
+	return irods_fs.MoveDataObjectWithForce(upload.connection, upload.tempPath, upload.path, !upload.exclusive)
+}
+
+// AbortUpload closes the replica and removes the file uploaded partially, the existing file is kept
+func (upload *ChunkedUpload) AbortUpload() error {
+	defer upload.release()
+
+	// the temporary file is removed even if closing the replica fails
+	finishErr := upload.finish()
+	removeErr := upload.removeTempFile()
+
+	err := errors.Join(finishErr, removeErr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to abort upload of %q", upload.path)
+	}
+
+	return nil
+}
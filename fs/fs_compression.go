@@ -0,0 +1,518 @@
+package fs
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// TransferCompression is a compression applied to data while it is transferred
+// iRODS protocol does not negotiate compression, so data is gzip-compressed by the client and stored as .gz objects
+type TransferCompression string
+
+const (
+	// TransferCompressionNone transfers data as is
+	TransferCompressionNone TransferCompression = ""
+	// TransferCompressionGzip always compresses data with gzip
+	TransferCompressionGzip TransferCompression = "gzip"
+	// TransferCompressionAuto compresses data with gzip only if it is compressible, e.g., text or CSV
+	TransferCompressionAuto TransferCompression = "auto"
+)
+
+const (
+	// GzipFileExtension is an extension appended to data objects compressed with gzip
+	GzipFileExtension string = ".gz"
+
+	// compressibilitySampleSize is a size of the sample compressed to decide if data is compressible
+	compressibilitySampleSize int = 64 * 1024
+	// compressibilityRatioThreshold is a max ratio of compressed size to sample size to consider data compressible
+	compressibilityRatioThreshold float64 = 0.9
+	// compressionBufferSize is a size of buffer used to compress or decompress
+	compressionBufferSize int = 1024 * 1024
+)
+
+// CompressedTransferOptions is options for UploadFileCompressed and DownloadFileCompressed
+type CompressedTransferOptions struct {
+	Compression      TransferCompression            // compression to apply, empty transfers as is
+	Level            int                            // gzip level, 0 uses default
+	KeepName         bool                           // do not append or strip .gz extension
+	Resource         string                         // resource to upload to or download from
+	Force            bool                           // overwrite the destination if exists
+	VerifyChecksum   bool                           // compare checksum of the data object with the compressed data
+	TransferCallback common.TransferTrackerCallback // progress, reported in bytes read from the source
+}
+
+// IsCompressible returns true if the sample of data compresses well with gzip
+func IsCompressible(sample []byte) bool {
+	if len(sample) == 0 {
+		return false
+	}
+
+	if len(sample) > compressibilitySampleSize {
+		sample = sample[:compressibilitySampleSize]
+	}
+
+	counter := &countingWriter{}
+	writer, err := gzip.NewWriterLevel(counter, gzip.BestSpeed)
+	if err != nil {
+		return false
+	}
+
+	_, err = writer.Write(sample)
+	if err != nil {
+		return false
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return false
+	}
+
+	return float64(counter.size) < float64(len(sample))*compressibilityRatioThreshold
+}
+
+// UploadFileCompressed uploads a local file to irods, compressing it on the client following the options
+// if data is compressed, the data object is named with .gz extension unless KeepName is set
+func (fs *FileSystem) UploadFileCompressed(localPath string, irodsPath string, options *CompressedTransferOptions) (*FileTransferResult, error) {
+	startTime := time.Now()
+	result, err := fs.uploadFileCompressed(localPath, irodsPath, options)
+
+	resource := ""
+	if options != nil {
+		resource = options.Resource
+	}
+
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditUploadFile,
+		Path:      irodsPath,
+		Target:    resource,
+		Bytes:     getTransferResultSize(result),
+	})
+	return result, err
+}
+
+func (fs *FileSystem) uploadFileCompressed(localPath string, irodsPath string, options *CompressedTransferOptions) (*FileTransferResult, error) {
+	localSrcPath := util.GetCorrectLocalPath(localPath)
+	irodsDestPath := util.GetCorrectIRODSPath(irodsPath)
+
+	logger := log.WithFields(log.Fields{
+		"local_path": localSrcPath,
+		"irods_path": irodsDestPath,
+	})
+
+	if options == nil {
+		options = &CompressedTransferOptions{}
+	}
+
+	fileTransferResult := &FileTransferResult{}
+	fileTransferResult.LocalPath = localSrcPath
+	fileTransferResult.StartTime = time.Now()
+	defer fs.reportTransfer(fileTransferResult, nil, options.VerifyChecksum)
+
+	stat, err := os.Stat(localSrcPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// file not exists
+			newErr := errors.Join(err, types.NewFileNotFoundError(localSrcPath))
+			return fileTransferResult, errors.Wrapf(newErr, "failed to find a file for local path %q", localSrcPath)
+		}
+		return fileTransferResult, err
+	}
+
+	if stat.IsDir() {
+		newErr := types.NewFileNotFoundError(localSrcPath)
+		return fileTransferResult, errors.Wrapf(newErr, "failed to find a file for local path %q, the path is for a directory", localSrcPath)
+	}
+
+	fileTransferResult.LocalSize = stat.Size()
+
+	localFile, err := os.Open(localSrcPath)
+	if err != nil {
+		return fileTransferResult, errors.Wrapf(err, "failed to open file %q", localSrcPath)
+	}
+	defer localFile.Close() //nolint
+
+	reader := bufio.NewReaderSize(localFile, compressionBufferSize)
+
+	compression, err := resolveUploadCompression(options.Compression, reader)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
+	logger.Debugf("uploading with compression %q", compression)
+
+	irodsFilePath := fs.getUploadTargetPath(localSrcPath, irodsDestPath)
+	if compression == TransferCompressionGzip && !options.KeepName && !strings.HasSuffix(irodsFilePath, GzipFileExtension) {
+		irodsFilePath += GzipFileExtension
+	}
+
+	fileTransferResult.IRODSPath = irodsFilePath
+	fileTransferResult.Compression = compression
+
+	var hashAlg hash.Hash
+	if options.VerifyChecksum {
+		checksumAlgorithm, newHashAlg, err := fs.newLocalFileHash(types.ChecksumAlgorithmUnknown)
+		if err != nil {
+			return fileTransferResult, err
+		}
+
+		fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
+		hashAlg = newHashAlg
+	}
+
+	err = fs.withPathsLockedForWrite(func() error {
+		handle, err := fs.createFileWithOptions(irodsFilePath, options.Resource, string(types.FileOpenModeWriteOnly), &CreateFileOptions{
+			Exclusive: !options.Force,
+		})
+		if err != nil {
+			return err
+		}
+
+		// stored bytes are hashed to be compared with the checksum computed by the server
+		writers := []io.Writer{handle}
+		if hashAlg != nil {
+			writers = append(writers, hashAlg)
+		}
+
+		err = copyCompressed(io.MultiWriter(writers...), reader, compression, options.Level, stat, options.TransferCallback)
+		if err != nil {
+			handle.Close() //nolint
+			return errors.Wrapf(err, "failed to upload %q to %q", localSrcPath, irodsFilePath)
+		}
+
+		return handle.Close()
+	}, irodsFilePath)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
+	if hashAlg != nil {
+		fileTransferResult.LocalCheckSum = hashAlg.Sum(nil)
+	}
+
+	entry, err := fs.StatNoCache(irodsFilePath)
+	if err != nil {
+		return fileTransferResult, err
+	}
+
+	fileTransferResult.IRODSSize = entry.Size
+
+	if options.VerifyChecksum {
+		err = fs.verifyCompressedTransfer(fileTransferResult)
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "checksum verification failed, upload failed")
+		}
+	}
+
+	fileTransferResult.EndTime = time.Now()
+
+	return fileTransferResult, nil
+}
+
+// DownloadFileCompressed downloads a data object to local, decompressing it on the client following the options
+// with TransferCompressionAuto, gzip-compressed data objects are detected and decompressed
+// if data is decompressed and localPath is a dir, .gz extension is stripped from the local file name unless KeepName is set
+func (fs *FileSystem) DownloadFileCompressed(irodsPath string, localPath string, options *CompressedTransferOptions) (*FileTransferResult, error) {
+	irodsSrcPath := util.GetCorrectIRODSPath(irodsPath)
+	localDestPath := util.GetCorrectLocalPath(localPath)
+
+	logger := log.WithFields(log.Fields{
+		"irods_path": irodsSrcPath,
+		"local_path": localDestPath,
+	})
+
+	if options == nil {
+		options = &CompressedTransferOptions{}
+	}
+
+	fileTransferResult := &FileTransferResult{}
+	fileTransferResult.IRODSPath = irodsSrcPath
+	fileTransferResult.StartTime = time.Now()
+	defer fs.reportTransfer(fileTransferResult, nil, options.VerifyChecksum)
+
+	entry, err := fs.StatNoCache(irodsSrcPath)
+	if err != nil {
+		return fileTransferResult, errors.Wrapf(err, "failed to stat %q", irodsSrcPath)
+	}
+
+	if entry.IsDir() {
+		newErr := types.NewFileNotFoundError(irodsSrcPath)
+		return fileTransferResult, errors.Wrapf(newErr, "failed to find a file for irods path %q, the path is for a directory", irodsSrcPath)
+	}
+
+	fileTransferResult.IRODSSize = entry.Size
+
+	handle, err := fs.OpenFile(irodsSrcPath, options.Resource, string(types.FileOpenModeReadOnly))
+	if err != nil {
+		return fileTransferResult, err
+	}
+	defer handle.Close() //nolint
+
+	var hashAlg hash.Hash
+	var irodsReader io.Reader = handle
+	if options.VerifyChecksum {
+		checksumAlgorithm, newHashAlg, err := fs.newLocalFileHash(types.ChecksumAlgorithmUnknown)
+		if err != nil {
+			return fileTransferResult, err
+		}
+
+		fileTransferResult.LocalCheckSumAlgorithm = checksumAlgorithm
+		hashAlg = newHashAlg
+
+		// stored bytes are hashed to be compared with the checksum computed by the server
+		irodsReader = io.TeeReader(handle, hashAlg)
+	}
+
+	reader := bufio.NewReaderSize(irodsReader, compressionBufferSize)
+
+	compression, err := resolveDownloadCompression(options.Compression, reader)
+	if err != nil {
+		return fileTransferResult, errors.Wrapf(err, "failed to download %q", irodsSrcPath)
+	}
+
+	logger.Debugf("downloading with compression %q", compression)
+
+	localFilePath := localDestPath
+	stat, err := os.Stat(localDestPath)
+	if err == nil && stat.IsDir() {
+		fileName := entry.Name
+		if compression == TransferCompressionGzip && !options.KeepName {
+			fileName = strings.TrimSuffix(fileName, GzipFileExtension)
+		}
+
+		localFilePath = filepath.Join(localDestPath, fileName)
+	}
+
+	fileTransferResult.LocalPath = localFilePath
+	fileTransferResult.Compression = compression
+
+	flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !options.Force {
+		flag |= os.O_EXCL
+	}
+
+	localFile, err := os.OpenFile(localFilePath, flag, 0o644)
+	if err != nil {
+		if os.IsExist(err) {
+			newErr := errors.Join(err, types.NewFileAlreadyExistError(localFilePath))
+			return fileTransferResult, errors.Wrapf(newErr, "failed to create file %q", localFilePath)
+		}
+		return fileTransferResult, errors.Wrapf(err, "failed to create file %q", localFilePath)
+	}
+
+	size, err := copyDecompressed(localFile, reader, compression, entry.Size, options.TransferCallback)
+	if err != nil {
+		localFile.Close()        //nolint
+		os.Remove(localFilePath) //nolint
+		return fileTransferResult, errors.Wrapf(err, "failed to download %q to %q", irodsSrcPath, localFilePath)
+	}
+
+	err = localFile.Close()
+	if err != nil {
+		return fileTransferResult, errors.Wrapf(err, "failed to close file %q", localFilePath)
+	}
+
+	fileTransferResult.LocalSize = size
+
+	if hashAlg != nil {
+		// drain what the decompressor did not read, e.g., trailing bytes, to hash the whole data object
+		_, err = io.Copy(io.Discard, reader)
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "failed to read %q", irodsSrcPath)
+		}
+
+		fileTransferResult.LocalCheckSum = hashAlg.Sum(nil)
+
+		err = fs.verifyCompressedTransfer(fileTransferResult)
+		if err != nil {
+			return fileTransferResult, errors.Wrapf(err, "checksum verification failed, download failed")
+		}
+	}
+
+	fileTransferResult.EndTime = time.Now()
+
+	return fileTransferResult, nil
+}
+
+// verifyCompressedTransfer compares the hash of stored bytes with the checksum computed by the server
+func (fs *FileSystem) verifyCompressedTransfer(result *FileTransferResult) error {
+	checksum, err := fs.getServerChecksum(result.IRODSPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get checksum of %q", result.IRODSPath)
+	}
+
+	result.IRODSCheckSumAlgorithm = checksum.Algorithm
+	result.IRODSCheckSum = checksum.Checksum
+
+	if checksum.Algorithm != result.LocalCheckSumAlgorithm {
+		// different algorithm was used, hash the data object with the server algorithm
+		hashBytes, err := fs.hashFile(result.IRODSPath, checksum.Algorithm)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get %q hash of %q", checksum.Algorithm, result.IRODSPath)
+		}
+
+		result.LocalCheckSumAlgorithm = checksum.Algorithm
+		result.LocalCheckSum = hashBytes
+	}
+
+	if !bytes.Equal(result.IRODSCheckSum, result.LocalCheckSum) {
+		return errors.Errorf("checksum mismatch (%s vs %s)", hex.EncodeToString(result.IRODSCheckSum), hex.EncodeToString(result.LocalCheckSum))
+	}
+
+	return nil
+}
+
+// resolveUploadCompression decides compression for upload, with auto, the beginning of data is sampled
+func resolveUploadCompression(compression TransferCompression, reader *bufio.Reader) (TransferCompression, error) {
+	switch compression {
+	case TransferCompressionNone, TransferCompressionGzip:
+		return compression, nil
+	case TransferCompressionAuto:
+		sample, err := reader.Peek(compressibilitySampleSize)
+		if err != nil && err != io.EOF {
+			return "", errors.Wrapf(err, "failed to read sample")
+		}
+
+		if IsCompressible(sample) {
+			return TransferCompressionGzip, nil
+		}
+		return TransferCompressionNone, nil
+	default:
+		return "", errors.Errorf("unknown transfer compression %q", compression)
+	}
+}
+
+// resolveDownloadCompression decides compression for download, with auto, gzip header is detected
+func resolveDownloadCompression(compression TransferCompression, reader *bufio.Reader) (TransferCompression, error) {
+	switch compression {
+	case TransferCompressionNone:
+		return compression, nil
+	case TransferCompressionGzip:
+		if !hasGzipHeader(reader) {
+			return "", errors.Errorf("data is not compressed with gzip")
+		}
+		return compression, nil
+	case TransferCompressionAuto:
+		if hasGzipHeader(reader) {
+			return TransferCompressionGzip, nil
+		}
+		return TransferCompressionNone, nil
+	default:
+		return "", errors.Errorf("unknown transfer compression %q", compression)
+	}
+}
+
+// hasGzipHeader returns true if data begins with gzip magic number
+func hasGzipHeader(reader *bufio.Reader) bool {
+	magic, err := reader.Peek(2)
+	if err != nil {
+		return false
+	}
+
+	return magic[0] == 0x1f && magic[1] == 0x8b
+}
+
+// copyCompressed copies data from reader to writer, compressing it if compression is gzip
+func copyCompressed(writer io.Writer, reader io.Reader, compression TransferCompression, level int, stat os.FileInfo, transferCallback common.TransferTrackerCallback) error {
+	progressReader := newProgressReader(reader, "upload", stat.Size(), transferCallback)
+
+	if compression != TransferCompressionGzip {
+		_, err := io.CopyBuffer(writer, progressReader, make([]byte, compressionBufferSize))
+		return err
+	}
+
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+
+	gzipWriter, err := gzip.NewWriterLevel(writer, level)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create gzip writer")
+	}
+
+	gzipWriter.Name = stat.Name()
+	gzipWriter.ModTime = stat.ModTime()
+
+	_, err = io.CopyBuffer(gzipWriter, progressReader, make([]byte, compressionBufferSize))
+	if err != nil {
+		gzipWriter.Close() //nolint
+		return err
+	}
+
+	return gzipWriter.Close()
+}
+
+// copyDecompressed copies data from reader to writer, decompressing it if compression is gzip, returns bytes written
+func copyDecompressed(writer io.Writer, reader io.Reader, compression TransferCompression, size int64, transferCallback common.TransferTrackerCallback) (int64, error) {
+	// progress is reported in stored bytes as uncompressed size is unknown
+	progressReader := newProgressReader(reader, "download", size, transferCallback)
+
+	if compression != TransferCompressionGzip {
+		return io.CopyBuffer(writer, progressReader, make([]byte, compressionBufferSize))
+	}
+
+	gzipReader, err := gzip.NewReader(progressReader)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to create gzip reader")
+	}
+	defer gzipReader.Close() //nolint
+
+	return io.CopyBuffer(writer, gzipReader, make([]byte, compressionBufferSize))
+}
+
+// progressReader reports bytes read to the transfer callback
+type progressReader struct {
+	reader           io.Reader
+	task             string
+	total            int64
+	processed        int64
+	transferCallback common.TransferTrackerCallback
+}
+
+func newProgressReader(reader io.Reader, task string, total int64, transferCallback common.TransferTrackerCallback) *progressReader {
+	if transferCallback != nil {
+		transferCallback(task, 0, total)
+	}
+
+	return &progressReader{
+		reader:           reader,
+		task:             task,
+		total:            total,
+		transferCallback: transferCallback,
+	}
+}
+
+func (reader *progressReader) Read(p []byte) (int, error) {
+	readLen, err := reader.reader.Read(p)
+	if readLen > 0 {
+		reader.processed += int64(readLen)
+		if reader.transferCallback != nil {
+			reader.transferCallback(reader.task, reader.processed, reader.total)
+		}
+	}
+
+	return readLen, err
+}
+
+// countingWriter counts bytes written
+type countingWriter struct {
+	size int64
+}
+
+func (writer *countingWriter) Write(p []byte) (int, error) {
+	writer.size += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,299 @@
+package fs
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/util"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// CopyDirConcurrencyDefault is a default number of files copied concurrently by CopyDir
+	CopyDirConcurrencyDefault int = 4
+)
+
+// CopyDirProgress is a progress of CopyDir, reported after each entry is copied or failed
+type CopyDirProgress struct {
+	SourcePath string
+	DestPath   string
+	Type       EntryType
+	Size       int64
+	Error      error // error of the entry, nil if copied
+
+	ProcessedFiles int
+	TotalFiles     int
+	ProcessedBytes int64
+	TotalBytes     int64
+}
+
+// CopyDirProgressCallback is a callback of CopyDir, calls are serialized
+type CopyDirProgressCallback func(progress *CopyDirProgress)
+
+// CopyDirOptions is options for CopyDir
+type CopyDirOptions struct {
+	Force            bool                    // overwrite existing files
+	Concurrency      int                     // number of files copied concurrently, 0 uses default
+	StopOnError      bool                    // stop copying at the first error, otherwise copy remaining entries and report errors
+	ProgressCallback CopyDirProgressCallback // called after each entry is copied or failed
+}
+
+// CopyDirEntryError is an error of an entry failed to copy
+type CopyDirEntryError struct {
+	SourcePath string
+	DestPath   string
+	Type       EntryType
+	Err        error
+}
+
+// CopyDirResult is a result of CopyDir
+type CopyDirResult struct {
+	Dirs   int   // number of dirs created
+	Files  int   // number of files copied
+	Bytes  int64 // bytes of files copied
+	Errors []*CopyDirEntryError
+}
+
+type copyDirTask struct {
+	sourcePath string
+	destPath   string
+	entryType  EntryType
+	size       int64
+}
+
+// CopyDir copies a dir recursively on the server side
+// if destPath is an existing dir, the dir is copied into it
+func (fs *FileSystem) CopyDir(srcPath string, destPath string, options *CopyDirOptions) (*CopyDirResult, error) {
+	startTime := time.Now()
+	result, err := fs.copyDir(srcPath, destPath, options)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditCopyDir,
+		Path:      srcPath,
+		DestPath:  destPath,
+		Bytes:     getCopyDirResultSize(result),
+	})
+	return result, err
+}
+
+func (fs *FileSystem) copyDir(srcPath string, destPath string, options *CopyDirOptions) (*CopyDirResult, error) {
+	irodsSrcPath := util.GetCorrectIRODSPath(srcPath)
+	irodsDestPath := util.GetCorrectIRODSPath(destPath)
+
+	destDirPath := irodsDestPath
+	if fs.ExistsDir(irodsDestPath) {
+		// make full dir name for dest
+		srcDirName := util.GetIRODSPathFileName(irodsSrcPath)
+		destDirPath = util.MakeIRODSPath(irodsDestPath, srcDirName)
+	}
+
+	return fs.copyDirToDir(irodsSrcPath, destDirPath, options)
+}
+
+// CopyDirToDir copies a dir recursively on the server side, existing dest dir is merged
+func (fs *FileSystem) CopyDirToDir(srcPath string, destPath string, options *CopyDirOptions) (*CopyDirResult, error) {
+	startTime := time.Now()
+	result, err := fs.copyDirToDir(srcPath, destPath, options)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditCopyDir,
+		Path:      srcPath,
+		DestPath:  destPath,
+		Bytes:     getCopyDirResultSize(result),
+	})
+	return result, err
+}
+
+func (fs *FileSystem) copyDirToDir(srcPath string, destPath string, options *CopyDirOptions) (*CopyDirResult, error) {
+	irodsSrcPath := util.GetCorrectIRODSPath(srcPath)
+	irodsDestPath := util.GetCorrectIRODSPath(destPath)
+
+	logger := log.WithFields(log.Fields{
+		"source": irodsSrcPath,
+		"dest":   irodsDestPath,
+	})
+
+	if options == nil {
+		options = &CopyDirOptions{}
+	}
+
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = CopyDirConcurrencyDefault
+	}
+
+	if irodsSrcPath == irodsDestPath || strings.HasPrefix(irodsDestPath, irodsSrcPath+"/") {
+		return nil, errors.Errorf("failed to copy dir %q to its sub dir %q", irodsSrcPath, irodsDestPath)
+	}
+
+	srcEntry, err := fs.StatNoCache(irodsSrcPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !srcEntry.IsDir() {
+		return nil, errors.Errorf("path %q is not a dir", irodsSrcPath)
+	}
+
+	// collect entries, dirs are in breadth-first order so parents are created first
+	dirTasks := []*copyDirTask{
+		{
+			sourcePath: irodsSrcPath,
+			destPath:   irodsDestPath,
+			entryType:  DirectoryEntry,
+		},
+	}
+	fileTasks := []*copyDirTask{}
+	var totalBytes int64
+
+	for idx := 0; idx < len(dirTasks); idx++ {
+		dirTask := dirTasks[idx]
+
+		entries, err := fs.ListNoCache(dirTask.sourcePath)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			task := &copyDirTask{
+				sourcePath: entry.Path,
+				destPath:   util.MakeIRODSPath(dirTask.destPath, entry.Name),
+				entryType:  entry.Type,
+				size:       entry.Size,
+			}
+
+			if entry.IsDir() {
+				dirTasks = append(dirTasks, task)
+			} else {
+				fileTasks = append(fileTasks, task)
+				totalBytes += entry.Size
+			}
+		}
+	}
+
+	result := &CopyDirResult{
+		Errors: []*CopyDirEntryError{},
+	}
+
+	progress := CopyDirProgress{
+		TotalFiles: len(fileTasks),
+		TotalBytes: totalBytes,
+	}
+
+	stopped := false
+	resultMutex := sync.Mutex{}
+
+	// report records the result of a task, returns false if copy should stop
+	report := func(task *copyDirTask, taskErr error) bool {
+		resultMutex.Lock()
+		defer resultMutex.Unlock()
+
+		if taskErr != nil {
+			logger.WithError(taskErr).Debugf("failed to copy %q to %q", task.sourcePath, task.destPath)
+
+			result.Errors = append(result.Errors, &CopyDirEntryError{
+				SourcePath: task.sourcePath,
+				DestPath:   task.destPath,
+				Type:       task.entryType,
+				Err:        taskErr,
+			})
+
+			if options.StopOnError {
+				stopped = true
+			}
+		} else if task.entryType == DirectoryEntry {
+			result.Dirs++
+		} else {
+			result.Files++
+			result.Bytes += task.size
+		}
+
+		if task.entryType != DirectoryEntry {
+			progress.ProcessedFiles++
+			progress.ProcessedBytes += task.size
+		}
+
+		if options.ProgressCallback != nil {
+			taskProgress := progress
+			taskProgress.SourcePath = task.sourcePath
+			taskProgress.DestPath = task.destPath
+			taskProgress.Type = task.entryType
+			taskProgress.Size = task.size
+			taskProgress.Error = taskErr
+			options.ProgressCallback(&taskProgress)
+		}
+
+		return !stopped
+	}
+
+	// make dirs
+	failedDirs := map[string]bool{}
+	for _, dirTask := range dirTasks {
+		parentPath := util.GetIRODSPathDirname(dirTask.sourcePath)
+		if failedDirs[parentPath] {
+			err = errors.Errorf("parent dir of %q is not created", dirTask.destPath)
+		} else {
+			err = fs.makeDir(dirTask.destPath, true)
+		}
+
+		if err != nil {
+			failedDirs[dirTask.sourcePath] = true
+		}
+
+		if !report(dirTask, err) {
+			return result, errors.Wrapf(err, "failed to make dir %q", dirTask.destPath)
+		}
+	}
+
+	// copy files
+	taskChan := make(chan *copyDirTask)
+	waitGroup := sync.WaitGroup{}
+
+	for i := 0; i < concurrency; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			for task := range taskChan {
+				report(task, fs.copyFileToFile(task.sourcePath, task.destPath, options.Force))
+			}
+		}()
+	}
+
+	for _, fileTask := range fileTasks {
+		if failedDirs[util.GetIRODSPathDirname(fileTask.sourcePath)] {
+			report(fileTask, errors.Errorf("parent dir of %q is not created", fileTask.destPath))
+			continue
+		}
+
+		resultMutex.Lock()
+		stop := stopped
+		resultMutex.Unlock()
+
+		if stop {
+			break
+		}
+
+		taskChan <- fileTask
+	}
+
+	close(taskChan)
+	waitGroup.Wait()
+
+	fs.InvalidateCache(irodsDestPath, true)
+	fs.InvalidateCacheForDirCreate(irodsDestPath)
+
+	if len(result.Errors) > 0 {
+		return result, errors.Wrapf(result.Errors[0].Err, "failed to copy %d entries from %q to %q", len(result.Errors), irodsSrcPath, irodsDestPath)
+	}
+
+	return result, nil
+}
+
+func getCopyDirResultSize(result *CopyDirResult) int64 {
+	if result == nil {
+		return 0
+	}
+
+	return result.Bytes
+}
@@ -0,0 +1,474 @@
+package fs
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+	"github.com/rs/xid"
+	log "github.com/sirupsen/logrus"
+)
+
+// CrossTransferMethod is a method to transfer a data object between two FileSystems
+type CrossTransferMethod string
+
+const (
+	// CrossTransferMethodAuto uses server-side copy if the destination sees the same source, e.g., via federation, stream otherwise
+	// the source is the same if its data ID and checksum match, so sources without checksums are streamed
+	CrossTransferMethodAuto CrossTransferMethod = ""
+	// CrossTransferMethodServerSide copies on the server of the destination, the source must be accessible from the destination
+	CrossTransferMethodServerSide CrossTransferMethod = "server_side"
+	// CrossTransferMethodStream streams data through the client
+	CrossTransferMethodStream CrossTransferMethod = "stream"
+)
+
+const (
+	// CrossTransferBufferSizeDefault is a default size of buffer used to stream data through the client
+	CrossTransferBufferSizeDefault int = 4 * 1024 * 1024
+)
+
+// CrossTransferOptions is options for CrossTransferFile
+type CrossTransferOptions struct {
+	Method           CrossTransferMethod            // transfer method, empty chooses automatically
+	Move             bool                           // remove the source after the transfer is verified
+	Force            bool                           // overwrite the destination if exists
+	Resource         string                         // resource of the destination, used when streaming
+	VerifyChecksum   bool                           // compare checksums of the source and the destination
+	BufferSize       int                            // size of buffer used to stream, 0 uses default
+	TransferCallback common.TransferTrackerCallback // progress
+}
+
+// CrossTransferResult is a result of CrossTransferFile
+type CrossTransferResult struct {
+	SourcePath        string                  `json:"source_path"`
+	DestPath          string                  `json:"dest_path"`
+	Method            CrossTransferMethod     `json:"method"` // method actually used
+	Size              int64                   `json:"size"`
+	CheckSumAlgorithm types.ChecksumAlgorithm `json:"checksum_algorithm"`
+	SourceCheckSum    []byte                  `json:"source_checksum"`
+	DestCheckSum      []byte                  `json:"dest_checksum"`
+	Moved             bool                    `json:"moved"` // true if the source is removed
+	StartTime         time.Time               `json:"start_time"`
+	EndTime           time.Time               `json:"end_time"`
+}
+
+// CrossTransferFile copies or moves a data object between two FileSystems, e.g., connected to federated zones or as different accounts
+// server-side copy is used if the destination can access the source, data is streamed through the client otherwise
+// if destPath is an existing dir, the file is transferred into it
+func CrossTransferFile(srcFS *FileSystem, srcPath string, destFS *FileSystem, destPath string, options *CrossTransferOptions) (*CrossTransferResult, error) {
+	startTime := time.Now()
+	result, err := crossTransferFile(srcFS, srcPath, destFS, destPath, options)
+
+	operation := AuditCopyFile
+	if options != nil && options.Move {
+		operation = AuditRenameFile
+	}
+
+	destFS.recordAudit(startTime, err, &AuditRecord{
+		Operation: operation,
+		Path:      srcPath,
+		DestPath:  destPath,
+		Bytes:     getCrossTransferResultSize(result),
+	})
+	return result, err
+}
+
+func crossTransferFile(srcFS *FileSystem, srcPath string, destFS *FileSystem, destPath string, options *CrossTransferOptions) (*CrossTransferResult, error) {
+	irodsSrcPath := util.GetCorrectIRODSPath(srcPath)
+	irodsDestPath := util.GetCorrectIRODSPath(destPath)
+
+	logger := log.WithFields(log.Fields{
+		"source": irodsSrcPath,
+		"dest":   irodsDestPath,
+	})
+
+	if options == nil {
+		options = &CrossTransferOptions{}
+	}
+
+	srcEntry, err := srcFS.StatNoCache(irodsSrcPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if srcEntry.IsDir() {
+		return nil, errors.Errorf("path %q is not a file", irodsSrcPath)
+	}
+
+	destEntry, err := destFS.StatNoCache(irodsDestPath)
+	if err != nil {
+		if !types.IsFileNotFoundError(err) {
+			return nil, err
+		}
+	} else if destEntry.IsDir() {
+		// make full file name for dest
+		irodsDestPath = util.MakeIRODSPath(irodsDestPath, srcEntry.Name)
+	}
+
+	if srcFS == destFS && irodsSrcPath == irodsDestPath {
+		return nil, errors.Errorf("source and destination %q are the same", irodsSrcPath)
+	}
+
+	result := &CrossTransferResult{
+		SourcePath: irodsSrcPath,
+		DestPath:   irodsDestPath,
+		Size:       srcEntry.Size,
+		StartTime:  time.Now(),
+	}
+
+	err = withCrossTransferPathsLocked(srcFS, irodsSrcPath, destFS, irodsDestPath, func() error {
+		// stat again, the source may have changed before it is locked
+		lockedSrcEntry, statErr := srcFS.StatNoCache(irodsSrcPath)
+		if statErr != nil {
+			return statErr
+		}
+
+		result.Size = lockedSrcEntry.Size
+
+		transferErr := transferFile(srcFS, lockedSrcEntry, destFS, irodsDestPath, options, result, logger)
+		if transferErr != nil {
+			return transferErr
+		}
+
+		// verify before the paths are unlocked, not to see changes made by others
+		return verifyCrossTransfer(srcFS, destFS, result, options.VerifyChecksum)
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if options.Move {
+		err = srcFS.RemoveFile(irodsSrcPath, true)
+		if err != nil {
+			return result, errors.Wrapf(err, "failed to remove source %q after transfer", irodsSrcPath)
+		}
+
+		result.Moved = true
+	}
+
+	result.EndTime = time.Now()
+	return result, nil
+}
+
+// transferFile copies the file with the method given in options, sets the method used to the result
+func transferFile(srcFS *FileSystem, srcEntry *Entry, destFS *FileSystem, irodsDestPath string, options *CrossTransferOptions, result *CrossTransferResult, logger *log.Entry) error {
+	method := options.Method
+	if method == CrossTransferMethodAuto {
+		if srcFS.canServerSideCopyTo(destFS, srcEntry) {
+			serverSideErr := destFS.serverSideCopyFile(srcEntry.Path, irodsDestPath, options)
+			if serverSideErr == nil {
+				result.Method = CrossTransferMethodServerSide
+				return nil
+			}
+
+			if types.IsFileAlreadyExistError(serverSideErr) {
+				return serverSideErr
+			}
+
+			logger.WithError(serverSideErr).Debug("failed to copy on the server side, streaming through the client")
+		}
+
+		method = CrossTransferMethodStream
+	}
+
+	result.Method = method
+
+	switch method {
+	case CrossTransferMethodServerSide:
+		return destFS.serverSideCopyFile(srcEntry.Path, irodsDestPath, options)
+	case CrossTransferMethodStream:
+		return streamFile(srcFS, srcEntry.Path, destFS, irodsDestPath, srcEntry.Size, options)
+	default:
+		return errors.Errorf("unknown cross transfer method %q", method)
+	}
+}
+
+// withCrossTransferPathsLocked runs fn while the source and destination paths are locked
+func withCrossTransferPathsLocked(srcFS *FileSystem, srcPath string, destFS *FileSystem, destPath string, fn func() error) error {
+	if srcFS == destFS {
+		return srcFS.withPathsLockedForWrite(fn, srcPath, destPath)
+	}
+
+	// lock in the order of FileSystem IDs, avoids deadlock with a transfer in the opposite direction
+	firstFS, firstPath, secondFS, secondPath := srcFS, srcPath, destFS, destPath
+	if destFS.GetID() < srcFS.GetID() {
+		firstFS, firstPath, secondFS, secondPath = destFS, destPath, srcFS, srcPath
+	}
+
+	return firstFS.withPathsLockedForWrite(func() error {
+		return secondFS.withPathsLockedForWrite(fn, secondPath)
+	}, firstPath)
+}
+
+// canServerSideCopyTo returns true if the destination FileSystem can access the source file, so the server of the destination can copy it
+// separate grids may have different files at the same path, so the file seen by the destination must have the same data ID and checksum
+// unless both FileSystems are connected to the same zone of the same server
+func (fs *FileSystem) canServerSideCopyTo(destFS *FileSystem, srcEntry *Entry) bool {
+	if fs == destFS {
+		return true
+	}
+
+	srcAccount := fs.GetAccount()
+	destAccount := destFS.GetAccount()
+	if srcAccount.Host == destAccount.Host && srcAccount.Port == destAccount.Port && srcAccount.ClientZone == destAccount.ClientZone {
+		return true
+	}
+
+	if len(srcEntry.CheckSum) == 0 {
+		return false
+	}
+
+	// the source is visible via federation or the destination account has access to it
+	entry, err := destFS.StatNoCache(srcEntry.Path)
+	if err != nil {
+		return false
+	}
+
+	if entry.IsDir() || entry.ID != srcEntry.ID || entry.Size != srcEntry.Size {
+		return false
+	}
+
+	return entry.CheckSumAlgorithm == srcEntry.CheckSumAlgorithm && bytes.Equal(entry.CheckSum, srcEntry.CheckSum)
+}
+
+// serverSideCopyFile copies the file on the server of the FileSystem
+func (fs *FileSystem) serverSideCopyFile(srcPath string, destPath string, options *CrossTransferOptions) error {
+	size := int64(0)
+	if options.TransferCallback != nil {
+		if entry, err := fs.StatNoCache(srcPath); err == nil {
+			size = entry.Size
+		}
+
+		options.TransferCallback("copy", 0, size)
+	}
+
+	if !options.Force && fs.ExistsFile(destPath) {
+		return types.NewFileAlreadyExistError(destPath)
+	}
+
+	// we use ioSession to acquire connection as it can take a long time
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.CopyDataObject(conn, srcPath, destPath, options.Force)
+	if err != nil {
+		return err
+	}
+
+	fs.InvalidateCacheForFileCreate(destPath)
+	fs.cachePropagation.PropagateFileCreate(destPath)
+
+	if options.TransferCallback != nil {
+		options.TransferCallback("copy", size, size)
+	}
+
+	return nil
+}
+
+// streamFile reads the source file and writes it to a temporary file next to the destination through the client,
+// the temporary file replaces the destination only if the stream succeeds, so the existing destination is kept otherwise
+func streamFile(srcFS *FileSystem, srcPath string, destFS *FileSystem, destPath string, size int64, options *CrossTransferOptions) error {
+	if !options.Force && destFS.ExistsFile(destPath) {
+		return types.NewFileAlreadyExistError(destPath)
+	}
+
+	tempPath := getCrossTransferTempPath(destPath, xid.New().String())
+
+	err := streamFileToPath(srcFS, srcPath, destFS, tempPath, size, options)
+	if err == nil {
+		err = destFS.replaceWithTempFile(tempPath, destPath, options.Force)
+	}
+
+	if err != nil {
+		removeErr := destFS.removeFile(tempPath, true)
+		if removeErr != nil && !types.IsFileNotFoundError(removeErr) {
+			log.WithError(removeErr).Warnf("failed to remove temporary file %q", tempPath)
+		}
+
+		destFS.InvalidateCacheForFileRemove(tempPath)
+		destFS.cachePropagation.PropagateFileRemove(tempPath)
+		return err
+	}
+
+	return nil
+}
+
+// getCrossTransferTempPath returns the path of the temporary file in the same collection
+func getCrossTransferTempPath(irodsPath string, id string) string {
+	dirPath := util.GetIRODSPathDirname(irodsPath)
+	fileName := util.GetIRODSPathFileName(irodsPath)
+	return util.MakeIRODSPath(dirPath, fmt.Sprintf(".%s.%s.transfer", fileName, id))
+}
+
+// streamFileToPath reads the source file and writes it to a new file at destPath
+func streamFileToPath(srcFS *FileSystem, srcPath string, destFS *FileSystem, destPath string, size int64, options *CrossTransferOptions) error {
+	bufferSize := options.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = CrossTransferBufferSizeDefault
+	}
+
+	srcHandle, err := srcFS.OpenFile(srcPath, "", string(types.FileOpenModeReadOnly))
+	if err != nil {
+		return err
+	}
+	defer srcHandle.Close() //nolint
+
+	destHandle, err := destFS.createFileWithOptions(destPath, options.Resource, string(types.FileOpenModeWriteOnly), &CreateFileOptions{
+		Exclusive: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	buffer := make([]byte, bufferSize)
+	transferred := int64(0)
+
+	if options.TransferCallback != nil {
+		options.TransferCallback("stream", transferred, size)
+	}
+
+	for {
+		readLen, readErr := srcHandle.Read(buffer)
+		if readLen > 0 {
+			_, writeErr := destHandle.Write(buffer[:readLen])
+			if writeErr != nil {
+				destHandle.Close() //nolint
+				return errors.Wrapf(writeErr, "failed to write to %q", destPath)
+			}
+
+			transferred += int64(readLen)
+			if options.TransferCallback != nil {
+				options.TransferCallback("stream", transferred, size)
+			}
+		}
+
+		if readErr != nil {
+			if readErr == io.EOF {
+				break
+			}
+
+			destHandle.Close() //nolint
+			return errors.Wrapf(readErr, "failed to read from %q", srcPath)
+		}
+	}
+
+	return destHandle.Close()
+}
+
+// replaceWithTempFile moves the temporary file to destPath, an existing file at destPath is replaced if force is set
+func (fs *FileSystem) replaceWithTempFile(tempPath string, destPath string, force bool) error {
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.MoveDataObjectWithForce(conn, tempPath, destPath, force)
+	if err != nil {
+		return err
+	}
+
+	fs.InvalidateCacheForFileRemove(tempPath)
+	fs.cachePropagation.PropagateFileRemove(tempPath)
+	if force {
+		// the dest may have been replaced
+		fs.InvalidateCacheForFileRemove(destPath)
+	}
+	fs.InvalidateCacheForFileCreate(destPath)
+	fs.cachePropagation.PropagateFileCreate(destPath)
+	return nil
+}
+
+// verifyCrossTransfer compares sizes, and checksums if verifyChecksum is set, of the source and the destination
+func verifyCrossTransfer(srcFS *FileSystem, destFS *FileSystem, result *CrossTransferResult, verifyChecksum bool) error {
+	destEntry, err := destFS.StatNoCache(result.DestPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to stat destination %q", result.DestPath)
+	}
+
+	if destEntry.Size != result.Size {
+		return errors.Errorf("size verification failed, transfer failed (%d vs %d)", result.Size, destEntry.Size)
+	}
+
+	if !verifyChecksum {
+		return nil
+	}
+
+	srcChecksum, err := srcFS.getServerChecksum(result.SourcePath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get checksum of source %q", result.SourcePath)
+	}
+
+	destChecksum, err := destFS.getServerChecksum(result.DestPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get checksum of destination %q", result.DestPath)
+	}
+
+	result.CheckSumAlgorithm = srcChecksum.Algorithm
+	result.SourceCheckSum = srcChecksum.Checksum
+	result.DestCheckSum = destChecksum.Checksum
+
+	if destChecksum.Algorithm != srcChecksum.Algorithm {
+		// zones use different algorithms, hash the destination with the source algorithm
+		result.DestCheckSum, err = destFS.hashFile(result.DestPath, srcChecksum.Algorithm)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get %q hash of destination %q", srcChecksum.Algorithm, result.DestPath)
+		}
+	}
+
+	if !bytes.Equal(result.SourceCheckSum, result.DestCheckSum) {
+		return errors.Errorf("checksum verification failed, transfer failed (%s vs %s)", hex.EncodeToString(result.SourceCheckSum), hex.EncodeToString(result.DestCheckSum))
+	}
+
+	return nil
+}
+
+// getServerChecksum returns the checksum of the data object computed by the server
+func (fs *FileSystem) getServerChecksum(irodsPath string) (*types.IRODSChecksum, error) {
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	return irods_fs.GetDataObjectChecksum(conn, irodsPath, "")
+}
+
+// hashFile reads the data object and returns its hash
+func (fs *FileSystem) hashFile(irodsPath string, algorithm types.ChecksumAlgorithm) ([]byte, error) {
+	hashAlg, err := util.NewHashAlgorithm(string(algorithm))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %q hash", algorithm)
+	}
+
+	handle, err := fs.OpenFile(irodsPath, "", string(types.FileOpenModeReadOnly))
+	if err != nil {
+		return nil, err
+	}
+	defer handle.Close() //nolint
+
+	_, err = io.CopyBuffer(hashAlg, handle, make([]byte, CrossTransferBufferSizeDefault))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %q", irodsPath)
+	}
+
+	return hashAlg.Sum(nil), nil
+}
+
+func getCrossTransferResultSize(result *CrossTransferResult) int64 {
+	if result == nil {
+		return 0
+	}
+
+	return result.Size
+}
@@ -0,0 +1,148 @@
+package fs
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+// DataObjectLock is an advisory lock on a data object held on the server
+// the server releases the lock when its connection is closed, so the lock keeps a dedicated connection until Unlock is called
+type DataObjectLock struct {
+	filesystem *FileSystem
+	connection *connection.IRODSConnection
+	handle     *types.IRODSFileLockHandle
+	mutex      sync.Mutex
+}
+
+// GetPath returns the path of the locked data object
+func (lock *DataObjectLock) GetPath() string {
+	return lock.handle.Path
+}
+
+// GetLockType returns the lock type
+func (lock *DataObjectLock) GetLockType() types.DataObjectLockType {
+	return lock.handle.Type
+}
+
+// IsLocked returns true if the lock is not released yet
+func (lock *DataObjectLock) IsLocked() bool {
+	lock.mutex.Lock()
+	defer lock.mutex.Unlock()
+
+	return lock.connection != nil
+}
+
+// Unlock releases the lock and returns its connection to the session, unlocking twice does nothing
+func (lock *DataObjectLock) Unlock() error {
+	lock.mutex.Lock()
+	defer lock.mutex.Unlock()
+
+	if lock.connection == nil {
+		return nil
+	}
+
+	conn := lock.connection
+	lock.connection = nil
+
+	err := irods_fs.UnlockDataObject(conn, lock.handle)
+	if err != nil {
+		// closing the connection releases the lock on the server
+		lock.filesystem.ioSession.DiscardConnection(conn)
+		return err
+	}
+
+	return lock.filesystem.ioSession.ReturnConnection(conn)
+}
+
+// ToString stringifies the object
+func (lock *DataObjectLock) ToString() string {
+	return fmt.Sprintf("<DataObjectLock %s %s %t>", lock.handle.Path, lock.handle.Type, lock.IsLocked())
+}
+
+// LockFile places an advisory read or write lock on the file, other clients can hold read locks together but a write lock is exclusive
+// if wait is true, it blocks until the lock is granted, otherwise it fails immediately if a conflicting lock is held
+// locks only coordinate clients using them, they do not prevent other clients from accessing the file
+func (fs *FileSystem) LockFile(irodsPath string, lockType types.DataObjectLockType, wait bool) (*DataObjectLock, error) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	if lockType != types.DataObjectLockTypeRead && lockType != types.DataObjectLockTypeWrite {
+		return nil, errors.Errorf("lock type %q is neither read nor write", lockType)
+	}
+
+	lockCommand := types.DataObjectLockCommandSetLock
+	if wait {
+		lockCommand = types.DataObjectLockCommandSetLockWait
+	}
+
+	// the lock is bound to the connection, so it must not be shared
+	conn, err := fs.ioSession.AcquireConnection(false)
+	if err != nil {
+		return nil, err
+	}
+
+	handle, err := irods_fs.LockDataObject(conn, irodsCorrectPath, lockType, lockCommand)
+	if err != nil {
+		fs.ioSession.ReturnConnection(conn) //nolint
+		return nil, err
+	}
+
+	return &DataObjectLock{
+		filesystem: fs,
+		connection: conn,
+		handle:     handle,
+	}, nil
+}
+
+// GetFileLockType returns the type of a lock held on the file, returns DataObjectLockTypeUnlock if the file is not locked
+func (fs *FileSystem) GetFileLockType(irodsPath string) (types.DataObjectLockType, error) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return "", err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	return irods_fs.GetDataObjectLockType(conn, irodsCorrectPath)
+}
+
+// ExtendedEntry is an Entry with states that are not cached
+type ExtendedEntry struct {
+	*Entry
+
+	LockType types.DataObjectLockType `json:"lock_type,omitempty"` // empty for directory
+}
+
+// ToString stringifies the object
+func (entry *ExtendedEntry) ToString() string {
+	return fmt.Sprintf("<ExtendedEntry %d %s %s %d %s>", entry.ID, entry.Type, entry.Path, entry.Size, entry.LockType)
+}
+
+// StatExtended returns extended status of a file or a directory, including the lock state of the file
+func (fs *FileSystem) StatExtended(irodsPath string) (*ExtendedEntry, error) {
+	entry, err := fs.Stat(irodsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	extendedEntry := &ExtendedEntry{
+		Entry: entry,
+	}
+
+	if entry.Type == FileEntry {
+		lockType, err := fs.GetFileLockType(entry.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		extendedEntry.LockType = lockType
+	}
+
+	return extendedEntry, nil
+}
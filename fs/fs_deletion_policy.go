@@ -0,0 +1,147 @@
+package fs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+)
+
+// DeletionMode is a mode of deleting files and dirs
+type DeletionMode string
+
+const (
+	// DeletionModeTrash moves entries to the trash of the user
+	DeletionModeTrash DeletionMode = "trash"
+	// DeletionModeForce deletes entries permanently without moving them to trash
+	DeletionModeForce DeletionMode = "force"
+	// DeletionModeUnregister unregisters entries from the catalog, physical files are not deleted
+	DeletionModeUnregister DeletionMode = "unregister"
+)
+
+// DeletionPolicy is a policy of RemoveFileWithPolicy and RemoveDirWithPolicy
+type DeletionPolicy struct {
+	Mode          DeletionMode  // empty uses DeletionModeTrash
+	ReplicaNumber *int          // delete the given replica only, nil deletes all replicas, files only
+	MinAge        time.Duration // keep entries modified more recently than this, 0 deletes regardless of age
+}
+
+// NewDeletionPolicy creates a new DeletionPolicy equivalent to the force flag of RemoveFile and RemoveDir
+func NewDeletionPolicy(force bool) *DeletionPolicy {
+	if force {
+		return &DeletionPolicy{
+			Mode: DeletionModeForce,
+		}
+	}
+
+	return &DeletionPolicy{
+		Mode: DeletionModeTrash,
+	}
+}
+
+// Validate validates deletion policy
+func (policy *DeletionPolicy) Validate() error {
+	switch policy.Mode {
+	case "", DeletionModeTrash, DeletionModeForce, DeletionModeUnregister:
+	default:
+		return errors.Errorf("unknown deletion mode %q", policy.Mode)
+	}
+
+	if policy.ReplicaNumber != nil && *policy.ReplicaNumber < 0 {
+		return errors.Errorf("negative replica number %d", *policy.ReplicaNumber)
+	}
+
+	if policy.MinAge < 0 {
+		return errors.Errorf("negative min age %s", policy.MinAge)
+	}
+
+	return nil
+}
+
+func (policy *DeletionPolicy) validate() error {
+	if policy == nil {
+		return errors.Errorf("deletion policy is nil")
+	}
+
+	return policy.Validate()
+}
+
+func (policy *DeletionPolicy) validateForDir() error {
+	err := policy.validate()
+	if err != nil {
+		return err
+	}
+
+	if policy.ReplicaNumber != nil {
+		return errors.Errorf("replica number is not supported for dirs")
+	}
+
+	return nil
+}
+
+func (policy *DeletionPolicy) isForce() bool {
+	return policy.Mode == DeletionModeForce
+}
+
+func (policy *DeletionPolicy) isUnregister() bool {
+	return policy.Mode == DeletionModeUnregister
+}
+
+// isFiltered returns true if entries may be kept after deletion
+func (policy *DeletionPolicy) isFiltered() bool {
+	return policy.ReplicaNumber != nil || policy.MinAge > 0
+}
+
+// getKeywords returns keywords of the filters
+func (policy *DeletionPolicy) getKeywords() map[common.KeyWord]string {
+	keywords := map[common.KeyWord]string{}
+
+	if policy.ReplicaNumber != nil {
+		keywords[common.REPL_NUM_KW] = fmt.Sprintf("%d", *policy.ReplicaNumber)
+	}
+
+	if policy.MinAge > 0 {
+		keywords[common.AGE_KW] = fmt.Sprintf("%d", int(policy.MinAge/time.Minute))
+	}
+
+	return keywords
+}
+
+func (policy *DeletionPolicy) getFileAuditOperation() AuditOperation {
+	if policy != nil && policy.isUnregister() {
+		return AuditUnregisterFile
+	}
+	return AuditRemoveFile
+}
+
+func (policy *DeletionPolicy) getDirAuditOperation() AuditOperation {
+	if policy != nil && policy.isUnregister() {
+		return AuditUnregisterDir
+	}
+	return AuditRemoveDir
+}
+
+// RemoveFileWithPolicy deletes a file following the policy
+func (fs *FileSystem) RemoveFileWithPolicy(irodsPath string, policy *DeletionPolicy) error {
+	startTime := time.Now()
+	err := fs.withPathsLocked(func() error {
+		return fs.removeFileWithPolicy(irodsPath, policy)
+	}, irodsPath)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: policy.getFileAuditOperation(),
+		Path:      irodsPath,
+	})
+	return err
+}
+
+// RemoveDirWithPolicy deletes a dir following the policy
+func (fs *FileSystem) RemoveDirWithPolicy(irodsPath string, recurse bool, policy *DeletionPolicy) error {
+	startTime := time.Now()
+	err := fs.removeDirWithPolicy(irodsPath, recurse, policy)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: policy.getDirAuditOperation(),
+		Path:      irodsPath,
+	})
+	return err
+}
@@ -11,6 +11,7 @@ type DirStat struct {
 	Path      string `json:"path"`
 	TotalSize int64  `json:"total_size"`
 	FileCount int64  `json:"file_count"`
+	DirCount  int64  `json:"dir_count"` // number of sub dirs
 	Recursive bool   `json:"recursive"`
 }
 
@@ -19,11 +20,12 @@ func NewDirStat(path string, recursive bool, stat *types.IRODSCollectionStat) *D
 		Path:      path,
 		TotalSize: stat.TotalSize,
 		FileCount: stat.DataObjectCount,
+		DirCount:  stat.CollectionCount,
 		Recursive: recursive,
 	}
 }
 
 // ToString stringifies the object
 func (dstat *DirStat) ToString() string {
-	return fmt.Sprintf("<DirStat %s %d %d %t>", dstat.Path, dstat.TotalSize, dstat.FileCount, dstat.Recursive)
+	return fmt.Sprintf("<DirStat %s %d %d %d %t>", dstat.Path, dstat.TotalSize, dstat.FileCount, dstat.DirCount, dstat.Recursive)
 }
@@ -18,25 +18,77 @@ const (
 	DirectoryEntry EntryType = "directory"
 )
 
+// EntryReplica is a struct for a replica of a file entry
 type EntryReplica struct {
-}
-
-// Entry is a struct for filesystem entry
-type Entry struct {
-	ID                int64                   `json:"id"`
-	Type              EntryType               `json:"type"`
-	Name              string                  `json:"name"`
-	Path              string                  `json:"path"`
+	Number            int64                   `json:"number"`
 	Owner             string                  `json:"owner"`
-	Size              int64                   `json:"size"`
-	DataType          string                  `json:"data_type"`
+	ResourceName      string                  `json:"resource_name"`
+	ResourceHierarchy string                  `json:"resource_hierarchy"`
+	PhysicalPath      string                  `json:"physical_path"`
+	Status            types.ReplicaStatus     `json:"status"`
+	CheckSumAlgorithm types.ChecksumAlgorithm `json:"checksum_algorithm"`
+	CheckSum          []byte                  `json:"checksum"`
 	CreateTime        time.Time               `json:"create_time"`
 	ModifyTime        time.Time               `json:"modify_time"`
 	AccessTime        time.Time               `json:"access_time"`
-	CheckSumAlgorithm types.ChecksumAlgorithm `json:"checksum_algorithm"`
-	CheckSum          []byte                  `json:"checksum"`
-	IRODSReplicas     []types.IRODSReplica    `json:"replicas,omitempty"`
-	CacheID           string                  `json:"cache_id,omitempty"`
+}
+
+// NewEntryReplicaFromReplica creates EntryReplica from IRODSReplica
+func NewEntryReplicaFromReplica(replica *types.IRODSReplica) *EntryReplica {
+	checksumAlgorithm := types.ChecksumAlgorithmUnknown
+	var checksumString []byte
+
+	if replica.Checksum != nil && len(replica.Checksum.Checksum) > 0 {
+		checksumAlgorithm = replica.Checksum.Algorithm
+		checksumString = replica.Checksum.Checksum
+	}
+
+	return &EntryReplica{
+		Number:            replica.Number,
+		Owner:             replica.Owner,
+		ResourceName:      replica.ResourceName,
+		ResourceHierarchy: replica.ResourceHierarchy,
+		PhysicalPath:      replica.Path,
+		Status:            replica.GetStatus(),
+		CheckSumAlgorithm: checksumAlgorithm,
+		CheckSum:          checksumString,
+		CreateTime:        replica.CreateTime,
+		ModifyTime:        replica.ModifyTime,
+		AccessTime:        replica.AccessTime,
+	}
+}
+
+// IsGood returns true if the replica is up to date
+func (replica *EntryReplica) IsGood() bool {
+	return replica.Status == types.ReplicaStatusGood
+}
+
+// ToString stringifies the object
+func (replica *EntryReplica) ToString() string {
+	return fmt.Sprintf("<EntryReplica %d %s %s %s %s>", replica.Number, replica.Status, replica.ResourceHierarchy, replica.PhysicalPath, replica.ModifyTime)
+}
+
+// Entry is a struct for filesystem entry
+type Entry struct {
+	ID                int64                       `json:"id"`
+	Type              EntryType                   `json:"type"`
+	Name              string                      `json:"name"`
+	Path              string                      `json:"path"`
+	Owner             string                      `json:"owner"`
+	Size              int64                       `json:"size"`
+	DataType          string                      `json:"data_type"`
+	CreateTime        time.Time                   `json:"create_time"`
+	ModifyTime        time.Time                   `json:"modify_time"`
+	AccessTime        time.Time                   `json:"access_time"`
+	CheckSumAlgorithm types.ChecksumAlgorithm     `json:"checksum_algorithm"`
+	CheckSum          []byte                      `json:"checksum"`
+	IRODSReplicas     []types.IRODSReplica        `json:"replicas,omitempty"`
+	Inheritance       bool                        `json:"inheritance,omitempty"`  // ACL inheritance, only for directory
+	SpecialType       types.SpecialCollectionType `json:"special_type,omitempty"` // type of special collection, only for directory
+	SpecialInfo1      string                      `json:"special_info1,omitempty"`
+	SpecialInfo2      string                      `json:"special_info2,omitempty"`
+	CacheID           string                      `json:"cache_id,omitempty"`
+	Metadata          []*types.IRODSMeta          `json:"metadata,omitempty"` // AVUs, only if requested, e.g., StatWithMetadata
 }
 
 func NewEntryFromCollection(collection *types.IRODSCollection) *Entry {
@@ -54,6 +106,10 @@ func NewEntryFromCollection(collection *types.IRODSCollection) *Entry {
 		CheckSumAlgorithm: types.ChecksumAlgorithmUnknown,
 		CheckSum:          nil,
 		IRODSReplicas:     nil,
+		Inheritance:       collection.Inheritance,
+		SpecialType:       collection.SpecialType,
+		SpecialInfo1:      collection.SpecialInfo1,
+		SpecialInfo2:      collection.SpecialInfo2,
 		CacheID:           xid.New().String(),
 	}
 }
@@ -102,15 +158,35 @@ func (entry *Entry) IsDir() bool {
 	return entry.Type == DirectoryEntry
 }
 
+// IsSpecialDir returns if the entry is for special directory, such as mounted collections
+// content of special directories may not be listed or accessed like normal directories
+func (entry *Entry) IsSpecialDir() bool {
+	return entry.Type == DirectoryEntry && entry.SpecialType != types.NormalCollectionType
+}
+
+// GetReplicas returns replicas of the file entry, empty for directories
+func (entry *Entry) GetReplicas() []*EntryReplica {
+	replicas := make([]*EntryReplica, 0, len(entry.IRODSReplicas))
+	for idx := range entry.IRODSReplicas {
+		replicas = append(replicas, NewEntryReplicaFromReplica(&entry.IRODSReplicas[idx]))
+	}
+
+	return replicas
+}
+
 // ToCollection returns collection
 func (entry *Entry) ToCollection() *types.IRODSCollection {
 	return &types.IRODSCollection{
-		ID:         entry.ID,
-		Path:       entry.Path,
-		Name:       entry.Name,
-		Owner:      entry.Owner,
-		CreateTime: entry.CreateTime,
-		ModifyTime: entry.ModifyTime,
+		ID:           entry.ID,
+		Path:         entry.Path,
+		Name:         entry.Name,
+		Owner:        entry.Owner,
+		CreateTime:   entry.CreateTime,
+		ModifyTime:   entry.ModifyTime,
+		Inheritance:  entry.Inheritance,
+		SpecialType:  entry.SpecialType,
+		SpecialInfo1: entry.SpecialInfo1,
+		SpecialInfo2: entry.SpecialInfo2,
 	}
 }
 
@@ -0,0 +1,331 @@
+package fs
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// ListingExportFormat is a format of exported listing
+type ListingExportFormat string
+
+const (
+	// ListingExportJSONLines writes a JSON object per line
+	ListingExportJSONLines ListingExportFormat = "jsonl"
+	// ListingExportCSV writes a CSV row per entry with a header row
+	ListingExportCSV ListingExportFormat = "csv"
+)
+
+// ListingExportOptions is options for ExportListing
+type ListingExportOptions struct {
+	Format          ListingExportFormat // empty uses ListingExportJSONLines
+	IncludeMetadata bool                // export AVUs of each entry, listed in a query per page
+	FilesOnly       bool                // do not export dirs, dirs are still walked
+	MaxDepth        int                 // max depth of entries exported, entries in the root are at depth 1, 0 is unlimited
+	PageSize        int                 // rows read per page, 0 uses ListIteratorPageSizeDefault
+	ErrorPolicy     WalkErrorPolicy     // empty uses WalkErrorAbort
+}
+
+// Validate validates listing export options
+func (options *ListingExportOptions) Validate() error {
+	switch options.Format {
+	case "", ListingExportJSONLines, ListingExportCSV:
+	default:
+		return errors.Errorf("unknown listing export format %q", options.Format)
+	}
+
+	if options.MaxDepth < 0 {
+		return errors.Errorf("negative max depth %d", options.MaxDepth)
+	}
+
+	if options.PageSize < 0 {
+		return errors.Errorf("page size must be positive")
+	}
+
+	switch options.ErrorPolicy {
+	case "", WalkErrorAbort, WalkErrorSkip:
+	default:
+		return errors.Errorf("unknown walk error policy %q", options.ErrorPolicy)
+	}
+
+	return nil
+}
+
+// ListingExportMeta is an AVU in exported listing
+type ListingExportMeta struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Units string `json:"units,omitempty"`
+}
+
+// ListingExportRecord is a record of exported listing
+type ListingExportRecord struct {
+	Path       string              `json:"path"`
+	Type       EntryType           `json:"type"`
+	Size       int64               `json:"size"`
+	Checksum   string              `json:"checksum,omitempty"` // iRODS checksum string, e.g., sha2:...
+	Owner      string              `json:"owner"`
+	ModifyTime time.Time           `json:"modify_time"`
+	Metadata   []ListingExportMeta `json:"metadata,omitempty"`
+}
+
+// ListingExportResult is a summary of ExportListing
+type ListingExportResult struct {
+	Dirs      int64 `json:"dirs"`       // number of dirs exported
+	Files     int64 `json:"files"`      // number of files exported
+	TotalSize int64 `json:"total_size"` // sum of file sizes
+	Errors    int64 `json:"errors"`     // number of dirs failed to list or entries failed to get metadata, only with WalkErrorSkip
+}
+
+// listingExportCSVHeader is a header row of CSV export
+var listingExportCSVHeader = []string{"path", "type", "size", "checksum", "owner", "modify_time", "metadata"}
+
+// listingExporter writes records in a format
+type listingExporter interface {
+	write(record *ListingExportRecord) error
+	flush() error
+}
+
+type jsonLinesExporter struct {
+	encoder *json.Encoder
+}
+
+func (exporter *jsonLinesExporter) write(record *ListingExportRecord) error {
+	return exporter.encoder.Encode(record)
+}
+
+func (exporter *jsonLinesExporter) flush() error {
+	return nil
+}
+
+type csvExporter struct {
+	writer *csv.Writer
+}
+
+func (exporter *csvExporter) write(record *ListingExportRecord) error {
+	// AVUs are written as a JSON array in a column
+	metadata := ""
+	if len(record.Metadata) > 0 {
+		metadataBytes, err := json.Marshal(record.Metadata)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal metadata of %q", record.Path)
+		}
+		metadata = string(metadataBytes)
+	}
+
+	return exporter.writer.Write([]string{
+		record.Path,
+		string(record.Type),
+		strconv.FormatInt(record.Size, 10),
+		record.Checksum,
+		record.Owner,
+		record.ModifyTime.UTC().Format(time.RFC3339),
+		metadata,
+	})
+}
+
+func (exporter *csvExporter) flush() error {
+	exporter.writer.Flush()
+	return exporter.writer.Error()
+}
+
+// ExportListing walks the dir tree at root and writes entries to writer, including root, as JSON Lines or CSV.
+// Dirs are listed page by page and entries are written as pages arrive, so only a page of entries and paths of dirs to visit are kept in memory.
+// Entries are listed without cache.
+func (fs *FileSystem) ExportListing(root string, writer io.Writer, options *ListingExportOptions) (*ListingExportResult, error) {
+	irodsRootPath := util.GetCorrectIRODSPath(root)
+
+	if options == nil {
+		options = &ListingExportOptions{}
+	}
+
+	err := options.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var exporter listingExporter
+	switch options.Format {
+	case ListingExportCSV:
+		csvWriter := csv.NewWriter(writer)
+		err = csvWriter.Write(listingExportCSVHeader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to write csv header")
+		}
+		exporter = &csvExporter{writer: csvWriter}
+	default:
+		exporter = &jsonLinesExporter{encoder: json.NewEncoder(writer)}
+	}
+
+	rootEntry, err := fs.StatNoCache(irodsRootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListingExportResult{}
+
+	err = fs.exportListingEntry(rootEntry, exporter, options, result)
+	if err != nil {
+		return result, err
+	}
+
+	type pendingDir struct {
+		path  string
+		depth int // depth of entries in the dir
+	}
+
+	pendingDirs := []pendingDir{}
+	if rootEntry.IsDir() {
+		pendingDirs = append(pendingDirs, pendingDir{path: rootEntry.Path, depth: 1})
+	}
+
+	for len(pendingDirs) > 0 {
+		dir := pendingDirs[len(pendingDirs)-1]
+		pendingDirs = pendingDirs[:len(pendingDirs)-1]
+
+		subDirPaths, err := fs.exportListingDir(dir.path, exporter, options, result)
+		if err != nil {
+			return result, err
+		}
+
+		if options.MaxDepth > 0 && dir.depth >= options.MaxDepth {
+			continue
+		}
+
+		// push in reverse order to visit sub dirs in listing order
+		for i := len(subDirPaths) - 1; i >= 0; i-- {
+			pendingDirs = append(pendingDirs, pendingDir{path: subDirPaths[i], depth: dir.depth + 1})
+		}
+	}
+
+	err = exporter.flush()
+	if err != nil {
+		return result, errors.Wrapf(err, "failed to flush listing export")
+	}
+
+	return result, nil
+}
+
+// exportListingDir exports entries in the dir, returns paths of sub dirs
+func (fs *FileSystem) exportListingDir(dirPath string, exporter listingExporter, options *ListingExportOptions, result *ListingExportResult) ([]string, error) {
+	iterator, err := fs.ListIteratorWithOptions(dirPath, &ListOptions{
+		PageSize:     options.PageSize,
+		WithMetadata: options.IncludeMetadata,
+	})
+	if err != nil {
+		return nil, fs.handleListingExportError(err, dirPath, "failed to list dir", options, result)
+	}
+	defer iterator.Close() //nolint
+
+	subDirPaths := []string{}
+	for {
+		entries, err := iterator.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return subDirPaths, fs.handleListingExportError(err, dirPath, "failed to list dir", options, result)
+		}
+
+		for _, entry := range entries {
+			err = fs.exportListingEntry(entry, exporter, options, result)
+			if err != nil {
+				return nil, err
+			}
+
+			if entry.IsDir() {
+				subDirPaths = append(subDirPaths, entry.Path)
+			}
+		}
+
+		// write out a page at a time
+		err = exporter.flush()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to flush listing export")
+		}
+	}
+
+	return subDirPaths, nil
+}
+
+// exportListingEntry writes a record for the entry
+func (fs *FileSystem) exportListingEntry(entry *Entry, exporter listingExporter, options *ListingExportOptions, result *ListingExportResult) error {
+	if entry.IsDir() && options.FilesOnly {
+		return nil
+	}
+
+	record := &ListingExportRecord{
+		Path:       entry.Path,
+		Type:       entry.Type,
+		Size:       entry.Size,
+		Owner:      entry.Owner,
+		ModifyTime: entry.ModifyTime,
+	}
+
+	if len(entry.CheckSum) > 0 {
+		checksum, err := types.MakeIRODSChecksumString(entry.CheckSumAlgorithm, entry.CheckSum)
+		if err != nil {
+			log.WithError(err).Debugf("failed to make checksum string for %q", entry.Path)
+		} else {
+			record.Checksum = checksum
+		}
+	}
+
+	if options.IncludeMetadata {
+		metadata := entry.Metadata
+		var err error
+		if metadata == nil {
+			// root is not listed with metadata
+			metadata, err = fs.listEntryMetadataNoCache(entry)
+		}
+
+		if err != nil {
+			// the entry is still exported without metadata if the error is skipped
+			err = fs.handleListingExportError(err, entry.Path, "failed to list metadata", options, result)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, meta := range metadata {
+			record.Metadata = append(record.Metadata, ListingExportMeta{
+				Name:  meta.Name,
+				Value: meta.Value,
+				Units: meta.Units,
+			})
+		}
+	}
+
+	err := exporter.write(record)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write listing export record for %q", entry.Path)
+	}
+
+	if entry.IsDir() {
+		result.Dirs++
+	} else {
+		result.Files++
+		result.TotalSize += entry.Size
+	}
+
+	return nil
+}
+
+// handleListingExportError returns the error unless it is skipped by the error policy
+func (fs *FileSystem) handleListingExportError(err error, irodsPath string, message string, options *ListingExportOptions, result *ListingExportResult) error {
+	if options.ErrorPolicy == WalkErrorSkip {
+		log.WithError(err).Debugf("%s %q, skipping", message, irodsPath)
+		result.Errors++
+		return nil
+	}
+
+	return errors.Wrapf(err, "%s %q", message, irodsPath)
+}
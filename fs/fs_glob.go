@@ -0,0 +1,161 @@
+package fs
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+const (
+	globAnyComponents string = "**"
+)
+
+// Glob returns entries whose paths match the pattern, sorted by path
+// * and ? match characters within a path component, ** matches zero or more path components, [] ranges are also supported.
+// The pattern is translated into catalog LIKE queries to narrow down candidates,
+// then the candidates are matched against the pattern on the client as LIKE cannot express all of the pattern.
+func (fs *FileSystem) Glob(pattern string) ([]*Entry, error) {
+	irodsPattern := util.GetCorrectIRODSPath(pattern)
+	patternComponents := splitGlobPath(irodsPattern)
+
+	hasWildcard := false
+	for _, component := range patternComponents {
+		if component != globAnyComponents {
+			// check syntax
+			_, err := path.Match(component, "")
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid glob pattern %q", irodsPattern)
+			}
+		}
+
+		if strings.ContainsAny(component, `*?[\`) {
+			hasWildcard = true
+		}
+	}
+
+	if !hasWildcard {
+		entry, err := fs.StatNoCache(irodsPattern)
+		if err != nil {
+			if types.IsFileNotFoundError(err) {
+				return []*Entry{}, nil
+			}
+			return nil, err
+		}
+
+		return []*Entry{entry}, nil
+	}
+
+	collSqlWildcard := getGlobSQLWildcard(patternComponents)
+
+	lastComponent := patternComponents[len(patternComponents)-1]
+	dirnameSqlWildcard := collSqlWildcard
+	basenameSqlWildcard := "%"
+	if lastComponent != globAnyComponents {
+		dirnameSqlWildcard = getGlobSQLWildcard(patternComponents[:len(patternComponents)-1])
+		basenameSqlWildcard = util.UnixWildcardsToSQLWildcards(lastComponent)
+	}
+
+	results := []*Entry{}
+
+	// we use ioSession to acquire connection as it can take a long time
+	err := fs.ioSession.RunIdempotentOperation(true, func(conn *connection.IRODSConnection) error {
+		results = []*Entry{}
+
+		collections, err := irods_fs.SearchCollectionsSQLWildcard(conn, collSqlWildcard)
+		if err != nil {
+			return err
+		}
+
+		for _, collection := range collections {
+			if matchGlobComponents(patternComponents, splitGlobPath(collection.Path)) {
+				results = append(results, NewEntryFromCollection(collection))
+			}
+		}
+
+		dataObjects, err := irods_fs.SearchDataObjectsSQLWildcard(conn, dirnameSqlWildcard, basenameSqlWildcard)
+		if err != nil {
+			return err
+		}
+
+		for _, dataObject := range dataObjects {
+			if matchGlobComponents(patternComponents, splitGlobPath(dataObject.Path)) {
+				results = append(results, NewEntryFromDataObject(dataObject))
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(results, func(i int, j int) bool {
+		return results[i].Path < results[j].Path
+	})
+
+	return results, nil
+}
+
+// splitGlobPath splits the path into components, the root has no components
+func splitGlobPath(p string) []string {
+	trimmed := strings.Trim(p, "/")
+	if len(trimmed) == 0 {
+		return []string{}
+	}
+
+	return strings.Split(trimmed, "/")
+}
+
+// getGlobSQLWildcard returns a SQL LIKE pattern matching paths that the components can match, it may match more
+// ** and the slash before it become % so that it also matches zero components
+func getGlobSQLWildcard(components []string) string {
+	sb := strings.Builder{}
+	for _, component := range components {
+		if component == globAnyComponents {
+			sb.WriteString("%")
+			continue
+		}
+
+		sb.WriteString("/")
+		sb.WriteString(util.UnixWildcardsToSQLWildcards(component))
+	}
+
+	if sb.Len() == 0 {
+		return "/"
+	}
+
+	return sb.String()
+}
+
+// matchGlobComponents returns true if the path components match the pattern components
+func matchGlobComponents(patternComponents []string, pathComponents []string) bool {
+	if len(patternComponents) == 0 {
+		return len(pathComponents) == 0
+	}
+
+	if patternComponents[0] == globAnyComponents {
+		for i := 0; i <= len(pathComponents); i++ {
+			if matchGlobComponents(patternComponents[1:], pathComponents[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(pathComponents) == 0 {
+		return false
+	}
+
+	matched, err := path.Match(patternComponents[0], pathComponents[0])
+	if err != nil || !matched {
+		return false
+	}
+
+	return matchGlobComponents(patternComponents[1:], pathComponents[1:])
+}
@@ -0,0 +1,410 @@
+package fs
+
+import (
+	"io"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// ListIteratorPageSizeDefault is a default number of rows ListIterator reads per page
+	ListIteratorPageSizeDefault int = common.MaxQueryRows
+)
+
+// ListSortField is a field that ListWithOptions sorts entries by
+type ListSortField = irods_fs.ListSortField
+
+const (
+	// ListSortByName sorts entries by name
+	ListSortByName ListSortField = irods_fs.ListSortByName
+	// ListSortBySize sorts entries by size, dirs are sorted by name
+	ListSortBySize ListSortField = irods_fs.ListSortBySize
+	// ListSortByModifyTime sorts entries by modify time
+	ListSortByModifyTime ListSortField = irods_fs.ListSortByModifyTime
+)
+
+// ListOptions is options for ListWithOptions and ListIteratorWithOptions
+type ListOptions struct {
+	SortBy         ListSortField // empty uses the server order
+	SortDescending bool
+	NamePattern    string    // SQL LIKE pattern of entry names, e.g., "%.txt", empty matches all
+	Type           EntryType // list entries of the type only, empty lists both dirs and files
+	PageSize       int       // rows read per page, 0 uses ListIteratorPageSizeDefault
+	WithMetadata   bool      // fill Metadata of entries, AVUs are listed in a query per page
+}
+
+// Validate validates list options
+func (options *ListOptions) Validate() error {
+	if options.PageSize < 0 {
+		return errors.Errorf("page size must be positive")
+	}
+
+	switch options.Type {
+	case "", FileEntry, DirectoryEntry:
+	default:
+		return errors.Errorf("unknown entry type %q", options.Type)
+	}
+
+	return options.getQueryOptions().Validate()
+}
+
+func (options *ListOptions) getPageSize() int {
+	if options.PageSize <= 0 {
+		return ListIteratorPageSizeDefault
+	}
+	return options.PageSize
+}
+
+// getListedObjects returns a set to dedupe data objects by ID
+// sorting by a replica column, e.g., size, splits rows of a data object if its replicas differ
+func (options *ListOptions) getListedObjects() map[int64]bool {
+	switch options.SortBy {
+	case ListSortBySize, ListSortByModifyTime:
+		return map[int64]bool{}
+	default:
+		return nil
+	}
+}
+
+func (options *ListOptions) getQueryOptions() *irods_fs.ListQueryOptions {
+	return &irods_fs.ListQueryOptions{
+		SortBy:         options.SortBy,
+		SortDescending: options.SortDescending,
+		NamePattern:    options.NamePattern,
+	}
+}
+
+// ListIterator lists entries of a collection page by page, sub dirs first, then files.
+// Only a page of entries is kept in memory, entries are not cached.
+// The iterator holds a connection until it reaches the end or is closed, so Close must be called.
+type ListIterator struct {
+	filesystem *FileSystem
+	path       string
+	pageSize   int
+	options    *irods_fs.ListQueryOptions
+	entryType  EntryType
+	conn       *connection.IRODSConnection
+	metadata   bool
+
+	listingFiles  bool
+	continueIndex int
+	pendingObject *types.IRODSDataObject // last data object of the previous page, its other replicas may be in the next page
+	listedObjects map[int64]bool         // IDs of data objects returned, set if rows of a data object may not be adjacent
+	done          bool
+	closed        bool
+	mutex         sync.Mutex
+}
+
+// ListIterator returns an iterator listing entries of the dir at the given path page by page
+func (fs *FileSystem) ListIterator(irodsPath string) (*ListIterator, error) {
+	return fs.ListIteratorWithPageSize(irodsPath, ListIteratorPageSizeDefault)
+}
+
+// ListIteratorWithPageSize returns an iterator listing entries of the dir page by page, reading up to pageSize rows per page
+func (fs *FileSystem) ListIteratorWithPageSize(irodsPath string, pageSize int) (*ListIterator, error) {
+	if pageSize <= 0 {
+		return nil, errors.Errorf("page size must be positive")
+	}
+
+	return fs.ListIteratorWithOptions(irodsPath, &ListOptions{
+		PageSize: pageSize,
+	})
+}
+
+// ListIteratorWithOptions returns an iterator listing entries of the dir page by page, sorted and filtered by the server
+func (fs *FileSystem) ListIteratorWithOptions(irodsPath string, options *ListOptions) (*ListIterator, error) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	if options == nil {
+		options = &ListOptions{}
+	}
+
+	err := options.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := fs.StatNoCache(irodsCorrectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !entry.IsDir() {
+		return nil, errors.Errorf("path %q is not a dir", irodsCorrectPath)
+	}
+
+	// continue index of a query is bound to the connection, so the connection is not shared
+	conn, err := fs.metadataSession.AcquireConnection(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListIterator{
+		filesystem: fs,
+		path:       irodsCorrectPath,
+		pageSize:   options.getPageSize(),
+		options:    options.getQueryOptions(),
+		entryType:  options.Type,
+		conn:       conn,
+		metadata:   options.WithMetadata,
+		// skip dirs if only files are listed
+		listingFiles:  options.Type == FileEntry,
+		listedObjects: options.getListedObjects(),
+	}, nil
+}
+
+// ListWithOptions lists entries of the dir at the given path, sorted and filtered by the server
+// sub dirs come first, then files, each sorted by the options
+// entries are not cached
+func (fs *FileSystem) ListWithOptions(irodsPath string, options *ListOptions) ([]*Entry, error) {
+	iterator, err := fs.ListIteratorWithOptions(irodsPath, options)
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close() //nolint
+
+	entries := []*Entry{}
+	for {
+		page, err := iterator.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		entries = append(entries, page...)
+	}
+
+	return entries, nil
+}
+
+// ListEntryCallback is a callback of ListWithCallback, returning false stops listing
+type ListEntryCallback func(entry *Entry) bool
+
+// ListWithCallback lists entries of the dir at the given path and calls fn for each entry as pages arrive
+// listing stops when fn returns false, remaining pages are not read
+func (fs *FileSystem) ListWithCallback(irodsPath string, fn ListEntryCallback) error {
+	iterator, err := fs.ListIterator(irodsPath)
+	if err != nil {
+		return err
+	}
+
+	for {
+		entries, err := iterator.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			iterator.Close() //nolint
+			return err
+		}
+
+		for _, entry := range entries {
+			if !fn(entry) {
+				return iterator.Close()
+			}
+		}
+	}
+
+	return iterator.Close()
+}
+
+// GetPath returns the path of the dir being listed
+func (iter *ListIterator) GetPath() string {
+	return iter.path
+}
+
+// Next returns the next page of entries, returns io.EOF after the last page
+// a page may have fewer entries than the page size as replicas of a file are merged into an entry
+func (iter *ListIterator) Next() ([]*Entry, error) {
+	iter.mutex.Lock()
+	defer iter.mutex.Unlock()
+
+	if iter.closed {
+		return nil, errors.Errorf("list iterator for %q is closed", iter.path)
+	}
+
+	for !iter.done {
+		var entries []*Entry
+		var err error
+		if iter.listingFiles {
+			entries, err = iter.nextFiles()
+		} else {
+			entries, err = iter.nextDirs()
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if iter.metadata && len(entries) > 0 {
+			err = iter.fillMetadata(entries)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if iter.done {
+			// no more queries are needed
+			iter.releaseConnection()
+		}
+
+		if len(entries) > 0 {
+			return entries, nil
+		}
+	}
+
+	return nil, io.EOF
+}
+
+func (iter *ListIterator) nextDirs() ([]*Entry, error) {
+	collections, nextContinueIndex, err := irods_fs.ListSubCollectionsPage(iter.conn, iter.path, iter.continueIndex, iter.pageSize, iter.options)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*Entry, 0, len(collections))
+	for _, collection := range collections {
+		entries = append(entries, NewEntryFromCollection(collection))
+	}
+
+	iter.continueIndex = nextContinueIndex
+	if nextContinueIndex == 0 {
+		iter.listingFiles = true
+		if iter.entryType == DirectoryEntry {
+			// skip files if only dirs are listed
+			iter.done = true
+		}
+	}
+
+	return entries, nil
+}
+
+func (iter *ListIterator) nextFiles() ([]*Entry, error) {
+	dataObjects, nextContinueIndex, err := irods_fs.ListDataObjectsPage(iter.conn, iter.path, iter.continueIndex, iter.pageSize, iter.options)
+	if err != nil {
+		return nil, err
+	}
+
+	// data objects are deduped by ID, so the first row of a data object decides its position
+	pageObjects := []*types.IRODSDataObject{}
+	pageObjectMap := map[int64]*types.IRODSDataObject{}
+	if iter.pendingObject != nil {
+		pageObjectMap[iter.pendingObject.ID] = iter.pendingObject
+	}
+
+	for _, dataObject := range dataObjects {
+		if pageObject, ok := pageObjectMap[dataObject.ID]; ok {
+			// another replica
+			pageObject.Replicas = append(pageObject.Replicas, dataObject.Replicas...)
+			continue
+		}
+
+		if iter.listedObjects != nil && iter.listedObjects[dataObject.ID] {
+			// another replica of a data object returned in a previous page
+			continue
+		}
+
+		if iter.pendingObject != nil {
+			pageObjects = append(pageObjects, iter.pendingObject)
+		}
+		iter.pendingObject = dataObject
+		pageObjectMap[dataObject.ID] = dataObject
+	}
+
+	iter.continueIndex = nextContinueIndex
+	if nextContinueIndex == 0 {
+		if iter.pendingObject != nil {
+			pageObjects = append(pageObjects, iter.pendingObject)
+			iter.pendingObject = nil
+		}
+
+		iter.done = true
+	}
+
+	entries := make([]*Entry, 0, len(pageObjects))
+	for _, pageObject := range pageObjects {
+		if iter.listedObjects != nil {
+			iter.listedObjects[pageObject.ID] = true
+		}
+		entries = append(entries, NewEntryFromDataObject(pageObject))
+	}
+
+	return entries, nil
+}
+
+// fillMetadata fills metadata of entries in a page, entries in a page are either dirs or files
+func (iter *ListIterator) fillMetadata(entries []*Entry) error {
+	ids := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+
+	var metas map[int64][]*types.IRODSMeta
+	var err error
+	if entries[0].IsDir() {
+		metas, err = irods_fs.ListSubCollectionsMeta(iter.conn, iter.path, ids)
+	} else {
+		metas, err = irods_fs.ListDataObjectsMetaInCollection(iter.conn, iter.path, ids)
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "failed to list metadata of entries in %q", iter.path)
+	}
+
+	for _, entry := range entries {
+		entry.Metadata = metas[entry.ID]
+		if entry.Metadata == nil {
+			entry.Metadata = []*types.IRODSMeta{}
+		}
+	}
+
+	return nil
+}
+
+// Close stops listing and releases the connection, it can be called multiple times
+func (iter *ListIterator) Close() error {
+	iter.mutex.Lock()
+	defer iter.mutex.Unlock()
+
+	if iter.closed {
+		return nil
+	}
+
+	iter.closed = true
+	iter.pendingObject = nil
+
+	if iter.conn == nil {
+		return nil
+	}
+
+	// stopped early, close the open query on the server
+	err := irods_fs.CloseQuery(iter.conn, iter.continueIndex)
+	if err != nil {
+		log.WithError(err).Debugf("failed to close list query for %q", iter.path)
+		iter.filesystem.metadataSession.DiscardConnection(iter.conn)
+		iter.conn = nil
+		return err
+	}
+
+	iter.releaseConnection()
+	return nil
+}
+
+func (iter *ListIterator) releaseConnection() {
+	if iter.conn == nil {
+		return
+	}
+
+	iter.filesystem.metadataSession.ReturnConnection(iter.conn) //nolint
+	iter.conn = nil
+}
@@ -1,14 +1,24 @@
 package fs
 
 import (
+	"time"
+
 	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
 	"github.com/cyverse/go-irodsclient/irods/types"
 	"github.com/cyverse/go-irodsclient/irods/util"
 )
 
+// SearchOptions is options for searches, such as case-insensitive matching
+type SearchOptions = irods_fs.SearchOptions
+
 // SearchByMeta searches all file system entries with given metadata
 func (fs *FileSystem) SearchByMeta(metaname string, metavalue string) ([]*Entry, error) {
-	return fs.searchEntriesByMeta(metaname, metavalue)
+	return fs.searchEntriesByMeta(metaname, metavalue, nil)
+}
+
+// SearchByMetaWithOptions searches all file system entries with given metadata using the search options
+func (fs *FileSystem) SearchByMetaWithOptions(metaname string, metavalue string, options *SearchOptions) ([]*Entry, error) {
+	return fs.searchEntriesByMeta(metaname, metavalue, options)
 }
 
 // ListMetadata lists metadata for the given path
@@ -22,6 +32,18 @@ func (fs *FileSystem) ListMetadata(irodsPath string) ([]*types.IRODSMeta, error)
 	}
 
 	// otherwise, retrieve it and add it to cache
+	return fs.listMetadataNoCache(irodsCorrectPath)
+}
+
+// ListMetadataNoCache lists metadata for the given path retrieved from the server, bypassing cache
+// cache is refreshed with the result
+func (fs *FileSystem) ListMetadataNoCache(irodsPath string) ([]*types.IRODSMeta, error) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	return fs.listMetadataNoCache(irodsCorrectPath)
+}
+
+func (fs *FileSystem) listMetadataNoCache(irodsCorrectPath string) ([]*types.IRODSMeta, error) {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return nil, err
@@ -48,8 +70,58 @@ func (fs *FileSystem) ListMetadata(irodsPath string) ([]*types.IRODSMeta, error)
 	return metadataobjects, nil
 }
 
+// listEntryMetadataNoCache lists metadata of the entry, without stat and caching as listMetadataNoCache does
+func (fs *FileSystem) listEntryMetadataNoCache(entry *Entry) ([]*types.IRODSMeta, error) {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return nil, err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	if entry.IsDir() {
+		return irods_fs.ListCollectionMeta(conn, entry.Path)
+	}
+	return irods_fs.ListDataObjectMeta(conn, entry.Path)
+}
+
+// StatWithMetadata returns an entry for the path with Metadata filled
+// the entry and metadata are read from cache if available, the returned entry is a copy
+func (fs *FileSystem) StatWithMetadata(irodsPath string) (*Entry, error) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	entry, err := fs.Stat(irodsCorrectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := fs.cache.GetMetadataCache(irodsCorrectPath)
+	if metadata == nil {
+		metadata, err = fs.listEntryMetadataNoCache(entry)
+		if err != nil {
+			return nil, err
+		}
+
+		fs.cache.AddMetadataCache(irodsCorrectPath, metadata)
+	}
+
+	// cached entries are shared, so metadata is set on a copy
+	entryWithMetadata := *entry
+	entryWithMetadata.Metadata = metadata
+	return &entryWithMetadata, nil
+}
+
 // AddMetadata adds a metadata for the path
 func (fs *FileSystem) AddMetadata(irodsPath string, attName string, attValue string, attUnits string) error {
+	startTime := time.Now()
+	err := fs.addMetadata(irodsPath, attName, attValue, attUnits)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditAddMetadata,
+		Path:      irodsPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) addMetadata(irodsPath string, attName string, attValue string, attUnits string) error {
 	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
 
 	metadata := &types.IRODSMeta{
@@ -82,6 +154,16 @@ func (fs *FileSystem) AddMetadata(irodsPath string, attName string, attValue str
 
 // DeleteMetadata deletes a metadata for the path
 func (fs *FileSystem) DeleteMetadata(irodsPath string, avuID int64) error {
+	startTime := time.Now()
+	err := fs.deleteMetadata(irodsPath, avuID)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditDeleteMetadata,
+		Path:      irodsPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) deleteMetadata(irodsPath string, avuID int64) error {
 	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
 
 	metadata := &types.IRODSMeta{
@@ -112,6 +194,16 @@ func (fs *FileSystem) DeleteMetadata(irodsPath string, avuID int64) error {
 
 // DeleteMetadataByName deletes a metadata for the path by name
 func (fs *FileSystem) DeleteMetadataByName(irodsPath string, attName string) error {
+	startTime := time.Now()
+	err := fs.deleteMetadataByName(irodsPath, attName)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditDeleteMetadata,
+		Path:      irodsPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) deleteMetadataByName(irodsPath string, attName string) error {
 	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
 
 	metadata := &types.IRODSMeta{
@@ -143,6 +235,16 @@ func (fs *FileSystem) DeleteMetadataByName(irodsPath string, attName string) err
 
 // DeleteMetadataByAVU deletes a metadata for the path by AVU
 func (fs *FileSystem) DeleteMetadataByAVU(irodsPath string, attName string, attValue string, attUnits string) error {
+	startTime := time.Now()
+	err := fs.deleteMetadataByAVU(irodsPath, attName, attValue, attUnits)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditDeleteMetadata,
+		Path:      irodsPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) deleteMetadataByAVU(irodsPath string, attName string, attValue string, attUnits string) error {
 	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
 
 	metadata := &types.IRODSMeta{
@@ -176,6 +278,16 @@ func (fs *FileSystem) DeleteMetadataByAVU(irodsPath string, attName string, attV
 
 // AddUserMetadata adds a user metadata
 func (fs *FileSystem) AddUserMetadata(username string, zoneName string, attName string, attValue string, attUnits string) error {
+	startTime := time.Now()
+	err := fs.addUserMetadata(username, zoneName, attName, attValue, attUnits)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditAddUserMetadata,
+		Target:    username + "#" + zoneName,
+	})
+	return err
+}
+
+func (fs *FileSystem) addUserMetadata(username string, zoneName string, attName string, attValue string, attUnits string) error {
 	metadata := &types.IRODSMeta{
 		Name:  attName,
 		Value: attValue,
@@ -198,6 +310,16 @@ func (fs *FileSystem) AddUserMetadata(username string, zoneName string, attName
 
 // DeleteUserMetadata deletes a user metadata
 func (fs *FileSystem) DeleteUserMetadata(username string, zoneName string, avuID int64) error {
+	startTime := time.Now()
+	err := fs.deleteUserMetadata(username, zoneName, avuID)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditDeleteUserMetadata,
+		Target:    username + "#" + zoneName,
+	})
+	return err
+}
+
+func (fs *FileSystem) deleteUserMetadata(username string, zoneName string, avuID int64) error {
 	metadata := &types.IRODSMeta{
 		AVUID: avuID,
 	}
@@ -218,6 +340,16 @@ func (fs *FileSystem) DeleteUserMetadata(username string, zoneName string, avuID
 
 // DeleteUserMetadataByName deletes a user metadata by name
 func (fs *FileSystem) DeleteUserMetadataByName(username string, zoneName string, attName string) error {
+	startTime := time.Now()
+	err := fs.deleteUserMetadataByName(username, zoneName, attName)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditDeleteUserMetadata,
+		Target:    username + "#" + zoneName,
+	})
+	return err
+}
+
+func (fs *FileSystem) deleteUserMetadataByName(username string, zoneName string, attName string) error {
 	metadata := &types.IRODSMeta{
 		AVUID: 0,
 		Name:  attName,
@@ -239,6 +371,16 @@ func (fs *FileSystem) DeleteUserMetadataByName(username string, zoneName string,
 
 // DeleteUserMetadataByAVU deletes a user metadata by AVU
 func (fs *FileSystem) DeleteUserMetadataByAVU(username string, zoneName string, attName string, attValue string, attUnits string) error {
+	startTime := time.Now()
+	err := fs.deleteUserMetadataByAVU(username, zoneName, attName, attValue, attUnits)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditDeleteUserMetadata,
+		Target:    username + "#" + zoneName,
+	})
+	return err
+}
+
+func (fs *FileSystem) deleteUserMetadataByAVU(username string, zoneName string, attName string, attValue string, attUnits string) error {
 	metadata := &types.IRODSMeta{
 		AVUID: 0,
 		Name:  attName,
@@ -278,6 +420,16 @@ func (fs *FileSystem) ListUserMetadata(username string, zoneName string) ([]*typ
 
 // AddResourceMetadata adds a resource metadata
 func (fs *FileSystem) AddResourceMetadata(resource string, attName string, attValue string, attUnits string) error {
+	startTime := time.Now()
+	err := fs.addResourceMetadata(resource, attName, attValue, attUnits)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditAddResourceMetadata,
+		Target:    resource,
+	})
+	return err
+}
+
+func (fs *FileSystem) addResourceMetadata(resource string, attName string, attValue string, attUnits string) error {
 	metadata := &types.IRODSMeta{
 		Name:  attName,
 		Value: attValue,
@@ -300,6 +452,16 @@ func (fs *FileSystem) AddResourceMetadata(resource string, attName string, attVa
 
 // DeleteResourceMetadata deletes a resource metadata
 func (fs *FileSystem) DeleteResourceMetadata(resource string, avuID int64) error {
+	startTime := time.Now()
+	err := fs.deleteResourceMetadata(resource, avuID)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditDeleteResourceMetadata,
+		Target:    resource,
+	})
+	return err
+}
+
+func (fs *FileSystem) deleteResourceMetadata(resource string, avuID int64) error {
 	metadata := &types.IRODSMeta{
 		AVUID: avuID,
 	}
@@ -320,6 +482,16 @@ func (fs *FileSystem) DeleteResourceMetadata(resource string, avuID int64) error
 
 // DeleteResourceMetadataByName deletes a resource metadata by name
 func (fs *FileSystem) DeleteResourceMetadataByName(resource string, attName string) error {
+	startTime := time.Now()
+	err := fs.deleteResourceMetadataByName(resource, attName)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditDeleteResourceMetadata,
+		Target:    resource,
+	})
+	return err
+}
+
+func (fs *FileSystem) deleteResourceMetadataByName(resource string, attName string) error {
 	metadata := &types.IRODSMeta{
 		AVUID: 0,
 		Name:  attName,
@@ -341,6 +513,16 @@ func (fs *FileSystem) DeleteResourceMetadataByName(resource string, attName stri
 
 // DeleteResourceMetadataByAVU deletes a resource metadata by AVU
 func (fs *FileSystem) DeleteResourceMetadataByAVU(resource string, attName string, attValue string, attUnits string) error {
+	startTime := time.Now()
+	err := fs.deleteResourceMetadataByAVU(resource, attName, attValue, attUnits)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditDeleteResourceMetadata,
+		Target:    resource,
+	})
+	return err
+}
+
+func (fs *FileSystem) deleteResourceMetadataByAVU(resource string, attName string, attValue string, attUnits string) error {
 	metadata := &types.IRODSMeta{
 		AVUID: 0,
 		Name:  attName,
@@ -379,14 +561,14 @@ func (fs *FileSystem) ListResourceMetadata(resource string) ([]*types.IRODSMeta,
 }
 
 // searchEntriesByMeta searches entries by meta
-func (fs *FileSystem) searchEntriesByMeta(metaName string, metaValue string) ([]*Entry, error) {
+func (fs *FileSystem) searchEntriesByMeta(metaName string, metaValue string, options *SearchOptions) ([]*Entry, error) {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return nil, err
 	}
 	defer fs.metadataSession.ReturnConnection(conn) //nolint
 
-	collections, err := irods_fs.SearchCollectionsByMeta(conn, metaName, metaValue)
+	collections, err := irods_fs.SearchCollectionsByMetaWithOptions(conn, metaName, metaValue, options)
 	if err != nil {
 		return nil, err
 	}
@@ -402,7 +584,7 @@ func (fs *FileSystem) searchEntriesByMeta(metaName string, metaValue string) ([]
 		fs.cache.AddEntryCache(entry)
 	}
 
-	dataobjects, err := irods_fs.SearchDataObjectsMasterReplicaByMeta(conn, metaName, metaValue)
+	dataobjects, err := irods_fs.SearchDataObjectsMasterReplicaByMetaWithOptions(conn, metaName, metaValue, options)
 	if err != nil {
 		return nil, err
 	}
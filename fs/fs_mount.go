@@ -0,0 +1,79 @@
+package fs
+
+import (
+	"time"
+
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+// MountDir mounts a source as a special dir, equivalent to imcoll -m
+// source is a physical directory for MountPointCollectionType, a struct file path for TarStructFileCollectionType
+// or a dir path for LinkPointCollectionType
+func (fs *FileSystem) MountDir(irodsPath string, mountType types.SpecialCollectionType, source string, resource string) error {
+	startTime := time.Now()
+	err := fs.mountDir(irodsPath, mountType, source, resource)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditMountDir,
+		Path:      irodsPath,
+		Target:    string(mountType),
+	})
+	return err
+}
+
+func (fs *FileSystem) mountDir(irodsPath string, mountType types.SpecialCollectionType, source string, resource string) error {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	if mountType == types.TarStructFileCollectionType || mountType == types.LinkPointCollectionType {
+		source = util.GetCorrectIRODSPath(source)
+	}
+
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.MountCollection(conn, irodsCorrectPath, mountType, source, resource)
+	if err != nil {
+		return err
+	}
+
+	// the dir may be created, content of the dir is replaced
+	fs.InvalidateCache(irodsCorrectPath, true)
+	fs.InvalidateCacheForDirCreate(irodsCorrectPath)
+	fs.cachePropagation.PropagateDirCreate(irodsCorrectPath)
+	return nil
+}
+
+// UnmountDir unmounts a special dir, equivalent to imcoll -U
+func (fs *FileSystem) UnmountDir(irodsPath string, resource string) error {
+	startTime := time.Now()
+	err := fs.unmountDir(irodsPath, resource)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditUnmountDir,
+		Path:      irodsPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) unmountDir(irodsPath string, resource string) error {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.UnmountCollection(conn, irodsCorrectPath, resource)
+	if err != nil {
+		return err
+	}
+
+	// content of the dir is no longer visible
+	fs.InvalidateCache(irodsCorrectPath, true)
+	fs.InvalidateCacheForDirUpdate(irodsCorrectPath)
+	return nil
+}
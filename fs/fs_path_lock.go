@@ -0,0 +1,177 @@
+package fs
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// lockPaths locks paths modified by an operation, so operations on the same path from this FileSystem are serialized
+// parent dirs of the paths are read-locked, so an operation on a dir waits for operations in its subtree and vice versa
+// if RejectConcurrentPathOperations is set, returns PathInUseError instead of waiting
+// returns a function to unlock the paths
+func (fs *FileSystem) lockPaths(irodsPaths ...string) (func(), error) {
+	// true for write lock, false for read lock of parent dirs
+	lockModes := map[string]bool{}
+	for _, irodsPath := range irodsPaths {
+		irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+		lockModes[irodsCorrectPath] = true
+
+		parentPath := irodsCorrectPath
+		for parentPath != "/" {
+			parentPath = util.GetIRODSPathDirname(parentPath)
+			if _, ok := lockModes[parentPath]; !ok {
+				lockModes[parentPath] = false
+			}
+		}
+	}
+
+	// sort to lock in the same order, avoids deadlock
+	// parent dirs are locked before their entries
+	paths := []string{}
+	for path := range lockModes {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	lockedPaths := []string{}
+	unlock := func() {
+		// unlock in reverse order
+		for i := len(lockedPaths) - 1; i >= 0; i-- {
+			var err error
+			if lockModes[lockedPaths[i]] {
+				err = fs.pathLocks.Unlock(lockedPaths[i])
+			} else {
+				err = fs.pathLocks.RUnlock(lockedPaths[i])
+			}
+
+			if err != nil {
+				log.WithError(err).Errorf("failed to unlock path %q", lockedPaths[i])
+			}
+		}
+	}
+
+	for _, path := range paths {
+		write := lockModes[path]
+
+		if fs.config.RejectConcurrentPathOperations {
+			locked := false
+			if write {
+				locked = fs.pathLocks.TryLock(path)
+			} else {
+				locked = fs.pathLocks.TryRLock(path)
+			}
+
+			if !locked {
+				unlock()
+				return nil, types.NewPathInUseError(path)
+			}
+		} else if write {
+			fs.pathLocks.Lock(path)
+		} else {
+			fs.pathLocks.RLock(path)
+		}
+
+		lockedPaths = append(lockedPaths, path)
+	}
+
+	return unlock, nil
+}
+
+// lockPathsForWrite locks paths like lockPaths, and waits until write file handles of the paths opened by this FileSystem are closed
+// used by operations writing the content of files, not to mix with writes via the file handles
+func (fs *FileSystem) lockPathsForWrite(irodsPaths ...string) (func(), error) {
+	unlock, err := fs.lockPaths(irodsPaths...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, irodsPath := range irodsPaths {
+		err = fs.waitForWriteFileHandles(util.GetCorrectIRODSPath(irodsPath))
+		if err != nil {
+			unlock()
+			return nil, err
+		}
+	}
+
+	return unlock, nil
+}
+
+// waitForWriteFileHandles waits until write file handles of the path are closed
+// if RejectConcurrentPathOperations is set, returns PathInUseError instead of waiting
+func (fs *FileSystem) waitForWriteFileHandles(irodsPath string) error {
+	hasWriteFileHandles := func() bool {
+		for _, handle := range fs.fileHandleMap.ListByPath(irodsPath) {
+			if handle.IsWriteMode() {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !hasWriteFileHandles() {
+		return nil
+	}
+
+	if fs.config.RejectConcurrentPathOperations {
+		return types.NewPathInUseError(irodsPath)
+	}
+
+	closed := make(chan bool, 1)
+	eventHandlerID := fs.fileHandleMap.AddCloseEventHandler(irodsPath, func(path, id string, empty bool) {
+		select {
+		case closed <- true:
+		default:
+		}
+	})
+	defer fs.fileHandleMap.RemoveCloseEventHandler(eventHandlerID)
+
+	timeout := time.After(time.Duration(fs.config.MetadataConnection.OperationTimeout))
+	for hasWriteFileHandles() {
+		select {
+		case <-closed:
+		case <-timeout:
+			return errors.Errorf("failed to lock path %q, there are files still opened for write", irodsPath)
+		}
+	}
+
+	return nil
+}
+
+// withPathsLocked runs fn while the paths are locked
+func (fs *FileSystem) withPathsLocked(fn func() error, irodsPaths ...string) error {
+	unlockPaths, err := fs.lockPaths(irodsPaths...)
+	if err != nil {
+		return err
+	}
+	defer unlockPaths()
+
+	return fn()
+}
+
+// withPathsLockedForWrite runs fn while the paths are locked with lockPathsForWrite
+func (fs *FileSystem) withPathsLockedForWrite(fn func() error, irodsPaths ...string) error {
+	unlockPaths, err := fs.lockPathsForWrite(irodsPaths...)
+	if err != nil {
+		return err
+	}
+	defer unlockPaths()
+
+	return fn()
+}
+
+// getUploadTargetPath returns the path of the data object a local file is uploaded to, the file is uploaded under the dest path if it is a directory
+func (fs *FileSystem) getUploadTargetPath(localPath string, irodsPath string) string {
+	entry, err := fs.Stat(irodsPath)
+	if err == nil && entry.IsDir() {
+		return util.MakeIRODSPath(irodsPath, filepath.Base(localPath))
+	}
+
+	return irodsPath
+}
@@ -0,0 +1,159 @@
+package fs
+
+import (
+	"time"
+
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+// RegisterFile registers an existing physical file on the resource as a file without copying data
+// registerChecksum computes and registers the checksum, verifyChecksum also verifies the checksum after registration
+func (fs *FileSystem) RegisterFile(irodsPath string, physicalPath string, resource string, registerChecksum bool, verifyChecksum bool, force bool) error {
+	startTime := time.Now()
+	err := fs.registerFile(irodsPath, physicalPath, resource, registerChecksum, verifyChecksum, false, force)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditRegisterFile,
+		Path:      irodsPath,
+		Target:    resource,
+	})
+	return err
+}
+
+// RegisterFileReplica registers an existing physical file on the resource as a new replica of the file
+func (fs *FileSystem) RegisterFileReplica(irodsPath string, physicalPath string, resource string, registerChecksum bool, verifyChecksum bool) error {
+	startTime := time.Now()
+	err := fs.registerFile(irodsPath, physicalPath, resource, registerChecksum, verifyChecksum, true, false)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditRegisterFile,
+		Path:      irodsPath,
+		Target:    resource,
+	})
+	return err
+}
+
+func (fs *FileSystem) registerFile(irodsPath string, physicalPath string, resource string, registerChecksum bool, verifyChecksum bool, registerReplica bool, force bool) error {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	// we use ioSession to acquire connection as checksum calculation can take a long time
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.RegisterDataObject(conn, irodsCorrectPath, physicalPath, resource, registerChecksum, verifyChecksum, registerReplica, force)
+	if err != nil {
+		return err
+	}
+
+	if registerReplica {
+		fs.InvalidateCacheForFileUpdate(irodsCorrectPath)
+		fs.cachePropagation.PropagateFileUpdate(irodsCorrectPath)
+		return nil
+	}
+
+	fs.InvalidateCacheForFileCreate(irodsCorrectPath)
+	fs.cachePropagation.PropagateFileCreate(irodsCorrectPath)
+	return nil
+}
+
+// RegisterDir registers an existing physical directory on the resource as a dir without copying data
+// sub-directories and files in the directory are registered recursively
+func (fs *FileSystem) RegisterDir(irodsPath string, physicalPath string, resource string, registerChecksum bool, verifyChecksum bool, force bool) error {
+	startTime := time.Now()
+	err := fs.registerDir(irodsPath, physicalPath, resource, registerChecksum, verifyChecksum, force)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditRegisterDir,
+		Path:      irodsPath,
+		Target:    resource,
+	})
+	return err
+}
+
+func (fs *FileSystem) registerDir(irodsPath string, physicalPath string, resource string, registerChecksum bool, verifyChecksum bool, force bool) error {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	// we use ioSession to acquire connection as it can take a long time
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.RegisterCollection(conn, irodsCorrectPath, physicalPath, resource, registerChecksum, verifyChecksum, force)
+	if err != nil {
+		return err
+	}
+
+	// entries are created under the dir like extraction
+	fs.invalidateCacheForDirExtract(irodsCorrectPath)
+	fs.cachePropagation.PropagateDirExtract(irodsCorrectPath)
+	return nil
+}
+
+// UnregisterFile unregisters a file from the catalog, the physical file is not deleted
+func (fs *FileSystem) UnregisterFile(irodsPath string) error {
+	startTime := time.Now()
+	err := fs.unregisterFile(irodsPath)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditUnregisterFile,
+		Path:      irodsPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) unregisterFile(irodsPath string) error {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.UnregisterDataObject(conn, irodsCorrectPath)
+	if err != nil && !types.IsFileNotFoundError(err) {
+		return err
+	}
+
+	fs.InvalidateCacheForFileRemove(irodsCorrectPath)
+	fs.cachePropagation.PropagateFileRemove(irodsCorrectPath)
+	return err
+}
+
+// UnregisterDir unregisters a dir from the catalog, physical files are not deleted
+func (fs *FileSystem) UnregisterDir(irodsPath string, recurse bool) error {
+	startTime := time.Now()
+	err := fs.unregisterDir(irodsPath, recurse)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditUnregisterDir,
+		Path:      irodsPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) unregisterDir(irodsPath string, recurse bool) error {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	// we use ioSession to acquire connection as it can take a long time
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.UnregisterCollection(conn, irodsCorrectPath, recurse)
+	if err != nil {
+		if types.IsFileNotFoundError(err) {
+			fs.InvalidateCacheForFileRemove(irodsCorrectPath)
+			fs.cachePropagation.PropagateFileRemove(irodsCorrectPath)
+		}
+		return err
+	}
+
+	fs.InvalidateCacheForDirRemove(irodsCorrectPath, recurse)
+	fs.cachePropagation.PropagateDirRemove(irodsCorrectPath)
+	return nil
+}
@@ -0,0 +1,115 @@
+package fs
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// ReplicationPolicy is a policy of ReplicateFileWithOptions on failures
+type ReplicationPolicy string
+
+const (
+	// ReplicationAllRequired requires replicas on all resources, stops at the first failure and returns the error
+	ReplicationAllRequired ReplicationPolicy = "all_required"
+	// ReplicationBestEffort tries all resources, returns an error only if none succeeds
+	ReplicationBestEffort ReplicationPolicy = "best_effort"
+)
+
+// ReplicateFileOptions is options for ReplicateFileWithOptions
+type ReplicateFileOptions struct {
+	Resources []string          // destination resources or resource hierarchies, empty uses the default resource
+	Update    bool              // update stale replicas on the resources
+	Policy    ReplicationPolicy // empty uses ReplicationAllRequired
+	AdminFlag bool              // replicate files of other users, requires rodsadmin privilege
+}
+
+// Validate validates replicate file options
+func (options *ReplicateFileOptions) Validate() error {
+	switch options.Policy {
+	case "", ReplicationAllRequired, ReplicationBestEffort:
+	default:
+		return errors.Errorf("unknown replication policy %q", options.Policy)
+	}
+
+	return nil
+}
+
+// ReplicationOutcome is an outcome of replication to a resource
+type ReplicationOutcome struct {
+	Resource  string        `json:"resource"`
+	Attempted bool          `json:"attempted"` // false if skipped as replication to a previous resource failed
+	Error     error         `json:"-"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// IsSucceeded returns true if the replica is made on the resource
+func (outcome *ReplicationOutcome) IsSucceeded() bool {
+	return outcome.Attempted && outcome.Error == nil
+}
+
+// ReplicateFileWithOptions replicates a file to the resources in order, returns outcomes per resource
+// outcomes are returned with the error
+func (fs *FileSystem) ReplicateFileWithOptions(irodsPath string, options *ReplicateFileOptions) ([]*ReplicationOutcome, error) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	if options == nil {
+		options = &ReplicateFileOptions{}
+	}
+
+	err := options.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	resources := options.Resources
+	if len(resources) == 0 {
+		// default resource
+		resources = []string{""}
+	}
+
+	outcomes := make([]*ReplicationOutcome, 0, len(resources))
+	for _, resource := range resources {
+		outcomes = append(outcomes, &ReplicationOutcome{
+			Resource: resource,
+		})
+	}
+
+	var firstErr error
+	succeeded := 0
+	for _, outcome := range outcomes {
+		startTime := time.Now()
+		outcome.Attempted = true
+		outcome.Error = fs.replicateFile(irodsCorrectPath, outcome.Resource, options.Update, options.AdminFlag)
+		outcome.Duration = time.Since(startTime)
+
+		fs.recordAudit(startTime, outcome.Error, &AuditRecord{
+			Operation: AuditReplicateFile,
+			Path:      irodsPath,
+			Target:    outcome.Resource,
+		})
+
+		if outcome.Error == nil {
+			succeeded++
+			continue
+		}
+
+		if firstErr == nil {
+			firstErr = errors.Wrapf(outcome.Error, "failed to replicate file %q to resource %q", irodsCorrectPath, outcome.Resource)
+		}
+
+		if options.Policy != ReplicationBestEffort {
+			return outcomes, firstErr
+		}
+
+		log.WithError(outcome.Error).Debugf("failed to replicate file %q to resource %q, continuing", irodsCorrectPath, outcome.Resource)
+	}
+
+	if succeeded == 0 {
+		return outcomes, firstErr
+	}
+
+	return outcomes, nil
+}
@@ -0,0 +1,81 @@
+package fs
+
+import (
+	"time"
+
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// resourceHierarchyCacheEntry is a cached resource hierarchy, or a resource not found
+type resourceHierarchyCacheEntry struct {
+	hierarchy  string
+	err        error // ResourceNotFoundError if the resource does not exist
+	expiryTime time.Time
+}
+
+// ResolveResourceHierarchy returns the full resource hierarchy (root;child;leaf) of the resource
+// a resource hierarchy is returned as is, a resource name, e.g., a leaf, is resolved to the hierarchy from the root
+// results, including resources not found, are cached for the cache timeout
+func (fs *FileSystem) ResolveResourceHierarchy(resource string) (string, error) {
+	if len(resource) == 0 || types.IsResourceHierarchy(resource) {
+		return resource, nil
+	}
+
+	fs.resourceHierarchiesMutex.Lock()
+	defer fs.resourceHierarchiesMutex.Unlock()
+
+	if cacheEntry, ok := fs.resourceHierarchies[resource]; ok {
+		if time.Now().Before(cacheEntry.expiryTime) {
+			return cacheEntry.hierarchy, cacheEntry.err
+		}
+
+		delete(fs.resourceHierarchies, resource)
+	}
+
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return "", err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	hierarchy, err := irods_fs.GetResourceHierarchy(conn, resource)
+	if err != nil && !types.IsResourceNotFoundError(err) {
+		return "", err
+	}
+
+	if !fs.config.Cache.NoCache {
+		if fs.resourceHierarchies == nil {
+			fs.resourceHierarchies = map[string]*resourceHierarchyCacheEntry{}
+		}
+
+		fs.resourceHierarchies[resource] = &resourceHierarchyCacheEntry{
+			hierarchy:  hierarchy,
+			err:        err,
+			expiryTime: time.Now().Add(time.Duration(fs.config.Cache.Timeout)),
+		}
+	}
+
+	return hierarchy, err
+}
+
+// InvalidateResourceHierarchyCache invalidates cached resource hierarchies, call it after resources are added, removed, or moved in hierarchies
+func (fs *FileSystem) InvalidateResourceHierarchyCache() {
+	fs.resourceHierarchiesMutex.Lock()
+	defer fs.resourceHierarchiesMutex.Unlock()
+
+	fs.resourceHierarchies = nil
+}
+
+// getTargetResource returns the resource to open or upload to, child resources are targeted with their resource hierarchies
+func (fs *FileSystem) getTargetResource(resource string) string {
+	hierarchy, err := fs.ResolveResourceHierarchy(resource)
+	if err != nil {
+		// let the server decide
+		log.WithError(err).Debugf("failed to resolve resource hierarchy of resource %q", resource)
+		return resource
+	}
+
+	return hierarchy
+}
@@ -1,6 +1,11 @@
 package fs
 
 import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
 	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
 	"github.com/cyverse/go-irodsclient/irods/types"
 	"github.com/cyverse/go-irodsclient/irods/util"
@@ -8,6 +13,17 @@ import (
 
 // ExtractStructFile extracts a struct file
 func (fs *FileSystem) ExtractStructFile(path string, targetCollection string, resource string, dataType types.DataType, force bool, bulkReg bool) error {
+	startTime := time.Now()
+	err := fs.extractStructFile(path, targetCollection, resource, dataType, force, bulkReg)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditExtractStructFile,
+		Path:      path,
+		DestPath:  targetCollection,
+	})
+	return err
+}
+
+func (fs *FileSystem) extractStructFile(path string, targetCollection string, resource string, dataType types.DataType, force bool, bulkReg bool) error {
 	irodsPath := util.GetCorrectIRODSPath(path)
 	targetIrodsPath := util.GetCorrectIRODSPath(targetCollection)
 
@@ -32,3 +48,128 @@ func (fs *FileSystem) ExtractStructFile(path string, targetCollection string, re
 
 	return nil
 }
+
+// BundleStructFile creates a struct file from files in the source collection on the server
+// add appends files to the existing struct file
+func (fs *FileSystem) BundleStructFile(path string, sourceCollection string, resource string, dataType types.DataType, force bool, add bool) error {
+	startTime := time.Now()
+	err := fs.bundleStructFile(path, sourceCollection, resource, dataType, force, add)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditBundleStructFile,
+		Path:      sourceCollection,
+		DestPath:  path,
+	})
+	return err
+}
+
+func (fs *FileSystem) bundleStructFile(path string, sourceCollection string, resource string, dataType types.DataType, force bool, add bool) error {
+	irodsPath := util.GetCorrectIRODSPath(path)
+	sourceIrodsPath := util.GetCorrectIRODSPath(sourceCollection)
+
+	// we use a connection from ioSession as it takes long time to finish.
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.BundleStructFile(conn, irodsPath, sourceIrodsPath, resource, dataType, force, add)
+	if err != nil {
+		return err
+	}
+
+	fs.InvalidateCacheForFileCreate(irodsPath)
+	fs.cachePropagation.PropagateFileCreate(irodsPath)
+
+	return nil
+}
+
+// PhysicalBundleDir bundles small files in the collection into tar files on the bundle resource on the server
+// maxSubFiles and maxBundleSizeGB use server defaults if they are not positive
+func (fs *FileSystem) PhysicalBundleDir(collection string, bundleResource string, maxSubFiles int, maxBundleSizeGB int) error {
+	startTime := time.Now()
+	err := fs.physicalBundleDir(collection, bundleResource, maxSubFiles, maxBundleSizeGB)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditPhysicalBundleDir,
+		Path:      collection,
+		Target:    bundleResource,
+	})
+	return err
+}
+
+func (fs *FileSystem) physicalBundleDir(collection string, bundleResource string, maxSubFiles int, maxBundleSizeGB int) error {
+	irodsPath := util.GetCorrectIRODSPath(collection)
+
+	// we use a connection from ioSession as it takes long time to finish.
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	err = irods_fs.PhysicalBundleCollection(conn, irodsPath, bundleResource, maxSubFiles, maxBundleSizeGB)
+	if err != nil {
+		return err
+	}
+
+	// replicas in the bundle resource are added
+	fs.InvalidateCache(irodsPath, true)
+
+	return nil
+}
+
+// OpenSubStructFile opens a file inside a tar struct file for read, so a single member can be streamed without extracting the struct file
+// subFilePath is relative to the root of the struct file, resource selects the replica to read if given
+func (fs *FileSystem) OpenSubStructFile(structFilePath string, subFilePath string, resource string) (*SubStructFileHandle, error) {
+	irodsPath := util.GetCorrectIRODSPath(structFilePath)
+	subFilePath = strings.TrimPrefix(path.Clean("/"+subFilePath), "/")
+	if len(subFilePath) == 0 {
+		return nil, errors.Errorf("empty sub file path for struct file %q", irodsPath)
+	}
+
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return nil, err
+	}
+
+	dataObject, err := irods_fs.GetDataObject(conn, irodsPath)
+	if err != nil {
+		fs.ioSession.ReturnConnection(conn) //nolint
+		return nil, err
+	}
+
+	var replica *types.IRODSReplica
+	for _, objReplica := range dataObject.Replicas {
+		if len(resource) > 0 && objReplica.ResourceName != resource && !strings.HasPrefix(objReplica.ResourceHierarchy, resource+";") {
+			continue
+		}
+
+		if replica == nil || (replica.Status != string(types.ReplicaStatusGood) && objReplica.Status == string(types.ReplicaStatusGood)) {
+			replica = objReplica
+		}
+	}
+
+	if replica == nil {
+		fs.ioSession.ReturnConnection(conn) //nolint
+		return nil, errors.Errorf("failed to find a replica of struct file %q in resource %q", irodsPath, resource)
+	}
+
+	irodsResource, err := irods_fs.GetResource(conn, replica.ResourceName)
+	if err != nil {
+		fs.ioSession.ReturnConnection(conn) //nolint
+		return nil, err
+	}
+
+	irodsFileHandle, err := irods_fs.OpenSubStructFile(conn, dataObject, replica, irodsResource, subFilePath)
+	if err != nil {
+		fs.ioSession.ReturnConnection(conn) //nolint
+		return nil, err
+	}
+
+	// do not return connection here
+	return &SubStructFileHandle{
+		filesystem:      fs,
+		connection:      conn,
+		irodsFileHandle: irodsFileHandle,
+	}, nil
+}
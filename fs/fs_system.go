@@ -25,3 +25,36 @@ func (fs *FileSystem) ListProcesses(address string, zoneName string) ([]*types.I
 func (fs *FileSystem) ListAllProcesses() ([]*types.IRODSProcess, error) {
 	return fs.ListProcesses("", "")
 }
+
+// GeneralAdmin performs a general admin request for admin actions that do not have dedicated functions
+func (fs *FileSystem) GeneralAdmin(action types.IRODSAdminAction, target types.IRODSAdminTarget, params ...string) error {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	return irods_fs.GeneralAdmin(conn, action, target, params...)
+}
+
+// AddToken adds a token to the namespace
+func (fs *FileSystem) AddToken(namespace types.IRODSTokenNamespace, name string, values ...string) error {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	return irods_fs.AddToken(conn, namespace, name, values...)
+}
+
+// RemoveToken removes a token from the namespace
+func (fs *FileSystem) RemoveToken(namespace types.IRODSTokenNamespace, name string) error {
+	conn, err := fs.metadataSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.metadataSession.ReturnConnection(conn) //nolint
+
+	return irods_fs.RemoveToken(conn, namespace, name)
+}
@@ -152,6 +152,17 @@ func (fs *FileSystem) ListTicketUserGroupRestrictions(ticketID int64) ([]string,
 
 // CreateTicket creates a new ticket
 func (fs *FileSystem) CreateTicket(ticketName string, ticketType types.TicketType, path string) error {
+	startTime := time.Now()
+	err := fs.createTicket(ticketName, ticketType, path)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditCreateTicket,
+		Path:      path,
+		Target:    ticketName,
+	})
+	return err
+}
+
+func (fs *FileSystem) createTicket(ticketName string, ticketType types.TicketType, path string) error {
 	irodsPath := util.GetCorrectIRODSPath(path)
 
 	conn, err := fs.metadataSession.AcquireConnection(true)
@@ -170,6 +181,16 @@ func (fs *FileSystem) CreateTicket(ticketName string, ticketType types.TicketTyp
 
 // DeleteTicket deletes the given ticket
 func (fs *FileSystem) DeleteTicket(ticketName string) error {
+	startTime := time.Now()
+	err := fs.deleteTicket(ticketName)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditDeleteTicket,
+		Target:    ticketName,
+	})
+	return err
+}
+
+func (fs *FileSystem) deleteTicket(ticketName string) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -186,6 +207,16 @@ func (fs *FileSystem) DeleteTicket(ticketName string) error {
 
 // ModifyTicketUseLimit modifies the use limit of the given ticket
 func (fs *FileSystem) ModifyTicketUseLimit(ticketName string, uses int64) error {
+	startTime := time.Now()
+	err := fs.modifyTicketUseLimit(ticketName, uses)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditModifyTicket,
+		Target:    ticketName,
+	})
+	return err
+}
+
+func (fs *FileSystem) modifyTicketUseLimit(ticketName string, uses int64) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -202,6 +233,16 @@ func (fs *FileSystem) ModifyTicketUseLimit(ticketName string, uses int64) error
 
 // ClearTicketUseLimit clears the use limit of the given ticket
 func (fs *FileSystem) ClearTicketUseLimit(ticketName string) error {
+	startTime := time.Now()
+	err := fs.clearTicketUseLimit(ticketName)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditModifyTicket,
+		Target:    ticketName,
+	})
+	return err
+}
+
+func (fs *FileSystem) clearTicketUseLimit(ticketName string) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -218,6 +259,16 @@ func (fs *FileSystem) ClearTicketUseLimit(ticketName string) error {
 
 // ModifyTicketWriteFileLimit modifies the write file limit of the given ticket
 func (fs *FileSystem) ModifyTicketWriteFileLimit(ticketName string, count int64) error {
+	startTime := time.Now()
+	err := fs.modifyTicketWriteFileLimit(ticketName, count)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditModifyTicket,
+		Target:    ticketName,
+	})
+	return err
+}
+
+func (fs *FileSystem) modifyTicketWriteFileLimit(ticketName string, count int64) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -234,6 +285,16 @@ func (fs *FileSystem) ModifyTicketWriteFileLimit(ticketName string, count int64)
 
 // ClearTicketWriteFileLimit clears the write file limit of the given ticket
 func (fs *FileSystem) ClearTicketWriteFileLimit(ticketName string) error {
+	startTime := time.Now()
+	err := fs.clearTicketWriteFileLimit(ticketName)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditModifyTicket,
+		Target:    ticketName,
+	})
+	return err
+}
+
+func (fs *FileSystem) clearTicketWriteFileLimit(ticketName string) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -250,6 +311,16 @@ func (fs *FileSystem) ClearTicketWriteFileLimit(ticketName string) error {
 
 // ModifyTicketWriteByteLimit modifies the write byte limit of the given ticket
 func (fs *FileSystem) ModifyTicketWriteByteLimit(ticketName string, bytes int64) error {
+	startTime := time.Now()
+	err := fs.modifyTicketWriteByteLimit(ticketName, bytes)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditModifyTicket,
+		Target:    ticketName,
+	})
+	return err
+}
+
+func (fs *FileSystem) modifyTicketWriteByteLimit(ticketName string, bytes int64) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -266,6 +337,16 @@ func (fs *FileSystem) ModifyTicketWriteByteLimit(ticketName string, bytes int64)
 
 // ClearTicketWriteByteLimit clears the write byte limit of the given ticket
 func (fs *FileSystem) ClearTicketWriteByteLimit(ticketName string) error {
+	startTime := time.Now()
+	err := fs.clearTicketWriteByteLimit(ticketName)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditModifyTicket,
+		Target:    ticketName,
+	})
+	return err
+}
+
+func (fs *FileSystem) clearTicketWriteByteLimit(ticketName string) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -282,6 +363,16 @@ func (fs *FileSystem) ClearTicketWriteByteLimit(ticketName string) error {
 
 // AddTicketAllowedUser adds a user to the allowed user names list of the given ticket
 func (fs *FileSystem) AddTicketAllowedUser(ticketName string, userName string) error {
+	startTime := time.Now()
+	err := fs.addTicketAllowedUser(ticketName, userName)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditModifyTicket,
+		Target:    ticketName,
+	})
+	return err
+}
+
+func (fs *FileSystem) addTicketAllowedUser(ticketName string, userName string) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -298,6 +389,16 @@ func (fs *FileSystem) AddTicketAllowedUser(ticketName string, userName string) e
 
 // RemoveTicketAllowedUser removes the user from the allowed user names list of the given ticket
 func (fs *FileSystem) RemoveTicketAllowedUser(ticketName string, userName string) error {
+	startTime := time.Now()
+	err := fs.removeTicketAllowedUser(ticketName, userName)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditModifyTicket,
+		Target:    ticketName,
+	})
+	return err
+}
+
+func (fs *FileSystem) removeTicketAllowedUser(ticketName string, userName string) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -314,6 +415,16 @@ func (fs *FileSystem) RemoveTicketAllowedUser(ticketName string, userName string
 
 // AddTicketAllowedGroup adds a group to the allowed group names list of the given ticket
 func (fs *FileSystem) AddTicketAllowedGroup(ticketName string, groupName string) error {
+	startTime := time.Now()
+	err := fs.addTicketAllowedGroup(ticketName, groupName)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditModifyTicket,
+		Target:    ticketName,
+	})
+	return err
+}
+
+func (fs *FileSystem) addTicketAllowedGroup(ticketName string, groupName string) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -330,6 +441,16 @@ func (fs *FileSystem) AddTicketAllowedGroup(ticketName string, groupName string)
 
 // RemoveTicketAllowedGroup removes the group from the allowed group names list of the given ticket
 func (fs *FileSystem) RemoveTicketAllowedGroup(ticketName string, groupName string) error {
+	startTime := time.Now()
+	err := fs.removeTicketAllowedGroup(ticketName, groupName)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditModifyTicket,
+		Target:    ticketName,
+	})
+	return err
+}
+
+func (fs *FileSystem) removeTicketAllowedGroup(ticketName string, groupName string) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -346,6 +467,16 @@ func (fs *FileSystem) RemoveTicketAllowedGroup(ticketName string, groupName stri
 
 // AddTicketAllowedHost adds a host to the allowed hosts list of the given ticket
 func (fs *FileSystem) AddTicketAllowedHost(ticketName string, host string) error {
+	startTime := time.Now()
+	err := fs.addTicketAllowedHost(ticketName, host)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditModifyTicket,
+		Target:    ticketName,
+	})
+	return err
+}
+
+func (fs *FileSystem) addTicketAllowedHost(ticketName string, host string) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -362,6 +493,16 @@ func (fs *FileSystem) AddTicketAllowedHost(ticketName string, host string) error
 
 // RemoveTicketAllowedHost removes the host from the allowed hosts list of the given ticket
 func (fs *FileSystem) RemoveTicketAllowedHost(ticketName string, host string) error {
+	startTime := time.Now()
+	err := fs.removeTicketAllowedHost(ticketName, host)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditModifyTicket,
+		Target:    ticketName,
+	})
+	return err
+}
+
+func (fs *FileSystem) removeTicketAllowedHost(ticketName string, host string) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -378,6 +519,16 @@ func (fs *FileSystem) RemoveTicketAllowedHost(ticketName string, host string) er
 
 // ModifyTicketExpirationTime modifies the expiration time of the given ticket
 func (fs *FileSystem) ModifyTicketExpirationTime(ticketName string, expirationTime time.Time) error {
+	startTime := time.Now()
+	err := fs.modifyTicketExpirationTime(ticketName, expirationTime)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditModifyTicket,
+		Target:    ticketName,
+	})
+	return err
+}
+
+func (fs *FileSystem) modifyTicketExpirationTime(ticketName string, expirationTime time.Time) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -394,6 +545,16 @@ func (fs *FileSystem) ModifyTicketExpirationTime(ticketName string, expirationTi
 
 // ClearTicketExpirationTime clears the expiration time of the given ticket
 func (fs *FileSystem) ClearTicketExpirationTime(ticketName string) error {
+	startTime := time.Now()
+	err := fs.clearTicketExpirationTime(ticketName)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditModifyTicket,
+		Target:    ticketName,
+	})
+	return err
+}
+
+func (fs *FileSystem) clearTicketExpirationTime(ticketName string) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -1,6 +1,8 @@
 package fs
 
 import (
+	"time"
+
 	"github.com/cyverse/go-irodsclient/irods/common"
 	"github.com/cyverse/go-irodsclient/irods/connection"
 	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
@@ -8,11 +10,63 @@ import (
 	"github.com/cyverse/go-irodsclient/irods/util"
 )
 
+// TouchOptions is options for Touch
+type TouchOptions struct {
+	NoCreate      bool       // do not create a new empty file if the path does not exist
+	ReplicaNumber *int       // update the timestamp of the given replica only, nil updates all replicas
+	Resource      string     // leaf resource of the replica to update, used with ReplicaNumber
+	ReferencePath string     // use the modify time of the reference path, takes precedence over ModifyTime
+	ModifyTime    *time.Time // modify time to set, nil uses current time
+}
+
 // Touch creates an empty file or update timestamp
 func (fs *FileSystem) Touch(irodsPath string, resource string, noCreate bool, replicaNumber *int, referencePath string, secondsSinceEpoch *int) error {
+	opts := &TouchOptions{
+		NoCreate:      noCreate,
+		ReplicaNumber: replicaNumber,
+		Resource:      resource,
+		ReferencePath: referencePath,
+	}
+
+	if secondsSinceEpoch != nil {
+		modifyTime := time.Unix(int64(*secondsSinceEpoch), 0)
+		opts.ModifyTime = &modifyTime
+	}
+
+	return fs.TouchWithOptions(irodsPath, opts)
+}
+
+// TouchWithOptions creates an empty file or update timestamp of a file or a directory, following the options
+// nil opts creates the file if it does not exist and sets its modify time to current time
+func (fs *FileSystem) TouchWithOptions(irodsPath string, opts *TouchOptions) error {
+	startTime := time.Now()
+	err := fs.touch(irodsPath, opts)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditTouch,
+		Path:      irodsPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) touch(irodsPath string, opts *TouchOptions) error {
 	// we use ioSession to acquire connection as it can take a long time
 	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
 
+	if opts == nil {
+		opts = &TouchOptions{}
+	}
+
+	var secondsSinceEpoch *int
+	if opts.ModifyTime != nil {
+		seconds := int(opts.ModifyTime.Unix())
+		secondsSinceEpoch = &seconds
+	}
+
+	referencePath := ""
+	if len(opts.ReferencePath) > 0 {
+		referencePath = util.GetCorrectIRODSPath(opts.ReferencePath)
+	}
+
 	conn, err := fs.ioSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -23,18 +77,21 @@ func (fs *FileSystem) Touch(irodsPath string, resource string, noCreate bool, re
 	if err != nil {
 		if types.IsFileNotFoundError(err) {
 			// create
-			err = fs.touchInternal(conn, entry, irodsCorrectPath, resource, noCreate, replicaNumber, referencePath, secondsSinceEpoch)
+			err = fs.touchInternal(conn, nil, irodsCorrectPath, opts.Resource, opts.NoCreate, opts.ReplicaNumber, referencePath, secondsSinceEpoch)
 			if err != nil {
 				return err
 			}
 
-			fs.InvalidateCacheForFileCreate(irodsCorrectPath)
+			if !opts.NoCreate {
+				fs.InvalidateCacheForFileCreate(irodsCorrectPath)
+				fs.cachePropagation.PropagateFileCreate(irodsCorrectPath)
+			}
 			return nil
 		}
 		return err
 	}
 
-	err = fs.touchInternal(conn, entry, irodsCorrectPath, resource, noCreate, replicaNumber, referencePath, secondsSinceEpoch)
+	err = fs.touchInternal(conn, entry, irodsCorrectPath, opts.Resource, opts.NoCreate, opts.ReplicaNumber, referencePath, secondsSinceEpoch)
 	if err != nil {
 		return err
 	}
@@ -43,6 +100,7 @@ func (fs *FileSystem) Touch(irodsPath string, resource string, noCreate bool, re
 		fs.InvalidateCacheForDirUpdate(irodsCorrectPath)
 	} else {
 		fs.InvalidateCacheForFileUpdate(irodsCorrectPath)
+		fs.cachePropagation.PropagateFileUpdate(irodsCorrectPath)
 	}
 
 	return nil
@@ -0,0 +1,74 @@
+package fs
+
+import (
+	"bytes"
+	"time"
+
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+)
+
+// TransferVerificationResult is a result of checksum verification of a transfer
+type TransferVerificationResult string
+
+const (
+	// TransferVerificationSkipped is for transfers not verified, verification is not requested or checksums are not available
+	TransferVerificationSkipped TransferVerificationResult = "skipped"
+	// TransferVerificationPassed is for transfers having matching checksums
+	TransferVerificationPassed TransferVerificationResult = "passed"
+	// TransferVerificationFailed is for transfers having mismatching checksums
+	TransferVerificationFailed TransferVerificationResult = "failed"
+)
+
+// TransferReport contains performance of a file transfer, for logging and aggregating
+type TransferReport struct {
+	Bytes        int64                      `json:"bytes"`
+	Duration     time.Duration              `json:"duration"`
+	Throughput   float64                    `json:"throughput"` // bytes per second
+	TaskNum      int                        `json:"task_num"`   // number of tasks transferred in parallel, 0 if unknown
+	Retries      int                        `json:"retries"`    // number of retries of tasks failed
+	Verification TransferVerificationResult `json:"verification"`
+}
+
+// reportTransfer sets TransferReport to the result if it is enabled in config, stats is nil for serial transfers
+func (fs *FileSystem) reportTransfer(result *FileTransferResult, stats *irods_fs.DataObjectTransferStats, verifyChecksum bool) {
+	if !fs.config.TransferReport || result == nil {
+		return
+	}
+
+	endTime := result.EndTime
+	if endTime.IsZero() {
+		// failed
+		endTime = time.Now()
+	}
+
+	report := &TransferReport{
+		Bytes:        result.LocalSize,
+		Duration:     endTime.Sub(result.StartTime),
+		TaskNum:      1,
+		Retries:      0,
+		Verification: TransferVerificationSkipped,
+	}
+
+	if report.Bytes == 0 {
+		report.Bytes = result.IRODSSize
+	}
+
+	if report.Duration > 0 {
+		report.Throughput = float64(report.Bytes) / report.Duration.Seconds()
+	}
+
+	if stats != nil {
+		report.TaskNum = stats.GetTaskNum()
+		report.Retries = stats.GetRetries()
+	}
+
+	if verifyChecksum && len(result.LocalCheckSum) > 0 && len(result.IRODSCheckSum) > 0 && result.LocalCheckSumAlgorithm == result.IRODSCheckSumAlgorithm {
+		if bytes.Equal(result.LocalCheckSum, result.IRODSCheckSum) {
+			report.Verification = TransferVerificationPassed
+		} else {
+			report.Verification = TransferVerificationFailed
+		}
+	}
+
+	result.Report = report
+}
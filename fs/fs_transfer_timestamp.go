@@ -0,0 +1,133 @@
+package fs
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// UploadModifyTimeMode determines how the modify time of a local file is preserved when it is uploaded
+type UploadModifyTimeMode string
+
+const (
+	// UploadModifyTimeModeNone does not preserve the modify time, the data object has the upload time
+	UploadModifyTimeModeNone UploadModifyTimeMode = ""
+	// UploadModifyTimeModeTouch sets the modify time of the data object to the modify time of the local file with touch API
+	// falls back to UploadModifyTimeModeAVU if the server does not support touch API
+	UploadModifyTimeModeTouch UploadModifyTimeMode = "touch"
+	// UploadModifyTimeModeAVU records the modify time of the local file in an AVU of the data object, the data object has the upload time
+	UploadModifyTimeModeAVU UploadModifyTimeMode = "avu"
+
+	// LocalModifyTimeAVUName is the name of the AVU having the modify time of the local file in seconds since epoch
+	LocalModifyTimeAVUName string = "go-irodsclient::local_mtime"
+)
+
+// GetRecordedLocalModifyTime returns the modify time of the local file recorded when the file was uploaded with UploadModifyTimeModeAVU
+// returns false if it is not recorded
+func (fs *FileSystem) GetRecordedLocalModifyTime(irodsPath string) (time.Time, bool, error) {
+	metas, err := fs.ListMetadata(irodsPath)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	for _, meta := range metas {
+		if meta.Name != LocalModifyTimeAVUName {
+			continue
+		}
+
+		seconds, err := strconv.ParseInt(meta.Value, 10, 64)
+		if err != nil {
+			return time.Time{}, false, errors.Wrapf(err, "failed to parse local modify time %q of %q", meta.Value, irodsPath)
+		}
+
+		return time.Unix(seconds, 0), true, nil
+	}
+
+	return time.Time{}, false, nil
+}
+
+// preserveDownloadModifyTime sets the modify time of the downloaded local file to the modify time of the data object
+// the local modify time recorded on upload takes precedence
+func (fs *FileSystem) preserveDownloadModifyTime(entry *Entry, localPath string) error {
+	if fs.config == nil || !fs.config.PreserveDownloadModifyTime {
+		return nil
+	}
+
+	modifyTime := entry.ModifyTime
+
+	recordedTime, ok, err := fs.GetRecordedLocalModifyTime(entry.Path)
+	if err != nil {
+		log.WithError(err).Debugf("failed to get recorded local modify time of %q, using the modify time of the data object", entry.Path)
+	} else if ok {
+		modifyTime = recordedTime
+	}
+
+	err = os.Chtimes(localPath, time.Time{}, modifyTime)
+	if err != nil {
+		return errors.Wrapf(err, "failed to set modify time of %q", localPath)
+	}
+
+	return nil
+}
+
+// preserveUploadModifyTime preserves the modify time of the uploaded local file following the config
+func (fs *FileSystem) preserveUploadModifyTime(irodsPath string, stat os.FileInfo) error {
+	if fs.config == nil {
+		return nil
+	}
+
+	modifyTime := stat.ModTime()
+
+	switch fs.config.PreserveUploadModifyTime {
+	case UploadModifyTimeModeNone:
+		return nil
+	case UploadModifyTimeModeTouch:
+		err := fs.touch(irodsPath, &TouchOptions{
+			NoCreate:   true,
+			ModifyTime: &modifyTime,
+		})
+		if err == nil {
+			return nil
+		}
+
+		if !types.IsAPINotSupportedError(err) {
+			return errors.Wrapf(err, "failed to set modify time of %q", irodsPath)
+		}
+
+		log.Debugf("touch is not supported, recording local modify time of %q in an AVU", irodsPath)
+		return fs.recordLocalModifyTime(irodsPath, modifyTime)
+	case UploadModifyTimeModeAVU:
+		return fs.recordLocalModifyTime(irodsPath, modifyTime)
+	default:
+		return errors.Errorf("unknown upload modify time mode %q", fs.config.PreserveUploadModifyTime)
+	}
+}
+
+// recordLocalModifyTime replaces the AVU having the local modify time
+func (fs *FileSystem) recordLocalModifyTime(irodsPath string, modifyTime time.Time) error {
+	metas, err := fs.ListMetadataNoCache(irodsPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list metadata of %q", irodsPath)
+	}
+
+	for _, meta := range metas {
+		if meta.Name == LocalModifyTimeAVUName {
+			err = fs.deleteMetadata(irodsPath, meta.AVUID)
+			if err != nil {
+				return errors.Wrapf(err, "failed to delete local modify time of %q", irodsPath)
+			}
+		}
+	}
+
+	err = fs.addMetadata(irodsPath, LocalModifyTimeAVUName, strconv.FormatInt(modifyTime.Unix(), 10), "")
+	if err != nil {
+		return errors.Wrapf(err, "failed to record local modify time of %q", irodsPath)
+	}
+
+	return nil
+}
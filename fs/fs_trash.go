@@ -0,0 +1,249 @@
+package fs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+// TrashEntry is an entry in trash
+type TrashEntry struct {
+	Entry        *Entry
+	OriginalPath string // path the entry is restored to by default
+}
+
+// EmptyTrashOptions is options for EmptyTrash
+type EmptyTrashOptions struct {
+	Path   string        // trash path to empty, empty uses the trash home of User
+	Admin  bool          // empty trash of other users, requires rodsadmin
+	User   string        // user whose trash is emptied, used with Admin, empty empties trash of all users
+	MinAge time.Duration // keep entries modified more recently than this, 0 removes all entries
+}
+
+// GetTrashHomeDirPath returns the trash home directory path
+func (fs *FileSystem) GetTrashHomeDirPath() string {
+	return fs.account.GetTrashHomeDirPath()
+}
+
+// getTrashOriginalPath returns the path that the entry in trash was deleted from
+func (fs *FileSystem) getTrashOriginalPath(trashPath string) string {
+	trashHomePath := fs.GetTrashHomeDirPath()
+	relPath := strings.TrimPrefix(trashPath, trashHomePath)
+	return util.GetCorrectIRODSPath(fs.GetHomeDirPath() + relPath)
+}
+
+func (fs *FileSystem) isInTrashHome(irodsPath string) bool {
+	return strings.HasPrefix(irodsPath, fs.GetTrashHomeDirPath()+"/")
+}
+
+// ListTrash lists entries in the trash of the current user, sub dirs are listed if recurse is true
+func (fs *FileSystem) ListTrash(recurse bool) ([]*TrashEntry, error) {
+	trashEntries := []*TrashEntry{}
+
+	collPaths := []string{fs.GetTrashHomeDirPath()}
+	for len(collPaths) > 0 {
+		collPath := collPaths[0]
+		collPaths = collPaths[1:]
+
+		entries, err := fs.ListNoCache(collPath)
+		if err != nil {
+			if types.IsFileNotFoundError(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			trashEntries = append(trashEntries, &TrashEntry{
+				Entry:        entry,
+				OriginalPath: fs.getTrashOriginalPath(entry.Path),
+			})
+
+			if recurse && entry.IsDir() {
+				collPaths = append(collPaths, entry.Path)
+			}
+		}
+	}
+
+	return trashEntries, nil
+}
+
+// RestoreTrash moves the entry in trash back to destPath, empty destPath restores to its original path
+// parent dirs of the dest are created if they do not exist
+func (fs *FileSystem) RestoreTrash(trashPath string, destPath string) error {
+	startTime := time.Now()
+	err := fs.restoreTrash(trashPath, destPath)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditRestoreTrash,
+		Path:      trashPath,
+		DestPath:  destPath,
+	})
+	return err
+}
+
+func (fs *FileSystem) restoreTrash(trashPath string, destPath string) error {
+	irodsTrashPath := util.GetCorrectIRODSPath(trashPath)
+
+	if !fs.isInTrashHome(irodsTrashPath) {
+		return errors.Errorf("path %q is not in trash %q", irodsTrashPath, fs.GetTrashHomeDirPath())
+	}
+
+	irodsDestPath := fs.getTrashOriginalPath(irodsTrashPath)
+	if len(destPath) > 0 {
+		irodsDestPath = util.GetCorrectIRODSPath(destPath)
+	}
+
+	entry, err := fs.StatNoCache(irodsTrashPath)
+	if err != nil {
+		return err
+	}
+
+	if fs.Exists(irodsDestPath) {
+		return types.NewFileAlreadyExistError(irodsDestPath)
+	}
+
+	err = fs.makeDir(util.GetIRODSPathDirname(irodsDestPath), true)
+	if err != nil {
+		return err
+	}
+
+	if entry.IsDir() {
+		return fs.renameDirToDir(irodsTrashPath, irodsDestPath, false)
+	}
+	return fs.renameFileToFile(irodsTrashPath, irodsDestPath, false)
+}
+
+// EmptyTrash removes entries in trash permanently, equivalent to irmtrash
+func (fs *FileSystem) EmptyTrash(options *EmptyTrashOptions) error {
+	if options == nil {
+		options = &EmptyTrashOptions{}
+	}
+
+	startTime := time.Now()
+	err := fs.emptyTrash(options)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditEmptyTrash,
+		Path:      fs.getEmptyTrashPath(options),
+		Target:    options.User,
+	})
+	return err
+}
+
+func (fs *FileSystem) getEmptyTrashPath(options *EmptyTrashOptions) string {
+	if len(options.Path) > 0 {
+		return util.GetCorrectIRODSPath(options.Path)
+	}
+
+	if options.Admin {
+		if len(options.User) > 0 {
+			return fmt.Sprintf("/%s/trash/home/%s", fs.account.ClientZone, options.User)
+		}
+		return fmt.Sprintf("/%s/trash/home", fs.account.ClientZone)
+	}
+
+	return fs.GetTrashHomeDirPath()
+}
+
+func (fs *FileSystem) emptyTrash(options *EmptyTrashOptions) error {
+	irodsTrashPath := fs.getEmptyTrashPath(options)
+
+	if options.MinAge < 0 {
+		return errors.Errorf("negative min age %s", options.MinAge)
+	}
+
+	// we use ioSession to acquire connection as it can take a long time
+	conn, err := fs.ioSession.AcquireConnection(true)
+	if err != nil {
+		return err
+	}
+	defer fs.ioSession.ReturnConnection(conn) //nolint
+
+	if options.MinAge > 0 {
+		// the server takes the age in minutes, so old entries are found here
+		err = fs.removeOldTrash(conn, irodsTrashPath, time.Now().Add(-options.MinAge), options.Admin)
+	} else {
+		err = irods_fs.EmptyTrash(conn, irodsTrashPath, 0, options.Admin)
+	}
+
+	// entries may be removed partially on failure
+	fs.InvalidateCache(irodsTrashPath, true)
+	fs.InvalidateCacheForDirUpdate(irodsTrashPath)
+	return err
+}
+
+// removeOldTrash removes files in trash modified at or before the cutoff, compared at second precision
+// dirs left empty are removed as well, trash homes are kept
+func (fs *FileSystem) removeOldTrash(conn *connection.IRODSConnection, irodsTrashPath string, cutoff time.Time, admin bool) error {
+	keywords := map[common.KeyWord]string{}
+	if admin {
+		keywords[common.ADMIN_KW] = ""
+	}
+
+	isOld := func(entry *Entry) bool {
+		return entry.ModifyTime.Unix() <= cutoff.Unix()
+	}
+
+	trashHomeParentPath := fmt.Sprintf("/%s/trash/home", fs.account.ClientZone)
+
+	dirPaths := []string{}
+	oldDirs := map[string]bool{}
+	remainingEntries := map[string]int{}
+
+	collPaths := []string{irodsTrashPath}
+	for len(collPaths) > 0 {
+		collPath := collPaths[0]
+		collPaths = collPaths[1:]
+
+		entries, err := fs.ListNoCache(collPath)
+		if err != nil {
+			if types.IsFileNotFoundError(err) {
+				continue
+			}
+			return err
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() {
+				dirPaths = append(dirPaths, entry.Path)
+				oldDirs[entry.Path] = isOld(entry)
+				collPaths = append(collPaths, entry.Path)
+				continue
+			}
+
+			if !isOld(entry) {
+				remainingEntries[collPath]++
+				continue
+			}
+
+			err = irods_fs.DeleteDataObjectWithKeywords(conn, entry.Path, true, false, keywords)
+			if err != nil && !types.IsFileNotFoundError(err) {
+				return err
+			}
+		}
+	}
+
+	// remove empty dirs, children first
+	for i := len(dirPaths) - 1; i >= 0; i-- {
+		dirPath := dirPaths[i]
+		parentPath := util.GetIRODSPathDirname(dirPath)
+
+		if remainingEntries[dirPath] > 0 || !oldDirs[dirPath] || parentPath == trashHomeParentPath {
+			remainingEntries[parentPath]++
+			continue
+		}
+
+		err := irods_fs.DeleteCollectionWithKeywords(conn, dirPath, false, true, false, keywords)
+		if err != nil && !types.IsFileNotFoundError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,395 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/util"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// UploadDirConcurrencyDefault is a default number of files uploaded concurrently by UploadDir
+	UploadDirConcurrencyDefault int = 4
+)
+
+// SymlinkPolicy determines how local symlinks are handled when a local dir is uploaded
+type SymlinkPolicy string
+
+const (
+	// SymlinkPolicyFollow uploads targets of symlinks, symlinks to dirs already visited are skipped to avoid loops
+	SymlinkPolicyFollow SymlinkPolicy = "follow"
+	// SymlinkPolicySkip skips symlinks
+	SymlinkPolicySkip SymlinkPolicy = "skip"
+	// SymlinkPolicyError fails the upload before uploading any file if a symlink is found
+	SymlinkPolicyError SymlinkPolicy = "error"
+)
+
+// UploadDirProgress is a progress of UploadDir, reported after each entry is uploaded or failed
+type UploadDirProgress struct {
+	LocalPath string
+	IRODSPath string
+	Type      EntryType
+	Size      int64
+	Error     error // error of the entry, nil if uploaded
+
+	ProcessedFiles int
+	TotalFiles     int
+	ProcessedBytes int64
+	TotalBytes     int64
+}
+
+// UploadDirProgressCallback is a callback of UploadDir, calls are serialized
+type UploadDirProgressCallback func(progress *UploadDirProgress)
+
+// UploadDirOptions is options for UploadDir
+type UploadDirOptions struct {
+	Resource         string                    // resource to upload files, empty uses the default resource
+	VerifyChecksum   bool                      // verify checksums of uploaded files
+	SymlinkPolicy    SymlinkPolicy             // empty uses SymlinkPolicyFollow
+	Concurrency      int                       // number of files uploaded concurrently, 0 uses default
+	StopOnError      bool                      // stop uploading at the first error, otherwise upload remaining entries and report errors
+	ProgressCallback UploadDirProgressCallback // called after each entry is uploaded or failed
+}
+
+// Validate validates upload dir options
+func (options *UploadDirOptions) Validate() error {
+	switch options.SymlinkPolicy {
+	case "", SymlinkPolicyFollow, SymlinkPolicySkip, SymlinkPolicyError:
+	default:
+		return errors.Errorf("unknown symlink policy %q", options.SymlinkPolicy)
+	}
+
+	if options.Concurrency < 0 {
+		return errors.Errorf("concurrency must not be negative")
+	}
+
+	return nil
+}
+
+// UploadDirEntryError is an error of an entry failed to upload
+type UploadDirEntryError struct {
+	LocalPath string
+	IRODSPath string
+	Type      EntryType
+	Err       error
+}
+
+// UploadDirResult is a result of UploadDir
+type UploadDirResult struct {
+	Dirs            int   // number of dirs created
+	Files           int   // number of files uploaded
+	Bytes           int64 // bytes of files uploaded
+	SkippedSymlinks int   // number of symlinks skipped by the policy or to avoid loops
+	Errors          []*UploadDirEntryError
+}
+
+type uploadDirTask struct {
+	localPath string
+	irodsPath string
+	entryType EntryType
+	size      int64
+}
+
+// UploadDir uploads a local dir recursively
+// if irodsPath is an existing dir, the local dir is uploaded into it
+func (fs *FileSystem) UploadDir(localPath string, irodsPath string, options *UploadDirOptions) (*UploadDirResult, error) {
+	startTime := time.Now()
+	result, err := fs.uploadDir(localPath, irodsPath, options)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditUploadDir,
+		Path:      irodsPath,
+		Bytes:     getUploadDirResultSize(result),
+	})
+	return result, err
+}
+
+func (fs *FileSystem) uploadDir(localPath string, irodsPath string, options *UploadDirOptions) (*UploadDirResult, error) {
+	localSrcPath := util.GetCorrectLocalPath(localPath)
+	irodsDestPath := util.GetCorrectIRODSPath(irodsPath)
+
+	logger := log.WithFields(log.Fields{
+		"local_path": localSrcPath,
+		"irods_path": irodsDestPath,
+	})
+
+	if options == nil {
+		options = &UploadDirOptions{}
+	}
+
+	err := options.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	symlinkPolicy := options.SymlinkPolicy
+	if len(symlinkPolicy) == 0 {
+		symlinkPolicy = SymlinkPolicyFollow
+	}
+
+	concurrency := options.Concurrency
+	if concurrency == 0 {
+		concurrency = UploadDirConcurrencyDefault
+	}
+
+	stat, err := os.Stat(localSrcPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to stat %q", localSrcPath)
+	}
+
+	if !stat.IsDir() {
+		return nil, errors.Errorf("local path %q is not a dir", localSrcPath)
+	}
+
+	destDirPath := irodsDestPath
+	if fs.ExistsDir(irodsDestPath) {
+		// make full dir name for dest
+		destDirPath = util.MakeIRODSPath(irodsDestPath, filepath.Base(localSrcPath))
+	}
+
+	result := &UploadDirResult{
+		Errors: []*UploadDirEntryError{},
+	}
+
+	// collect entries, dirs are in breadth-first order so parents are created first
+	dirTasks := []*uploadDirTask{
+		{
+			localPath: localSrcPath,
+			irodsPath: destDirPath,
+			entryType: DirectoryEntry,
+		},
+	}
+	fileTasks := []*uploadDirTask{}
+	failedTasks := []*uploadDirTask{}
+	failedTaskErrors := []error{}
+	var totalBytes int64
+
+	// real paths of dirs visited, a symlink to a visited dir would upload it again or loop forever
+	visitedDirs := map[string]bool{}
+	realSrcPath, err := filepath.EvalSymlinks(localSrcPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve %q", localSrcPath)
+	}
+	visitedDirs[realSrcPath] = true
+
+	for idx := 0; idx < len(dirTasks); idx++ {
+		dirTask := dirTasks[idx]
+
+		dirEntries, err := os.ReadDir(dirTask.localPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read dir %q", dirTask.localPath)
+		}
+
+		for _, dirEntry := range dirEntries {
+			task := &uploadDirTask{
+				localPath: filepath.Join(dirTask.localPath, dirEntry.Name()),
+				irodsPath: util.MakeIRODSPath(dirTask.irodsPath, dirEntry.Name()),
+			}
+
+			if dirEntry.Type()&os.ModeSymlink != 0 {
+				switch symlinkPolicy {
+				case SymlinkPolicySkip:
+					logger.Debugf("skipping symlink %q", task.localPath)
+					result.SkippedSymlinks++
+					continue
+				case SymlinkPolicyError:
+					return nil, errors.Errorf("failed to upload symlink %q, symlinks are not allowed", task.localPath)
+				}
+
+				// follow
+				targetStat, err := os.Stat(task.localPath)
+				if err != nil {
+					task.entryType = FileEntry
+					failedTasks = append(failedTasks, task)
+					failedTaskErrors = append(failedTaskErrors, errors.Wrapf(err, "failed to follow symlink %q", task.localPath))
+					continue
+				}
+
+				if targetStat.IsDir() {
+					realPath, err := filepath.EvalSymlinks(task.localPath)
+					if err != nil {
+						task.entryType = DirectoryEntry
+						failedTasks = append(failedTasks, task)
+						failedTaskErrors = append(failedTaskErrors, errors.Wrapf(err, "failed to resolve symlink %q", task.localPath))
+						continue
+					}
+
+					if visitedDirs[realPath] {
+						logger.Debugf("skipping symlink %q to visited dir %q", task.localPath, realPath)
+						result.SkippedSymlinks++
+						continue
+					}
+
+					visitedDirs[realPath] = true
+					task.entryType = DirectoryEntry
+					dirTasks = append(dirTasks, task)
+				} else if targetStat.Mode().IsRegular() {
+					task.entryType = FileEntry
+					task.size = targetStat.Size()
+					fileTasks = append(fileTasks, task)
+					totalBytes += task.size
+				} else {
+					logger.Debugf("skipping symlink %q to irregular file", task.localPath)
+					result.SkippedSymlinks++
+				}
+				continue
+			}
+
+			if dirEntry.IsDir() {
+				realPath, err := filepath.EvalSymlinks(task.localPath)
+				if err == nil {
+					visitedDirs[realPath] = true
+				}
+
+				task.entryType = DirectoryEntry
+				dirTasks = append(dirTasks, task)
+			} else if dirEntry.Type().IsRegular() {
+				info, err := dirEntry.Info()
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to stat %q", task.localPath)
+				}
+
+				task.entryType = FileEntry
+				task.size = info.Size()
+				fileTasks = append(fileTasks, task)
+				totalBytes += task.size
+			} else {
+				// devices, sockets and pipes
+				logger.Debugf("skipping irregular file %q", task.localPath)
+			}
+		}
+	}
+
+	progress := UploadDirProgress{
+		TotalFiles: len(fileTasks),
+		TotalBytes: totalBytes,
+	}
+
+	stopped := false
+	resultMutex := sync.Mutex{}
+
+	// report records the result of a task, returns false if upload should stop
+	report := func(task *uploadDirTask, taskErr error) bool {
+		resultMutex.Lock()
+		defer resultMutex.Unlock()
+
+		if taskErr != nil {
+			logger.WithError(taskErr).Debugf("failed to upload %q to %q", task.localPath, task.irodsPath)
+
+			result.Errors = append(result.Errors, &UploadDirEntryError{
+				LocalPath: task.localPath,
+				IRODSPath: task.irodsPath,
+				Type:      task.entryType,
+				Err:       taskErr,
+			})
+
+			if options.StopOnError {
+				stopped = true
+			}
+		} else if task.entryType == DirectoryEntry {
+			result.Dirs++
+		} else {
+			result.Files++
+			result.Bytes += task.size
+		}
+
+		if task.entryType != DirectoryEntry {
+			progress.ProcessedFiles++
+			progress.ProcessedBytes += task.size
+		}
+
+		if options.ProgressCallback != nil {
+			taskProgress := progress
+			taskProgress.LocalPath = task.localPath
+			taskProgress.IRODSPath = task.irodsPath
+			taskProgress.Type = task.entryType
+			taskProgress.Size = task.size
+			taskProgress.Error = taskErr
+			options.ProgressCallback(&taskProgress)
+		}
+
+		return !stopped
+	}
+
+	// broken symlinks
+	for idx, failedTask := range failedTasks {
+		if !report(failedTask, failedTaskErrors[idx]) {
+			return result, failedTaskErrors[idx]
+		}
+	}
+
+	// make dirs
+	failedDirs := map[string]bool{}
+	for _, dirTask := range dirTasks {
+		parentPath := filepath.Dir(dirTask.localPath)
+		if failedDirs[parentPath] {
+			err = errors.Errorf("parent dir of %q is not created", dirTask.irodsPath)
+		} else {
+			err = fs.makeDir(dirTask.irodsPath, true)
+		}
+
+		if err != nil {
+			failedDirs[dirTask.localPath] = true
+		}
+
+		if !report(dirTask, err) {
+			return result, errors.Wrapf(err, "failed to make dir %q", dirTask.irodsPath)
+		}
+	}
+
+	// upload files
+	taskChan := make(chan *uploadDirTask)
+	waitGroup := sync.WaitGroup{}
+
+	for i := 0; i < concurrency; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			for task := range taskChan {
+				_, uploadErr := fs.uploadFile(task.localPath, task.irodsPath, options.Resource, false, options.VerifyChecksum, nil)
+				report(task, uploadErr)
+			}
+		}()
+	}
+
+	for _, fileTask := range fileTasks {
+		if failedDirs[filepath.Dir(fileTask.localPath)] {
+			report(fileTask, errors.Errorf("parent dir of %q is not created", fileTask.irodsPath))
+			continue
+		}
+
+		resultMutex.Lock()
+		stop := stopped
+		resultMutex.Unlock()
+
+		if stop {
+			break
+		}
+
+		taskChan <- fileTask
+	}
+
+	close(taskChan)
+	waitGroup.Wait()
+
+	fs.InvalidateCache(destDirPath, true)
+	fs.InvalidateCacheForDirCreate(destDirPath)
+
+	if len(result.Errors) > 0 {
+		return result, errors.Wrapf(result.Errors[0].Err, "failed to upload %d entries from %q to %q", len(result.Errors), localSrcPath, destDirPath)
+	}
+
+	return result, nil
+}
+
+func getUploadDirResultSize(result *UploadDirResult) int64 {
+	if result == nil {
+		return 0
+	}
+
+	return result.Bytes
+}
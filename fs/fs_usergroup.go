@@ -1,6 +1,8 @@
 package fs
 
 import (
+	"time"
+
 	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
 	"github.com/cyverse/go-irodsclient/irods/types"
 )
@@ -254,6 +256,16 @@ func (fs *FileSystem) ListUserGroups(zoneName string, username string) ([]*types
 
 // CreateUser creates a new user
 func (fs *FileSystem) CreateUser(username string, zoneName string, userType types.IRODSUserType) (*types.IRODSUser, error) {
+	startTime := time.Now()
+	result, err := fs.createUser(username, zoneName, userType)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditCreateUser,
+		Target:    username + "#" + zoneName,
+	})
+	return result, err
+}
+
+func (fs *FileSystem) createUser(username string, zoneName string, userType types.IRODSUserType) (*types.IRODSUser, error) {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return nil, err
@@ -278,6 +290,16 @@ func (fs *FileSystem) CreateUser(username string, zoneName string, userType type
 
 // ChangeUserPassword changes a user's password
 func (fs *FileSystem) ChangeUserPassword(username string, zoneName string, newPassword string) error {
+	startTime := time.Now()
+	err := fs.changeUserPassword(username, zoneName, newPassword)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditModifyUser,
+		Target:    username + "#" + zoneName,
+	})
+	return err
+}
+
+func (fs *FileSystem) changeUserPassword(username string, zoneName string, newPassword string) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -294,6 +316,16 @@ func (fs *FileSystem) ChangeUserPassword(username string, zoneName string, newPa
 
 // ChangeUserType changes a user's type
 func (fs *FileSystem) ChangeUserType(username string, zoneName string, newType types.IRODSUserType) error {
+	startTime := time.Now()
+	err := fs.changeUserType(username, zoneName, newType)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditModifyUser,
+		Target:    username + "#" + zoneName,
+	})
+	return err
+}
+
+func (fs *FileSystem) changeUserType(username string, zoneName string, newType types.IRODSUserType) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -310,6 +342,16 @@ func (fs *FileSystem) ChangeUserType(username string, zoneName string, newType t
 
 // RemoveUser removes a user
 func (fs *FileSystem) RemoveUser(username string, zoneName string, userType types.IRODSUserType) error {
+	startTime := time.Now()
+	err := fs.removeUser(username, zoneName, userType)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditRemoveUser,
+		Target:    username + "#" + zoneName,
+	})
+	return err
+}
+
+func (fs *FileSystem) removeUser(username string, zoneName string, userType types.IRODSUserType) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -326,6 +368,16 @@ func (fs *FileSystem) RemoveUser(username string, zoneName string, userType type
 
 // AddGroupMember adds a user to a group
 func (fs *FileSystem) AddGroupMember(groupName string, username string, zoneName string) error {
+	startTime := time.Now()
+	err := fs.addGroupMember(groupName, username, zoneName)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditAddGroupMember,
+		Target:    groupName,
+	})
+	return err
+}
+
+func (fs *FileSystem) addGroupMember(groupName string, username string, zoneName string) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -342,6 +394,16 @@ func (fs *FileSystem) AddGroupMember(groupName string, username string, zoneName
 
 // RemoveGroupMember removes a user from a group
 func (fs *FileSystem) RemoveGroupMember(groupName string, username string, zoneName string) error {
+	startTime := time.Now()
+	err := fs.removeGroupMember(groupName, username, zoneName)
+	fs.recordAudit(startTime, err, &AuditRecord{
+		Operation: AuditRemoveGroupMember,
+		Target:    groupName,
+	})
+	return err
+}
+
+func (fs *FileSystem) removeGroupMember(groupName string, username string, zoneName string) error {
 	conn, err := fs.metadataSession.AcquireConnection(true)
 	if err != nil {
 		return err
@@ -0,0 +1,204 @@
+package fs
+
+import (
+	iofs "io/fs"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/util"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// SkipDir is returned by WalkFunc to skip the dir, same as io/fs.SkipDir
+	SkipDir = iofs.SkipDir
+	// SkipAll is returned by WalkFunc to stop walking without an error, same as io/fs.SkipAll
+	SkipAll = iofs.SkipAll
+)
+
+// WalkErrorPolicy is a policy of Walk on errors listing dirs
+type WalkErrorPolicy string
+
+const (
+	// WalkErrorAbort stops walking and returns the error
+	WalkErrorAbort WalkErrorPolicy = "abort"
+	// WalkErrorSkip skips the dir failed to list and continues walking
+	WalkErrorSkip WalkErrorPolicy = "skip"
+)
+
+// WalkFunc is a callback of Walk, called for each entry visited, calls are serialized.
+// If listing a dir fails, it is called again for the dir with the error.
+// Returning SkipDir for a dir skips its contents, returning SkipAll stops walking,
+// returning other errors stops walking and Walk returns the error.
+type WalkFunc func(irodsPath string, entry *Entry, err error) error
+
+// WalkOptions is options for Walk
+type WalkOptions struct {
+	Concurrency int             // number of sibling dirs listed concurrently, each uses a pooled connection, 0 or 1 walks sequentially in depth-first order
+	MaxDepth    int             // max depth of entries visited, entries in the root are at depth 1, 0 is unlimited
+	ErrorPolicy WalkErrorPolicy // empty uses WalkErrorAbort
+}
+
+// Validate validates walk options
+func (options *WalkOptions) Validate() error {
+	if options.Concurrency < 0 {
+		return errors.Errorf("negative concurrency %d", options.Concurrency)
+	}
+
+	if options.MaxDepth < 0 {
+		return errors.Errorf("negative max depth %d", options.MaxDepth)
+	}
+
+	switch options.ErrorPolicy {
+	case "", WalkErrorAbort, WalkErrorSkip:
+	default:
+		return errors.Errorf("unknown walk error policy %q", options.ErrorPolicy)
+	}
+
+	return nil
+}
+
+type walker struct {
+	filesystem *FileSystem
+	fn         WalkFunc
+	options    *WalkOptions
+
+	slots     chan struct{} // extra goroutines for sibling dirs
+	waitGroup sync.WaitGroup
+	fnMutex   sync.Mutex
+
+	mutex   sync.Mutex
+	stopped bool
+	err     error
+}
+
+// Walk walks the dir tree at root and calls fn for each entry including root, dirs are visited before their contents.
+// Entries are listed without cache.
+func (fs *FileSystem) Walk(root string, fn WalkFunc, options *WalkOptions) error {
+	irodsRootPath := util.GetCorrectIRODSPath(root)
+
+	if options == nil {
+		options = &WalkOptions{}
+	}
+
+	err := options.Validate()
+	if err != nil {
+		return err
+	}
+
+	rootEntry, err := fs.StatNoCache(irodsRootPath)
+	if err != nil {
+		return err
+	}
+
+	extraSlots := options.Concurrency - 1
+	if extraSlots < 0 {
+		extraSlots = 0
+	}
+
+	w := &walker{
+		filesystem: fs,
+		fn:         fn,
+		options:    options,
+		slots:      make(chan struct{}, extraSlots),
+	}
+
+	if w.visit(irodsRootPath, rootEntry, nil) {
+		w.walkDir(rootEntry, 1)
+	}
+
+	w.waitGroup.Wait()
+
+	return w.err
+}
+
+func (w *walker) isStopped() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.stopped
+}
+
+// stop stops walking, err is returned by Walk, only the first error is kept
+func (w *walker) stop(err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.stopped {
+		return
+	}
+
+	w.stopped = true
+	w.err = err
+}
+
+// visit calls fn, returns true if the contents of the entry should be walked
+func (w *walker) visit(irodsPath string, entry *Entry, err error) bool {
+	w.fnMutex.Lock()
+	defer w.fnMutex.Unlock()
+
+	if w.isStopped() {
+		return false
+	}
+
+	fnErr := w.fn(irodsPath, entry, err)
+	if fnErr != nil {
+		if errors.Is(fnErr, SkipDir) {
+			return false
+		}
+
+		if errors.Is(fnErr, SkipAll) {
+			w.stop(nil)
+			return false
+		}
+
+		w.stop(fnErr)
+		return false
+	}
+
+	return err == nil && entry != nil && entry.IsDir()
+}
+
+// walkDir walks the contents of the dir, entries in the dir are at the given depth
+func (w *walker) walkDir(dirEntry *Entry, depth int) {
+	if w.options.MaxDepth > 0 && depth > w.options.MaxDepth {
+		return
+	}
+
+	entries, err := w.filesystem.ListNoCache(dirEntry.Path)
+	if err != nil {
+		log.WithError(err).Debugf("failed to list dir %q", dirEntry.Path)
+
+		w.visit(dirEntry.Path, dirEntry, err)
+		if w.options.ErrorPolicy != WalkErrorSkip {
+			w.stop(errors.Wrapf(err, "failed to list dir %q", dirEntry.Path))
+		}
+		return
+	}
+
+	for _, entry := range entries {
+		if w.isStopped() {
+			return
+		}
+
+		if !w.visit(entry.Path, entry, nil) {
+			continue
+		}
+
+		select {
+		case w.slots <- struct{}{}:
+			// walk the sibling dir in parallel
+			w.waitGroup.Add(1)
+			go func(subDirEntry *Entry) {
+				defer w.waitGroup.Done()
+				defer func() {
+					<-w.slots
+				}()
+
+				w.walkDir(subDirEntry, depth+1)
+			}(entry)
+		default:
+			w.walkDir(entry, depth+1)
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package fs
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+	"github.com/rs/xid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// WatcherIntervalDefault is a default polling interval of Watcher
+	WatcherIntervalDefault time.Duration = 10 * time.Second
+	// WatcherEventBufferSizeDefault is a default size of Watcher event channel
+	WatcherEventBufferSizeDefault int = 100
+)
+
+// WatchEventType is a type of watch events
+type WatchEventType string
+
+const (
+	// WatchCreatedEvent is an event type for a created entry
+	WatchCreatedEvent WatchEventType = "created"
+	// WatchModifiedEvent is an event type for a modified entry, size, modify time or checksum changed
+	WatchModifiedEvent WatchEventType = "modified"
+	// WatchRemovedEvent is an event type for a removed entry
+	WatchRemovedEvent WatchEventType = "removed"
+)
+
+// WatchEvent is an event of changes in a watched collection
+type WatchEvent struct {
+	Type  WatchEventType
+	Path  string
+	Entry *Entry // last known entry for removed entries
+}
+
+// WatcherConfig is a config for Watcher
+type WatcherConfig struct {
+	Interval        time.Duration // polling interval, 0 uses default
+	Recursive       bool          // watch sub-collections
+	EventBufferSize int           // size of event channel, 0 uses default
+}
+
+// NewDefaultWatcherConfig creates a new default WatcherConfig
+func NewDefaultWatcherConfig() *WatcherConfig {
+	return &WatcherConfig{
+		Interval:        WatcherIntervalDefault,
+		Recursive:       false,
+		EventBufferSize: WatcherEventBufferSizeDefault,
+	}
+}
+
+func (config *WatcherConfig) fillDefaults() {
+	if config.Interval == 0 {
+		config.Interval = WatcherIntervalDefault
+	}
+
+	if config.EventBufferSize == 0 {
+		config.EventBufferSize = WatcherEventBufferSizeDefault
+	}
+}
+
+// Validate validates watcher config
+func (config *WatcherConfig) Validate() error {
+	if config.Interval < 0 {
+		return errors.Errorf("watcher interval must not be negative")
+	}
+
+	if config.EventBufferSize < 0 {
+		return errors.Errorf("watcher event buffer size must not be negative")
+	}
+
+	return nil
+}
+
+// Watcher polls a collection and delivers events for created, modified and removed entries.
+// Changes are detected by comparing listings, so changes made between polls and reverted are not seen.
+type Watcher struct {
+	id         string
+	filesystem *FileSystem
+	path       string
+	config     *WatcherConfig
+
+	entries map[string]*Entry // path-entry mapping of the last listing, accessed only by the polling goroutine
+
+	events        chan WatchEvent
+	errors        chan error
+	terminateChan chan bool
+	terminateOnce sync.Once
+	waitGroup     sync.WaitGroup
+}
+
+// Watch starts watching the collection at the given path.
+// The current listing is taken before returning, changes after that are delivered via Events().
+func (fs *FileSystem) Watch(irodsPath string, config *WatcherConfig) (*Watcher, error) {
+	irodsCorrectPath := util.GetCorrectIRODSPath(irodsPath)
+
+	if config == nil {
+		config = NewDefaultWatcherConfig()
+	}
+
+	config.fillDefaults()
+	err := config.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := fs.StatNoCache(irodsCorrectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !entry.IsDir() {
+		return nil, errors.Errorf("path %q is not a collection", irodsCorrectPath)
+	}
+
+	watcher := &Watcher{
+		id:            xid.New().String(),
+		filesystem:    fs,
+		path:          irodsCorrectPath,
+		config:        config,
+		events:        make(chan WatchEvent, config.EventBufferSize),
+		errors:        make(chan error, 1),
+		terminateChan: make(chan bool),
+	}
+
+	entries, err := watcher.list()
+	if err != nil {
+		return nil, err
+	}
+
+	watcher.entries = entries
+
+	fs.addWatcher(watcher)
+
+	watcher.waitGroup.Add(1)
+	go watcher.run()
+
+	return watcher, nil
+}
+
+// GetPath returns the path of the watched collection
+func (watcher *Watcher) GetPath() string {
+	return watcher.path
+}
+
+// Events returns a channel of watch events, closed when the watcher stops
+func (watcher *Watcher) Events() <-chan WatchEvent {
+	return watcher.events
+}
+
+// Errors returns a channel of polling errors, errors are dropped if not received before the next error
+func (watcher *Watcher) Errors() <-chan error {
+	return watcher.errors
+}
+
+// Stop stops watching and closes the event channel
+func (watcher *Watcher) Stop() {
+	watcher.terminateOnce.Do(func() {
+		close(watcher.terminateChan)
+	})
+
+	watcher.waitGroup.Wait()
+
+	watcher.filesystem.removeWatcher(watcher)
+}
+
+func (watcher *Watcher) run() {
+	defer watcher.waitGroup.Done()
+	defer close(watcher.events)
+
+	ticker := time.NewTicker(watcher.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-watcher.terminateChan:
+			return
+		case <-ticker.C:
+			if !watcher.poll() {
+				return
+			}
+		}
+	}
+}
+
+// poll lists the collection and sends events for changes, returns false if the watcher is stopped while sending
+func (watcher *Watcher) poll() bool {
+	logger := log.WithFields(log.Fields{
+		"path": watcher.path,
+	})
+
+	entries, err := watcher.list()
+	if err != nil {
+		logger.WithError(err).Debug("failed to poll watched collection")
+		watcher.sendError(err)
+		return true
+	}
+
+	events := []WatchEvent{}
+	for entryPath, entry := range entries {
+		oldEntry, ok := watcher.entries[entryPath]
+		if !ok {
+			events = append(events, WatchEvent{
+				Type:  WatchCreatedEvent,
+				Path:  entryPath,
+				Entry: entry,
+			})
+		} else if isEntryModified(oldEntry, entry) {
+			events = append(events, WatchEvent{
+				Type:  WatchModifiedEvent,
+				Path:  entryPath,
+				Entry: entry,
+			})
+		}
+	}
+
+	for entryPath, oldEntry := range watcher.entries {
+		if _, ok := entries[entryPath]; !ok {
+			events = append(events, WatchEvent{
+				Type:  WatchRemovedEvent,
+				Path:  entryPath,
+				Entry: oldEntry,
+			})
+		}
+	}
+
+	watcher.entries = entries
+
+	sort.SliceStable(events, func(i int, j int) bool {
+		return events[i].Path < events[j].Path
+	})
+
+	for _, event := range events {
+		select {
+		case watcher.events <- event:
+		case <-watcher.terminateChan:
+			return false
+		}
+	}
+
+	return true
+}
+
+func (watcher *Watcher) sendError(err error) {
+	select {
+	case watcher.errors <- err:
+	default:
+		// drop, previous error is not received yet
+	}
+}
+
+// list returns path-entry mapping of entries under the watched collection
+// a removed collection is listed as empty, so removed events are sent for its entries
+func (watcher *Watcher) list() (map[string]*Entry, error) {
+	entries := map[string]*Entry{}
+
+	collPaths := []string{watcher.path}
+	for len(collPaths) > 0 {
+		collPath := collPaths[0]
+		collPaths = collPaths[1:]
+
+		collEntries, err := watcher.filesystem.ListNoCache(collPath)
+		if err != nil {
+			if types.IsFileNotFoundError(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, entry := range collEntries {
+			entries[entry.Path] = entry
+
+			if watcher.config.Recursive && entry.IsDir() {
+				collPaths = append(collPaths, entry.Path)
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+func isEntryModified(oldEntry *Entry, newEntry *Entry) bool {
+	if oldEntry.Type != newEntry.Type || oldEntry.ID != newEntry.ID {
+		return true
+	}
+
+	if oldEntry.Size != newEntry.Size || !oldEntry.ModifyTime.Equal(newEntry.ModifyTime) {
+		return true
+	}
+
+	return string(oldEntry.CheckSum) != string(newEntry.CheckSum)
+}
+
+func (fs *FileSystem) addWatcher(watcher *Watcher) {
+	fs.watchersMutex.Lock()
+	defer fs.watchersMutex.Unlock()
+
+	fs.watchers[watcher.id] = watcher
+}
+
+func (fs *FileSystem) removeWatcher(watcher *Watcher) {
+	fs.watchersMutex.Lock()
+	defer fs.watchersMutex.Unlock()
+
+	delete(fs.watchers, watcher.id)
+}
+
+// stopWatchers stops all watchers
+func (fs *FileSystem) stopWatchers() {
+	fs.watchersMutex.Lock()
+	watchers := make([]*Watcher, 0, len(fs.watchers))
+	for _, watcher := range fs.watchers {
+		watchers = append(watchers, watcher)
+	}
+	fs.watchersMutex.Unlock()
+
+	for _, watcher := range watchers {
+		watcher.Stop()
+	}
+}
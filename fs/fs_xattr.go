@@ -0,0 +1,186 @@
+package fs
+
+import (
+	"encoding/base64"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/system"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+const (
+	// XattrAVUPrefixDefault is a default prefix of AVU names mapped from local extended attributes
+	XattrAVUPrefixDefault string = "xattr::"
+
+	// XattrAVUUnitsBase64 is the units of AVUs having base64-encoded values of binary extended attributes
+	XattrAVUUnitsBase64 string = "base64"
+	// XattrAVUUnitsEmpty is the units of AVUs of empty extended attributes, iRODS does not allow empty values
+	XattrAVUUnitsEmpty string = "empty"
+
+	xattrAVUValueEmpty string = "-"
+)
+
+// xattrAVU is a value of an AVU mapped from an extended attribute
+type xattrAVU struct {
+	value string
+	units string
+}
+
+// getXattrAVUPrefix returns the prefix of AVU names mapped from local extended attributes
+func (fs *FileSystem) getXattrAVUPrefix() string {
+	if fs.config == nil || len(fs.config.XattrAVUPrefix) == 0 {
+		return XattrAVUPrefixDefault
+	}
+
+	return fs.config.XattrAVUPrefix
+}
+
+func (fs *FileSystem) isXattrMappingEnabled() bool {
+	return fs.config != nil && fs.config.MapXattrs
+}
+
+// encodeXattrAVU encodes a value of an extended attribute to an AVU value
+func encodeXattrAVU(value []byte) xattrAVU {
+	if len(value) == 0 {
+		return xattrAVU{value: xattrAVUValueEmpty, units: XattrAVUUnitsEmpty}
+	}
+
+	if utf8.Valid(value) && !strings.ContainsRune(string(value), 0) {
+		return xattrAVU{value: string(value)}
+	}
+
+	return xattrAVU{value: base64.StdEncoding.EncodeToString(value), units: XattrAVUUnitsBase64}
+}
+
+// decodeXattrAVU decodes an AVU value to a value of an extended attribute
+func decodeXattrAVU(meta *types.IRODSMeta) ([]byte, error) {
+	switch meta.Units {
+	case XattrAVUUnitsEmpty:
+		return []byte{}, nil
+	case XattrAVUUnitsBase64:
+		value, err := base64.StdEncoding.DecodeString(meta.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode value of AVU %q", meta.Name)
+		}
+		return value, nil
+	default:
+		return []byte(meta.Value), nil
+	}
+}
+
+// ListXattrAVUs returns extended attributes mapped to AVUs of the path
+func (fs *FileSystem) ListXattrAVUs(irodsPath string) (map[string][]byte, error) {
+	metas, err := fs.ListMetadata(irodsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := fs.getXattrAVUPrefix()
+
+	xattrs := map[string][]byte{}
+	for _, meta := range metas {
+		if !strings.HasPrefix(meta.Name, prefix) {
+			continue
+		}
+
+		value, err := decodeXattrAVU(meta)
+		if err != nil {
+			return nil, err
+		}
+
+		xattrs[strings.TrimPrefix(meta.Name, prefix)] = value
+	}
+
+	return xattrs, nil
+}
+
+// mapXattrsToAVUs replaces AVUs of the path having the prefix with extended attributes of the local file
+func (fs *FileSystem) mapXattrsToAVUs(localPath string, irodsPath string) error {
+	if !fs.isXattrMappingEnabled() {
+		return nil
+	}
+
+	xattrs, err := system.ListXattrs(localPath)
+	if err != nil {
+		return err
+	}
+
+	prefix := fs.getXattrAVUPrefix()
+
+	avus := map[string]xattrAVU{}
+	for name, value := range xattrs {
+		avus[prefix+name] = encodeXattrAVU(value)
+	}
+
+	metas, err := fs.ListMetadataNoCache(irodsPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list metadata of %q", irodsPath)
+	}
+
+	for _, meta := range metas {
+		if !strings.HasPrefix(meta.Name, prefix) {
+			continue
+		}
+
+		if avu, ok := avus[meta.Name]; ok && avu.value == meta.Value && avu.units == meta.Units {
+			// not changed
+			delete(avus, meta.Name)
+			continue
+		}
+
+		err = fs.deleteMetadata(irodsPath, meta.AVUID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete AVU %q of %q", meta.Name, irodsPath)
+		}
+	}
+
+	for name, avu := range avus {
+		err = fs.addMetadata(irodsPath, name, avu.value, avu.units)
+		if err != nil {
+			return errors.Wrapf(err, "failed to add AVU %q to %q", name, irodsPath)
+		}
+	}
+
+	return nil
+}
+
+// mapAVUsToXattrs replaces extended attributes of the local file with AVUs of the path having the prefix
+func (fs *FileSystem) mapAVUsToXattrs(irodsPath string, localPath string) error {
+	if !fs.isXattrMappingEnabled() {
+		return nil
+	}
+
+	xattrs, err := fs.ListXattrAVUs(irodsPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list extended attributes of %q", irodsPath)
+	}
+
+	localXattrs, err := system.ListXattrs(localPath)
+	if err != nil {
+		return err
+	}
+
+	for name := range localXattrs {
+		if _, ok := xattrs[name]; !ok {
+			err = system.RemoveXattr(localPath, name)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for name, value := range xattrs {
+		if localValue, ok := localXattrs[name]; ok && string(localValue) == string(value) {
+			continue
+		}
+
+		err = system.SetXattr(localPath, name, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
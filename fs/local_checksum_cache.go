@@ -0,0 +1,180 @@
+package fs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// LocalChecksumCacheEntry is a cached checksum of a local file
+type LocalChecksumCacheEntry struct {
+	Path      string                  `json:"path"`
+	Size      int64                   `json:"size"`
+	ModTime   time.Time               `json:"mod_time"`
+	Algorithm types.ChecksumAlgorithm `json:"algorithm"`
+	Checksum  []byte                  `json:"checksum"`
+}
+
+// ToString stringifies the object
+func (entry *LocalChecksumCacheEntry) ToString() string {
+	return fmt.Sprintf("<LocalChecksumCacheEntry %s %d %s %s %x>", entry.Path, entry.Size, entry.ModTime, entry.Algorithm, entry.Checksum)
+}
+
+// LocalChecksumCache caches checksums of local files keyed by path, size and modification time,
+// so repeated syncs do not rehash local files that are not changed.
+// It can be saved to a file and loaded in the next run.
+type LocalChecksumCache struct {
+	entries map[string]*LocalChecksumCacheEntry // absolute path -> entry
+	mutex   sync.RWMutex
+}
+
+// NewLocalChecksumCache creates a new empty LocalChecksumCache
+func NewLocalChecksumCache() *LocalChecksumCache {
+	return &LocalChecksumCache{
+		entries: map[string]*LocalChecksumCacheEntry{},
+	}
+}
+
+// NewLocalChecksumCacheFromFile creates a new LocalChecksumCache from a file saved by Save, returns an empty cache if the file does not exist
+func NewLocalChecksumCacheFromFile(cachePath string) (*LocalChecksumCache, error) {
+	cache := NewLocalChecksumCache()
+
+	cacheBytes, err := os.ReadFile(cachePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+
+		return nil, errors.Wrapf(err, "failed to read local checksum cache file %q", cachePath)
+	}
+
+	entries := []*LocalChecksumCacheEntry{}
+	err = json.Unmarshal(cacheBytes, &entries)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal local checksum cache file %q", cachePath)
+	}
+
+	for _, entry := range entries {
+		cache.entries[entry.Path] = entry
+	}
+
+	return cache, nil
+}
+
+// Save writes the cache to a file, the file is replaced atomically
+func (cache *LocalChecksumCache) Save(cachePath string) error {
+	cache.mutex.RLock()
+	entries := make([]*LocalChecksumCacheEntry, 0, len(cache.entries))
+	for _, entry := range cache.entries {
+		entries = append(entries, entry)
+	}
+	cache.mutex.RUnlock()
+
+	cacheBytes, err := json.Marshal(entries)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal local checksum cache")
+	}
+
+	tempPath := cachePath + ".tmp"
+	err = os.WriteFile(tempPath, cacheBytes, 0o600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write local checksum cache file %q", tempPath)
+	}
+
+	err = os.Rename(tempPath, cachePath)
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return errors.Wrapf(err, "failed to rename local checksum cache file %q to %q", tempPath, cachePath)
+	}
+
+	return nil
+}
+
+// Get returns the cached checksum of the local file, returns false if the file is changed since it is cached or the checksum is calculated with other algorithm
+func (cache *LocalChecksumCache) Get(localPath string, size int64, modTime time.Time, algorithm types.ChecksumAlgorithm) ([]byte, bool) {
+	cache.mutex.RLock()
+	defer cache.mutex.RUnlock()
+
+	entry, ok := cache.entries[getLocalChecksumCacheKey(localPath)]
+	if !ok {
+		return nil, false
+	}
+
+	if entry.Size != size || !entry.ModTime.Equal(modTime) || entry.Algorithm != algorithm {
+		return nil, false
+	}
+
+	return entry.Checksum, true
+}
+
+// Put caches the checksum of the local file
+func (cache *LocalChecksumCache) Put(localPath string, size int64, modTime time.Time, algorithm types.ChecksumAlgorithm, checksum []byte) {
+	key := getLocalChecksumCacheKey(localPath)
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.entries[key] = &LocalChecksumCacheEntry{
+		Path:      key,
+		Size:      size,
+		ModTime:   modTime,
+		Algorithm: algorithm,
+		Checksum:  checksum,
+	}
+}
+
+// Remove removes the cached checksum of the local file
+func (cache *LocalChecksumCache) Remove(localPath string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	delete(cache.entries, getLocalChecksumCacheKey(localPath))
+}
+
+// RemoveStale removes cached checksums of local files that do not exist anymore, returns the number of entries removed
+func (cache *LocalChecksumCache) RemoveStale() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	removed := 0
+	for key := range cache.entries {
+		_, err := os.Stat(key)
+		if err != nil && os.IsNotExist(err) {
+			delete(cache.entries, key)
+			removed++
+		}
+	}
+
+	return removed
+}
+
+// Clear removes all cached checksums
+func (cache *LocalChecksumCache) Clear() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.entries = map[string]*LocalChecksumCacheEntry{}
+}
+
+// Len returns the number of cached checksums
+func (cache *LocalChecksumCache) Len() int {
+	cache.mutex.RLock()
+	defer cache.mutex.RUnlock()
+
+	return len(cache.entries)
+}
+
+func getLocalChecksumCacheKey(localPath string) string {
+	absPath, err := filepath.Abs(localPath)
+	if err != nil {
+		return filepath.Clean(localPath)
+	}
+
+	return absPath
+}
@@ -0,0 +1,76 @@
+package fs
+
+import (
+	"time"
+
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+const (
+	// SharedCacheMaxEntriesDefault is a default max number of caches of each kind in SharedCache
+	SharedCacheMaxEntriesDefault int = 100000
+)
+
+// SharedCacheConfig defines shared cache config
+type SharedCacheConfig struct {
+	Timeout     types.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`           // default cache timeout, FileSystems use their own cache timeout
+	CleanupTime types.Duration `yaml:"cleanup_time,omitempty" json:"cleanup_time,omitempty"` // cache cleanup time
+	MaxEntries  int            `yaml:"max_entries,omitempty" json:"max_entries,omitempty"`   // max number of caches of each kind, 0 uses default, negative is unbounded
+}
+
+// NewDefaultSharedCacheConfig creates a new default SharedCacheConfig
+func NewDefaultSharedCacheConfig() SharedCacheConfig {
+	return SharedCacheConfig{
+		Timeout:     types.Duration(FileSystemCacheTimeout),
+		CleanupTime: types.Duration(FileSystemCacheTimeout),
+		MaxEntries:  SharedCacheMaxEntriesDefault,
+	}
+}
+
+// SharedCache is a size-bounded cache of entries, dirs, metadata and ACLs shared by multiple FileSystems,
+// e.g., one FileSystem per user in a multi-tenant service.
+// Caches are keyed by user, zone and path, so FileSystems of the same user share caches.
+// When the number of caches exceeds the max, caches expiring soonest are evicted.
+type SharedCache struct {
+	config *SharedCacheConfig
+	store  *cacheStore
+}
+
+// NewSharedCache creates a new SharedCache
+func NewSharedCache(config *SharedCacheConfig) *SharedCache {
+	if config == nil {
+		sharedCacheConfig := NewDefaultSharedCacheConfig()
+		config = &sharedCacheConfig
+	}
+
+	if config.MaxEntries == 0 {
+		config.MaxEntries = SharedCacheMaxEntriesDefault
+	}
+
+	maxEntries := config.MaxEntries
+	if maxEntries < 0 {
+		maxEntries = 0
+	}
+
+	return &SharedCache{
+		config: config,
+		store:  newCacheStore(time.Duration(config.Timeout), time.Duration(config.CleanupTime), maxEntries),
+	}
+}
+
+// GetConfig returns shared cache config
+func (cache *SharedCache) GetConfig() *SharedCacheConfig {
+	return cache.config
+}
+
+// GetEntryCount returns the number of caches of all kinds
+func (cache *SharedCache) GetEntryCount() int {
+	return cache.store.count()
+}
+
+// Clear clears all caches
+func (cache *SharedCache) Clear() {
+	for _, kind := range cacheStoreKinds {
+		cache.store.flush(kind, "")
+	}
+}
@@ -0,0 +1,83 @@
+package fs
+
+import (
+	"io"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// SubStructFileHandle is a handle for a file inside a struct file opened for read, it implements io.ReadCloser
+type SubStructFileHandle struct {
+	filesystem      *FileSystem
+	connection      *connection.IRODSConnection
+	irodsFileHandle *types.IRODSSubStructFileHandle
+	offset          int64
+	eof             bool
+	closed          bool
+	mutex           sync.Mutex
+}
+
+// GetStructFilePath returns the path of the struct file
+func (handle *SubStructFileHandle) GetStructFilePath() string {
+	return handle.irodsFileHandle.StructFilePath
+}
+
+// GetSubFilePath returns the path of the file in the struct file
+func (handle *SubStructFileHandle) GetSubFilePath() string {
+	return handle.irodsFileHandle.SubFilePath
+}
+
+// GetOffset returns current offset
+func (handle *SubStructFileHandle) GetOffset() int64 {
+	handle.mutex.Lock()
+	defer handle.mutex.Unlock()
+
+	return handle.offset
+}
+
+// Read reads the file sequentially
+func (handle *SubStructFileHandle) Read(buffer []byte) (int, error) {
+	handle.mutex.Lock()
+	defer handle.mutex.Unlock()
+
+	if handle.closed {
+		return 0, errors.Errorf("sub file %q in struct file %q is already closed", handle.irodsFileHandle.SubFilePath, handle.irodsFileHandle.StructFilePath)
+	}
+
+	if handle.eof {
+		return 0, io.EOF
+	}
+
+	if len(buffer) == 0 {
+		return 0, nil
+	}
+
+	readLen, err := irods_fs.ReadSubStructFile(handle.connection, handle.irodsFileHandle, buffer)
+	handle.offset += int64(readLen)
+
+	if err == io.EOF {
+		handle.eof = true
+	}
+
+	return readLen, err
+}
+
+// Close closes the file and returns the connection
+func (handle *SubStructFileHandle) Close() error {
+	handle.mutex.Lock()
+	defer handle.mutex.Unlock()
+
+	if handle.closed {
+		return nil
+	}
+
+	handle.closed = true
+
+	defer handle.filesystem.ioSession.ReturnConnection(handle.connection) //nolint
+
+	return irods_fs.CloseSubStructFile(handle.connection, handle.irodsFileHandle)
+}
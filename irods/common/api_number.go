@@ -163,6 +163,12 @@ const (
 	RM_COLL201_AN         APINumber = 663
 	OPEN_COLLECTION201_AN APINumber = 712
 
+	GET_LIBRARY_FEATURES_AN APINumber = 801
+	REPLICA_TRUNCATE_AN     APINumber = 802
+
+	// 10000 - 10999 - server report API calls
+	CLIENT_HINTS_AN APINumber = 10215
+
 	// 1000 - 1059 - NETCDF API calls
 	NC_OPEN_AN             APINumber = 1000
 	NC_CREATE_AN           APINumber = 1001
@@ -190,6 +196,7 @@ const (
 	ATOMIC_APPLY_METADATA_OPERATIONS_APN APINumber = 20002
 	REPLICA_CLOSE_APN                    APINumber = 20004
 	TOUCH_APN                            APINumber = 20007
+	GET_GRID_CONFIGURATION_VALUE_APN     APINumber = 20009
 
 	NEW_AUTH_PLUGIN_REQ_AN APINumber = 110000
 )
@@ -10,6 +10,7 @@ const (
 	MaxQueryRows        int = 500
 	MaxPasswordLength   int = 50
 	MaxNameLength       int = 64
+	MaxPathLength       int = 1024
 	ReadWriteBufferSize int = 1024 * 1024 * 4 // 4MB
 
 	/*
@@ -1,5 +1,7 @@
 package common
 
+import "strings"
+
 // ICATColumnNumber is an ICAT Column number type
 type ICATColumnNumber int
 
@@ -50,6 +52,9 @@ const (
 	ICAT_COLUMN_COLL_COMMENTS    ICATColumnNumber = 507
 	ICAT_COLUMN_COLL_CREATE_TIME ICATColumnNumber = 508
 	ICAT_COLUMN_COLL_MODIFY_TIME ICATColumnNumber = 509
+	ICAT_COLUMN_COLL_TYPE        ICATColumnNumber = 510
+	ICAT_COLUMN_COLL_INFO1       ICATColumnNumber = 511
+	ICAT_COLUMN_COLL_INFO2       ICATColumnNumber = 512
 
 	// Data Object Meta
 	ICAT_COLUMN_META_DATA_ATTR_NAME   ICATColumnNumber = 600
@@ -213,3 +218,188 @@ const (
 	ICAT_COLUMN_PROG_NAME   ICATColumnNumber = 1000008
 	ICAT_COLUMN_SERVER_ADDR ICATColumnNumber = 1000009
 )
+
+// icatColumnNames maps column names, constant names without the ICAT_COLUMN_ prefix, to column numbers
+var icatColumnNames = map[string]ICATColumnNumber{
+	"USER_ID":                        ICAT_COLUMN_USER_ID,
+	"USER_NAME":                      ICAT_COLUMN_USER_NAME,
+	"USER_TYPE":                      ICAT_COLUMN_USER_TYPE,
+	"USER_ZONE":                      ICAT_COLUMN_USER_ZONE,
+	"USER_INFO":                      ICAT_COLUMN_USER_INFO,
+	"USER_COMMENT":                   ICAT_COLUMN_USER_COMMENT,
+	"USER_CREATE_TIME":               ICAT_COLUMN_USER_CREATE_TIME,
+	"USER_MODIFY_TIME":               ICAT_COLUMN_USER_MODIFY_TIME,
+	"D_DATA_ID":                      ICAT_COLUMN_D_DATA_ID,
+	"D_COLL_ID":                      ICAT_COLUMN_D_COLL_ID,
+	"DATA_NAME":                      ICAT_COLUMN_DATA_NAME,
+	"DATA_REPL_NUM":                  ICAT_COLUMN_DATA_REPL_NUM,
+	"DATA_VERSION":                   ICAT_COLUMN_DATA_VERSION,
+	"DATA_TYPE_NAME":                 ICAT_COLUMN_DATA_TYPE_NAME,
+	"DATA_SIZE":                      ICAT_COLUMN_DATA_SIZE,
+	"D_RESC_NAME":                    ICAT_COLUMN_D_RESC_NAME,
+	"D_DATA_PATH":                    ICAT_COLUMN_D_DATA_PATH,
+	"D_OWNER_NAME":                   ICAT_COLUMN_D_OWNER_NAME,
+	"D_OWNER_ZONE":                   ICAT_COLUMN_D_OWNER_ZONE,
+	"D_REPL_STATUS":                  ICAT_COLUMN_D_REPL_STATUS,
+	"D_DATA_STATUS":                  ICAT_COLUMN_D_DATA_STATUS,
+	"D_DATA_CHECKSUM":                ICAT_COLUMN_D_DATA_CHECKSUM,
+	"D_EXPIRY":                       ICAT_COLUMN_D_EXPIRY,
+	"D_MAP_ID":                       ICAT_COLUMN_D_MAP_ID,
+	"D_COMMENTS":                     ICAT_COLUMN_D_COMMENTS,
+	"D_CREATE_TIME":                  ICAT_COLUMN_D_CREATE_TIME,
+	"D_MODIFY_TIME":                  ICAT_COLUMN_D_MODIFY_TIME,
+	"D_RESC_HIER":                    ICAT_COLUMN_D_RESC_HIER,
+	"D_RESC_ID":                      ICAT_COLUMN_D_RESC_ID,
+	"D_ACCESS_TIME":                  ICAT_COLUMN_D_ACCESS_TIME,
+	"COLL_ID":                        ICAT_COLUMN_COLL_ID,
+	"COLL_NAME":                      ICAT_COLUMN_COLL_NAME,
+	"COLL_PARENT_NAME":               ICAT_COLUMN_COLL_PARENT_NAME,
+	"COLL_OWNER_NAME":                ICAT_COLUMN_COLL_OWNER_NAME,
+	"COLL_OWNER_ZONE":                ICAT_COLUMN_COLL_OWNER_ZONE,
+	"COLL_MAP_ID":                    ICAT_COLUMN_COLL_MAP_ID,
+	"COLL_INHERITANCE":               ICAT_COLUMN_COLL_INHERITANCE,
+	"COLL_COMMENTS":                  ICAT_COLUMN_COLL_COMMENTS,
+	"COLL_CREATE_TIME":               ICAT_COLUMN_COLL_CREATE_TIME,
+	"COLL_MODIFY_TIME":               ICAT_COLUMN_COLL_MODIFY_TIME,
+	"COLL_TYPE":                      ICAT_COLUMN_COLL_TYPE,
+	"COLL_INFO1":                     ICAT_COLUMN_COLL_INFO1,
+	"COLL_INFO2":                     ICAT_COLUMN_COLL_INFO2,
+	"META_DATA_ATTR_NAME":            ICAT_COLUMN_META_DATA_ATTR_NAME,
+	"META_DATA_ATTR_VALUE":           ICAT_COLUMN_META_DATA_ATTR_VALUE,
+	"META_DATA_ATTR_UNITS":           ICAT_COLUMN_META_DATA_ATTR_UNITS,
+	"META_DATA_ATTR_ID":              ICAT_COLUMN_META_DATA_ATTR_ID,
+	"META_DATA_CREATE_TIME":          ICAT_COLUMN_META_DATA_CREATE_TIME,
+	"META_DATA_MODIFY_TIME":          ICAT_COLUMN_META_DATA_MODIFY_TIME,
+	"META_COLL_ATTR_NAME":            ICAT_COLUMN_META_COLL_ATTR_NAME,
+	"META_COLL_ATTR_VALUE":           ICAT_COLUMN_META_COLL_ATTR_VALUE,
+	"META_COLL_ATTR_UNITS":           ICAT_COLUMN_META_COLL_ATTR_UNITS,
+	"META_COLL_ATTR_ID":              ICAT_COLUMN_META_COLL_ATTR_ID,
+	"META_COLL_CREATE_TIME":          ICAT_COLUMN_META_COLL_CREATE_TIME,
+	"META_COLL_MODIFY_TIME":          ICAT_COLUMN_META_COLL_MODIFY_TIME,
+	"META_NAMESPACE_COLL":            ICAT_COLUMN_META_NAMESPACE_COLL,
+	"META_NAMESPACE_DATA":            ICAT_COLUMN_META_NAMESPACE_DATA,
+	"META_NAMESPACE_RESC":            ICAT_COLUMN_META_NAMESPACE_RESC,
+	"META_NAMESPACE_USER":            ICAT_COLUMN_META_NAMESPACE_USER,
+	"META_NAMESPACE_RESC_GROUP":      ICAT_COLUMN_META_NAMESPACE_RESC_GROUP,
+	"META_NAMESPACE_RULE":            ICAT_COLUMN_META_NAMESPACE_RULE,
+	"META_NAMESPACE_MSRVC":           ICAT_COLUMN_META_NAMESPACE_MSRVC,
+	"META_NAMESPACE_MET2":            ICAT_COLUMN_META_NAMESPACE_MET2,
+	"META_RESC_ATTR_NAME":            ICAT_COLUMN_META_RESC_ATTR_NAME,
+	"META_RESC_ATTR_VALUE":           ICAT_COLUMN_META_RESC_ATTR_VALUE,
+	"META_RESC_ATTR_UNITS":           ICAT_COLUMN_META_RESC_ATTR_UNITS,
+	"META_RESC_ATTR_ID":              ICAT_COLUMN_META_RESC_ATTR_ID,
+	"META_RESC_CREATE_TIME":          ICAT_COLUMN_META_RESC_CREATE_TIME,
+	"META_RESC_MODIFY_TIME":          ICAT_COLUMN_META_RESC_MODIFY_TIME,
+	"META_USER_ATTR_NAME":            ICAT_COLUMN_META_USER_ATTR_NAME,
+	"META_USER_ATTR_VALUE":           ICAT_COLUMN_META_USER_ATTR_VALUE,
+	"META_USER_ATTR_UNITS":           ICAT_COLUMN_META_USER_ATTR_UNITS,
+	"META_USER_ATTR_ID":              ICAT_COLUMN_META_USER_ATTR_ID,
+	"META_USER_CREATE_TIME":          ICAT_COLUMN_META_USER_CREATE_TIME,
+	"META_USER_MODIFY_TIME":          ICAT_COLUMN_META_USER_MODIFY_TIME,
+	"META_RESC_GROUP_ATTR_NAME":      ICAT_COLUMN_META_RESC_GROUP_ATTR_NAME,
+	"META_RESC_GROUP_ATTR_VALUE":     ICAT_COLUMN_META_RESC_GROUP_ATTR_VALUE,
+	"META_RESC_GROUP_ATTR_UNITS":     ICAT_COLUMN_META_RESC_GROUP_ATTR_UNITS,
+	"META_RESC_GROUP_ATTR_ID":        ICAT_COLUMN_META_RESC_GROUP_ATTR_ID,
+	"META_RESC_GROUP_CREATE_TIME":    ICAT_COLUMN_META_RESC_GROUP_CREATE_TIME,
+	"META_RESC_GROUP_MODIFY_TIME":    ICAT_COLUMN_META_RESC_GROUP_MODIFY_TIME,
+	"META_RULE_ATTR_NAME":            ICAT_COLUMN_META_RULE_ATTR_NAME,
+	"META_RULE_ATTR_VALUE":           ICAT_COLUMN_META_RULE_ATTR_VALUE,
+	"META_RULE_ATTR_UNITS":           ICAT_COLUMN_META_RULE_ATTR_UNITS,
+	"META_RULE_ATTR_ID":              ICAT_COLUMN_META_RULE_ATTR_ID,
+	"META_RULE_CREATE_TIME":          ICAT_COLUMN_META_RULE_CREATE_TIME,
+	"META_RULE_MODIFY_TIME":          ICAT_COLUMN_META_RULE_MODIFY_TIME,
+	"META_MSRVC_ATTR_NAME":           ICAT_COLUMN_META_MSRVC_ATTR_NAME,
+	"META_MSRVC_ATTR_VALUE":          ICAT_COLUMN_META_MSRVC_ATTR_VALUE,
+	"META_MSRVC_ATTR_UNITS":          ICAT_COLUMN_META_MSRVC_ATTR_UNITS,
+	"META_MSRVC_ATTR_ID":             ICAT_COLUMN_META_MSRVC_ATTR_ID,
+	"META_MSRVC_CREATE_TIME":         ICAT_COLUMN_META_MSRVC_CREATE_TIME,
+	"META_MSRVC_MODIFY_TIME":         ICAT_COLUMN_META_MSRVC_MODIFY_TIME,
+	"META_MET2_ATTR_NAME":            ICAT_COLUMN_META_MET2_ATTR_NAME,
+	"META_MET2_ATTR_VALUE":           ICAT_COLUMN_META_MET2_ATTR_VALUE,
+	"META_MET2_ATTR_UNITS":           ICAT_COLUMN_META_MET2_ATTR_UNITS,
+	"META_MET2_ATTR_ID":              ICAT_COLUMN_META_MET2_ATTR_ID,
+	"META_MET2_CREATE_TIME":          ICAT_COLUMN_META_MET2_CREATE_TIME,
+	"META_MET2_MODIFY_TIME":          ICAT_COLUMN_META_MET2_MODIFY_TIME,
+	"DATA_ACCESS_TYPE":               ICAT_COLUMN_DATA_ACCESS_TYPE,
+	"DATA_ACCESS_NAME":               ICAT_COLUMN_DATA_ACCESS_NAME,
+	"DATA_TOKEN_NAMESPACE":           ICAT_COLUMN_DATA_TOKEN_NAMESPACE,
+	"DATA_ACCESS_USER_ID":            ICAT_COLUMN_DATA_ACCESS_USER_ID,
+	"DATA_ACCESS_DATA_ID":            ICAT_COLUMN_DATA_ACCESS_DATA_ID,
+	"COLL_ACCESS_TYPE":               ICAT_COLUMN_COLL_ACCESS_TYPE,
+	"COLL_ACCESS_NAME":               ICAT_COLUMN_COLL_ACCESS_NAME,
+	"COLL_TOKEN_NAMESPACE":           ICAT_COLUMN_COLL_TOKEN_NAMESPACE,
+	"COLL_ACCESS_USER_ID":            ICAT_COLUMN_COLL_ACCESS_USER_ID,
+	"COLL_ACCESS_COLL_ID":            ICAT_COLUMN_COLL_ACCESS_COLL_ID,
+	"COLL_USER_GROUP_ID":             ICAT_COLUMN_COLL_USER_GROUP_ID,
+	"COLL_USER_GROUP_NAME":           ICAT_COLUMN_COLL_USER_GROUP_NAME,
+	"R_RESC_ID":                      ICAT_COLUMN_R_RESC_ID,
+	"R_RESC_NAME":                    ICAT_COLUMN_R_RESC_NAME,
+	"R_ZONE_NAME":                    ICAT_COLUMN_R_ZONE_NAME,
+	"R_TYPE_NAME":                    ICAT_COLUMN_R_TYPE_NAME,
+	"R_CLASS_NAME":                   ICAT_COLUMN_R_CLASS_NAME,
+	"R_LOC":                          ICAT_COLUMN_R_LOC,
+	"R_VAULT_PATH":                   ICAT_COLUMN_R_VAULT_PATH,
+	"R_FREE_SPACE":                   ICAT_COLUMN_R_FREE_SPACE,
+	"R_RESC_INFO":                    ICAT_COLUMN_R_RESC_INFO,
+	"R_RESC_COMMENT":                 ICAT_COLUMN_R_RESC_COMMENT,
+	"R_CREATE_TIME":                  ICAT_COLUMN_R_CREATE_TIME,
+	"R_MODIFY_TIME":                  ICAT_COLUMN_R_MODIFY_TIME,
+	"R_RESC_STATUS":                  ICAT_COLUMN_R_RESC_STATUS,
+	"R_FREE_SPACE_TIME":              ICAT_COLUMN_R_FREE_SPACE_TIME,
+	"R_RESC_CHILDREN":                ICAT_COLUMN_R_RESC_CHILDREN,
+	"R_RESC_CONTEXT":                 ICAT_COLUMN_R_RESC_CONTEXT,
+	"R_RESC_PARENT":                  ICAT_COLUMN_R_RESC_PARENT,
+	"R_RESC_PARENT_CONTEXT":          ICAT_COLUMN_R_RESC_PARENT_CONTEXT,
+	"QUOTA_USER_ID":                  ICAT_COLUMN_QUOTA_USER_ID,
+	"QUOTA_RESC_ID":                  ICAT_COLUMN_QUOTA_RESC_ID,
+	"QUOTA_LIMIT":                    ICAT_COLUMN_QUOTA_LIMIT,
+	"QUOTA_OVER":                     ICAT_COLUMN_QUOTA_OVER,
+	"QUOTA_MODIFY_TIME":              ICAT_COLUMN_QUOTA_MODIFY_TIME,
+	"QUOTA_USAGE_USER_ID":            ICAT_COLUMN_QUOTA_USAGE_USER_ID,
+	"QUOTA_USAGE_RESC_ID":            ICAT_COLUMN_QUOTA_USAGE_RESC_ID,
+	"QUOTA_USAGE":                    ICAT_COLUMN_QUOTA_USAGE,
+	"QUOTA_USAGE_MODIFY_TIME":        ICAT_COLUMN_QUOTA_USAGE_MODIFY_TIME,
+	"QUOTA_RESC_NAME":                ICAT_COLUMN_QUOTA_RESC_NAME,
+	"QUOTA_USER_NAME":                ICAT_COLUMN_QUOTA_USER_NAME,
+	"QUOTA_USER_ZONE":                ICAT_COLUMN_QUOTA_USER_ZONE,
+	"QUOTA_USER_TYPE":                ICAT_COLUMN_QUOTA_USER_TYPE,
+	"TICKET_ID":                      ICAT_COLUMN_TICKET_ID,
+	"TICKET_STRING":                  ICAT_COLUMN_TICKET_STRING,
+	"TICKET_TYPE":                    ICAT_COLUMN_TICKET_TYPE,
+	"TICKET_USER_ID":                 ICAT_COLUMN_TICKET_USER_ID,
+	"TICKET_OBJECT_ID":               ICAT_COLUMN_TICKET_OBJECT_ID,
+	"TICKET_OBJECT_TYPE":             ICAT_COLUMN_TICKET_OBJECT_TYPE,
+	"TICKET_USES_LIMIT":              ICAT_COLUMN_TICKET_USES_LIMIT,
+	"TICKET_USES_COUNT":              ICAT_COLUMN_TICKET_USES_COUNT,
+	"TICKET_EXPIRY_TS":               ICAT_COLUMN_TICKET_EXPIRY_TS,
+	"TICKET_WRITE_FILE_COUNT":        ICAT_COLUMN_TICKET_WRITE_FILE_COUNT,
+	"TICKET_WRITE_FILE_LIMIT":        ICAT_COLUMN_TICKET_WRITE_FILE_LIMIT,
+	"TICKET_WRITE_BYTE_COUNT":        ICAT_COLUMN_TICKET_WRITE_BYTE_COUNT,
+	"TICKET_WRITE_BYTE_LIMIT":        ICAT_COLUMN_TICKET_WRITE_BYTE_LIMIT,
+	"TICKET_ALLOWED_HOST_TICKET_ID":  ICAT_COLUMN_TICKET_ALLOWED_HOST_TICKET_ID,
+	"TICKET_ALLOWED_HOST":            ICAT_COLUMN_TICKET_ALLOWED_HOST,
+	"TICKET_ALLOWED_USER_TICKET_ID":  ICAT_COLUMN_TICKET_ALLOWED_USER_TICKET_ID,
+	"TICKET_ALLOWED_USER_NAME":       ICAT_COLUMN_TICKET_ALLOWED_USER_NAME,
+	"TICKET_ALLOWED_GROUP_TICKET_ID": ICAT_COLUMN_TICKET_ALLOWED_GROUP_TICKET_ID,
+	"TICKET_ALLOWED_GROUP_NAME":      ICAT_COLUMN_TICKET_ALLOWED_GROUP_NAME,
+	"TICKET_DATA_NAME":               ICAT_COLUMN_TICKET_DATA_NAME,
+	"TICKET_DATA_COLL_NAME":          ICAT_COLUMN_TICKET_DATA_COLL_NAME,
+	"TICKET_COLL_NAME":               ICAT_COLUMN_TICKET_COLL_NAME,
+	"TICKET_OWNER_NAME":              ICAT_COLUMN_TICKET_OWNER_NAME,
+	"TICKET_OWNER_ZONE":              ICAT_COLUMN_TICKET_OWNER_ZONE,
+	"PROCESS_ID":                     ICAT_COLUMN_PROCESS_ID,
+	"STARTTIME":                      ICAT_COLUMN_STARTTIME,
+	"PROXY_NAME":                     ICAT_COLUMN_PROXY_NAME,
+	"PROXY_ZONE":                     ICAT_COLUMN_PROXY_ZONE,
+	"CLIENT_NAME":                    ICAT_COLUMN_CLIENT_NAME,
+	"CLIENT_ZONE":                    ICAT_COLUMN_CLIENT_ZONE,
+	"REMOTE_ADDR":                    ICAT_COLUMN_REMOTE_ADDR,
+	"PROG_NAME":                      ICAT_COLUMN_PROG_NAME,
+	"SERVER_ADDR":                    ICAT_COLUMN_SERVER_ADDR,
+}
+
+// GetICATColumnNumber returns the column number of the name, e.g., "DATA_NAME" or "ICAT_COLUMN_DATA_NAME"
+func GetICATColumnNumber(name string) (ICATColumnNumber, bool) {
+	column, ok := icatColumnNames[strings.TrimPrefix(strings.ToUpper(name), "ICAT_COLUMN_")]
+	return column, ok
+}
@@ -0,0 +1,13 @@
+package common
+
+// ICATQueryOption is an option flag of GenQuery
+type ICATQueryOption int
+
+const (
+	ICAT_QUERY_OPTION_RETURN_TOTAL_ROW_COUNT ICATQueryOption = 0x20
+	ICAT_QUERY_OPTION_NO_DISTINCT            ICATQueryOption = 0x40
+	ICAT_QUERY_OPTION_QUOTA_QUERY            ICATQueryOption = 0x80
+	ICAT_QUERY_OPTION_AUTO_CLOSE             ICATQueryOption = 0x100
+	// compares upper-cased columns in conditions, condition values must be upper-cased
+	ICAT_QUERY_OPTION_UPPER_CASE_WHERE ICATQueryOption = 0x200
+)
@@ -5,6 +5,23 @@ type ICATSelectFunction int
 
 const (
 	ICAT_SELECT_FUNC_NONE  ICATSelectFunction = 1
+	ICAT_SELECT_FUNC_MIN   ICATSelectFunction = 2
+	ICAT_SELECT_FUNC_MAX   ICATSelectFunction = 3
 	ICAT_SELECT_FUNC_SUM   ICATSelectFunction = 4
+	ICAT_SELECT_FUNC_AVG   ICATSelectFunction = 5
 	ICAT_SELECT_FUNC_COUNT ICATSelectFunction = 6
+
+	// flags combined with select functions
+	ICAT_SELECT_ORDER_BY      ICATSelectFunction = 0x400
+	ICAT_SELECT_ORDER_BY_DESC ICATSelectFunction = 0x800
 )
+
+// IsAggregate returns true if the select function aggregates values, order flags are ignored
+func (selectFunc ICATSelectFunction) IsAggregate() bool {
+	switch selectFunc &^ (ICAT_SELECT_ORDER_BY | ICAT_SELECT_ORDER_BY_DESC) {
+	case ICAT_SELECT_FUNC_MIN, ICAT_SELECT_FUNC_MAX, ICAT_SELECT_FUNC_SUM, ICAT_SELECT_FUNC_AVG, ICAT_SELECT_FUNC_COUNT:
+		return true
+	default:
+		return false
+	}
+}
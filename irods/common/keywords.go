@@ -117,6 +117,7 @@ const (
 	MAX_SUB_FILE_KW    KeyWord = "maxSubFile" // max number of files for tar file bundles
 	MAX_BUNDLE_SIZE_KW KeyWord = "maxBunSize" // max size of a tar bundle in Gbs
 	NO_STAGING_KW      KeyWord = "noStaging"
+	ADD_KW             KeyWord = "add" // add to an existing struct file
 
 	// OBJ_PATH_KW already defined
 	// COLL_NAME_KW already defined
@@ -0,0 +1,23 @@
+package common
+
+// SpecialCollectionClass is a class of special collections
+type SpecialCollectionClass int
+
+// special collection classes
+const (
+	NO_SPEC_COLL     SpecialCollectionClass = 0
+	STRUCT_FILE_COLL SpecialCollectionClass = 1
+	MOUNTED_COLL     SpecialCollectionClass = 2
+	LINKED_COLL      SpecialCollectionClass = 3
+)
+
+// StructFileType is a type of struct files
+type StructFileType int
+
+// struct file types
+const (
+	NONE_STRUCT_FILE_T StructFileType = 0
+	HAAW_STRUCT_FILE_T StructFileType = 1
+	TAR_STRUCT_FILE_T  StructFileType = 2
+	MSSO_STRUCT_FILE_T StructFileType = 3
+)
@@ -0,0 +1,219 @@
+package connection
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// AddressFamily is a preference of IP address families used to connect to servers
+type AddressFamily string
+
+const (
+	// AddressFamilyAny uses addresses in the order resolved
+	AddressFamilyAny AddressFamily = ""
+	// AddressFamilyPreferIPv4 tries IPv4 addresses first
+	AddressFamilyPreferIPv4 AddressFamily = "prefer_ipv4"
+	// AddressFamilyPreferIPv6 tries IPv6 addresses first
+	AddressFamilyPreferIPv6 AddressFamily = "prefer_ipv6"
+	// AddressFamilyIPv4Only uses IPv4 addresses only
+	AddressFamilyIPv4Only AddressFamily = "ipv4"
+	// AddressFamilyIPv6Only uses IPv6 addresses only
+	AddressFamilyIPv6Only AddressFamily = "ipv6"
+)
+
+const (
+	// HappyEyeballsDelayDefault is a delay before connecting to the next address of a host in parallel, as recommended by RFC 8305
+	HappyEyeballsDelayDefault time.Duration = 250 * time.Millisecond
+)
+
+// Validate validates the address family
+func (family AddressFamily) Validate() error {
+	switch family {
+	case AddressFamilyAny, AddressFamilyPreferIPv4, AddressFamilyPreferIPv6, AddressFamilyIPv4Only, AddressFamilyIPv6Only:
+		return nil
+	default:
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "unknown address family %q", family)
+	}
+}
+
+// isIPv6Address returns true if the address is an IPv6 address
+func isIPv6Address(address string) bool {
+	ip := net.ParseIP(address)
+	return ip != nil && ip.To4() == nil
+}
+
+// orderAddressesByFamily filters and orders resolved addresses by the address family preference
+// if a family is preferred, addresses of the families are interleaved starting with the preferred one as RFC 8305 does
+func orderAddressesByFamily(addresses []string, family AddressFamily) []string {
+	ipv4Addresses := []string{}
+	ipv6Addresses := []string{}
+	for _, address := range addresses {
+		if isIPv6Address(address) {
+			ipv6Addresses = append(ipv6Addresses, address)
+		} else {
+			ipv4Addresses = append(ipv4Addresses, address)
+		}
+	}
+
+	var preferred, others []string
+	switch family {
+	case AddressFamilyIPv4Only:
+		return ipv4Addresses
+	case AddressFamilyIPv6Only:
+		return ipv6Addresses
+	case AddressFamilyPreferIPv4:
+		preferred, others = ipv4Addresses, ipv6Addresses
+	case AddressFamilyPreferIPv6:
+		preferred, others = ipv6Addresses, ipv4Addresses
+	default:
+		return addresses
+	}
+
+	ordered := []string{}
+	for i := 0; i < len(preferred) || i < len(others); i++ {
+		if i < len(preferred) {
+			ordered = append(ordered, preferred[i])
+		}
+		if i < len(others) {
+			ordered = append(ordered, others[i])
+		}
+	}
+	return ordered
+}
+
+// resolveHostAddresses resolves the host to addresses ordered by the address family preference
+// IP literals are returned as they are if they match the address family, the host is returned as it is if it can't be resolved
+func resolveHostAddresses(ctx context.Context, resolver *net.Resolver, host string, family AddressFamily) []string {
+	logger := log.WithFields(log.Fields{
+		"host":           host,
+		"address_family": family,
+	})
+
+	if net.ParseIP(host) != nil {
+		return orderAddressesByFamily([]string{host}, family)
+	}
+
+	addresses, err := resolver.LookupHost(ctx, host)
+	if err != nil || len(addresses) == 0 {
+		// let dialer report the error
+		logger.Debugf("failed to resolve host: %v", err)
+		return []string{host}
+	}
+
+	orderedAddresses := orderAddressesByFamily(addresses, family)
+	if len(orderedAddresses) == 0 {
+		logger.Debugf("host has no address of the address family, resolved addresses %v", addresses)
+	}
+
+	return orderedAddresses
+}
+
+// dialResult is a result of a connection attempt
+type dialResult struct {
+	endpoint hostEndpoint
+	socket   net.Conn
+	err      error
+}
+
+// dialEndpoints connects to one of the endpoints, endpoints are tried in order.
+// If delay is positive, the next endpoint is tried in parallel when an attempt doesn't finish within the delay (Happy Eyeballs),
+// the first connection made is returned and the others are closed.
+// onFailure is called for each failed attempt.
+func dialEndpoints(ctx context.Context, endpoints []hostEndpoint, delay time.Duration, dial func(ctx context.Context, endpoint hostEndpoint) (net.Conn, error), onFailure func(endpoint hostEndpoint, err error)) (net.Conn, hostEndpoint, error) {
+	if len(endpoints) == 0 {
+		return nil, hostEndpoint{}, errors.Errorf("no address to connect")
+	}
+
+	if delay <= 0 {
+		// one at a time
+		var lastErr error
+		for _, endpoint := range endpoints {
+			socket, err := dial(ctx, endpoint)
+			if err == nil {
+				return socket, endpoint, nil
+			}
+
+			onFailure(endpoint, err)
+			lastErr = err
+		}
+
+		return nil, hostEndpoint{}, lastErr
+	}
+
+	attemptCtx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	results := make(chan dialResult, len(endpoints))
+	next := 0
+	pending := 0
+
+	startAttempt := func() {
+		endpoint := endpoints[next]
+		next++
+		pending++
+
+		go func() {
+			socket, err := dial(attemptCtx, endpoint)
+			results <- dialResult{
+				endpoint: endpoint,
+				socket:   socket,
+				err:      err,
+			}
+		}()
+	}
+
+	startAttempt()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var lastErr error
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			if next < len(endpoints) {
+				startAttempt()
+				timer.Reset(delay)
+			}
+		case result := <-results:
+			pending--
+
+			if result.err == nil {
+				// close connections of other attempts made concurrently
+				go func(pending int) {
+					for i := 0; i < pending; i++ {
+						otherResult := <-results
+						if otherResult.err == nil {
+							_ = otherResult.socket.Close()
+						}
+					}
+				}(pending)
+
+				return result.socket, result.endpoint, nil
+			}
+
+			onFailure(result.endpoint, result.err)
+			lastErr = result.err
+
+			// try the next one immediately
+			if next < len(endpoints) {
+				startAttempt()
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(delay)
+			}
+		}
+	}
+
+	return nil, hostEndpoint{}, lastErr
+}
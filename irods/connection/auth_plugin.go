@@ -2,6 +2,8 @@ package connection
 
 import (
 	"encoding/json"
+	"strconv"
+	"sync"
 
 	"github.com/cockroachdb/errors"
 	"github.com/cyverse/go-irodsclient/irods/message"
@@ -181,7 +183,7 @@ func (ctx *IRODSAuthContext) GetRedacted() *IRODSAuthContext {
 
 	// redact password
 	for k := range copy.context {
-		if k == AUTH_PASSWORD_KEY || k == "password" {
+		if k == AUTH_PASSWORD_KEY || k == "password" || k == OPENID_AUTH_ACCESS_TOKEN_KEY {
 			copy.context[k] = "REDACTED"
 		}
 	}
@@ -227,7 +229,7 @@ func (plugin *BaseIRODSAuthPlugin) Execute(conn *IRODSConnection, operationName
 }
 
 func (plugin *BaseIRODSAuthPlugin) Request(conn *IRODSConnection, context *IRODSAuthContext) (*IRODSAuthContext, error) {
-	timeout := conn.GetOperationTimeout()
+	timeout := conn.GetAuthTimeout()
 
 	authRequest := message.NewIRODSMessageNewAuthPluginRequest(context.context)
 
@@ -240,3 +242,88 @@ func (plugin *BaseIRODSAuthPlugin) Request(conn *IRODSConnection, context *IRODS
 
 	return &IRODSAuthContext{context: authResponse.AuthContext}, nil
 }
+
+// IRODSAuthPluginFactory creates an auth plugin and its initial auth context for the given connection
+// the connection is already started up, so SSL status and server version are available
+type IRODSAuthPluginFactory func(conn *IRODSConnection) (IRODSAuthPlugin, *IRODSAuthContext, error)
+
+var (
+	authPluginFactories     = map[types.AuthScheme]IRODSAuthPluginFactory{}
+	authPluginFactoriesLock sync.RWMutex
+)
+
+func init() {
+	RegisterAuthPlugin(types.AuthSchemeNative, newNativeAuthPluginForConnection)
+	RegisterAuthPlugin(types.AuthSchemePAM, newPAMPasswordAuthPluginForConnection)
+	RegisterAuthPlugin(types.AuthSchemePAMPassword, newPAMPasswordAuthPluginForConnection)
+	RegisterAuthPlugin(types.AuthSchemePAMInteractive, newPAMInteractiveAuthPluginForConnection)
+	RegisterAuthPlugin(types.AuthSchemeOpenID, newOpenIDAuthPluginForConnection)
+}
+
+// RegisterAuthPlugin registers an auth plugin factory for the auth scheme
+// registered plugins are used with iRODS 4.3+ servers that support the new auth framework
+// registering a factory for an existing scheme replaces it
+func RegisterAuthPlugin(authScheme types.AuthScheme, factory IRODSAuthPluginFactory) {
+	authPluginFactoriesLock.Lock()
+	defer authPluginFactoriesLock.Unlock()
+
+	if factory == nil {
+		delete(authPluginFactories, authScheme)
+		return
+	}
+
+	authPluginFactories[authScheme] = factory
+}
+
+// GetAuthPluginFactory returns an auth plugin factory registered for the auth scheme
+func GetAuthPluginFactory(authScheme types.AuthScheme) (IRODSAuthPluginFactory, bool) {
+	authPluginFactoriesLock.RLock()
+	defer authPluginFactoriesLock.RUnlock()
+
+	factory, ok := authPluginFactories[authScheme]
+	return factory, ok
+}
+
+func newNativeAuthPluginForConnection(conn *IRODSConnection) (IRODSAuthPlugin, *IRODSAuthContext, error) {
+	plugin := NewNativeAuthPlugin()
+	authContext := NewIRODSAuthContext()
+	authContext.Set("password", conn.account.Password)
+	authContext.Set(AUTH_TTL_KEY, "0")
+
+	return plugin, authContext, nil
+}
+
+func newPAMPasswordAuthPluginForConnection(conn *IRODSConnection) (IRODSAuthPlugin, *IRODSAuthContext, error) {
+	ttl := conn.account.PamTTL
+	if ttl < 0 {
+		ttl = 0 // server decides
+	}
+
+	plugin := NewPAMPasswordAuthPlugin(conn.isSSLSocket)
+	authContext := NewIRODSAuthContext()
+	authContext.Set("password", conn.account.Password)
+	authContext.Set(AUTH_TTL_KEY, strconv.Itoa(ttl))
+
+	return plugin, authContext, nil
+}
+
+func newPAMInteractiveAuthPluginForConnection(conn *IRODSConnection) (IRODSAuthPlugin, *IRODSAuthContext, error) {
+	var plugin *PAMInteractiveAuthPlugin
+	if conn.config.PAMInteractivePromptHandler != nil {
+		plugin = NewPAMInteractiveAuthPluginWithPromptHandler(conn.isSSLSocket, conn.config.PAMInteractivePromptHandler)
+	} else {
+		plugin = NewPAMInteractiveAuthPlugin(conn.isSSLSocket)
+	}
+
+	return plugin, NewIRODSAuthContext(), nil
+}
+
+func newOpenIDAuthPluginForConnection(conn *IRODSConnection) (IRODSAuthPlugin, *IRODSAuthContext, error) {
+	tokenSource := conn.config.OpenIDTokenSource
+	if tokenSource == nil {
+		// use password as a static access token
+		tokenSource = StaticOpenIDTokenSource(conn.account.Password)
+	}
+
+	return NewOpenIDAuthPlugin(tokenSource), NewIRODSAuthContext(), nil
+}
@@ -1,6 +1,8 @@
 package connection
 
 import (
+	"io"
+	"net"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -15,21 +17,55 @@ const (
 
 	OperationTimeoutDefault     time.Duration = 1 * time.Minute
 	LongOperationTimeoutDefault time.Duration = 5 * time.Minute
+
+	ControlKeepAliveIntervalDefault time.Duration = 1 * time.Minute
 )
 
 type IRODSConnectionConfig struct {
 	ConnectTimeout       time.Duration
-	OperationTimeout     time.Duration
-	LongOperationTimeout time.Duration
+	AuthTimeout          time.Duration // timeout for authentication, OperationTimeout is used if not set
+	OperationTimeout     time.Duration // timeout for metadata operations
+	LongOperationTimeout time.Duration // timeout for metadata operations that take a long time
+	DataTransferTimeout  time.Duration // timeout for each data transfer request, OperationTimeout is used if not set
 	ApplicationName      string
-	TcpBufferSize        int
+	TcpBufferSize        int           // SO_SNDBUF and SO_RCVBUF, 0 uses system default
+	TcpSendBufferSize    int           // SO_SNDBUF, overrides TcpBufferSize if set
+	TcpReceiveBufferSize int           // SO_RCVBUF, overrides TcpBufferSize if set
+	TcpKeepAlivePeriod   time.Duration // TCP keepalive interval, 0 uses default, negative disables TCP keepalive
+	TcpDelay             bool          // if true, Nagle's algorithm is enabled (TCP_NODELAY is not set)
+
+	AddressFamily      AddressFamily // preference of IP address families of servers, empty uses addresses in the order resolved
+	HappyEyeballsDelay time.Duration // delay before connecting to the next address of a host in parallel, 0 uses default, negative tries addresses one at a time
+	Resolver           *net.Resolver // can be null, resolves host names and SRV records with net.DefaultResolver if not set
+
+	ControlKeepAliveInterval time.Duration // interval to ping the connection while it controls a parallel transfer, 0 uses default, negative disables
+
+	RetryPolicy *RetryPolicy // can be null, uses default retry policy if not set
+
+	WireDebugWriter      io.Writer // can be null, dumps sent/received messages with credentials redacted if set
+	WireDebugBinaryLimit int       // max binary bytes dumped per message, 0 uses default, negative disables binary dump
+
+	FaultInjectionPolicy *FaultInjectionPolicy // can be null, injects failures to the socket for testing if set
+
+	PAMInteractivePromptHandler PAMInteractivePromptHandler // can be null, reads from stdin if not set
+	OpenIDTokenSource           OpenIDTokenSource           // can be null, uses password as an access token if not set
 
 	Metrics *metrics.IRODSMetrics // can be null
 }
 
 type IRODSResourceServerConnectionConfig struct {
-	ConnectTimeout time.Duration
-	TcpBufferSize  int
+	ConnectTimeout       time.Duration
+	TcpBufferSize        int           // SO_SNDBUF and SO_RCVBUF, 0 uses system default
+	TcpSendBufferSize    int           // SO_SNDBUF, overrides TcpBufferSize if set
+	TcpReceiveBufferSize int           // SO_RCVBUF, overrides TcpBufferSize if set
+	TcpKeepAlivePeriod   time.Duration // TCP keepalive interval, 0 uses default, negative disables TCP keepalive
+	TcpDelay             bool          // if true, Nagle's algorithm is enabled (TCP_NODELAY is not set)
+
+	AddressFamily      AddressFamily // preference of IP address families of servers, empty uses addresses in the order resolved
+	HappyEyeballsDelay time.Duration // delay before connecting to the next address of a host in parallel, 0 uses default, negative tries addresses one at a time
+	Resolver           *net.Resolver // can be null, resolves host names with net.DefaultResolver if not set
+
+	FaultInjectionPolicy *FaultInjectionPolicy // can be null, injects failures to the socket for testing if set
 
 	Metrics *metrics.IRODSMetrics // can be null
 }
@@ -47,6 +83,22 @@ func (connConfig *IRODSConnectionConfig) fillDefaults() {
 		connConfig.LongOperationTimeout = LongOperationTimeoutDefault
 	}
 
+	if connConfig.AuthTimeout <= 0 {
+		connConfig.AuthTimeout = connConfig.OperationTimeout
+	}
+
+	if connConfig.DataTransferTimeout <= 0 {
+		connConfig.DataTransferTimeout = connConfig.OperationTimeout
+	}
+
+	if connConfig.HappyEyeballsDelay == 0 {
+		connConfig.HappyEyeballsDelay = HappyEyeballsDelayDefault
+	}
+
+	if connConfig.ControlKeepAliveInterval == 0 {
+		connConfig.ControlKeepAliveInterval = ControlKeepAliveIntervalDefault
+	}
+
 	if len(connConfig.ApplicationName) == 0 {
 		connConfig.ApplicationName = ApplicationNameDefault
 	}
@@ -54,6 +106,14 @@ func (connConfig *IRODSConnectionConfig) fillDefaults() {
 	if connConfig.TcpBufferSize < 0 {
 		connConfig.TcpBufferSize = 0
 	}
+
+	if connConfig.RetryPolicy == nil {
+		connConfig.RetryPolicy = NewDefaultRetryPolicy()
+	}
+
+	if connConfig.WireDebugBinaryLimit == 0 {
+		connConfig.WireDebugBinaryLimit = WireDebugBinaryLimitDefault
+	}
 }
 
 func (connConfig *IRODSConnectionConfig) Validate() error {
@@ -77,11 +137,50 @@ func (connConfig *IRODSConnectionConfig) Validate() error {
 		return errors.Wrapf(newErr, "long operation timeout is invalid")
 	}
 
+	if connConfig.AuthTimeout <= 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "auth timeout is invalid")
+	}
+
+	if connConfig.DataTransferTimeout <= 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "data transfer timeout is invalid")
+	}
+
 	if connConfig.TcpBufferSize < 0 {
 		newErr := types.NewConnectionConfigError(nil)
 		return errors.Wrapf(newErr, "tcp buffer size is invalid")
 	}
 
+	if connConfig.TcpSendBufferSize < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "tcp send buffer size is invalid")
+	}
+
+	if connConfig.TcpReceiveBufferSize < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "tcp receive buffer size is invalid")
+	}
+
+	err := connConfig.AddressFamily.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "address family is invalid")
+	}
+
+	if connConfig.RetryPolicy != nil {
+		err := connConfig.RetryPolicy.Validate()
+		if err != nil {
+			return errors.Wrapf(err, "retry policy is invalid")
+		}
+	}
+
+	if connConfig.FaultInjectionPolicy != nil {
+		err := connConfig.FaultInjectionPolicy.Validate()
+		if err != nil {
+			return errors.Wrapf(err, "fault injection policy is invalid")
+		}
+	}
+
 	return nil
 }
 
@@ -93,6 +192,10 @@ func (connConfig *IRODSResourceServerConnectionConfig) fillDefaults() {
 	if connConfig.TcpBufferSize <= 0 {
 		connConfig.TcpBufferSize = TcpBufferSizeDefault
 	}
+
+	if connConfig.HappyEyeballsDelay == 0 {
+		connConfig.HappyEyeballsDelay = HappyEyeballsDelayDefault
+	}
 }
 
 func (connConfig *IRODSResourceServerConnectionConfig) Validate() error {
@@ -106,5 +209,66 @@ func (connConfig *IRODSResourceServerConnectionConfig) Validate() error {
 		return errors.Wrapf(newErr, "tcp buffer size is invalid")
 	}
 
+	if connConfig.TcpSendBufferSize < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "tcp send buffer size is invalid")
+	}
+
+	if connConfig.TcpReceiveBufferSize < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "tcp receive buffer size is invalid")
+	}
+
+	err := connConfig.AddressFamily.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "address family is invalid")
+	}
+
+	if connConfig.FaultInjectionPolicy != nil {
+		err := connConfig.FaultInjectionPolicy.Validate()
+		if err != nil {
+			return errors.Wrapf(err, "fault injection policy is invalid")
+		}
+	}
+
 	return nil
 }
+
+func (connConfig *IRODSConnectionConfig) getResolver() *net.Resolver {
+	if connConfig.Resolver == nil {
+		return net.DefaultResolver
+	}
+	return connConfig.Resolver
+}
+
+func (connConfig *IRODSResourceServerConnectionConfig) getResolver() *net.Resolver {
+	if connConfig.Resolver == nil {
+		return net.DefaultResolver
+	}
+	return connConfig.Resolver
+}
+
+func (connConfig *IRODSConnectionConfig) getTCPSocketOptions() tcpSocketOptions {
+	return makeTCPSocketOptions(connConfig.TcpBufferSize, connConfig.TcpSendBufferSize, connConfig.TcpReceiveBufferSize, connConfig.TcpKeepAlivePeriod, connConfig.TcpDelay)
+}
+
+func (connConfig *IRODSResourceServerConnectionConfig) getTCPSocketOptions() tcpSocketOptions {
+	return makeTCPSocketOptions(connConfig.TcpBufferSize, connConfig.TcpSendBufferSize, connConfig.TcpReceiveBufferSize, connConfig.TcpKeepAlivePeriod, connConfig.TcpDelay)
+}
+
+func makeTCPSocketOptions(bufferSize int, sendBufferSize int, receiveBufferSize int, keepAlivePeriod time.Duration, delay bool) tcpSocketOptions {
+	if sendBufferSize <= 0 {
+		sendBufferSize = bufferSize
+	}
+
+	if receiveBufferSize <= 0 {
+		receiveBufferSize = bufferSize
+	}
+
+	return tcpSocketOptions{
+		KeepAlivePeriod:   keepAlivePeriod,
+		Delay:             delay,
+		SendBufferSize:    sendBufferSize,
+		ReceiveBufferSize: receiveBufferSize,
+	}
+}
@@ -32,7 +32,10 @@ type IRODSConnection struct {
 	failed               bool
 	isSSLSocket          bool
 	socket               net.Conn
+	serverHost           string // host actually connected, may differ from account's host on failover
+	serverAddress        string // address actually connected
 	serverVersion        *types.IRODSVersion
+	serverCapabilities   *types.IRODSServerCapabilities
 	sslSharedSecret      []byte
 	creationTime         time.Time
 	lastSuccessfulAccess time.Time
@@ -133,18 +136,28 @@ func (conn *IRODSConnection) SetReadTimeout(timeout time.Duration) error {
 	return nil
 }
 
+// GetServerCapabilities returns features supported by the server, computed when the connection is made
+// the returned value must not be modified
+func (conn *IRODSConnection) GetServerCapabilities() *types.IRODSServerCapabilities {
+	if conn.serverCapabilities == nil {
+		return types.NewIRODSServerCapabilities(conn.serverVersion)
+	}
+
+	return conn.serverCapabilities
+}
+
 // SupportParallelUpload checks if the server supports parallel upload
 // available from 4.2.9
 func (conn *IRODSConnection) SupportParallelUpload() bool {
-	return conn.serverVersion.HasHigherVersionThan(4, 2, 9)
+	return conn.GetServerCapabilities().ParallelUpload
 }
 
 func (conn *IRODSConnection) requireNewAuthFramework() bool {
-	return conn.serverVersion.HasHigherVersionThan(4, 3, 0)
+	return conn.GetServerCapabilities().NewAuthFramework
 }
 
 func (conn *IRODSConnection) requireNewPamAuth() bool {
-	return conn.serverVersion.HasHigherVersionThan(4, 3, 0)
+	return conn.GetServerCapabilities().NewAuthFramework
 }
 
 func (conn *IRODSConnection) requiresCSNegotiation() bool {
@@ -189,6 +202,27 @@ func (conn *IRODSConnection) GetLastSuccessfulAccess() time.Time {
 	return conn.lastSuccessfulAccess
 }
 
+// GetControlKeepAliveInterval returns interval to ping the connection while it controls a parallel transfer, returns 0 if disabled
+func (conn *IRODSConnection) GetControlKeepAliveInterval() time.Duration {
+	if conn.config.ControlKeepAliveInterval < 0 {
+		return 0
+	}
+	return conn.config.ControlKeepAliveInterval
+}
+
+// GetRetryPolicy returns retry policy for operations on this connection
+func (conn *IRODSConnection) GetRetryPolicy() *RetryPolicy {
+	if conn.config.RetryPolicy == nil {
+		return NewDefaultRetryPolicy()
+	}
+	return conn.config.RetryPolicy
+}
+
+// GetServerAddress returns the address of the server actually connected
+func (conn *IRODSConnection) GetServerAddress() string {
+	return conn.serverAddress
+}
+
 // GetClientSignature returns client signature to be used in password obfuscation
 func (conn *IRODSConnection) GetClientSignature() string {
 	return conn.clientSignature
@@ -204,83 +238,59 @@ func (conn *IRODSConnection) IsTransactionDirty() bool {
 	return conn.dirtyTransaction
 }
 
-// setSocketOpt sets socket opts
-func (conn *IRODSConnection) setSocketOpt(socket net.Conn, bufferSize int) {
-	logger := log.WithFields(log.Fields{
-		"buffer_size": bufferSize,
-	})
-
-	if tcpSocket, ok := socket.(*net.TCPConn); ok {
-		// TCP socket
-		err := tcpSocket.SetNoDelay(true)
-		if err != nil {
-			logger.Errorf("failed to set no delay: %+v", err)
-		}
+func (conn *IRODSConnection) connectTCP() error {
+	logger := log.WithFields(log.Fields{})
 
-		err = tcpSocket.SetKeepAlive(true)
-		if err != nil {
-			logger.Errorf("failed to set keep alive: %+v", err)
-		}
+	resolveCtx, resolveCancelFunc := context.WithTimeout(context.Background(), conn.config.ConnectTimeout)
+	endpoints := getHostEndpoints(resolveCtx, conn.account, conn.config.getResolver(), conn.config.AddressFamily)
+	resolveCancelFunc()
 
-		err = tcpSocket.SetKeepAlivePeriod(15 * time.Second) // 15 seconds
-		if err != nil {
-			logger.Errorf("failed to set keep alive period: %+v", err)
-		}
+	dial := func(ctx context.Context, endpoint hostEndpoint) (net.Conn, error) {
+		logger.Debugf("Connecting to %s", endpoint.Address)
 
-		err = tcpSocket.SetLinger(5) // 5 seconds
-		if err != nil {
-			logger.Errorf("failed to set linger: %+v", err)
-		}
+		// must connect to the server within ConnectTimeout
+		dialCtx, cancelFunc := context.WithTimeout(ctx, conn.config.ConnectTimeout)
+		defer cancelFunc()
 
-		// TCP buffer size
-		if bufferSize > 0 {
-			logger.Infof("setting tcp buffer size to %d", bufferSize)
+		return dialTCP(dialCtx, endpoint.Address, conn.account.ProxyURL, conn.config.getTCPSocketOptions())
+	}
 
-			sockErr := tcpSocket.SetReadBuffer(bufferSize)
-			if sockErr != nil {
-				sockBuffErr := errors.Wrapf(sockErr, "failed to set tcp read buffer size %d", bufferSize)
-				logger.Errorf("%+v", sockBuffErr)
-			}
+	onFailure := func(endpoint hostEndpoint, err error) {
+		logger.Debugf("failed to connect to %s, trying next address: %v", endpoint.Address, err)
+		hostHealth.MarkFailure(endpoint.Address)
 
-			sockErr = tcpSocket.SetWriteBuffer(bufferSize)
-			if sockErr != nil {
-				sockBuffErr := errors.Wrapf(sockErr, "failed to set tcp write buffer size %d", bufferSize)
-				logger.Errorf("%+v", sockBuffErr)
-			}
+		if conn.config.Metrics != nil {
+			conn.config.Metrics.IncreaseCounterForConnectionFailures(1)
 		}
 	}
-}
-
-func (conn *IRODSConnection) connectTCP() error {
-	logger := log.WithFields(log.Fields{})
 
-	server := fmt.Sprintf("%s:%d", conn.account.Host, conn.account.Port)
-	logger.Debugf("Connecting to %s", server)
-
-	// must connect to the server within ConnectTimeout
-	var dialer net.Dialer
-	ctx, cancelFunc := context.WithTimeout(context.Background(), conn.config.ConnectTimeout)
-	defer cancelFunc()
+	var lastErr error
+	for _, hostEndpoints := range groupEndpointsByHost(endpoints) {
+		// addresses of a host are tried in parallel (Happy Eyeballs), hosts are tried in order
+		socket, endpoint, err := dialEndpoints(context.Background(), hostEndpoints, conn.config.HappyEyeballsDelay, dial, onFailure)
+		if err != nil {
+			lastErr = err
+			continue
+		}
 
-	socket, err := dialer.DialContext(ctx, "tcp", server)
-	if err != nil {
-		newErr := errors.Join(err, types.NewConnectionError())
-		connErr := errors.Wrapf(newErr, "failed to connect to specified host %q and port %d", conn.account.Host, conn.account.Port)
+		hostHealth.MarkSuccess(endpoint.Address)
 
 		if conn.config.Metrics != nil {
-			conn.config.Metrics.IncreaseCounterForConnectionFailures(1)
+			conn.config.Metrics.IncreaseConnectionsOpened(1)
 		}
-		return connErr
-	}
 
-	conn.setSocketOpt(socket, conn.config.TcpBufferSize)
+		conn.socket = conn.config.FaultInjectionPolicy.wrap(socket, endpoint.Address)
+		conn.serverHost = endpoint.Host
+		conn.serverAddress = endpoint.Address
+		return nil
+	}
 
-	if conn.config.Metrics != nil {
-		conn.config.Metrics.IncreaseConnectionsOpened(1)
+	if lastErr == nil {
+		lastErr = errors.Errorf("no host to connect")
 	}
 
-	conn.socket = socket
-	return nil
+	newErr := errors.Join(lastErr, types.NewConnectionError())
+	return errors.Wrapf(newErr, "failed to connect to specified hosts %v and port %d", conn.account.GetHosts(), conn.account.Port)
 }
 
 // Connect connects to iRODS
@@ -293,8 +303,6 @@ func (conn *IRODSConnection) Connect() error {
 }
 
 func (conn *IRODSConnection) connectInternal() error {
-	timeout := conn.GetOperationTimeout()
-
 	conn.connected = false
 
 	// connect TCP
@@ -305,7 +313,7 @@ func (conn *IRODSConnection) connectInternal() error {
 
 	irodsVersion, err := conn.startup()
 	if err != nil {
-		connErr := errors.Wrapf(err, "failed to startup an iRODS connection to server %q and port %d", conn.account.Host, conn.account.Port)
+		connErr := errors.Wrapf(err, "failed to startup an iRODS connection to server %q", conn.serverAddress)
 		_ = conn.disconnectNow()
 		if conn.config.Metrics != nil {
 			conn.config.Metrics.IncreaseCounterForConnectionFailures(1)
@@ -314,82 +322,126 @@ func (conn *IRODSConnection) connectInternal() error {
 	}
 
 	conn.serverVersion = irodsVersion
+	conn.serverCapabilities = types.NewIRODSServerCapabilities(irodsVersion)
 
-	switch conn.account.AuthenticationScheme {
-	case types.AuthSchemeNative:
-		if conn.requireNewAuthFramework() {
-			err = conn.loginNativePlugin()
-		} else {
-			err = conn.loginNativeLegacy()
-		}
-	case types.AuthSchemePAM, types.AuthSchemePAMPassword:
-		if conn.requireNewAuthFramework() {
-			if len(conn.account.PAMToken) > 0 {
-				err = conn.loginPAMWithTokenPlugin()
-			} else {
-				err = conn.loginPAMWithPasswordPlugin()
-			}
+	err = conn.login()
+	if err != nil {
+		_ = conn.logout()
+		_ = conn.disconnectNow()
+		return err
+	}
+
+	if conn.serverCapabilities.LibraryFeatures {
+		features, err := conn.getLibraryFeatures()
+		if err != nil {
+			// features are optional
+			log.WithError(err).Debugf("failed to get library features of server %q", conn.serverAddress)
 		} else {
-			if len(conn.account.PAMToken) > 0 {
-				err = conn.loginPAMWithTokenLegacy()
-			} else {
-				err = conn.loginPAMWithPasswordLegacy()
-				if err != nil {
-					return errors.Wrapf(err, "failed to login to irods using PAM authentication")
-				}
-
-				// reconnect when success
-				_ = conn.logout()
-				_ = conn.disconnectNow()
-
-				// connect TCP
-				err = conn.connectTCP()
-				if err != nil {
-					return err
-				}
-
-				_, err = conn.startup()
-				if err != nil {
-					connErr := errors.Wrapf(err, "failed to startup an iRODS connection to server %q and port %d", conn.account.Host, conn.account.Port)
-					_ = conn.logout()
-					_ = conn.disconnectNow()
-					if conn.config.Metrics != nil {
-						conn.config.Metrics.IncreaseCounterForConnectionFailures(1)
-					}
-					return connErr
-				}
-
-				err = conn.loginPAMWithTokenLegacy()
-			}
+			conn.serverCapabilities.Features = features
 		}
-	default:
+	}
+
+	conn.connected = true
+	conn.lastSuccessfulAccess = time.Now()
+	return nil
+}
+
+// loginFuncs are login functions for schemes that also support servers without the new auth framework
+// other registered schemes log in with their auth plugins
+var loginFuncs = map[types.AuthScheme]func(conn *IRODSConnection) error{
+	types.AuthSchemeNative:         (*IRODSConnection).loginNative,
+	types.AuthSchemePAM:            (*IRODSConnection).loginPAM,
+	types.AuthSchemePAMPassword:    (*IRODSConnection).loginPAM,
+	types.AuthSchemePAMInteractive: (*IRODSConnection).loginPAM,
+}
+
+// login authenticates with the authentication scheme of the account, and supplies the ticket if given
+func (conn *IRODSConnection) login() error {
+	timeout := conn.GetOperationTimeout()
+
+	authScheme := conn.account.AuthenticationScheme
+	if _, ok := GetAuthPluginFactory(authScheme); !ok {
 		newErr := types.NewConnectionConfigError(conn.account)
-		err = errors.Wrapf(newErr, "unknown Authentication Scheme %q", conn.account.AuthenticationScheme)
+		return errors.Wrapf(newErr, "no auth plugin is registered for scheme %q", authScheme)
+	}
+
+	var err error
+	if loginFunc, ok := loginFuncs[authScheme]; ok {
+		err = loginFunc(conn)
+	} else if conn.requireNewAuthFramework() {
+		err = conn.loginPlugin()
+	} else {
+		newErr := types.NewConnectionConfigError(conn.account)
+		err = errors.Wrapf(newErr, "authentication scheme %q requires iRODS 4.3 or higher", authScheme)
 	}
 
 	if err != nil {
-		connErr := errors.Wrapf(err, "failed to login to irods")
-		_ = conn.logout()
-		_ = conn.disconnectNow()
-		return connErr
+		return errors.Wrapf(err, "failed to login to irods")
 	}
 
 	if conn.account.UseTicket() {
 		req := message.NewIRODSMessageTicketAdminRequest("session", conn.account.Ticket)
 		err := conn.RequestAndCheck(req, &message.IRODSMessageTicketAdminResponse{}, nil, timeout)
 		if err != nil {
-			_ = conn.logout()
-			_ = conn.disconnectNow()
 			newErr := errors.Join(err, types.NewAuthError(conn.account))
 			return errors.Wrapf(newErr, "received supply ticket error")
 		}
 	}
 
-	conn.connected = true
-	conn.lastSuccessfulAccess = time.Now()
 	return nil
 }
 
+var (
+	// libraryFeaturesCache keeps features reported by servers, so pooled connections do not ask again
+	libraryFeaturesCache     = map[string]map[string]int{}
+	libraryFeaturesCacheLock sync.RWMutex
+)
+
+// getLibraryFeaturesCacheKey returns a key of the features cache, the server version is included to detect upgrades
+func (conn *IRODSConnection) getLibraryFeaturesCacheKey() string {
+	releaseVersion := ""
+	if conn.serverVersion != nil {
+		releaseVersion = conn.serverVersion.ReleaseVersion
+	}
+
+	return fmt.Sprintf("%s|%s#%s|%s", conn.serverAddress, conn.account.ProxyUser, conn.account.ProxyZone, releaseVersion)
+}
+
+// getLibraryFeatures returns features reported by get_library_features
+// features are cached per server and account, failures are not cached
+func (conn *IRODSConnection) getLibraryFeatures() (map[string]int, error) {
+	key := conn.getLibraryFeaturesCacheKey()
+
+	libraryFeaturesCacheLock.RLock()
+	cachedFeatures, ok := libraryFeaturesCache[key]
+	libraryFeaturesCacheLock.RUnlock()
+
+	if ok {
+		return copyLibraryFeatures(cachedFeatures), nil
+	}
+
+	request := message.NewIRODSMessageGetLibraryFeaturesRequest()
+	response := message.IRODSMessageGetLibraryFeaturesResponse{}
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get library features")
+	}
+
+	libraryFeaturesCacheLock.Lock()
+	libraryFeaturesCache[key] = copyLibraryFeatures(response.Features)
+	libraryFeaturesCacheLock.Unlock()
+
+	return response.Features, nil
+}
+
+func copyLibraryFeatures(features map[string]int) map[string]int {
+	newFeatures := make(map[string]int, len(features))
+	for name, value := range features {
+		newFeatures[name] = value
+	}
+	return newFeatures
+}
+
 func (conn *IRODSConnection) startup() (*types.IRODSVersion, error) {
 	logger := log.WithFields(log.Fields{})
 
@@ -484,7 +536,7 @@ func (conn *IRODSConnection) startup() (*types.IRODSVersion, error) {
 
 		// If negotiation failed we're done
 		if policyResult == types.CSNegotiationFailure {
-			newErr := types.NewConnectionError()
+			newErr := errors.Join(types.NewConnectionError(), types.NewSSLNegotiationError())
 			return nil, errors.Wrapf(newErr, "client-server negotiation failed (client %q, server %q)", string(clientPolicy), string(serverPolicy))
 		}
 
@@ -500,7 +552,8 @@ func (conn *IRODSConnection) startup() (*types.IRODSVersion, error) {
 		if policyResult == types.CSNegotiationUseSSL {
 			err := conn.sslStartup()
 			if err != nil {
-				return nil, errors.Wrapf(err, "failed to start up SSL")
+				newErr := errors.Join(err, types.NewSSLNegotiationError())
+				return nil, errors.Wrapf(newErr, "failed to start up SSL")
 			}
 		}
 
@@ -525,7 +578,7 @@ func (conn *IRODSConnection) sslStartup() error {
 		return errors.Wrapf(newErr, "SSL Configuration is not set")
 	}
 
-	tlsConfig, err := irodsSSLConfig.GetTLSConfig(conn.account.Host, true)
+	tlsConfig, err := irodsSSLConfig.GetTLSConfig(conn.serverHost, true)
 	if err != nil {
 		newErr := errors.Join(err, types.NewConnectionConfigError(conn.account))
 		return errors.Wrapf(newErr, "Failed to get TLS config")
@@ -537,7 +590,7 @@ func (conn *IRODSConnection) sslStartup() error {
 	err = sslSocket.Handshake()
 	if err != nil {
 		newErr := errors.Join(err, types.NewConnectionError())
-		return errors.Wrapf(newErr, "SSL Handshake error")
+		return errors.Wrapf(newErr, "SSL Handshake error (trust mode: %s)", irodsSSLConfig.GetTrustDescription())
 	}
 
 	// from now on use ssl socket
@@ -573,6 +626,14 @@ func (conn *IRODSConnection) sslStartup() error {
 	return nil
 }
 
+// loginNative logs in using native authentication, with the auth plugin if the server supports it
+func (conn *IRODSConnection) loginNative() error {
+	if conn.requireNewAuthFramework() {
+		return conn.loginNativePlugin()
+	}
+	return conn.loginNativeLegacy()
+}
+
 func (conn *IRODSConnection) loginNativeLegacy() error {
 	logger := log.WithFields(log.Fields{})
 	logger.Debug("Logging in using legacy native authentication method")
@@ -584,14 +645,128 @@ func (conn *IRODSConnection) loginNativePlugin() error {
 	logger := log.WithFields(log.Fields{})
 	logger.Debug("Logging in using native authentication method with plugin")
 
-	plugin := NewNativeAuthPlugin()
-	authContext := NewIRODSAuthContext()
-	authContext.Set("password", conn.account.Password)
-	authContext.Set(AUTH_TTL_KEY, "0")
+	return conn.loginPlugin()
+}
+
+// loginPlugin logs in using the auth plugin registered for the account's auth scheme
+func (conn *IRODSConnection) loginPlugin() error {
+	logger := log.WithFields(log.Fields{})
+
+	authScheme := conn.account.AuthenticationScheme
+	logger.Debugf("Logging in using auth plugin for scheme %q", authScheme)
+
+	factory, ok := GetAuthPluginFactory(authScheme)
+	if !ok {
+		newErr := types.NewConnectionConfigError(conn.account)
+		return errors.Wrapf(newErr, "no auth plugin is registered for scheme %q", authScheme)
+	}
+
+	plugin, authContext, err := factory(conn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create auth plugin for scheme %q", authScheme)
+	}
+
+	if authContext == nil {
+		authContext = NewIRODSAuthContext()
+	}
 
 	return AuthenticateClient(conn, plugin, authContext)
 }
 
+// loginPAM logs in using PAM authentication
+// if a cached PAM token is rejected (e.g., expired), it obtains a new token using the password
+func (conn *IRODSConnection) loginPAM() error {
+	logger := log.WithFields(log.Fields{})
+
+	if len(conn.account.PAMToken) > 0 {
+		err := conn.loginPAMWithToken()
+		if err == nil {
+			return nil
+		}
+
+		if !types.IsAuthError(err) {
+			return err
+		}
+
+		if conn.account.AuthenticationScheme != types.AuthSchemePAMInteractive && len(conn.account.Password) == 0 {
+			return err
+		}
+
+		// the token may have been expired, renew it
+		logger.Debug("PAM token is rejected, renewing the token")
+
+		conn.account.PAMToken = ""
+
+		err = conn.restartup()
+		if err != nil {
+			return err
+		}
+	}
+
+	if conn.account.AuthenticationScheme == types.AuthSchemePAMInteractive {
+		if !conn.requireNewAuthFramework() {
+			newErr := types.NewConnectionConfigError(conn.account)
+			return errors.Wrapf(newErr, "PAM interactive authentication requires iRODS 4.3 or higher")
+		}
+
+		return conn.loginPAMInteractivePlugin()
+	}
+
+	if conn.requireNewAuthFramework() {
+		return conn.loginPAMWithPasswordPlugin()
+	}
+
+	err := conn.loginPAMWithPasswordLegacy()
+	if err != nil {
+		return errors.Wrapf(err, "failed to login to irods using PAM authentication")
+	}
+
+	// reconnect when success
+	err = conn.restartup()
+	if err != nil {
+		return err
+	}
+
+	return conn.loginPAMWithTokenLegacy()
+}
+
+// loginPAMWithToken logs in using PAM token obtained previously
+func (conn *IRODSConnection) loginPAMWithToken() error {
+	if conn.requireNewAuthFramework() {
+		return conn.loginPAMWithTokenPlugin()
+	}
+
+	return conn.loginPAMWithTokenLegacy()
+}
+
+// restartup closes the current socket and starts up a new iRODS connection without login
+func (conn *IRODSConnection) restartup() error {
+	_ = conn.logout()
+	_ = conn.disconnectNow()
+
+	conn.isSSLSocket = false
+	conn.sslSharedSecret = nil
+
+	// connect TCP
+	err := conn.connectTCP()
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.startup()
+	if err != nil {
+		connErr := errors.Wrapf(err, "failed to startup an iRODS connection to server %q", conn.serverAddress)
+		_ = conn.logout()
+		_ = conn.disconnectNow()
+		if conn.config.Metrics != nil {
+			conn.config.Metrics.IncreaseCounterForConnectionFailures(1)
+		}
+		return connErr
+	}
+
+	return nil
+}
+
 func (conn *IRODSConnection) loginPAMWithPasswordLegacy() error {
 	logger := log.WithFields(log.Fields{})
 	logger.Debug("Logging in using legacy pam authentication method")
@@ -604,10 +779,15 @@ func (conn *IRODSConnection) loginPAMWithPasswordPlugin() error {
 
 	logger.Debug("Logging in using pam authentication method with plugin")
 
-	plugin := NewPAMPasswordAuthPlugin(conn.isSSLSocket)
-	authContext := NewIRODSAuthContext()
+	return conn.loginPlugin()
+}
 
-	return AuthenticateClient(conn, plugin, authContext)
+func (conn *IRODSConnection) loginPAMInteractivePlugin() error {
+	logger := log.WithFields(log.Fields{})
+
+	logger.Debug("Logging in using pam interactive authentication method with plugin")
+
+	return conn.loginPlugin()
 }
 
 func (conn *IRODSConnection) loginPAMWithTokenLegacy() error {
@@ -710,6 +890,7 @@ func (conn *IRODSConnection) Reconnect() error {
 	conn.socket = nil
 
 	conn.serverVersion = nil
+	conn.serverCapabilities = nil
 	conn.sslSharedSecret = nil
 
 	conn.creationTime = time.Now()
@@ -959,6 +1140,10 @@ func (conn *IRODSConnection) SendMessageWithTrackerCallBack(msg *message.IRODSMe
 		return errors.Wrapf(err, "failed to set write timeout")
 	}
 
+	if conn.config.WireDebugWriter != nil {
+		dumpWireDebugMessage(conn.config.WireDebugWriter, conn.config.WireDebugBinaryLimit, wireDebugSend, conn.serverAddress, headerBytes, msg.Body)
+	}
+
 	bytes := messageBuffer.Bytes()
 	err = conn.Send(bytes, len(bytes), nil)
 	if err != nil {
@@ -1087,12 +1272,85 @@ func (conn *IRODSConnection) ReadMessageWithTrackerCallBack(bsBuffer []byte, tim
 	body.Type = header.Type
 	body.IntInfo = header.IntInfo
 
+	if conn.config.WireDebugWriter != nil {
+		headerBytes, _ := header.GetBytes()
+		dumpWireDebugMessage(conn.config.WireDebugWriter, conn.config.WireDebugBinaryLimit, wireDebugRecv, conn.serverAddress, headerBytes, &body)
+	}
+
 	return &message.IRODSMessage{
 		Header: header,
 		Body:   &body,
 	}, nil
 }
 
+// Ping checks if the connection is alive by requesting misc server info.
+// Ping is not counted as an activity, so it does not extend idle timeout of the connection.
+func (conn *IRODSConnection) Ping() error {
+	if !conn.locked {
+		return errors.Errorf("connection must be locked before use")
+	}
+
+	lastSuccessfulAccess := conn.lastSuccessfulAccess
+
+	request := message.NewIRODSMessageGetMiscServerInfoRequest()
+	response := message.IRODSMessageGetMiscServerInfoResponse{}
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
+	if err != nil {
+		return errors.Wrapf(err, "failed to ping")
+	}
+
+	conn.lastSuccessfulAccess = lastSuccessfulAccess
+	return nil
+}
+
+// GetServerInfo returns information of the server, e.g., server type, release version, boot time and zone
+func (conn *IRODSConnection) GetServerInfo() (*types.IRODSServerInfo, error) {
+	if !conn.locked {
+		return nil, errors.Errorf("connection must be locked before use")
+	}
+
+	request := message.NewIRODSMessageGetMiscServerInfoRequest()
+	response := message.IRODSMessageGetMiscServerInfoResponse{}
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get server info")
+	}
+
+	bootTime := time.Time{}
+	if response.ServerBootTime > 0 {
+		bootTime = time.Unix(int64(response.ServerBootTime), 0)
+	}
+
+	return &types.IRODSServerInfo{
+		Type:           types.IRODSServerType(response.ServerType),
+		ReleaseVersion: response.ReleaseVersion,
+		APIVersion:     response.APIVersion,
+		BootTime:       bootTime,
+		Zone:           response.RodsZone,
+	}, nil
+}
+
+// GetGridConfigurationValue returns the value of the option in the namespace of the grid configuration, e.g., "delay_server" and "leader"
+// the server must be 4.3.1 or higher and the user must be a rodsadmin
+func (conn *IRODSConnection) GetGridConfigurationValue(namespace string, optionName string) (string, error) {
+	if !conn.locked {
+		return "", errors.Errorf("connection must be locked before use")
+	}
+
+	if !conn.GetServerCapabilities().GridConfiguration {
+		return "", types.NewAPINotSupportedError(common.GET_GRID_CONFIGURATION_VALUE_APN)
+	}
+
+	request := message.NewIRODSMessageGetGridConfigurationValueRequest(namespace, optionName)
+	response := message.IRODSMessageGetGridConfigurationValueResponse{}
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get grid configuration value %q in namespace %q", optionName, namespace)
+	}
+
+	return response.OptionValue, nil
+}
+
 // Commit a transaction. This is useful in combination with the NO_COMMIT_FLAG.
 // Usage is limited to privileged accounts.
 func (conn *IRODSConnection) Commit() error {
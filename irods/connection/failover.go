@@ -0,0 +1,182 @@
+package connection
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cyverse/go-irodsclient/irods/types"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// HostFailureCooldown is a duration that a failed host is tried after healthy hosts
+	HostFailureCooldown time.Duration = 30 * time.Second
+
+	// SRVService and SRVProto are the service and protocol of DNS SRV records of catalog providers, _irods._tcp.<domain>
+	SRVService string = "irods"
+	SRVProto   string = "tcp"
+)
+
+// hostEndpoint is a catalog provider endpoint to connect to
+type hostEndpoint struct {
+	Host    string // host name, used for TLS server name verification
+	Address string // host:port or ip:port to dial
+}
+
+// hostHealthTracker tracks connection failures of catalog provider endpoints
+type hostHealthTracker struct {
+	failures map[string]time.Time
+	mutex    sync.Mutex
+}
+
+var hostHealth = &hostHealthTracker{
+	failures: map[string]time.Time{},
+}
+
+// MarkFailure marks the address failed
+func (tracker *hostHealthTracker) MarkFailure(address string) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	tracker.failures[address] = time.Now()
+}
+
+// MarkSuccess marks the address healthy
+func (tracker *hostHealthTracker) MarkSuccess(address string) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	delete(tracker.failures, address)
+}
+
+// IsHealthy returns true if the address has not failed recently
+func (tracker *hostHealthTracker) IsHealthy(address string) bool {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	return tracker.isHealthyNoLock(address, time.Now())
+}
+
+func (tracker *hostHealthTracker) isHealthyNoLock(address string, now time.Time) bool {
+	failedTime, ok := tracker.failures[address]
+	if !ok {
+		return true
+	}
+
+	return now.Sub(failedTime) > HostFailureCooldown
+}
+
+// Order returns endpoints ordered for connection, healthy endpoints keep their order and come first,
+// recently failed endpoints follow, the least recently failed first
+func (tracker *hostHealthTracker) Order(endpoints []hostEndpoint) []hostEndpoint {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	now := time.Now()
+
+	healthy := []hostEndpoint{}
+	unhealthy := []hostEndpoint{}
+	for _, endpoint := range endpoints {
+		if tracker.isHealthyNoLock(endpoint.Address, now) {
+			healthy = append(healthy, endpoint)
+		} else {
+			unhealthy = append(unhealthy, endpoint)
+		}
+	}
+
+	sort.SliceStable(unhealthy, func(i int, j int) bool {
+		return tracker.failures[unhealthy[i].Address].Before(tracker.failures[unhealthy[j].Address])
+	})
+
+	return append(healthy, unhealthy...)
+}
+
+// lookupSRVHosts returns catalog provider hosts ("host:port") discovered from DNS SRV records of the domain,
+// ordered by priority and randomized by weight
+func lookupSRVHosts(ctx context.Context, resolver *net.Resolver, domain string) []string {
+	logger := log.WithFields(log.Fields{
+		"domain": domain,
+	})
+
+	_, records, err := resolver.LookupSRV(ctx, SRVService, SRVProto, domain)
+	if err != nil {
+		// fall back to hosts of the account
+		logger.Debugf("failed to look up SRV records: %v", err)
+		return nil
+	}
+
+	hosts := []string{}
+	for _, record := range records {
+		// "." means the service is not available in the domain
+		target := strings.TrimSuffix(record.Target, ".")
+		if len(target) == 0 {
+			continue
+		}
+
+		hosts = append(hosts, net.JoinHostPort(target, strconv.Itoa(int(record.Port))))
+	}
+
+	logger.Debugf("discovered hosts %v from SRV records", hosts)
+	return hosts
+}
+
+// getHostEndpoints returns catalog provider endpoints of the account in order of preference.
+// Hosts discovered from SRV records come first, followed by the host and failover hosts of the account.
+// Host names are expanded to all of their addresses of the address family unless a proxy is used, as the proxy resolves names.
+func getHostEndpoints(ctx context.Context, account *types.IRODSAccount, resolver *net.Resolver, family AddressFamily) []hostEndpoint {
+	endpoints := []hostEndpoint{}
+	seen := map[string]bool{}
+
+	addEndpoint := func(host string, address string) {
+		if seen[address] {
+			return
+		}
+
+		seen[address] = true
+		endpoints = append(endpoints, hostEndpoint{
+			Host:    host,
+			Address: address,
+		})
+	}
+
+	hostPorts := account.GetHosts()
+	if len(account.SRVDomain) > 0 {
+		hostPorts = append(lookupSRVHosts(ctx, resolver, account.SRVDomain), hostPorts...)
+	}
+
+	for _, hostPort := range hostPorts {
+		host, port := account.SplitHostPort(hostPort)
+		portString := strconv.Itoa(port)
+
+		if len(account.ProxyURL) > 0 {
+			addEndpoint(host, net.JoinHostPort(host, portString))
+			continue
+		}
+
+		for _, addr := range resolveHostAddresses(ctx, resolver, host, family) {
+			addEndpoint(host, net.JoinHostPort(addr, portString))
+		}
+	}
+
+	return hostHealth.Order(endpoints)
+}
+
+// groupEndpointsByHost groups consecutive endpoints of the same host, addresses of a host can be tried in parallel
+func groupEndpointsByHost(endpoints []hostEndpoint) [][]hostEndpoint {
+	groups := [][]hostEndpoint{}
+	for _, endpoint := range endpoints {
+		last := len(groups) - 1
+		if last >= 0 && groups[last][0].Host == endpoint.Host {
+			groups[last] = append(groups[last], endpoint)
+			continue
+		}
+
+		groups = append(groups, []hostEndpoint{endpoint})
+	}
+	return groups
+}
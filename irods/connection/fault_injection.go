@@ -0,0 +1,202 @@
+package connection
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// FaultInjectionFilter returns true if faults should be injected to the connection to the address
+type FaultInjectionFilter func(address string) bool
+
+// FaultInjectionPolicy is a policy for injecting failures to connection sockets.
+// This is for testing retry and resume logic without real network failures, do not use in production.
+type FaultInjectionPolicy struct {
+	DropAfterSentBytes     int64                // closes the socket once this many bytes are sent, 0 disables
+	DropAfterReceivedBytes int64                // closes the socket once this many bytes are received, 0 disables
+	ResponseDelay          time.Duration        // delays every read from the socket, 0 disables
+	CorruptOffset          int64                // offset of received bytes to corrupt
+	CorruptLength          int64                // number of received bytes to corrupt from CorruptOffset, 0 disables
+	MaxFaultyConnections   int                  // number of connections faults are injected to, 0 injects to all connections
+	Filter                 FaultInjectionFilter // can be nil, injects to all connections if not set
+
+	faultyConnections int64
+}
+
+// Validate validates the fault injection policy
+func (policy *FaultInjectionPolicy) Validate() error {
+	if policy.DropAfterSentBytes < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "drop after sent bytes %d is invalid", policy.DropAfterSentBytes)
+	}
+
+	if policy.DropAfterReceivedBytes < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "drop after received bytes %d is invalid", policy.DropAfterReceivedBytes)
+	}
+
+	if policy.ResponseDelay < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "response delay is invalid")
+	}
+
+	if policy.CorruptOffset < 0 || policy.CorruptLength < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "corrupt range (offset %d, length %d) is invalid", policy.CorruptOffset, policy.CorruptLength)
+	}
+
+	if policy.MaxFaultyConnections < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "max faulty connections %d is invalid", policy.MaxFaultyConnections)
+	}
+
+	return nil
+}
+
+// GetFaultyConnections returns the number of connections faults have been injected to
+func (policy *FaultInjectionPolicy) GetFaultyConnections() int {
+	return int(atomic.LoadInt64(&policy.faultyConnections))
+}
+
+// Reset resets the number of faulty connections, so MaxFaultyConnections applies again
+func (policy *FaultInjectionPolicy) Reset() {
+	atomic.StoreInt64(&policy.faultyConnections, 0)
+}
+
+// wrap returns a socket that injects faults, or the socket as is if the policy does not apply to it
+func (policy *FaultInjectionPolicy) wrap(socket net.Conn, address string) net.Conn {
+	if policy == nil {
+		return socket
+	}
+
+	if policy.Filter != nil && !policy.Filter(address) {
+		return socket
+	}
+
+	count := atomic.AddInt64(&policy.faultyConnections, 1)
+	if policy.MaxFaultyConnections > 0 && count > int64(policy.MaxFaultyConnections) {
+		atomic.AddInt64(&policy.faultyConnections, -1)
+		return socket
+	}
+
+	log.Debugf("injecting faults to connection to %s", address)
+
+	return &faultInjectingConn{
+		Conn:    socket,
+		policy:  policy,
+		address: address,
+	}
+}
+
+// faultInjectingConn is a socket that injects faults following the policy
+type faultInjectingConn struct {
+	net.Conn
+
+	policy        *FaultInjectionPolicy
+	address       string
+	sentBytes     int64 // guarded by writeMutex
+	receivedBytes int64 // guarded by readMutex
+	dropped       int32
+	readMutex     sync.Mutex
+	writeMutex    sync.Mutex
+}
+
+func (conn *faultInjectingConn) isDropped() bool {
+	return atomic.LoadInt32(&conn.dropped) != 0
+}
+
+func (conn *faultInjectingConn) drop(reason string) error {
+	if atomic.CompareAndSwapInt32(&conn.dropped, 0, 1) {
+		log.Debugf("dropping connection to %s, %s", conn.address, reason)
+		_ = conn.Conn.Close()
+	}
+
+	return errors.Errorf("connection to %s dropped by fault injection, %s", conn.address, reason)
+}
+
+// Read reads data from the socket, delays, truncates and corrupts data following the policy
+func (conn *faultInjectingConn) Read(buffer []byte) (int, error) {
+	if conn.isDropped() {
+		return 0, errors.Errorf("connection to %s dropped by fault injection", conn.address)
+	}
+
+	if conn.policy.ResponseDelay > 0 {
+		time.Sleep(conn.policy.ResponseDelay)
+	}
+
+	conn.readMutex.Lock()
+	defer conn.readMutex.Unlock()
+
+	limit := conn.policy.DropAfterReceivedBytes
+	if limit > 0 {
+		remaining := limit - conn.receivedBytes
+		if remaining <= 0 {
+			return 0, conn.drop("received bytes limit reached")
+		}
+
+		if int64(len(buffer)) > remaining {
+			buffer = buffer[:remaining]
+		}
+	}
+
+	readLen, err := conn.Conn.Read(buffer)
+	if readLen > 0 {
+		conn.corrupt(buffer[:readLen], conn.receivedBytes)
+		conn.receivedBytes += int64(readLen)
+	}
+
+	return readLen, err
+}
+
+// corrupt flips bits of data in the corrupt range, offset is the stream offset of data
+func (conn *faultInjectingConn) corrupt(data []byte, offset int64) {
+	if conn.policy.CorruptLength <= 0 {
+		return
+	}
+
+	start := conn.policy.CorruptOffset
+	end := start + conn.policy.CorruptLength
+	for idx := range data {
+		streamOffset := offset + int64(idx)
+		if streamOffset >= start && streamOffset < end {
+			data[idx] = ^data[idx]
+		}
+	}
+}
+
+// Write writes data to the socket, drops the socket following the policy
+func (conn *faultInjectingConn) Write(buffer []byte) (int, error) {
+	conn.writeMutex.Lock()
+	defer conn.writeMutex.Unlock()
+
+	if conn.isDropped() {
+		return 0, errors.Errorf("connection to %s dropped by fault injection", conn.address)
+	}
+
+	limit := conn.policy.DropAfterSentBytes
+	if limit > 0 {
+		remaining := limit - conn.sentBytes
+		if int64(len(buffer)) > remaining {
+			writeLen := 0
+			if remaining > 0 {
+				var err error
+				writeLen, err = conn.Conn.Write(buffer[:remaining])
+				conn.sentBytes += int64(writeLen)
+				if err != nil {
+					return writeLen, err
+				}
+			}
+
+			return writeLen, conn.drop("sent bytes limit reached")
+		}
+	}
+
+	writeLen, err := conn.Conn.Write(buffer)
+	conn.sentBytes += int64(writeLen)
+	return writeLen, err
+}
@@ -10,7 +10,7 @@ import (
 )
 
 func AuthenticateNative(conn *IRODSConnection, password string) error {
-	timeout := conn.GetOperationTimeout()
+	timeout := conn.GetAuthTimeout()
 
 	authRequest := message.NewIRODSMessageAuthRequest()
 	authChallenge := message.IRODSMessageAuthChallengeResponse{}
@@ -0,0 +1,111 @@
+package connection
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+const (
+	OPENID_AUTH_ACCESS_TOKEN_KEY string = "access_token"
+)
+
+// OpenIDTokenSource supplies OIDC access tokens for openid authentication
+// implementations should return a refreshed token when the previous one is expired
+type OpenIDTokenSource interface {
+	Token() (string, error)
+}
+
+// StaticOpenIDTokenSource is an OpenIDTokenSource that always returns the same access token
+type StaticOpenIDTokenSource string
+
+// Token returns the access token
+func (source StaticOpenIDTokenSource) Token() (string, error) {
+	return string(source), nil
+}
+
+type OpenIDAuthPlugin struct {
+	BaseIRODSAuthPlugin
+	tokenSource OpenIDTokenSource
+}
+
+// NewOpenIDAuthPlugin creates an OpenIDAuthPlugin that presents access tokens given by the token source
+func NewOpenIDAuthPlugin(tokenSource OpenIDTokenSource) *OpenIDAuthPlugin {
+	plugin := &OpenIDAuthPlugin{
+		tokenSource: tokenSource,
+	}
+
+	plugin.initialize()
+	return plugin
+}
+
+func (plugin *OpenIDAuthPlugin) initialize() {
+	plugin.AddOperation(AUTH_CLIENT_START, plugin.AuthClientStart)
+	plugin.AddOperation(AUTH_CLIENT_AUTH_REQUEST, plugin.clientRequest)
+	plugin.AddOperation(AUTH_CLIENT_AUTH_RESPONSE, plugin.clientResponse)
+}
+
+func (plugin *OpenIDAuthPlugin) GetName() string {
+	return "openid"
+}
+
+func (plugin *OpenIDAuthPlugin) AuthClientStart(conn *IRODSConnection, requestContext *IRODSAuthContext) (*IRODSAuthContext, error) {
+	responseContext := requestContext.GetCopy()
+
+	responseContext.Set(AUTH_NEXT_OPERATION, AUTH_CLIENT_AUTH_REQUEST)
+
+	responseContext.Set("user_name", conn.account.ProxyUser)
+	responseContext.Set("zone_name", conn.account.ProxyZone)
+
+	return responseContext, nil
+}
+
+func (plugin *OpenIDAuthPlugin) clientRequest(conn *IRODSConnection, requestContext *IRODSAuthContext) (*IRODSAuthContext, error) {
+	if !conn.isSSLSocket {
+		return nil, errors.Wrapf(types.NewAuthError(conn.account), "OpenID authentication requires secure connection")
+	}
+
+	if plugin.tokenSource == nil {
+		return nil, errors.Wrapf(types.NewAuthError(conn.account), "OpenID token source is not given")
+	}
+
+	// get a fresh token for every login, the token source refreshes expired tokens
+	accessToken, err := plugin.tokenSource.Token()
+	if err != nil {
+		newErr := errors.Join(err, types.NewAuthError(conn.account))
+		return nil, errors.Wrapf(newErr, "failed to get OpenID access token")
+	}
+
+	if len(accessToken) == 0 {
+		return nil, errors.Wrapf(types.NewAuthError(conn.account), "empty OpenID access token")
+	}
+
+	reqContext := requestContext.GetCopy()
+	reqContext.Set(OPENID_AUTH_ACCESS_TOKEN_KEY, accessToken)
+	reqContext.Set(AUTH_NEXT_OPERATION, AUTH_AGENT_AUTH_REQUEST)
+
+	responseContext, err := plugin.Request(conn, reqContext)
+	if err != nil {
+		return nil, err
+	}
+
+	// don't keep the access token in the context
+	responseContext.Remove(OPENID_AUTH_ACCESS_TOKEN_KEY)
+	responseContext.Set(AUTH_NEXT_OPERATION, AUTH_CLIENT_AUTH_RESPONSE)
+
+	return responseContext, nil
+}
+
+func (plugin *OpenIDAuthPlugin) clientResponse(conn *IRODSConnection, requestContext *IRODSAuthContext) (*IRODSAuthContext, error) {
+	reqContext := requestContext.GetCopy()
+	reqContext.Set(AUTH_NEXT_OPERATION, AUTH_AGENT_AUTH_RESPONSE)
+
+	responseContext, err := plugin.Request(conn, reqContext)
+	if err != nil {
+		return nil, err
+	}
+
+	responseContext.Set(AUTH_NEXT_OPERATION, AUTH_FLOW_COMPLETE)
+	conn.loggedIn = true
+
+	return responseContext, nil
+}
@@ -0,0 +1,72 @@
+package connection
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// operationTarget is used to extract a path and a resource from request message body
+type operationTarget struct {
+	ObjectPath     string                       `xml:"objPath"`
+	CollectionName string                       `xml:"collName"`
+	KeyVals        message.IRODSMessageSSKeyVal `xml:"KeyValPair_PI"`
+}
+
+// getOperationName returns an operation name from the request type, e.g., OpenDataObject
+func getOperationName(request Request) string {
+	name := fmt.Sprintf("%T", request)
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		name = name[idx+1:]
+	}
+
+	name = strings.TrimPrefix(name, "IRODSMessage")
+	return strings.TrimSuffix(name, "Request")
+}
+
+// newOperationError wraps the error with the context of the request
+// this is called only on failures, so re-building the request message is acceptable
+func (conn *IRODSConnection) newOperationError(request Request, err error) error {
+	if types.IsOperationError(err) {
+		// already has a context
+		return err
+	}
+
+	operation := getOperationName(request)
+	apiNumber := common.APINumber(0)
+	path := ""
+	resource := ""
+
+	requestMessage, msgErr := conn.getRequestMessage(request)
+	if msgErr == nil && requestMessage.Body != nil {
+		if requestMessage.Body.Type == message.RODS_MESSAGE_API_REQ_TYPE {
+			apiNumber = common.APINumber(requestMessage.Body.IntInfo)
+		}
+
+		target := operationTarget{}
+		if xml.Unmarshal(requestMessage.Body.Message, &target) == nil {
+			path = target.ObjectPath
+			if len(path) == 0 {
+				path = target.CollectionName
+			}
+
+			for idx, key := range target.KeyVals.Keys {
+				if idx >= len(target.KeyVals.Values) {
+					break
+				}
+
+				if key == string(common.DEST_RESC_NAME_KW) || key == string(common.RESC_NAME_KW) {
+					resource = target.KeyVals.Values[idx].Value
+					break
+				}
+			}
+		}
+	}
+
+	return errors.WithStack(types.NewOperationError(operation, path, resource, apiNumber, err))
+}
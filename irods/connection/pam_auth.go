@@ -15,7 +15,7 @@ import (
 func AuthenticatePAMWithPassword(conn *IRODSConnection, password string) error {
 	logger := log.WithFields(log.Fields{})
 
-	timeout := conn.GetOperationTimeout()
+	timeout := conn.GetAuthTimeout()
 
 	// Check whether ssl has already started
 	if _, ok := conn.socket.(*tls.Conn); !ok {
@@ -29,11 +29,28 @@ const (
 
 type PAMInteractiveInputHandler func() (string, error)
 
+// PAMInteractivePrompt is a prompt sent by the server during pam_interactive authentication
+type PAMInteractivePrompt struct {
+	// Message is the prompt message given by the server, can be empty
+	Message string
+	// Sensitive is true if the response must not be echoed, e.g., password or OTP code
+	Sensitive bool
+	// Informational is true if the prompt is only for display, the response is ignored
+	Informational bool
+	// DefaultValue is used if the response is empty
+	DefaultValue string
+}
+
+// PAMInteractivePromptHandler answers a prompt sent by the server during pam_interactive authentication
+// applications can implement this to supply OTP codes or answer arbitrary prompts
+type PAMInteractivePromptHandler func(prompt *PAMInteractivePrompt) (string, error)
+
 type PAMInteractiveAuthPlugin struct {
 	BaseIRODSAuthPlugin
 	requireSecureConnection  bool
 	getInputHandler          PAMInteractiveInputHandler
 	getSensitiveInputHandler PAMInteractiveInputHandler
+	promptHandler            PAMInteractivePromptHandler
 }
 
 func NewPAMInteractiveAuthPlugin(requireSecureConnection bool) *PAMInteractiveAuthPlugin {
@@ -59,6 +76,20 @@ func NewPAMInteractiveAuthPluginWithHandlers(requireSecureConnection bool, getIn
 	return plugin
 }
 
+// NewPAMInteractiveAuthPluginWithPromptHandler creates a PAMInteractiveAuthPlugin that answers server prompts using the given handler
+func NewPAMInteractiveAuthPluginWithPromptHandler(requireSecureConnection bool, promptHandler PAMInteractivePromptHandler) *PAMInteractiveAuthPlugin {
+	plugin := &PAMInteractiveAuthPlugin{
+		requireSecureConnection: requireSecureConnection,
+		promptHandler:           promptHandler,
+	}
+
+	plugin.getInputHandler = plugin.getInputFromClientStdin
+	plugin.getSensitiveInputHandler = plugin.getPasswordFromClientStdin
+
+	plugin.initialize()
+	return plugin
+}
+
 func (plugin *PAMInteractiveAuthPlugin) initialize() {
 	plugin.AddOperation(AUTH_CLIENT_START, plugin.AuthClientStart)
 	plugin.AddOperation(AUTH_CLIENT_AUTH_REQUEST, plugin.clientRequest)
@@ -140,12 +171,17 @@ func (plugin *PAMInteractiveAuthPlugin) clientResponse(conn *IRODSConnection, re
 
 func (plugin *PAMInteractiveAuthPlugin) stepClientNext(conn *IRODSConnection, requestContext *IRODSAuthContext) (*IRODSAuthContext, error) {
 	reqContext := requestContext.GetCopy()
-	if msgMap, ok := reqContext.GetMap("msg"); ok && msgMap != nil {
-		if promptVal, ok2 := msgMap["prompt"]; ok2 {
-			if prompt, ok3 := promptVal.(string); ok3 {
-				fmt.Printf("%s", prompt)
-			}
+	prompt := plugin.getPromptMessage(reqContext)
+	if plugin.promptHandler != nil {
+		_, err := plugin.promptHandler(&PAMInteractivePrompt{
+			Message:       prompt,
+			Informational: true,
+		})
+		if err != nil {
+			return nil, err
 		}
+	} else {
+		fmt.Printf("%s", prompt)
 	}
 
 	err := plugin.patchState(reqContext)
@@ -181,7 +217,7 @@ func (plugin *PAMInteractiveAuthPlugin) stepWaiting(conn *IRODSConnection, reque
 		return nil, err
 	}
 
-	input, err := plugin.getInputHandler()
+	input, err := plugin.getInput(reqContext, defaultValue, false)
 	if err != nil {
 		return nil, err
 	}
@@ -224,7 +260,7 @@ func (plugin *PAMInteractiveAuthPlugin) stepWaitingPw(conn *IRODSConnection, req
 		return nil, err
 	}
 
-	input, err := plugin.getSensitiveInputHandler()
+	input, err := plugin.getInput(reqContext, defaultValue, true)
 	if err != nil {
 		return nil, err
 	}
@@ -296,6 +332,9 @@ func (plugin *PAMInteractiveAuthPlugin) performNativeAuth(conn *IRODSConnection,
 	requestResult, _ := responseContext.GetString("request_result")
 	input.Set("password", requestResult)
 
+	// store PAM token in the account for future use
+	conn.account.PAMToken = requestResult
+
 	nativeAuthPlugin := NewNativeAuthPlugin()
 	err := AuthenticateClient(conn, nativeAuthPlugin, input)
 	if err != nil {
@@ -312,6 +351,33 @@ func (plugin *PAMInteractiveAuthPlugin) performNativeAuth(conn *IRODSConnection,
 	return responseContext, nil
 }
 
+func (plugin *PAMInteractiveAuthPlugin) getPromptMessage(requestContext *IRODSAuthContext) string {
+	if msgMap, ok := requestContext.GetMap("msg"); ok && msgMap != nil {
+		if promptVal, ok2 := msgMap["prompt"]; ok2 {
+			if prompt, ok3 := promptVal.(string); ok3 {
+				return prompt
+			}
+		}
+	}
+
+	return ""
+}
+
+func (plugin *PAMInteractiveAuthPlugin) getInput(requestContext *IRODSAuthContext, defaultValue string, sensitive bool) (string, error) {
+	if plugin.promptHandler != nil {
+		return plugin.promptHandler(&PAMInteractivePrompt{
+			Message:      plugin.getPromptMessage(requestContext),
+			Sensitive:    sensitive,
+			DefaultValue: defaultValue,
+		})
+	}
+
+	if sensitive {
+		return plugin.getSensitiveInputHandler()
+	}
+	return plugin.getInputHandler()
+}
+
 func (plugin *PAMInteractiveAuthPlugin) getInputFromClientStdin() (string, error) {
 	userInput := ""
 	_, err := fmt.Scanln(&userInput)
@@ -0,0 +1,141 @@
+package connection
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/net/proxy"
+)
+
+// socketOptionDialer is a dialer that sets TCP socket options on new sockets
+type socketOptionDialer struct {
+	dialer  net.Dialer
+	options tcpSocketOptions
+}
+
+// Dial dials the address
+func (dialer *socketOptionDialer) Dial(network string, address string) (net.Conn, error) {
+	return dialer.DialContext(context.Background(), network, address)
+}
+
+// DialContext dials the address with the given context
+func (dialer *socketOptionDialer) DialContext(ctx context.Context, network string, address string) (net.Conn, error) {
+	socket, err := dialer.dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	setTCPSocketOptions(socket, dialer.options)
+	return socket, nil
+}
+
+// dialTCP connects to the address directly or via a SOCKS5 or HTTP CONNECT proxy if proxyURL is given
+func dialTCP(ctx context.Context, address string, proxyURL string, options tcpSocketOptions) (net.Conn, error) {
+	forward := &socketOptionDialer{
+		options: options,
+	}
+
+	if len(proxyURL) == 0 {
+		return forward.DialContext(ctx, "tcp", address)
+	}
+
+	pURL, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse proxy url")
+	}
+
+	switch strings.ToLower(pURL.Scheme) {
+	case "socks5", "socks5h":
+		return dialSOCKS5Proxy(ctx, pURL, address, forward)
+	case "http":
+		return dialHTTPProxy(ctx, pURL, address, forward)
+	default:
+		return nil, errors.Errorf("unsupported proxy scheme %q", pURL.Scheme)
+	}
+}
+
+func dialSOCKS5Proxy(ctx context.Context, proxyURL *url.URL, address string, forward *socketOptionDialer) (net.Conn, error) {
+	var auth *proxy.Auth
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		auth = &proxy.Auth{
+			User:     proxyURL.User.Username(),
+			Password: password,
+		}
+	}
+
+	socksDialer, err := proxy.SOCKS5("tcp", proxyURL.Host, auth, forward)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create SOCKS5 dialer for proxy %q", proxyURL.Host)
+	}
+
+	contextDialer, ok := socksDialer.(proxy.ContextDialer)
+	if !ok {
+		return nil, errors.Errorf("SOCKS5 dialer does not support context")
+	}
+
+	socket, err := contextDialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to %q via SOCKS5 proxy %q", address, proxyURL.Host)
+	}
+
+	return socket, nil
+}
+
+func dialHTTPProxy(ctx context.Context, proxyURL *url.URL, address string, forward *socketOptionDialer) (net.Conn, error) {
+	socket, err := forward.DialContext(ctx, "tcp", proxyURL.Host)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to HTTP proxy %q", proxyURL.Host)
+	}
+
+	// the proxy handshake must finish before the context expires
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = socket.SetDeadline(deadline)
+	}
+
+	request := fmt.Sprintf("CONNECT %s HTTP/1.1\r\nHost: %s\r\n", address, address)
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		credential := base64.StdEncoding.EncodeToString([]byte(proxyURL.User.Username() + ":" + password))
+		request += fmt.Sprintf("Proxy-Authorization: Basic %s\r\n", credential)
+	}
+	request += "\r\n"
+
+	_, err = socket.Write([]byte(request))
+	if err != nil {
+		_ = socket.Close()
+		return nil, errors.Wrapf(err, "failed to send CONNECT request to HTTP proxy %q", proxyURL.Host)
+	}
+
+	reader := bufio.NewReader(socket)
+	response, err := http.ReadResponse(reader, &http.Request{Method: http.MethodConnect})
+	if err != nil {
+		_ = socket.Close()
+		return nil, errors.Wrapf(err, "failed to read CONNECT response from HTTP proxy %q", proxyURL.Host)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		_ = socket.Close()
+		return nil, errors.Errorf("HTTP proxy %q refused to connect to %q: %s", proxyURL.Host, address, response.Status)
+	}
+
+	if reader.Buffered() > 0 {
+		// iRODS server never talks first, so this should not happen
+		_ = socket.Close()
+		return nil, errors.Errorf("unexpected data received from HTTP proxy %q", proxyURL.Host)
+	}
+
+	// clear handshake deadline
+	_ = socket.SetDeadline(time.Time{})
+
+	return socket, nil
+}
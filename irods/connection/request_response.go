@@ -7,6 +7,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/cyverse/go-irodsclient/irods/common"
 	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/metrics"
 )
 
 // Request is an interface for calling iRODS RPC.
@@ -36,6 +37,9 @@ type RequestResponsePair struct {
 	RequestCallback  common.TransferTrackerCallback // can be null
 	ResponseCallback common.TransferTrackerCallback // can be null
 	Error            error
+
+	requestMessage *message.IRODSMessage
+	sentTime       time.Time
 }
 
 // RequestResponseTimeout is a structure that contains timeout values for iRODS RPC calls.
@@ -45,11 +49,7 @@ type RequestResponseTimeout struct {
 }
 
 func (conn *IRODSConnection) useNewXML() bool {
-	if conn.serverVersion == nil {
-		return true
-	}
-
-	return conn.serverVersion.HasHigherVersionThan(4, 2, 9) // new xml is used in 4.2.9
+	return conn.GetServerCapabilities().NewXML
 }
 
 // Request sends a request and expects a response.
@@ -59,8 +59,16 @@ func (conn *IRODSConnection) Request(request Request, response Response, bsBuffe
 }
 
 // RequestWithTrackerCallBack sends a request and expects a response.
-// bsBuffer is optional
+// bsBuffer is optional, errors other than io.EOF are wrapped in OperationError
 func (conn *IRODSConnection) RequestWithTrackerCallBack(request Request, response Response, bsBuffer []byte, timeout *RequestResponseTimeout, reqCallback common.TransferTrackerCallback, resCallback common.TransferTrackerCallback) error {
+	err := conn.requestWithTrackerCallBack(request, response, bsBuffer, timeout, reqCallback, resCallback)
+	if err != nil && err != io.EOF {
+		return conn.newOperationError(request, err)
+	}
+	return err
+}
+
+func (conn *IRODSConnection) requestWithTrackerCallBack(request Request, response Response, bsBuffer []byte, timeout *RequestResponseTimeout, reqCallback common.TransferTrackerCallback, resCallback common.TransferTrackerCallback) error {
 	// set transaction dirty
 	conn.SetTransactionDirty(true)
 
@@ -79,6 +87,12 @@ func (conn *IRODSConnection) RequestWithTrackerCallBack(request Request, respons
 		responseTimeout = timeout.ResponseTimeout
 	}
 
+	sentTime := time.Now()
+	var responseMessage *message.IRODSMessage
+	defer func() {
+		conn.observeOperation(requestMessage, responseMessage, sentTime, err != nil)
+	}()
+
 	err = conn.SendMessageWithTrackerCallBack(requestMessage, requestTimeout, reqCallback)
 	if err != nil {
 		if conn.config.Metrics != nil {
@@ -90,7 +104,7 @@ func (conn *IRODSConnection) RequestWithTrackerCallBack(request Request, respons
 
 	// Server responds with results
 	// external bs buffer
-	responseMessage, err := conn.ReadMessageWithTrackerCallBack(bsBuffer, responseTimeout, resCallback)
+	responseMessage, err = conn.ReadMessageWithTrackerCallBack(bsBuffer, responseTimeout, resCallback)
 	if err != nil {
 		if conn.config.Metrics != nil {
 			conn.config.Metrics.IncreaseCounterForRequestResponseFailures(1)
@@ -148,7 +162,7 @@ func (conn *IRODSConnection) RequestAsyncWithTrackerCallBack(rrChan chan Request
 					conn.config.Metrics.IncreaseCounterForRequestResponseFailures(1)
 				}
 
-				lastErr = err
+				lastErr = conn.newOperationError(pair.Request, err)
 				pair.Error = lastErr
 				waitResponseChan <- pair
 				continue
@@ -159,18 +173,23 @@ func (conn *IRODSConnection) RequestAsyncWithTrackerCallBack(rrChan chan Request
 				requestTimeout = pair.Timeout.RequestTimeout
 			}
 
+			pair.sentTime = time.Now()
 			err = conn.SendMessageWithTrackerCallBack(requestMessage, requestTimeout, pair.RequestCallback)
 			if err != nil {
 				if conn.config.Metrics != nil {
 					conn.config.Metrics.IncreaseCounterForRequestResponseFailures(1)
 				}
 
-				lastErr = errors.Wrapf(err, "failed to send a request message")
+				conn.observeOperation(requestMessage, nil, pair.sentTime, true)
+
+				lastErr = conn.newOperationError(pair.Request, errors.Wrapf(err, "failed to send a request message"))
 				pair.Error = lastErr
 				waitResponseChan <- pair
 				continue
 			}
 
+			pair.requestMessage = requestMessage
+
 			waitResponseChan <- pair
 		}
 	}()
@@ -202,6 +221,7 @@ func (conn *IRODSConnection) RequestAsyncWithTrackerCallBack(rrChan chan Request
 			}
 
 			responseMessage, err := conn.ReadMessageWithTrackerCallBack(pair.BsBuffer, responseTimeout, pair.ResponseCallback)
+			conn.observeOperation(pair.requestMessage, responseMessage, pair.sentTime, err != nil)
 			if err != nil {
 				if conn.config.Metrics != nil {
 					conn.config.Metrics.IncreaseCounterForRequestResponseFailures(1)
@@ -210,7 +230,7 @@ func (conn *IRODSConnection) RequestAsyncWithTrackerCallBack(rrChan chan Request
 				if err == io.EOF {
 					lastErr = err
 				} else {
-					lastErr = errors.Wrapf(err, "failed to receive a response message")
+					lastErr = conn.newOperationError(pair.Request, errors.Wrapf(err, "failed to receive a response message"))
 				}
 
 				pair.Error = lastErr
@@ -225,7 +245,7 @@ func (conn *IRODSConnection) RequestAsyncWithTrackerCallBack(rrChan chan Request
 					conn.config.Metrics.IncreaseCounterForRequestResponseFailures(1)
 				}
 
-				lastErr = errors.Wrapf(err, "failed to parse response message")
+				lastErr = conn.newOperationError(pair.Request, errors.Wrapf(err, "failed to parse response message"))
 				pair.Error = lastErr
 				outputPair <- pair
 				continue
@@ -275,7 +295,40 @@ func (conn *IRODSConnection) RequestAndCheckWithTrackerCallBack(request Request,
 		return err
 	}
 
-	return response.CheckError()
+	return conn.CheckResponseError(request, response)
+}
+
+// CheckResponseError checks the error in the response of the request, the error is wrapped in OperationError
+func (conn *IRODSConnection) CheckResponseError(request Request, response CheckErrorResponse) error {
+	if err := response.CheckError(); err != nil {
+		return conn.newOperationError(request, err)
+	}
+
+	return nil
+}
+
+// observeOperation records latency and bytes of the API operation to metrics
+// responses having negative intInfo are errors returned by the server
+func (conn *IRODSConnection) observeOperation(requestMessage *message.IRODSMessage, responseMessage *message.IRODSMessage, sentTime time.Time, failed bool) {
+	if conn.config.Metrics == nil || requestMessage == nil || requestMessage.Header == nil {
+		return
+	}
+
+	if requestMessage.Header.Type != message.RODS_MESSAGE_API_REQ_TYPE {
+		return
+	}
+
+	bytesSent := uint64(requestMessage.Header.MessageLen) + uint64(requestMessage.Header.ErrorLen) + uint64(requestMessage.Header.BsLen)
+	bytesReceived := uint64(0)
+	if responseMessage != nil && responseMessage.Header != nil {
+		bytesReceived = uint64(responseMessage.Header.MessageLen) + uint64(responseMessage.Header.ErrorLen) + uint64(responseMessage.Header.BsLen)
+		if responseMessage.Header.IntInfo < 0 {
+			failed = true
+		}
+	}
+
+	operation := metrics.GetOperationType(common.APINumber(requestMessage.Header.IntInfo))
+	conn.config.Metrics.ObserveOperation(operation, time.Since(sentTime), bytesSent, bytesReceived, failed)
 }
 
 func (conn *IRODSConnection) getRequestMessage(request Request) (*message.IRODSMessage, error) {
@@ -312,6 +365,7 @@ func (conn *IRODSConnection) getResponse(responseMessage *message.IRODSMessage,
 	return nil
 }
 
+// GetOperationTimeout returns timeout for metadata operations
 func (conn *IRODSConnection) GetOperationTimeout() *RequestResponseTimeout {
 	return &RequestResponseTimeout{
 		RequestTimeout:  conn.config.OperationTimeout,
@@ -319,9 +373,26 @@ func (conn *IRODSConnection) GetOperationTimeout() *RequestResponseTimeout {
 	}
 }
 
+// GetLongResponseOperationTimeout returns timeout for metadata operations that take a long time to respond
 func (conn *IRODSConnection) GetLongResponseOperationTimeout() *RequestResponseTimeout {
 	return &RequestResponseTimeout{
 		RequestTimeout:  conn.config.OperationTimeout,
 		ResponseTimeout: conn.config.LongOperationTimeout,
 	}
 }
+
+// GetAuthTimeout returns timeout for authentication
+func (conn *IRODSConnection) GetAuthTimeout() *RequestResponseTimeout {
+	return &RequestResponseTimeout{
+		RequestTimeout:  conn.config.AuthTimeout,
+		ResponseTimeout: conn.config.AuthTimeout,
+	}
+}
+
+// GetDataTransferTimeout returns timeout for each request of data transfer, such as DataObjRead and DataObjWrite
+func (conn *IRODSConnection) GetDataTransferTimeout() *RequestResponseTimeout {
+	return &RequestResponseTimeout{
+		RequestTimeout:  conn.config.DataTransferTimeout,
+		ResponseTimeout: conn.config.DataTransferTimeout,
+	}
+}
@@ -2,9 +2,9 @@ package connection
 
 import (
 	"context"
-	"fmt"
 	"io"
 	"net"
+	"strconv"
 	"sync"
 	"time"
 
@@ -135,53 +135,6 @@ func (conn *IRODSResourceServerConnection) GetLastSuccessfulAccess() time.Time {
 	return conn.lastSuccessfulAccess
 }
 
-// setSocketOpt sets socket opts
-func (conn *IRODSResourceServerConnection) setSocketOpt(socket net.Conn, bufferSize int) {
-	logger := log.WithFields(log.Fields{
-		"buffer_size": bufferSize,
-	})
-
-	if tcpSocket, ok := socket.(*net.TCPConn); ok {
-		// TCP socket
-		err := tcpSocket.SetNoDelay(true)
-		if err != nil {
-			logger.Errorf("failed to set no delay: %+v", err)
-		}
-
-		err = tcpSocket.SetKeepAlive(true)
-		if err != nil {
-			logger.Errorf("failed to set keep alive: %+v", err)
-		}
-
-		err = tcpSocket.SetKeepAlivePeriod(15 * time.Second) // 15 seconds
-		if err != nil {
-			logger.Errorf("failed to set keep alive period: %+v", err)
-		}
-
-		err = tcpSocket.SetLinger(5) // 5 seconds
-		if err != nil {
-			logger.Errorf("failed to set linger: %+v", err)
-		}
-
-		// TCP buffer size
-		if bufferSize > 0 {
-			logger.Infof("setting tcp buffer size to %d", bufferSize)
-
-			sockErr := tcpSocket.SetReadBuffer(bufferSize)
-			if sockErr != nil {
-				sockBuffErr := errors.Wrapf(sockErr, "failed to set tcp read buffer size %d", bufferSize)
-				logger.Errorf("%+v", sockBuffErr)
-			}
-
-			sockErr = tcpSocket.SetWriteBuffer(bufferSize)
-			if sockErr != nil {
-				sockBuffErr := errors.Wrapf(sockErr, "failed to set tcp write buffer size %d", bufferSize)
-				logger.Errorf("%+v", sockBuffErr)
-			}
-		}
-	}
-}
-
 // Connect connects to iRODS
 func (conn *IRODSResourceServerConnection) Connect() error {
 	logger := log.WithFields(log.Fields{})
@@ -192,15 +145,42 @@ func (conn *IRODSResourceServerConnection) Connect() error {
 	conn.Lock()
 	defer conn.Unlock()
 
-	server := fmt.Sprintf("%s:%d", conn.serverInfo.Host, conn.serverInfo.Port)
-	logger.Debugf("Connecting to %s", server)
+	proxyURL := conn.controlConnection.account.ProxyURL
+	portString := strconv.Itoa(conn.serverInfo.Port)
+
+	endpoints := []hostEndpoint{}
+	if len(proxyURL) > 0 {
+		// proxy resolves names
+		endpoints = append(endpoints, hostEndpoint{
+			Host:    conn.serverInfo.Host,
+			Address: net.JoinHostPort(conn.serverInfo.Host, portString),
+		})
+	} else {
+		resolveCtx, resolveCancelFunc := context.WithTimeout(context.Background(), conn.config.ConnectTimeout)
+		for _, addr := range resolveHostAddresses(resolveCtx, conn.config.getResolver(), conn.serverInfo.Host, conn.config.AddressFamily) {
+			endpoints = append(endpoints, hostEndpoint{
+				Host:    conn.serverInfo.Host,
+				Address: net.JoinHostPort(addr, portString),
+			})
+		}
+		resolveCancelFunc()
+	}
+
+	dial := func(ctx context.Context, endpoint hostEndpoint) (net.Conn, error) {
+		logger.Debugf("Connecting to %s", endpoint.Address)
+
+		// must connect to the server within ConnectTimeout
+		dialCtx, cancelFunc := context.WithTimeout(ctx, conn.config.ConnectTimeout)
+		defer cancelFunc()
 
-	// must connect to the server within ConnectTimeout
-	var dialer net.Dialer
-	ctx, cancelFunc := context.WithTimeout(context.Background(), conn.config.ConnectTimeout)
-	defer cancelFunc()
+		return dialTCP(dialCtx, endpoint.Address, proxyURL, conn.config.getTCPSocketOptions())
+	}
 
-	socket, err := dialer.DialContext(ctx, "tcp", server)
+	onFailure := func(endpoint hostEndpoint, err error) {
+		logger.Debugf("failed to connect to %s, trying next address: %v", endpoint.Address, err)
+	}
+
+	socket, endpoint, err := dialEndpoints(context.Background(), endpoints, conn.config.HappyEyeballsDelay, dial, onFailure)
 	if err != nil {
 		newErr := errors.Join(err, types.NewConnectionError())
 		connErr := errors.Wrapf(newErr, "failed to connect to specified host %q and port %d", conn.serverInfo.Host, conn.serverInfo.Port)
@@ -211,13 +191,11 @@ func (conn *IRODSResourceServerConnection) Connect() error {
 		return connErr
 	}
 
-	conn.setSocketOpt(socket, conn.config.TcpBufferSize)
-
 	if conn.config.Metrics != nil {
 		conn.config.Metrics.IncreaseConnectionsOpened(1)
 	}
 
-	conn.socket = socket
+	conn.socket = conn.config.FaultInjectionPolicy.wrap(socket, endpoint.Address)
 
 	auth := message.NewIRODSMessageResourceServerAuth(conn.serverInfo)
 	authBytes, err := auth.GetBytes()
@@ -466,6 +444,10 @@ func (conn *IRODSResourceServerConnection) Decrypt(iv []byte, source []byte, des
 	}
 
 	sslConf := conn.controlConnection.account.SSLConfiguration
+	if sslConf == nil {
+		return 0, errors.Errorf("SSL configuration is not set")
+	}
+
 	encryptionAlg := types.GetEncryptionAlgorithm(sslConf.EncryptionAlgorithm)
 
 	len, err := util.Decrypt(encryptionAlg, conn.controlConnection.sslSharedSecret, iv, source, dest)
@@ -476,13 +458,17 @@ func (conn *IRODSResourceServerConnection) Decrypt(iv []byte, source []byte, des
 	return len, nil
 }
 
-// Decrypt decrypts byte buf
+// Encrypt encrypts byte buf
 func (conn *IRODSResourceServerConnection) Encrypt(iv []byte, source []byte, dest []byte) (int, error) {
 	if !conn.controlConnection.isSSLSocket {
 		return 0, errors.Errorf("the connection is not SSL encrypted")
 	}
 
 	sslConf := conn.controlConnection.account.SSLConfiguration
+	if sslConf == nil {
+		return 0, errors.Errorf("SSL configuration is not set")
+	}
+
 	encryptionAlg := types.GetEncryptionAlgorithm(sslConf.EncryptionAlgorithm)
 
 	len, err := util.Encrypt(encryptionAlg, conn.controlConnection.sslSharedSecret, iv, source, dest)
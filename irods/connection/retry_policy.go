@@ -0,0 +1,193 @@
+package connection
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// RetryMaxAttemptsDefault is a default number of attempts including the first one
+	RetryMaxAttemptsDefault int = 3
+	// RetryTransferMaxAttemptsDefault is a default number of attempts of data transfer tasks, transfers retry socket failures without limit
+	RetryTransferMaxAttemptsDefault int = RetryMaxAttemptsUnlimited
+	// RetryInitialBackoffDefault is a default backoff before the first retry
+	RetryInitialBackoffDefault time.Duration = 100 * time.Millisecond
+	// RetryMaxBackoffDefault is a default max backoff between retries
+	RetryMaxBackoffDefault time.Duration = 5 * time.Second
+	// RetryBackoffMultiplierDefault is a default multiplier of backoff
+	RetryBackoffMultiplierDefault float64 = 2.0
+	// RetryJitterDefault is a default jitter ratio of backoff
+	RetryJitterDefault float64 = 0.2
+
+	// RetryMaxAttemptsUnlimited retries without limit while operations fail with retryable errors
+	RetryMaxAttemptsUnlimited int = 0
+)
+
+// RetryErrorClassifier returns true if the operation failed with the error can be retried
+type RetryErrorClassifier func(err error) bool
+
+// RetryPolicy is a policy for retrying operations that failed with transient errors
+type RetryPolicy struct {
+	MaxAttempts         int                  // number of attempts including the first one, 1 disables retry, 0 retries without limit
+	TransferMaxAttempts int                  // number of attempts of data transfer tasks, 1 disables retry, 0 retries without limit
+	InitialBackoff      time.Duration        // backoff before the first retry
+	MaxBackoff          time.Duration        // max backoff between retries
+	BackoffMultiplier   float64              // backoff grows exponentially by the multiplier
+	Jitter              float64              // ratio of random jitter applied to backoff, 0.0 - 1.0
+	Classifier          RetryErrorClassifier // can be nil, connection errors are retried if not set
+}
+
+// NewDefaultRetryPolicy returns a default retry policy
+func NewDefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:         RetryMaxAttemptsDefault,
+		TransferMaxAttempts: RetryTransferMaxAttemptsDefault,
+		InitialBackoff:      RetryInitialBackoffDefault,
+		MaxBackoff:          RetryMaxBackoffDefault,
+		BackoffMultiplier:   RetryBackoffMultiplierDefault,
+		Jitter:              RetryJitterDefault,
+		Classifier:          nil,
+	}
+}
+
+// NewNoRetryPolicy returns a retry policy that does not retry
+func NewNoRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:         1,
+		TransferMaxAttempts: 1,
+	}
+}
+
+// IsRetryableError returns true if the error is a connection error, used as a default classifier
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return types.IsConnectionError(err)
+}
+
+// Validate validates the retry policy
+func (policy *RetryPolicy) Validate() error {
+	if policy.MaxAttempts < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "retry max attempts %d is invalid", policy.MaxAttempts)
+	}
+
+	if policy.TransferMaxAttempts < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "retry transfer max attempts %d is invalid", policy.TransferMaxAttempts)
+	}
+
+	if policy.InitialBackoff < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "retry initial backoff is invalid")
+	}
+
+	if policy.MaxBackoff < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "retry max backoff is invalid")
+	}
+
+	if policy.BackoffMultiplier < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "retry backoff multiplier is invalid")
+	}
+
+	if policy.Jitter < 0 || policy.Jitter > 1 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "retry jitter %f is invalid", policy.Jitter)
+	}
+
+	return nil
+}
+
+// GetMaxAttempts returns number of attempts including the first one, 0 means no limit
+func (policy *RetryPolicy) GetMaxAttempts() int {
+	if policy.MaxAttempts < 0 {
+		return 1
+	}
+	return policy.MaxAttempts
+}
+
+// GetTransferPolicy returns a copy of the policy for data transfer tasks, which uses TransferMaxAttempts as MaxAttempts
+func (policy *RetryPolicy) GetTransferPolicy() *RetryPolicy {
+	transferPolicy := *policy
+	transferPolicy.MaxAttempts = policy.TransferMaxAttempts
+	return &transferPolicy
+}
+
+// IsUnlimited returns true if the policy retries without limit
+func (policy *RetryPolicy) IsUnlimited() bool {
+	return policy.GetMaxAttempts() == RetryMaxAttemptsUnlimited
+}
+
+// IsRetryable returns true if the error can be retried
+func (policy *RetryPolicy) IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if policy.Classifier != nil {
+		return policy.Classifier(err)
+	}
+
+	return IsRetryableError(err)
+}
+
+// GetBackoff returns backoff before the given retry, retry starts from 1
+func (policy *RetryPolicy) GetBackoff(retry int) time.Duration {
+	if retry <= 0 || policy.InitialBackoff <= 0 {
+		return 0
+	}
+
+	multiplier := policy.BackoffMultiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(policy.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if policy.MaxBackoff > 0 && backoff > float64(policy.MaxBackoff) {
+		backoff = float64(policy.MaxBackoff)
+	}
+
+	if policy.Jitter > 0 {
+		// randomize in range of [backoff * (1 - jitter), backoff * (1 + jitter)]
+		backoff = backoff * (1 + policy.Jitter*(2*rand.Float64()-1))
+	}
+
+	return time.Duration(backoff)
+}
+
+// Run runs the operation and retries it with backoff while it fails with retryable errors.
+// attempt passed to the operation starts from 0.
+func (policy *RetryPolicy) Run(operation func(attempt int) error) error {
+	logger := log.WithFields(log.Fields{})
+
+	maxAttempts := policy.GetMaxAttempts()
+
+	var err error
+	for attempt := 0; policy.IsUnlimited() || attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			backoff := policy.GetBackoff(attempt)
+			logger.WithError(err).Debugf("retrying operation after %v (attempt %d/%d)", backoff, attempt+1, maxAttempts)
+			time.Sleep(backoff)
+		}
+
+		err = operation(attempt)
+		if err == nil {
+			return nil
+		}
+
+		if !policy.IsRetryable(err) {
+			return err
+		}
+	}
+
+	return err
+}
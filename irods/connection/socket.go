@@ -0,0 +1,90 @@
+package connection
+
+import (
+	"net"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	TcpKeepAlivePeriodDefault time.Duration = 15 * time.Second
+	TcpLingerDefault          int           = 5 // 5 seconds
+)
+
+// tcpSocketOptions is a set of TCP socket options
+type tcpSocketOptions struct {
+	KeepAlivePeriod   time.Duration // 0 uses default, negative disables TCP keepalive
+	Delay             bool          // if true, Nagle's algorithm is enabled (TCP_NODELAY is not set)
+	SendBufferSize    int           // SO_SNDBUF, 0 uses system default
+	ReceiveBufferSize int           // SO_RCVBUF, 0 uses system default
+}
+
+// setTCPSocketOptions sets TCP socket options, errors are logged and ignored
+func setTCPSocketOptions(socket net.Conn, options tcpSocketOptions) {
+	logger := log.WithFields(log.Fields{
+		"keepalive_period":    options.KeepAlivePeriod,
+		"delay":               options.Delay,
+		"send_buffer_size":    options.SendBufferSize,
+		"receive_buffer_size": options.ReceiveBufferSize,
+	})
+
+	tcpSocket, ok := socket.(*net.TCPConn)
+	if !ok {
+		return
+	}
+
+	err := tcpSocket.SetNoDelay(!options.Delay)
+	if err != nil {
+		logger.Errorf("failed to set no delay: %+v", err)
+	}
+
+	if options.KeepAlivePeriod < 0 {
+		err = tcpSocket.SetKeepAlive(false)
+		if err != nil {
+			logger.Errorf("failed to disable keep alive: %+v", err)
+		}
+	} else {
+		keepAlivePeriod := options.KeepAlivePeriod
+		if keepAlivePeriod == 0 {
+			keepAlivePeriod = TcpKeepAlivePeriodDefault
+		}
+
+		err = tcpSocket.SetKeepAlive(true)
+		if err != nil {
+			logger.Errorf("failed to set keep alive: %+v", err)
+		}
+
+		err = tcpSocket.SetKeepAlivePeriod(keepAlivePeriod)
+		if err != nil {
+			logger.Errorf("failed to set keep alive period: %+v", err)
+		}
+	}
+
+	err = tcpSocket.SetLinger(TcpLingerDefault)
+	if err != nil {
+		logger.Errorf("failed to set linger: %+v", err)
+	}
+
+	// TCP buffer size
+	if options.ReceiveBufferSize > 0 {
+		logger.Infof("setting tcp read buffer size to %d", options.ReceiveBufferSize)
+
+		sockErr := tcpSocket.SetReadBuffer(options.ReceiveBufferSize)
+		if sockErr != nil {
+			sockBuffErr := errors.Wrapf(sockErr, "failed to set tcp read buffer size %d", options.ReceiveBufferSize)
+			logger.Errorf("%+v", sockBuffErr)
+		}
+	}
+
+	if options.SendBufferSize > 0 {
+		logger.Infof("setting tcp write buffer size to %d", options.SendBufferSize)
+
+		sockErr := tcpSocket.SetWriteBuffer(options.SendBufferSize)
+		if sockErr != nil {
+			sockBuffErr := errors.Wrapf(sockErr, "failed to set tcp write buffer size %d", options.SendBufferSize)
+			logger.Errorf("%+v", sockBuffErr)
+		}
+	}
+}
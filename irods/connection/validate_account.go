@@ -0,0 +1,71 @@
+package connection
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// ValidateAccount checks if the account can log in to iRODS, it dials, negotiates, authenticates, and disconnects.
+// returns AccountValidationError having the reason of failure, use types.GetAccountValidationFailureReason to get the reason
+func ValidateAccount(account *types.IRODSAccount, config *IRODSConnectionConfig) error {
+	logger := log.WithFields(log.Fields{})
+
+	conn, err := NewIRODSConnection(account, config)
+	if err != nil {
+		return types.NewAccountValidationError(types.AccountValidationInvalidConfig, err)
+	}
+
+	conn.Lock()
+	defer conn.Unlock()
+
+	err = conn.connectTCP()
+	if err != nil {
+		return types.NewAccountValidationError(types.AccountValidationUnreachable, err)
+	}
+
+	irodsVersion, err := conn.startup()
+	if err != nil {
+		_ = conn.disconnectNow()
+
+		reason := types.AccountValidationHandshakeFailed
+		if types.IsSSLNegotiationError(err) {
+			reason = types.AccountValidationSSLNegotiationFailed
+		}
+
+		newErr := errors.Wrapf(err, "failed to startup an iRODS connection to server %q", conn.serverAddress)
+		return types.NewAccountValidationError(reason, newErr)
+	}
+
+	conn.serverVersion = irodsVersion
+	conn.serverCapabilities = types.NewIRODSServerCapabilities(irodsVersion)
+
+	err = conn.login()
+
+	// disconnect regardless of the result
+	logoutErr := conn.logout()
+	if logoutErr != nil {
+		logger.WithError(logoutErr).Debugf("failed to logout from server %q", conn.serverAddress)
+	}
+	_ = conn.disconnectNow()
+
+	if err != nil {
+		return types.NewAccountValidationError(getLoginFailureReason(err), err)
+	}
+
+	return nil
+}
+
+// getLoginFailureReason classifies the login error
+func getLoginFailureReason(err error) types.AccountValidationFailureReason {
+	switch {
+	case errors.Is(err, types.ErrPasswordExpired):
+		return types.AccountValidationCredentialExpired
+	case errors.Is(err, types.ErrInvalidAuthentication), errors.Is(err, types.ErrInvalidUser), errors.Is(err, types.ErrPAMPasswordFailed):
+		return types.AccountValidationBadPassword
+	case types.IsConnectionConfigError(err):
+		return types.AccountValidationInvalidConfig
+	default:
+		return types.AccountValidationAuthFailed
+	}
+}
@@ -0,0 +1,196 @@
+package connection
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/cyverse/go-irodsclient/irods/message"
+)
+
+const (
+	// WireDebugBinaryLimitDefault is a default number of binary bytes dumped per message
+	WireDebugBinaryLimitDefault int = 64
+
+	wireDebugRedacted string = "<Redacted>"
+)
+
+var (
+	// xml elements carrying credentials
+	wireDebugSensitiveElementRegex = regexp.MustCompile(`(?s)(<(pamPassword|irodsPamPassword|response|challenge|context_|result_)>).*?(</(pamPassword|irodsPamPassword|response|challenge|context_|result_)>)`)
+	// admin requests setting a password, e.g., arg3=password, arg4=<obfuscated password>
+	wireDebugAdminPasswordRegex = regexp.MustCompile(`(?s)(<arg\d>password</arg\d>\s*<arg\d>).*?(</arg\d>)`)
+	// base64 encoded json in BinBytesBuf, e.g., auth plugin requests and responses
+	wireDebugBinBytesBufRegex = regexp.MustCompile(`(?s)(<BinBytesBuf_PI>.*?<buf>)(.*?)(</buf>)`)
+
+	// json keys carrying credentials in auth plugin contexts
+	wireDebugSensitiveJSONKeys = map[string]bool{
+		AUTH_PASSWORD_KEY:            true,
+		"password":                   true,
+		OPENID_AUTH_ACCESS_TOKEN_KEY: true,
+		"request_result":             true,
+		"resp":                       true, // pam_interactive user input
+		"pstate":                     true, // pam_interactive state, holds user input
+	}
+
+	// json keys of pam_interactive state patches, each patch carries user input in its value
+	wireDebugSensitiveJSONPatchKeys = map[string]bool{
+		"patch": true,
+	}
+
+	// serialize dumps from multiple connections writing to the same writer
+	wireDebugMutex sync.Mutex
+)
+
+// wireDebugDirection is a direction of a dumped message
+type wireDebugDirection string
+
+const (
+	wireDebugSend wireDebugDirection = "SEND"
+	wireDebugRecv wireDebugDirection = "RECV"
+)
+
+// redactWireDebugMessage removes credentials from the xml message body
+func redactWireDebugMessage(body []byte) string {
+	redacted := wireDebugSensitiveElementRegex.ReplaceAll(body, []byte("${1}"+wireDebugRedacted+"${3}"))
+	redacted = wireDebugAdminPasswordRegex.ReplaceAll(redacted, []byte("${1}"+wireDebugRedacted+"${2}"))
+	redacted = wireDebugBinBytesBufRegex.ReplaceAllFunc(redacted, func(match []byte) []byte {
+		groups := wireDebugBinBytesBufRegex.FindSubmatch(match)
+		return bytes.Join([][]byte{groups[1], redactWireDebugBinBytesBuf(groups[2]), groups[3]}, nil)
+	})
+	return string(redacted)
+}
+
+// redactWireDebugBinBytesBuf removes credentials from base64 encoded json, returns the redacted json in plain text
+// data that is not json is redacted entirely
+func redactWireDebugBinBytesBuf(data []byte) []byte {
+	decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return []byte(wireDebugRedacted)
+	}
+
+	nullIndex := bytes.IndexByte(decoded, '\x00')
+	if nullIndex >= 0 {
+		decoded = decoded[:nullIndex]
+	}
+
+	var jsonObj interface{}
+	err = json.Unmarshal(decoded, &jsonObj)
+	if err != nil {
+		return []byte(wireDebugRedacted)
+	}
+
+	redacted, err := json.Marshal(redactWireDebugJSON(jsonObj))
+	if err != nil {
+		return []byte(wireDebugRedacted)
+	}
+	return redacted
+}
+
+// redactWireDebugJSON replaces values of credential keys in the json object recursively
+func redactWireDebugJSON(obj interface{}) interface{} {
+	switch v := obj.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if wireDebugSensitiveJSONKeys[key] {
+				v[key] = wireDebugRedacted
+				continue
+			}
+			if wireDebugSensitiveJSONPatchKeys[key] {
+				v[key] = redactWireDebugJSONPatch(value)
+				continue
+			}
+			v[key] = redactWireDebugJSON(value)
+		}
+		return v
+	case []interface{}:
+		for idx, value := range v {
+			v[idx] = redactWireDebugJSON(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// redactWireDebugJSONPatch replaces values of json patch operations, keeps operations and paths
+func redactWireDebugJSONPatch(patch interface{}) interface{} {
+	operations, ok := patch.([]interface{})
+	if !ok {
+		return wireDebugRedacted
+	}
+
+	for idx, operation := range operations {
+		operationMap, ok := operation.(map[string]interface{})
+		if !ok {
+			operations[idx] = wireDebugRedacted
+			continue
+		}
+
+		if _, ok := operationMap["value"]; ok {
+			operationMap["value"] = wireDebugRedacted
+		}
+	}
+	return operations
+}
+
+// dumpWireDebugBinary returns hex dump of the binary data, truncated to limit
+func dumpWireDebugBinary(data []byte, limit int) string {
+	if limit <= 0 || len(data) == 0 {
+		return ""
+	}
+
+	truncated := false
+	if len(data) > limit {
+		data = data[:limit]
+		truncated = true
+	}
+
+	dump := hex.Dump(data)
+	if truncated {
+		dump += "...(truncated)\n"
+	}
+	return dump
+}
+
+// dumpWireDebugMessage writes the message to the writer in human readable form
+func dumpWireDebugMessage(writer io.Writer, binaryLimit int, direction wireDebugDirection, address string, headerBytes []byte, body *message.IRODSMessageBody) {
+	if writer == nil {
+		return
+	}
+
+	wireDebugMutex.Lock()
+	defer wireDebugMutex.Unlock()
+
+	fmt.Fprintf(writer, "=== %s %s %s\n", direction, address, time.Now().Format(time.RFC3339Nano)) //nolint
+	fmt.Fprintf(writer, "header: %s\n", string(headerBytes))                                       //nolint
+
+	if body == nil {
+		return
+	}
+
+	if body.Type == message.RODS_MESSAGE_SSL_SHARED_SECRET_TYPE {
+		// the whole message is a secret
+		fmt.Fprintf(writer, "message: %s\n", wireDebugRedacted) //nolint
+		return
+	}
+
+	if len(body.Message) > 0 {
+		fmt.Fprintf(writer, "message: %s\n", redactWireDebugMessage(body.Message)) //nolint
+	}
+
+	if len(body.Error) > 0 {
+		fmt.Fprintf(writer, "error: %s\n", redactWireDebugMessage(body.Error)) //nolint
+	}
+
+	if len(body.Bs) > 0 {
+		fmt.Fprintf(writer, "bs: %d bytes\n", len(body.Bs))           //nolint
+		fmt.Fprint(writer, dumpWireDebugBinary(body.Bs, binaryLimit)) //nolint
+	}
+}
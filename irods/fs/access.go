@@ -52,7 +52,7 @@ func GetCollectionAccessInheritance(conn *connection.IRODSConnection, path strin
 			return nil, errors.Wrapf(err, "failed to receive a collection access inheritance query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -111,6 +111,11 @@ func GetCollectionAccessInheritance(conn *connection.IRODSConnection, path strin
 		}
 	}
 
+	if len(inheritances) == 0 {
+		newErr := types.NewFileNotFoundError(path)
+		return nil, errors.Wrapf(newErr, "failed to find the collection for path %q", path)
+	}
+
 	return inheritances[0], nil
 }
 
@@ -151,7 +156,7 @@ func ListCollectionAccesses(conn *connection.IRODSConnection, path string) ([]*t
 			return nil, errors.Wrapf(err, "failed to receive a collection access query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -264,7 +269,7 @@ func ListDataObjectAccesses(conn *connection.IRODSConnection, dataObjPath string
 			return nil, errors.Wrapf(err, "failed to receive a data object access query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -377,7 +382,7 @@ func ListDataObjectAccessesWithoutCollection(conn *connection.IRODSConnection, d
 			return nil, errors.Wrapf(err, "failed to receive a data object access query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -513,7 +518,7 @@ func ListAccessesForSubCollectionsOld(conn *connection.IRODSConnection, collPath
 			return nil, errors.Wrapf(err, "failed to receive a collection access query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -629,7 +634,7 @@ func ListAccessesForDataObjectsInCollection(conn *connection.IRODSConnection, co
 			return nil, errors.Wrapf(err, "failed to receive a data object access query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -744,7 +749,7 @@ func ListAccessesForDataObjectsWithoutCollection(conn *connection.IRODSConnectio
 			return nil, errors.Wrapf(err, "failed to receive a data object access query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
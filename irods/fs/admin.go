@@ -0,0 +1,47 @@
+package fs
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// GeneralAdmin performs a general admin request, params are given to arg2 ~ arg9
+// this is for admin actions that do not have dedicated functions, the user must be a rodsadmin
+func GeneralAdmin(conn *connection.IRODSConnection, action types.IRODSAdminAction, target types.IRODSAdminTarget, params ...string) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	if len(action) == 0 {
+		return errors.New("admin action is not given")
+	}
+
+	if len(params) > types.MaxAdminRequestParams {
+		return errors.Errorf("too many params %d for admin action %q, max %d", len(params), action, types.MaxAdminRequestParams)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	req := message.NewIRODSMessageAdminRequest(string(action), string(target), params...)
+
+	err := conn.RequestAndCheck(req, &message.IRODSMessageAdminResponse{}, nil, conn.GetOperationTimeout())
+	if err != nil {
+		return errors.Wrapf(err, "received general admin error for action %q, target %q", action, target)
+	}
+	return nil
+}
+
+// AddToken adds a token to the namespace
+func AddToken(conn *connection.IRODSConnection, namespace types.IRODSTokenNamespace, name string, values ...string) error {
+	params := append([]string{string(namespace), name}, values...)
+	return GeneralAdmin(conn, types.IRODSAdminActionAdd, types.IRODSAdminTargetToken, params...)
+}
+
+// RemoveToken removes a token from the namespace
+func RemoveToken(conn *connection.IRODSConnection, namespace types.IRODSTokenNamespace, name string) error {
+	return GeneralAdmin(conn, types.IRODSAdminActionRemove, types.IRODSAdminTargetToken, string(namespace), name)
+}
@@ -0,0 +1,179 @@
+package fs
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// GetDataObjectAggregate returns the aggregate of the column over data objects in the collection, computed on the server
+// only replica 0 of each data object is aggregated, MIN, MAX and AVG return an empty string if there is no data object
+func GetDataObjectAggregate(conn *connection.IRODSConnection, collPath string, recurse bool, selectFunc common.ICATSelectFunction, column common.ICATColumnNumber) (string, error) {
+	if conn == nil || !conn.IsConnected() {
+		return "", errors.Errorf("connection is nil or disconnected")
+	}
+
+	switch selectFunc {
+	case common.ICAT_SELECT_FUNC_COUNT, common.ICAT_SELECT_FUNC_SUM, common.ICAT_SELECT_FUNC_MIN, common.ICAT_SELECT_FUNC_MAX, common.ICAT_SELECT_FUNC_AVG:
+	default:
+		return "", errors.Errorf("select function %d is not an aggregate function", selectFunc)
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForList(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	if selectFunc == common.ICAT_SELECT_FUNC_AVG {
+		// averages of the collection and sub-collections cannot be combined, so compute it from sum and count
+		sum, err := getDataObjectAggregateInTree(conn, collPath, recurse, common.ICAT_SELECT_FUNC_SUM, column)
+		if err != nil {
+			return "", err
+		}
+
+		count, err := getDataObjectAggregateInTree(conn, collPath, recurse, common.ICAT_SELECT_FUNC_COUNT, column)
+		if err != nil {
+			return "", err
+		}
+
+		countValue, _ := strconv.ParseInt(count, 10, 64)
+		if countValue == 0 {
+			return "", nil
+		}
+
+		sumValue, err := strconv.ParseFloat(sum, 64)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to parse sum %q", sum)
+		}
+
+		return strconv.FormatFloat(sumValue/float64(countValue), 'f', -1, 64), nil
+	}
+
+	return getDataObjectAggregateInTree(conn, collPath, recurse, selectFunc, column)
+}
+
+// getDataObjectAggregateInTree returns the aggregate over data objects in the collection and its sub-collections if recurse is true, connection must be locked
+func getDataObjectAggregateInTree(conn *connection.IRODSConnection, collPath string, recurse bool, selectFunc common.ICATSelectFunction, column common.ICATColumnNumber) (string, error) {
+	value, err := getDataObjectAggregate(conn, collPath, false, selectFunc, column)
+	if err != nil {
+		return "", err
+	}
+
+	if recurse {
+		subValue, err := getDataObjectAggregate(conn, collPath, true, selectFunc, column)
+		if err != nil {
+			return "", err
+		}
+
+		value, err = combineAggregates(selectFunc, value, subValue)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if len(value) == 0 && (selectFunc == common.ICAT_SELECT_FUNC_COUNT || selectFunc == common.ICAT_SELECT_FUNC_SUM) {
+		return "0", nil
+	}
+
+	return value, nil
+}
+
+// getDataObjectAggregate returns the aggregate over data objects directly in the collection or in its sub-collections, connection must be locked
+func getDataObjectAggregate(conn *connection.IRODSConnection, collPath string, inSubCollections bool, selectFunc common.ICATSelectFunction, column common.ICATColumnNumber) (string, error) {
+	query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, 0, 0, 0)
+	query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
+	query.AddSelectWithFunction(column, selectFunc)
+
+	query.AddEqualStringCondition(common.ICAT_COLUMN_DATA_REPL_NUM, "0")
+	if inSubCollections {
+		addSubCollectionCondition(query, collPath)
+	} else {
+		query.AddEqualStringCondition(common.ICAT_COLUMN_COLL_NAME, collPath)
+	}
+
+	queryResult := message.IRODSMessageQueryResponse{}
+	err := conn.Request(query, &queryResult, nil, conn.GetLongResponseOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+			return "", nil
+		}
+
+		return "", errors.Wrapf(err, "failed to receive a data object query result message")
+	}
+
+	err = conn.CheckResponseError(query, &queryResult)
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+			return "", nil
+		}
+
+		return "", errors.Wrapf(err, "received data object query error")
+	}
+
+	for attr := 0; attr < queryResult.AttributeCount && attr < len(queryResult.SQLResult); attr++ {
+		sqlResult := queryResult.SQLResult[attr]
+		if sqlResult.AttributeIndex == int(column) && len(sqlResult.Values) > 0 {
+			return sqlResult.Values[0], nil
+		}
+	}
+
+	return "", nil
+}
+
+// combineAggregates combines two aggregates of the same select function, empty values are ignored
+func combineAggregates(selectFunc common.ICATSelectFunction, value1 string, value2 string) (string, error) {
+	if len(value1) == 0 {
+		return value2, nil
+	}
+
+	if len(value2) == 0 {
+		return value1, nil
+	}
+
+	switch selectFunc {
+	case common.ICAT_SELECT_FUNC_COUNT, common.ICAT_SELECT_FUNC_SUM:
+		intValue1, err := strconv.ParseInt(value1, 10, 64)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to parse aggregate %q", value1)
+		}
+
+		intValue2, err := strconv.ParseInt(value2, 10, 64)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to parse aggregate %q", value2)
+		}
+
+		return strconv.FormatInt(intValue1+intValue2, 10), nil
+	case common.ICAT_SELECT_FUNC_MIN, common.ICAT_SELECT_FUNC_MAX:
+		cmp := strings.Compare(value1, value2)
+
+		// numbers, e.g., sizes, are compared numerically, times are zero-padded
+		intValue1, err1 := strconv.ParseInt(value1, 10, 64)
+		intValue2, err2 := strconv.ParseInt(value2, 10, 64)
+		if err1 == nil && err2 == nil {
+			switch {
+			case intValue1 < intValue2:
+				cmp = -1
+			case intValue1 > intValue2:
+				cmp = 1
+			default:
+				cmp = 0
+			}
+		}
+
+		if (selectFunc == common.ICAT_SELECT_FUNC_MIN) == (cmp <= 0) {
+			return value1, nil
+		}
+		return value2, nil
+	default:
+		return "", errors.Errorf("cannot combine aggregates of select function %d", selectFunc)
+	}
+}
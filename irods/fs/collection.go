@@ -61,6 +61,10 @@ func GetCollection(conn *connection.IRODSConnection, path string) (*types.IRODSC
 	query.AddSelect(common.ICAT_COLUMN_COLL_OWNER_NAME)
 	query.AddSelect(common.ICAT_COLUMN_COLL_CREATE_TIME)
 	query.AddSelect(common.ICAT_COLUMN_COLL_MODIFY_TIME)
+	query.AddSelect(common.ICAT_COLUMN_COLL_INHERITANCE)
+	query.AddSelect(common.ICAT_COLUMN_COLL_TYPE)
+	query.AddSelect(common.ICAT_COLUMN_COLL_INFO1)
+	query.AddSelect(common.ICAT_COLUMN_COLL_INFO2)
 
 	query.AddEqualStringCondition(common.ICAT_COLUMN_COLL_NAME, path)
 
@@ -78,7 +82,7 @@ func GetCollection(conn *connection.IRODSConnection, path string) (*types.IRODSC
 		return nil, errors.Wrapf(err, "failed to receive collection query result message")
 	}
 
-	err = queryResult.CheckError()
+	err = conn.CheckResponseError(query, &queryResult)
 	if err != nil {
 		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 			newErr := errors.Join(err, types.NewFileNotFoundError(path))
@@ -106,6 +110,10 @@ func GetCollection(conn *connection.IRODSConnection, path string) (*types.IRODSC
 	collectionOwner := ""
 	createTime := time.Time{}
 	modifyTime := time.Time{}
+	inheritance := false
+	specialType := types.NormalCollectionType
+	specialInfo1 := ""
+	specialInfo2 := ""
 	for idx := 0; idx < queryResult.AttributeCount; idx++ {
 		sqlResult := queryResult.SQLResult[idx]
 		if len(sqlResult.Values) != queryResult.RowCount {
@@ -137,6 +145,16 @@ func GetCollection(conn *connection.IRODSConnection, path string) (*types.IRODSC
 				return nil, errors.Wrapf(err, "failed to parse modify time %q", value)
 			}
 			modifyTime = mT
+		case int(common.ICAT_COLUMN_COLL_INHERITANCE):
+			inherit, _ := strconv.ParseBool(value)
+			// if error, assume false
+			inheritance = inherit
+		case int(common.ICAT_COLUMN_COLL_TYPE):
+			specialType = types.SpecialCollectionType(value)
+		case int(common.ICAT_COLUMN_COLL_INFO1):
+			specialInfo1 = value
+		case int(common.ICAT_COLUMN_COLL_INFO2):
+			specialInfo2 = value
 		default:
 			// ignore
 		}
@@ -148,12 +166,16 @@ func GetCollection(conn *connection.IRODSConnection, path string) (*types.IRODSC
 	}
 
 	return &types.IRODSCollection{
-		ID:         collectionID,
-		Path:       collectionPath,
-		Name:       util.GetIRODSPathFileName(collectionPath),
-		Owner:      collectionOwner,
-		CreateTime: createTime,
-		ModifyTime: modifyTime,
+		ID:           collectionID,
+		Path:         collectionPath,
+		Name:         util.GetIRODSPathFileName(collectionPath),
+		Owner:        collectionOwner,
+		CreateTime:   createTime,
+		ModifyTime:   modifyTime,
+		Inheritance:  inheritance,
+		SpecialType:  specialType,
+		SpecialInfo1: specialInfo1,
+		SpecialInfo2: specialInfo2,
 	}, nil
 }
 
@@ -202,7 +224,7 @@ func ListCollectionMeta(conn *connection.IRODSConnection, path string) ([]*types
 			return nil, errors.Wrapf(err, "failed to receive a collection metadata query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -305,120 +327,205 @@ func ListSubCollections(conn *connection.IRODSConnection, path string) ([]*types
 
 	collections := []*types.IRODSCollection{}
 
-	continueQuery := true
 	continueIndex := 0
-	for continueQuery {
-		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, 0)
-		query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
-		query.AddSelect(common.ICAT_COLUMN_COLL_ID)
-		query.AddSelect(common.ICAT_COLUMN_COLL_NAME)
-		query.AddSelect(common.ICAT_COLUMN_COLL_OWNER_NAME)
-		query.AddSelect(common.ICAT_COLUMN_COLL_CREATE_TIME)
-		query.AddSelect(common.ICAT_COLUMN_COLL_MODIFY_TIME)
-
-		query.AddEqualStringCondition(common.ICAT_COLUMN_COLL_PARENT_NAME, path)
-
-		queryResult := message.IRODSMessageQueryResponse{}
-		err := conn.Request(query, &queryResult, nil, conn.GetLongResponseOperationTimeout())
+	for {
+		pagenatedCollections, nextContinueIndex, err := listSubCollectionsPage(conn, path, continueIndex, common.MaxQueryRows, nil)
 		if err != nil {
-			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
-				// empty
-				break
-			} else if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
-				newErr := errors.Join(err, types.NewFileNotFoundError(path))
-				return nil, errors.Wrapf(newErr, "failed to find the collection for path %q", path)
-			}
+			return nil, err
+		}
 
-			return nil, errors.Wrapf(err, "failed to receive a collection query result message")
+		collections = append(collections, pagenatedCollections...)
+
+		if nextContinueIndex == 0 {
+			break
 		}
+		continueIndex = nextContinueIndex
+	}
 
-		err = queryResult.CheckError()
+	return collections, nil
+}
+
+// ListSubCollectionsPage lists a page of sub-collections in the given collection, up to maxRows
+// continueIndex is 0 for the first page, returned continue index is 0 after the last page
+// call CloseQuery with the returned continue index to stop listing before the last page
+// options must be the same for all pages of a listing, nil lists all sub-collections in the server order
+func ListSubCollectionsPage(conn *connection.IRODSConnection, path string, continueIndex int, maxRows int, options *ListQueryOptions) ([]*types.IRODSCollection, int, error) {
+	if conn == nil || !conn.IsConnected() {
+		return nil, 0, errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil && continueIndex == 0 {
+		metrics.IncreaseCounterForList(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	if options != nil {
+		err := options.Validate()
 		if err != nil {
-			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
-				// empty
-				break
-			} else if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
-				newErr := errors.Join(err, types.NewFileNotFoundError(path))
-				return nil, errors.Wrapf(newErr, "failed to find the collection for path %q", path)
-			}
+			return nil, 0, err
+		}
+	}
 
-			return nil, errors.Wrapf(err, "received collection query error")
+	return listSubCollectionsPage(conn, path, continueIndex, maxRows, options)
+}
+
+func listSubCollectionsPage(conn *connection.IRODSConnection, path string, continueIndex int, maxRows int, options *ListQueryOptions) ([]*types.IRODSCollection, int, error) {
+	query := message.NewIRODSMessageQueryRequest(maxRows, continueIndex, 0, 0)
+	query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
+
+	columns := []common.ICATColumnNumber{
+		common.ICAT_COLUMN_COLL_ID,
+		common.ICAT_COLUMN_COLL_NAME,
+		common.ICAT_COLUMN_COLL_OWNER_NAME,
+		common.ICAT_COLUMN_COLL_CREATE_TIME,
+		common.ICAT_COLUMN_COLL_MODIFY_TIME,
+		common.ICAT_COLUMN_COLL_INHERITANCE,
+		common.ICAT_COLUMN_COLL_TYPE,
+		common.ICAT_COLUMN_COLL_INFO1,
+		common.ICAT_COLUMN_COLL_INFO2,
+	}
+
+	if options != nil {
+		addSortedSelects(query, columns, options.getCollectionSortColumn(), options.SortDescending, common.ICAT_COLUMN_COLL_NAME)
+
+		if len(options.NamePattern) > 0 {
+			query.AddLikePrefixStringCondition(common.ICAT_COLUMN_COLL_NAME, util.MakeIRODSPath(path, ""), options.NamePattern)
 		}
+	} else {
+		addSortedSelects(query, columns, 0, false, 0)
+	}
 
-		if queryResult.RowCount == 0 {
-			break
+	query.AddEqualStringCondition(common.ICAT_COLUMN_COLL_PARENT_NAME, path)
+
+	queryResult := message.IRODSMessageQueryResponse{}
+	err := conn.Request(query, &queryResult, nil, conn.GetLongResponseOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+			// empty
+			return []*types.IRODSCollection{}, 0, nil
+		} else if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
+			newErr := errors.Join(err, types.NewFileNotFoundError(path))
+			return nil, 0, errors.Wrapf(newErr, "failed to find the collection for path %q", path)
 		}
 
-		if queryResult.AttributeCount > len(queryResult.SQLResult) {
-			return nil, errors.Errorf("failed to receive collection attributes - requires %d, but received %d attributes", queryResult.AttributeCount, len(queryResult.SQLResult))
+		return nil, 0, errors.Wrapf(err, "failed to receive a collection query result message")
+	}
+
+	err = conn.CheckResponseError(query, &queryResult)
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+			// empty
+			return []*types.IRODSCollection{}, 0, nil
+		} else if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
+			newErr := errors.Join(err, types.NewFileNotFoundError(path))
+			return nil, 0, errors.Wrapf(newErr, "failed to find the collection for path %q", path)
 		}
 
-		pagenatedCollections := make([]*types.IRODSCollection, queryResult.RowCount)
+		return nil, 0, errors.Wrapf(err, "received collection query error")
+	}
 
-		for attr := 0; attr < queryResult.AttributeCount; attr++ {
-			sqlResult := queryResult.SQLResult[attr]
-			if len(sqlResult.Values) != queryResult.RowCount {
-				return nil, errors.Errorf("failed to receive collection rows - requires %d, but received %d attributes", queryResult.RowCount, len(sqlResult.Values))
-			}
+	if queryResult.RowCount == 0 {
+		return []*types.IRODSCollection{}, 0, nil
+	}
 
-			for row := 0; row < queryResult.RowCount; row++ {
-				value := sqlResult.Values[row]
+	if queryResult.AttributeCount > len(queryResult.SQLResult) {
+		return nil, 0, errors.Errorf("failed to receive collection attributes - requires %d, but received %d attributes", queryResult.AttributeCount, len(queryResult.SQLResult))
+	}
 
-				if pagenatedCollections[row] == nil {
-					// create a new
-					pagenatedCollections[row] = &types.IRODSCollection{
-						ID:         -1,
-						Path:       "",
-						Name:       "",
-						Owner:      "",
-						CreateTime: time.Time{},
-						ModifyTime: time.Time{},
-					}
-				}
+	pagenatedCollections := make([]*types.IRODSCollection, queryResult.RowCount)
 
-				switch sqlResult.AttributeIndex {
-				case int(common.ICAT_COLUMN_COLL_ID):
-					cID, err := strconv.ParseInt(value, 10, 64)
-					if err != nil {
-						return nil, errors.Wrapf(err, "failed to parse collection id %q", value)
-					}
-					pagenatedCollections[row].ID = cID
-				case int(common.ICAT_COLUMN_COLL_NAME):
-					pagenatedCollections[row].Path = value
-					pagenatedCollections[row].Name = util.GetIRODSPathFileName(value)
-				case int(common.ICAT_COLUMN_COLL_OWNER_NAME):
-					pagenatedCollections[row].Owner = value
-				case int(common.ICAT_COLUMN_COLL_CREATE_TIME):
-					cT, err := util.GetIRODSDateTime(value)
-					if err != nil {
-						return nil, errors.Wrapf(err, "failed to parse create time %q", value)
-					}
-					pagenatedCollections[row].CreateTime = cT
-				case int(common.ICAT_COLUMN_COLL_MODIFY_TIME):
-					mT, err := util.GetIRODSDateTime(value)
-					if err != nil {
-						return nil, errors.Wrapf(err, "failed to parse modify time %q", value)
-					}
-					pagenatedCollections[row].ModifyTime = mT
-				default:
-					// ignore
-				}
-			}
+	for attr := 0; attr < queryResult.AttributeCount; attr++ {
+		sqlResult := queryResult.SQLResult[attr]
+		if len(sqlResult.Values) != queryResult.RowCount {
+			return nil, 0, errors.Errorf("failed to receive collection rows - requires %d, but received %d attributes", queryResult.RowCount, len(sqlResult.Values))
 		}
 
-		collections = append(collections, pagenatedCollections...)
+		for row := 0; row < queryResult.RowCount; row++ {
+			value := sqlResult.Values[row]
+
+			if pagenatedCollections[row] == nil {
+				// create a new
+				pagenatedCollections[row] = &types.IRODSCollection{
+					ID:         -1,
+					Path:       "",
+					Name:       "",
+					Owner:      "",
+					CreateTime: time.Time{},
+					ModifyTime: time.Time{},
+				}
+			}
 
-		continueIndex = queryResult.ContinueIndex
-		if continueIndex == 0 {
-			continueQuery = false
+			switch sqlResult.AttributeIndex {
+			case int(common.ICAT_COLUMN_COLL_ID):
+				cID, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					return nil, 0, errors.Wrapf(err, "failed to parse collection id %q", value)
+				}
+				pagenatedCollections[row].ID = cID
+			case int(common.ICAT_COLUMN_COLL_NAME):
+				pagenatedCollections[row].Path = value
+				pagenatedCollections[row].Name = util.GetIRODSPathFileName(value)
+			case int(common.ICAT_COLUMN_COLL_OWNER_NAME):
+				pagenatedCollections[row].Owner = value
+			case int(common.ICAT_COLUMN_COLL_CREATE_TIME):
+				cT, err := util.GetIRODSDateTime(value)
+				if err != nil {
+					return nil, 0, errors.Wrapf(err, "failed to parse create time %q", value)
+				}
+				pagenatedCollections[row].CreateTime = cT
+			case int(common.ICAT_COLUMN_COLL_MODIFY_TIME):
+				mT, err := util.GetIRODSDateTime(value)
+				if err != nil {
+					return nil, 0, errors.Wrapf(err, "failed to parse modify time %q", value)
+				}
+				pagenatedCollections[row].ModifyTime = mT
+			case int(common.ICAT_COLUMN_COLL_INHERITANCE):
+				inherit, _ := strconv.ParseBool(value)
+				// if error, assume false
+				pagenatedCollections[row].Inheritance = inherit
+			case int(common.ICAT_COLUMN_COLL_TYPE):
+				pagenatedCollections[row].SpecialType = types.SpecialCollectionType(value)
+			case int(common.ICAT_COLUMN_COLL_INFO1):
+				pagenatedCollections[row].SpecialInfo1 = value
+			case int(common.ICAT_COLUMN_COLL_INFO2):
+				pagenatedCollections[row].SpecialInfo2 = value
+			default:
+				// ignore
+			}
 		}
 	}
 
-	return collections, nil
+	return pagenatedCollections, queryResult.ContinueIndex, nil
 }
 
 // SearchCollectionsUnixWildcard searches collections using unix-style wildcard
 func SearchCollectionsUnixWildcard(conn *connection.IRODSConnection, pathUnixWildcard string) ([]*types.IRODSCollection, error) {
+	return SearchCollectionsUnixWildcardWithOptions(conn, pathUnixWildcard, nil)
+}
+
+// SearchCollectionsUnixWildcardWithOptions is SearchCollectionsUnixWildcard with search options, nil options are the same as SearchCollectionsUnixWildcard
+func SearchCollectionsUnixWildcardWithOptions(conn *connection.IRODSConnection, pathUnixWildcard string, options *SearchOptions) ([]*types.IRODSCollection, error) {
+	pathSqlWildcard := util.UnixWildcardsToSQLWildcards(pathUnixWildcard)
+	return searchCollectionsSQLWildcard(conn, pathSqlWildcard, func(p string) bool {
+		return fnmatch.Match(pathUnixWildcard, p, options.getFnmatchFlags())
+	}, options)
+}
+
+// SearchCollectionsSQLWildcard searches collections whose paths match the SQL LIKE pattern
+func SearchCollectionsSQLWildcard(conn *connection.IRODSConnection, pathSqlWildcard string) ([]*types.IRODSCollection, error) {
+	return SearchCollectionsSQLWildcardWithOptions(conn, pathSqlWildcard, nil)
+}
+
+// SearchCollectionsSQLWildcardWithOptions is SearchCollectionsSQLWildcard with search options, nil options are the same as SearchCollectionsSQLWildcard
+func SearchCollectionsSQLWildcardWithOptions(conn *connection.IRODSConnection, pathSqlWildcard string, options *SearchOptions) ([]*types.IRODSCollection, error) {
+	return searchCollectionsSQLWildcard(conn, pathSqlWildcard, nil, options)
+}
+
+func searchCollectionsSQLWildcard(conn *connection.IRODSConnection, pathSqlWildcard string, match func(p string) bool, options *SearchOptions) ([]*types.IRODSCollection, error) {
 	if conn == nil || !conn.IsConnected() {
 		return nil, errors.Errorf("connection is nil or disconnected")
 	}
@@ -428,8 +535,6 @@ func SearchCollectionsUnixWildcard(conn *connection.IRODSConnection, pathUnixWil
 		metrics.IncreaseCounterForList(1)
 	}
 
-	pathSqlWildcard := util.UnixWildcardsToSQLWildcards(pathUnixWildcard)
-
 	// lock the connection
 	conn.Lock()
 	defer conn.Unlock()
@@ -439,13 +544,17 @@ func SearchCollectionsUnixWildcard(conn *connection.IRODSConnection, pathUnixWil
 	continueQuery := true
 	continueIndex := 0
 	for continueQuery {
-		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, 0)
+		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, options.GetQueryOptions())
 		query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
 		query.AddSelect(common.ICAT_COLUMN_COLL_ID)
 		query.AddSelect(common.ICAT_COLUMN_COLL_NAME)
 		query.AddSelect(common.ICAT_COLUMN_COLL_OWNER_NAME)
 		query.AddSelect(common.ICAT_COLUMN_COLL_CREATE_TIME)
 		query.AddSelect(common.ICAT_COLUMN_COLL_MODIFY_TIME)
+		query.AddSelect(common.ICAT_COLUMN_COLL_INHERITANCE)
+		query.AddSelect(common.ICAT_COLUMN_COLL_TYPE)
+		query.AddSelect(common.ICAT_COLUMN_COLL_INFO1)
+		query.AddSelect(common.ICAT_COLUMN_COLL_INFO2)
 
 		query.AddLikeStringCondition(common.ICAT_COLUMN_COLL_NAME, pathSqlWildcard)
 
@@ -462,7 +571,7 @@ func SearchCollectionsUnixWildcard(conn *connection.IRODSConnection, pathUnixWil
 			return nil, errors.Wrapf(err, "failed to receive a collection query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -529,6 +638,16 @@ func SearchCollectionsUnixWildcard(conn *connection.IRODSConnection, pathUnixWil
 						return nil, errors.Wrapf(err, "failed to parse modify time %q", value)
 					}
 					pagenatedCollections[row].ModifyTime = mT
+				case int(common.ICAT_COLUMN_COLL_INHERITANCE):
+					inherit, _ := strconv.ParseBool(value)
+					// if error, assume false
+					pagenatedCollections[row].Inheritance = inherit
+				case int(common.ICAT_COLUMN_COLL_TYPE):
+					pagenatedCollections[row].SpecialType = types.SpecialCollectionType(value)
+				case int(common.ICAT_COLUMN_COLL_INFO1):
+					pagenatedCollections[row].SpecialInfo1 = value
+				case int(common.ICAT_COLUMN_COLL_INFO2):
+					pagenatedCollections[row].SpecialInfo2 = value
 				default:
 					// ignore
 				}
@@ -538,7 +657,7 @@ func SearchCollectionsUnixWildcard(conn *connection.IRODSConnection, pathUnixWil
 		// Filter results by original unix wildcard, since the SQL wildcards
 		// are less strict (e.g. a unix wildcard range is converted to a generic wildcards in SQL).
 		for _, pagenatedCollection := range pagenatedCollections {
-			if fnmatch.Match(pathUnixWildcard, pagenatedCollection.Path, fnmatch.FNM_PATHNAME) {
+			if match == nil || match(pagenatedCollection.Path) {
 				collections = append(collections, pagenatedCollection)
 			}
 		}
@@ -578,6 +697,21 @@ func CreateCollection(conn *connection.IRODSConnection, path string, recurse boo
 
 // DeleteCollection deletes a collection for the path
 func DeleteCollection(conn *connection.IRODSConnection, path string, recurse bool, force bool) error {
+	return deleteCollection(conn, path, recurse, force, false, nil)
+}
+
+// DeleteCollectionWithKeywords deletes a collection for the path with extra keywords, e.g., AGE_KW
+// unregister keeps physical files of the data objects in the collection
+func DeleteCollectionWithKeywords(conn *connection.IRODSConnection, path string, recurse bool, force bool, unregister bool, keywords map[common.KeyWord]string) error {
+	return deleteCollection(conn, path, recurse, force, unregister, keywords)
+}
+
+// UnregisterCollection unregisters a collection for the path from the catalog, physical files are not deleted
+func UnregisterCollection(conn *connection.IRODSConnection, path string, recurse bool) error {
+	return deleteCollection(conn, path, recurse, false, true, nil)
+}
+
+func deleteCollection(conn *connection.IRODSConnection, path string, recurse bool, force bool, unregister bool, keywords map[common.KeyWord]string) error {
 	if conn == nil || !conn.IsConnected() {
 		return errors.Errorf("connection is nil or disconnected")
 	}
@@ -592,6 +726,14 @@ func DeleteCollection(conn *connection.IRODSConnection, path string, recurse boo
 	defer conn.Unlock()
 
 	request := message.NewIRODSMessageRemoveCollectionRequest(path, recurse, force)
+	if unregister {
+		request.SetUnregister()
+	}
+
+	for k, v := range keywords {
+		request.AddKeyVal(k, v)
+	}
+
 	response := message.IRODSMessageRemoveCollectionResponse{}
 	timeout := conn.GetOperationTimeout()
 	if recurse {
@@ -666,7 +808,7 @@ func MoveCollection(conn *connection.IRODSConnection, srcPath string, destPath s
 			return errors.Wrapf(newErr, "failed to find the collection for path %q", srcPath)
 		}
 
-		return errors.Wrapf(err, "received move collection error")
+		return getMoveError(srcPath, destPath, err, "received move collection error")
 	}
 	return nil
 }
@@ -748,6 +890,11 @@ func DeleteCollectionMeta(conn *connection.IRODSConnection, path string, metadat
 
 // SearchCollectionsByMeta searches collections by metadata
 func SearchCollectionsByMeta(conn *connection.IRODSConnection, metaName string, metaValue string) ([]*types.IRODSCollection, error) {
+	return SearchCollectionsByMetaWithOptions(conn, metaName, metaValue, nil)
+}
+
+// SearchCollectionsByMetaWithOptions is SearchCollectionsByMeta with search options, nil options are the same as SearchCollectionsByMeta
+func SearchCollectionsByMetaWithOptions(conn *connection.IRODSConnection, metaName string, metaValue string, options *SearchOptions) ([]*types.IRODSCollection, error) {
 	if conn == nil || !conn.IsConnected() {
 		return nil, errors.Errorf("connection is nil or disconnected")
 	}
@@ -766,13 +913,17 @@ func SearchCollectionsByMeta(conn *connection.IRODSConnection, metaName string,
 	continueQuery := true
 	continueIndex := 0
 	for continueQuery {
-		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, 0)
+		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, options.GetQueryOptions())
 		query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
 		query.AddSelect(common.ICAT_COLUMN_COLL_ID)
 		query.AddSelect(common.ICAT_COLUMN_COLL_NAME)
 		query.AddSelect(common.ICAT_COLUMN_COLL_OWNER_NAME)
 		query.AddSelect(common.ICAT_COLUMN_COLL_CREATE_TIME)
 		query.AddSelect(common.ICAT_COLUMN_COLL_MODIFY_TIME)
+		query.AddSelect(common.ICAT_COLUMN_COLL_INHERITANCE)
+		query.AddSelect(common.ICAT_COLUMN_COLL_TYPE)
+		query.AddSelect(common.ICAT_COLUMN_COLL_INFO1)
+		query.AddSelect(common.ICAT_COLUMN_COLL_INFO2)
 
 		query.AddEqualStringCondition(common.ICAT_COLUMN_META_COLL_ATTR_NAME, metaName)
 		query.AddEqualStringCondition(common.ICAT_COLUMN_META_COLL_ATTR_VALUE, metaValue)
@@ -788,7 +939,7 @@ func SearchCollectionsByMeta(conn *connection.IRODSConnection, metaName string,
 			return nil, errors.Wrapf(err, "failed to receive a collection query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -852,6 +1003,16 @@ func SearchCollectionsByMeta(conn *connection.IRODSConnection, metaName string,
 						return nil, errors.Wrapf(err, "failed to parse modify time %q", value)
 					}
 					pagenatedCollections[row].ModifyTime = mT
+				case int(common.ICAT_COLUMN_COLL_INHERITANCE):
+					inherit, _ := strconv.ParseBool(value)
+					// if error, assume false
+					pagenatedCollections[row].Inheritance = inherit
+				case int(common.ICAT_COLUMN_COLL_TYPE):
+					pagenatedCollections[row].SpecialType = types.SpecialCollectionType(value)
+				case int(common.ICAT_COLUMN_COLL_INFO1):
+					pagenatedCollections[row].SpecialInfo1 = value
+				case int(common.ICAT_COLUMN_COLL_INFO2):
+					pagenatedCollections[row].SpecialInfo2 = value
 				default:
 					// ignore
 				}
@@ -872,6 +1033,11 @@ func SearchCollectionsByMeta(conn *connection.IRODSConnection, metaName string,
 // SearchCollectionsByMetaWildcard searches collections by metadata
 // Caution: This is a very slow operation
 func SearchCollectionsByMetaWildcard(conn *connection.IRODSConnection, metaName string, metaValue string) ([]*types.IRODSCollection, error) {
+	return SearchCollectionsByMetaWildcardWithOptions(conn, metaName, metaValue, nil)
+}
+
+// SearchCollectionsByMetaWildcardWithOptions is SearchCollectionsByMetaWildcard with search options, nil options are the same as SearchCollectionsByMetaWildcard
+func SearchCollectionsByMetaWildcardWithOptions(conn *connection.IRODSConnection, metaName string, metaValue string, options *SearchOptions) ([]*types.IRODSCollection, error) {
 	if conn == nil || !conn.IsConnected() {
 		return nil, errors.Errorf("connection is nil or disconnected")
 	}
@@ -890,13 +1056,17 @@ func SearchCollectionsByMetaWildcard(conn *connection.IRODSConnection, metaName
 	continueQuery := true
 	continueIndex := 0
 	for continueQuery {
-		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, 0)
+		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, options.GetQueryOptions())
 		query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
 		query.AddSelect(common.ICAT_COLUMN_COLL_ID)
 		query.AddSelect(common.ICAT_COLUMN_COLL_NAME)
 		query.AddSelect(common.ICAT_COLUMN_COLL_OWNER_NAME)
 		query.AddSelect(common.ICAT_COLUMN_COLL_CREATE_TIME)
 		query.AddSelect(common.ICAT_COLUMN_COLL_MODIFY_TIME)
+		query.AddSelect(common.ICAT_COLUMN_COLL_INHERITANCE)
+		query.AddSelect(common.ICAT_COLUMN_COLL_TYPE)
+		query.AddSelect(common.ICAT_COLUMN_COLL_INFO1)
+		query.AddSelect(common.ICAT_COLUMN_COLL_INFO2)
 
 		query.AddEqualStringCondition(common.ICAT_COLUMN_META_COLL_ATTR_NAME, metaName)
 		query.AddLikeStringCondition(common.ICAT_COLUMN_META_COLL_ATTR_VALUE, metaValue)
@@ -912,7 +1082,7 @@ func SearchCollectionsByMetaWildcard(conn *connection.IRODSConnection, metaName
 			return nil, errors.Wrapf(err, "failed to receive a collection query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -982,6 +1152,16 @@ func SearchCollectionsByMetaWildcard(conn *connection.IRODSConnection, metaName
 							return nil, errors.Wrapf(err, "failed to parse modify time %q", value)
 						}
 						pagenatedCollections[row].ModifyTime = mT
+					case int(common.ICAT_COLUMN_COLL_INHERITANCE):
+						inherit, _ := strconv.ParseBool(value)
+						// if error, assume false
+						pagenatedCollections[row].Inheritance = inherit
+					case int(common.ICAT_COLUMN_COLL_TYPE):
+						pagenatedCollections[row].SpecialType = types.SpecialCollectionType(value)
+					case int(common.ICAT_COLUMN_COLL_INFO1):
+						pagenatedCollections[row].SpecialInfo1 = value
+					case int(common.ICAT_COLUMN_COLL_INFO2):
+						pagenatedCollections[row].SpecialInfo2 = value
 					default:
 						// ignore
 					}
@@ -1049,7 +1229,7 @@ func GetCollectionStat(conn *connection.IRODSConnection, collPath string, recurs
 			return nil, errors.Wrapf(err, "failed to receive a data object query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -1120,7 +1300,7 @@ func GetCollectionStat(conn *connection.IRODSConnection, collPath string, recurs
 			query.AddSelectWithSum(common.ICAT_COLUMN_DATA_SIZE)
 
 			query.AddEqualStringCondition(common.ICAT_COLUMN_DATA_REPL_NUM, "0")
-			query.AddLikeStringCondition(common.ICAT_COLUMN_COLL_NAME, collPath+"/%")
+			addSubCollectionCondition(query, collPath)
 
 			// query.AddLikeStringCondition(common.ICAT_COLUMN_COLL_NAME, collPath+"/")
 
@@ -1138,7 +1318,7 @@ func GetCollectionStat(conn *connection.IRODSConnection, collPath string, recurs
 				return nil, errors.Wrapf(err, "failed to receive a data object query result message")
 			}
 
-			err = queryResult.CheckError()
+			err = conn.CheckResponseError(query, &queryResult)
 			if err != nil {
 				if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 					// empty
@@ -1198,5 +1378,80 @@ func GetCollectionStat(conn *connection.IRODSConnection, collPath string, recurs
 		}
 	}
 
+	collCount, err := getSubCollectionCount(conn, collPath, recurse)
+	if err != nil {
+		return nil, err
+	}
+
+	stat.CollectionCount = collCount
+
 	return &stat, nil
 }
+
+// addSubCollectionCondition adds a condition matching all sub-collections of the collection
+func addSubCollectionCondition(query *message.IRODSMessageQueryRequest, collPath string) {
+	if collPath == "/" {
+		// must not match the root itself
+		query.AddLikeStringCondition(common.ICAT_COLUMN_COLL_NAME, "/_%")
+		return
+	}
+	query.AddLikePrefixStringCondition(common.ICAT_COLUMN_COLL_NAME, collPath+"/", "%")
+}
+
+// getSubCollectionCount returns the number of sub-collections using COUNT aggregate, connection must be locked
+func getSubCollectionCount(conn *connection.IRODSConnection, collPath string, recurse bool) (int64, error) {
+	query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, 0, 0, 0)
+	query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
+	query.AddSelectWithCount(common.ICAT_COLUMN_COLL_ID)
+
+	if recurse {
+		addSubCollectionCondition(query, collPath)
+	} else {
+		query.AddEqualStringCondition(common.ICAT_COLUMN_COLL_PARENT_NAME, collPath)
+		if collPath == "/" {
+			// root is a child of itself
+			query.AddCondition(common.ICAT_COLUMN_COLL_NAME, "<> '/'")
+		}
+	}
+
+	queryResult := message.IRODSMessageQueryResponse{}
+	err := conn.Request(query, &queryResult, nil, conn.GetLongResponseOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+			return 0, nil
+		}
+
+		return 0, errors.Wrapf(err, "failed to receive a collection query result message")
+	}
+
+	err = conn.CheckResponseError(query, &queryResult)
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+			return 0, nil
+		}
+
+		return 0, errors.Wrapf(err, "received collection query error")
+	}
+
+	var collCount int64
+	for attr := 0; attr < queryResult.AttributeCount && attr < len(queryResult.SQLResult); attr++ {
+		sqlResult := queryResult.SQLResult[attr]
+		if sqlResult.AttributeIndex != int(common.ICAT_COLUMN_COLL_ID) {
+			continue
+		}
+
+		for _, value := range sqlResult.Values {
+			if len(value) == 0 {
+				continue
+			}
+
+			count, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return 0, errors.Wrapf(err, "failed to parse collection count %q", value)
+			}
+			collCount += count
+		}
+	}
+
+	return collCount, nil
+}
@@ -92,7 +92,7 @@ func GetDataObject(conn *connection.IRODSConnection, dataObjPath string) (*types
 		query.AddSelect(common.ICAT_COLUMN_D_CREATE_TIME)
 		query.AddSelect(common.ICAT_COLUMN_D_MODIFY_TIME)
 
-		if conn.GetVersion().HasHigherVersionThan(5, 0, 0) {
+		if conn.GetServerCapabilities().DataObjectAccessTime {
 			query.AddSelect(common.ICAT_COLUMN_D_ACCESS_TIME)
 		}
 
@@ -113,7 +113,7 @@ func GetDataObject(conn *connection.IRODSConnection, dataObjPath string) (*types
 			return nil, errors.Wrapf(err, "failed to receive a data object query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
 				newErr := errors.Join(err, types.NewFileNotFoundError(dataObjPath))
@@ -313,7 +313,7 @@ func GetDataObjectMasterReplica(conn *connection.IRODSConnection, dataObjPath st
 		query.AddSelect(common.ICAT_COLUMN_D_CREATE_TIME)
 		query.AddSelect(common.ICAT_COLUMN_D_MODIFY_TIME)
 
-		if conn.GetVersion().HasHigherVersionThan(5, 0, 0) {
+		if conn.GetServerCapabilities().DataObjectAccessTime {
 			query.AddSelect(common.ICAT_COLUMN_D_ACCESS_TIME)
 		}
 
@@ -335,7 +335,7 @@ func GetDataObjectMasterReplica(conn *connection.IRODSConnection, dataObjPath st
 			return nil, errors.Wrapf(err, "failed to receive a data object query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
 				newErr := errors.Join(err, types.NewFileNotFoundError(dataObjPath))
@@ -521,181 +521,19 @@ func ListDataObjects(conn *connection.IRODSConnection, collPath string) ([]*type
 
 	dataObjects := []*types.IRODSDataObject{}
 
-	continueQuery := true
 	continueIndex := 0
-	for continueQuery {
-		// data object
-		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, 0)
-		query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
-		query.AddSelect(common.ICAT_COLUMN_D_DATA_ID)
-		query.AddSelect(common.ICAT_COLUMN_DATA_NAME)
-		query.AddSelect(common.ICAT_COLUMN_DATA_SIZE)
-		query.AddSelect(common.ICAT_COLUMN_DATA_TYPE_NAME)
-
-		// replica
-		query.AddSelect(common.ICAT_COLUMN_DATA_REPL_NUM)
-		query.AddSelect(common.ICAT_COLUMN_D_OWNER_NAME)
-		query.AddSelect(common.ICAT_COLUMN_D_DATA_CHECKSUM)
-		query.AddSelect(common.ICAT_COLUMN_D_REPL_STATUS)
-		query.AddSelect(common.ICAT_COLUMN_D_RESC_NAME)
-		query.AddSelect(common.ICAT_COLUMN_D_DATA_PATH)
-		query.AddSelect(common.ICAT_COLUMN_D_RESC_HIER)
-		query.AddSelect(common.ICAT_COLUMN_D_CREATE_TIME)
-		query.AddSelect(common.ICAT_COLUMN_D_MODIFY_TIME)
-
-		if conn.GetVersion().HasHigherVersionThan(5, 0, 0) {
-			query.AddSelect(common.ICAT_COLUMN_D_ACCESS_TIME)
-		}
-
-		query.AddEqualStringCondition(common.ICAT_COLUMN_COLL_NAME, collPath)
-
-		queryResult := message.IRODSMessageQueryResponse{}
-		err := conn.Request(query, &queryResult, nil, conn.GetLongResponseOperationTimeout())
-		if err != nil {
-			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
-				// empty
-				break
-			} else if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
-				newErr := errors.Join(err, types.NewFileNotFoundError(collPath))
-				return nil, errors.Wrapf(newErr, "failed to find the collection for path %q", collPath)
-			}
-
-			return nil, errors.Wrapf(err, "failed to receive a data object query result message")
-		}
-
-		err = queryResult.CheckError()
+	for {
+		pagenatedDataObjects, nextContinueIndex, err := listDataObjectsPage(conn, collPath, continueIndex, common.MaxQueryRows, nil)
 		if err != nil {
-			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
-				// empty
-				break
-			} else if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
-				newErr := errors.Join(err, types.NewFileNotFoundError(collPath))
-				return nil, errors.Wrapf(newErr, "failed to find the collection for path %q", collPath)
-			}
-
-			return nil, errors.Wrapf(err, "received data object query error")
-		}
-
-		if queryResult.RowCount == 0 {
-			break
-		}
-
-		if queryResult.AttributeCount > len(queryResult.SQLResult) {
-			return nil, errors.Errorf("failed to receive data object attributes - requires %d, but received %d attributes", queryResult.AttributeCount, len(queryResult.SQLResult))
-		}
-
-		pagenatedDataObjects := make([]*types.IRODSDataObject, queryResult.RowCount)
-
-		for attr := 0; attr < queryResult.AttributeCount; attr++ {
-			sqlResult := queryResult.SQLResult[attr]
-			if len(sqlResult.Values) != queryResult.RowCount {
-				return nil, errors.Errorf("failed to receive data object rows - requires %d, but received %d attributes", queryResult.RowCount, len(sqlResult.Values))
-			}
-
-			for row := 0; row < queryResult.RowCount; row++ {
-				value := sqlResult.Values[row]
-
-				if pagenatedDataObjects[row] == nil {
-					// create a new
-					replica := &types.IRODSReplica{
-						Number:            -1,
-						Owner:             "",
-						Checksum:          nil,
-						Status:            "",
-						ResourceName:      "",
-						Path:              "",
-						ResourceHierarchy: "",
-						CreateTime:        time.Time{},
-						ModifyTime:        time.Time{},
-						AccessTime:        time.Time{},
-					}
-
-					pagenatedDataObjects[row] = &types.IRODSDataObject{
-						ID:           -1,
-						CollectionID: -1,
-						Path:         "",
-						Name:         "",
-						Size:         0,
-						DataType:     "",
-						Replicas:     []*types.IRODSReplica{replica},
-					}
-				}
-
-				switch sqlResult.AttributeIndex {
-				case int(common.ICAT_COLUMN_D_DATA_ID):
-					objID, err := strconv.ParseInt(value, 10, 64)
-					if err != nil {
-						return nil, errors.Wrapf(err, "failed to parse data object id %q", value)
-					}
-					pagenatedDataObjects[row].ID = objID
-				case int(common.ICAT_COLUMN_DATA_NAME):
-					pagenatedDataObjects[row].Path = util.MakeIRODSPath(collPath, value)
-					pagenatedDataObjects[row].Name = value
-				case int(common.ICAT_COLUMN_DATA_SIZE):
-					objSize, err := strconv.ParseInt(value, 10, 64)
-					if err != nil {
-						return nil, errors.Wrapf(err, "failed to parse data object size %q", value)
-					}
-					pagenatedDataObjects[row].Size = objSize
-				case int(common.ICAT_COLUMN_DATA_TYPE_NAME):
-					pagenatedDataObjects[row].DataType = value
-				case int(common.ICAT_COLUMN_DATA_REPL_NUM):
-					repNum, err := strconv.ParseInt(value, 10, 64)
-					if err != nil {
-						return nil, errors.Wrapf(err, "failed to parse data object replica number %q", value)
-					}
-					pagenatedDataObjects[row].Replicas[0].Number = repNum
-				case int(common.ICAT_COLUMN_D_OWNER_NAME):
-					pagenatedDataObjects[row].Replicas[0].Owner = value
-				case int(common.ICAT_COLUMN_D_DATA_CHECKSUM):
-					checksum, err := types.CreateIRODSChecksum(value)
-					if err != nil {
-						return nil, errors.Wrapf(err, "failed to parse data object checksum %q", value)
-					}
-					pagenatedDataObjects[row].Replicas[0].Checksum = checksum
-				case int(common.ICAT_COLUMN_D_REPL_STATUS):
-					pagenatedDataObjects[row].Replicas[0].Status = value
-				case int(common.ICAT_COLUMN_D_RESC_NAME):
-					pagenatedDataObjects[row].Replicas[0].ResourceName = value
-				case int(common.ICAT_COLUMN_D_DATA_PATH):
-					pagenatedDataObjects[row].Replicas[0].Path = value
-				case int(common.ICAT_COLUMN_D_RESC_HIER):
-					pagenatedDataObjects[row].Replicas[0].ResourceHierarchy = value
-				case int(common.ICAT_COLUMN_D_CREATE_TIME):
-					cT, err := util.GetIRODSDateTime(value)
-					if err != nil {
-						return nil, errors.Wrapf(err, "failed to parse create time %q", value)
-					}
-					pagenatedDataObjects[row].Replicas[0].CreateTime = cT
-				case int(common.ICAT_COLUMN_D_MODIFY_TIME):
-					mT, err := util.GetIRODSDateTime(value)
-					if err != nil {
-						return nil, errors.Wrapf(err, "failed to parse modify time %q", value)
-					}
-					pagenatedDataObjects[row].Replicas[0].ModifyTime = mT
-
-					if pagenatedDataObjects[row].Replicas[0].AccessTime.IsZero() {
-						// if access time is not set, set it to modify time
-						pagenatedDataObjects[row].Replicas[0].AccessTime = mT
-					}
-				case int(common.ICAT_COLUMN_D_ACCESS_TIME):
-					aT, err := util.GetIRODSDateTime(value)
-					if err != nil {
-						return nil, errors.Wrapf(err, "failed to parse access time %q", value)
-					}
-					pagenatedDataObjects[row].Replicas[0].AccessTime = aT
-				default:
-					// ignore
-				}
-			}
+			return nil, err
 		}
 
 		dataObjects = append(dataObjects, pagenatedDataObjects...)
 
-		continueIndex = queryResult.ContinueIndex
-		if continueIndex == 0 {
-			continueQuery = false
+		if nextContinueIndex == 0 {
+			break
 		}
+		continueIndex = nextContinueIndex
 	}
 
 	// merge data objects per file
@@ -720,6 +558,219 @@ func ListDataObjects(conn *connection.IRODSConnection, collPath string) ([]*type
 	return mergedDataObjects, nil
 }
 
+// ListDataObjectsPage lists a page of data objects in the given collection, up to maxRows replicas
+// each returned data object has one replica, rows are ordered by data object ID so replicas of a data object are adjacent,
+// but they may be split across pages
+// continueIndex is 0 for the first page, returned continue index is 0 after the last page
+// call CloseQuery with the returned continue index to stop listing before the last page
+// options must be the same for all pages of a listing, nil lists all data objects in the server order
+func ListDataObjectsPage(conn *connection.IRODSConnection, collPath string, continueIndex int, maxRows int, options *ListQueryOptions) ([]*types.IRODSDataObject, int, error) {
+	if conn == nil || !conn.IsConnected() {
+		return nil, 0, errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil && continueIndex == 0 {
+		metrics.IncreaseCounterForList(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	if options != nil {
+		err := options.Validate()
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return listDataObjectsPage(conn, collPath, continueIndex, maxRows, options)
+}
+
+func listDataObjectsPage(conn *connection.IRODSConnection, collPath string, continueIndex int, maxRows int, options *ListQueryOptions) ([]*types.IRODSDataObject, int, error) {
+	// data object
+	query := message.NewIRODSMessageQueryRequest(maxRows, continueIndex, 0, 0)
+	query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
+	columns := []common.ICATColumnNumber{
+		common.ICAT_COLUMN_D_DATA_ID,
+		common.ICAT_COLUMN_DATA_NAME,
+		common.ICAT_COLUMN_DATA_SIZE,
+		common.ICAT_COLUMN_DATA_TYPE_NAME,
+
+		// replica
+		common.ICAT_COLUMN_DATA_REPL_NUM,
+		common.ICAT_COLUMN_D_OWNER_NAME,
+		common.ICAT_COLUMN_D_DATA_CHECKSUM,
+		common.ICAT_COLUMN_D_REPL_STATUS,
+		common.ICAT_COLUMN_D_RESC_NAME,
+		common.ICAT_COLUMN_D_DATA_PATH,
+		common.ICAT_COLUMN_D_RESC_HIER,
+		common.ICAT_COLUMN_D_CREATE_TIME,
+		common.ICAT_COLUMN_D_MODIFY_TIME,
+	}
+
+	if conn.GetServerCapabilities().DataObjectAccessTime {
+		columns = append(columns, common.ICAT_COLUMN_D_ACCESS_TIME)
+	}
+
+	if options != nil {
+		// data object ID keeps replicas of a data object adjacent
+		addSortedSelects(query, columns, options.getDataObjectSortColumn(), options.SortDescending, common.ICAT_COLUMN_D_DATA_ID)
+
+		if len(options.NamePattern) > 0 {
+			query.AddLikeStringCondition(common.ICAT_COLUMN_DATA_NAME, options.NamePattern)
+		}
+	} else {
+		addSortedSelects(query, columns, 0, false, 0)
+	}
+
+	query.AddEqualStringCondition(common.ICAT_COLUMN_COLL_NAME, collPath)
+
+	queryResult := message.IRODSMessageQueryResponse{}
+	err := conn.Request(query, &queryResult, nil, conn.GetLongResponseOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+			// empty
+			return []*types.IRODSDataObject{}, 0, nil
+		} else if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
+			newErr := errors.Join(err, types.NewFileNotFoundError(collPath))
+			return nil, 0, errors.Wrapf(newErr, "failed to find the collection for path %q", collPath)
+		}
+
+		return nil, 0, errors.Wrapf(err, "failed to receive a data object query result message")
+	}
+
+	err = conn.CheckResponseError(query, &queryResult)
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+			// empty
+			return []*types.IRODSDataObject{}, 0, nil
+		} else if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
+			newErr := errors.Join(err, types.NewFileNotFoundError(collPath))
+			return nil, 0, errors.Wrapf(newErr, "failed to find the collection for path %q", collPath)
+		}
+
+		return nil, 0, errors.Wrapf(err, "received data object query error")
+	}
+
+	if queryResult.RowCount == 0 {
+		return []*types.IRODSDataObject{}, 0, nil
+	}
+
+	if queryResult.AttributeCount > len(queryResult.SQLResult) {
+		return nil, 0, errors.Errorf("failed to receive data object attributes - requires %d, but received %d attributes", queryResult.AttributeCount, len(queryResult.SQLResult))
+	}
+
+	pagenatedDataObjects := make([]*types.IRODSDataObject, queryResult.RowCount)
+
+	for attr := 0; attr < queryResult.AttributeCount; attr++ {
+		sqlResult := queryResult.SQLResult[attr]
+		if len(sqlResult.Values) != queryResult.RowCount {
+			return nil, 0, errors.Errorf("failed to receive data object rows - requires %d, but received %d attributes", queryResult.RowCount, len(sqlResult.Values))
+		}
+
+		for row := 0; row < queryResult.RowCount; row++ {
+			value := sqlResult.Values[row]
+
+			if pagenatedDataObjects[row] == nil {
+				// create a new
+				replica := &types.IRODSReplica{
+					Number:            -1,
+					Owner:             "",
+					Checksum:          nil,
+					Status:            "",
+					ResourceName:      "",
+					Path:              "",
+					ResourceHierarchy: "",
+					CreateTime:        time.Time{},
+					ModifyTime:        time.Time{},
+					AccessTime:        time.Time{},
+				}
+
+				pagenatedDataObjects[row] = &types.IRODSDataObject{
+					ID:           -1,
+					CollectionID: -1,
+					Path:         "",
+					Name:         "",
+					Size:         0,
+					DataType:     "",
+					Replicas:     []*types.IRODSReplica{replica},
+				}
+			}
+
+			switch sqlResult.AttributeIndex {
+			case int(common.ICAT_COLUMN_D_DATA_ID):
+				objID, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					return nil, 0, errors.Wrapf(err, "failed to parse data object id %q", value)
+				}
+				pagenatedDataObjects[row].ID = objID
+			case int(common.ICAT_COLUMN_DATA_NAME):
+				pagenatedDataObjects[row].Path = util.MakeIRODSPath(collPath, value)
+				pagenatedDataObjects[row].Name = value
+			case int(common.ICAT_COLUMN_DATA_SIZE):
+				objSize, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					return nil, 0, errors.Wrapf(err, "failed to parse data object size %q", value)
+				}
+				pagenatedDataObjects[row].Size = objSize
+			case int(common.ICAT_COLUMN_DATA_TYPE_NAME):
+				pagenatedDataObjects[row].DataType = value
+			case int(common.ICAT_COLUMN_DATA_REPL_NUM):
+				repNum, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					return nil, 0, errors.Wrapf(err, "failed to parse data object replica number %q", value)
+				}
+				pagenatedDataObjects[row].Replicas[0].Number = repNum
+			case int(common.ICAT_COLUMN_D_OWNER_NAME):
+				pagenatedDataObjects[row].Replicas[0].Owner = value
+			case int(common.ICAT_COLUMN_D_DATA_CHECKSUM):
+				checksum, err := types.CreateIRODSChecksum(value)
+				if err != nil {
+					return nil, 0, errors.Wrapf(err, "failed to parse data object checksum %q", value)
+				}
+				pagenatedDataObjects[row].Replicas[0].Checksum = checksum
+			case int(common.ICAT_COLUMN_D_REPL_STATUS):
+				pagenatedDataObjects[row].Replicas[0].Status = value
+			case int(common.ICAT_COLUMN_D_RESC_NAME):
+				pagenatedDataObjects[row].Replicas[0].ResourceName = value
+			case int(common.ICAT_COLUMN_D_DATA_PATH):
+				pagenatedDataObjects[row].Replicas[0].Path = value
+			case int(common.ICAT_COLUMN_D_RESC_HIER):
+				pagenatedDataObjects[row].Replicas[0].ResourceHierarchy = value
+			case int(common.ICAT_COLUMN_D_CREATE_TIME):
+				cT, err := util.GetIRODSDateTime(value)
+				if err != nil {
+					return nil, 0, errors.Wrapf(err, "failed to parse create time %q", value)
+				}
+				pagenatedDataObjects[row].Replicas[0].CreateTime = cT
+			case int(common.ICAT_COLUMN_D_MODIFY_TIME):
+				mT, err := util.GetIRODSDateTime(value)
+				if err != nil {
+					return nil, 0, errors.Wrapf(err, "failed to parse modify time %q", value)
+				}
+				pagenatedDataObjects[row].Replicas[0].ModifyTime = mT
+
+				if pagenatedDataObjects[row].Replicas[0].AccessTime.IsZero() {
+					// if access time is not set, set it to modify time
+					pagenatedDataObjects[row].Replicas[0].AccessTime = mT
+				}
+			case int(common.ICAT_COLUMN_D_ACCESS_TIME):
+				aT, err := util.GetIRODSDateTime(value)
+				if err != nil {
+					return nil, 0, errors.Wrapf(err, "failed to parse access time %q", value)
+				}
+				pagenatedDataObjects[row].Replicas[0].AccessTime = aT
+			default:
+				// ignore
+			}
+		}
+	}
+
+	return pagenatedDataObjects, queryResult.ContinueIndex, nil
+}
+
 // ListDataObjectsMasterReplica lists data objects in the given collection, returns only master replica
 func ListDataObjectsMasterReplica(conn *connection.IRODSConnection, collPath string) ([]*types.IRODSDataObject, error) {
 	if conn == nil || !conn.IsConnected() {
@@ -759,7 +810,7 @@ func ListDataObjectsMasterReplica(conn *connection.IRODSConnection, collPath str
 		query.AddSelect(common.ICAT_COLUMN_D_CREATE_TIME)
 		query.AddSelect(common.ICAT_COLUMN_D_MODIFY_TIME)
 
-		if conn.GetVersion().HasHigherVersionThan(5, 0, 0) {
+		if conn.GetServerCapabilities().DataObjectAccessTime {
 			query.AddSelect(common.ICAT_COLUMN_D_ACCESS_TIME)
 		}
 
@@ -780,7 +831,7 @@ func ListDataObjectsMasterReplica(conn *connection.IRODSConnection, collPath str
 			return nil, errors.Wrapf(err, "failed to receive a data object query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -948,6 +999,31 @@ func ListDataObjectsMasterReplica(conn *connection.IRODSConnection, collPath str
 
 // SearchDataObjectsUnixWildcard searches data objects in the given collection using unix-style wildcard
 func SearchDataObjectsUnixWildcard(conn *connection.IRODSConnection, pathUnixWildcard string) ([]*types.IRODSDataObject, error) {
+	return SearchDataObjectsUnixWildcardWithOptions(conn, pathUnixWildcard, nil)
+}
+
+// SearchDataObjectsUnixWildcardWithOptions is SearchDataObjectsUnixWildcard with search options, nil options are the same as SearchDataObjectsUnixWildcard
+func SearchDataObjectsUnixWildcardWithOptions(conn *connection.IRODSConnection, pathUnixWildcard string, options *SearchOptions) ([]*types.IRODSDataObject, error) {
+	pathSqlWildcard := util.UnixWildcardsToSQLWildcards(pathUnixWildcard)
+	basenameSqlWildcard := path.Base(pathSqlWildcard)
+	dirnameSqlWildcard := path.Dir(pathSqlWildcard)
+
+	return searchDataObjectsSQLWildcard(conn, dirnameSqlWildcard, basenameSqlWildcard, func(p string) bool {
+		return fnmatch.Match(pathUnixWildcard, p, options.getFnmatchFlags())
+	}, options)
+}
+
+// SearchDataObjectsSQLWildcard searches data objects whose collection paths and names match the SQL LIKE patterns
+func SearchDataObjectsSQLWildcard(conn *connection.IRODSConnection, dirnameSqlWildcard string, basenameSqlWildcard string) ([]*types.IRODSDataObject, error) {
+	return SearchDataObjectsSQLWildcardWithOptions(conn, dirnameSqlWildcard, basenameSqlWildcard, nil)
+}
+
+// SearchDataObjectsSQLWildcardWithOptions is SearchDataObjectsSQLWildcard with search options, nil options are the same as SearchDataObjectsSQLWildcard
+func SearchDataObjectsSQLWildcardWithOptions(conn *connection.IRODSConnection, dirnameSqlWildcard string, basenameSqlWildcard string, options *SearchOptions) ([]*types.IRODSDataObject, error) {
+	return searchDataObjectsSQLWildcard(conn, dirnameSqlWildcard, basenameSqlWildcard, nil, options)
+}
+
+func searchDataObjectsSQLWildcard(conn *connection.IRODSConnection, dirnameSqlWildcard string, basenameSqlWildcard string, match func(p string) bool, options *SearchOptions) ([]*types.IRODSDataObject, error) {
 	if conn == nil || !conn.IsConnected() {
 		return nil, errors.Errorf("connection is nil or disconnected")
 	}
@@ -957,10 +1033,6 @@ func SearchDataObjectsUnixWildcard(conn *connection.IRODSConnection, pathUnixWil
 		metrics.IncreaseCounterForList(1)
 	}
 
-	pathSqlWildcard := util.UnixWildcardsToSQLWildcards(pathUnixWildcard)
-	basenameSqlWildcard := path.Base(pathSqlWildcard)
-	dirnameSqlWildcard := path.Dir(pathSqlWildcard)
-
 	// lock the connection
 	conn.Lock()
 	defer conn.Unlock()
@@ -971,7 +1043,7 @@ func SearchDataObjectsUnixWildcard(conn *connection.IRODSConnection, pathUnixWil
 	continueIndex := 0
 	for continueQuery {
 		// data object
-		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, 0)
+		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, options.GetQueryOptions())
 		query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
 		query.AddSelect(common.ICAT_COLUMN_D_DATA_ID)
 		query.AddSelect(common.ICAT_COLUMN_D_COLL_ID)
@@ -991,7 +1063,7 @@ func SearchDataObjectsUnixWildcard(conn *connection.IRODSConnection, pathUnixWil
 		query.AddSelect(common.ICAT_COLUMN_D_CREATE_TIME)
 		query.AddSelect(common.ICAT_COLUMN_D_MODIFY_TIME)
 
-		if conn.GetVersion().HasHigherVersionThan(5, 0, 0) {
+		if conn.GetServerCapabilities().DataObjectAccessTime {
 			query.AddSelect(common.ICAT_COLUMN_D_ACCESS_TIME)
 		}
 
@@ -1011,7 +1083,7 @@ func SearchDataObjectsUnixWildcard(conn *connection.IRODSConnection, pathUnixWil
 			return nil, errors.Wrapf(err, "failed to receive a data object query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -1154,7 +1226,7 @@ func SearchDataObjectsUnixWildcard(conn *connection.IRODSConnection, pathUnixWil
 		// Filter results by original unix wildcard, since the SQL wildcards
 		// are less strict (e.g. a unix wildcard range is converted to a generic wildcards in SQL).
 		for _, pagenatedDataObject := range pagenatedDataObjects {
-			if fnmatch.Match(pathUnixWildcard, pagenatedDataObject.Path, fnmatch.FNM_PATHNAME) {
+			if match == nil || match(pagenatedDataObject.Path) {
 				dataObjects = append(dataObjects, pagenatedDataObject)
 			}
 		}
@@ -1189,6 +1261,11 @@ func SearchDataObjectsUnixWildcard(conn *connection.IRODSConnection, pathUnixWil
 
 // SearchDataObjectsMasterReplicaUnixWildcard searches data objects in the given collection using unix-style wildcard, returns only master replica
 func SearchDataObjectsMasterReplicaUnixWildcard(conn *connection.IRODSConnection, pathUnixWildcard string) ([]*types.IRODSDataObject, error) {
+	return SearchDataObjectsMasterReplicaUnixWildcardWithOptions(conn, pathUnixWildcard, nil)
+}
+
+// SearchDataObjectsMasterReplicaUnixWildcardWithOptions is SearchDataObjectsMasterReplicaUnixWildcard with search options, nil options are the same as SearchDataObjectsMasterReplicaUnixWildcard
+func SearchDataObjectsMasterReplicaUnixWildcardWithOptions(conn *connection.IRODSConnection, pathUnixWildcard string, options *SearchOptions) ([]*types.IRODSDataObject, error) {
 	if conn == nil || !conn.IsConnected() {
 		return nil, errors.Errorf("connection is nil or disconnected")
 	}
@@ -1212,7 +1289,7 @@ func SearchDataObjectsMasterReplicaUnixWildcard(conn *connection.IRODSConnection
 	continueIndex := 0
 	for continueQuery {
 		// data object
-		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, 0)
+		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, options.GetQueryOptions())
 		query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
 		query.AddSelect(common.ICAT_COLUMN_D_DATA_ID)
 		query.AddSelect(common.ICAT_COLUMN_D_COLL_ID)
@@ -1232,7 +1309,7 @@ func SearchDataObjectsMasterReplicaUnixWildcard(conn *connection.IRODSConnection
 		query.AddSelect(common.ICAT_COLUMN_D_CREATE_TIME)
 		query.AddSelect(common.ICAT_COLUMN_D_MODIFY_TIME)
 
-		if conn.GetVersion().HasHigherVersionThan(5, 0, 0) {
+		if conn.GetServerCapabilities().DataObjectAccessTime {
 			query.AddSelect(common.ICAT_COLUMN_D_ACCESS_TIME)
 		}
 
@@ -1253,7 +1330,7 @@ func SearchDataObjectsMasterReplicaUnixWildcard(conn *connection.IRODSConnection
 			return nil, errors.Wrapf(err, "failed to receive a data object query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -1396,7 +1473,7 @@ func SearchDataObjectsMasterReplicaUnixWildcard(conn *connection.IRODSConnection
 		// Filter results by original unix wildcard, since the SQL wildcards
 		// are less strict (e.g. a unix wildcard range is converted to a generic wildcards in SQL).
 		for _, pagenatedDataObject := range pagenatedDataObjects {
-			if fnmatch.Match(pathUnixWildcard, pagenatedDataObject.Path, fnmatch.FNM_PATHNAME) {
+			if fnmatch.Match(pathUnixWildcard, pagenatedDataObject.Path, options.getFnmatchFlags()) {
 				dataObjects = append(dataObjects, pagenatedDataObject)
 			}
 		}
@@ -1484,7 +1561,7 @@ func ListDataObjectMeta(conn *connection.IRODSConnection, dataObjPath string) ([
 			return nil, errors.Wrapf(err, "failed to receive a data object metadata query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -1572,6 +1649,16 @@ func ListDataObjectMeta(conn *connection.IRODSConnection, dataObjPath string) ([
 
 // DeleteDataObject deletes a data object for the path
 func DeleteDataObject(conn *connection.IRODSConnection, path string, force bool) error {
+	return deleteDataObject(conn, path, force, false, nil)
+}
+
+// DeleteDataObjectWithKeywords deletes a data object for the path with extra keywords, e.g., REPL_NUM_KW, AGE_KW
+// unregister keeps the physical file
+func DeleteDataObjectWithKeywords(conn *connection.IRODSConnection, path string, force bool, unregister bool, keywords map[common.KeyWord]string) error {
+	return deleteDataObject(conn, path, force, unregister, keywords)
+}
+
+func deleteDataObject(conn *connection.IRODSConnection, path string, force bool, unregister bool, keywords map[common.KeyWord]string) error {
 	if conn == nil || !conn.IsConnected() {
 		return errors.Errorf("connection is nil or disconnected")
 	}
@@ -1586,6 +1673,14 @@ func DeleteDataObject(conn *connection.IRODSConnection, path string, force bool)
 	defer conn.Unlock()
 
 	request := message.NewIRODSMessageRemoveDataObjectRequest(path, force)
+	if unregister {
+		request.SetUnregister()
+	}
+
+	for k, v := range keywords {
+		request.AddKeyVal(k, v)
+	}
+
 	response := message.IRODSMessageRemoveDataObjectResponse{}
 	err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
 	if err != nil {
@@ -1601,6 +1696,9 @@ func DeleteDataObject(conn *connection.IRODSConnection, path string, force bool)
 			return errors.Wrapf(newErr, "failed to find the collection for path %q", path)
 		}
 
+		if unregister {
+			return errors.Wrapf(err, "failed to unregister data object")
+		}
 		return errors.Wrapf(err, "failed to delete data object")
 	}
 	return nil
@@ -1608,6 +1706,11 @@ func DeleteDataObject(conn *connection.IRODSConnection, path string, force bool)
 
 // MoveDataObject moves a data object for the path to another path
 func MoveDataObject(conn *connection.IRODSConnection, srcPath string, destPath string) error {
+	return MoveDataObjectWithForce(conn, srcPath, destPath, false)
+}
+
+// MoveDataObjectWithForce moves a data object for the path to another path, an existing data object at destPath is replaced if force is set
+func MoveDataObjectWithForce(conn *connection.IRODSConnection, srcPath string, destPath string, force bool) error {
 	if conn == nil || !conn.IsConnected() {
 		return errors.Errorf("connection is nil or disconnected")
 	}
@@ -1622,6 +1725,10 @@ func MoveDataObject(conn *connection.IRODSConnection, srcPath string, destPath s
 	defer conn.Unlock()
 
 	request := message.NewIRODSMessageMoveDataObjectRequest(srcPath, destPath)
+	if force {
+		request.Paths[1].KeyVals.Add(string(common.FORCE_FLAG_KW), "")
+	}
+
 	response := message.IRODSMessageMoveDataObjectResponse{}
 	err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
 	if err != nil {
@@ -1633,45 +1740,140 @@ func MoveDataObject(conn *connection.IRODSConnection, srcPath string, destPath s
 			return errors.Wrapf(newErr, "failed to find the collection for path %q", srcPath)
 		}
 
-		return errors.Wrapf(err, "failed to move data object")
+		return getMoveError(srcPath, destPath, err, "failed to move data object")
 	}
 	return nil
 }
 
+// getMoveError returns typed errors for destination conflicts of rename
+func getMoveError(srcPath string, destPath string, err error, message string) error {
+	switch types.GetIRODSErrorCode(err) {
+	case common.CATALOG_ALREADY_HAS_ITEM_BY_THAT_NAME, common.OVERWRITE_WITHOUT_FORCE_FLAG, common.CAT_NAME_EXISTS_AS_DATAOBJ, common.CAT_NAME_EXISTS_AS_COLLECTION:
+		newErr := errors.Join(err, types.NewFileAlreadyExistError(destPath))
+		return errors.Wrapf(newErr, "data object or collection for path %q already exists", destPath)
+	case common.SYS_CROSS_ZONE_MV_NOT_SUPPORTED:
+		newErr := errors.Join(err, types.NewCrossCollectionRenameError(srcPath, destPath, "moving across zones is not supported"))
+		return errors.Wrapf(newErr, "failed to move %q to another zone", srcPath)
+	}
+
+	return errors.Wrapf(err, "%s", message)
+}
+
 // CopyDataObject creates a copy of a data object for the path
 func CopyDataObject(conn *connection.IRODSConnection, srcPath string, destPath string, force bool) error {
 	if conn == nil || !conn.IsConnected() {
 		return errors.Errorf("connection is nil or disconnected")
 	}
 
-	metrics := conn.GetMetrics()
-	if metrics != nil {
-		metrics.IncreaseCounterForDataObjectRename(1)
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForDataObjectRename(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	request := message.NewIRODSMessageCopyDataObjectRequest(srcPath, destPath, force)
+	response := message.IRODSMessageCopyDataObjectResponse{}
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
+			newErr := errors.Join(err, types.NewFileNotFoundError(srcPath))
+			return errors.Wrapf(newErr, "failed to find the data object for path %q", srcPath)
+		} else if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION {
+			newErr := errors.Join(err, types.NewFileNotFoundError(srcPath))
+			return errors.Wrapf(newErr, "failed to find the collection for path %q", srcPath)
+		}
+
+		return errors.Wrapf(err, "failed to copy data object")
+	}
+	return nil
+}
+
+// TruncateDataObject truncates a data object for the path to the given size
+// replica_truncate API is used on iRODS 4.3.2 or higher, otherwise DataObjTruncate API is used
+func TruncateDataObject(conn *connection.IRODSConnection, path string, size int64) error {
+	return truncateDataObject(conn, path, size, "", nil, false)
+}
+
+// TruncateDataObjectReplica truncates a replica of a data object for the path to the given size
+// resource is a leaf resource name of the replica, this requires replica_truncate API available on iRODS 4.3.2 or higher
+func TruncateDataObjectReplica(conn *connection.IRODSConnection, path string, size int64, resource string, replicaNumber *int, adminFlag bool) error {
+	return truncateDataObject(conn, path, size, resource, replicaNumber, adminFlag)
+}
+
+func truncateDataObject(conn *connection.IRODSConnection, path string, size int64, resource string, replicaNumber *int, adminFlag bool) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForDataObjectUpdate(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	targetReplica := len(resource) > 0 || replicaNumber != nil || adminFlag
+
+	if conn.GetServerCapabilities().ReplicaTruncate {
+		request := message.NewIRODSMessageReplicaTruncateRequest(path, size)
+		if len(resource) > 0 {
+			request.AddKeyVal(common.RESC_NAME_KW, resource)
+		}
+
+		if replicaNumber != nil {
+			request.AddKeyVal(common.REPL_NUM_KW, fmt.Sprintf("%d", *replicaNumber))
+		}
+
+		if adminFlag {
+			request.AddKeyVal(common.ADMIN_KW, "")
+		}
+
+		response := message.IRODSMessageReplicaTruncateResponse{}
+		err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
+		if err == nil {
+			return nil
+		}
+
+		if types.GetIRODSErrorCode(err) != common.SYS_UNMATCHED_API_NUM {
+			return getTruncateDataObjectError(path, err)
+		}
+
+		// not supported, fall back to DataObjTruncate
 	}
 
-	// lock the connection
-	conn.Lock()
-	defer conn.Unlock()
+	if targetReplica {
+		newErr := types.NewAPINotSupportedError(common.REPLICA_TRUNCATE_AN)
+		return errors.Wrapf(newErr, "failed to truncate a replica of data object %q", path)
+	}
 
-	request := message.NewIRODSMessageCopyDataObjectRequest(srcPath, destPath, force)
-	response := message.IRODSMessageCopyDataObjectResponse{}
+	request := message.NewIRODSMessageTruncateDataObjectRequest(path, size)
+	response := message.IRODSMessageTruncateDataObjectResponse{}
 	err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
 	if err != nil {
-		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
-			newErr := errors.Join(err, types.NewFileNotFoundError(srcPath))
-			return errors.Wrapf(newErr, "failed to find the data object for path %q", srcPath)
-		} else if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION {
-			newErr := errors.Join(err, types.NewFileNotFoundError(srcPath))
-			return errors.Wrapf(newErr, "failed to find the collection for path %q", srcPath)
-		}
-
-		return errors.Wrapf(err, "failed to copy data object")
+		return getTruncateDataObjectError(path, err)
 	}
 	return nil
 }
 
-// TruncateDataObject truncates a data object for the path to the given size
-func TruncateDataObject(conn *connection.IRODSConnection, path string, size int64) error {
+func getTruncateDataObjectError(path string, err error) error {
+	if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
+		newErr := errors.Join(err, types.NewFileNotFoundError(path))
+		return errors.Wrapf(newErr, "failed to find the data object for path %q", path)
+	} else if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION {
+		newErr := errors.Join(err, types.NewFileNotFoundError(path))
+		return errors.Wrapf(newErr, "failed to find the collection for path %q", path)
+	}
+
+	return errors.Wrapf(err, "failed to truncate data object")
+}
+
+// ReplicateDataObject replicates a data object for the path to the given reousrce
+func ReplicateDataObject(conn *connection.IRODSConnection, path string, resource string, update bool, adminFlag bool) error {
 	if conn == nil || !conn.IsConnected() {
 		return errors.Errorf("connection is nil or disconnected")
 	}
@@ -1685,8 +1887,23 @@ func TruncateDataObject(conn *connection.IRODSConnection, path string, size int6
 	conn.Lock()
 	defer conn.Unlock()
 
-	request := message.NewIRODSMessageTruncateDataObjectRequest(path, size)
-	response := message.IRODSMessageTruncateDataObjectResponse{}
+	// use default resource when resource param is empty
+	if len(resource) == 0 {
+		account := conn.GetAccount()
+		resource = account.DefaultResource
+	}
+
+	request := message.NewIRODSMessageReplicateDataObjectRequest(path, resource)
+
+	if update {
+		request.AddKeyVal(common.UPDATE_REPL_KW, "")
+	}
+
+	if adminFlag {
+		request.AddKeyVal(common.ADMIN_KW, "")
+	}
+
+	response := message.IRODSMessageReplicateDataObjectResponse{}
 	err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
 	if err != nil {
 		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
@@ -1697,13 +1914,16 @@ func TruncateDataObject(conn *connection.IRODSConnection, path string, size int6
 			return errors.Wrapf(newErr, "failed to find the collection for path %q", path)
 		}
 
-		return errors.Wrapf(err, "failed to truncate data object")
+		return errors.Wrapf(err, "failed to replicate data object")
 	}
 	return nil
 }
 
-// ReplicateDataObject replicates a data object for the path to the given reousrce
-func ReplicateDataObject(conn *connection.IRODSConnection, path string, resource string, update bool, adminFlag bool) error {
+// PhysicalMoveDataObject moves a replica of a data object to another resource on the server side
+// srcResource and destResource can be resource names or resource hierarchies (e.g., "root;leaf")
+// replicaNumber selects the replica to move if given, otherwise srcResource selects it
+// empty destResource uses the default resource
+func PhysicalMoveDataObject(conn *connection.IRODSConnection, path string, srcResource string, destResource string, replicaNumber *int, adminFlag bool) error {
 	if conn == nil || !conn.IsConnected() {
 		return errors.Errorf("connection is nil or disconnected")
 	}
@@ -1718,22 +1938,38 @@ func ReplicateDataObject(conn *connection.IRODSConnection, path string, resource
 	defer conn.Unlock()
 
 	// use default resource when resource param is empty
-	if len(resource) == 0 {
+	if len(destResource) == 0 {
 		account := conn.GetAccount()
-		resource = account.DefaultResource
+		destResource = account.DefaultResource
 	}
 
-	request := message.NewIRODSMessageReplicateDataObjectRequest(path, resource)
+	request := message.NewIRODSMessagePhysicalMoveDataObjectRequest(path)
 
-	if update {
-		request.AddKeyVal(common.UPDATE_REPL_KW, "")
+	if len(srcResource) > 0 {
+		if strings.Contains(srcResource, ";") {
+			request.AddKeyVal(common.RESC_HIER_STR_KW, srcResource)
+		} else {
+			request.AddKeyVal(common.RESC_NAME_KW, srcResource)
+		}
+	}
+
+	if len(destResource) > 0 {
+		if strings.Contains(destResource, ";") {
+			request.AddKeyVal(common.DEST_RESC_HIER_STR_KW, destResource)
+		} else {
+			request.AddKeyVal(common.DEST_RESC_NAME_KW, destResource)
+		}
+	}
+
+	if replicaNumber != nil {
+		request.AddKeyVal(common.REPL_NUM_KW, fmt.Sprintf("%d", *replicaNumber))
 	}
 
 	if adminFlag {
 		request.AddKeyVal(common.ADMIN_KW, "")
 	}
 
-	response := message.IRODSMessageReplicateDataObjectResponse{}
+	response := message.IRODSMessagePhysicalMoveDataObjectResponse{}
 	err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
 	if err != nil {
 		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
@@ -1744,7 +1980,7 @@ func ReplicateDataObject(conn *connection.IRODSConnection, path string, resource
 			return errors.Wrapf(newErr, "failed to find the collection for path %q", path)
 		}
 
-		return errors.Wrapf(err, "failed to replicate data object")
+		return errors.Wrapf(err, "failed to physically move data object")
 	}
 	return nil
 }
@@ -1844,6 +2080,62 @@ func CreateDataObject(conn *connection.IRODSConnection, path string, resource st
 	}, nil
 }
 
+// CreateDataObjectExclusive creates a data object for the path with O_EXCL, returns a file handle
+// it fails with FileAlreadyExistError if the path exists, the server checks the existence atomically
+func CreateDataObjectExclusive(conn *connection.IRODSConnection, path string, resource string, mode string, keywords map[common.KeyWord]string) (*types.IRODSFileHandle, error) {
+	if conn == nil || !conn.IsConnected() {
+		return nil, errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForDataObjectCreate(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	// use default resource when resource param is empty
+	if len(resource) == 0 {
+		account := conn.GetAccount()
+		resource = account.DefaultResource
+	}
+
+	fileOpenMode := types.FileOpenMode(mode)
+
+	// no force flag, so the server does not overwrite
+	request := message.NewIRODSMessageCreateDataObjectRequest(path, resource, fileOpenMode, false)
+	request.OpenFlags |= int(types.O_CREAT) | int(types.O_EXCL)
+	response := message.IRODSMessageCreateDataObjectResponse{}
+
+	for k, v := range keywords {
+		request.AddKeyVal(k, v)
+	}
+
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
+	if err != nil {
+		switch types.GetIRODSErrorCode(err) {
+		case common.OVERWRITE_WITHOUT_FORCE_FLAG, common.CAT_NAME_EXISTS_AS_DATAOBJ, common.CAT_NAME_EXISTS_AS_COLLECTION:
+			newErr := errors.Join(err, types.NewFileAlreadyExistError(path))
+			return nil, errors.Wrapf(newErr, "data object or collection for path %q already exists", path)
+		case common.CAT_UNKNOWN_COLLECTION:
+			newErr := errors.Join(err, types.NewFileNotFoundError(path))
+			return nil, errors.Wrapf(newErr, "failed to find the collection for path %q", path)
+		}
+
+		return nil, errors.Wrapf(err, "failed to create data object")
+	}
+
+	return &types.IRODSFileHandle{
+		FileDescriptor: response.GetFileDescriptor(),
+		Path:           path,
+		OpenMode:       fileOpenMode,
+		Resource:       resource,
+		Oper:           common.OPER_TYPE_NONE,
+	}, nil
+}
+
 // OpenDataObject opens a data object for the path, returns a file handle
 func OpenDataObject(conn *connection.IRODSConnection, path string, resource string, mode string, keywords map[common.KeyWord]string) (*types.IRODSFileHandle, int64, error) {
 	if conn == nil || !conn.IsConnected() {
@@ -1900,12 +2192,9 @@ func OpenDataObject(conn *connection.IRODSConnection, path string, resource stri
 	}
 
 	// handle seek
-	var offset int64 = 0
-	if fileOpenMode.SeekToEnd() {
-		offset, err = seekDataObject(conn, handle, 0, types.SeekEnd)
-		if err != nil {
-			return handle, -1, err
-		}
+	offset, err := seekToEndForAppend(conn, handle, false)
+	if err != nil {
+		return nil, -1, err
 	}
 
 	return handle, offset, nil
@@ -1967,12 +2256,9 @@ func OpenDataObjectWithReplicaToken(conn *connection.IRODSConnection, path strin
 	}
 
 	// handle seek
-	var offset int64 = 0
-	if fileOpenMode.SeekToEnd() {
-		offset, err = seekDataObject(conn, handle, 0, types.SeekEnd)
-		if err != nil {
-			return handle, -1, err
-		}
+	offset, err := seekToEndForAppend(conn, handle, true)
+	if err != nil {
+		return nil, -1, err
 	}
 
 	return handle, offset, nil
@@ -2034,11 +2320,9 @@ func OpenDataObjectWithOperation(conn *connection.IRODSConnection, path string,
 	}
 
 	// handle seek
-	if fileOpenMode.SeekToEnd() {
-		_, err = seekDataObject(conn, handle, 0, types.SeekEnd)
-		if err != nil {
-			return handle, err
-		}
+	_, err = seekToEndForAppend(conn, handle, false)
+	if err != nil {
+		return nil, err
 	}
 
 	return handle, nil
@@ -2100,11 +2384,9 @@ func OpenDataObjectForPutParallel(conn *connection.IRODSConnection, path string,
 	}
 
 	// handle seek
-	if fileOpenMode.SeekToEnd() {
-		_, err = seekDataObject(conn, handle, 0, types.SeekEnd)
-		if err != nil {
-			return handle, err
-		}
+	_, err = seekToEndForAppend(conn, handle, false)
+	if err != nil {
+		return nil, err
 	}
 
 	return handle, nil
@@ -2153,6 +2435,61 @@ func GetReplicaAccessInfo(conn *connection.IRODSConnection, handle *types.IRODSF
 	return response.ReplicaToken, resourceHierarchy, nil
 }
 
+// GetDataObjectReplicaAccessInfo returns serializable replica access info of a data object opened for write
+// other clients can open the same replica with OpenDataObjectWithReplicaAccessInfo while the handle is open
+func GetDataObjectReplicaAccessInfo(conn *connection.IRODSConnection, handle *types.IRODSFileHandle, threadNum int, dataSize int64) (*types.IRODSReplicaAccessInfo, error) {
+	if conn == nil || !conn.IsConnected() {
+		return nil, errors.Errorf("connection is nil or disconnected")
+	}
+
+	if !conn.SupportParallelUpload() {
+		return nil, types.NewAPINotSupportedError(common.GET_FILE_DESCRIPTOR_INFO_APN)
+	}
+
+	if !handle.OpenMode.IsWrite() {
+		return nil, errors.Errorf("data object %q is not opened for write", handle.Path)
+	}
+
+	replicaToken, resourceHierarchy, err := GetReplicaAccessInfo(conn, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.IRODSReplicaAccessInfo{
+		Path:              handle.Path,
+		Resource:          handle.Resource,
+		ResourceHierarchy: resourceHierarchy,
+		ReplicaToken:      replicaToken,
+		ThreadNum:         threadNum,
+		DataSize:          dataSize,
+	}, nil
+}
+
+// OpenDataObjectWithReplicaAccessInfo opens a replica being written by another client, returns a file handle
+// the handle must be closed with CloseDataObjectReplica, so only the client that created the replica finalizes it
+func OpenDataObjectWithReplicaAccessInfo(conn *connection.IRODSConnection, accessInfo *types.IRODSReplicaAccessInfo, mode string, keywords map[common.KeyWord]string) (*types.IRODSFileHandle, int64, error) {
+	if conn == nil || !conn.IsConnected() {
+		return nil, -1, errors.Errorf("connection is nil or disconnected")
+	}
+
+	if !conn.SupportParallelUpload() {
+		return nil, -1, types.NewAPINotSupportedError(common.REPLICA_CLOSE_APN)
+	}
+
+	err := accessInfo.Validate()
+	if err != nil {
+		return nil, -1, err
+	}
+
+	dataSize := accessInfo.DataSize
+	if dataSize <= 0 {
+		// unknown
+		dataSize = -1
+	}
+
+	return OpenDataObjectWithReplicaToken(conn, accessInfo.Path, accessInfo.Resource, mode, accessInfo.ReplicaToken, accessInfo.ResourceHierarchy, accessInfo.ThreadNum, dataSize, keywords)
+}
+
 // SeekDataObject moves file pointer of a data object, returns offset
 func SeekDataObject(conn *connection.IRODSConnection, handle *types.IRODSFileHandle, offset int64, whence types.Whence) (int64, error) {
 	if conn == nil || !conn.IsConnected() {
@@ -2189,6 +2526,44 @@ func seekDataObject(conn *connection.IRODSConnection, handle *types.IRODSFileHan
 	return response.Offset, nil
 }
 
+// seekToEndForAppend moves the file pointer of a data object opened with an append mode to the end of the replica, returns offset
+// the handle is closed if seek fails, so callers do not leak the file descriptor, connection must be locked
+func seekToEndForAppend(conn *connection.IRODSConnection, handle *types.IRODSFileHandle, replicaAccess bool) (int64, error) {
+	if !handle.OpenMode.SeekToEnd() {
+		return 0, nil
+	}
+
+	offset, err := seekDataObject(conn, handle, 0, types.SeekEnd)
+	if err == nil {
+		return offset, nil
+	}
+
+	// close
+	// replicas opened with a replica token must not be finalized
+	var closeErr error
+	if replicaAccess {
+		closeRequest := message.NewIRODSMessageCloseDataObjectReplicaRequest(handle.FileDescriptor, false, false, false, false, false)
+		closeResponse := message.IRODSMessageCloseDataObjectReplicaResponse{}
+		closeErr = conn.RequestAndCheck(closeRequest, &closeResponse, nil, conn.GetOperationTimeout())
+	} else {
+		closeRequest := message.NewIRODSMessageCloseDataObjectRequest(handle.FileDescriptor)
+		closeResponse := message.IRODSMessageCloseDataObjectResponse{}
+		closeErr = conn.RequestAndCheck(closeRequest, &closeResponse, nil, conn.GetOperationTimeout())
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForDataObjectClose(1)
+		metrics.DecreaseCounterForOpenFileHandles(1)
+	}
+
+	if closeErr != nil {
+		return -1, errors.Join(err, closeErr)
+	}
+
+	return -1, err
+}
+
 // ReadDataObject reads data from a data object
 func ReadDataObject(conn *connection.IRODSConnection, handle *types.IRODSFileHandle, buffer []byte) (int, error) {
 	return ReadDataObjectWithTrackerCallBack(conn, handle, buffer, nil)
@@ -2209,9 +2584,57 @@ func ReadDataObjectWithTrackerCallBack(conn *connection.IRODSConnection, handle
 	conn.Lock()
 	defer conn.Unlock()
 
+	return readDataObject(conn, handle, buffer, callback)
+}
+
+// ReadAtDataObject reads data from a data object at the offset, returns io.EOF if it reaches the end
+// seek and read are done while the connection is locked, so goroutines sharing the connection do not interleave them
+func ReadAtDataObject(conn *connection.IRODSConnection, handle *types.IRODSFileHandle, buffer []byte, offset int64) (int, error) {
+	return ReadAtDataObjectWithTrackerCallBack(conn, handle, buffer, offset, nil)
+}
+
+// ReadAtDataObjectWithTrackerCallBack reads data from a data object at the offset, returns io.EOF if it reaches the end
+func ReadAtDataObjectWithTrackerCallBack(conn *connection.IRODSConnection, handle *types.IRODSFileHandle, buffer []byte, offset int64, callback common.TransferTrackerCallback) (int, error) {
+	if conn == nil || !conn.IsConnected() {
+		return 0, errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForDataObjectRead(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	err := seekDataObjectTo(conn, handle, offset)
+	if err != nil {
+		return 0, err
+	}
+
+	return readDataObject(conn, handle, buffer, callback)
+}
+
+// seekDataObjectTo moves file pointer of a data object to the offset, connection must be locked
+func seekDataObjectTo(conn *connection.IRODSConnection, handle *types.IRODSFileHandle, offset int64) error {
+	newOffset, err := seekDataObject(conn, handle, offset, types.SeekSet)
+	if err != nil {
+		return errors.Wrapf(err, "failed to seek data object")
+	}
+
+	if newOffset != offset {
+		return errors.Errorf("failed to seek to %d, moved to %d", offset, newOffset)
+	}
+
+	return nil
+}
+
+// readDataObject reads data from a data object, connection must be locked
+func readDataObject(conn *connection.IRODSConnection, handle *types.IRODSFileHandle, buffer []byte, callback common.TransferTrackerCallback) (int, error) {
 	request := message.NewIRODSMessageReadDataObjectRequest(handle.FileDescriptor, len(buffer))
 	response := message.IRODSMessageReadDataObjectResponse{}
-	err := conn.RequestAndCheckWithTrackerCallBack(request, &response, buffer, conn.GetOperationTimeout(), nil, callback)
+	err := conn.RequestAndCheckWithTrackerCallBack(request, &response, buffer, conn.GetDataTransferTimeout(), nil, callback)
 	if err != nil {
 		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
 			newErr := errors.Join(err, types.NewFileNotFoundError(handle.Path))
@@ -2253,9 +2676,43 @@ func WriteDataObjectWithTrackerCallBack(conn *connection.IRODSConnection, handle
 	conn.Lock()
 	defer conn.Unlock()
 
+	return writeDataObject(conn, handle, data, callback)
+}
+
+// WriteAtDataObject writes data to a data object at the offset
+// seek and write are done while the connection is locked, so goroutines sharing the connection do not interleave them
+func WriteAtDataObject(conn *connection.IRODSConnection, handle *types.IRODSFileHandle, data []byte, offset int64) error {
+	return WriteAtDataObjectWithTrackerCallBack(conn, handle, data, offset, nil)
+}
+
+// WriteAtDataObjectWithTrackerCallBack writes data to a data object at the offset
+func WriteAtDataObjectWithTrackerCallBack(conn *connection.IRODSConnection, handle *types.IRODSFileHandle, data []byte, offset int64, callback common.TransferTrackerCallback) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForDataObjectWrite(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	err := seekDataObjectTo(conn, handle, offset)
+	if err != nil {
+		return err
+	}
+
+	return writeDataObject(conn, handle, data, callback)
+}
+
+// writeDataObject writes data to a data object, connection must be locked
+func writeDataObject(conn *connection.IRODSConnection, handle *types.IRODSFileHandle, data []byte, callback common.TransferTrackerCallback) error {
 	request := message.NewIRODSMessageWriteDataObjectRequest(handle.FileDescriptor, data)
 	response := message.IRODSMessageWriteDataObjectResponse{}
-	err := conn.RequestAndCheckWithTrackerCallBack(request, &response, nil, conn.GetOperationTimeout(), callback, nil)
+	err := conn.RequestAndCheckWithTrackerCallBack(request, &response, nil, conn.GetDataTransferTimeout(), callback, nil)
 	if err != nil {
 		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
 			newErr := errors.Join(err, types.NewFileNotFoundError(handle.Path))
@@ -2309,7 +2766,7 @@ func WriteDataObjectAsyncWithTrackerCallBack(conn *connection.IRODSConnection, h
 			}
 
 			if res, ok := rrPair.Response.(connection.CheckErrorResponse); ok {
-				resErr := res.CheckError()
+				resErr := conn.CheckResponseError(rrPair.Request, res)
 				if resErr != nil {
 					if types.GetIRODSErrorCode(resErr) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(resErr) == common.CAT_UNKNOWN_FILE {
 						newErr := errors.Join(resErr, types.NewFileNotFoundError(handle.Path))
@@ -2354,7 +2811,7 @@ func WriteDataObjectAsyncWithTrackerCallBack(conn *connection.IRODSConnection, h
 				Request:  message.NewIRODSMessageWriteDataObjectRequest(handle.FileDescriptor, buffer[:bytesRead]),
 				Response: &message.IRODSMessageWriteDataObjectResponse{},
 				BsBuffer: nil,
-				Timeout:  conn.GetOperationTimeout(),
+				Timeout:  conn.GetDataTransferTimeout(),
 				RequestCallback: func(taskName string, processed int64, total int64) {
 					// callback
 					if processed > 0 && processed == total {
@@ -2614,6 +3071,35 @@ func GetLockDataObject(conn *connection.IRODSConnection, path string) (*types.IR
 	return handle, nil
 }
 
+// GetDataObjectLockType returns the type of a lock held on a data object by other clients, returns DataObjectLockTypeUnlock if it is not locked
+func GetDataObjectLockType(conn *connection.IRODSConnection, path string) (types.DataObjectLockType, error) {
+	if conn == nil || !conn.IsConnected() {
+		return "", errors.Errorf("connection is nil or disconnected")
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	// the server tests if a write lock can be placed, the result is the type of a conflicting lock
+	request := message.NewIRODSMessageLockDataObjectRequest(path, types.DataObjectLockTypeWrite, types.DataObjectLockCommandGetLock)
+	response := message.IRODSMessageLockDataObjectResponse{}
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
+			newErr := errors.Join(err, types.NewFileNotFoundError(path))
+			return "", errors.Wrapf(newErr, "failed to find the data object for path %q", path)
+		} else if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION {
+			newErr := errors.Join(err, types.NewFileNotFoundError(path))
+			return "", errors.Wrapf(newErr, "failed to find the collection for path %q", path)
+		}
+
+		return "", errors.Wrapf(err, "failed to get data object lock type")
+	}
+
+	return types.GetDataObjectLockTypeFromFlockType(response.GetFileDescriptor()), nil
+}
+
 // UnlockDataObject unlocks a file handle of a data object
 func UnlockDataObject(conn *connection.IRODSConnection, handle *types.IRODSFileLockHandle) error {
 	if conn == nil || !conn.IsConnected() {
@@ -2727,6 +3213,11 @@ func DeleteDataObjectMeta(conn *connection.IRODSConnection, path string, metadat
 
 // SearchDataObjectsByMeta searches data objects by metadata
 func SearchDataObjectsByMeta(conn *connection.IRODSConnection, metaName string, metaValue string) ([]*types.IRODSDataObject, error) {
+	return SearchDataObjectsByMetaWithOptions(conn, metaName, metaValue, nil)
+}
+
+// SearchDataObjectsByMetaWithOptions is SearchDataObjectsByMeta with search options, nil options are the same as SearchDataObjectsByMeta
+func SearchDataObjectsByMetaWithOptions(conn *connection.IRODSConnection, metaName string, metaValue string, options *SearchOptions) ([]*types.IRODSDataObject, error) {
 	if conn == nil || !conn.IsConnected() {
 		return nil, errors.Errorf("connection is nil or disconnected")
 	}
@@ -2746,7 +3237,7 @@ func SearchDataObjectsByMeta(conn *connection.IRODSConnection, metaName string,
 	continueIndex := 0
 	for continueQuery {
 		// data object
-		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, 0)
+		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, options.GetQueryOptions())
 		query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
 		query.AddSelect(common.ICAT_COLUMN_COLL_ID)
 		query.AddSelect(common.ICAT_COLUMN_COLL_NAME)
@@ -2766,7 +3257,7 @@ func SearchDataObjectsByMeta(conn *connection.IRODSConnection, metaName string,
 		query.AddSelect(common.ICAT_COLUMN_D_CREATE_TIME)
 		query.AddSelect(common.ICAT_COLUMN_D_MODIFY_TIME)
 
-		if conn.GetVersion().HasHigherVersionThan(5, 0, 0) {
+		if conn.GetServerCapabilities().DataObjectAccessTime {
 			query.AddSelect(common.ICAT_COLUMN_D_ACCESS_TIME)
 		}
 
@@ -2784,7 +3275,7 @@ func SearchDataObjectsByMeta(conn *connection.IRODSConnection, metaName string,
 			return nil, errors.Wrapf(err, "failed to receive a data object query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -2955,6 +3446,11 @@ func SearchDataObjectsByMeta(conn *connection.IRODSConnection, metaName string,
 
 // SearchDataObjectsMasterReplicaByMeta searches data objects by metadata, returns only master replica
 func SearchDataObjectsMasterReplicaByMeta(conn *connection.IRODSConnection, metaName string, metaValue string) ([]*types.IRODSDataObject, error) {
+	return SearchDataObjectsMasterReplicaByMetaWithOptions(conn, metaName, metaValue, nil)
+}
+
+// SearchDataObjectsMasterReplicaByMetaWithOptions is SearchDataObjectsMasterReplicaByMeta with search options, nil options are the same as SearchDataObjectsMasterReplicaByMeta
+func SearchDataObjectsMasterReplicaByMetaWithOptions(conn *connection.IRODSConnection, metaName string, metaValue string, options *SearchOptions) ([]*types.IRODSDataObject, error) {
 	if conn == nil || !conn.IsConnected() {
 		return nil, errors.Errorf("connection is nil or disconnected")
 	}
@@ -2974,7 +3470,7 @@ func SearchDataObjectsMasterReplicaByMeta(conn *connection.IRODSConnection, meta
 	continueIndex := 0
 	for continueQuery {
 		// data object
-		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, 0)
+		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, options.GetQueryOptions())
 		query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
 		query.AddSelect(common.ICAT_COLUMN_COLL_ID)
 		query.AddSelect(common.ICAT_COLUMN_COLL_NAME)
@@ -2994,7 +3490,7 @@ func SearchDataObjectsMasterReplicaByMeta(conn *connection.IRODSConnection, meta
 		query.AddSelect(common.ICAT_COLUMN_D_CREATE_TIME)
 		query.AddSelect(common.ICAT_COLUMN_D_MODIFY_TIME)
 
-		if conn.GetVersion().HasHigherVersionThan(5, 0, 0) {
+		if conn.GetServerCapabilities().DataObjectAccessTime {
 			query.AddSelect(common.ICAT_COLUMN_D_ACCESS_TIME)
 		}
 
@@ -3013,7 +3509,7 @@ func SearchDataObjectsMasterReplicaByMeta(conn *connection.IRODSConnection, meta
 			return nil, errors.Wrapf(err, "failed to receive a data object query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -3193,6 +3689,11 @@ func SearchDataObjectsMasterReplicaByMeta(conn *connection.IRODSConnection, meta
 // SearchDataObjectsByMetaWildcard searches data objects by metadata
 // Caution: This is a very slow operation
 func SearchDataObjectsByMetaWildcard(conn *connection.IRODSConnection, metaName string, metaValue string) ([]*types.IRODSDataObject, error) {
+	return SearchDataObjectsByMetaWildcardWithOptions(conn, metaName, metaValue, nil)
+}
+
+// SearchDataObjectsByMetaWildcardWithOptions is SearchDataObjectsByMetaWildcard with search options, nil options are the same as SearchDataObjectsByMetaWildcard
+func SearchDataObjectsByMetaWildcardWithOptions(conn *connection.IRODSConnection, metaName string, metaValue string, options *SearchOptions) ([]*types.IRODSDataObject, error) {
 	if conn == nil || !conn.IsConnected() {
 		return nil, errors.Errorf("connection is nil or disconnected")
 	}
@@ -3212,7 +3713,7 @@ func SearchDataObjectsByMetaWildcard(conn *connection.IRODSConnection, metaName
 	continueIndex := 0
 	for continueQuery {
 		// data object
-		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, 0)
+		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, options.GetQueryOptions())
 		query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
 		query.AddSelect(common.ICAT_COLUMN_COLL_ID)
 		query.AddSelect(common.ICAT_COLUMN_COLL_NAME)
@@ -3232,7 +3733,7 @@ func SearchDataObjectsByMetaWildcard(conn *connection.IRODSConnection, metaName
 		query.AddSelect(common.ICAT_COLUMN_D_CREATE_TIME)
 		query.AddSelect(common.ICAT_COLUMN_D_MODIFY_TIME)
 
-		if conn.GetVersion().HasHigherVersionThan(5, 0, 0) {
+		if conn.GetServerCapabilities().DataObjectAccessTime {
 			query.AddSelect(common.ICAT_COLUMN_D_ACCESS_TIME)
 		}
 
@@ -3250,7 +3751,7 @@ func SearchDataObjectsByMetaWildcard(conn *connection.IRODSConnection, metaName
 			return nil, errors.Wrapf(err, "failed to receive a data object query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -3422,6 +3923,11 @@ func SearchDataObjectsByMetaWildcard(conn *connection.IRODSConnection, metaName
 // SearchDataObjectsMasterReplicaByMetaWildcard searches data objects by metadata, returns only master replica
 // Caution: This is a very slow operation
 func SearchDataObjectsMasterReplicaByMetaWildcard(conn *connection.IRODSConnection, metaName string, metaValue string) ([]*types.IRODSDataObject, error) {
+	return SearchDataObjectsMasterReplicaByMetaWildcardWithOptions(conn, metaName, metaValue, nil)
+}
+
+// SearchDataObjectsMasterReplicaByMetaWildcardWithOptions is SearchDataObjectsMasterReplicaByMetaWildcard with search options, nil options are the same as SearchDataObjectsMasterReplicaByMetaWildcard
+func SearchDataObjectsMasterReplicaByMetaWildcardWithOptions(conn *connection.IRODSConnection, metaName string, metaValue string, options *SearchOptions) ([]*types.IRODSDataObject, error) {
 	if conn == nil || !conn.IsConnected() {
 		return nil, errors.Errorf("connection is nil or disconnected")
 	}
@@ -3441,7 +3947,7 @@ func SearchDataObjectsMasterReplicaByMetaWildcard(conn *connection.IRODSConnecti
 	continueIndex := 0
 	for continueQuery {
 		// data object
-		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, 0)
+		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, options.GetQueryOptions())
 		query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
 		query.AddSelect(common.ICAT_COLUMN_COLL_ID)
 		query.AddSelect(common.ICAT_COLUMN_COLL_NAME)
@@ -3461,7 +3967,7 @@ func SearchDataObjectsMasterReplicaByMetaWildcard(conn *connection.IRODSConnecti
 		query.AddSelect(common.ICAT_COLUMN_D_CREATE_TIME)
 		query.AddSelect(common.ICAT_COLUMN_D_MODIFY_TIME)
 
-		if conn.GetVersion().HasHigherVersionThan(5, 0, 0) {
+		if conn.GetServerCapabilities().DataObjectAccessTime {
 			query.AddSelect(common.ICAT_COLUMN_D_ACCESS_TIME)
 		}
 
@@ -3480,7 +3986,7 @@ func SearchDataObjectsMasterReplicaByMetaWildcard(conn *connection.IRODSConnecti
 			return nil, errors.Wrapf(err, "failed to receive a data object query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -2,6 +2,8 @@ package fs
 
 import (
 	"bytes"
+	"crypto/md5"
+	"hash"
 	"io"
 	"os"
 	"sync"
@@ -12,6 +14,7 @@ import (
 	"github.com/cyverse/go-irodsclient/irods/connection"
 	"github.com/cyverse/go-irodsclient/irods/message"
 	"github.com/cyverse/go-irodsclient/irods/session"
+	"github.com/cyverse/go-irodsclient/irods/system"
 	"github.com/cyverse/go-irodsclient/irods/types"
 	"github.com/cyverse/go-irodsclient/irods/util"
 
@@ -24,6 +27,10 @@ func CloseDataObjectReplica(conn *connection.IRODSConnection, handle *types.IROD
 		return errors.Errorf("connection is nil or disconnected")
 	}
 
+	if handle == nil {
+		return errors.Errorf("file handle is nil")
+	}
+
 	// lock the connection
 	conn.Lock()
 	defer conn.Unlock()
@@ -167,6 +174,12 @@ func UploadDataObjectFromBufferWithConnection(conn *connection.IRODSConnection,
 
 // UploadDataObject put a data object at the local path to the iRODS path
 func UploadDataObject(sess *session.IRODSSession, localPath string, irodsPath string, resource string, replicate bool, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) error {
+	return UploadDataObjectWithHash(sess, localPath, irodsPath, resource, replicate, keywords, nil, transferCallback)
+}
+
+// UploadDataObjectWithHash put a data object at the local path to the iRODS path, and feeds the uploaded data to hashAlg
+// the hash of the local file is calculated in the same pass of the upload, so the file does not need to be read again for checksum verification
+func UploadDataObjectWithHash(sess *session.IRODSSession, localPath string, irodsPath string, resource string, replicate bool, keywords map[common.KeyWord]string, hashAlg hash.Hash, transferCallback common.TransferTrackerCallback) error {
 	logger := log.WithFields(log.Fields{
 		"local_path": localPath,
 		"irods_path": irodsPath,
@@ -240,6 +253,14 @@ func UploadDataObject(sess *session.IRODSSession, localPath string, irodsPath st
 				break
 			}
 
+			if hashAlg != nil {
+				_, writeErr = hashAlg.Write(buffer[:bytesRead])
+				if writeErr != nil {
+					writeErr = errors.Wrapf(writeErr, "failed to write data to hash algorithm")
+					break
+				}
+			}
+
 			totalBytesUploaded += int64(bytesRead)
 		}
 
@@ -276,6 +297,11 @@ func UploadDataObject(sess *session.IRODSSession, localPath string, irodsPath st
 
 // UploadDataObjectWithConnection put a data object at the local path to the iRODS path
 func UploadDataObjectWithConnection(conn *connection.IRODSConnection, localPath string, irodsPath string, resource string, replicate bool, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) error {
+	return UploadDataObjectWithConnectionAndHash(conn, localPath, irodsPath, resource, replicate, keywords, nil, transferCallback)
+}
+
+// UploadDataObjectWithConnectionAndHash put a data object at the local path to the iRODS path, and feeds the uploaded data to hashAlg
+func UploadDataObjectWithConnectionAndHash(conn *connection.IRODSConnection, localPath string, irodsPath string, resource string, replicate bool, keywords map[common.KeyWord]string, hashAlg hash.Hash, transferCallback common.TransferTrackerCallback) error {
 	logger := log.WithFields(log.Fields{
 		"local_path": localPath,
 		"irods_path": irodsPath,
@@ -340,6 +366,14 @@ func UploadDataObjectWithConnection(conn *connection.IRODSConnection, localPath
 				break
 			}
 
+			if hashAlg != nil {
+				_, writeErr = hashAlg.Write(buffer[:bytesRead])
+				if writeErr != nil {
+					writeErr = errors.Wrapf(writeErr, "failed to write data to hash algorithm")
+					break
+				}
+			}
+
 			totalBytesUploaded += int64(bytesRead)
 		}
 
@@ -377,6 +411,13 @@ func UploadDataObjectWithConnection(conn *connection.IRODSConnection, localPath
 // UploadDataObjectParallel put a data object at the local path to the iRODS path in parallel
 // Partitions a file into n (taskNum) tasks and uploads in parallel
 func UploadDataObjectParallel(sess *session.IRODSSession, localPath string, irodsPath string, resource string, taskNum int, replicate bool, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) error {
+	return UploadDataObjectParallelWithStats(sess, localPath, irodsPath, resource, taskNum, replicate, keywords, transferCallback, nil)
+}
+
+// UploadDataObjectParallelWithStats put a data object at the local path to the iRODS path in parallel
+// Partitions a file into n (taskNum) tasks and uploads in parallel
+// Statistics of the transfer, such as the number of tasks and retries, are collected to stats if it is not nil
+func UploadDataObjectParallelWithStats(sess *session.IRODSSession, localPath string, irodsPath string, resource string, taskNum int, replicate bool, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback, stats *DataObjectTransferStats) error {
 	logger := log.WithFields(log.Fields{
 		"local_path": localPath,
 		"irods_path": irodsPath,
@@ -386,8 +427,16 @@ func UploadDataObjectParallel(sess *session.IRODSSession, localPath string, irod
 	})
 
 	if !sess.SupportParallelUpload() {
-		// serial upload
-		return UploadDataObject(sess, localPath, irodsPath, resource, replicate, keywords, transferCallback)
+		// servers older than 4.2.9 do not support replica tokens
+		// use legacy portal protocol (redirect-to-resource) for parallel upload, serial upload if portal is not available
+		logger.Debug("replica token is not supported, use legacy portal protocol")
+
+		serialFallback := func(numTasks int) error {
+			stats.setTaskNum(1)
+			return UploadDataObject(sess, localPath, irodsPath, resource, replicate, keywords, transferCallback)
+		}
+
+		return uploadDataObjectToResourceServer(sess, localPath, irodsPath, resource, taskNum, replicate, keywords, transferCallback, serialFallback, stats)
 	}
 
 	// use default resource when resource param is empty
@@ -405,6 +454,7 @@ func UploadDataObjectParallel(sess *session.IRODSSession, localPath string, irod
 
 	if fileLength == 0 {
 		// empty file
+		stats.setTaskNum(1)
 		return UploadDataObject(sess, localPath, irodsPath, resource, replicate, keywords, transferCallback)
 	}
 
@@ -415,6 +465,7 @@ func UploadDataObjectParallel(sess *session.IRODSSession, localPath string, irod
 
 	if numTasks == 1 {
 		// serial upload
+		stats.setTaskNum(1)
 		return UploadDataObject(sess, localPath, irodsPath, resource, replicate, keywords, transferCallback)
 	}
 
@@ -437,6 +488,7 @@ func UploadDataObjectParallel(sess *session.IRODSSession, localPath string, irod
 			return errors.Wrapf(err, "failed to return connection")
 		}
 
+		stats.setTaskNum(1)
 		return UploadDataObject(sess, localPath, irodsPath, resource, replicate, keywords, transferCallback)
 	}
 
@@ -503,20 +555,6 @@ func UploadDataObjectParallel(sess *session.IRODSSession, localPath string, irod
 			taskWaitGroup.Done()
 		}()
 
-		// open the file with read-write mode
-		// to not seek to end
-		taskHandle, _, taskErr := OpenDataObjectWithReplicaToken(transferConn, irodsPath, resource, "w", replicaToken, resourceHierarchy, numTasks, fileLength, keywords)
-		if taskErr != nil {
-			errChan <- taskErr
-			return
-		}
-		defer func() {
-			errClose := CloseDataObjectReplica(transferConn, taskHandle)
-			if errClose != nil {
-				errChan <- errClose
-			}
-		}()
-
 		f, taskErr := os.OpenFile(localPath, os.O_RDONLY, 0)
 		if taskErr != nil {
 			errChan <- errors.Wrapf(taskErr, "failed to open file %q", localPath)
@@ -526,58 +564,117 @@ func UploadDataObjectParallel(sess *session.IRODSSession, localPath string, irod
 			_ = f.Close()
 		}()
 
-		taskNewOffset, taskErr := SeekDataObject(transferConn, taskHandle, taskOffset, types.SeekSet)
-		if taskErr != nil {
-			errChan <- taskErr
-			return
-		}
-
-		if taskNewOffset != taskOffset {
-			errChan <- errors.Errorf("failed to seek to target offset %d", taskOffset)
-			return
-		}
-
+		lastOffset := taskOffset
 		taskRemain := taskLength
 
-		// copy
 		buffer := make([]byte, common.ReadWriteBufferSize)
-		var taskWriteErr error
-		for taskRemain > 0 {
-			bufferLen := common.ReadWriteBufferSize
-			if taskRemain < int64(bufferLen) {
-				bufferLen = int(taskRemain)
+
+		attempt := func(attemptConn *connection.IRODSConnection) error {
+			// open the file with read-write mode
+			// to not seek to end
+			attemptHandle, _, openErr := OpenDataObjectWithReplicaToken(attemptConn, irodsPath, resource, "w", replicaToken, resourceHierarchy, numTasks, fileLength, keywords)
+			if openErr != nil {
+				return openErr
 			}
 
-			bytesRead, taskReadErr := f.ReadAt(buffer[:bufferLen], taskOffset+(taskLength-taskRemain))
-			if bytesRead > 0 {
-				taskWriteErr = WriteDataObjectWithTrackerCallBack(transferConn, taskHandle, buffer[:bytesRead], nil)
-				if taskWriteErr != nil {
-					break
+			attemptErr := func() error {
+				// seek to last offset, writes are positional so a partially written buffer is overwritten
+				if lastOffset != taskOffset {
+					taskLogger.Debugf("resuming uploading data object partition, last offset %d", lastOffset)
 				}
 
-				atomic.AddInt64(&totalBytesUploaded, int64(bytesRead))
-				if transferCallback != nil {
-					transferCallback("upload", atomic.LoadInt64(&totalBytesUploaded), fileLength)
+				newOffset, seekErr := SeekDataObject(attemptConn, attemptHandle, lastOffset, types.SeekSet)
+				if seekErr != nil {
+					return errors.Wrapf(seekErr, "failed to seek data object %q to offset %d", irodsPath, lastOffset)
 				}
 
-				taskRemain -= int64(bytesRead)
-			}
+				if newOffset != lastOffset {
+					return errors.Errorf("failed to seek to target offset %d", lastOffset)
+				}
 
-			if taskReadErr != nil {
-				if taskReadErr == io.EOF {
-					break
-				} else {
-					taskWriteErr = errors.Wrapf(taskReadErr, "failed to read file %q", localPath)
-					break
+				// copy
+				for taskRemain > 0 {
+					bufferLen := common.ReadWriteBufferSize
+					if taskRemain < int64(bufferLen) {
+						bufferLen = int(taskRemain)
+					}
+
+					bytesRead, readErr := f.ReadAt(buffer[:bufferLen], lastOffset)
+					if bytesRead > 0 {
+						writeErr := WriteDataObjectWithTrackerCallBack(attemptConn, attemptHandle, buffer[:bytesRead], nil)
+						if writeErr != nil {
+							return writeErr
+						}
+
+						atomic.AddInt64(&totalBytesUploaded, int64(bytesRead))
+						if transferCallback != nil {
+							transferCallback("upload", atomic.LoadInt64(&totalBytesUploaded), fileLength)
+						}
+
+						taskRemain -= int64(bytesRead)
+						lastOffset += int64(bytesRead)
+					}
+
+					if readErr != nil {
+						if readErr == io.EOF {
+							return nil
+						}
+
+						return errors.Wrapf(readErr, "failed to read file %q", localPath)
+					}
+
+					if len(errChan) > 0 {
+						// other tasks failed
+						return errors.Errorf("stop running as other tasks failed")
+					}
 				}
+
+				return nil
+			}()
+
+			if attemptConn.IsSocketFailed() || !attemptConn.IsConnected() {
+				// the replica is closed by the server when the connection is lost
+				return attemptErr
+			}
+
+			closeErr := CloseDataObjectReplica(attemptConn, attemptHandle)
+			if attemptErr != nil {
+				return attemptErr
 			}
+
+			return closeErr
 		}
 
-		if taskWriteErr != nil {
-			errChan <- taskWriteErr
+		retryErr := transferConn.GetRetryPolicy().GetTransferPolicy().Run(func(attemptNo int) error {
+			if attemptNo > 0 {
+				// retry
+				stats.addRetry()
+				taskLogger.Errorf("socket failed, retrying...")
+
+				connErr := transferConn.Reconnect()
+				if connErr != nil {
+					return errors.Wrapf(connErr, "failed to reconnect")
+				}
+
+				if !transferConn.IsConnected() {
+					return errors.Errorf("connection is disconnected")
+				}
+			}
+
+			attemptErr := attempt(transferConn)
+			if attemptErr != nil && transferConn.IsSocketFailed() {
+				return errors.Join(attemptErr, types.NewConnectionError())
+			}
+
+			return attemptErr
+		})
+		if retryErr != nil {
+			errChan <- retryErr
 		}
 	}
 
+	stats.setTaskNum(numTasks)
+
 	lengthPerThread := fileLength / int64(numTasks)
 	if fileLength%int64(numTasks) > 0 {
 		lengthPerThread++
@@ -585,6 +682,9 @@ func UploadDataObjectParallel(sess *session.IRODSSession, localPath string, irod
 
 	offset := int64(0)
 
+	// keep the control connection alive while it is idle
+	stopKeepAlive := keepControlConnectionAlive(controlConn)
+
 	for i := 0; i < numTasks; i++ {
 		taskWaitGroup.Add(1)
 
@@ -593,6 +693,7 @@ func UploadDataObjectParallel(sess *session.IRODSSession, localPath string, irod
 	}
 
 	taskWaitGroup.Wait()
+	stopKeepAlive()
 
 	if len(errChan) > 0 {
 		_ = CloseDataObject(controlConn, handle)
@@ -618,6 +719,13 @@ func UploadDataObjectParallel(sess *session.IRODSSession, localPath string, irod
 // UploadDataObjectParallelWithConnections put a data object at the local path to the iRODS path in parallel
 // Partitions a file into n (taskNum) tasks and uploads in parallel
 func UploadDataObjectParallelWithConnections(conns []*connection.IRODSConnection, localPath string, irodsPath string, resource string, replicate bool, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) error {
+	return UploadDataObjectParallelWithConnectionsAndStats(conns, localPath, irodsPath, resource, replicate, keywords, transferCallback, nil)
+}
+
+// UploadDataObjectParallelWithConnectionsAndStats put a data object at the local path to the iRODS path in parallel
+// Partitions a file into n (taskNum) tasks and uploads in parallel
+// Statistics of the transfer, such as the number of tasks and retries, are collected to stats if it is not nil
+func UploadDataObjectParallelWithConnectionsAndStats(conns []*connection.IRODSConnection, localPath string, irodsPath string, resource string, replicate bool, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback, stats *DataObjectTransferStats) error {
 	logger := log.WithFields(log.Fields{
 		"local_path": localPath,
 		"irods_path": irodsPath,
@@ -637,6 +745,7 @@ func UploadDataObjectParallelWithConnections(conns []*connection.IRODSConnection
 
 	if !conns[0].SupportParallelUpload() {
 		// serial upload
+		stats.setTaskNum(1)
 		return UploadDataObjectWithConnection(conns[0], localPath, irodsPath, resource, replicate, keywords, transferCallback)
 	}
 
@@ -655,12 +764,14 @@ func UploadDataObjectParallelWithConnections(conns []*connection.IRODSConnection
 
 	if fileLength == 0 {
 		// empty file
+		stats.setTaskNum(1)
 		return UploadDataObjectWithConnection(conns[0], localPath, irodsPath, resource, replicate, keywords, transferCallback)
 	}
 
 	// if we have only one data connection, use serial upload
 	if len(conns) < 2 {
 		// serial upload
+		stats.setTaskNum(1)
 		return UploadDataObjectWithConnection(conns[0], localPath, irodsPath, resource, replicate, keywords, transferCallback)
 	}
 
@@ -705,20 +816,6 @@ func UploadDataObjectParallelWithConnections(conns []*connection.IRODSConnection
 
 		defer taskWaitGroup.Done()
 
-		// open the file with read-write mode
-		// to not seek to end
-		taskHandle, _, taskErr := OpenDataObjectWithReplicaToken(transferConn, irodsPath, resource, "w", replicaToken, resourceHierarchy, numTasks, fileLength, keywords)
-		if taskErr != nil {
-			errChan <- taskErr
-			return
-		}
-		defer func() {
-			errClose := CloseDataObjectReplica(transferConn, taskHandle)
-			if errClose != nil {
-				errChan <- errClose
-			}
-		}()
-
 		f, taskErr := os.OpenFile(localPath, os.O_RDONLY, 0)
 		if taskErr != nil {
 			errChan <- errors.Wrapf(taskErr, "failed to open file %q", localPath)
@@ -728,58 +825,117 @@ func UploadDataObjectParallelWithConnections(conns []*connection.IRODSConnection
 			_ = f.Close()
 		}()
 
-		taskNewOffset, taskErr := SeekDataObject(transferConn, taskHandle, taskOffset, types.SeekSet)
-		if taskErr != nil {
-			errChan <- taskErr
-			return
-		}
-
-		if taskNewOffset != taskOffset {
-			errChan <- errors.Errorf("failed to seek to target offset %d", taskOffset)
-			return
-		}
-
+		lastOffset := taskOffset
 		taskRemain := taskLength
 
-		// copy
 		buffer := make([]byte, common.ReadWriteBufferSize)
-		var taskWriteErr error
-		for taskRemain > 0 {
-			bufferLen := common.ReadWriteBufferSize
-			if taskRemain < int64(bufferLen) {
-				bufferLen = int(taskRemain)
+
+		attempt := func(attemptConn *connection.IRODSConnection) error {
+			// open the file with read-write mode
+			// to not seek to end
+			attemptHandle, _, openErr := OpenDataObjectWithReplicaToken(attemptConn, irodsPath, resource, "w", replicaToken, resourceHierarchy, numTasks, fileLength, keywords)
+			if openErr != nil {
+				return openErr
 			}
 
-			bytesRead, taskReadErr := f.ReadAt(buffer[:bufferLen], taskOffset+(taskLength-taskRemain))
-			if bytesRead > 0 {
-				taskWriteErr = WriteDataObjectWithTrackerCallBack(transferConn, taskHandle, buffer[:bytesRead], nil)
-				if taskWriteErr != nil {
-					break
+			attemptErr := func() error {
+				// seek to last offset, writes are positional so a partially written buffer is overwritten
+				if lastOffset != taskOffset {
+					taskLogger.Debugf("resuming uploading data object partition, last offset %d", lastOffset)
 				}
 
-				atomic.AddInt64(&totalBytesUploaded, int64(bytesRead))
-				if transferCallback != nil {
-					transferCallback("upload", atomic.LoadInt64(&totalBytesUploaded), fileLength)
+				newOffset, seekErr := SeekDataObject(attemptConn, attemptHandle, lastOffset, types.SeekSet)
+				if seekErr != nil {
+					return errors.Wrapf(seekErr, "failed to seek data object %q to offset %d", irodsPath, lastOffset)
 				}
 
-				taskRemain -= int64(bytesRead)
-			}
+				if newOffset != lastOffset {
+					return errors.Errorf("failed to seek to target offset %d", lastOffset)
+				}
 
-			if taskReadErr != nil {
-				if taskReadErr == io.EOF {
-					break
-				} else {
-					taskWriteErr = errors.Wrapf(taskReadErr, "failed to read file %q", localPath)
-					break
+				// copy
+				for taskRemain > 0 {
+					bufferLen := common.ReadWriteBufferSize
+					if taskRemain < int64(bufferLen) {
+						bufferLen = int(taskRemain)
+					}
+
+					bytesRead, readErr := f.ReadAt(buffer[:bufferLen], lastOffset)
+					if bytesRead > 0 {
+						writeErr := WriteDataObjectWithTrackerCallBack(attemptConn, attemptHandle, buffer[:bytesRead], nil)
+						if writeErr != nil {
+							return writeErr
+						}
+
+						atomic.AddInt64(&totalBytesUploaded, int64(bytesRead))
+						if transferCallback != nil {
+							transferCallback("upload", atomic.LoadInt64(&totalBytesUploaded), fileLength)
+						}
+
+						taskRemain -= int64(bytesRead)
+						lastOffset += int64(bytesRead)
+					}
+
+					if readErr != nil {
+						if readErr == io.EOF {
+							return nil
+						}
+
+						return errors.Wrapf(readErr, "failed to read file %q", localPath)
+					}
+
+					if len(errChan) > 0 {
+						// other tasks failed
+						return errors.Errorf("stop running as other tasks failed")
+					}
 				}
+
+				return nil
+			}()
+
+			if attemptConn.IsSocketFailed() || !attemptConn.IsConnected() {
+				// the replica is closed by the server when the connection is lost
+				return attemptErr
 			}
+
+			closeErr := CloseDataObjectReplica(attemptConn, attemptHandle)
+			if attemptErr != nil {
+				return attemptErr
+			}
+
+			return closeErr
 		}
 
-		if taskWriteErr != nil {
-			errChan <- taskWriteErr
+		retryErr := transferConn.GetRetryPolicy().GetTransferPolicy().Run(func(attemptNo int) error {
+			if attemptNo > 0 {
+				// retry
+				stats.addRetry()
+				taskLogger.Errorf("socket failed, retrying...")
+
+				connErr := transferConn.Reconnect()
+				if connErr != nil {
+					return errors.Wrapf(connErr, "failed to reconnect")
+				}
+
+				if !transferConn.IsConnected() {
+					return errors.Errorf("connection is disconnected")
+				}
+			}
+
+			attemptErr := attempt(transferConn)
+			if attemptErr != nil && transferConn.IsSocketFailed() {
+				return errors.Join(attemptErr, types.NewConnectionError())
+			}
+
+			return attemptErr
+		})
+		if retryErr != nil {
+			errChan <- retryErr
 		}
 	}
 
+	stats.setTaskNum(numTasks)
+
 	lengthPerThread := fileLength / int64(numTasks)
 	if fileLength%int64(numTasks) > 0 {
 		lengthPerThread++
@@ -787,6 +943,9 @@ func UploadDataObjectParallelWithConnections(conns []*connection.IRODSConnection
 
 	offset := int64(0)
 
+	// keep the control connection alive while it is idle
+	stopKeepAlive := keepControlConnectionAlive(controlConn)
+
 	for i := 0; i < numTasks; i++ {
 		taskWaitGroup.Add(1)
 
@@ -795,6 +954,7 @@ func UploadDataObjectParallelWithConnections(conns []*connection.IRODSConnection
 	}
 
 	taskWaitGroup.Wait()
+	stopKeepAlive()
 
 	if len(errChan) > 0 {
 		_ = CloseDataObject(controlConn, handle)
@@ -959,6 +1119,33 @@ func DownloadDataObjectToBufferWithConnection(conn *connection.IRODSConnection,
 	return nil
 }
 
+// createLocalFileForDownload creates the local file preallocated to the size before download tasks write ranges of the file
+// if truncate is false, data in the existing file is kept
+func createLocalFileForDownload(localPath string, size int64, truncate bool) error {
+	flag := os.O_RDWR | os.O_CREATE
+	if truncate {
+		flag |= os.O_TRUNC
+	}
+
+	f, err := os.OpenFile(localPath, flag, 0666)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create file %q", localPath)
+	}
+
+	err = system.PreallocateFile(f, size)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return errors.Wrapf(err, "failed to close file %q", localPath)
+	}
+
+	return nil
+}
+
 // DownloadDataObject downloads a data object at the iRODS path to the local path
 func DownloadDataObject(sess *session.IRODSSession, dataObject *types.IRODSDataObject, resource string, localPath string, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) error {
 	return DownloadDataObjectParallel(sess, dataObject, resource, localPath, 1, keywords, transferCallback)
@@ -981,9 +1168,31 @@ func DownloadDataObjectResumableWithConnection(conn *connection.IRODSConnection,
 	return DownloadDataObjectParallelResumableWithConnections(conns, dataObject, resource, localPath, keywords, transferCallback)
 }
 
+// DownloadDataObjectResumableWithRangesAndStats downloads a data object at the iRODS path to the local path with support of transfer resume
+// Returns the status of the range downloaded, which can be used to repair the local file with RepairDataObjectRanges
+// Statistics of the transfer are collected to stats if it is not nil
+func DownloadDataObjectResumableWithRangesAndStats(sess *session.IRODSSession, dataObject *types.IRODSDataObject, resource string, localPath string, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback, stats *DataObjectTransferStats) ([]*DataObjectTransferStatusEntry, error) {
+	return DownloadDataObjectParallelResumableWithRangesAndStats(sess, dataObject, resource, localPath, 1, keywords, transferCallback, stats)
+}
+
+// DownloadDataObjectResumableWithConnectionRangesAndStats downloads a data object at the iRODS path to the local path with support of transfer resume
+// Returns the status of the range downloaded, which can be used to repair the local file with RepairDataObjectRangesWithConnection
+// Statistics of the transfer are collected to stats if it is not nil
+func DownloadDataObjectResumableWithConnectionRangesAndStats(conn *connection.IRODSConnection, dataObject *types.IRODSDataObject, resource string, localPath string, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback, stats *DataObjectTransferStats) ([]*DataObjectTransferStatusEntry, error) {
+	conns := []*connection.IRODSConnection{conn}
+	return DownloadDataObjectParallelResumableWithConnectionsRangesAndStats(conns, dataObject, resource, localPath, keywords, transferCallback, stats)
+}
+
 // DownloadDataObjectParallel downloads a data object at the iRODS path to the local path in parallel
 // Partitions a file into n (taskNum) tasks and downloads in parallel
 func DownloadDataObjectParallel(sess *session.IRODSSession, dataObject *types.IRODSDataObject, resource string, localPath string, taskNum int, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) error {
+	return DownloadDataObjectParallelWithStats(sess, dataObject, resource, localPath, taskNum, keywords, transferCallback, nil)
+}
+
+// DownloadDataObjectParallelWithStats downloads a data object at the iRODS path to the local path in parallel
+// Partitions a file into n (taskNum) tasks and downloads in parallel
+// Statistics of the transfer, such as the number of tasks and retries, are collected to stats if it is not nil
+func DownloadDataObjectParallelWithStats(sess *session.IRODSSession, dataObject *types.IRODSDataObject, resource string, localPath string, taskNum int, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback, stats *DataObjectTransferStats) error {
 	logger := log.WithFields(log.Fields{
 		"irods_path": dataObject.Path,
 		"resource":   resource,
@@ -1042,14 +1251,10 @@ func DownloadDataObjectParallel(sess *session.IRODSSession, dataObject *types.IR
 
 	logger.Debugf("downloading data object in parallel %s, size(%d), threads(%d)", dataObject.Path, dataObject.Size, numTasks)
 
-	// create an empty file
-	f, err := os.Create(localPath)
-	if err != nil {
-		return errors.Wrapf(err, "failed to create file %q", localPath)
-	}
-	err = f.Close()
+	// create a file preallocated to the full size, fails early if there is not enough disk space
+	err = createLocalFileForDownload(localPath, dataObject.Size, true)
 	if err != nil {
-		return errors.Wrapf(err, "failed to close file %q", localPath)
+		return err
 	}
 
 	errChan := make(chan error, numTasks)
@@ -1189,35 +1394,36 @@ func DownloadDataObjectParallel(sess *session.IRODSSession, dataObject *types.IR
 			return nil
 		}
 
-		for {
-			attemptErr := attempt(transferConn)
-			if attemptErr == nil {
-				// done downloading
-				return
-			}
-
-			if transferConn.IsSocketFailed() {
+		retryErr := transferConn.GetRetryPolicy().GetTransferPolicy().Run(func(attemptNo int) error {
+			if attemptNo > 0 {
 				// retry
-				taskLogger.WithError(attemptErr).Errorf("socket failed, retrying...")
+				stats.addRetry()
+				taskLogger.Errorf("socket failed, retrying...")
 
 				connErr := transferConn.Reconnect()
 				if connErr != nil {
-					errChan <- errors.Wrapf(connErr, "failed to reconnect")
-					return
+					return errors.Wrapf(connErr, "failed to reconnect")
 				}
 
 				if !transferConn.IsConnected() {
-					errChan <- errors.Errorf("connection is disconnected")
-					return
+					return errors.Errorf("connection is disconnected")
 				}
-			} else {
-				// other errors
-				errChan <- attemptErr
-				return
 			}
+
+			attemptErr := attempt(transferConn)
+			if attemptErr != nil && transferConn.IsSocketFailed() {
+				return errors.Join(attemptErr, types.NewConnectionError())
+			}
+
+			return attemptErr
+		})
+		if retryErr != nil {
+			errChan <- retryErr
 		}
 	}
 
+	stats.setTaskNum(numTasks)
+
 	lengthPerThread := dataObject.Size / int64(numTasks)
 	if dataObject.Size%int64(numTasks) > 0 {
 		lengthPerThread++
@@ -1244,6 +1450,13 @@ func DownloadDataObjectParallel(sess *session.IRODSSession, dataObject *types.IR
 // DownloadDataObjectParallelWithConnections downloads a data object at the iRODS path to the local path in parallel
 // Partitions a file into n (taskNum) tasks and downloads in parallel
 func DownloadDataObjectParallelWithConnections(conns []*connection.IRODSConnection, dataObject *types.IRODSDataObject, resource string, localPath string, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) error {
+	return DownloadDataObjectParallelWithConnectionsAndStats(conns, dataObject, resource, localPath, keywords, transferCallback, nil)
+}
+
+// DownloadDataObjectParallelWithConnectionsAndStats downloads a data object at the iRODS path to the local path in parallel
+// Partitions a file into n (taskNum) tasks and downloads in parallel
+// Statistics of the transfer, such as the number of tasks and retries, are collected to stats if it is not nil
+func DownloadDataObjectParallelWithConnectionsAndStats(conns []*connection.IRODSConnection, dataObject *types.IRODSDataObject, resource string, localPath string, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback, stats *DataObjectTransferStats) error {
 	logger := log.WithFields(log.Fields{
 		"irods_path": dataObject.Path,
 		"resource":   resource,
@@ -1285,14 +1498,10 @@ func DownloadDataObjectParallelWithConnections(conns []*connection.IRODSConnecti
 
 	logger.Debugf("downloading data object in parallel, size(%d), threads(%d)", dataObject.Size, numTasks)
 
-	// create an empty file
-	f, err := os.Create(localPath)
+	// create a file preallocated to the full size, fails early if there is not enough disk space
+	err := createLocalFileForDownload(localPath, dataObject.Size, true)
 	if err != nil {
-		return errors.Wrapf(err, "failed to create file %q", localPath)
-	}
-	err = f.Close()
-	if err != nil {
-		return errors.Wrapf(err, "failed to close file %q", localPath)
+		return err
 	}
 
 	errChan := make(chan error, numTasks)
@@ -1428,35 +1637,36 @@ func DownloadDataObjectParallelWithConnections(conns []*connection.IRODSConnecti
 			return nil
 		}
 
-		for {
-			attemptErr := attempt(transferConn)
-			if attemptErr == nil {
-				// done downloading
-				return
-			}
-
-			if transferConn.IsSocketFailed() {
+		retryErr := transferConn.GetRetryPolicy().GetTransferPolicy().Run(func(attemptNo int) error {
+			if attemptNo > 0 {
 				// retry
-				taskLogger.WithError(attemptErr).Errorf("socket failed, retrying...")
+				stats.addRetry()
+				taskLogger.Errorf("socket failed, retrying...")
 
 				connErr := transferConn.Reconnect()
 				if connErr != nil {
-					errChan <- errors.Wrapf(connErr, "failed to reconnect")
-					return
+					return errors.Wrapf(connErr, "failed to reconnect")
 				}
 
 				if !transferConn.IsConnected() {
-					errChan <- errors.Errorf("connection is disconnected")
-					return
+					return errors.Errorf("connection is disconnected")
 				}
-			} else {
-				// other errors
-				errChan <- attemptErr
-				return
 			}
+
+			attemptErr := attempt(transferConn)
+			if attemptErr != nil && transferConn.IsSocketFailed() {
+				return errors.Join(attemptErr, types.NewConnectionError())
+			}
+
+			return attemptErr
+		})
+		if retryErr != nil {
+			errChan <- retryErr
 		}
 	}
 
+	stats.setTaskNum(numTasks)
+
 	lengthPerThread := dataObject.Size / int64(numTasks)
 	if dataObject.Size%int64(numTasks) > 0 {
 		lengthPerThread++
@@ -1484,6 +1694,20 @@ func DownloadDataObjectParallelWithConnections(conns []*connection.IRODSConnecti
 // Partitions a file into n (taskNum) tasks and downloads in parallel
 // TODO: Need to partition a file in small chunks so that different number of tasks can be used to continue downloading
 func DownloadDataObjectParallelResumable(sess *session.IRODSSession, dataObject *types.IRODSDataObject, resource string, localPath string, taskNum int, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) error {
+	_, err := DownloadDataObjectParallelResumableWithRanges(sess, dataObject, resource, localPath, taskNum, keywords, transferCallback)
+	return err
+}
+
+// DownloadDataObjectParallelResumableWithRanges downloads a data object at the iRODS path to the local path in parallel with support of transfer resume
+// Returns statuses of the ranges downloaded by tasks, which can be used to repair corrupted ranges with RepairDataObjectRanges
+func DownloadDataObjectParallelResumableWithRanges(sess *session.IRODSSession, dataObject *types.IRODSDataObject, resource string, localPath string, taskNum int, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) ([]*DataObjectTransferStatusEntry, error) {
+	return DownloadDataObjectParallelResumableWithRangesAndStats(sess, dataObject, resource, localPath, taskNum, keywords, transferCallback, nil)
+}
+
+// DownloadDataObjectParallelResumableWithRangesAndStats downloads a data object at the iRODS path to the local path in parallel with support of transfer resume
+// Returns statuses of the ranges downloaded by tasks, which can be used to repair corrupted ranges with RepairDataObjectRanges
+// Statistics of the transfer, such as the number of tasks and retries, are collected to stats if it is not nil
+func DownloadDataObjectParallelResumableWithRangesAndStats(sess *session.IRODSSession, dataObject *types.IRODSDataObject, resource string, localPath string, taskNum int, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback, stats *DataObjectTransferStats) ([]*DataObjectTransferStatusEntry, error) {
 	logger := log.WithFields(log.Fields{
 		"irods_path": dataObject.Path,
 		"resource":   resource,
@@ -1502,13 +1726,13 @@ func DownloadDataObjectParallelResumable(sess *session.IRODSSession, dataObject
 		// create an empty file
 		f, err := os.Create(localPath)
 		if err != nil {
-			return errors.Wrapf(err, "failed to create file %q", localPath)
+			return nil, errors.Wrapf(err, "failed to create file %q", localPath)
 		}
 		err = f.Close()
 		if err != nil {
-			return errors.Wrapf(err, "failed to close file %q", localPath)
+			return nil, errors.Wrapf(err, "failed to close file %q", localPath)
 		}
-		return nil
+		return []*DataObjectTransferStatusEntry{}, nil
 	}
 
 	numTasks := taskNum
@@ -1522,7 +1746,7 @@ func DownloadDataObjectParallelResumable(sess *session.IRODSSession, dataObject
 	transferConns, err := sess.AcquireConnectionsMulti(numTasks, false)
 	if err != nil {
 		if len(transferConns) == 0 {
-			return errors.Wrapf(err, "failed to get %d connections, got %d", numTasks, len(transferConns))
+			return nil, errors.Wrapf(err, "failed to get %d connections, got %d", numTasks, len(transferConns))
 		}
 
 		logger.WithError(err).Debugf("failed to get %d connections, got %d", numTasks, len(transferConns))
@@ -1530,7 +1754,7 @@ func DownloadDataObjectParallelResumable(sess *session.IRODSSession, dataObject
 
 	for _, conn := range transferConns {
 		if conn == nil || !conn.IsConnected() {
-			return errors.Errorf("connection is nil or disconnected")
+			return nil, errors.Errorf("connection is nil or disconnected")
 		}
 	}
 
@@ -1543,30 +1767,27 @@ func DownloadDataObjectParallelResumable(sess *session.IRODSSession, dataObject
 	// create transfer status
 	transferStatusLocal, err := GetOrNewDataObjectTransferStatusLocal(localPath, dataObject.Size, numTasks)
 	if err != nil {
-		return errors.Wrapf(err, "failed to read transfer status file for %q", localPath)
+		return nil, errors.Wrapf(err, "failed to read transfer status file for %q", localPath)
 	}
 
 	logger.Debugf("downloading data object in parallel, size(%d), threads(%d)", dataObject.Size, numTasks)
 
 	err = transferStatusLocal.CreateStatusFile()
 	if err != nil {
-		return errors.Wrapf(err, "failed to create transfer status file for %q", localPath)
+		return nil, errors.Wrapf(err, "failed to create transfer status file for %q", localPath)
 	}
 
 	err = transferStatusLocal.WriteHeader()
 	if err != nil {
 		transferStatusLocal.CloseStatusFile() //nolint
-		return errors.Wrapf(err, "failed to write transfer status file header for %q", localPath)
+		return nil, errors.Wrapf(err, "failed to write transfer status file header for %q", localPath)
 	}
 
-	// create an empty file
-	f, err := os.OpenFile(localPath, os.O_RDWR|os.O_CREATE, 0666)
-	if err != nil {
-		return errors.Wrapf(err, "failed to create file %q", localPath)
-	}
-	err = f.Close()
+	// create a file preallocated to the full size, fails early if there is not enough disk space
+	// data downloaded before is kept to resume
+	err = createLocalFileForDownload(localPath, dataObject.Size, false)
 	if err != nil {
-		return errors.Wrapf(err, "failed to close file %q", localPath)
+		return nil, err
 	}
 
 	errChan := make(chan error, numTasks)
@@ -1575,6 +1796,7 @@ func DownloadDataObjectParallelResumable(sess *session.IRODSSession, dataObject
 	currentBytesDownloaded := make([]int64, numTasks)
 	bytesDownloaded := make([]int64, numTasks)
 	totalBytesDownloaded := int64(0)
+	rangeStatuses := make([]*DataObjectTransferStatusEntry, numTasks)
 	if transferCallback != nil {
 		transferCallback("download", atomic.LoadInt64(&totalBytesDownloaded), dataObject.Size)
 	}
@@ -1611,12 +1833,24 @@ func DownloadDataObjectParallelResumable(sess *session.IRODSSession, dataObject
 		// find last failure point
 		transferStatus := transferStatusLocal.GetStatus()
 		lastOffset := int64(taskOffset)
+		rangeStatuses[taskID] = &DataObjectTransferStatusEntry{
+			StartOffset: taskOffset,
+			Length:      taskLength,
+		}
 		if transferStatus != nil {
 			if transferStatusEntry, ok := transferStatus.StatusMap[taskOffset]; ok {
 				lastOffset = transferStatusEntry.StartOffset + transferStatusEntry.CompletedLength
+				rangeStatuses[taskID] = transferStatusEntry
 			}
 		}
 
+		// hash of the range is known only if the range is downloaded from the beginning
+		rangeLength := getTransferRangeLength(taskOffset, taskLength, dataObject.Size)
+		var rangeHash hash.Hash
+		if lastOffset == taskOffset {
+			rangeHash = md5.New()
+		}
+
 		calcProgress := func() {
 			newTotal := int64(0)
 			for i := 0; i < numTasks; i++ {
@@ -1692,6 +1926,10 @@ func DownloadDataObjectParallelResumable(sess *session.IRODSSession, dataObject
 						return errors.Wrapf(attemptWriteErr, "failed to write to file %q from task %d", localPath, taskID)
 					}
 
+					if rangeHash != nil {
+						rangeHash.Write(buffer[:bytesRead])
+					}
+
 					atomic.StoreInt64(&currentBytesDownloaded[taskID], 0)
 					atomic.AddInt64(&bytesDownloaded[taskID], int64(bytesRead))
 
@@ -1703,7 +1941,11 @@ func DownloadDataObjectParallelResumable(sess *session.IRODSSession, dataObject
 						Length:          taskLength,
 						CompletedLength: (taskLength - taskRemain) + int64(bytesRead),
 					}
+					if rangeHash != nil && transferStatusEntry.CompletedLength >= rangeLength {
+						transferStatusEntry.Checksum = rangeHash.Sum(nil)
+					}
 					transferStatusLocal.WriteStatus(transferStatusEntry) //nolint
+					rangeStatuses[taskID] = transferStatusEntry
 
 					taskRemain -= int64(bytesRead)
 					lastOffset += int64(bytesRead)
@@ -1726,35 +1968,36 @@ func DownloadDataObjectParallelResumable(sess *session.IRODSSession, dataObject
 			return nil
 		}
 
-		for {
-			attemptErr := attempt(transferConn)
-			if attemptErr == nil {
-				// done downloading
-				return
-			}
-
-			if transferConn.IsSocketFailed() {
+		retryErr := transferConn.GetRetryPolicy().GetTransferPolicy().Run(func(attemptNo int) error {
+			if attemptNo > 0 {
 				// retry
-				taskLogger.WithError(attemptErr).Errorf("socket failed, retrying...")
+				stats.addRetry()
+				taskLogger.Errorf("socket failed, retrying...")
 
 				connErr := transferConn.Reconnect()
 				if connErr != nil {
-					errChan <- errors.Wrapf(connErr, "failed to reconnect")
-					return
+					return errors.Wrapf(connErr, "failed to reconnect")
 				}
 
 				if !transferConn.IsConnected() {
-					errChan <- errors.Errorf("connection is disconnected")
-					return
+					return errors.Errorf("connection is disconnected")
 				}
-			} else {
-				// other errors
-				errChan <- attemptErr
-				return
 			}
+
+			attemptErr := attempt(transferConn)
+			if attemptErr != nil && transferConn.IsSocketFailed() {
+				return errors.Join(attemptErr, types.NewConnectionError())
+			}
+
+			return attemptErr
+		})
+		if retryErr != nil {
+			errChan <- retryErr
 		}
 	}
 
+	stats.setTaskNum(numTasks)
+
 	lengthPerThread := dataObject.Size / int64(numTasks)
 	if dataObject.Size%int64(numTasks) > 0 {
 		lengthPerThread++
@@ -1773,26 +2016,40 @@ func DownloadDataObjectParallelResumable(sess *session.IRODSSession, dataObject
 
 	if len(errChan) > 0 {
 		_ = transferStatusLocal.CloseStatusFile()
-		return <-errChan
+		return nil, <-errChan
 	}
 
 	err = transferStatusLocal.CloseStatusFile()
 	if err != nil {
-		return errors.Wrapf(err, "failed to close status file")
+		return nil, errors.Wrapf(err, "failed to close status file")
 	}
 
 	err = transferStatusLocal.DeleteStatusFile()
 	if err != nil {
-		return errors.Wrapf(err, "failed to delete status file")
+		return nil, errors.Wrapf(err, "failed to delete status file")
 	}
 
-	return nil
+	return rangeStatuses, nil
 }
 
-// DownloadDataObjectParallelResumable downloads a data object at the iRODS path to the local path in parallel with support of transfer resume
+// DownloadDataObjectParallelResumableWithConnections downloads a data object at the iRODS path to the local path in parallel with support of transfer resume
 // Partitions a file into n (taskNum) tasks and downloads in parallel
 // TODO: Need to partition a file in small chunks so that different number of tasks can be used to continue downloading
 func DownloadDataObjectParallelResumableWithConnections(conns []*connection.IRODSConnection, dataObject *types.IRODSDataObject, resource string, localPath string, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) error {
+	_, err := DownloadDataObjectParallelResumableWithConnectionsAndRanges(conns, dataObject, resource, localPath, keywords, transferCallback)
+	return err
+}
+
+// DownloadDataObjectParallelResumableWithConnectionsAndRanges downloads a data object at the iRODS path to the local path in parallel with support of transfer resume
+// Returns statuses of the ranges downloaded by tasks, which can be used to repair corrupted ranges with RepairDataObjectRangesWithConnection
+func DownloadDataObjectParallelResumableWithConnectionsAndRanges(conns []*connection.IRODSConnection, dataObject *types.IRODSDataObject, resource string, localPath string, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) ([]*DataObjectTransferStatusEntry, error) {
+	return DownloadDataObjectParallelResumableWithConnectionsRangesAndStats(conns, dataObject, resource, localPath, keywords, transferCallback, nil)
+}
+
+// DownloadDataObjectParallelResumableWithConnectionsRangesAndStats downloads a data object at the iRODS path to the local path in parallel with support of transfer resume
+// Returns statuses of the ranges downloaded by tasks, which can be used to repair corrupted ranges with RepairDataObjectRangesWithConnection
+// Statistics of the transfer, such as the number of tasks and retries, are collected to stats if it is not nil
+func DownloadDataObjectParallelResumableWithConnectionsRangesAndStats(conns []*connection.IRODSConnection, dataObject *types.IRODSDataObject, resource string, localPath string, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback, stats *DataObjectTransferStats) ([]*DataObjectTransferStatusEntry, error) {
 	logger := log.WithFields(log.Fields{
 		"irods_path": dataObject.Path,
 		"resource":   resource,
@@ -1800,12 +2057,12 @@ func DownloadDataObjectParallelResumableWithConnections(conns []*connection.IROD
 	})
 
 	if len(conns) == 0 {
-		return errors.Errorf("no connections provided")
+		return nil, errors.Errorf("no connections provided")
 	}
 
 	for _, conn := range conns {
 		if conn == nil || !conn.IsConnected() {
-			return errors.Errorf("connection is nil or disconnected")
+			return nil, errors.Errorf("connection is nil or disconnected")
 		}
 	}
 
@@ -1820,13 +2077,13 @@ func DownloadDataObjectParallelResumableWithConnections(conns []*connection.IROD
 		// create an empty file
 		f, err := os.Create(localPath)
 		if err != nil {
-			return errors.Wrapf(err, "failed to create file %q", localPath)
+			return nil, errors.Wrapf(err, "failed to create file %q", localPath)
 		}
 		err = f.Close()
 		if err != nil {
-			return errors.Wrapf(err, "failed to close file %q", localPath)
+			return nil, errors.Wrapf(err, "failed to close file %q", localPath)
 		}
-		return nil
+		return []*DataObjectTransferStatusEntry{}, nil
 	}
 
 	transferConns := conns[:]
@@ -1835,30 +2092,27 @@ func DownloadDataObjectParallelResumableWithConnections(conns []*connection.IROD
 	// create transfer status
 	transferStatusLocal, err := GetOrNewDataObjectTransferStatusLocal(localPath, dataObject.Size, numTasks)
 	if err != nil {
-		return errors.Wrapf(err, "failed to read transfer status file for %q", localPath)
+		return nil, errors.Wrapf(err, "failed to read transfer status file for %q", localPath)
 	}
 
 	logger.Debug("downloading data object in parallel")
 
 	err = transferStatusLocal.CreateStatusFile()
 	if err != nil {
-		return errors.Wrapf(err, "failed to create transfer status file for %q", localPath)
+		return nil, errors.Wrapf(err, "failed to create transfer status file for %q", localPath)
 	}
 
 	err = transferStatusLocal.WriteHeader()
 	if err != nil {
 		transferStatusLocal.CloseStatusFile() //nolint
-		return errors.Wrapf(err, "failed to write transfer status file header for %q", localPath)
+		return nil, errors.Wrapf(err, "failed to write transfer status file header for %q", localPath)
 	}
 
-	// create an empty file
-	f, err := os.OpenFile(localPath, os.O_RDWR|os.O_CREATE, 0666)
-	if err != nil {
-		return errors.Wrapf(err, "failed to create file %q", localPath)
-	}
-	err = f.Close()
+	// create a file preallocated to the full size, fails early if there is not enough disk space
+	// data downloaded before is kept to resume
+	err = createLocalFileForDownload(localPath, dataObject.Size, false)
 	if err != nil {
-		return errors.Wrapf(err, "failed to close file %q", localPath)
+		return nil, err
 	}
 
 	errChan := make(chan error, numTasks)
@@ -1867,6 +2121,7 @@ func DownloadDataObjectParallelResumableWithConnections(conns []*connection.IROD
 	currentBytesDownloaded := make([]int64, numTasks)
 	bytesDownloaded := make([]int64, numTasks)
 	totalBytesDownloaded := int64(0)
+	rangeStatuses := make([]*DataObjectTransferStatusEntry, numTasks)
 	if transferCallback != nil {
 		transferCallback("download", atomic.LoadInt64(&totalBytesDownloaded), dataObject.Size)
 	}
@@ -1899,12 +2154,24 @@ func DownloadDataObjectParallelResumableWithConnections(conns []*connection.IROD
 		// find last failure point
 		transferStatus := transferStatusLocal.GetStatus()
 		lastOffset := int64(taskOffset)
+		rangeStatuses[taskID] = &DataObjectTransferStatusEntry{
+			StartOffset: taskOffset,
+			Length:      taskLength,
+		}
 		if transferStatus != nil {
 			if transferStatusEntry, ok := transferStatus.StatusMap[taskOffset]; ok {
 				lastOffset = transferStatusEntry.StartOffset + transferStatusEntry.CompletedLength
+				rangeStatuses[taskID] = transferStatusEntry
 			}
 		}
 
+		// hash of the range is known only if the range is downloaded from the beginning
+		rangeLength := getTransferRangeLength(taskOffset, taskLength, dataObject.Size)
+		var rangeHash hash.Hash
+		if lastOffset == taskOffset {
+			rangeHash = md5.New()
+		}
+
 		calcProgress := func() {
 			newTotal := int64(0)
 			for i := 0; i < numTasks; i++ {
@@ -1980,6 +2247,10 @@ func DownloadDataObjectParallelResumableWithConnections(conns []*connection.IROD
 						return errors.Wrapf(attemptWriteErr, "failed to write to file %q from task %d", localPath, taskID)
 					}
 
+					if rangeHash != nil {
+						rangeHash.Write(buffer[:bytesRead])
+					}
+
 					atomic.StoreInt64(&currentBytesDownloaded[taskID], 0)
 					atomic.AddInt64(&bytesDownloaded[taskID], int64(bytesRead))
 
@@ -1991,7 +2262,11 @@ func DownloadDataObjectParallelResumableWithConnections(conns []*connection.IROD
 						Length:          taskLength,
 						CompletedLength: (taskLength - taskRemain) + int64(bytesRead),
 					}
+					if rangeHash != nil && transferStatusEntry.CompletedLength >= rangeLength {
+						transferStatusEntry.Checksum = rangeHash.Sum(nil)
+					}
 					transferStatusLocal.WriteStatus(transferStatusEntry) //nolint
+					rangeStatuses[taskID] = transferStatusEntry
 
 					taskRemain -= int64(bytesRead)
 					lastOffset += int64(bytesRead)
@@ -2014,35 +2289,36 @@ func DownloadDataObjectParallelResumableWithConnections(conns []*connection.IROD
 			return nil
 		}
 
-		for {
-			attemptErr := attempt(transferConn)
-			if attemptErr == nil {
-				// done downloading
-				return
-			}
-
-			if transferConn.IsSocketFailed() {
+		retryErr := transferConn.GetRetryPolicy().GetTransferPolicy().Run(func(attemptNo int) error {
+			if attemptNo > 0 {
 				// retry
-				taskLogger.WithError(attemptErr).Errorf("socket failed, retrying...")
+				stats.addRetry()
+				taskLogger.Errorf("socket failed, retrying...")
 
 				connErr := transferConn.Reconnect()
 				if connErr != nil {
-					errChan <- errors.Wrapf(connErr, "failed to reconnect")
-					return
+					return errors.Wrapf(connErr, "failed to reconnect")
 				}
 
 				if !transferConn.IsConnected() {
-					errChan <- errors.Errorf("connection is disconnected")
-					return
+					return errors.Errorf("connection is disconnected")
 				}
-			} else {
-				// other errors
-				errChan <- attemptErr
-				return
 			}
+
+			attemptErr := attempt(transferConn)
+			if attemptErr != nil && transferConn.IsSocketFailed() {
+				return errors.Join(attemptErr, types.NewConnectionError())
+			}
+
+			return attemptErr
+		})
+		if retryErr != nil {
+			errChan <- retryErr
 		}
 	}
 
+	stats.setTaskNum(numTasks)
+
 	lengthPerThread := dataObject.Size / int64(numTasks)
 	if dataObject.Size%int64(numTasks) > 0 {
 		lengthPerThread++
@@ -2061,18 +2337,18 @@ func DownloadDataObjectParallelResumableWithConnections(conns []*connection.IROD
 
 	if len(errChan) > 0 {
 		_ = transferStatusLocal.CloseStatusFile()
-		return <-errChan
+		return nil, <-errChan
 	}
 
 	err = transferStatusLocal.CloseStatusFile()
 	if err != nil {
-		return errors.Wrapf(err, "failed to close status file")
+		return nil, errors.Wrapf(err, "failed to close status file")
 	}
 
 	err = transferStatusLocal.DeleteStatusFile()
 	if err != nil {
-		return errors.Wrapf(err, "failed to delete status file")
+		return nil, errors.Wrapf(err, "failed to delete status file")
 	}
 
-	return nil
+	return rangeStatuses, nil
 }
@@ -0,0 +1,65 @@
+package fs
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	log "github.com/sirupsen/logrus"
+)
+
+// KeepConnectionAlive pings the connection periodically while it keeps a data object open but is idle, e.g., between chunks of an upload,
+// the connection can be used meanwhile as pings lock the connection
+// returns a function to stop pinging
+func KeepConnectionAlive(conn *connection.IRODSConnection) func() {
+	return keepControlConnectionAlive(conn)
+}
+
+// keepControlConnectionAlive pings the control connection periodically while it is idle during a parallel transfer,
+// so firewalls/NAT do not drop it before the data object is closed
+// returns a function to stop pinging, it must be called before the control connection is used again
+func keepControlConnectionAlive(controlConn *connection.IRODSConnection) func() {
+	interval := controlConn.GetControlKeepAliveInterval()
+	if interval <= 0 {
+		return func() {}
+	}
+
+	logger := log.WithFields(log.Fields{
+		"interval": interval,
+	})
+
+	stopChan := make(chan bool)
+	waitGroup := sync.WaitGroup{}
+	waitGroup.Add(1)
+
+	go func() {
+		defer waitGroup.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stopChan:
+				return
+			case <-ticker.C:
+				controlConn.Lock()
+				err := controlConn.Ping()
+				controlConn.Unlock()
+
+				if err != nil {
+					// the final close reports the error if the connection is really lost
+					logger.WithError(err).Debug("failed to ping the control connection")
+				}
+			}
+		}
+	}()
+
+	stopOnce := sync.Once{}
+	return func() {
+		stopOnce.Do(func() {
+			close(stopChan)
+			waitGroup.Wait()
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package fs
+
+import (
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+// SetReplicaStatus sets the status of a replica of a data object, e.g., marks a replica stale or good
+// this requires rodsadmin privilege in most server configurations, set adminFlag for that
+func SetReplicaStatus(conn *connection.IRODSConnection, path string, replicaNumber int, status types.ReplicaStatus, adminFlag bool) error {
+	keywords := map[common.KeyWord]string{
+		common.REPL_STATUS_KW: string(status),
+	}
+
+	return modifyDataObjectMeta(conn, path, replicaNumber, keywords, adminFlag)
+}
+
+// ModifyDataObjectSystemMetadata modifies system metadata of a replica of a data object, e.g., after the physical file is changed out-of-band
+// nil fields of metadata are not modified, this requires rodsadmin privilege in most server configurations, set adminFlag for that
+func ModifyDataObjectSystemMetadata(conn *connection.IRODSConnection, path string, replicaNumber int, metadata *types.IRODSDataObjectSystemMetadata, adminFlag bool) error {
+	if metadata == nil || metadata.IsEmpty() {
+		return errors.Errorf("no system metadata to modify for data object %q", path)
+	}
+
+	keywords := map[common.KeyWord]string{}
+
+	if metadata.Size != nil {
+		if *metadata.Size < 0 {
+			return errors.Errorf("invalid data size %d", *metadata.Size)
+		}
+		keywords[common.DATA_SIZE_KW] = strconv.FormatInt(*metadata.Size, 10)
+	}
+
+	if metadata.DataType != nil {
+		keywords[common.DATA_TYPE_KW] = *metadata.DataType
+	}
+
+	if metadata.Comments != nil {
+		keywords[common.DATA_COMMENTS_KW] = *metadata.Comments
+	}
+
+	if metadata.ModifyTime != nil {
+		keywords[common.DATA_MODIFY_KW] = util.GetIRODSDateTimeString(*metadata.ModifyTime)
+	}
+
+	if metadata.ExpiryTime != nil {
+		keywords[common.DATA_EXPIRY_KW] = util.GetIRODSDateTimeString(*metadata.ExpiryTime)
+	}
+
+	return modifyDataObjectMeta(conn, path, replicaNumber, keywords, adminFlag)
+}
+
+// modifyDataObjectMeta modifies system metadata of a replica of a data object
+func modifyDataObjectMeta(conn *connection.IRODSConnection, path string, replicaNumber int, keywords map[common.KeyWord]string, adminFlag bool) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	// the server finds the replica by data id and resource hierarchy
+	dataObject, err := GetDataObject(conn, path)
+	if err != nil {
+		return err
+	}
+
+	var replica *types.IRODSReplica
+	for _, dataObjectReplica := range dataObject.Replicas {
+		if dataObjectReplica.Number == int64(replicaNumber) {
+			replica = dataObjectReplica
+			break
+		}
+	}
+
+	if replica == nil {
+		newErr := types.NewFileNotFoundError(path)
+		return errors.Wrapf(newErr, "failed to find the replica %d of data object %q", replicaNumber, path)
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForDataObjectUpdate(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	request := message.NewIRODSMessageModifyDataObjectMetaRequest(path, dataObject.ID, replicaNumber, replica.ResourceHierarchy)
+	for key, val := range keywords {
+		request.AddKeyVal(key, val)
+	}
+
+	if adminFlag {
+		request.AddKeyVal(common.ADMIN_KW, "")
+	}
+
+	response := message.IRODSMessageModifyDataObjectMetaResponse{}
+	err = conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_FILE {
+			newErr := errors.Join(err, types.NewFileNotFoundError(path))
+			return errors.Wrapf(newErr, "failed to find the data object for path %q", path)
+		}
+
+		return errors.Wrapf(err, "failed to modify system metadata of data object %q", path)
+	}
+	return nil
+}
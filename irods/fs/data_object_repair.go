@@ -0,0 +1,201 @@
+package fs
+
+import (
+	"bytes"
+	"crypto/md5"
+	"io"
+	"os"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/session"
+	"github.com/cyverse/go-irodsclient/irods/types"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// getTransferRangeLength returns the length of the range in the data object of the size, the length of the last task may exceed the size
+func getTransferRangeLength(startOffset int64, length int64, size int64) int64 {
+	if startOffset >= size {
+		return 0
+	}
+
+	if startOffset+length > size {
+		return size - startOffset
+	}
+
+	return length
+}
+
+// RepairDataObjectRanges repairs corrupted ranges of the local file downloaded with DownloadDataObjectParallelResumableWithRanges
+// Ranges are read again from the data object and compared with the local file, so data corrupted in transit is detected, only corrupted parts are written
+// The whole data object is used as a range if no ranges are given
+// Returns statuses of the repaired ranges, the statuses given are updated
+func RepairDataObjectRanges(sess *session.IRODSSession, dataObject *types.IRODSDataObject, resource string, localPath string, rangeStatuses []*DataObjectTransferStatusEntry, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) ([]*DataObjectTransferStatusEntry, error) {
+	// use default resource when resource param is empty
+	if len(resource) == 0 {
+		account := sess.GetAccount()
+		resource = account.DefaultResource
+	}
+
+	conn, err := sess.AcquireConnection(true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get connection")
+	}
+
+	defer func() {
+		_ = sess.ReturnConnection(conn)
+	}()
+
+	return RepairDataObjectRangesWithConnection(conn, dataObject, resource, localPath, rangeStatuses, keywords, transferCallback)
+}
+
+// RepairDataObjectRangesWithConnection repairs corrupted ranges of the local file downloaded with DownloadDataObjectParallelResumableWithConnectionsAndRanges
+// Ranges are read again from the data object and compared with the local file, so data corrupted in transit is detected, only corrupted parts are written
+// The whole data object is used as a range if no ranges are given
+// Returns statuses of the repaired ranges, the statuses given are updated
+func RepairDataObjectRangesWithConnection(conn *connection.IRODSConnection, dataObject *types.IRODSDataObject, resource string, localPath string, rangeStatuses []*DataObjectTransferStatusEntry, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) ([]*DataObjectTransferStatusEntry, error) {
+	logger := log.WithFields(log.Fields{
+		"irods_path": dataObject.Path,
+		"resource":   resource,
+		"local_path": localPath,
+	})
+
+	if conn == nil || !conn.IsConnected() {
+		return nil, errors.Errorf("connection is nil or disconnected")
+	}
+
+	// use default resource when resource param is empty
+	if len(resource) == 0 {
+		account := conn.GetAccount()
+		resource = account.DefaultResource
+	}
+
+	f, err := os.OpenFile(localPath, os.O_RDWR, 0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open file %q", localPath)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if len(rangeStatuses) == 0 && dataObject.Size > 0 {
+		rangeStatuses = []*DataObjectTransferStatusEntry{
+			{
+				StartOffset: 0,
+				Length:      dataObject.Size,
+			},
+		}
+	}
+
+	totalBytes := int64(0)
+	for _, rangeStatus := range rangeStatuses {
+		totalBytes += getTransferRangeLength(rangeStatus.StartOffset, rangeStatus.Length, dataObject.Size)
+	}
+
+	logger.Debugf("comparing %d ranges with the data object, %d bytes", len(rangeStatuses), totalBytes)
+
+	handle, _, err := OpenDataObject(conn, dataObject.Path, resource, "r", keywords)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open data object %q", dataObject.Path)
+	}
+
+	defer func() {
+		_ = CloseDataObject(conn, handle)
+	}()
+
+	totalBytesRead := int64(0)
+	if transferCallback != nil {
+		transferCallback("download", totalBytesRead, totalBytes)
+	}
+
+	buffer := make([]byte, common.ReadWriteBufferSize)
+	localBuffer := make([]byte, common.ReadWriteBufferSize)
+
+	repairedRanges := []*DataObjectTransferStatusEntry{}
+	for _, rangeStatus := range rangeStatuses {
+		rangeLength := getTransferRangeLength(rangeStatus.StartOffset, rangeStatus.Length, dataObject.Size)
+		if rangeLength == 0 {
+			continue
+		}
+
+		hashAlg := md5.New()
+		rangeRemain := rangeLength
+		offset := rangeStatus.StartOffset
+		repaired := false
+
+		newOffset, err := SeekDataObject(conn, handle, offset, types.SeekSet)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to seek data object %q to offset %d", dataObject.Path, offset)
+		}
+
+		if newOffset != offset {
+			return nil, errors.Errorf("failed to seek data object %q to offset %d", dataObject.Path, offset)
+		}
+
+		for rangeRemain > 0 {
+			bufferLen := common.ReadWriteBufferSize
+			if rangeRemain < int64(bufferLen) {
+				bufferLen = int(rangeRemain)
+			}
+
+			bytesRead, readErr := ReadDataObject(conn, handle, buffer[:bufferLen])
+			if bytesRead > 0 {
+				localBytesRead, localReadErr := f.ReadAt(localBuffer[:bytesRead], offset)
+				if localReadErr != nil && localReadErr != io.EOF {
+					return nil, errors.Wrapf(localReadErr, "failed to read file %q at offset %d", localPath, offset)
+				}
+
+				if localBytesRead != bytesRead || !bytes.Equal(localBuffer[:bytesRead], buffer[:bytesRead]) {
+					// corrupted
+					_, writeErr := f.WriteAt(buffer[:bytesRead], offset)
+					if writeErr != nil {
+						return nil, errors.Wrapf(writeErr, "failed to write to file %q", localPath)
+					}
+					repaired = true
+				}
+
+				hashAlg.Write(buffer[:bytesRead])
+
+				offset += int64(bytesRead)
+				rangeRemain -= int64(bytesRead)
+				totalBytesRead += int64(bytesRead)
+
+				if transferCallback != nil {
+					transferCallback("download", totalBytesRead, totalBytes)
+				}
+			}
+
+			if readErr != nil {
+				if readErr == io.EOF {
+					break
+				}
+
+				return nil, errors.Wrapf(readErr, "failed to read range at offset %d of data object %q", offset, dataObject.Path)
+			}
+		}
+
+		if rangeRemain > 0 {
+			return nil, errors.Errorf("failed to read range at offset %d of data object %q, data object is shorter than expected", rangeStatus.StartOffset, dataObject.Path)
+		}
+
+		rangeStatus.CompletedLength = rangeLength
+		rangeStatus.Checksum = hashAlg.Sum(nil)
+
+		if repaired {
+			repairedRanges = append(repairedRanges, rangeStatus)
+		}
+	}
+
+	if len(repairedRanges) > 0 {
+		logger.Debugf("repaired %d corrupted ranges", len(repairedRanges))
+	}
+
+	err = f.Sync()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to sync file %q", localPath)
+	}
+
+	return repairedRanges, nil
+}
@@ -244,6 +244,12 @@ func downloadDataObjectChunkFromResourceServer(sess *session.IRODSSession, taskI
 	encKeysize := 0
 
 	if controlConn.IsSSL() {
+		if encConfig == nil {
+			newErr := types.NewConnectionConfigError(controlConn.GetAccount())
+			return errors.Wrapf(newErr, "SSL configuration is not set, cannot encrypt data channel")
+		}
+
+		// data channel is encrypted using the encryption settings negotiated via control connection
 		encKeysize = encConfig.EncryptionKeySize
 	}
 
@@ -261,7 +267,7 @@ func downloadDataObjectChunkFromResourceServer(sess *session.IRODSSession, taskI
 	var dataBuffer []byte
 	var encryptedDataBuffer []byte
 
-	timeout := controlConn.GetOperationTimeout()
+	timeout := controlConn.GetDataTransferTimeout()
 
 	for cont {
 		// read transfer header
@@ -464,6 +470,12 @@ func uploadDataObjectChunkToResourceServer(sess *session.IRODSSession, taskID in
 	encKeysize := 0
 
 	if controlConn.IsSSL() {
+		if encConfig == nil {
+			newErr := types.NewConnectionConfigError(controlConn.GetAccount())
+			return errors.Wrapf(newErr, "SSL configuration is not set, cannot encrypt data channel")
+		}
+
+		// data channel is encrypted using the encryption settings negotiated via control connection
 		encKeysize = encConfig.EncryptionKeySize
 	}
 
@@ -482,7 +494,7 @@ func uploadDataObjectChunkToResourceServer(sess *session.IRODSSession, taskID in
 	var encryptedDataBuffer []byte
 	dataBufferSize := common.ReadWriteBufferSize
 
-	timeout := controlConn.GetOperationTimeout()
+	timeout := controlConn.GetDataTransferTimeout()
 
 	for cont {
 		// read transfer header
@@ -723,14 +735,10 @@ func DownloadDataObjectFromResourceServer(sess *session.IRODSSession, dataObject
 	numTasks = handle.Threads
 	// get from portal
 
-	// create an empty file
-	f, err := os.Create(localPath)
+	// create a file preallocated to the full size, fails early if there is not enough disk space
+	err = createLocalFileForDownload(localPath, dataObject.Size, true)
 	if err != nil {
-		return errors.Wrapf(err, "failed to create file %q", localPath)
-	}
-	err = f.Close()
-	if err != nil {
-		return errors.Wrapf(err, "failed to close file %q", localPath)
+		return err
 	}
 
 	errChan := make(chan error, numTasks)
@@ -777,6 +785,9 @@ func DownloadDataObjectFromResourceServer(sess *session.IRODSSession, dataObject
 		}
 	}
 
+	// keep the control connection alive while it is idle
+	stopKeepAlive := keepControlConnectionAlive(controlConn)
+
 	for i := 0; i < numTasks; i++ {
 		taskWaitGroup.Add(1)
 
@@ -784,6 +795,7 @@ func DownloadDataObjectFromResourceServer(sess *session.IRODSSession, dataObject
 	}
 
 	taskWaitGroup.Wait()
+	stopKeepAlive()
 
 	if len(errChan) > 0 {
 		return <-errChan
@@ -847,14 +859,10 @@ func DownloadDataObjectFromResourceServerWithConnection(sess *session.IRODSSessi
 	numTasks = handle.Threads
 	// get from portal
 
-	// create an empty file
-	f, err := os.Create(localPath)
-	if err != nil {
-		return errors.Wrapf(err, "failed to create file %q", localPath)
-	}
-	err = f.Close()
+	// create a file preallocated to the full size, fails early if there is not enough disk space
+	err = createLocalFileForDownload(localPath, dataObject.Size, true)
 	if err != nil {
-		return errors.Wrapf(err, "failed to close file %q", localPath)
+		return err
 	}
 
 	errChan := make(chan error, numTasks)
@@ -901,6 +909,9 @@ func DownloadDataObjectFromResourceServerWithConnection(sess *session.IRODSSessi
 		}
 	}
 
+	// keep the control connection alive while it is idle
+	stopKeepAlive := keepControlConnectionAlive(controlConn)
+
 	for i := 0; i < numTasks; i++ {
 		taskWaitGroup.Add(1)
 
@@ -908,6 +919,7 @@ func DownloadDataObjectFromResourceServerWithConnection(sess *session.IRODSSessi
 	}
 
 	taskWaitGroup.Wait()
+	stopKeepAlive()
 
 	if len(errChan) > 0 {
 		return <-errChan
@@ -918,6 +930,15 @@ func DownloadDataObjectFromResourceServerWithConnection(sess *session.IRODSSessi
 
 // UploadDataObjectToResourceServer uploads a data object at the local path to the iRODS path
 func UploadDataObjectToResourceServer(sess *session.IRODSSession, localPath string, irodsPath string, resource string, taskNum int, replicate bool, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback) error {
+	fallback := func(numTasks int) error {
+		return UploadDataObjectParallel(sess, localPath, irodsPath, resource, numTasks, replicate, keywords, transferCallback)
+	}
+
+	return uploadDataObjectToResourceServer(sess, localPath, irodsPath, resource, taskNum, replicate, keywords, transferCallback, fallback, nil)
+}
+
+// uploadDataObjectToResourceServer uploads a data object via server-issued portals, calls fallback if redirection is not available
+func uploadDataObjectToResourceServer(sess *session.IRODSSession, localPath string, irodsPath string, resource string, taskNum int, replicate bool, keywords map[common.KeyWord]string, transferCallback common.TransferTrackerCallback, fallback func(numTasks int) error, stats *DataObjectTransferStats) error {
 	logger := log.WithFields(log.Fields{
 		"local_path": localPath,
 		"irods_path": irodsPath,
@@ -940,6 +961,7 @@ func UploadDataObjectToResourceServer(sess *session.IRODSSession, localPath stri
 	fileLength := stat.Size()
 	if fileLength == 0 {
 		// empty file
+		stats.setTaskNum(1)
 		return UploadDataObject(sess, localPath, irodsPath, resource, replicate, keywords, transferCallback)
 	}
 
@@ -949,6 +971,7 @@ func UploadDataObjectToResourceServer(sess *session.IRODSSession, localPath stri
 	}
 
 	if numTasks == 1 {
+		stats.setTaskNum(1)
 		return UploadDataObject(sess, localPath, irodsPath, resource, replicate, keywords, transferCallback)
 	}
 
@@ -976,8 +999,8 @@ func UploadDataObjectToResourceServer(sess *session.IRODSSession, localPath stri
 		_ = sess.ReturnConnection(controlConn)
 		controlConnReleased = true
 
-		logger.WithError(err).Debug("failed to get redirection info for data object, switch to UploadDataObjctParallel")
-		return UploadDataObjectParallel(sess, localPath, irodsPath, resource, 0, replicate, keywords, transferCallback)
+		logger.WithError(err).Debug("failed to get redirection info for data object, switch to fallback")
+		return fallback(0)
 	}
 
 	logger.Debugf("upload data object in parallel (redirect-to-resource), size(%d), threads(%d)", fileLength, numTasks)
@@ -991,14 +1014,15 @@ func UploadDataObjectToResourceServer(sess *session.IRODSSession, localPath stri
 		_ = sess.ReturnConnection(controlConn)
 		controlConnReleased = true
 
-		logger.Debugf("failed to get redirection info for data object %q, switch to UploadDataObjectParallel", irodsPath)
+		logger.Debugf("failed to get redirection info for data object %q, switch to fallback", irodsPath)
 
-		return UploadDataObjectParallel(sess, localPath, irodsPath, resource, numTasks, replicate, keywords, transferCallback)
+		return fallback(numTasks)
 	}
 
 	logger.Debugf("Redirect to resource: threads %d, addr %q, port %d, window size %d, cookie %d", handle.Threads, handle.RedirectionInfo.Host, handle.RedirectionInfo.Port, handle.RedirectionInfo.WindowSize, handle.RedirectionInfo.Cookie)
 
 	numTasks = handle.Threads
+	stats.setTaskNum(numTasks)
 	// put to portal
 
 	errChan := make(chan error, numTasks)
@@ -1045,6 +1069,9 @@ func UploadDataObjectToResourceServer(sess *session.IRODSSession, localPath stri
 		}
 	}
 
+	// keep the control connection alive while it is idle
+	stopKeepAlive := keepControlConnectionAlive(controlConn)
+
 	for i := 0; i < numTasks; i++ {
 		taskWaitGroup.Add(1)
 
@@ -1052,6 +1079,7 @@ func UploadDataObjectToResourceServer(sess *session.IRODSSession, localPath stri
 	}
 
 	taskWaitGroup.Wait()
+	stopKeepAlive()
 
 	if len(errChan) > 0 {
 		return <-errChan
@@ -1166,6 +1194,9 @@ func UploadDataObjectToResourceServerWithConnection(sess *session.IRODSSession,
 		}
 	}
 
+	// keep the control connection alive while it is idle
+	stopKeepAlive := keepControlConnectionAlive(controlConn)
+
 	for i := 0; i < numTasks; i++ {
 		taskWaitGroup.Add(1)
 
@@ -1173,6 +1204,7 @@ func UploadDataObjectToResourceServerWithConnection(sess *session.IRODSSession,
 	}
 
 	taskWaitGroup.Wait()
+	stopKeepAlive()
 
 	if len(errChan) > 0 {
 		return <-errChan
@@ -0,0 +1,46 @@
+package fs
+
+import "sync/atomic"
+
+// DataObjectTransferStats collects statistics of a data object transfer
+// All methods are safe to call on nil
+type DataObjectTransferStats struct {
+	taskNum int64
+	retries int64
+}
+
+// setTaskNum sets the number of tasks transferring in parallel
+func (stats *DataObjectTransferStats) setTaskNum(taskNum int) {
+	if stats == nil {
+		return
+	}
+
+	atomic.StoreInt64(&stats.taskNum, int64(taskNum))
+}
+
+// addRetry increases the number of retries
+func (stats *DataObjectTransferStats) addRetry() {
+	if stats == nil {
+		return
+	}
+
+	atomic.AddInt64(&stats.retries, 1)
+}
+
+// GetTaskNum returns the number of tasks transferred in parallel
+func (stats *DataObjectTransferStats) GetTaskNum() int {
+	if stats == nil {
+		return 0
+	}
+
+	return int(atomic.LoadInt64(&stats.taskNum))
+}
+
+// GetRetries returns the number of retries of tasks failed
+func (stats *DataObjectTransferStats) GetRetries() int {
+	if stats == nil {
+		return 0
+	}
+
+	return int(atomic.LoadInt64(&stats.retries))
+}
@@ -0,0 +1,233 @@
+package fs
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+const (
+	// metaQueryIDBatchSize is the max number of IDs given in an "in" condition of a metadata query
+	metaQueryIDBatchSize int = 50
+)
+
+// metaQueryColumns is a set of columns to query AVUs of entries
+type metaQueryColumns struct {
+	entryID    common.ICATColumnNumber
+	parent     common.ICATColumnNumber
+	attrID     common.ICATColumnNumber
+	attrName   common.ICATColumnNumber
+	attrValue  common.ICATColumnNumber
+	attrUnits  common.ICATColumnNumber
+	createTime common.ICATColumnNumber
+	modifyTime common.ICATColumnNumber
+}
+
+var dataObjectMetaQueryColumns = &metaQueryColumns{
+	entryID:    common.ICAT_COLUMN_D_DATA_ID,
+	parent:     common.ICAT_COLUMN_COLL_NAME,
+	attrID:     common.ICAT_COLUMN_META_DATA_ATTR_ID,
+	attrName:   common.ICAT_COLUMN_META_DATA_ATTR_NAME,
+	attrValue:  common.ICAT_COLUMN_META_DATA_ATTR_VALUE,
+	attrUnits:  common.ICAT_COLUMN_META_DATA_ATTR_UNITS,
+	createTime: common.ICAT_COLUMN_META_DATA_CREATE_TIME,
+	modifyTime: common.ICAT_COLUMN_META_DATA_MODIFY_TIME,
+}
+
+var collectionMetaQueryColumns = &metaQueryColumns{
+	entryID:    common.ICAT_COLUMN_COLL_ID,
+	parent:     common.ICAT_COLUMN_COLL_PARENT_NAME,
+	attrID:     common.ICAT_COLUMN_META_COLL_ATTR_ID,
+	attrName:   common.ICAT_COLUMN_META_COLL_ATTR_NAME,
+	attrValue:  common.ICAT_COLUMN_META_COLL_ATTR_VALUE,
+	attrUnits:  common.ICAT_COLUMN_META_COLL_ATTR_UNITS,
+	createTime: common.ICAT_COLUMN_META_COLL_CREATE_TIME,
+	modifyTime: common.ICAT_COLUMN_META_COLL_MODIFY_TIME,
+}
+
+// ListDataObjectsMetaInCollection lists metadata of data objects in the collection, returns metadata keyed by data object ID
+// dataObjectIDs limits data objects, empty for all data objects in the collection, data objects without metadata are not in the result
+func ListDataObjectsMetaInCollection(conn *connection.IRODSConnection, collPath string, dataObjectIDs []int64) (map[int64][]*types.IRODSMeta, error) {
+	return listEntriesMeta(conn, dataObjectMetaQueryColumns, collPath, dataObjectIDs)
+}
+
+// ListSubCollectionsMeta lists metadata of sub collections of the collection, returns metadata keyed by collection ID
+// collectionIDs limits sub collections, empty for all sub collections, collections without metadata are not in the result
+func ListSubCollectionsMeta(conn *connection.IRODSConnection, parentPath string, collectionIDs []int64) (map[int64][]*types.IRODSMeta, error) {
+	return listEntriesMeta(conn, collectionMetaQueryColumns, parentPath, collectionIDs)
+}
+
+func listEntriesMeta(conn *connection.IRODSConnection, columns *metaQueryColumns, parentPath string, ids []int64) (map[int64][]*types.IRODSMeta, error) {
+	if conn == nil || !conn.IsConnected() {
+		return nil, errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForMetadataList(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	metas := map[int64][]*types.IRODSMeta{}
+
+	if len(ids) == 0 {
+		err := listEntriesMetaBatch(conn, columns, parentPath, nil, metas)
+		if err != nil {
+			return nil, err
+		}
+		return metas, nil
+	}
+
+	// long "in" conditions are split
+	for start := 0; start < len(ids); start += metaQueryIDBatchSize {
+		end := start + metaQueryIDBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		err := listEntriesMetaBatch(conn, columns, parentPath, ids[start:end], metas)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return metas, nil
+}
+
+func listEntriesMetaBatch(conn *connection.IRODSConnection, columns *metaQueryColumns, parentPath string, ids []int64, metas map[int64][]*types.IRODSMeta) error {
+	continueQuery := true
+	continueIndex := 0
+	for continueQuery {
+		query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, continueIndex, 0, 0)
+		query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
+		query.AddSelect(columns.entryID)
+		query.AddSelect(columns.attrID)
+		query.AddSelect(columns.attrName)
+		query.AddSelect(columns.attrValue)
+		query.AddSelect(columns.attrUnits)
+		query.AddSelect(columns.createTime)
+		query.AddSelect(columns.modifyTime)
+
+		query.AddEqualStringCondition(columns.parent, parentPath)
+
+		if len(ids) > 0 {
+			query.AddInIDCondition(columns.entryID, ids)
+		}
+
+		queryResult := message.IRODSMessageQueryResponse{}
+		err := conn.Request(query, &queryResult, nil, conn.GetOperationTimeout())
+		if err != nil {
+			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+				// empty
+				break
+			} else if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION {
+				newErr := errors.Join(err, types.NewFileNotFoundError(parentPath))
+				return errors.Wrapf(newErr, "failed to find the collection for path %q", parentPath)
+			}
+
+			return errors.Wrapf(err, "failed to receive a metadata query result message")
+		}
+
+		err = conn.CheckResponseError(query, &queryResult)
+		if err != nil {
+			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+				// empty
+				break
+			} else if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION {
+				newErr := errors.Join(err, types.NewFileNotFoundError(parentPath))
+				return errors.Wrapf(newErr, "failed to find the collection for path %q", parentPath)
+			}
+
+			return errors.Wrapf(err, "received metadata query error")
+		}
+
+		if queryResult.RowCount == 0 {
+			break
+		}
+
+		if queryResult.AttributeCount > len(queryResult.SQLResult) {
+			return errors.Errorf("failed to receive metadata attributes - requires %d, but received %d attributes", queryResult.AttributeCount, len(queryResult.SQLResult))
+		}
+
+		pagenatedIDs := make([]int64, queryResult.RowCount)
+		pagenatedMetas := make([]*types.IRODSMeta, queryResult.RowCount)
+
+		for attr := 0; attr < queryResult.AttributeCount; attr++ {
+			sqlResult := queryResult.SQLResult[attr]
+			if len(sqlResult.Values) != queryResult.RowCount {
+				return errors.Errorf("failed to receive metadata rows - requires %d, but received %d attributes", queryResult.RowCount, len(sqlResult.Values))
+			}
+
+			for row := 0; row < queryResult.RowCount; row++ {
+				value := sqlResult.Values[row]
+
+				if pagenatedMetas[row] == nil {
+					// create a new
+					pagenatedMetas[row] = &types.IRODSMeta{
+						AVUID:      -1,
+						Name:       "",
+						Value:      "",
+						Units:      "",
+						CreateTime: time.Time{},
+						ModifyTime: time.Time{},
+					}
+				}
+
+				switch common.ICATColumnNumber(sqlResult.AttributeIndex) {
+				case columns.entryID:
+					entryID, err := strconv.ParseInt(value, 10, 64)
+					if err != nil {
+						return errors.Wrapf(err, "failed to parse entry id %q", value)
+					}
+					pagenatedIDs[row] = entryID
+				case columns.attrID:
+					avuID, err := strconv.ParseInt(value, 10, 64)
+					if err != nil {
+						return errors.Wrapf(err, "failed to parse metadata id %q", value)
+					}
+					pagenatedMetas[row].AVUID = avuID
+				case columns.attrName:
+					pagenatedMetas[row].Name = value
+				case columns.attrValue:
+					pagenatedMetas[row].Value = value
+				case columns.attrUnits:
+					pagenatedMetas[row].Units = value
+				case columns.createTime:
+					cT, err := util.GetIRODSDateTime(value)
+					if err != nil {
+						return errors.Wrapf(err, "failed to parse create time %q", value)
+					}
+					pagenatedMetas[row].CreateTime = cT
+				case columns.modifyTime:
+					mT, err := util.GetIRODSDateTime(value)
+					if err != nil {
+						return errors.Wrapf(err, "failed to parse modify time %q", value)
+					}
+					pagenatedMetas[row].ModifyTime = mT
+				default:
+					// ignore
+				}
+			}
+		}
+
+		for row := 0; row < queryResult.RowCount; row++ {
+			metas[pagenatedIDs[row]] = append(metas[pagenatedIDs[row]], pagenatedMetas[row])
+		}
+
+		continueIndex = queryResult.ContinueIndex
+		if continueIndex == 0 {
+			continueQuery = false
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package fs
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+const (
+	// nullSpecialValue clears special collection columns
+	nullSpecialValue string = "NULL_SPECIAL_VALUE"
+)
+
+// MountCollection mounts a source as a special collection for the path
+// source is a physical directory for MountPointCollectionType, a struct file path for TarStructFileCollectionType
+// or a collection path for LinkPointCollectionType. resource is required for MountPointCollectionType.
+func MountCollection(conn *connection.IRODSConnection, path string, mountType types.SpecialCollectionType, source string, resource string) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	switch mountType {
+	case types.MountPointCollectionType, types.LinkPointCollectionType, types.TarStructFileCollectionType:
+		// pass
+	default:
+		return errors.Errorf("failed to mount unsupported special collection type %q", mountType)
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForCollectionCreate(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	request := message.NewIRODSMessageRegisterDataObjectRequest(path, source, resource)
+	request.AddKeyVal(common.COLLECTION_TYPE_KW, string(mountType))
+
+	response := message.IRODSMessageRegisterDataObjectResponse{}
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION {
+			newErr := errors.Join(err, types.NewFileNotFoundError(path))
+			return errors.Wrapf(newErr, "failed to find the collection for path %q", path)
+		} else if types.GetIRODSErrorCode(err) == common.CAT_COLLECTION_NOT_EMPTY {
+			newErr := errors.Join(err, types.NewCollectionNotEmptyError(path))
+			return errors.Wrapf(newErr, "the collection for path %q is not empty", path)
+		}
+
+		return errors.Wrapf(err, "failed to mount %q to collection %q", source, path)
+	}
+	return nil
+}
+
+// UnmountCollection unmounts a special collection for the path, the collection becomes a normal collection
+func UnmountCollection(conn *connection.IRODSConnection, path string, resource string) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	request := message.NewIRODSMessageModifyCollectionRequest(path)
+	request.AddKeyVal(common.COLLECTION_TYPE_KW, nullSpecialValue)
+	request.AddKeyVal(common.COLLECTION_INFO1_KW, nullSpecialValue)
+	request.AddKeyVal(common.COLLECTION_INFO2_KW, nullSpecialValue)
+
+	if len(resource) > 0 {
+		request.AddKeyVal(common.RESC_NAME_KW, resource)
+	}
+
+	response := message.IRODSMessageModifyCollectionResponse{}
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION {
+			newErr := errors.Join(err, types.NewFileNotFoundError(path))
+			return errors.Wrapf(newErr, "failed to find the collection for path %q", path)
+		}
+
+		return errors.Wrapf(err, "failed to unmount collection %q", path)
+	}
+	return nil
+}
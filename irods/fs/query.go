@@ -0,0 +1,208 @@
+package fs
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/danwakefield/fnmatch"
+)
+
+// CloseQuery closes a paginated query on the server before reading all pages
+// the server keeps a limited number of open queries per connection, so queries stopped early must be closed
+func CloseQuery(conn *connection.IRODSConnection, continueIndex int) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	if continueIndex <= 0 {
+		// already closed by the server
+		return nil
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	// zero max rows closes the query
+	query := message.NewIRODSMessageQueryRequest(0, continueIndex, 0, 0)
+	query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
+	query.AddSelect(common.ICAT_COLUMN_COLL_ID)
+
+	queryResult := message.IRODSMessageQueryResponse{}
+	err := conn.Request(query, &queryResult, nil, conn.GetOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to close query")
+	}
+
+	return nil
+}
+
+// QueryAndScan runs a custom query reading all pages and appends rows to dest with message.IRODSMessageQueryResponse.ScanRows
+// dest is a pointer to a slice of structs having column tags, the continue index of the query is updated while paging
+func QueryAndScan(conn *connection.IRODSConnection, query *message.IRODSMessageQueryRequest, dest interface{}) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForSearch(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	query.ContinueIndex = 0
+
+	for {
+		queryResult := message.IRODSMessageQueryResponse{}
+		err := conn.Request(query, &queryResult, nil, conn.GetLongResponseOperationTimeout())
+		if err != nil {
+			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+				return nil
+			}
+			return errors.Wrapf(err, "failed to receive a query result message")
+		}
+
+		err = conn.CheckResponseError(query, &queryResult)
+		if err != nil {
+			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
+				return nil
+			}
+			return errors.Wrapf(err, "received query error")
+		}
+
+		err = queryResult.ScanRows(dest)
+		if err != nil {
+			return err
+		}
+
+		query.ContinueIndex = queryResult.ContinueIndex
+		if query.ContinueIndex == 0 {
+			return nil
+		}
+	}
+}
+
+// SearchOptions is options for catalog searches
+type SearchOptions struct {
+	CaseInsensitive bool // match names and metadata case-insensitively, uses UPPER in conditions
+	NoDistinct      bool // return duplicate rows as they are in the catalog
+}
+
+// GetQueryOptions returns GenQuery option flags, nil options return no flags
+func (options *SearchOptions) GetQueryOptions() int {
+	queryOptions := 0
+	if options == nil {
+		return queryOptions
+	}
+
+	if options.CaseInsensitive {
+		queryOptions |= int(common.ICAT_QUERY_OPTION_UPPER_CASE_WHERE)
+	}
+
+	if options.NoDistinct {
+		queryOptions |= int(common.ICAT_QUERY_OPTION_NO_DISTINCT)
+	}
+
+	return queryOptions
+}
+
+// getFnmatchFlags returns flags for matching paths with unix wildcards on the client
+func (options *SearchOptions) getFnmatchFlags() int {
+	flags := fnmatch.FNM_PATHNAME
+	if options != nil && options.CaseInsensitive {
+		flags |= fnmatch.FNM_CASEFOLD
+	}
+	return flags
+}
+
+// ListSortField is a field that listing results are sorted by on the server
+type ListSortField string
+
+const (
+	// ListSortByName sorts entries by name
+	ListSortByName ListSortField = "name"
+	// ListSortBySize sorts entries by size, collections are sorted by name
+	ListSortBySize ListSortField = "size"
+	// ListSortByModifyTime sorts entries by modify time
+	ListSortByModifyTime ListSortField = "modify_time"
+)
+
+// ListQueryOptions is options for paginated listing, filtering and sorting are done in the GenQuery
+type ListQueryOptions struct {
+	SortBy         ListSortField // empty uses the server order
+	SortDescending bool
+	NamePattern    string // SQL LIKE pattern of entry names, e.g., "%.txt", empty matches all
+}
+
+// Validate validates list query options
+func (options *ListQueryOptions) Validate() error {
+	switch options.SortBy {
+	case "", ListSortByName, ListSortBySize, ListSortByModifyTime:
+	default:
+		return errors.Errorf("unknown sort field %q", options.SortBy)
+	}
+
+	return nil
+}
+
+func (options *ListQueryOptions) getCollectionSortColumn() common.ICATColumnNumber {
+	if options == nil {
+		return 0
+	}
+
+	switch options.SortBy {
+	case ListSortByName, ListSortBySize:
+		return common.ICAT_COLUMN_COLL_NAME
+	case ListSortByModifyTime:
+		return common.ICAT_COLUMN_COLL_MODIFY_TIME
+	default:
+		return 0
+	}
+}
+
+func (options *ListQueryOptions) getDataObjectSortColumn() common.ICATColumnNumber {
+	if options == nil {
+		return 0
+	}
+
+	switch options.SortBy {
+	case ListSortByName:
+		return common.ICAT_COLUMN_DATA_NAME
+	case ListSortBySize:
+		return common.ICAT_COLUMN_DATA_SIZE
+	case ListSortByModifyTime:
+		return common.ICAT_COLUMN_D_MODIFY_TIME
+	default:
+		return 0
+	}
+}
+
+// addSortedSelects adds selects to the query, the sort column is added first as GenQuery sorts by flagged columns in order
+// tieBreaker is also sorted to keep rows of the same entry adjacent
+func addSortedSelects(query *message.IRODSMessageQueryRequest, columns []common.ICATColumnNumber, sortColumn common.ICATColumnNumber, descending bool, tieBreaker common.ICATColumnNumber) {
+	if sortColumn == 0 {
+		for _, column := range columns {
+			query.AddSelect(column)
+		}
+		return
+	}
+
+	query.AddSelectWithOrder(sortColumn, descending)
+	if tieBreaker != sortColumn {
+		query.AddSelectWithOrder(tieBreaker, false)
+	}
+
+	for _, column := range columns {
+		if column != sortColumn && column != tieBreaker {
+			query.AddSelect(column)
+		}
+	}
+}
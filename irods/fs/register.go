@@ -0,0 +1,102 @@
+package fs
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// RegisterDataObject registers an existing physical file on the resource as a data object for the path
+// registerChecksum computes and registers the checksum, verifyChecksum also verifies the checksum after registration
+// registerReplica registers the physical file as a new replica of the existing data object
+// registering a file in the vault of the resource requires rodsadmin privilege
+func RegisterDataObject(conn *connection.IRODSConnection, path string, physicalPath string, resource string, registerChecksum bool, verifyChecksum bool, registerReplica bool, force bool) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForDataObjectCreate(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	request := message.NewIRODSMessageRegisterDataObjectRequest(path, physicalPath, resource)
+	addRegisterKeyVals(request, registerChecksum, verifyChecksum, force)
+
+	if registerReplica {
+		request.AddKeyVal(common.REG_REPL_KW, "")
+	}
+
+	response := message.IRODSMessageRegisterDataObjectResponse{}
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
+	if err != nil {
+		return getRegisterError(path, physicalPath, err)
+	}
+	return nil
+}
+
+// RegisterCollection registers an existing physical directory on the resource as a collection for the path
+// sub-directories and files in the directory are registered recursively
+func RegisterCollection(conn *connection.IRODSConnection, path string, physicalPath string, resource string, registerChecksum bool, verifyChecksum bool, force bool) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForCollectionCreate(1)
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	request := message.NewIRODSMessageRegisterDataObjectRequest(path, physicalPath, resource)
+	request.AddKeyVal(common.COLLECTION_KW, "")
+	addRegisterKeyVals(request, registerChecksum, verifyChecksum, force)
+
+	response := message.IRODSMessageRegisterDataObjectResponse{}
+	// recursive registration requires long response operation timeout
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetLongResponseOperationTimeout())
+	if err != nil {
+		return getRegisterError(path, physicalPath, err)
+	}
+	return nil
+}
+
+// UnregisterDataObject unregisters a data object for the path from the catalog, the physical file is not deleted
+// unregistering a file in the vault of the resource requires rodsadmin privilege
+func UnregisterDataObject(conn *connection.IRODSConnection, path string) error {
+	return deleteDataObject(conn, path, false, true, nil)
+}
+
+func addRegisterKeyVals(request *message.IRODSMessageRegisterDataObjectRequest, registerChecksum bool, verifyChecksum bool, force bool) {
+	if verifyChecksum {
+		request.AddKeyVal(common.VERIFY_CHKSUM_KW, "")
+	} else if registerChecksum {
+		request.AddKeyVal(common.REG_CHKSUM_KW, "")
+	}
+
+	if force {
+		request.AddKeyVal(common.FORCE_FLAG_KW, "")
+	}
+}
+
+func getRegisterError(path string, physicalPath string, err error) error {
+	switch types.GetIRODSErrorCode(err) {
+	case common.CAT_UNKNOWN_COLLECTION:
+		newErr := errors.Join(err, types.NewFileNotFoundError(path))
+		return errors.Wrapf(newErr, "failed to find the collection for path %q", path)
+	case common.CATALOG_ALREADY_HAS_ITEM_BY_THAT_NAME, common.CAT_NAME_EXISTS_AS_COLLECTION, common.CAT_NAME_EXISTS_AS_DATAOBJ:
+		newErr := errors.Join(err, types.NewFileAlreadyExistError(path))
+		return errors.Wrapf(newErr, "failed to register physical path %q to %q", physicalPath, path)
+	}
+
+	return errors.Wrapf(err, "failed to register physical path %q to %q", physicalPath, path)
+}
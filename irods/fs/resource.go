@@ -1,7 +1,9 @@
 package fs
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -31,6 +33,7 @@ func GetResource(conn *connection.IRODSConnection, name string) (*types.IRODSRes
 	query.AddSelect(common.ICAT_COLUMN_R_LOC)
 	query.AddSelect(common.ICAT_COLUMN_R_VAULT_PATH)
 	query.AddSelect(common.ICAT_COLUMN_R_RESC_CONTEXT)
+	query.AddSelect(common.ICAT_COLUMN_R_RESC_PARENT)
 	query.AddSelect(common.ICAT_COLUMN_R_CREATE_TIME)
 	query.AddSelect(common.ICAT_COLUMN_R_MODIFY_TIME)
 
@@ -50,7 +53,7 @@ func GetResource(conn *connection.IRODSConnection, name string) (*types.IRODSRes
 		return nil, errors.Wrapf(err, "failed to receive a resource query result message")
 	}
 
-	err = queryResult.CheckError()
+	err = conn.CheckResponseError(query, &queryResult)
 	if err != nil {
 		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 			newErr := errors.Join(err, types.NewResourceNotFoundError(name))
@@ -103,6 +106,8 @@ func GetResource(conn *connection.IRODSConnection, name string) (*types.IRODSRes
 			resource.Path = value
 		case int(common.ICAT_COLUMN_R_RESC_CONTEXT):
 			resource.Context = value
+		case int(common.ICAT_COLUMN_R_RESC_PARENT):
+			resource.Parent = value
 		case int(common.ICAT_COLUMN_R_CREATE_TIME):
 			cT, err := util.GetIRODSDateTime(value)
 			if err != nil {
@@ -147,6 +152,7 @@ func ListResources(conn *connection.IRODSConnection) ([]*types.IRODSResource, er
 		query.AddSelect(common.ICAT_COLUMN_R_LOC)
 		query.AddSelect(common.ICAT_COLUMN_R_VAULT_PATH)
 		query.AddSelect(common.ICAT_COLUMN_R_RESC_CONTEXT)
+		query.AddSelect(common.ICAT_COLUMN_R_RESC_PARENT)
 		query.AddSelect(common.ICAT_COLUMN_R_CREATE_TIME)
 		query.AddSelect(common.ICAT_COLUMN_R_MODIFY_TIME)
 
@@ -163,7 +169,7 @@ func ListResources(conn *connection.IRODSConnection) ([]*types.IRODSResource, er
 			return nil, errors.Wrapf(err, "failed to receive a resource query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -205,6 +211,7 @@ func ListResources(conn *connection.IRODSConnection) ([]*types.IRODSResource, er
 						Location:   "",
 						Path:       "",
 						Context:    "",
+						Parent:     "",
 						CreateTime: time.Time{},
 						ModifyTime: time.Time{},
 					}
@@ -231,6 +238,8 @@ func ListResources(conn *connection.IRODSConnection) ([]*types.IRODSResource, er
 					pagenatedResources[row].Path = value
 				case int(common.ICAT_COLUMN_R_RESC_CONTEXT):
 					pagenatedResources[row].Context = value
+				case int(common.ICAT_COLUMN_R_RESC_PARENT):
+					pagenatedResources[row].Parent = value
 				case int(common.ICAT_COLUMN_R_CREATE_TIME):
 					cT, err := util.GetIRODSDateTime(value)
 					if err != nil {
@@ -260,6 +269,48 @@ func ListResources(conn *connection.IRODSConnection) ([]*types.IRODSResource, er
 	return resources, nil
 }
 
+// GetResourceHierarchy returns the resource hierarchy (root;child;leaf) from the root to the resource
+func GetResourceHierarchy(conn *connection.IRODSConnection, name string) (string, error) {
+	resources, err := ListResources(conn)
+	if err != nil {
+		return "", err
+	}
+
+	resourcesByID := map[string]*types.IRODSResource{}
+	resourcesByName := map[string]*types.IRODSResource{}
+	for _, resource := range resources {
+		resourcesByID[fmt.Sprintf("%d", resource.RescID)] = resource
+		resourcesByName[resource.Name] = resource
+	}
+
+	resource, ok := resourcesByName[name]
+	if !ok {
+		newErr := types.NewResourceNotFoundError(name)
+		return "", errors.Wrapf(newErr, "failed to find the resource for name %q", name)
+	}
+
+	hierarchy := []string{resource.Name}
+	for len(resource.Parent) > 0 {
+		// parent is resource ID since iRODS 4.2, resource name before
+		parent, ok := resourcesByID[resource.Parent]
+		if !ok {
+			parent, ok = resourcesByName[resource.Parent]
+			if !ok {
+				return "", errors.Errorf("failed to find the parent resource %q of resource %q", resource.Parent, resource.Name)
+			}
+		}
+
+		if len(hierarchy) > len(resources) {
+			return "", errors.Errorf("resource hierarchy of resource %q has a cycle", name)
+		}
+
+		hierarchy = append([]string{parent.Name}, hierarchy...)
+		resource = parent
+	}
+
+	return strings.Join(hierarchy, types.ResourceHierarchySeparator), nil
+}
+
 // AddResourceMeta sets metadata of a resource to the given key values.
 // metadata.AVUID is ignored
 func AddResourceMeta(conn *connection.IRODSConnection, name string, metadata *types.IRODSMeta) error {
@@ -364,7 +415,7 @@ func ListResourceMeta(conn *connection.IRODSConnection, name string) ([]*types.I
 			return nil, errors.Wrapf(err, "failed to receive a resource metadata query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -464,3 +515,33 @@ func AddChildToResource(conn *connection.IRODSConnection, parent string, child s
 	}
 	return nil
 }
+
+// CreateResource creates a resource, location is "host:vault_path" for storage resources and empty for coordinating resources
+func CreateResource(conn *connection.IRODSConnection, name string, resourceType string, location string, context string, zoneName string) error {
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	req := message.NewIRODSMessageAdminRequest("add", "resource", name, resourceType, location, context, zoneName)
+
+	err := conn.RequestAndCheck(req, &message.IRODSMessageAdminResponse{}, nil, conn.GetOperationTimeout())
+	if err != nil {
+		return errors.Wrapf(err, "received create resource error for resource %q, type %q", name, resourceType)
+	}
+	return nil
+}
+
+// RemoveResource removes a resource
+func RemoveResource(conn *connection.IRODSConnection, name string) error {
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	req := message.NewIRODSMessageAdminRequest("rm", "resource", name)
+
+	err := conn.RequestAndCheck(req, &message.IRODSMessageAdminResponse{}, nil, conn.GetOperationTimeout())
+	if err != nil {
+		return errors.Wrapf(err, "received remove resource error for resource %q", name)
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package fs
 
 import (
+	"io"
+
 	"github.com/cockroachdb/errors"
 	"github.com/cyverse/go-irodsclient/irods/common"
 	"github.com/cyverse/go-irodsclient/irods/connection"
@@ -47,3 +49,186 @@ func ExtractStructFile(conn *connection.IRODSConnection, path string, target str
 	}
 	return nil
 }
+
+// BundleStructFile creates a struct file for the path from data objects in the source collection on the server
+// add appends data objects to the existing struct file
+func BundleStructFile(conn *connection.IRODSConnection, path string, sourceCollection string, resource string, dataType types.DataType, force bool, add bool) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	switch dataType {
+	case types.TAR_FILE_DT, types.GZIP_TAR_DT, types.BZIP2_TAR_DT, types.ZIP_FILE_DT:
+		// pass
+	default:
+		return errors.Errorf("failed to bundle content to unsupported data type %q", dataType)
+	}
+
+	// use default resource when resource param is empty
+	if len(resource) == 0 {
+		account := conn.GetAccount()
+		resource = account.DefaultResource
+	}
+
+	request := message.NewIRODSMessageBundleStructFileRequest(path, sourceCollection, resource, dataType, force, add)
+	response := message.IRODSMessageBundleStructFileResponse{}
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetLongResponseOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION {
+			newErr := errors.Join(err, types.NewFileNotFoundError(sourceCollection))
+			return errors.Wrapf(newErr, "failed to find the collection for path %q", sourceCollection)
+		} else if types.GetIRODSErrorCode(err) == common.CATALOG_ALREADY_HAS_ITEM_BY_THAT_NAME || types.GetIRODSErrorCode(err) == common.OVERWRITE_WITHOUT_FORCE_FLAG {
+			newErr := errors.Join(err, types.NewFileAlreadyExistError(path))
+			return errors.Wrapf(newErr, "failed to create struct file for path %q", path)
+		}
+
+		return errors.Wrapf(err, "received bundle struct file error")
+	}
+	return nil
+}
+
+// PhysicalBundleCollection bundles small data objects in the collection into tar files on the bundle resource on the server
+// maxSubFiles and maxBundleSizeGB limit the number of files and the size of a bundle, server defaults are used if they are not positive
+// this requires rodsadmin privilege
+func PhysicalBundleCollection(conn *connection.IRODSConnection, collection string, bundleResource string, maxSubFiles int, maxBundleSizeGB int) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	if len(bundleResource) == 0 {
+		return errors.Errorf("bundle resource is not given")
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	request := message.NewIRODSMessagePhysicalBundleCollectionRequest(collection, bundleResource, maxSubFiles, maxBundleSizeGB)
+	response := message.IRODSMessagePhysicalBundleCollectionResponse{}
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetLongResponseOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND || types.GetIRODSErrorCode(err) == common.CAT_UNKNOWN_COLLECTION {
+			newErr := errors.Join(err, types.NewFileNotFoundError(collection))
+			return errors.Wrapf(newErr, "failed to find the collection for path %q", collection)
+		}
+
+		return errors.Wrapf(err, "received physical bundle collection error")
+	}
+	return nil
+}
+
+// OpenSubStructFile opens a file inside a tar struct file for read, without extracting the struct file to the catalog
+// the replica must be stored in the resource, subFilePath is relative to the root of the struct file
+func OpenSubStructFile(conn *connection.IRODSConnection, dataObject *types.IRODSDataObject, replica *types.IRODSReplica, resource *types.IRODSResource, subFilePath string) (*types.IRODSSubStructFileHandle, error) {
+	if conn == nil || !conn.IsConnected() {
+		return nil, errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForDataObjectOpen(1)
+	}
+
+	request, err := message.NewIRODSMessageOpenSubStructFileRequest(resource, dataObject, replica, subFilePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to make a sub struct file open request")
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	response := message.IRODSMessageOpenSubStructFileResponse{}
+	err = conn.RequestAndCheck(request, &response, nil, conn.GetLongResponseOperationTimeout())
+	if err != nil {
+		if types.GetIRODSErrorCode(err) == common.UNIX_FILE_OPEN_ERR-common.ErrorCode(common.ENOENT) {
+			newErr := errors.Join(err, types.NewFileNotFoundError(request.SubFilePath))
+			return nil, errors.Wrapf(newErr, "failed to find the sub file %q in struct file %q", subFilePath, dataObject.Path)
+		} else if types.GetIRODSErrorCode(err) == common.SYS_OBJ_TYPE_NOT_STRUCT_FILE {
+			return nil, errors.Wrapf(err, "data object %q is not a struct file", dataObject.Path)
+		}
+
+		return nil, errors.Wrapf(err, "failed to open sub file %q in struct file %q", subFilePath, dataObject.Path)
+	}
+
+	handle := &types.IRODSSubStructFileHandle{
+		FileDescriptor: response.GetFileDescriptor(),
+		StructFilePath: dataObject.Path,
+		SubFilePath:    subFilePath,
+		Resource:       resource,
+	}
+
+	if metrics != nil {
+		metrics.IncreaseCounterForOpenFileHandles(1)
+	}
+
+	return handle, nil
+}
+
+// ReadSubStructFile reads data from a file inside a struct file, returns io.EOF at the end of the file
+func ReadSubStructFile(conn *connection.IRODSConnection, handle *types.IRODSSubStructFileHandle, buffer []byte) (int, error) {
+	if conn == nil || !conn.IsConnected() {
+		return 0, errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForDataObjectRead(1)
+	}
+
+	request, err := message.NewIRODSMessageReadSubStructFileRequest(handle.Resource, handle.FileDescriptor, len(buffer))
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to make a sub struct file read request")
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	response := message.IRODSMessageReadSubStructFileResponse{}
+	err = conn.RequestAndCheck(request, &response, buffer, conn.GetDataTransferTimeout())
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to read sub file %q in struct file %q", handle.SubFilePath, handle.StructFilePath)
+	}
+
+	readLen := len(response.Data)
+	if readLen < len(buffer) {
+		// EOF
+		return readLen, io.EOF
+	}
+
+	return readLen, nil
+}
+
+// CloseSubStructFile closes a file inside a struct file
+func CloseSubStructFile(conn *connection.IRODSConnection, handle *types.IRODSSubStructFileHandle) error {
+	if conn == nil || !conn.IsConnected() {
+		return errors.Errorf("connection is nil or disconnected")
+	}
+
+	metrics := conn.GetMetrics()
+	if metrics != nil {
+		metrics.IncreaseCounterForDataObjectClose(1)
+		metrics.DecreaseCounterForOpenFileHandles(1)
+	}
+
+	request, err := message.NewIRODSMessageCloseSubStructFileRequest(handle.Resource, handle.FileDescriptor)
+	if err != nil {
+		return errors.Wrapf(err, "failed to make a sub struct file close request")
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	response := message.IRODSMessageCloseSubStructFileResponse{}
+	err = conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
+	if err != nil {
+		return errors.Wrapf(err, "failed to close sub file %q in struct file %q", handle.SubFilePath, handle.StructFilePath)
+	}
+	return nil
+}
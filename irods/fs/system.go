@@ -31,7 +31,7 @@ func StatProcess(conn *connection.IRODSConnection, address string, zoneName stri
 		return nil, errors.Wrapf(err, "failed to receive a process stat result message")
 	}
 
-	err = queryResult.CheckError()
+	err = conn.CheckResponseError(req, &queryResult)
 	if err != nil {
 		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 			// empty
@@ -110,3 +110,23 @@ func StatProcess(conn *connection.IRODSConnection, address string, zoneName stri
 	processes = append(processes, pagenatedProcesses...)
 	return processes, nil
 }
+
+// GetClientHints returns hints for clients, e.g., hash scheme, specific queries and plugins installed on the server
+func GetClientHints(conn *connection.IRODSConnection) (*types.IRODSClientHints, error) {
+	if conn == nil || !conn.IsConnected() {
+		return nil, errors.Errorf("connection is nil or disconnected")
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	request := message.NewIRODSMessageClientHintsRequest()
+	response := message.IRODSMessageClientHintsResponse{}
+	err := conn.RequestAndCheck(request, &response, nil, conn.GetOperationTimeout())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get client hints")
+	}
+
+	return &response.Hints, nil
+}
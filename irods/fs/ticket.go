@@ -46,7 +46,7 @@ func GetTicketForAnonymousAccess(conn *connection.IRODSConnection, ticketName st
 		return nil, errors.Wrapf(err, "failed to receive a ticket query result message")
 	}
 
-	err = queryResult.CheckError()
+	err = conn.CheckResponseError(query, &queryResult)
 	if err != nil {
 		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 			newErr := errors.Join(err, types.NewTicketNotFoundError(ticketName))
@@ -188,7 +188,7 @@ func GetTicketForDataObjects(conn *connection.IRODSConnection, ticketName string
 		return nil, errors.Wrapf(err, "failed to receive a ticket query result message")
 	}
 
-	err = queryResult.CheckError()
+	err = conn.CheckResponseError(query, &queryResult)
 	if err != nil {
 		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 			newErr := errors.Join(err, types.NewTicketNotFoundError(ticketName))
@@ -363,7 +363,7 @@ func GetTicketForCollections(conn *connection.IRODSConnection, ticketName string
 		return nil, errors.Wrapf(err, "failed to receive a ticket query result message")
 	}
 
-	err = queryResult.CheckError()
+	err = conn.CheckResponseError(query, &queryResult)
 	if err != nil {
 		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 			newErr := errors.Join(err, types.NewTicketNotFoundError(ticketName))
@@ -561,7 +561,7 @@ func ListTicketsForDataObjects(conn *connection.IRODSConnection) ([]*types.IRODS
 			return nil, errors.Wrapf(err, "failed to receive a ticket query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -746,7 +746,7 @@ func ListTicketsForCollections(conn *connection.IRODSConnection) ([]*types.IRODS
 			return nil, errors.Wrapf(err, "failed to receive a ticket query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -916,7 +916,7 @@ func ListTicketsBasic(conn *connection.IRODSConnection) ([]*types.IRODSTicket, e
 			return nil, errors.Wrapf(err, "failed to receive a ticket query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -1074,7 +1074,7 @@ func ListTicketAllowedHosts(conn *connection.IRODSConnection, ticketID int64) ([
 			return nil, errors.Wrapf(err, "failed to receive a ticket restriction query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -1154,7 +1154,7 @@ func ListTicketAllowedUserNames(conn *connection.IRODSConnection, ticketID int64
 			return nil, errors.Wrapf(err, "failed to receive a ticket restriction query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -1234,7 +1234,7 @@ func ListTicketAllowedGroupNames(conn *connection.IRODSConnection, ticketID int6
 			return nil, errors.Wrapf(err, "failed to receive a ticket restriction query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
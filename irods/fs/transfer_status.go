@@ -20,9 +20,10 @@ const (
 
 // DataObjectTransferStatusEntry
 type DataObjectTransferStatusEntry struct {
-	StartOffset     int64 `json:"start_offset"`
-	Length          int64 `json:"length"`
-	CompletedLength int64 `json:"completed_length"`
+	StartOffset     int64  `json:"start_offset"`
+	Length          int64  `json:"length"`
+	CompletedLength int64  `json:"completed_length"`
+	Checksum        []byte `json:"checksum,omitempty"` // md5 hash of the range read from the data object, empty if the range is resumed or not completed
 }
 
 // DataObjectTransferStatus represents data object transfer
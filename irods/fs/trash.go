@@ -0,0 +1,36 @@
+package fs
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+)
+
+// EmptyTrash removes entries in the trash collection permanently, equivalent to irmtrash
+// trashPath is a trash collection, e.g., /zone/trash/home/user, trash homes are kept while their contents are removed
+// entries modified less than ageMinutes ago are kept, 0 removes all entries
+// adminFlag is required to remove trash of other users
+func EmptyTrash(conn *connection.IRODSConnection, trashPath string, ageMinutes int, adminFlag bool) error {
+	if ageMinutes < 0 {
+		return errors.Errorf("negative age %d", ageMinutes)
+	}
+
+	keywords := map[common.KeyWord]string{}
+	if adminFlag {
+		keywords[common.ADMIN_RMTRASH_KW] = ""
+	} else {
+		keywords[common.RMTRASH_KW] = ""
+	}
+
+	if ageMinutes > 0 {
+		keywords[common.AGE_KW] = fmt.Sprintf("%d", ageMinutes)
+	}
+
+	err := deleteCollection(conn, trashPath, true, true, false, keywords)
+	if err != nil {
+		return errors.Wrapf(err, "failed to empty trash %q", trashPath)
+	}
+	return nil
+}
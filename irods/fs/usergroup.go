@@ -43,7 +43,7 @@ func GetUser(conn *connection.IRODSConnection, username string, zoneName string)
 		return nil, errors.Wrapf(err, "failed to receive a user query result message")
 	}
 
-	err = queryResult.CheckError()
+	err = conn.CheckResponseError(query, &queryResult)
 	if err != nil {
 		if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 			newErr := errors.Join(err, types.NewUserNotFoundError(username))
@@ -140,7 +140,7 @@ func ListUsers(conn *connection.IRODSConnection, zoneName string) ([]*types.IROD
 			return nil, errors.Wrapf(err, "failed to receive a user query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -244,7 +244,7 @@ func ListUsersByType(conn *connection.IRODSConnection, userType types.IRODSUserT
 			return nil, errors.Wrapf(err, "failed to receive a user query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -348,7 +348,7 @@ func ListGroupMembers(conn *connection.IRODSConnection, groupName string, zoneNa
 			return nil, errors.Wrapf(err, "failed to receive a group member query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -449,7 +449,7 @@ func ListUserGroupNames(conn *connection.IRODSConnection, username string, zoneN
 			return nil, errors.Wrapf(err, "failed to receive a group query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -652,7 +652,7 @@ func ListUserResourceQuota(conn *connection.IRODSConnection, username string, zo
 			return nil, errors.Wrapf(err, "failed to receive a quota query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -742,7 +742,7 @@ func GetUserGlobalQuota(conn *connection.IRODSConnection, username string, zoneN
 			return nil, errors.Wrapf(err, "failed to receive a quota query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			return nil, errors.Wrapf(err, "received a quota query error")
 		}
@@ -874,7 +874,7 @@ func ListUserMeta(conn *connection.IRODSConnection, username string, zoneName st
 			return nil, errors.Wrapf(err, "failed to receive a user metadata query result message")
 		}
 
-		err = queryResult.CheckError()
+		err = conn.CheckResponseError(query, &queryResult)
 		if err != nil {
 			if types.GetIRODSErrorCode(err) == common.CAT_NO_ROWS_FOUND {
 				// empty
@@ -18,8 +18,8 @@ type IRODSMessageAdminRequest struct {
 	Arg5    string   `xml:"arg5"`
 	Arg6    string   `xml:"arg6"`
 	Arg7    string   `xml:"arg7"`
-	Arg8    string   `xml:"arg8"` // unused
-	Arg9    string   `xml:"arg9"` // unused
+	Arg8    string   `xml:"arg8"`
+	Arg9    string   `xml:"arg9"`
 }
 
 // NewIRODSMessageAdminRequest creates a new IRODSMessageAdminRequest
@@ -0,0 +1,104 @@
+package message
+
+import (
+	"encoding/xml"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// IRODSMessageBundleStructFileRequest stores struct file bundle request
+type IRODSMessageBundleStructFileRequest struct {
+	XMLName          xml.Name             `xml:"StructFileExtAndRegInp_PI"`
+	Path             string               `xml:"objPath"`
+	SourceCollection string               `xml:"collection"`
+	OperationType    int                  `xml:"oprType"`
+	Flags            int                  `xml:"flags"` // unused
+	KeyVals          IRODSMessageSSKeyVal `xml:"KeyValPair_PI"`
+}
+
+// NewIRODSMessageBundleStructFileRequest creates a IRODSMessageBundleStructFileRequest message
+func NewIRODSMessageBundleStructFileRequest(path string, sourceCollection string, resource string, dataType types.DataType, force bool, add bool) *IRODSMessageBundleStructFileRequest {
+	request := &IRODSMessageBundleStructFileRequest{
+		Path:             path,
+		SourceCollection: sourceCollection,
+		OperationType:    0,
+		Flags:            0,
+		KeyVals: IRODSMessageSSKeyVal{
+			Length: 0,
+		},
+	}
+
+	if len(dataType) > 0 {
+		request.KeyVals.Add(string(common.DATA_TYPE_KW), string(dataType))
+	}
+
+	if len(resource) > 0 {
+		request.KeyVals.Add(string(common.DEST_RESC_NAME_KW), resource)
+	}
+
+	if force {
+		request.KeyVals.Add(string(common.FORCE_FLAG_KW), "")
+	}
+
+	if add {
+		request.KeyVals.Add(string(common.ADD_KW), "")
+	}
+
+	return request
+}
+
+// AddKeyVal adds a key-value pair
+func (msg *IRODSMessageBundleStructFileRequest) AddKeyVal(key common.KeyWord, val string) {
+	msg.KeyVals.Add(string(key), val)
+}
+
+// GetBytes returns byte array
+func (msg *IRODSMessageBundleStructFileRequest) GetBytes() ([]byte, error) {
+	xmlBytes, err := xml.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal irods message to xml")
+	}
+	return xmlBytes, nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessageBundleStructFileRequest) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+	return nil
+}
+
+// GetMessage builds a message
+func (msg *IRODSMessageBundleStructFileRequest) GetMessage() (*IRODSMessage, error) {
+	bytes, err := msg.GetBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get bytes from irods message")
+	}
+
+	msgBody := IRODSMessageBody{
+		Type:    RODS_MESSAGE_API_REQ_TYPE,
+		Message: bytes,
+		Error:   nil,
+		Bs:      nil,
+		IntInfo: int32(common.STRUCT_FILE_BUNDLE_AN),
+	}
+
+	msgHeader, err := msgBody.BuildHeader()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build header from irods message")
+	}
+
+	return &IRODSMessage{
+		Header: msgHeader,
+		Body:   &msgBody,
+	}, nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageBundleStructFileRequest) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForRequest()
+}
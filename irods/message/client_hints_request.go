@@ -0,0 +1,42 @@
+package message
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+)
+
+// IRODSMessageClientHintsRequest stores client hints request
+type IRODSMessageClientHintsRequest struct {
+	// empty structure
+}
+
+// NewIRODSMessageClientHintsRequest creates a IRODSMessageClientHintsRequest message
+func NewIRODSMessageClientHintsRequest() *IRODSMessageClientHintsRequest {
+	return &IRODSMessageClientHintsRequest{}
+}
+
+// GetMessage builds a message
+func (msg *IRODSMessageClientHintsRequest) GetMessage() (*IRODSMessage, error) {
+	msgBody := IRODSMessageBody{
+		Type:    RODS_MESSAGE_API_REQ_TYPE,
+		Message: nil,
+		Error:   nil,
+		Bs:      nil,
+		IntInfo: int32(common.CLIENT_HINTS_AN),
+	}
+
+	msgHeader, err := msgBody.BuildHeader()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build header from irods message")
+	}
+
+	return &IRODSMessage{
+		Header: msgHeader,
+		Body:   &msgBody,
+	}, nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageClientHintsRequest) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForRequest()
+}
@@ -0,0 +1,83 @@
+package message
+
+import (
+	"encoding/json"
+	"encoding/xml"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// IRODSMessageClientHintsResponse stores client hints response
+type IRODSMessageClientHintsResponse struct {
+	XMLName xml.Name `xml:"BytesBuf_PI"`
+	Length  int      `xml:"buflen"`
+	Data    string   `xml:"buf"` // json output
+
+	// parsed from json output
+	Hints types.IRODSClientHints `xml:"-"`
+
+	// stores error return
+	Result int `xml:"-"`
+}
+
+// GetBytes returns byte array
+func (msg *IRODSMessageClientHintsResponse) GetBytes() ([]byte, error) {
+	xmlBytes, err := xml.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal irods message to xml")
+	}
+	return xmlBytes, nil
+}
+
+// CheckError returns error if server returned an error
+func (msg *IRODSMessageClientHintsResponse) CheckError() error {
+	if msg.Result < 0 {
+		return types.NewIRODSError(common.ErrorCode(msg.Result))
+	}
+	return nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessageClientHintsResponse) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+
+	data := msg.Data
+	if msg.Length > 0 && msg.Length < len(data) {
+		data = data[:msg.Length]
+	}
+
+	if len(data) > 0 {
+		err = json.Unmarshal([]byte(data), &msg.Hints)
+		if err != nil {
+			return errors.Wrapf(err, "failed to unmarshal json to irods message")
+		}
+	}
+	return nil
+}
+
+// FromMessage returns struct from IRODSMessage
+func (msg *IRODSMessageClientHintsResponse) FromMessage(msgIn *IRODSMessage) error {
+	if msgIn.Body == nil {
+		return errors.Errorf("empty message body")
+	}
+
+	msg.Result = int(msgIn.Body.IntInfo)
+
+	if len(msgIn.Body.Message) > 0 {
+		err := msg.FromBytes(msgIn.Body.Message)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get irods message from message body")
+		}
+	}
+	return nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageClientHintsResponse) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForResponse()
+}
@@ -0,0 +1,36 @@
+package message
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// IRODSMessageCloseSubStructFileResponse stores sub struct file close response
+type IRODSMessageCloseSubStructFileResponse struct {
+	// empty structure
+	Result int
+}
+
+// CheckError returns error if server returned an error
+func (msg *IRODSMessageCloseSubStructFileResponse) CheckError() error {
+	if msg.Result < 0 {
+		return types.NewIRODSError(common.ErrorCode(msg.Result))
+	}
+	return nil
+}
+
+// FromMessage returns struct from IRODSMessage
+func (msg *IRODSMessageCloseSubStructFileResponse) FromMessage(msgIn *IRODSMessage) error {
+	if msgIn.Body == nil {
+		return errors.Errorf("empty message body")
+	}
+
+	msg.Result = int(msgIn.Body.IntInfo)
+	return nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageCloseSubStructFileResponse) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForResponse()
+}
@@ -29,9 +29,7 @@ func NewIRODSMessageCreateDataObjectRequest(path string, resource string, mode t
 
 	request.KeyVals.Add(string(common.DATA_TYPE_KW), string(types.GENERIC_DT))
 
-	if len(resource) > 0 {
-		request.KeyVals.Add(string(common.DEST_RESC_NAME_KW), resource)
-	}
+	request.KeyVals.AddDestResource(resource)
 
 	if force {
 		request.KeyVals.Add(string(common.FORCE_FLAG_KW), "")
@@ -61,9 +59,7 @@ func NewIRODSMessageCreateobjRequestWithKeyVals(path string, resource string, mo
 		request.KeyVals.Add(string(common.DATA_TYPE_KW), string(types.GENERIC_DT))
 	}
 
-	if len(resource) > 0 {
-		request.KeyVals.Add(string(common.DEST_RESC_NAME_KW), resource)
-	}
+	request.KeyVals.AddDestResource(resource)
 
 	if force {
 		request.KeyVals.Add(string(common.FORCE_FLAG_KW), "")
@@ -0,0 +1,72 @@
+package message
+
+import (
+	"encoding/xml"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+)
+
+// IRODSMessageGetGridConfigurationValueRequest stores grid configuration value request
+type IRODSMessageGetGridConfigurationValueRequest struct {
+	XMLName    xml.Name `xml:"GridConfigurationInp_PI"`
+	Namespace  string   `xml:"name_space"`
+	OptionName string   `xml:"option_name"`
+}
+
+// NewIRODSMessageGetGridConfigurationValueRequest creates a IRODSMessageGetGridConfigurationValueRequest message
+func NewIRODSMessageGetGridConfigurationValueRequest(namespace string, optionName string) *IRODSMessageGetGridConfigurationValueRequest {
+	return &IRODSMessageGetGridConfigurationValueRequest{
+		Namespace:  namespace,
+		OptionName: optionName,
+	}
+}
+
+// GetBytes returns byte array
+func (msg *IRODSMessageGetGridConfigurationValueRequest) GetBytes() ([]byte, error) {
+	xmlBytes, err := xml.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal irods message to xml")
+	}
+	return xmlBytes, nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessageGetGridConfigurationValueRequest) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+	return nil
+}
+
+// GetMessage builds a message
+func (msg *IRODSMessageGetGridConfigurationValueRequest) GetMessage() (*IRODSMessage, error) {
+	bytes, err := msg.GetBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get bytes from irods message")
+	}
+
+	msgBody := IRODSMessageBody{
+		Type:    RODS_MESSAGE_API_REQ_TYPE,
+		Message: bytes,
+		Error:   nil,
+		Bs:      nil,
+		IntInfo: int32(common.GET_GRID_CONFIGURATION_VALUE_APN),
+	}
+
+	msgHeader, err := msgBody.BuildHeader()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build header from irods message")
+	}
+
+	return &IRODSMessage{
+		Header: msgHeader,
+		Body:   &msgBody,
+	}, nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageGetGridConfigurationValueRequest) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForRequest()
+}
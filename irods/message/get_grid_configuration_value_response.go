@@ -0,0 +1,57 @@
+package message
+
+import (
+	"encoding/xml"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// IRODSMessageGetGridConfigurationValueResponse stores grid configuration value response
+type IRODSMessageGetGridConfigurationValueResponse struct {
+	XMLName     xml.Name `xml:"GridConfigurationOut_PI"`
+	OptionValue string   `xml:"option_value"`
+
+	// stores error return
+	Result int `xml:"-"`
+}
+
+// CheckError returns error if server returned an error
+func (msg *IRODSMessageGetGridConfigurationValueResponse) CheckError() error {
+	if msg.Result < 0 {
+		return types.NewIRODSError(common.ErrorCode(msg.Result))
+	}
+	return nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessageGetGridConfigurationValueResponse) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+	return nil
+}
+
+// FromMessage returns struct from IRODSMessage
+func (msg *IRODSMessageGetGridConfigurationValueResponse) FromMessage(msgIn *IRODSMessage) error {
+	if msgIn.Body == nil {
+		return errors.Errorf("empty message body")
+	}
+
+	msg.Result = int(msgIn.Body.IntInfo)
+
+	if len(msgIn.Body.Message) > 0 {
+		err := msg.FromBytes(msgIn.Body.Message)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get irods message from message body")
+		}
+	}
+	return nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageGetGridConfigurationValueResponse) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForResponse()
+}
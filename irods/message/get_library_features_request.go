@@ -0,0 +1,42 @@
+package message
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+)
+
+// IRODSMessageGetLibraryFeaturesRequest stores library features request
+type IRODSMessageGetLibraryFeaturesRequest struct {
+	// empty structure
+}
+
+// NewIRODSMessageGetLibraryFeaturesRequest creates a IRODSMessageGetLibraryFeaturesRequest message
+func NewIRODSMessageGetLibraryFeaturesRequest() *IRODSMessageGetLibraryFeaturesRequest {
+	return &IRODSMessageGetLibraryFeaturesRequest{}
+}
+
+// GetMessage builds a message
+func (msg *IRODSMessageGetLibraryFeaturesRequest) GetMessage() (*IRODSMessage, error) {
+	msgBody := IRODSMessageBody{
+		Type:    RODS_MESSAGE_API_REQ_TYPE,
+		Message: nil,
+		Error:   nil,
+		Bs:      nil,
+		IntInfo: int32(common.GET_LIBRARY_FEATURES_AN),
+	}
+
+	msgHeader, err := msgBody.BuildHeader()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build header from irods message")
+	}
+
+	return &IRODSMessage{
+		Header: msgHeader,
+		Body:   &msgBody,
+	}, nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageGetLibraryFeaturesRequest) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForRequest()
+}
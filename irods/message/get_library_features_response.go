@@ -0,0 +1,69 @@
+package message
+
+import (
+	"encoding/json"
+	"encoding/xml"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// IRODSMessageGetLibraryFeaturesResponse stores library features response
+type IRODSMessageGetLibraryFeaturesResponse struct {
+	XMLName xml.Name `xml:"STR_PI"`
+	Output  string   `xml:"myStr"` // json output
+
+	// parsed from json output, feature name to its version, e.g., "IRODS_HAS_LIBRARY_TICKET_ADMINISTRATION": 202208
+	Features map[string]int `xml:"-"`
+
+	// stores error return
+	Result int `xml:"-"`
+}
+
+// CheckError returns error if server returned an error
+func (msg *IRODSMessageGetLibraryFeaturesResponse) CheckError() error {
+	if msg.Result < 0 {
+		return types.NewIRODSError(common.ErrorCode(msg.Result))
+	}
+	return nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessageGetLibraryFeaturesResponse) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+
+	msg.Features = map[string]int{}
+	if len(msg.Output) > 0 {
+		err = json.Unmarshal([]byte(msg.Output), &msg.Features)
+		if err != nil {
+			return errors.Wrapf(err, "failed to unmarshal json to irods message")
+		}
+	}
+	return nil
+}
+
+// FromMessage returns struct from IRODSMessage
+func (msg *IRODSMessageGetLibraryFeaturesResponse) FromMessage(msgIn *IRODSMessage) error {
+	if msgIn.Body == nil {
+		return errors.Errorf("empty message body")
+	}
+
+	msg.Result = int(msgIn.Body.IntInfo)
+
+	if len(msgIn.Body.Message) > 0 {
+		err := msg.FromBytes(msgIn.Body.Message)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get irods message from message body")
+		}
+	}
+	return nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageGetLibraryFeaturesResponse) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForResponse()
+}
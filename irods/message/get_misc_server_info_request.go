@@ -0,0 +1,42 @@
+package message
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+)
+
+// IRODSMessageGetMiscServerInfoRequest stores misc server info request
+type IRODSMessageGetMiscServerInfoRequest struct {
+	// empty structure
+}
+
+// NewIRODSMessageGetMiscServerInfoRequest creates a IRODSMessageGetMiscServerInfoRequest message
+func NewIRODSMessageGetMiscServerInfoRequest() *IRODSMessageGetMiscServerInfoRequest {
+	return &IRODSMessageGetMiscServerInfoRequest{}
+}
+
+// GetMessage builds a message
+func (msg *IRODSMessageGetMiscServerInfoRequest) GetMessage() (*IRODSMessage, error) {
+	msgBody := IRODSMessageBody{
+		Type:    RODS_MESSAGE_API_REQ_TYPE,
+		Message: nil,
+		Error:   nil,
+		Bs:      nil,
+		IntInfo: int32(common.GET_MISC_SVR_INFO_AN),
+	}
+
+	msgHeader, err := msgBody.BuildHeader()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build header from irods message")
+	}
+
+	return &IRODSMessage{
+		Header: msgHeader,
+		Body:   &msgBody,
+	}, nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageGetMiscServerInfoRequest) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForRequest()
+}
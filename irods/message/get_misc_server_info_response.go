@@ -0,0 +1,70 @@
+package message
+
+import (
+	"encoding/xml"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// IRODSMessageGetMiscServerInfoResponse stores misc server info response
+type IRODSMessageGetMiscServerInfoResponse struct {
+	XMLName        xml.Name `xml:"MiscSvrInfo_PI"`
+	ServerType     int      `xml:"serverType"` // 1 = RCAT_ENABLED, 2 = RCAT_NOT_ENABLED
+	ServerBootTime uint32   `xml:"serverBootTime"`
+	ReleaseVersion string   `xml:"relVersion"`
+	APIVersion     string   `xml:"apiVersion"`
+	RodsZone       string   `xml:"rodsZone"`
+	// stores error return
+	Result int `xml:"-"`
+}
+
+// GetBytes returns byte array
+func (msg *IRODSMessageGetMiscServerInfoResponse) GetBytes() ([]byte, error) {
+	xmlBytes, err := xml.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal irods message to xml")
+	}
+	return xmlBytes, nil
+}
+
+// CheckError returns error if server returned an error
+func (msg *IRODSMessageGetMiscServerInfoResponse) CheckError() error {
+	if msg.Result < 0 {
+		return types.NewIRODSError(common.ErrorCode(msg.Result))
+	}
+	return nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessageGetMiscServerInfoResponse) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+	return nil
+}
+
+// FromMessage returns struct from IRODSMessage
+func (msg *IRODSMessageGetMiscServerInfoResponse) FromMessage(msgIn *IRODSMessage) error {
+	if msgIn.Body == nil {
+		return errors.Errorf("empty message body")
+	}
+
+	msg.Result = int(msgIn.Body.IntInfo)
+
+	if msgIn.Body.Message != nil {
+		err := msg.FromBytes(msgIn.Body.Message)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get irods message from message body")
+		}
+	}
+
+	return nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageGetMiscServerInfoResponse) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForResponse()
+}
@@ -1,6 +1,11 @@
 package message
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
 
 // IRODSMessageRawString ...
 type IRODSMessageRawString struct {
@@ -47,6 +52,21 @@ func (kv *IRODSMessageSSKeyVal) Add(key string, val string) {
 	kv.Length = len(kv.Keys)
 }
 
+// AddDestResource adds a key-val pair targeting the resource, a resource hierarchy (root;child;leaf) is given with resc_hier
+func (kv *IRODSMessageSSKeyVal) AddDestResource(resource string) {
+	if len(resource) == 0 {
+		return
+	}
+
+	if types.IsResourceHierarchy(resource) {
+		kv.Add(string(common.DEST_RESC_NAME_KW), types.GetResourceHierarchyRoot(resource))
+		kv.Add(string(common.RESC_HIER_STR_KW), resource)
+		return
+	}
+
+	kv.Add(string(common.DEST_RESC_NAME_KW), resource)
+}
+
 // NewIRODSMessageIIKeyVal creates a new IRODSMessageIIKeyVal
 func NewIRODSMessageIIKeyVal() *IRODSMessageIIKeyVal {
 	return &IRODSMessageIIKeyVal{
@@ -0,0 +1,90 @@
+package message
+
+import (
+	"encoding/xml"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+)
+
+// IRODSMessageModifyDataObjectMetaRequest stores data object system metadata modification request
+type IRODSMessageModifyDataObjectMetaRequest struct {
+	XMLName        xml.Name                   `xml:"ModDataObjMeta_PI"`
+	DataObjectInfo IRODSMessageDataObjectInfo `xml:"DataObjInfo_PI"`
+	KeyVals        IRODSMessageSSKeyVal       `xml:"KeyValPair_PI"`
+}
+
+// NewIRODSMessageModifyDataObjectMetaRequest creates a IRODSMessageModifyDataObjectMetaRequest message
+// the replica to modify is selected by data id, replica number and resource hierarchy
+func NewIRODSMessageModifyDataObjectMetaRequest(path string, dataID int64, replicaNumber int, resourceHierarchy string) *IRODSMessageModifyDataObjectMetaRequest {
+	request := &IRODSMessageModifyDataObjectMetaRequest{
+		DataObjectInfo: IRODSMessageDataObjectInfo{
+			Path:              path,
+			ResourceHierarchy: resourceHierarchy,
+			ReplicaNumber:     replicaNumber,
+			DataID:            dataID,
+			KeyVals: IRODSMessageSSKeyVal{
+				Length: 0,
+			},
+		},
+		KeyVals: IRODSMessageSSKeyVal{
+			Length: 0,
+		},
+	}
+
+	return request
+}
+
+// AddKeyVal adds a key-value pair
+func (msg *IRODSMessageModifyDataObjectMetaRequest) AddKeyVal(key common.KeyWord, val string) {
+	msg.KeyVals.Add(string(key), val)
+}
+
+// GetBytes returns byte array
+func (msg *IRODSMessageModifyDataObjectMetaRequest) GetBytes() ([]byte, error) {
+	xmlBytes, err := xml.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal irods message to xml")
+	}
+	return xmlBytes, nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessageModifyDataObjectMetaRequest) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+	return nil
+}
+
+// GetMessage builds a message
+func (msg *IRODSMessageModifyDataObjectMetaRequest) GetMessage() (*IRODSMessage, error) {
+	bytes, err := msg.GetBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get bytes from irods message")
+	}
+
+	msgBody := IRODSMessageBody{
+		Type:    RODS_MESSAGE_API_REQ_TYPE,
+		Message: bytes,
+		Error:   nil,
+		Bs:      nil,
+		IntInfo: int32(common.MOD_DATA_OBJ_META_AN),
+	}
+
+	msgHeader, err := msgBody.BuildHeader()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build header from irods message")
+	}
+
+	return &IRODSMessage{
+		Header: msgHeader,
+		Body:   &msgBody,
+	}, nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageModifyDataObjectMetaRequest) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForRequest()
+}
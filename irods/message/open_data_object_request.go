@@ -28,9 +28,7 @@ func NewIRODSMessageOpenDataObjectRequest(path string, resource string, mode typ
 		},
 	}
 
-	if len(resource) > 0 {
-		request.KeyVals.Add(string(common.DEST_RESC_NAME_KW), resource)
-	}
+	request.KeyVals.AddDestResource(resource)
 
 	return request
 }
@@ -51,9 +49,7 @@ func NewIRODSMessageOpenobjRequestWithOperation(path string, resource string, mo
 		},
 	}
 
-	if len(resource) > 0 {
-		request.KeyVals.Add(string(common.DEST_RESC_NAME_KW), resource)
-	}
+	request.KeyVals.AddDestResource(resource)
 
 	return request
 }
@@ -75,8 +71,8 @@ func NewIRODSMessageOpenobjRequestForPutParallel(path string, resource string, m
 	}
 
 	if len(resource) > 0 {
-		request.KeyVals.Add(string(common.RESC_NAME_KW), resource)
-		request.KeyVals.Add(string(common.DEST_RESC_NAME_KW), resource)
+		request.KeyVals.Add(string(common.RESC_NAME_KW), types.GetResourceHierarchyRoot(resource))
+		request.KeyVals.AddDestResource(resource)
 	}
 
 	request.AddKeyVal(common.NUM_THREADS_KW, fmt.Sprintf("%d", threadNum))
@@ -0,0 +1,105 @@
+package message
+
+import (
+	"encoding/xml"
+	"path"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// IRODSMessageOpenSubStructFileRequest stores sub struct file open request
+type IRODSMessageOpenSubStructFileRequest struct {
+	XMLName                  xml.Name                       `xml:"SubFile_PI"`
+	Host                     IRODSMessageHost               `xml:"RHostAddr_PI"`
+	SubFilePath              string                         `xml:"subFilePath"`
+	Mode                     int                            `xml:"mode"`
+	Flags                    int                            `xml:"flags"`
+	Offset                   int64                          `xml:"offset"`
+	SpecialCollectionPointer *IRODSMessageSpecialCollection `xml:"SpecColl_PI"`
+}
+
+// NewIRODSMessageOpenSubStructFileRequest creates a IRODSMessageOpenSubStructFileRequest message
+// the struct file data object is accessed as a struct file collection, so subFilePath is relative to the struct file
+func NewIRODSMessageOpenSubStructFileRequest(resource *types.IRODSResource, obj *types.IRODSDataObject, replica *types.IRODSReplica, subFilePath string) (*IRODSMessageOpenSubStructFileRequest, error) {
+	host, err := NewIRODSMessageHost(resource)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create irods host message")
+	}
+
+	if resource.Name != replica.ResourceName {
+		return nil, errors.Errorf("resource name %q does not match replica resource name %q", resource.Name, replica.ResourceName)
+	}
+
+	request := &IRODSMessageOpenSubStructFileRequest{
+		Host:        *host,
+		SubFilePath: path.Join(obj.Path, subFilePath),
+		Mode:        0,
+		Flags:       int(types.O_RDONLY),
+		Offset:      0,
+		SpecialCollectionPointer: &IRODSMessageSpecialCollection{
+			CollectionClass:   int(common.STRUCT_FILE_COLL),
+			Type:              int(common.TAR_STRUCT_FILE_T),
+			Collection:        obj.Path,
+			ObjectPath:        obj.Path,
+			Resource:          resource.Name,
+			ResourceHierarchy: replica.ResourceHierarchy,
+			PhysicalPath:      replica.Path,
+			CacheDirectory:    "", // staged by the server
+			CacheDirty:        0,
+			ReplicationNumber: int(replica.Number),
+		},
+	}
+
+	return request, nil
+}
+
+// GetBytes returns byte array
+func (msg *IRODSMessageOpenSubStructFileRequest) GetBytes() ([]byte, error) {
+	xmlBytes, err := xml.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal irods message to xml")
+	}
+	return xmlBytes, nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessageOpenSubStructFileRequest) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+	return nil
+}
+
+// GetMessage builds a message
+func (msg *IRODSMessageOpenSubStructFileRequest) GetMessage() (*IRODSMessage, error) {
+	bytes, err := msg.GetBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get bytes from irods message")
+	}
+
+	msgBody := IRODSMessageBody{
+		Type:    RODS_MESSAGE_API_REQ_TYPE,
+		Message: bytes,
+		Error:   nil,
+		Bs:      nil,
+		IntInfo: int32(common.SUB_STRUCT_FILE_OPEN_AN),
+	}
+
+	msgHeader, err := msgBody.BuildHeader()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build header from irods message")
+	}
+
+	return &IRODSMessage{
+		Header: msgHeader,
+		Body:   &msgBody,
+	}, nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageOpenSubStructFileRequest) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForRequest()
+}
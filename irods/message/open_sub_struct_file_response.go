@@ -0,0 +1,41 @@
+package message
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// IRODSMessageOpenSubStructFileResponse stores sub struct file open response
+type IRODSMessageOpenSubStructFileResponse struct {
+	// empty structure
+	FileDescriptor int
+}
+
+// CheckError returns error if server returned an error
+func (msg *IRODSMessageOpenSubStructFileResponse) CheckError() error {
+	if msg.FileDescriptor < 0 {
+		return types.NewIRODSError(common.ErrorCode(msg.FileDescriptor))
+	}
+	return nil
+}
+
+// GetFileDescriptor returns file descriptor
+func (msg *IRODSMessageOpenSubStructFileResponse) GetFileDescriptor() int {
+	return msg.FileDescriptor
+}
+
+// FromMessage returns struct from IRODSMessage
+func (msg *IRODSMessageOpenSubStructFileResponse) FromMessage(msgIn *IRODSMessage) error {
+	if msgIn.Body == nil {
+		return errors.Errorf("empty message body")
+	}
+
+	msg.FileDescriptor = int(msgIn.Body.IntInfo)
+	return nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageOpenSubStructFileResponse) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForResponse()
+}
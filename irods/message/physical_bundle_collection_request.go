@@ -0,0 +1,99 @@
+package message
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+)
+
+// IRODSMessagePhysicalBundleCollectionRequest stores physical collection bundle request
+type IRODSMessagePhysicalBundleCollectionRequest struct {
+	XMLName       xml.Name             `xml:"StructFileExtAndRegInp_PI"`
+	Path          string               `xml:"objPath"` // unused
+	Collection    string               `xml:"collection"`
+	OperationType int                  `xml:"oprType"`
+	Flags         int                  `xml:"flags"` // unused
+	KeyVals       IRODSMessageSSKeyVal `xml:"KeyValPair_PI"`
+}
+
+// NewIRODSMessagePhysicalBundleCollectionRequest creates a IRODSMessagePhysicalBundleCollectionRequest message
+// maxSubFiles and maxBundleSizeGB are ignored if they are not positive
+func NewIRODSMessagePhysicalBundleCollectionRequest(collection string, bundleResource string, maxSubFiles int, maxBundleSizeGB int) *IRODSMessagePhysicalBundleCollectionRequest {
+	request := &IRODSMessagePhysicalBundleCollectionRequest{
+		Path:          "",
+		Collection:    collection,
+		OperationType: 0,
+		Flags:         0,
+		KeyVals: IRODSMessageSSKeyVal{
+			Length: 0,
+		},
+	}
+
+	request.KeyVals.Add(string(common.DEST_RESC_NAME_KW), bundleResource)
+
+	if maxSubFiles > 0 {
+		request.KeyVals.Add(string(common.MAX_SUB_FILE_KW), fmt.Sprintf("%d", maxSubFiles))
+	}
+
+	if maxBundleSizeGB > 0 {
+		request.KeyVals.Add(string(common.MAX_BUNDLE_SIZE_KW), fmt.Sprintf("%d", maxBundleSizeGB))
+	}
+
+	return request
+}
+
+// AddKeyVal adds a key-value pair
+func (msg *IRODSMessagePhysicalBundleCollectionRequest) AddKeyVal(key common.KeyWord, val string) {
+	msg.KeyVals.Add(string(key), val)
+}
+
+// GetBytes returns byte array
+func (msg *IRODSMessagePhysicalBundleCollectionRequest) GetBytes() ([]byte, error) {
+	xmlBytes, err := xml.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal irods message to xml")
+	}
+	return xmlBytes, nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessagePhysicalBundleCollectionRequest) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+	return nil
+}
+
+// GetMessage builds a message
+func (msg *IRODSMessagePhysicalBundleCollectionRequest) GetMessage() (*IRODSMessage, error) {
+	bytes, err := msg.GetBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get bytes from irods message")
+	}
+
+	msgBody := IRODSMessageBody{
+		Type:    RODS_MESSAGE_API_REQ_TYPE,
+		Message: bytes,
+		Error:   nil,
+		Bs:      nil,
+		IntInfo: int32(common.PHY_BUNDLE_COLL_AN),
+	}
+
+	msgHeader, err := msgBody.BuildHeader()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build header from irods message")
+	}
+
+	return &IRODSMessage{
+		Header: msgHeader,
+		Body:   &msgBody,
+	}, nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessagePhysicalBundleCollectionRequest) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForRequest()
+}
@@ -0,0 +1,83 @@
+package message
+
+import (
+	"encoding/xml"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+)
+
+// IRODSMessagePhysicalMoveDataObjectRequest stores data object physical move request
+type IRODSMessagePhysicalMoveDataObjectRequest IRODSMessageDataObjectRequest
+
+// NewIRODSMessagePhysicalMoveDataObjectRequest creates a IRODSMessagePhysicalMoveDataObjectRequest message
+func NewIRODSMessagePhysicalMoveDataObjectRequest(path string) *IRODSMessagePhysicalMoveDataObjectRequest {
+	request := &IRODSMessagePhysicalMoveDataObjectRequest{
+		Path:          path,
+		CreateMode:    0,
+		OpenFlags:     0,
+		Offset:        0,
+		Size:          -1,
+		Threads:       0,
+		OperationType: int(common.OPER_TYPE_PHYMV),
+		KeyVals: IRODSMessageSSKeyVal{
+			Length: 0,
+		},
+	}
+
+	return request
+}
+
+// AddKeyVal adds a key-value pair
+func (msg *IRODSMessagePhysicalMoveDataObjectRequest) AddKeyVal(key common.KeyWord, val string) {
+	msg.KeyVals.Add(string(key), val)
+}
+
+// GetBytes returns byte array
+func (msg *IRODSMessagePhysicalMoveDataObjectRequest) GetBytes() ([]byte, error) {
+	xmlBytes, err := xml.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal irods message to xml")
+	}
+	return xmlBytes, nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessagePhysicalMoveDataObjectRequest) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+	return nil
+}
+
+// GetMessage builds a message
+func (msg *IRODSMessagePhysicalMoveDataObjectRequest) GetMessage() (*IRODSMessage, error) {
+	bytes, err := msg.GetBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get bytes from irods message")
+	}
+
+	msgBody := IRODSMessageBody{
+		Type:    RODS_MESSAGE_API_REQ_TYPE,
+		Message: bytes,
+		Error:   nil,
+		Bs:      nil,
+		IntInfo: int32(common.DATA_OBJ_PHYMV_AN),
+	}
+
+	msgHeader, err := msgBody.BuildHeader()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build header from irods message")
+	}
+
+	return &IRODSMessage{
+		Header: msgHeader,
+		Body:   &msgBody,
+	}, nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessagePhysicalMoveDataObjectRequest) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForRequest()
+}
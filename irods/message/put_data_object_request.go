@@ -25,9 +25,7 @@ func NewIRODSMessagePutDataObjectRequest(path string, resource string, fileLengt
 		},
 	}
 
-	if len(resource) > 0 {
-		request.KeyVals.Add(string(common.DEST_RESC_NAME_KW), resource)
-	}
+	request.KeyVals.AddDestResource(resource)
 
 	return request
 }
@@ -3,6 +3,7 @@ package message
 import (
 	"encoding/xml"
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/cyverse/go-irodsclient/irods/common"
@@ -49,21 +50,64 @@ func (msg *IRODSMessageQueryRequest) AddSelectRaw(key common.ICATColumnNumber, v
 	msg.Selects.Add(int(key), int(val))
 }
 
+// AddSelectWithSum adds a column to select its sum
 func (msg *IRODSMessageQueryRequest) AddSelectWithSum(key common.ICATColumnNumber) {
 	msg.Selects.Add(int(key), int(common.ICAT_SELECT_FUNC_SUM))
 }
 
+// AddSelectWithCount adds a column to select the number of its values
 func (msg *IRODSMessageQueryRequest) AddSelectWithCount(key common.ICATColumnNumber) {
 	msg.Selects.Add(int(key), int(common.ICAT_SELECT_FUNC_COUNT))
 }
 
+// AddSelectWithMin adds a column to select its minimum
+func (msg *IRODSMessageQueryRequest) AddSelectWithMin(key common.ICATColumnNumber) {
+	msg.Selects.Add(int(key), int(common.ICAT_SELECT_FUNC_MIN))
+}
+
+// AddSelectWithMax adds a column to select its maximum
+func (msg *IRODSMessageQueryRequest) AddSelectWithMax(key common.ICATColumnNumber) {
+	msg.Selects.Add(int(key), int(common.ICAT_SELECT_FUNC_MAX))
+}
+
+// AddSelectWithAvg adds a column to select its average
+func (msg *IRODSMessageQueryRequest) AddSelectWithAvg(key common.ICATColumnNumber) {
+	msg.Selects.Add(int(key), int(common.ICAT_SELECT_FUNC_AVG))
+}
+
+// AddSelectWithFunction adds a column to select with the select function, aggregates group results by other selected columns
+func (msg *IRODSMessageQueryRequest) AddSelectWithFunction(key common.ICATColumnNumber, selectFunc common.ICATSelectFunction) {
+	msg.Selects.Add(int(key), int(selectFunc))
+}
+
+// AddSelectWithOrder adds a select sorting results by the column, columns added earlier take precedence
+func (msg *IRODSMessageQueryRequest) AddSelectWithOrder(key common.ICATColumnNumber, descending bool) {
+	order := common.ICAT_SELECT_ORDER_BY
+	if descending {
+		order = common.ICAT_SELECT_ORDER_BY_DESC
+	}
+
+	msg.Selects.Add(int(key), int(common.ICAT_SELECT_FUNC_NONE|order))
+}
+
+// HasOption returns true if the option flag is set
+func (msg *IRODSMessageQueryRequest) HasOption(option common.ICATQueryOption) bool {
+	return msg.Options&int(option) != 0
+}
+
 // AddCondition adds a condition
+// the condition is upper-cased if the request has ICAT_QUERY_OPTION_UPPER_CASE_WHERE option
 func (msg *IRODSMessageQueryRequest) AddCondition(key common.ICATColumnNumber, val string) {
+	if msg.HasOption(common.ICAT_QUERY_OPTION_UPPER_CASE_WHERE) {
+		val = strings.ToUpper(val)
+	}
+
 	escapedVal := util.EscapeXMLSpecialChars(val)
 	msg.Conditions.Add(int(key), escapedVal)
 }
 
 // AddEqualStringCondition adds a condition that checks equality of a string
+// the value is taken literally, iRODS binds everything between the first and the last quotes, so quotes in the value need no escaping
 func (msg *IRODSMessageQueryRequest) AddEqualStringCondition(key common.ICATColumnNumber, val string) {
 	msg.AddCondition(key, fmt.Sprintf("= '%s'", val))
 }
@@ -73,11 +117,28 @@ func (msg *IRODSMessageQueryRequest) AddEqualIDCondition(key common.ICATColumnNu
 	msg.AddCondition(key, fmt.Sprintf("= '%d'", val))
 }
 
+// AddInIDCondition adds a condition that checks an ID is one of the IDs
+func (msg *IRODSMessageQueryRequest) AddInIDCondition(key common.ICATColumnNumber, vals []int64) {
+	quotedVals := make([]string, 0, len(vals))
+	for _, val := range vals {
+		quotedVals = append(quotedVals, fmt.Sprintf("'%d'", val))
+	}
+
+	msg.AddCondition(key, fmt.Sprintf("in (%s)", strings.Join(quotedVals, ", ")))
+}
+
 // AddLikeStringCondition adds a condition that checks containment of a string
+// the value is a LIKE pattern, use AddLikePrefixStringCondition to match a literal prefix such as a collection path
 func (msg *IRODSMessageQueryRequest) AddLikeStringCondition(key common.ICATColumnNumber, val string) {
 	msg.AddCondition(key, fmt.Sprintf("like '%s'", val))
 }
 
+// AddLikePrefixStringCondition adds a condition that checks a string starts with the prefix followed by the LIKE pattern
+// wildcards in the prefix are escaped, so the prefix matches literally
+func (msg *IRODSMessageQueryRequest) AddLikePrefixStringCondition(key common.ICATColumnNumber, prefix string, pattern string) {
+	msg.AddLikeStringCondition(key, util.EscapeSQLWildcards(prefix)+pattern)
+}
+
 // AddKeyVal adds a key-value pair
 func (msg *IRODSMessageQueryRequest) AddKeyVal(key common.KeyWord, val string) {
 	escapedVal := util.EscapeXMLSpecialChars(val)
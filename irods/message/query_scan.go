@@ -0,0 +1,248 @@
+package message
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+const (
+	// QueryColumnTag is a struct field tag that maps the field to a query column, e.g., `irods:"DATA_NAME"` or `irods:"403"`
+	QueryColumnTag string = "irods"
+)
+
+// queryStructField is a struct field mapped to a query column
+type queryStructField struct {
+	column common.ICATColumnNumber
+	index  []int
+}
+
+// getQueryStructFields returns fields of the struct type having column tags, fields of embedded structs are included
+func getQueryStructFields(structType reflect.Type) ([]queryStructField, error) {
+	fields := []queryStructField{}
+
+	for idx := 0; idx < structType.NumField(); idx++ {
+		field := structType.Field(idx)
+		tag, hasTag := field.Tag.Lookup(QueryColumnTag)
+
+		if !hasTag {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				embeddedFields, err := getQueryStructFields(field.Type)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, embeddedField := range embeddedFields {
+					embeddedField.index = append([]int{idx}, embeddedField.index...)
+					fields = append(fields, embeddedField)
+				}
+			}
+			continue
+		}
+
+		if tag == "-" {
+			continue
+		}
+
+		if !field.IsExported() {
+			return nil, errors.Errorf("field %q with column tag %q is not exported", field.Name, tag)
+		}
+
+		column, ok := common.GetICATColumnNumber(tag)
+		if !ok {
+			columnNumber, err := strconv.Atoi(tag)
+			if err != nil {
+				return nil, errors.Errorf("unknown column %q in tag of field %q", tag, field.Name)
+			}
+			column = common.ICATColumnNumber(columnNumber)
+		}
+
+		fields = append(fields, queryStructField{
+			column: column,
+			index:  []int{idx},
+		})
+	}
+
+	return fields, nil
+}
+
+// GetQueryStructColumns returns columns mapped by column tags of the struct, v is a struct, a pointer to a struct or a slice of them
+func GetQueryStructColumns(v interface{}) ([]common.ICATColumnNumber, error) {
+	structType := reflect.TypeOf(v)
+	for structType != nil && (structType.Kind() == reflect.Ptr || structType.Kind() == reflect.Slice) {
+		structType = structType.Elem()
+	}
+
+	if structType == nil || structType.Kind() != reflect.Struct {
+		return nil, errors.Errorf("type %T is not a struct", v)
+	}
+
+	fields, err := getQueryStructFields(structType)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]common.ICATColumnNumber, len(fields))
+	for idx, field := range fields {
+		columns[idx] = field.column
+	}
+
+	return columns, nil
+}
+
+// AddSelectsForStruct adds columns mapped by column tags of the struct to select
+func (msg *IRODSMessageQueryRequest) AddSelectsForStruct(v interface{}) error {
+	columns, err := GetQueryStructColumns(v)
+	if err != nil {
+		return err
+	}
+
+	for _, column := range columns {
+		msg.AddSelect(column)
+	}
+
+	return nil
+}
+
+// ScanRows appends rows of the result to dest, a pointer to a slice of structs or struct pointers
+// values are stored in fields having column tags, e.g., `irods:"DATA_NAME"`, columns not in the result leave fields zero
+// supported field types are string, bool, integers, floats and time.Time parsed from iRODS time strings
+func (msg *IRODSMessageQueryResponse) ScanRows(dest interface{}) error {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.IsNil() || destValue.Elem().Kind() != reflect.Slice {
+		return errors.Errorf("destination %T is not a pointer to a slice", dest)
+	}
+
+	sliceValue := destValue.Elem()
+	elemType := sliceValue.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	structType := elemType
+	if isPtr {
+		structType = elemType.Elem()
+	}
+
+	if structType.Kind() != reflect.Struct {
+		return errors.Errorf("destination %T is not a slice of structs", dest)
+	}
+
+	fields, err := getQueryStructFields(structType)
+	if err != nil {
+		return err
+	}
+
+	if msg.AttributeCount > len(msg.SQLResult) {
+		return errors.Errorf("failed to receive attributes - requires %d, but received %d attributes", msg.AttributeCount, len(msg.SQLResult))
+	}
+
+	// map fields to results
+	results := make([]*IRODSMessageSQLResult, len(fields))
+	for idx, field := range fields {
+		for attr := 0; attr < msg.AttributeCount; attr++ {
+			if msg.SQLResult[attr].AttributeIndex == int(field.column) {
+				results[idx] = &msg.SQLResult[attr]
+				break
+			}
+		}
+
+		if results[idx] != nil && len(results[idx].Values) != msg.RowCount {
+			return errors.Errorf("failed to receive rows - requires %d, but received %d attributes", msg.RowCount, len(results[idx].Values))
+		}
+	}
+
+	for row := 0; row < msg.RowCount; row++ {
+		structValue := reflect.New(structType).Elem()
+
+		for idx, field := range fields {
+			if results[idx] == nil {
+				continue
+			}
+
+			value := results[idx].Values[row]
+			fieldValue := structValue.FieldByIndex(field.index)
+
+			err := setQueryFieldValue(fieldValue, value)
+			if err != nil {
+				return errors.Wrapf(err, "failed to set column %d value %q to field %q", field.column, value, structType.FieldByIndex(field.index).Name)
+			}
+		}
+
+		if isPtr {
+			sliceValue.Set(reflect.Append(sliceValue, structValue.Addr()))
+		} else {
+			sliceValue.Set(reflect.Append(sliceValue, structValue))
+		}
+	}
+
+	return nil
+}
+
+// setQueryFieldValue parses the column value and sets it to the field, empty values leave numbers zero
+func setQueryFieldValue(fieldValue reflect.Value, value string) error {
+	if fieldValue.Type() == reflect.TypeOf(time.Time{}) {
+		if len(value) == 0 {
+			return nil
+		}
+
+		t, err := util.GetIRODSDateTime(value)
+		if err != nil {
+			return err
+		}
+
+		fieldValue.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch fieldValue.Kind() {
+	case reflect.String:
+		fieldValue.SetString(value)
+	case reflect.Bool:
+		if len(value) == 0 {
+			return nil
+		}
+
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return err
+		}
+		fieldValue.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if len(value) == 0 {
+			return nil
+		}
+
+		i, err := strconv.ParseInt(strings.TrimSpace(value), 10, fieldValue.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fieldValue.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if len(value) == 0 {
+			return nil
+		}
+
+		u, err := strconv.ParseUint(strings.TrimSpace(value), 10, fieldValue.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fieldValue.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		if len(value) == 0 {
+			return nil
+		}
+
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), fieldValue.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fieldValue.SetFloat(f)
+	default:
+		return errors.Errorf("unsupported field type %s", fieldValue.Type())
+	}
+
+	return nil
+}
@@ -38,6 +38,11 @@ func NewIRODSMessageRemoveCollectionRequest(name string, recurse bool, force boo
 	return request
 }
 
+// SetUnregister sets the request to unregister the collection from the catalog without deleting the physical files
+func (msg *IRODSMessageRemoveCollectionRequest) SetUnregister() {
+	msg.OperationType = int(common.OPER_TYPE_UNREG)
+}
+
 // AddKeyVal adds a key-value pair
 func (msg *IRODSMessageRemoveCollectionRequest) AddKeyVal(key common.KeyWord, val string) {
 	msg.KeyVals.Add(string(key), val)
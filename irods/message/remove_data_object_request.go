@@ -32,6 +32,11 @@ func NewIRODSMessageRemoveDataObjectRequest(path string, force bool) *IRODSMessa
 	return request
 }
 
+// SetUnregister sets the request to unregister the data object from the catalog without deleting the physical file
+func (msg *IRODSMessageRemoveDataObjectRequest) SetUnregister() {
+	msg.OperationType = int(common.OPER_TYPE_UNREG)
+}
+
 // AddKeyVal adds a key-value pair
 func (msg *IRODSMessageRemoveDataObjectRequest) AddKeyVal(key common.KeyWord, val string) {
 	msg.KeyVals.Add(string(key), val)
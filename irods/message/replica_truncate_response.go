@@ -0,0 +1,73 @@
+package message
+
+import (
+	"encoding/json"
+	"encoding/xml"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// IRODSMessageReplicaTruncateResponse stores replica truncation response
+type IRODSMessageReplicaTruncateResponse struct {
+	XMLName xml.Name `xml:"STR_PI"`
+	Output  string   `xml:"myStr"` // json output
+
+	// parsed from json output
+	ResourceHierarchy string `xml:"-" json:"resource_hierarchy"`
+	ReplicaNumber     int    `xml:"-" json:"replica_number"`
+	Message           string `xml:"-" json:"message"`
+
+	// stores error return
+	Result int `xml:"-" json:"-"`
+}
+
+// CheckError returns error if server returned an error
+func (msg *IRODSMessageReplicaTruncateResponse) CheckError() error {
+	if msg.Result < 0 {
+		if len(msg.Message) > 0 {
+			return types.NewIRODSErrorWithString(common.ErrorCode(msg.Result), msg.Message)
+		}
+		return types.NewIRODSError(common.ErrorCode(msg.Result))
+	}
+	return nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessageReplicaTruncateResponse) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+
+	if len(msg.Output) > 0 {
+		err = json.Unmarshal([]byte(msg.Output), msg)
+		if err != nil {
+			return errors.Wrapf(err, "failed to unmarshal json to irods message")
+		}
+	}
+	return nil
+}
+
+// FromMessage returns struct from IRODSMessage
+func (msg *IRODSMessageReplicaTruncateResponse) FromMessage(msgIn *IRODSMessage) error {
+	if msgIn.Body == nil {
+		return errors.Errorf("empty message body")
+	}
+
+	msg.Result = int(msgIn.Body.IntInfo)
+
+	if len(msgIn.Body.Message) > 0 {
+		err := msg.FromBytes(msgIn.Body.Message)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get irods message from message body")
+		}
+	}
+	return nil
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageReplicaTruncateResponse) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForResponse()
+}
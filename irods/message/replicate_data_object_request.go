@@ -5,12 +5,14 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
 )
 
 // IRODSMessageReplicateDataObjectRequest stores data object replication request
 type IRODSMessageReplicateDataObjectRequest IRODSMessageDataObjectRequest
 
 // NewIRODSMessageReplicateDataObjectRequest creates a IRODSMessageReplicateDataObjectRequest message
+// a resource hierarchy (root;child;leaf) is given with dest_resc_hier
 func NewIRODSMessageReplicateDataObjectRequest(path string, resource string) *IRODSMessageReplicateDataObjectRequest {
 	request := &IRODSMessageReplicateDataObjectRequest{
 		Path:          path,
@@ -26,7 +28,11 @@ func NewIRODSMessageReplicateDataObjectRequest(path string, resource string) *IR
 	}
 
 	if len(resource) > 0 {
-		request.KeyVals.Add(string(common.DEST_RESC_NAME_KW), resource)
+		if types.IsResourceHierarchy(resource) {
+			request.KeyVals.Add(string(common.DEST_RESC_HIER_STR_KW), resource)
+		} else {
+			request.KeyVals.Add(string(common.DEST_RESC_NAME_KW), resource)
+		}
 	}
 
 	return request
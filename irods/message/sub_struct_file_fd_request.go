@@ -0,0 +1,129 @@
+package message
+
+import (
+	"encoding/xml"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// IRODSMessageSubStructFileFDRequest stores sub struct file request for an opened file descriptor
+type IRODSMessageSubStructFileFDRequest struct {
+	XMLName        xml.Name         `xml:"SubStructFileFdOpr_PI"`
+	Host           IRODSMessageHost `xml:"RHostAddr_PI"`
+	Type           int              `xml:"type"`
+	FileDescriptor int              `xml:"fd"`
+	Length         int              `xml:"len"`
+}
+
+func newIRODSMessageSubStructFileFDRequest(resource *types.IRODSResource, desc int, len int) (*IRODSMessageSubStructFileFDRequest, error) {
+	host, err := NewIRODSMessageHost(resource)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create irods host message")
+	}
+
+	return &IRODSMessageSubStructFileFDRequest{
+		Host:           *host,
+		Type:           int(common.TAR_STRUCT_FILE_T),
+		FileDescriptor: desc,
+		Length:         len,
+	}, nil
+}
+
+// GetBytes returns byte array
+func (msg *IRODSMessageSubStructFileFDRequest) GetBytes() ([]byte, error) {
+	xmlBytes, err := xml.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal irods message to xml")
+	}
+	return xmlBytes, nil
+}
+
+// FromBytes returns struct from bytes
+func (msg *IRODSMessageSubStructFileFDRequest) FromBytes(bytes []byte) error {
+	err := xml.Unmarshal(bytes, msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal xml to irods message")
+	}
+	return nil
+}
+
+func (msg *IRODSMessageSubStructFileFDRequest) getMessage(apiNumber common.APINumber) (*IRODSMessage, error) {
+	bytes, err := msg.GetBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get bytes from irods message")
+	}
+
+	msgBody := IRODSMessageBody{
+		Type:    RODS_MESSAGE_API_REQ_TYPE,
+		Message: bytes,
+		Error:   nil,
+		Bs:      nil,
+		IntInfo: int32(apiNumber),
+	}
+
+	msgHeader, err := msgBody.BuildHeader()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build header from irods message")
+	}
+
+	return &IRODSMessage{
+		Header: msgHeader,
+		Body:   &msgBody,
+	}, nil
+}
+
+// IRODSMessageReadSubStructFileRequest stores sub struct file read request
+type IRODSMessageReadSubStructFileRequest struct {
+	IRODSMessageSubStructFileFDRequest
+}
+
+// NewIRODSMessageReadSubStructFileRequest creates a IRODSMessageReadSubStructFileRequest message
+func NewIRODSMessageReadSubStructFileRequest(resource *types.IRODSResource, desc int, len int) (*IRODSMessageReadSubStructFileRequest, error) {
+	request, err := newIRODSMessageSubStructFileFDRequest(resource, desc, len)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IRODSMessageReadSubStructFileRequest{
+		IRODSMessageSubStructFileFDRequest: *request,
+	}, nil
+}
+
+// GetMessage builds a message
+func (msg *IRODSMessageReadSubStructFileRequest) GetMessage() (*IRODSMessage, error) {
+	return msg.getMessage(common.SUB_STRUCT_FILE_READ_AN)
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageReadSubStructFileRequest) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForRequest()
+}
+
+// IRODSMessageCloseSubStructFileRequest stores sub struct file close request
+type IRODSMessageCloseSubStructFileRequest struct {
+	IRODSMessageSubStructFileFDRequest
+}
+
+// NewIRODSMessageCloseSubStructFileRequest creates a IRODSMessageCloseSubStructFileRequest message
+func NewIRODSMessageCloseSubStructFileRequest(resource *types.IRODSResource, desc int) (*IRODSMessageCloseSubStructFileRequest, error) {
+	request, err := newIRODSMessageSubStructFileFDRequest(resource, desc, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IRODSMessageCloseSubStructFileRequest{
+		IRODSMessageSubStructFileFDRequest: *request,
+	}, nil
+}
+
+// GetMessage builds a message
+func (msg *IRODSMessageCloseSubStructFileRequest) GetMessage() (*IRODSMessage, error) {
+	return msg.getMessage(common.SUB_STRUCT_FILE_CLOSE_AN)
+}
+
+// GetXMLCorrector returns XML corrector for this message
+func (msg *IRODSMessageCloseSubStructFileRequest) GetXMLCorrector() XMLCorrector {
+	return GetXMLCorrectorForRequest()
+}
@@ -47,6 +47,9 @@ type IRODSMetrics struct {
 	connectionFailures      uint64
 	connectionPoolFailures  uint64
 
+	// operations - latency histograms and bytes by operation type
+	operations map[OperationType]*OperationMetrics
+
 	mutex sync.Mutex
 }
 
@@ -906,4 +909,5 @@ func (metrics *IRODSMetrics) Sum(other *IRODSMetrics) {
 	metrics.requestResponseFailures += other.requestResponseFailures
 	metrics.connectionFailures += other.connectionFailures
 	metrics.connectionPoolFailures += other.connectionPoolFailures
+	metrics.sumOperations(other)
 }
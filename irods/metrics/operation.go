@@ -0,0 +1,290 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/cyverse/go-irodsclient/irods/common"
+)
+
+// OperationType is a type of iRODS API operation that metrics are collected for
+type OperationType string
+
+const (
+	// OperationOpen is for opening, creating data objects
+	OperationOpen OperationType = "open"
+	// OperationClose is for closing data objects
+	OperationClose OperationType = "close"
+	// OperationRead is for reading data objects
+	OperationRead OperationType = "read"
+	// OperationWrite is for writing data objects
+	OperationWrite OperationType = "write"
+	// OperationSeek is for seeking data objects
+	OperationSeek OperationType = "seek"
+	// OperationQuery is for queries, stat and listing
+	OperationQuery OperationType = "query"
+	// OperationAuth is for authentication
+	OperationAuth OperationType = "auth"
+	// OperationOther is for other operations
+	OperationOther OperationType = "other"
+)
+
+// LatencyHistogramBounds are upper bounds of latency histogram buckets, the last bucket has no upper bound
+var LatencyHistogramBounds = []time.Duration{
+	1 * time.Millisecond,
+	2 * time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// GetOperationType returns the operation type of the API
+func GetOperationType(apiNumber common.APINumber) OperationType {
+	switch apiNumber {
+	case common.DATA_OBJ_OPEN_AN, common.DATA_OBJ_CREATE_AN, common.DATA_OBJ_OPEN_AND_STAT_AN, common.DATA_OBJ_CREATE_AND_STAT_AN, common.GET_FILE_DESCRIPTOR_INFO_APN:
+		return OperationOpen
+	case common.DATA_OBJ_CLOSE_AN, common.REPLICA_CLOSE_APN:
+		return OperationClose
+	case common.DATA_OBJ_READ_AN, common.DATA_OBJ_GET_AN:
+		return OperationRead
+	case common.DATA_OBJ_WRITE_AN, common.DATA_OBJ_PUT_AN:
+		return OperationWrite
+	case common.DATA_OBJ_LSEEK_AN:
+		return OperationSeek
+	case common.GEN_QUERY_AN, common.SPECIFIC_QUERY_AN, common.OBJ_STAT_AN, common.QUERY_SPEC_COLL_AN:
+		return OperationQuery
+	case common.AUTH_REQUEST_AN, common.AUTH_RESPONSE_AN, common.PAM_AUTH_REQUEST_AN, common.AUTH_PLUG_REQ_AN, common.NEW_AUTH_PLUGIN_REQ_AN, common.SSL_START_AN:
+		return OperationAuth
+	default:
+		return OperationOther
+	}
+}
+
+// LatencyHistogram is a histogram of latencies
+type LatencyHistogram struct {
+	Bounds []time.Duration `json:"bounds"` // upper bounds of buckets
+	Counts []uint64        `json:"counts"` // counts of buckets, has one more bucket than bounds for latencies over the last bound
+	Sum    time.Duration   `json:"sum"`
+	Min    time.Duration   `json:"min"`
+	Max    time.Duration   `json:"max"`
+}
+
+// newLatencyHistogram creates a new empty LatencyHistogram
+func newLatencyHistogram() LatencyHistogram {
+	return LatencyHistogram{
+		Bounds: LatencyHistogramBounds,
+		Counts: make([]uint64, len(LatencyHistogramBounds)+1),
+	}
+}
+
+func (histogram *LatencyHistogram) observe(latency time.Duration) {
+	bucket := len(histogram.Bounds)
+	for i, bound := range histogram.Bounds {
+		if latency <= bound {
+			bucket = i
+			break
+		}
+	}
+
+	if histogram.GetCount() == 0 || latency < histogram.Min {
+		histogram.Min = latency
+	}
+
+	if latency > histogram.Max {
+		histogram.Max = latency
+	}
+
+	histogram.Counts[bucket]++
+	histogram.Sum += latency
+}
+
+func (histogram *LatencyHistogram) sum(other *LatencyHistogram) {
+	if other.GetCount() == 0 {
+		return
+	}
+
+	if histogram.GetCount() == 0 || other.Min < histogram.Min {
+		histogram.Min = other.Min
+	}
+
+	if other.Max > histogram.Max {
+		histogram.Max = other.Max
+	}
+
+	for i := range histogram.Counts {
+		histogram.Counts[i] += other.Counts[i]
+	}
+	histogram.Sum += other.Sum
+}
+
+func (histogram *LatencyHistogram) copy() LatencyHistogram {
+	newHistogram := *histogram
+	newHistogram.Counts = make([]uint64, len(histogram.Counts))
+	copy(newHistogram.Counts, histogram.Counts)
+	return newHistogram
+}
+
+// GetCount returns the number of latencies observed
+func (histogram *LatencyHistogram) GetCount() uint64 {
+	count := uint64(0)
+	for _, bucketCount := range histogram.Counts {
+		count += bucketCount
+	}
+	return count
+}
+
+// GetMean returns the mean latency
+func (histogram *LatencyHistogram) GetMean() time.Duration {
+	count := histogram.GetCount()
+	if count == 0 {
+		return 0
+	}
+
+	return histogram.Sum / time.Duration(count)
+}
+
+// GetPercentile returns the upper bound of the bucket the percentile (0-100) falls in, max latency for the last bucket
+func (histogram *LatencyHistogram) GetPercentile(percentile float64) time.Duration {
+	count := histogram.GetCount()
+	if count == 0 {
+		return 0
+	}
+
+	rank := uint64(float64(count) * percentile / 100)
+	if rank == 0 {
+		rank = 1
+	}
+
+	accumulated := uint64(0)
+	for i, bucketCount := range histogram.Counts {
+		accumulated += bucketCount
+		if accumulated >= rank {
+			if i < len(histogram.Bounds) && histogram.Bounds[i] < histogram.Max {
+				return histogram.Bounds[i]
+			}
+			return histogram.Max
+		}
+	}
+
+	return histogram.Max
+}
+
+// OperationMetrics contains metrics of an operation type
+type OperationMetrics struct {
+	Operation     OperationType    `json:"operation"`
+	Failures      uint64           `json:"failures"`
+	BytesSent     uint64           `json:"bytes_sent"`
+	BytesReceived uint64           `json:"bytes_received"`
+	Latency       LatencyHistogram `json:"latency"`
+}
+
+// GetCount returns the number of operations
+func (operationMetrics *OperationMetrics) GetCount() uint64 {
+	return operationMetrics.Latency.GetCount()
+}
+
+// OperationMetricsSnapshot is a snapshot of metrics of operations
+type OperationMetricsSnapshot struct {
+	Time       time.Time                           `json:"time"`
+	Operations map[OperationType]*OperationMetrics `json:"operations"`
+}
+
+// Get returns metrics of the operation type, returns empty metrics if the operation is never observed
+func (snapshot *OperationMetricsSnapshot) Get(operation OperationType) *OperationMetrics {
+	if operationMetrics, ok := snapshot.Operations[operation]; ok {
+		return operationMetrics
+	}
+
+	return &OperationMetrics{
+		Operation: operation,
+		Latency:   newLatencyHistogram(),
+	}
+}
+
+// ObserveOperation records latency and bytes transferred of an operation
+func (metrics *IRODSMetrics) ObserveOperation(operation OperationType, latency time.Duration, bytesSent uint64, bytesReceived uint64, failed bool) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	if metrics.operations == nil {
+		metrics.operations = map[OperationType]*OperationMetrics{}
+	}
+
+	operationMetrics, ok := metrics.operations[operation]
+	if !ok {
+		operationMetrics = &OperationMetrics{
+			Operation: operation,
+			Latency:   newLatencyHistogram(),
+		}
+		metrics.operations[operation] = operationMetrics
+	}
+
+	operationMetrics.Latency.observe(latency)
+	operationMetrics.BytesSent += bytesSent
+	operationMetrics.BytesReceived += bytesReceived
+	if failed {
+		operationMetrics.Failures++
+	}
+}
+
+// GetOperationMetrics returns a snapshot of metrics of operations
+func (metrics *IRODSMetrics) GetOperationMetrics() *OperationMetricsSnapshot {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	return metrics.getOperationMetrics()
+}
+
+// GetAndClearOperationMetrics returns a snapshot of metrics of operations then clear
+func (metrics *IRODSMetrics) GetAndClearOperationMetrics() *OperationMetricsSnapshot {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	snapshot := metrics.getOperationMetrics()
+	metrics.operations = nil
+	return snapshot
+}
+
+func (metrics *IRODSMetrics) getOperationMetrics() *OperationMetricsSnapshot {
+	snapshot := &OperationMetricsSnapshot{
+		Time:       time.Now(),
+		Operations: map[OperationType]*OperationMetrics{},
+	}
+
+	for operation, operationMetrics := range metrics.operations {
+		newOperationMetrics := *operationMetrics
+		newOperationMetrics.Latency = operationMetrics.Latency.copy()
+		snapshot.Operations[operation] = &newOperationMetrics
+	}
+
+	return snapshot
+}
+
+func (metrics *IRODSMetrics) sumOperations(other *IRODSMetrics) {
+	for operation, otherOperationMetrics := range other.operations {
+		if metrics.operations == nil {
+			metrics.operations = map[OperationType]*OperationMetrics{}
+		}
+
+		operationMetrics, ok := metrics.operations[operation]
+		if !ok {
+			operationMetrics = &OperationMetrics{
+				Operation: operation,
+				Latency:   newLatencyHistogram(),
+			}
+			metrics.operations[operation] = operationMetrics
+		}
+
+		operationMetrics.Failures += otherOperationMetrics.Failures
+		operationMetrics.BytesSent += otherOperationMetrics.BytesSent
+		operationMetrics.BytesReceived += otherOperationMetrics.BytesReceived
+		operationMetrics.Latency.sum(&otherOperationMetrics.Latency)
+	}
+}
@@ -1,6 +1,8 @@
 package session
 
 import (
+	"io"
+	"net"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -31,8 +33,34 @@ const (
 
 	// IRODSSessionConnectionMaxIdleNumberDefault is a default value of max idle connections
 	IRODSSessionConnectionMaxIdleNumberDefault int = 5
+	// IRODSSessionConnectionValidationIdleThresholdDefault is a default idle time after which idle connections are validated on checkout
+	IRODSSessionConnectionValidationIdleThresholdDefault time.Duration = 30 * time.Second
+	// IRODSSessionConnectionMaintenanceIntervalDefault is a default interval of connection pool maintenance
+	IRODSSessionConnectionMaintenanceIntervalDefault time.Duration = 1 * time.Minute
 )
 
+// ConnectionValidationMode determines how idle connections are validated on checkout
+type ConnectionValidationMode string
+
+const (
+	// ConnectionValidationNone does not validate idle connections
+	ConnectionValidationNone ConnectionValidationMode = ""
+	// ConnectionValidationAge discards idle connections that have not been used for the validation idle threshold
+	ConnectionValidationAge ConnectionValidationMode = "age"
+	// ConnectionValidationPing pings idle connections that have not been used for the validation idle threshold, and discards dead ones
+	ConnectionValidationPing ConnectionValidationMode = "ping"
+)
+
+// GetConnectionValidationMode returns ConnectionValidationMode from string
+func GetConnectionValidationMode(mode string) (ConnectionValidationMode, error) {
+	switch ConnectionValidationMode(mode) {
+	case ConnectionValidationNone, ConnectionValidationAge, ConnectionValidationPing:
+		return ConnectionValidationMode(mode), nil
+	default:
+		return ConnectionValidationNone, errors.Errorf("unknown connection validation mode %q", mode)
+	}
+}
+
 // ConnectionPoolConfig is for connection pool configuration
 type ConnectionPoolConfig struct {
 	ApplicationName      string
@@ -42,9 +70,39 @@ type ConnectionPoolConfig struct {
 	Lifespan             time.Duration // if a connection exceeds its lifespan, the connection will die
 	IdleTimeout          time.Duration // if there's no activity on a connection for the timeout time, the connection will die
 	ConnectTimeout       time.Duration // if there's no response for the timeout time, the connection will fail
-	OperationTimeout     time.Duration // timeout for iRODS operations
-	LongOperationTimeout time.Duration // timeout for long iRODS operations
+	AuthTimeout          time.Duration // timeout for authentication, OperationTimeout is used if not set
+	OperationTimeout     time.Duration // timeout for iRODS metadata operations
+	LongOperationTimeout time.Duration // timeout for long iRODS metadata operations
+	DataTransferTimeout  time.Duration // timeout for each data transfer request, OperationTimeout is used if not set
 	TcpBufferSize        int
+	TcpSendBufferSize    int           // SO_SNDBUF, overrides TcpBufferSize if set
+	TcpReceiveBufferSize int           // SO_RCVBUF, overrides TcpBufferSize if set
+	TcpKeepAlivePeriod   time.Duration // TCP keepalive interval, 0 uses default, negative disables TCP keepalive
+	TcpDelay             bool          // if true, Nagle's algorithm is enabled (TCP_NODELAY is not set)
+	KeepAliveInterval    time.Duration // interval to ping idle connections, 0 disables keepalive
+
+	AddressFamily      connection.AddressFamily // preference of IP address families of servers, empty uses addresses in the order resolved
+	HappyEyeballsDelay time.Duration            // delay before connecting to the next address of a host in parallel, 0 uses default, negative tries addresses one at a time
+	Resolver           *net.Resolver            // can be nil, resolves host names and SRV records with net.DefaultResolver if not set
+
+	ControlKeepAliveInterval time.Duration // interval to ping connections controlling parallel transfers, 0 uses default, negative disables
+	LazyInit                 bool          // if true, initial connections are created on first use rather than on creation
+
+	ValidationMode          ConnectionValidationMode // how to validate idle connections on checkout
+	ValidationIdleThreshold time.Duration            // idle connections not used for this period are validated on checkout
+
+	MaintenanceInterval time.Duration // interval to close expired idle connections
+	DeferIdleShrink     bool          // if true, idle connections over MaxIdle are kept until they expire IdleTimeout
+
+	RetryPolicy *connection.RetryPolicy // can be null, uses default retry policy if not set
+
+	WireDebugWriter      io.Writer // can be null, dumps sent/received messages if set
+	WireDebugBinaryLimit int       // max binary bytes dumped per message
+
+	FaultInjectionPolicy *connection.FaultInjectionPolicy // can be null, injects failures to sockets for testing if set
+
+	PAMInteractivePromptHandler connection.PAMInteractivePromptHandler // can be null
+	OpenIDTokenSource           connection.OpenIDTokenSource           // can be null
 
 	Metrics *metrics.IRODSMetrics // can be null
 }
@@ -53,19 +111,49 @@ type ConnectionPoolConfig struct {
 type IRODSSessionConfig struct {
 	ApplicationName string
 
-	ConnectionCreationTimeout time.Duration
-	ConnectionInitNumber      int
-	ConnectionMaxNumber       int
-	ConnectionLifespan        time.Duration
-	ConnectionIdleTimeout     time.Duration
-	ConnectionMaxIdleNumber   int
-	OperationTimeout          time.Duration // timeout for iRODS operations
-	LongOperationTimeout      time.Duration // timeout for long iRODS operations
-	TcpBufferSize             int
-	StartNewTransaction       bool
-
-	WaitConnection  bool            // if true, wait for a connection to be available when the pool is exhausted
-	AddressResolver AddressResolver // can be nil
+	ConnectionCreationTimeout   time.Duration
+	ConnectionInitNumber        int
+	ConnectionMaxNumber         int
+	ConnectionLifespan          time.Duration
+	ConnectionIdleTimeout       time.Duration
+	ConnectionMaxIdleNumber     int
+	AuthTimeout                 time.Duration // timeout for authentication, OperationTimeout is used if not set
+	OperationTimeout            time.Duration // timeout for iRODS metadata operations
+	LongOperationTimeout        time.Duration // timeout for long iRODS metadata operations
+	DataTransferTimeout         time.Duration // timeout for each data transfer request, OperationTimeout is used if not set
+	TcpBufferSize               int
+	TcpSendBufferSize           int           // SO_SNDBUF, overrides TcpBufferSize if set
+	TcpReceiveBufferSize        int           // SO_RCVBUF, overrides TcpBufferSize if set
+	TcpKeepAlivePeriod          time.Duration // TCP keepalive interval, 0 uses default, negative disables TCP keepalive
+	TcpDelay                    bool          // if true, Nagle's algorithm is enabled (TCP_NODELAY is not set)
+	StartNewTransaction         bool          // if true, refresh the view of dirty connections implicitly on return, see WithFreshView for explicit use
+	ConnectionKeepAliveInterval time.Duration // interval to ping idle connections, 0 disables keepalive
+	ControlKeepAliveInterval    time.Duration // interval to ping connections controlling parallel transfers, 0 uses default, negative disables
+
+	AddressFamily      connection.AddressFamily // preference of IP address families of servers, empty uses addresses in the order resolved
+	HappyEyeballsDelay time.Duration            // delay before connecting to the next address of a host in parallel, 0 uses default, negative tries addresses one at a time
+	Resolver           *net.Resolver            // can be nil, resolves host names and SRV records with net.DefaultResolver if not set
+
+	ConnectionValidationMode          ConnectionValidationMode // how to validate idle connections on checkout
+	ConnectionValidationIdleThreshold time.Duration            // idle connections not used for this period are validated on checkout
+
+	ConnectionMaintenanceInterval time.Duration // interval to close expired idle connections
+	ConnectionDeferIdleShrink     bool          // if true, idle connections over max idle number are kept until they expire idle timeout
+	LazyConnectionInit            bool          // if true, connections are made on first use, the server doesn't need to be reachable on creation
+
+	WaitConnection                 bool                    // if true, wait for a connection to be available when the pool is exhausted
+	WaitConnectionInsteadOfSharing bool                    // if true, always wait for a free connection instead of sharing an in-use connection
+	ConnectionWaitTimeout          time.Duration           // max time to wait for a connection to be available, 0 waits forever
+	AddressResolver                AddressResolver         // can be nil
+	RetryPolicy                    *connection.RetryPolicy // can be nil, uses default retry policy if not set
+
+	WireDebugWriter      io.Writer // can be nil, dumps sent/received messages with credentials redacted if set
+	WireDebugBinaryLimit int       // max binary bytes dumped per message, 0 uses default, negative disables binary dump
+
+	FaultInjectionPolicy *connection.FaultInjectionPolicy // can be nil, injects failures to sockets for testing if set
+
+	PAMInteractivePromptHandler connection.PAMInteractivePromptHandler // can be nil
+	OpenIDTokenSource           connection.OpenIDTokenSource           // can be nil
 }
 
 func (poolConfig *ConnectionPoolConfig) fillDefaults() {
@@ -108,6 +196,14 @@ func (poolConfig *ConnectionPoolConfig) fillDefaults() {
 	if poolConfig.TcpBufferSize < 0 {
 		poolConfig.TcpBufferSize = IRODSSessionTcpBufferSizeDefault
 	}
+
+	if poolConfig.ValidationIdleThreshold <= 0 {
+		poolConfig.ValidationIdleThreshold = IRODSSessionConnectionValidationIdleThresholdDefault
+	}
+
+	if poolConfig.MaintenanceInterval <= 0 {
+		poolConfig.MaintenanceInterval = IRODSSessionConnectionMaintenanceIntervalDefault
+	}
 }
 
 func (poolConfig *ConnectionPoolConfig) Validate() error {
@@ -156,11 +252,41 @@ func (poolConfig *ConnectionPoolConfig) Validate() error {
 		return errors.Wrapf(newErr, "long operation timeout is invalid")
 	}
 
+	if poolConfig.AuthTimeout < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "auth timeout is invalid")
+	}
+
+	if poolConfig.DataTransferTimeout < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "data transfer timeout is invalid")
+	}
+
 	if poolConfig.TcpBufferSize < 0 {
 		newErr := types.NewConnectionConfigError(nil)
 		return errors.Wrapf(newErr, "tcp buffer size is invalid")
 	}
 
+	if _, err := GetConnectionValidationMode(string(poolConfig.ValidationMode)); err != nil {
+		newErr := errors.Join(err, types.NewConnectionConfigError(nil))
+		return errors.Wrapf(newErr, "validation mode is invalid")
+	}
+
+	if poolConfig.ValidationIdleThreshold < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "validation idle threshold is invalid")
+	}
+
+	if poolConfig.MaintenanceInterval <= 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "maintenance interval is invalid")
+	}
+
+	if poolConfig.KeepAliveInterval < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "keepalive interval is invalid")
+	}
+
 	return nil
 }
 
@@ -168,10 +294,31 @@ func (poolConfig *ConnectionPoolConfig) ToConnectionConfig() *connection.IRODSCo
 	return &connection.IRODSConnectionConfig{
 		ApplicationName:      poolConfig.ApplicationName,
 		ConnectTimeout:       poolConfig.ConnectTimeout,
+		AuthTimeout:          poolConfig.AuthTimeout,
 		OperationTimeout:     poolConfig.OperationTimeout,
 		LongOperationTimeout: poolConfig.LongOperationTimeout,
+		DataTransferTimeout:  poolConfig.DataTransferTimeout,
 		TcpBufferSize:        poolConfig.TcpBufferSize,
+		TcpSendBufferSize:    poolConfig.TcpSendBufferSize,
+		TcpReceiveBufferSize: poolConfig.TcpReceiveBufferSize,
+		TcpKeepAlivePeriod:   poolConfig.TcpKeepAlivePeriod,
+		TcpDelay:             poolConfig.TcpDelay,
 		Metrics:              poolConfig.Metrics,
+		RetryPolicy:          poolConfig.RetryPolicy,
+
+		ControlKeepAliveInterval: poolConfig.ControlKeepAliveInterval,
+
+		AddressFamily:      poolConfig.AddressFamily,
+		HappyEyeballsDelay: poolConfig.HappyEyeballsDelay,
+		Resolver:           poolConfig.Resolver,
+
+		WireDebugWriter:      poolConfig.WireDebugWriter,
+		WireDebugBinaryLimit: poolConfig.WireDebugBinaryLimit,
+
+		FaultInjectionPolicy: poolConfig.FaultInjectionPolicy,
+
+		PAMInteractivePromptHandler: poolConfig.PAMInteractivePromptHandler,
+		OpenIDTokenSource:           poolConfig.OpenIDTokenSource,
 	}
 }
 
@@ -215,6 +362,11 @@ func (sessionConfig *IRODSSessionConfig) fillDefaults() {
 	if sessionConfig.TcpBufferSize < 0 {
 		sessionConfig.TcpBufferSize = IRODSSessionTcpBufferSizeDefault
 	}
+
+	if sessionConfig.RetryPolicy == nil {
+		// shared by the session and its connections
+		sessionConfig.RetryPolicy = connection.NewDefaultRetryPolicy()
+	}
 }
 
 func (sessionConfig *IRODSSessionConfig) Validate() error {
@@ -263,11 +415,53 @@ func (sessionConfig *IRODSSessionConfig) Validate() error {
 		return errors.Wrapf(newErr, "long operation timeout is invalid")
 	}
 
+	if sessionConfig.AuthTimeout < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "auth timeout is invalid")
+	}
+
+	if sessionConfig.DataTransferTimeout < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "data transfer timeout is invalid")
+	}
+
 	if sessionConfig.TcpBufferSize < 0 {
 		newErr := types.NewConnectionConfigError(nil)
 		return errors.Wrapf(newErr, "tcp buffer size is invalid")
 	}
 
+	if sessionConfig.ConnectionWaitTimeout < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "connection wait timeout is invalid")
+	}
+
+	if _, err := GetConnectionValidationMode(string(sessionConfig.ConnectionValidationMode)); err != nil {
+		newErr := errors.Join(err, types.NewConnectionConfigError(nil))
+		return errors.Wrapf(newErr, "connection validation mode is invalid")
+	}
+
+	if sessionConfig.ConnectionValidationIdleThreshold < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "connection validation idle threshold is invalid")
+	}
+
+	if sessionConfig.ConnectionMaintenanceInterval < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "connection maintenance interval is invalid")
+	}
+
+	if sessionConfig.ConnectionKeepAliveInterval < 0 {
+		newErr := types.NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "connection keepalive interval is invalid")
+	}
+
+	if sessionConfig.RetryPolicy != nil {
+		err := sessionConfig.RetryPolicy.Validate()
+		if err != nil {
+			return errors.Wrapf(err, "retry policy is invalid")
+		}
+	}
+
 	return nil
 }
 
@@ -280,8 +474,38 @@ func (sessionConfig *IRODSSessionConfig) ToConnectionPoolConfig() *ConnectionPoo
 		Lifespan:             sessionConfig.ConnectionLifespan,
 		IdleTimeout:          sessionConfig.ConnectionIdleTimeout,
 		ConnectTimeout:       sessionConfig.ConnectionCreationTimeout,
+		AuthTimeout:          sessionConfig.AuthTimeout,
 		OperationTimeout:     sessionConfig.OperationTimeout,
 		LongOperationTimeout: sessionConfig.LongOperationTimeout,
+		DataTransferTimeout:  sessionConfig.DataTransferTimeout,
 		TcpBufferSize:        sessionConfig.TcpBufferSize,
+		TcpSendBufferSize:    sessionConfig.TcpSendBufferSize,
+		TcpReceiveBufferSize: sessionConfig.TcpReceiveBufferSize,
+		TcpKeepAlivePeriod:   sessionConfig.TcpKeepAlivePeriod,
+		TcpDelay:             sessionConfig.TcpDelay,
+		KeepAliveInterval:    sessionConfig.ConnectionKeepAliveInterval,
+
+		ControlKeepAliveInterval: sessionConfig.ControlKeepAliveInterval,
+
+		AddressFamily:      sessionConfig.AddressFamily,
+		HappyEyeballsDelay: sessionConfig.HappyEyeballsDelay,
+		Resolver:           sessionConfig.Resolver,
+
+		ValidationMode:          sessionConfig.ConnectionValidationMode,
+		ValidationIdleThreshold: sessionConfig.ConnectionValidationIdleThreshold,
+
+		MaintenanceInterval: sessionConfig.ConnectionMaintenanceInterval,
+		DeferIdleShrink:     sessionConfig.ConnectionDeferIdleShrink,
+		LazyInit:            sessionConfig.LazyConnectionInit,
+
+		RetryPolicy: sessionConfig.RetryPolicy,
+
+		WireDebugWriter:      sessionConfig.WireDebugWriter,
+		WireDebugBinaryLimit: sessionConfig.WireDebugBinaryLimit,
+
+		FaultInjectionPolicy: sessionConfig.FaultInjectionPolicy,
+
+		PAMInteractivePromptHandler: sessionConfig.PAMInteractivePromptHandler,
+		OpenIDTokenSource:           sessionConfig.OpenIDTokenSource,
 	}
 }
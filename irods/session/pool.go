@@ -2,6 +2,7 @@ package session
 
 import (
 	"container/list"
+	"context"
 	"sync"
 	"time"
 
@@ -22,10 +23,17 @@ type ConnectionPool struct {
 	config              *ConnectionPoolConfig
 	idleConnections     *list.List // list of *connection.IRODSConnection
 	occupiedConnections map[*connection.IRODSConnection]bool
-	maxConnectionsReal  int                                // max connections can be created in reality
-	callbacks           map[string]ConnectionUsageCallback // callbacks for connection usage changes
+	maxConnectionsReal  int                                      // max connections can be created in reality
+	limitTime           time.Time                                // time max connections is lowered
+	limitResetTime      time.Time                                // time lowered max connections is reset
+	limitBackoff        time.Duration                            // lowered max connections is kept for the backoff
+	callbacks           map[string]ConnectionUsageCallback       // callbacks for connection usage changes
+	scalingCallbacks    map[string]ConnectionPoolScalingCallback // callbacks for scaling decisions
 	mutex               sync.Mutex
 	waitCond            *sync.Cond // condition variable for waiting
+	waitQueue           *list.List // FIFO queue of waiters, front is served first
+	initialized         bool       // true if initial connections are created
+	prewarming          int        // number of connections being created by Prewarm
 	terminateChan       chan bool
 	terminated          bool
 }
@@ -88,66 +96,29 @@ func NewConnectionPool(account *types.IRODSAccount, config *ConnectionPoolConfig
 		occupiedConnections: map[*connection.IRODSConnection]bool{},
 		maxConnectionsReal:  0,
 		callbacks:           map[string]ConnectionUsageCallback{},
+		scalingCallbacks:    map[string]ConnectionPoolScalingCallback{},
 		mutex:               sync.Mutex{},
+		waitQueue:           list.New(),
+		initialized:         false,
 		terminateChan:       make(chan bool),
 		terminated:          false,
 	}
 
 	pool.waitCond = sync.NewCond(&pool.mutex)
 
-	err = pool.init()
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to init connection pool")
+	// in lazy init mode, initial connections are created on first use
+	if !pool.config.LazyInit {
+		err = pool.init()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to init connection pool")
+		}
 	}
 
-	go func() {
-		ticker := time.NewTicker(1 * time.Minute)
-
-		for {
-			select {
-			case <-pool.terminateChan:
-				ticker.Stop()
-				return
-			case <-ticker.C:
-				pool.mutex.Lock()
-
-				now := time.Now()
-				for {
-					elem := pool.idleConnections.Front()
-					if elem == nil {
-						break
-					}
-
-					// if the front conn expired idle timeout, continue next
-					idleConnObj := elem.Value
-					if idleConn, ok := idleConnObj.(*connection.IRODSConnection); ok {
-						if idleConn.GetLastSuccessfulAccess().Add(pool.config.IdleTimeout).Before(now) {
-							// timeout
-							pool.idleConnections.Remove(elem)
-							idleConn.Disconnect() //nolint
-
-							pool.callCallbacks()
-						} else if idleConn.GetCreationTime().Add(pool.config.Lifespan).Before(now) {
-							// too old
-							pool.idleConnections.Remove(elem)
-							idleConn.Disconnect() //nolint
-
-							pool.callCallbacks()
-						} else {
-							break
-						}
-					} else {
-						// unknown object, remove it
-						pool.idleConnections.Remove(elem)
-
-						pool.callCallbacks()
-					}
-				}
+	if pool.config.KeepAliveInterval > 0 {
+		go pool.keepAliveLoop()
+	}
 
-				pool.mutex.Unlock()
-			}
-		}
-	}()
+	go pool.maintenanceLoop()
 
 	return pool, nil
 }
@@ -162,7 +133,7 @@ func (pool *ConnectionPool) Release() {
 	}
 
 	pool.terminated = true
-	pool.terminateChan <- true
+	close(pool.terminateChan)
 
 	for pool.idleConnections.Len() > 0 {
 		elem := pool.idleConnections.Front()
@@ -190,12 +161,135 @@ func (pool *ConnectionPool) Release() {
 	pool.waitCond.Broadcast()
 
 	pool.callbacks = map[string]ConnectionUsageCallback{}
+	pool.scalingCallbacks = map[string]ConnectionPoolScalingCallback{}
 
 	if pool.config.Metrics != nil {
 		pool.config.Metrics.ClearConnections()
 	}
 }
 
+// keepAliveLoop pings idle connections periodically so firewalls/NAT do not silently drop them
+func (pool *ConnectionPool) keepAliveLoop() {
+	ticker := time.NewTicker(pool.config.KeepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-pool.terminateChan:
+			return
+		case <-ticker.C:
+			pool.keepAlive()
+		}
+	}
+}
+
+// keepAlive pings idle connections that have not been used for keepalive interval
+// dead connections are discarded, so new connections are created on checkout
+func (pool *ConnectionPool) keepAlive() {
+	logger := log.WithFields(log.Fields{})
+
+	pool.mutex.Lock()
+
+	if pool.terminated {
+		pool.mutex.Unlock()
+		return
+	}
+
+	// take out connections to ping, so others do not check them out while pinging
+	now := time.Now()
+	pingConns := []*connection.IRODSConnection{}
+	elem := pool.idleConnections.Front()
+	for elem != nil {
+		nextElem := elem.Next()
+		if idleConn, ok := elem.Value.(*connection.IRODSConnection); ok {
+			if idleConn.GetLastSuccessfulAccess().Add(pool.config.KeepAliveInterval).Before(now) {
+				pool.idleConnections.Remove(elem)
+				pingConns = append(pingConns, idleConn)
+			}
+		}
+		elem = nextElem
+	}
+
+	if len(pingConns) > 0 {
+		pool.callCallbacks()
+	}
+
+	pool.mutex.Unlock()
+
+	if len(pingConns) == 0 {
+		return
+	}
+
+	aliveConns := []*connection.IRODSConnection{}
+	for _, pingConn := range pingConns {
+		pingConn.Lock()
+		err := pingConn.Ping()
+		pingConn.Unlock()
+
+		if err != nil {
+			logger.WithError(err).Debug("failed to ping an idle connection, discarding...")
+			_ = pingConn.Disconnect()
+			continue
+		}
+
+		aliveConns = append(aliveConns, pingConn)
+	}
+
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	for _, aliveConn := range aliveConns {
+		if pool.terminated || pool.idleConnections.Len() >= pool.config.MaxIdle {
+			_ = aliveConn.Disconnect()
+			continue
+		}
+
+		pool.idleConnections.PushBack(aliveConn)
+	}
+
+	pool.callCallbacks()
+	pool.waitCond.Broadcast()
+}
+
+// checkIdleConnection validates an idle connection on checkout following the validation mode
+// it does not make a network round trip, ping is true if the connection must be pinged to be validated
+func (pool *ConnectionPool) checkIdleConnection(conn *connection.IRODSConnection) (bool, bool) {
+	if pool.config.ValidationMode == ConnectionValidationNone {
+		return true, false
+	}
+
+	if conn.GetLastSuccessfulAccess().Add(pool.config.ValidationIdleThreshold).After(time.Now()) {
+		// recently used
+		return true, false
+	}
+
+	switch pool.config.ValidationMode {
+	case ConnectionValidationAge:
+		return false, false
+	case ConnectionValidationPing:
+		return true, true
+	default:
+		return true, false
+	}
+}
+
+// pingIdleConnection pings an idle connection taken out of the pool, must be called without holding pool.mutex
+func (pool *ConnectionPool) pingIdleConnection(conn *connection.IRODSConnection) bool {
+	logger := log.WithFields(log.Fields{
+		"validation_mode": pool.config.ValidationMode,
+	})
+
+	conn.Lock()
+	err := conn.Ping()
+	conn.Unlock()
+
+	if err != nil {
+		logger.WithError(err).Debug("failed to ping an idle connection")
+		return false
+	}
+	return true
+}
+
 func (pool *ConnectionPool) callCallbacks() {
 	for _, callback := range pool.callbacks {
 		callback(len(pool.occupiedConnections), pool.idleConnections.Len(), pool.getMaxConnectionsReal())
@@ -222,17 +316,27 @@ func (pool *ConnectionPool) RemoveUsageCallback(id string) {
 }
 
 func (pool *ConnectionPool) init() error {
-	logger := log.WithFields(log.Fields{})
-
 	pool.mutex.Lock()
 	defer pool.mutex.Unlock()
 
+	return pool.initConnections()
+}
+
+// initConnections creates initial connections, the caller must hold the mutex
+func (pool *ConnectionPool) initConnections() error {
+	logger := log.WithFields(log.Fields{})
+
+	if pool.initialized {
+		return nil
+	}
+
 	pool.callCallbacks()
 
 	// create connections
 	connConfig := pool.config.ToConnectionConfig()
 
-	for i := 0; i < pool.config.InitialCap; i++ {
+	// connections created by previous failed attempt are reused
+	for i := pool.idleConnections.Len(); i < pool.config.InitialCap; i++ {
 		newConn, err := connection.NewIRODSConnection(pool.account, connConfig)
 		if err != nil {
 			if pool.config.Metrics != nil {
@@ -249,7 +353,7 @@ func (pool *ConnectionPool) init() error {
 
 			if types.IsConnectionError(err) {
 				// rejected?
-				pool.maxConnectionsReal = i
+				pool.limitMaxConnections(i)
 				logger.Debugf("adjusted max connections: %d", pool.maxConnectionsReal)
 			}
 
@@ -261,14 +365,32 @@ func (pool *ConnectionPool) init() error {
 		pool.callCallbacks()
 	}
 
+	pool.initialized = true
 	return nil
 }
 
+// IsInitialized returns true if initial connections are created
+func (pool *ConnectionPool) IsInitialized() bool {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	return pool.initialized
+}
+
+// get must be called with pool.mutex held, the lock is released while pinging an idle connection
 func (pool *ConnectionPool) get(new bool, noConnect bool) (*connection.IRODSConnection, bool, error) {
 	logger := log.WithFields(log.Fields{
 		"new": new,
 	})
 
+	if !pool.initialized && !noConnect {
+		// lazy init
+		err := pool.initConnections()
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "failed to init connection pool")
+		}
+	}
+
 	maxConn := pool.getMaxConnectionsReal()
 
 	if len(pool.occupiedConnections) >= maxConn {
@@ -302,9 +424,43 @@ func (pool *ConnectionPool) get(new bool, noConnect bool) (*connection.IRODSConn
 			if elem != nil {
 				idleConnObj := pool.idleConnections.Remove(elem)
 				if idleConn, ok := idleConnObj.(*connection.IRODSConnection); ok {
+					valid, ping := true, false
+					if idleConn.IsConnected() {
+						valid, ping = pool.checkIdleConnection(idleConn)
+					}
+
+					if !valid {
+						logger.Debug("discarding a stale idle connection, replacing it with a new one")
+						_ = idleConn.Disconnect()
+					}
+
 					if idleConn.IsConnected() {
 						// move to occupied connections
 						pool.occupiedConnections[idleConn] = true
+
+						if ping {
+							// the connection is reserved as occupied, ping without the lock not to block others for a network round trip
+							pool.mutex.Unlock()
+							alive := pool.pingIdleConnection(idleConn)
+							pool.mutex.Lock()
+
+							if pool.terminated {
+								_ = idleConn.Disconnect()
+								return nil, false, errors.Errorf("connection pool is released")
+							}
+
+							if !alive {
+								logger.Debug("discarding a dead idle connection, replacing it with a new one")
+								delete(pool.occupiedConnections, idleConn)
+								_ = idleConn.Disconnect()
+
+								pool.callCallbacks()
+
+								// try other idle connections or create a new one, others may have changed the pool while pinging
+								return pool.get(new, noConnect)
+							}
+						}
+
 						logger.Debug("Reuse an idle connection")
 
 						pool.callCallbacks()
@@ -345,10 +501,11 @@ func (pool *ConnectionPool) get(new bool, noConnect bool) (*connection.IRODSConn
 
 			if types.IsConnectionError(err) {
 				// rejected?
-				pool.maxConnectionsReal = len(pool.occupiedConnections) + pool.idleConnections.Len()
+				pool.limitMaxConnections(len(pool.occupiedConnections) + pool.idleConnections.Len())
 
 				pool.callCallbacks()
 				if pool.maxConnectionsReal > 0 {
+					pool.callScalingCallbacks(ConnectionPoolScalingLimit, "server rejected a new connection")
 					logger.Debugf("adjusted max connections: %d", pool.maxConnectionsReal)
 					return nil, false, types.NewConnectionPoolFullError(len(pool.occupiedConnections), maxConn)
 				}
@@ -362,6 +519,7 @@ func (pool *ConnectionPool) get(new bool, noConnect bool) (*connection.IRODSConn
 	logger.Debug("Created a new connection")
 
 	pool.callCallbacks()
+	pool.callScalingCallbacks(ConnectionPoolScalingGrow, "no idle connection available")
 
 	if pool.config.Metrics != nil {
 		pool.config.Metrics.IncreaseConnectionsOccupied(1)
@@ -373,18 +531,177 @@ func (pool *ConnectionPool) get(new bool, noConnect bool) (*connection.IRODSConn
 // Get gets a new or an idle connection out of the pool
 // the boolean return value indicates if the returned connection is new (True) or existing idle (False)
 func (pool *ConnectionPool) Get(new bool, noConnect bool, wait bool) (*connection.IRODSConnection, bool, error) {
+	if wait {
+		return pool.GetWithContext(context.Background(), new, noConnect)
+	}
+
 	pool.mutex.Lock()
 	defer pool.mutex.Unlock()
 
-	for {
+	return pool.get(new, noConnect)
+}
+
+// GetWithContext gets a new or an idle connection out of the pool, waits for a connection to be available if the pool is full.
+// Waiters are served in FIFO order. Waiting is canceled when the context is done.
+// the boolean return value indicates if the returned connection is new (True) or existing idle (False)
+func (pool *ConnectionPool) GetWithContext(ctx context.Context, new bool, noConnect bool) (*connection.IRODSConnection, bool, error) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	// fast path, no one is waiting
+	if pool.waitQueue.Len() == 0 {
 		conn, newConn, err := pool.get(new, noConnect)
-		if err != nil && types.IsConnectionPoolFullError(err) && wait {
-			// if the pool is full and wait is true, wait for a while
-			pool.waitCond.Wait()
-		} else {
+		if err == nil || !types.IsConnectionPoolFullError(err) {
 			return conn, newConn, err
 		}
 	}
+
+	// wake up waiters when the context is done
+	stopWakeup := context.AfterFunc(ctx, func() {
+		pool.mutex.Lock()
+		defer pool.mutex.Unlock()
+
+		pool.waitCond.Broadcast()
+	})
+	defer stopWakeup()
+
+	waiter := pool.waitQueue.PushBack(struct{}{})
+	defer func() {
+		pool.waitQueue.Remove(waiter)
+		// let the next waiter try
+		pool.waitCond.Broadcast()
+	}()
+
+	for {
+		if pool.terminated {
+			return nil, false, errors.Errorf("connection pool is released")
+		}
+
+		if ctx.Err() != nil {
+			return nil, false, errors.Wrapf(ctx.Err(), "failed to wait for a connection to be available")
+		}
+
+		if pool.waitQueue.Front() == waiter {
+			conn, newConn, err := pool.get(new, noConnect)
+			if err == nil || !types.IsConnectionPoolFullError(err) {
+				return conn, newConn, err
+			}
+		}
+
+		pool.waitCond.Wait()
+	}
+}
+
+// Prewarm creates idle connections in parallel until at least n idle connections are available
+// it returns the number of connections newly created
+func (pool *ConnectionPool) Prewarm(n int) (int, error) {
+	logger := log.WithFields(log.Fields{
+		"n": n,
+	})
+
+	pool.mutex.Lock()
+
+	if pool.terminated {
+		pool.mutex.Unlock()
+		return 0, errors.Errorf("connection pool is released")
+	}
+
+	// do not exceed max connections
+	available := pool.getMaxConnectionsReal() - len(pool.occupiedConnections) - pool.idleConnections.Len() - pool.prewarming
+	required := n - pool.idleConnections.Len() - pool.prewarming
+	if required > available {
+		required = available
+	}
+
+	if required <= 0 {
+		pool.mutex.Unlock()
+		return 0, nil
+	}
+
+	pool.prewarming += required
+	pool.mutex.Unlock()
+
+	logger.Debugf("prewarming %d connections", required)
+
+	// connect and authenticate in parallel
+	connConfig := pool.config.ToConnectionConfig()
+	newConns := make([]*connection.IRODSConnection, required)
+	connErrs := make([]error, required)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < required; i++ {
+		wg.Add(1)
+
+		go func(idx int) {
+			defer wg.Done()
+
+			newConn, err := connection.NewIRODSConnection(pool.account, connConfig)
+			if err != nil {
+				connErrs[idx] = err
+				return
+			}
+
+			err = newConn.Connect()
+			if err != nil {
+				connErrs[idx] = err
+				return
+			}
+
+			newConns[idx] = newConn
+		}(i)
+	}
+
+	wg.Wait()
+
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	pool.prewarming -= required
+
+	created := 0
+	var connErr error
+	for i := 0; i < required; i++ {
+		if connErrs[i] != nil {
+			if pool.config.Metrics != nil {
+				pool.config.Metrics.IncreaseCounterForConnectionPoolFailures(1)
+			}
+
+			if connErr == nil {
+				connErr = connErrs[i]
+			}
+			continue
+		}
+
+		newConn := newConns[i]
+		if pool.terminated {
+			_ = newConn.Disconnect()
+			continue
+		}
+
+		pool.idleConnections.PushBack(newConn)
+		created++
+
+		pool.callCallbacks()
+		pool.callScalingCallbacks(ConnectionPoolScalingGrow, "prewarm")
+	}
+
+	if created > 0 {
+		pool.waitCond.Broadcast()
+	}
+
+	if connErr != nil {
+		return created, errors.Wrapf(connErr, "failed to prewarm connections")
+	}
+
+	return created, nil
+}
+
+// GetWaiters returns number of callers waiting for a connection
+func (pool *ConnectionPool) GetWaiters() int {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	return pool.waitQueue.Len()
 }
 
 // Return returns the connection after use
@@ -429,7 +746,13 @@ func (pool *ConnectionPool) Return(conn *connection.IRODSConnection) error {
 	pool.callCallbacks()
 
 	// check maxidle
-	for pool.idleConnections.Len() > pool.config.MaxIdle {
+	// if idle shrink is deferred, connections over maxidle are kept until they expire idle timeout
+	maxIdle := pool.config.MaxIdle
+	if pool.config.DeferIdleShrink {
+		maxIdle = pool.getMaxConnectionsReal()
+	}
+
+	for pool.idleConnections.Len() > maxIdle {
 		// check front since it's old
 		elem := pool.idleConnections.Front()
 		if elem != nil {
@@ -438,6 +761,7 @@ func (pool *ConnectionPool) Return(conn *connection.IRODSConnection) error {
 
 			if idleConn, ok := idleConnObj.(*connection.IRODSConnection); ok {
 				_ = idleConn.Disconnect()
+				pool.callScalingCallbacks(ConnectionPoolScalingShrink, "max idle")
 			}
 		}
 	}
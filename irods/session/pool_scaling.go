@@ -0,0 +1,184 @@
+package session
+
+import (
+	"time"
+
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/rs/xid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// connectionLimitBackoffInitial is a backoff before lowered max connections is reset
+	connectionLimitBackoffInitial time.Duration = 1 * time.Minute
+	// connectionLimitBackoffMax is a max backoff before lowered max connections is reset
+	connectionLimitBackoffMax time.Duration = 30 * time.Minute
+)
+
+// ConnectionPoolScalingAction is a scaling decision made by the connection pool
+type ConnectionPoolScalingAction string
+
+const (
+	// ConnectionPoolScalingGrow is an action that a new connection is created
+	ConnectionPoolScalingGrow ConnectionPoolScalingAction = "grow"
+	// ConnectionPoolScalingShrink is an action that an idle connection is closed
+	ConnectionPoolScalingShrink ConnectionPoolScalingAction = "shrink"
+	// ConnectionPoolScalingLimit is an action that max connections is lowered as the server rejected new connections
+	ConnectionPoolScalingLimit ConnectionPoolScalingAction = "limit"
+	// ConnectionPoolScalingResetLimit is an action that lowered max connections is reset to MaxCap after a backoff
+	ConnectionPoolScalingResetLimit ConnectionPoolScalingAction = "reset_limit"
+)
+
+// ConnectionPoolScalingEvent describes a scaling decision
+type ConnectionPoolScalingEvent struct {
+	Action   ConnectionPoolScalingAction
+	Reason   string
+	Occupied int
+	Idle     int
+	Max      int
+}
+
+// ConnectionPoolScalingCallback is called when the pool makes a scaling decision
+type ConnectionPoolScalingCallback func(event *ConnectionPoolScalingEvent)
+
+// AddScalingCallback adds scaling callback
+func (pool *ConnectionPool) AddScalingCallback(callback ConnectionPoolScalingCallback) string {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	callbackID := xid.New().String()
+	pool.scalingCallbacks[callbackID] = callback
+
+	return callbackID
+}
+
+// RemoveScalingCallback removes scaling callback
+func (pool *ConnectionPool) RemoveScalingCallback(id string) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	delete(pool.scalingCallbacks, id)
+}
+
+func (pool *ConnectionPool) callScalingCallbacks(action ConnectionPoolScalingAction, reason string) {
+	if len(pool.scalingCallbacks) == 0 {
+		return
+	}
+
+	event := &ConnectionPoolScalingEvent{
+		Action:   action,
+		Reason:   reason,
+		Occupied: len(pool.occupiedConnections),
+		Idle:     pool.idleConnections.Len(),
+		Max:      pool.getMaxConnectionsReal(),
+	}
+
+	for _, callback := range pool.scalingCallbacks {
+		callback(event)
+	}
+}
+
+// maintenanceLoop runs pool maintenance periodically
+func (pool *ConnectionPool) maintenanceLoop() {
+	ticker := time.NewTicker(pool.config.MaintenanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-pool.terminateChan:
+			return
+		case <-ticker.C:
+			pool.maintain()
+		}
+	}
+}
+
+// limitMaxConnections lowers max connections as the server rejected a new connection,
+// the limit is kept for a backoff, which doubles if the server rejects again soon after the limit is reset
+func (pool *ConnectionPool) limitMaxConnections(maxConnections int) {
+	now := time.Now()
+	if pool.limitBackoff > 0 && now.Sub(pool.limitResetTime) < pool.limitBackoff {
+		pool.limitBackoff *= 2
+		if pool.limitBackoff > connectionLimitBackoffMax {
+			pool.limitBackoff = connectionLimitBackoffMax
+		}
+	} else {
+		pool.limitBackoff = connectionLimitBackoffInitial
+	}
+
+	pool.maxConnectionsReal = maxConnections
+	pool.limitTime = now
+}
+
+// maintain closes idle connections expired idle timeout or lifespan,
+// and resets lowered max connections after a backoff so the pool can grow up to MaxCap again
+func (pool *ConnectionPool) maintain() {
+	expiredConns := pool.removeExpiredIdleConnections()
+
+	// disconnect outside the lock, not to block checkouts with network round trips
+	for _, expiredConn := range expiredConns {
+		_ = expiredConn.Disconnect()
+	}
+}
+
+// removeExpiredIdleConnections removes idle connections expired idle timeout or lifespan from the pool and returns them,
+// and resets lowered max connections if its backoff is passed
+func (pool *ConnectionPool) removeExpiredIdleConnections() []*connection.IRODSConnection {
+	logger := log.WithFields(log.Fields{})
+
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	if pool.terminated {
+		return nil
+	}
+
+	expiredConns := []*connection.IRODSConnection{}
+
+	now := time.Now()
+	elem := pool.idleConnections.Front()
+	for elem != nil {
+		nextElem := elem.Next()
+
+		idleConn, ok := elem.Value.(*connection.IRODSConnection)
+		if !ok {
+			// unknown object, remove it
+			pool.idleConnections.Remove(elem)
+			pool.callCallbacks()
+			elem = nextElem
+			continue
+		}
+
+		reason := ""
+		if idleConn.GetLastSuccessfulAccess().Add(pool.config.IdleTimeout).Before(now) {
+			reason = "idle timeout"
+		} else if idleConn.GetCreationTime().Add(pool.config.Lifespan).Before(now) {
+			reason = "lifespan"
+		}
+
+		if len(reason) > 0 {
+			logger.Debugf("closing an idle connection, %s", reason)
+
+			pool.idleConnections.Remove(elem)
+			expiredConns = append(expiredConns, idleConn)
+
+			pool.callCallbacks()
+			pool.callScalingCallbacks(ConnectionPoolScalingShrink, reason)
+		}
+
+		elem = nextElem
+	}
+
+	if pool.maxConnectionsReal > 0 && pool.maxConnectionsReal < pool.config.MaxCap && now.Sub(pool.limitTime) >= pool.limitBackoff {
+		// server may accept more connections now
+		logger.Debugf("resetting adjusted max connections %d to %d", pool.maxConnectionsReal, pool.config.MaxCap)
+		pool.maxConnectionsReal = 0
+		pool.limitResetTime = now
+
+		pool.callCallbacks()
+		pool.callScalingCallbacks(ConnectionPoolScalingResetLimit, "maintenance")
+		pool.waitCond.Broadcast()
+	}
+
+	return expiredConns
+}
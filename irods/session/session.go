@@ -1,6 +1,7 @@
 package session
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -29,8 +30,7 @@ type IRODSSession struct {
 	lastConnectionError     error
 	lastConnectionErrorTime time.Time
 
-	supportParallelUpload    bool
-	supportParallelUploadSet bool
+	serverCapabilities *types.IRODSServerCapabilities // nil until a connection is made
 
 	metrics metrics.IRODSMetrics
 	mutex   sync.Mutex
@@ -74,8 +74,7 @@ func NewIRODSSession(account *types.IRODSAccount, config *IRODSSessionConfig) (*
 		lastConnectionError:     nil,
 		lastConnectionErrorTime: time.Time{},
 
-		supportParallelUpload:    false,
-		supportParallelUploadSet: false,
+		serverCapabilities: nil,
 
 		metrics: metrics.IRODSMetrics{},
 
@@ -163,6 +162,51 @@ func (sess *IRODSSession) RemoveConnectionUsageCallback(id string) {
 	sess.connectionPool.RemoveUsageCallback(id)
 }
 
+// Prewarm establishes n authenticated connections in parallel before they are used
+// this is useful to avoid sequential connection setup costs at the beginning of parallel transfers
+func (sess *IRODSSession) Prewarm(n int) error {
+	sess.mutex.Lock()
+
+	// return last error
+	pendingErr := sess.getPendingError()
+	if pendingErr != nil {
+		sess.mutex.Unlock()
+		return errors.Wrapf(pendingErr, "failed to prewarm connections because pending error is found")
+	}
+
+	sess.mutex.Unlock()
+
+	// do not hold the session lock while connecting, so other operations are not blocked
+	_, err := sess.connectionPool.Prewarm(n)
+	if err != nil {
+		sess.mutex.Lock()
+		defer sess.mutex.Unlock()
+
+		sess.lastConnectionError = err
+		sess.lastConnectionErrorTime = time.Now()
+
+		return err
+	}
+
+	return nil
+}
+
+// AddConnectionScalingCallback adds connection pool scaling callback
+func (sess *IRODSSession) AddConnectionScalingCallback(callback ConnectionPoolScalingCallback) string {
+	sess.mutex.Lock()
+	defer sess.mutex.Unlock()
+
+	return sess.connectionPool.AddScalingCallback(callback)
+}
+
+// RemoveConnectionScalingCallback removes connection pool scaling callback
+func (sess *IRODSSession) RemoveConnectionScalingCallback(id string) {
+	sess.mutex.Lock()
+	defer sess.mutex.Unlock()
+
+	sess.connectionPool.RemoveScalingCallback(id)
+}
+
 // IsPermanantFailure returns if there is a failure that is unfixable, permanent
 func (sess *IRODSSession) IsPermanantFailure() bool {
 	sess.mutex.Lock()
@@ -188,8 +232,6 @@ func (sess *IRODSSession) SetPoormansRollbackFail(poormansRollbackFail bool) {
 
 // endTransaction ends transaction
 func (sess *IRODSSession) endTransaction(conn *connection.IRODSConnection) error {
-	logger := log.WithFields(log.Fields{})
-
 	// Each irods connection automatically starts a database transaction at initial setup.
 	// All queries against irods using a connection will give results corresponding to the time
 	// the connection was made, or since the last change using the very same connection.
@@ -200,12 +242,22 @@ func (sess *IRODSSession) endTransaction(conn *connection.IRODSConnection) error
 	// which will do nothing to the database (there are no operations staged for commit/rollback),
 	// but which will close the current transaction and starts a new one - refreshing the view for
 	// future queries.
+	// This is done implicitly only if StartNewTransaction is set. Use WithFreshView or RefreshView
+	// to request a fresh view explicitly.
 
 	if !sess.startNewTransaction {
 		// done
 		return nil
 	}
 
+	return sess.refreshView(conn)
+}
+
+// refreshView closes the current database transaction of the connection and starts a new one
+// the caller must hold the session mutex and the connection lock
+func (sess *IRODSSession) refreshView(conn *connection.IRODSConnection) error {
+	logger := log.WithFields(log.Fields{})
+
 	if !sess.commitFail {
 		commitErr := conn.Commit()
 		if commitErr == nil {
@@ -240,6 +292,42 @@ func (sess *IRODSSession) endTransaction(conn *connection.IRODSConnection) error
 	return errors.Errorf("failed to commit/rollback transaction")
 }
 
+// RefreshView starts a new database transaction on the connection, so that following queries
+// see all changes made via other connections
+// the connection must be acquired from this session
+func (sess *IRODSSession) RefreshView(conn *connection.IRODSConnection) error {
+	sess.mutex.Lock()
+	defer sess.mutex.Unlock()
+
+	conn.Lock()
+	defer conn.Unlock()
+
+	err := sess.refreshView(conn)
+	if err != nil {
+		return err
+	}
+
+	conn.SetTransactionDirty(false)
+	return nil
+}
+
+// WithFreshView runs fn with a dedicated connection that has a fresh view on the catalog
+// use this when fn must see changes made via other connections, the connection is returned after fn
+func (sess *IRODSSession) WithFreshView(fn func(conn *connection.IRODSConnection) error) error {
+	conn, err := sess.AcquireConnection(false)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get connection")
+	}
+	defer sess.ReturnConnection(conn) //nolint
+
+	err = sess.RefreshView(conn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to refresh view")
+	}
+
+	return fn(conn)
+}
+
 func (sess *IRODSSession) acquireConnection(new bool, allowShared bool, noConnect bool, wait bool) (*connection.IRODSConnection, error) {
 	logger := log.WithFields(log.Fields{
 		"new":          new,
@@ -277,12 +365,8 @@ func (sess *IRODSSession) acquireConnection(new bool, allowShared bool, noConnec
 			sess.sharedConnections[conn] = 1
 		}
 
-		if !sess.supportParallelUploadSet {
-			if conn.IsConnected() {
-				// check parallel upload
-				sess.supportParallelUpload = conn.SupportParallelUpload()
-				sess.supportParallelUploadSet = true
-			}
+		if sess.serverCapabilities == nil && conn.IsConnected() {
+			sess.serverCapabilities = conn.GetServerCapabilities()
 		}
 
 		return conn, nil
@@ -318,14 +402,27 @@ func (sess *IRODSSession) acquireConnection(new bool, allowShared bool, noConnec
 
 // AcquireConnection acquires an idle connection
 func (sess *IRODSSession) AcquireConnection(allowShared bool) (*connection.IRODSConnection, error) {
+	return sess.AcquireConnectionWithContext(context.Background(), allowShared)
+}
+
+// AcquireConnectionWithContext acquires an idle connection.
+// If the pool is full, it shares an in-use connection if allowShared is true,
+// or waits for a connection to be returned until the context is done or ConnectionWaitTimeout passes.
+// If WaitConnectionInsteadOfSharing is set in the session config, it always waits instead of sharing.
+func (sess *IRODSSession) AcquireConnectionWithContext(ctx context.Context, allowShared bool) (*connection.IRODSConnection, error) {
 	sess.mutex.Lock()
 
 	// return last error
 	pendingErr := sess.getPendingError()
 	if pendingErr != nil {
+		sess.mutex.Unlock()
 		return nil, errors.Wrapf(pendingErr, "failed to get a connection from the pool because pending error is found")
 	}
 
+	if sess.config.WaitConnectionInsteadOfSharing {
+		allowShared = false
+	}
+
 	// try to get a connection from the pool without waiting
 	conn, err := sess.acquireConnection(false, allowShared, false, false)
 	if err == nil {
@@ -335,16 +432,23 @@ func (sess *IRODSSession) AcquireConnection(allowShared bool) (*connection.IRODS
 	}
 
 	// we don't need to wait, other type of error
-	if !types.IsConnectionPoolFullError(err) || !sess.config.WaitConnection || allowShared {
+	waitConnection := sess.config.WaitConnection || sess.config.WaitConnectionInsteadOfSharing
+	if !types.IsConnectionPoolFullError(err) || !waitConnection || allowShared {
 		sess.mutex.Unlock()
 		return nil, err
 	}
 
 	// wait for a connection to be available
-	// ReturnConnection will wakeup sess.mutex
+	// ReturnConnection will wakeup the waiter
 	sess.mutex.Unlock()
 
-	conn, _, err = sess.connectionPool.Get(false, false, true)
+	if sess.config.ConnectionWaitTimeout > 0 {
+		var cancelFunc context.CancelFunc
+		ctx, cancelFunc = context.WithTimeout(ctx, sess.config.ConnectionWaitTimeout)
+		defer cancelFunc()
+	}
+
+	conn, _, err = sess.connectionPool.GetWithContext(ctx, false, false)
 	if err != nil {
 		return nil, err
 	}
@@ -361,12 +465,8 @@ func (sess *IRODSSession) AcquireConnection(allowShared bool) (*connection.IRODS
 		sess.sharedConnections[conn] = 1
 	}
 
-	if !sess.supportParallelUploadSet {
-		if conn.IsConnected() {
-			// check parallel upload
-			sess.supportParallelUpload = conn.SupportParallelUpload()
-			sess.supportParallelUploadSet = true
-		}
+	if sess.serverCapabilities == nil && conn.IsConnected() {
+		sess.serverCapabilities = conn.GetServerCapabilities()
 	}
 
 	return conn, nil
@@ -418,22 +518,94 @@ func (sess *IRODSSession) AcquireNewConnection(allowShared bool) (*connection.IR
 		sess.sharedConnections[conn] = 1
 	}
 
-	if !sess.supportParallelUploadSet {
-		if conn.IsConnected() {
-			// check parallel upload
-			sess.supportParallelUpload = conn.SupportParallelUpload()
-			sess.supportParallelUploadSet = true
-		}
+	if sess.serverCapabilities == nil && conn.IsConnected() {
+		sess.serverCapabilities = conn.GetServerCapabilities()
 	}
 
 	return conn, nil
 }
 
+// AcquireConnectionAsClientUser acquires a dedicated connection that acts as the given client user.
+// The session account must be a proxy (rodsadmin) account. The connection is not pooled,
+// it is disconnected when returned via ReturnConnection.
+func (sess *IRODSSession) AcquireConnectionAsClientUser(clientUser string, clientZone string) (*connection.IRODSConnection, error) {
+	sess.mutex.Lock()
+
+	// return last error
+	pendingErr := sess.getPendingError()
+	if pendingErr != nil {
+		sess.mutex.Unlock()
+		return nil, errors.Wrapf(pendingErr, "failed to get a connection because pending error is found")
+	}
+
+	sess.mutex.Unlock()
+
+	clientAccount := sess.connectionPool.account.GetAsClientUser(clientUser, clientZone)
+	err := clientAccount.Validate()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to validate client user %q", clientUser)
+	}
+
+	connConfig := sess.connectionPool.config.ToConnectionConfig()
+
+	conn, err := connection.NewIRODSConnection(clientAccount, connConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create a connection for client user %q", clientUser)
+	}
+
+	err = conn.Connect()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to irods server as client user %q", clientUser)
+	}
+
+	return conn, nil
+}
+
+// AcquireConnectionsMultiOptions is options for AcquireConnectionsMultiWithOptions
+type AcquireConnectionsMultiOptions struct {
+	AllowShared bool // shares in-use connections if the pool is full, ignored in strict mode
+	Strict      bool // returns exactly the number of distinct connections requested, or NotEnoughConnectionsError without any connections
+}
+
 // AcquireConnectionsMulti acquires multiple idle connections
+// It may return fewer connections than requested, or in-use connections shared if allowShared is true
 func (sess *IRODSSession) AcquireConnectionsMulti(number int, allowShared bool) ([]*connection.IRODSConnection, error) {
+	return sess.AcquireConnectionsMultiWithOptions(number, &AcquireConnectionsMultiOptions{
+		AllowShared: allowShared,
+	})
+}
+
+// AcquireConnectionsMultiWithOptions acquires multiple idle connections
+// In strict mode, callers can reduce the number of connections requested on NotEnoughConnectionsError
+func (sess *IRODSSession) AcquireConnectionsMultiWithOptions(number int, opts *AcquireConnectionsMultiOptions) ([]*connection.IRODSConnection, error) {
+	if opts == nil {
+		opts = &AcquireConnectionsMultiOptions{}
+	}
+
 	sess.mutex.Lock()
 	defer sess.mutex.Unlock()
 
+	if !opts.Strict {
+		return sess.acquireConnectionsMulti(number, opts.AllowShared)
+	}
+
+	connections, err := sess.acquireConnectionsMulti(number, false)
+	if len(connections) == number {
+		return connections, nil
+	}
+
+	// return all connections acquired
+	for _, conn := range connections {
+		returnErr := sess.returnConnection(conn)
+		if returnErr != nil {
+			return nil, errors.Wrapf(returnErr, "failed to return connection")
+		}
+	}
+
+	return nil, errors.Join(types.NewNotEnoughConnectionsError(number, len(connections)), err)
+}
+
+func (sess *IRODSSession) acquireConnectionsMulti(number int, allowShared bool) ([]*connection.IRODSConnection, error) {
 	// return last error
 	pendingErr := sess.getPendingError()
 	if pendingErr != nil {
@@ -467,6 +639,7 @@ func (sess *IRODSSession) AcquireConnectionsMulti(number int, allowShared bool)
 	}
 
 	newConnections := []*connection.IRODSConnection{}
+	newConnectionsMutex := sync.Mutex{}
 	var connError error
 	wait := sync.WaitGroup{}
 	for _, conn := range connections {
@@ -478,6 +651,10 @@ func (sess *IRODSSession) AcquireConnectionsMulti(number int, allowShared bool)
 				defer wait.Done()
 
 				err := conn.Connect()
+
+				newConnectionsMutex.Lock()
+				defer newConnectionsMutex.Unlock()
+
 				if err != nil {
 					connError = errors.Wrapf(err, "failed to connect to iRODS server")
 
@@ -619,8 +796,9 @@ func (sess *IRODSSession) Release() {
 	sess.connectionPool.Release()
 }
 
-// SupportParallelUpload returns if parallel upload is supported
-func (sess *IRODSSession) SupportParallelUpload() bool {
+// GetServerCapabilities returns features supported by the server, a connection is made if there is no connection yet
+// the returned value must not be modified
+func (sess *IRODSSession) GetServerCapabilities() (*types.IRODSServerCapabilities, error) {
 	logger := log.WithFields(log.Fields{})
 
 	sess.mutex.Lock()
@@ -629,10 +807,10 @@ func (sess *IRODSSession) SupportParallelUpload() bool {
 	// return last error
 	pendingErr := sess.getPendingError()
 	if pendingErr != nil {
-		return false
+		return nil, pendingErr
 	}
 
-	if !sess.supportParallelUploadSet {
+	if sess.serverCapabilities == nil {
 		conn, _, err := sess.connectionPool.Get(false, false, true)
 		if err != nil {
 			if !types.IsConnectionPoolFullError(err) {
@@ -640,22 +818,29 @@ func (sess *IRODSSession) SupportParallelUpload() bool {
 				sess.lastConnectionErrorTime = time.Now()
 			}
 
-			return false
+			return nil, err
 		}
 
 		conn.Lock()
-
-		// check parallel upload
-		sess.supportParallelUpload = conn.SupportParallelUpload()
-		logger.Debugf("support parallel upload: %t", sess.supportParallelUpload)
-
+		sess.serverCapabilities = conn.GetServerCapabilities()
 		conn.Unlock()
 
+		logger.Debugf("server capabilities: %s", sess.serverCapabilities.ToString())
+
 		sess.connectionPool.Return(conn) //nolint
-		sess.supportParallelUploadSet = true
 	}
 
-	return sess.supportParallelUpload
+	return sess.serverCapabilities, nil
+}
+
+// SupportParallelUpload returns if parallel upload is supported
+func (sess *IRODSSession) SupportParallelUpload() bool {
+	capabilities, err := sess.GetServerCapabilities()
+	if err != nil {
+		return false
+	}
+
+	return capabilities.ParallelUpload
 }
 
 // GetOpenConnections returns the number of connections open in the pool
@@ -698,6 +883,14 @@ func (sess *IRODSSession) GetAvailableConnections() int {
 	return sess.connectionPool.GetAvailableConnections()
 }
 
+// GetRetryPolicy returns retry policy for operations of the session, connections of the session use the same policy
+func (sess *IRODSSession) GetRetryPolicy() *connection.RetryPolicy {
+	if sess.config.RetryPolicy == nil {
+		return connection.NewDefaultRetryPolicy()
+	}
+	return sess.config.RetryPolicy
+}
+
 // GetMetrics returns metrics
 func (sess *IRODSSession) GetMetrics() *metrics.IRODSMetrics {
 	return &sess.metrics
@@ -712,10 +905,46 @@ func (sess *IRODSSession) GetRedirectionConnection(controlConnection *connection
 	}
 
 	connConfig := &connection.IRODSResourceServerConnectionConfig{
-		ConnectTimeout: sess.config.ConnectionCreationTimeout,
-		TcpBufferSize:  sess.config.TcpBufferSize,
-		Metrics:        &sess.metrics,
+		ConnectTimeout:       sess.config.ConnectionCreationTimeout,
+		TcpBufferSize:        sess.config.TcpBufferSize,
+		TcpSendBufferSize:    sess.config.TcpSendBufferSize,
+		TcpReceiveBufferSize: sess.config.TcpReceiveBufferSize,
+		TcpKeepAlivePeriod:   sess.config.TcpKeepAlivePeriod,
+		TcpDelay:             sess.config.TcpDelay,
+		AddressFamily:        sess.config.AddressFamily,
+		HappyEyeballsDelay:   sess.config.HappyEyeballsDelay,
+		Resolver:             sess.config.Resolver,
+		FaultInjectionPolicy: sess.config.FaultInjectionPolicy,
+		Metrics:              &sess.metrics,
 	}
 
 	return connection.NewIRODSResourceServerConnection(controlConnection, &resourceServerInfo, connConfig)
 }
+
+// IdempotentOperation is an operation that is safe to replay, such as stat, list and query
+type IdempotentOperation func(conn *connection.IRODSConnection) error
+
+// RunIdempotentOperation runs a read-only operation with a pooled connection.
+// If the connection's socket fails during the operation, the operation is replayed on a fresh connection
+// following the session's retry policy.
+func (sess *IRODSSession) RunIdempotentOperation(allowShared bool, operation IdempotentOperation) error {
+	return sess.GetRetryPolicy().Run(func(attempt int) error {
+		// do not share a connection on retry to avoid getting the failed connection again
+		conn, err := sess.AcquireConnection(allowShared && attempt == 0)
+		if err != nil {
+			return err
+		}
+
+		err = operation(conn)
+		socketFailed := conn.IsSocketFailed()
+
+		// failed connections are discarded on return
+		_ = sess.ReturnConnection(conn)
+
+		if err != nil && socketFailed {
+			return errors.Join(err, types.NewConnectionError())
+		}
+
+		return err
+	})
+}
@@ -0,0 +1,10 @@
+package system
+
+import "os"
+
+// PreallocateFile allocates disk space of the local file to the size and sets the size of the file,
+// it fails early if there is not enough disk space, data already in the file is kept.
+// falls back to truncate if the file system does not support preallocation
+func PreallocateFile(f *os.File, size int64) error {
+	return preallocateFile(f, size)
+}
@@ -0,0 +1,19 @@
+//go:build !linux && !darwin
+
+package system
+
+import (
+	"os"
+
+	"github.com/cockroachdb/errors"
+)
+
+func preallocateFile(f *os.File, size int64) error {
+	// preallocation is not supported, sets the size only
+	err := f.Truncate(size)
+	if err != nil {
+		return errors.Wrapf(err, "failed to truncate file %q to %d", f.Name(), size)
+	}
+
+	return nil
+}
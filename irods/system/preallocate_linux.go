@@ -0,0 +1,29 @@
+//go:build linux
+
+package system
+
+import (
+	"os"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/sys/unix"
+)
+
+func preallocateFile(f *os.File, size int64) error {
+	if size > 0 {
+		err := unix.Fallocate(int(f.Fd()), 0, 0, size)
+		if err != nil {
+			// falls back to truncate if the file system does not support preallocation, e.g., tmpfs on old kernels
+			if !errors.Is(err, unix.EOPNOTSUPP) && !errors.Is(err, unix.ENOSYS) {
+				return errors.Wrapf(err, "failed to preallocate %d bytes for file %q", size, f.Name())
+			}
+		}
+	}
+
+	err := f.Truncate(size)
+	if err != nil {
+		return errors.Wrapf(err, "failed to truncate file %q to %d", f.Name(), size)
+	}
+
+	return nil
+}
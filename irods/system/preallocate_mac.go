@@ -0,0 +1,42 @@
+//go:build darwin
+
+package system
+
+import (
+	"os"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/sys/unix"
+)
+
+func preallocateFile(f *os.File, size int64) error {
+	stat, err := f.Stat()
+	if err != nil {
+		return errors.Wrapf(err, "failed to stat file %q", f.Name())
+	}
+
+	if size > stat.Size() {
+		// allocate from the physical end of the file
+		fstore := &unix.Fstore_t{
+			Flags:   unix.F_ALLOCATEALL,
+			Posmode: unix.F_PEOFPOSMODE,
+			Offset:  0,
+			Length:  size - stat.Size(),
+		}
+
+		err = unix.FcntlFstore(f.Fd(), unix.F_PREALLOCATE, fstore)
+		if err != nil {
+			// falls back to truncate if the file system does not support preallocation
+			if !errors.Is(err, unix.ENOTSUP) && !errors.Is(err, unix.EINVAL) {
+				return errors.Wrapf(err, "failed to preallocate %d bytes for file %q", size, f.Name())
+			}
+		}
+	}
+
+	err = f.Truncate(size)
+	if err != nil {
+		return errors.Wrapf(err, "failed to truncate file %q to %d", f.Name(), size)
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package system
+
+// ListXattrs returns extended attributes of a local file, on linux only attributes in the user namespace are returned
+func ListXattrs(path string) (map[string][]byte, error) {
+	return listXattrs(path)
+}
+
+// SetXattr sets an extended attribute of a local file
+func SetXattr(path string, name string, value []byte) error {
+	return setXattr(path, name, value)
+}
+
+// RemoveXattr removes an extended attribute of a local file
+func RemoveXattr(path string, name string) error {
+	return removeXattr(path, name)
+}
@@ -0,0 +1,21 @@
+//go:build !linux && !darwin
+
+package system
+
+import (
+	"runtime"
+
+	"github.com/cockroachdb/errors"
+)
+
+func listXattrs(path string) (map[string][]byte, error) {
+	return nil, errors.Errorf("extended attributes are not supported on %s", runtime.GOOS)
+}
+
+func setXattr(path string, name string, value []byte) error {
+	return errors.Errorf("extended attributes are not supported on %s", runtime.GOOS)
+}
+
+func removeXattr(path string, name string) error {
+	return errors.Errorf("extended attributes are not supported on %s", runtime.GOOS)
+}
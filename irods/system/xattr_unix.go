@@ -0,0 +1,89 @@
+//go:build linux || darwin
+
+package system
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/sys/unix"
+)
+
+func listXattrs(path string) (map[string][]byte, error) {
+	size, err := unix.Listxattr(path, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list extended attributes of %q", path)
+	}
+
+	xattrs := map[string][]byte{}
+	if size == 0 {
+		return xattrs, nil
+	}
+
+	namesBuffer := make([]byte, size)
+	size, err = unix.Listxattr(path, namesBuffer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list extended attributes of %q", path)
+	}
+
+	// names are null-terminated
+	for _, nameBytes := range bytes.Split(namesBuffer[:size], []byte{0}) {
+		name := string(nameBytes)
+		if len(name) == 0 {
+			continue
+		}
+
+		if runtime.GOOS == "linux" && !strings.HasPrefix(name, "user.") {
+			// system, security and trusted namespaces are not portable
+			continue
+		}
+
+		value, err := getXattr(path, name)
+		if err != nil {
+			return nil, err
+		}
+
+		xattrs[name] = value
+	}
+
+	return xattrs, nil
+}
+
+func getXattr(path string, name string) ([]byte, error) {
+	size, err := unix.Getxattr(path, name, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get extended attribute %q of %q", name, path)
+	}
+
+	value := make([]byte, size)
+	if size == 0 {
+		return value, nil
+	}
+
+	size, err = unix.Getxattr(path, name, value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get extended attribute %q of %q", name, path)
+	}
+
+	return value[:size], nil
+}
+
+func setXattr(path string, name string, value []byte) error {
+	err := unix.Setxattr(path, name, value, 0)
+	if err != nil {
+		return errors.Wrapf(err, "failed to set extended attribute %q of %q", name, path)
+	}
+
+	return nil
+}
+
+func removeXattr(path string, name string) error {
+	err := unix.Removexattr(path, name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove extended attribute %q of %q", name, path)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package testharness
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+const (
+	VersionDefault         string        = "4.3.3"
+	StartupTimeoutDefault  time.Duration = 3 * time.Minute
+	ApplicationNameDefault string        = "go-irodsclient-testharness"
+
+	// values below are baked in the provider images
+	Zone              string = "tempZone"
+	AdminUser         string = "rods"
+	AdminPassword     string = "rods"
+	DefaultResource   string = "demoResc"
+	catalogPassword   string = "testpassword"
+	catalogAlias      string = "irods-catalog"
+	providerAlias     string = "irods-catalog-provider"
+	dataPortRangeSpec string = "20000-20199"
+
+	// untyped to be used as nat.Port
+	providerPort = "1247/tcp"
+)
+
+// TestHarnessUser is a user to be created after the provider starts
+type TestHarnessUser struct {
+	Name     string
+	Password string
+	Type     types.IRODSUserType // rodsuser is used if not set
+}
+
+// TestHarnessResource is a resource to be created after the provider starts
+type TestHarnessResource struct {
+	Name      string
+	Type      string // e.g., unixfilesystem, passthru, replication
+	VaultPath string // for storage resources, empty for coordinating resources
+	Context   string
+	Parent    string // can be empty, adds the resource as a child of parent if set
+}
+
+// TestHarnessConfig is a configuration for TestHarness
+type TestHarnessConfig struct {
+	Version        string // iRODS version, e.g., 4.2.8, 4.2.11, 4.3.3
+	CatalogImage   string // overrides the catalog (database) image derived from Version
+	ProviderImage  string // overrides the provider image derived from Version
+	StartupTimeout time.Duration
+
+	// BindFixedPorts binds 1247 and the data transfer ports (20000-20199) to the same host ports.
+	// Parallel transfers are redirected to the data transfer ports, so they only work if this is set.
+	// Only one harness with BindFixedPorts can run at a time.
+	BindFixedPorts bool
+
+	Users     []TestHarnessUser
+	Resources []TestHarnessResource
+}
+
+// NewDefaultTestHarnessConfig creates a default TestHarnessConfig
+func NewDefaultTestHarnessConfig() *TestHarnessConfig {
+	return &TestHarnessConfig{
+		Version:        VersionDefault,
+		StartupTimeout: StartupTimeoutDefault,
+	}
+}
+
+func (config *TestHarnessConfig) fillDefaults() {
+	if len(config.Version) == 0 {
+		config.Version = VersionDefault
+	}
+
+	if len(config.CatalogImage) == 0 {
+		config.CatalogImage = fmt.Sprintf("cyverse/irods-test-docker-catalog:v%s", config.Version)
+	}
+
+	if len(config.ProviderImage) == 0 {
+		config.ProviderImage = fmt.Sprintf("cyverse/irods-test-docker-catalog-provider:v%s", config.Version)
+	}
+
+	if config.StartupTimeout <= 0 {
+		config.StartupTimeout = StartupTimeoutDefault
+	}
+
+	for idx := range config.Users {
+		if len(config.Users[idx].Type) == 0 {
+			config.Users[idx].Type = types.IRODSUserRodsUser
+		}
+	}
+}
+
+// Validate validates field values and returns error if occurs
+func (config *TestHarnessConfig) Validate() error {
+	for _, user := range config.Users {
+		if len(user.Name) == 0 {
+			return errors.Errorf("user name is empty")
+		}
+
+		if user.Name == AdminUser {
+			return errors.Errorf("user %q already exists", user.Name)
+		}
+	}
+
+	for _, resource := range config.Resources {
+		if len(resource.Name) == 0 {
+			return errors.Errorf("resource name is empty")
+		}
+
+		if len(resource.Type) == 0 {
+			return errors.Errorf("resource type is empty for resource %q", resource.Name)
+		}
+	}
+
+	return nil
+}
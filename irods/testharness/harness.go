@@ -0,0 +1,386 @@
+package testharness
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	irods_fs "github.com/cyverse/go-irodsclient/fs"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	lowlevel_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	dockercontainer "github.com/docker/docker/api/types/container"
+)
+
+// TestHarness runs an iRODS provider and its catalog database in docker containers for integration tests
+type TestHarness struct {
+	config *TestHarnessConfig
+
+	network  *testcontainers.DockerNetwork
+	catalog  *testcontainers.DockerContainer
+	provider *testcontainers.DockerContainer
+
+	host      string
+	port      int
+	passwords map[string]string // username -> password
+
+	mutex sync.Mutex
+}
+
+// NewTestHarness creates a new TestHarness, containers are not started until Start is called
+func NewTestHarness(config *TestHarnessConfig) (*TestHarness, error) {
+	if config == nil {
+		config = NewDefaultTestHarnessConfig()
+	}
+
+	config.fillDefaults()
+
+	err := config.Validate()
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid test harness configuration")
+	}
+
+	passwords := map[string]string{
+		AdminUser: AdminPassword,
+	}
+
+	for _, user := range config.Users {
+		passwords[user.Name] = user.Password
+	}
+
+	return &TestHarness{
+		config:    config,
+		passwords: passwords,
+	}, nil
+}
+
+// GetConfig returns the configuration
+func (harness *TestHarness) GetConfig() *TestHarnessConfig {
+	return harness.config
+}
+
+// Start starts containers, waits for the provider to accept connections and creates users and resources
+func (harness *TestHarness) Start(ctx context.Context) error {
+	logger := log.WithFields(log.Fields{
+		"version": harness.config.Version,
+	})
+
+	harness.mutex.Lock()
+	defer harness.mutex.Unlock()
+
+	if harness.provider != nil {
+		return errors.Errorf("test harness is already started")
+	}
+
+	logger.Infof("Starting iRODS %s containers", harness.config.Version)
+
+	err := harness.startContainers(ctx)
+	if err != nil {
+		harness.terminate(context.Background())
+		return err
+	}
+
+	err = harness.waitForProvider(ctx)
+	if err != nil {
+		harness.terminate(context.Background())
+		return err
+	}
+
+	err = harness.setup()
+	if err != nil {
+		harness.terminate(context.Background())
+		return err
+	}
+
+	logger.Infof("Started iRODS %s containers, listening on %s:%d", harness.config.Version, harness.host, harness.port)
+
+	return nil
+}
+
+// Stop terminates containers
+func (harness *TestHarness) Stop(ctx context.Context) error {
+	harness.mutex.Lock()
+	defer harness.mutex.Unlock()
+
+	return harness.terminate(ctx)
+}
+
+func (harness *TestHarness) startContainers(ctx context.Context) error {
+	nw, err := network.New(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create a docker network")
+	}
+
+	harness.network = nw
+
+	catalog, err := testcontainers.Run(ctx, harness.config.CatalogImage,
+		network.WithNetwork([]string{catalogAlias}, nw),
+		testcontainers.WithEnv(map[string]string{
+			"POSTGRES_PASSWORD": catalogPassword,
+		}),
+		testcontainers.WithWaitStrategy(wait.ForListeningPort("5432/tcp").WithStartupTimeout(harness.config.StartupTimeout)),
+	)
+	if catalog != nil {
+		harness.catalog = catalog
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to start catalog container %q", harness.config.CatalogImage)
+	}
+
+	ports := []string{providerPort}
+	if harness.config.BindFixedPorts {
+		ports = []string{
+			fmt.Sprintf("1247:%s", providerPort),
+			fmt.Sprintf("%s:%s/tcp", dataPortRangeSpec, dataPortRangeSpec),
+		}
+	}
+
+	provider, err := testcontainers.Run(ctx, harness.config.ProviderImage,
+		network.WithNetwork([]string{providerAlias}, nw),
+		testcontainers.WithExposedPorts(ports...),
+		testcontainers.WithHostConfigModifier(func(hostConfig *dockercontainer.HostConfig) {
+			hostConfig.ShmSize = 500 * 1024 * 1024
+		}),
+		testcontainers.WithWaitStrategy(wait.ForListeningPort(providerPort).WithStartupTimeout(harness.config.StartupTimeout)),
+	)
+	if provider != nil {
+		harness.provider = provider
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to start provider container %q", harness.config.ProviderImage)
+	}
+
+	host, err := provider.Host(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get provider host")
+	}
+
+	mappedPort, err := provider.MappedPort(ctx, providerPort)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get provider port")
+	}
+
+	harness.host = host
+	harness.port = mappedPort.Int()
+	return nil
+}
+
+// waitForProvider waits until the provider accepts iRODS connections, the port opens before iRODS is ready
+func (harness *TestHarness) waitForProvider(ctx context.Context) error {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	timeoutChan := time.After(harness.config.StartupTimeout)
+
+	var lastErr error
+	for {
+		conn, err := harness.connectAdminNoLock()
+		if err == nil {
+			conn.Disconnect()
+			return nil
+		}
+
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "canceled while waiting for iRODS provider")
+		case <-timeoutChan:
+			return errors.Wrapf(lastErr, "timed out waiting for iRODS provider")
+		case <-ticker.C:
+		}
+	}
+}
+
+func (harness *TestHarness) connectAdminNoLock() (*connection.IRODSConnection, error) {
+	account, err := harness.getAccountNoLock(AdminUser)
+	if err != nil {
+		return nil, err
+	}
+
+	connConfig := &connection.IRODSConnectionConfig{
+		ConnectTimeout:   10 * time.Second,
+		OperationTimeout: 30 * time.Second,
+		ApplicationName:  ApplicationNameDefault,
+	}
+
+	conn, err := connection.NewIRODSConnection(account, connConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create an admin connection")
+	}
+
+	err = conn.Connect()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect as admin")
+	}
+
+	return conn, nil
+}
+
+// setup creates users and resources
+func (harness *TestHarness) setup() error {
+	if len(harness.config.Users) == 0 && len(harness.config.Resources) == 0 {
+		return nil
+	}
+
+	conn, err := harness.connectAdminNoLock()
+	if err != nil {
+		return err
+	}
+	defer conn.Disconnect()
+
+	for _, user := range harness.config.Users {
+		err = lowlevel_fs.CreateUser(conn, user.Name, Zone, user.Type)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create user %q", user.Name)
+		}
+
+		if len(user.Password) > 0 {
+			err = lowlevel_fs.ChangeUserPassword(conn, user.Name, Zone, user.Password)
+			if err != nil {
+				return errors.Wrapf(err, "failed to set password for user %q", user.Name)
+			}
+		}
+	}
+
+	if len(harness.config.Resources) > 0 {
+		// new storage resources are placed on the same host as the default resource
+		defaultResource, err := lowlevel_fs.GetResource(conn, DefaultResource)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get resource %q", DefaultResource)
+		}
+
+		for _, resource := range harness.config.Resources {
+			location := ""
+			if len(resource.VaultPath) > 0 {
+				location = fmt.Sprintf("%s:%s", defaultResource.Location, resource.VaultPath)
+			}
+
+			err = lowlevel_fs.CreateResource(conn, resource.Name, resource.Type, location, resource.Context, Zone)
+			if err != nil {
+				return errors.Wrapf(err, "failed to create resource %q", resource.Name)
+			}
+		}
+
+		for _, resource := range harness.config.Resources {
+			if len(resource.Parent) > 0 {
+				err = lowlevel_fs.AddChildToResource(conn, resource.Parent, resource.Name, "")
+				if err != nil {
+					return errors.Wrapf(err, "failed to add resource %q to %q", resource.Name, resource.Parent)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (harness *TestHarness) terminate(ctx context.Context) error {
+	errs := []error{}
+
+	if harness.provider != nil {
+		err := harness.provider.Terminate(ctx)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to terminate provider container"))
+		}
+		harness.provider = nil
+	}
+
+	if harness.catalog != nil {
+		err := harness.catalog.Terminate(ctx)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to terminate catalog container"))
+		}
+		harness.catalog = nil
+	}
+
+	if harness.network != nil {
+		err := harness.network.Remove(ctx)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to remove docker network"))
+		}
+		harness.network = nil
+	}
+
+	harness.host = ""
+	harness.port = 0
+
+	return errors.Join(errs...)
+}
+
+// GetHost returns host to connect to the provider
+func (harness *TestHarness) GetHost() string {
+	harness.mutex.Lock()
+	defer harness.mutex.Unlock()
+
+	return harness.host
+}
+
+// GetPort returns port to connect to the provider
+func (harness *TestHarness) GetPort() int {
+	harness.mutex.Lock()
+	defer harness.mutex.Unlock()
+
+	return harness.port
+}
+
+// GetAccount returns an account for the given user, the user must be the admin or one of configured users
+func (harness *TestHarness) GetAccount(username string) (*types.IRODSAccount, error) {
+	harness.mutex.Lock()
+	defer harness.mutex.Unlock()
+
+	return harness.getAccountNoLock(username)
+}
+
+func (harness *TestHarness) getAccountNoLock(username string) (*types.IRODSAccount, error) {
+	if harness.port == 0 {
+		return nil, errors.Errorf("test harness is not started")
+	}
+
+	password, ok := harness.passwords[username]
+	if !ok {
+		return nil, errors.Errorf("failed to find user %q", username)
+	}
+
+	return types.CreateIRODSAccount(harness.host, harness.port, username, Zone, types.AuthSchemeNative, password, DefaultResource)
+}
+
+// GetAdminAccount returns an account for the admin user
+func (harness *TestHarness) GetAdminAccount() (*types.IRODSAccount, error) {
+	return harness.GetAccount(AdminUser)
+}
+
+// GetFileSystemConfig returns a FileSystemConfig that resolves addresses returned by the provider to the harness host
+func (harness *TestHarness) GetFileSystemConfig(applicationName string) *irods_fs.FileSystemConfig {
+	if len(strings.TrimSpace(applicationName)) == 0 {
+		applicationName = ApplicationNameDefault
+	}
+
+	host := harness.GetHost()
+
+	fsConfig := irods_fs.NewFileSystemConfig(applicationName)
+	fsConfig.AddressResolver = func(address string) string {
+		// container hostnames are not resolvable from the host
+		return host
+	}
+
+	return fsConfig
+}
+
+// GetFileSystem returns a FileSystem connected as the given user
+func (harness *TestHarness) GetFileSystem(username string) (*irods_fs.FileSystem, error) {
+	account, err := harness.GetAccount(username)
+	if err != nil {
+		return nil, err
+	}
+
+	return irods_fs.NewFileSystem(account, harness.GetFileSystemConfig(ApplicationNameDefault))
+}
@@ -0,0 +1,1252 @@
+package testserver
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+)
+
+// catalogCollection is a collection stored in catalog
+type catalogCollection struct {
+	id         int64
+	path       string
+	owner      string
+	createTime time.Time
+	modifyTime time.Time
+
+	specialType string // special collection type, empty for normal collections
+	info1       string
+	info2       string
+}
+
+// catalogDataObject is a data object stored in catalog, data is stored in the replica 0
+type catalogDataObject struct {
+	id            int64
+	path          string
+	owner         string
+	data          []byte
+	physicalPath  string // empty for the default path in the vault
+	dataType      string
+	replicaStatus string
+	createTime    time.Time
+	modifyTime    time.Time
+	staleReplicas []int64 // sizes of stale replicas following the replica 0, they have no data
+}
+
+// catalog is an in-memory iCAT
+type catalog struct {
+	zone          string
+	resource      string
+	nextID        int64
+	users         map[string]string // username -> password
+	collections   map[string]*catalogCollection
+	dataObjects   map[string]*catalogDataObject
+	vault         map[string][]byte // physical path -> data of unregistered data objects
+	replicaTokens map[string]string // replica token -> path of the data object opened for write
+	tickets       map[string]string // ticket name -> path
+	mutex         sync.Mutex
+}
+
+func newCatalog(zone string, resource string) *catalog {
+	cat := &catalog{
+		zone:          zone,
+		resource:      resource,
+		nextID:        10000,
+		users:         map[string]string{},
+		collections:   map[string]*catalogCollection{},
+		dataObjects:   map[string]*catalogDataObject{},
+		vault:         map[string][]byte{},
+		replicaTokens: map[string]string{},
+		tickets:       map[string]string{},
+	}
+
+	zonePath := fmt.Sprintf("/%s", zone)
+	for _, collPath := range []string{"/", zonePath, path.Join(zonePath, "home"), path.Join(zonePath, "trash"), path.Join(zonePath, "trash", "home")} {
+		cat.addCollectionNoLock(collPath, "")
+	}
+
+	return cat
+}
+
+func (cat *catalog) newID() int64 {
+	cat.nextID++
+	return cat.nextID
+}
+
+func (cat *catalog) addUser(username string, password string) {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	cat.users[username] = password
+
+	for _, homePath := range []string{fmt.Sprintf("/%s/home/%s", cat.zone, username), cat.getTrashHomePath(username)} {
+		if _, ok := cat.collections[homePath]; !ok {
+			cat.addCollectionNoLock(homePath, username)
+		}
+	}
+}
+
+func (cat *catalog) addTicket(ticket string, irodsPath string) {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	cat.tickets[ticket] = irodsPath
+}
+
+func (cat *catalog) hasTicket(ticket string) bool {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	_, ok := cat.tickets[ticket]
+	return ok
+}
+
+func (cat *catalog) getUserPassword(username string) (string, bool) {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	password, ok := cat.users[username]
+	return password, ok
+}
+
+func (cat *catalog) addCollectionNoLock(collPath string, owner string) *catalogCollection {
+	now := time.Now()
+	coll := &catalogCollection{
+		id:         cat.newID(),
+		path:       collPath,
+		owner:      owner,
+		createTime: now,
+		modifyTime: now,
+	}
+
+	cat.collections[collPath] = coll
+	return coll
+}
+
+func (cat *catalog) existsNoLock(irodsPath string) bool {
+	if _, ok := cat.collections[irodsPath]; ok {
+		return true
+	}
+
+	if _, ok := cat.dataObjects[irodsPath]; ok {
+		return true
+	}
+
+	return false
+}
+
+// hasChildrenNoLock returns true if the collection has any collection or data object in it
+func (cat *catalog) hasChildrenNoLock(collPath string) bool {
+	for childPath := range cat.collections {
+		if childPath != collPath && util.GetIRODSPathDirname(childPath) == collPath {
+			return true
+		}
+	}
+
+	for childPath := range cat.dataObjects {
+		if util.GetIRODSPathDirname(childPath) == collPath {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (cat *catalog) makeCollection(collPath string, owner string, recurse bool) common.ErrorCode {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	if _, ok := cat.collections[collPath]; ok {
+		if recurse {
+			return 0
+		}
+		return common.CATALOG_ALREADY_HAS_ITEM_BY_THAT_NAME
+	}
+
+	if _, ok := cat.dataObjects[collPath]; ok {
+		return common.CAT_NAME_EXISTS_AS_DATAOBJ
+	}
+
+	parentPath := util.GetIRODSPathDirname(collPath)
+	if _, ok := cat.collections[parentPath]; !ok {
+		if !recurse {
+			return common.CAT_UNKNOWN_COLLECTION
+		}
+
+		if _, ok := cat.dataObjects[parentPath]; ok {
+			return common.CAT_NAME_EXISTS_AS_DATAOBJ
+		}
+
+		// create parents
+		missingPaths := []string{}
+		for p := parentPath; p != "/"; p = util.GetIRODSPathDirname(p) {
+			if _, ok := cat.collections[p]; ok {
+				break
+			}
+
+			missingPaths = append(missingPaths, p)
+		}
+
+		for idx := len(missingPaths) - 1; idx >= 0; idx-- {
+			cat.addCollectionNoLock(missingPaths[idx], owner)
+		}
+	}
+
+	cat.addCollectionNoLock(collPath, owner)
+	return 0
+}
+
+// removeCollection removes a collection, unregister keeps data of the data objects in the vault
+// non-force removal moves the collection to the trash of the user
+// ageMinutes keeps entries modified less than ageMinutes ago
+func (cat *catalog) removeCollection(collPath string, username string, recurse bool, force bool, unregister bool, ageMinutes int) common.ErrorCode {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	if _, ok := cat.collections[collPath]; !ok {
+		return common.CAT_UNKNOWN_COLLECTION
+	}
+
+	if ageMinutes > 0 {
+		if !recurse && cat.hasChildrenNoLock(collPath) {
+			return common.CAT_COLLECTION_NOT_EMPTY
+		}
+
+		cat.removeOldEntriesNoLock(collPath, username, force, unregister, ageMinutes)
+		return 0
+	}
+
+	if cat.hasChildrenNoLock(collPath) {
+		if !recurse {
+			return common.CAT_COLLECTION_NOT_EMPTY
+		}
+
+		if !force && !unregister && !cat.isTrashPath(collPath) {
+			cat.moveToTrashNoLock(collPath, username)
+			return 0
+		}
+
+		prefix := collPath + "/"
+		for childPath := range cat.collections {
+			if strings.HasPrefix(childPath, prefix) {
+				delete(cat.collections, childPath)
+			}
+		}
+
+		for childPath, obj := range cat.dataObjects {
+			if strings.HasPrefix(childPath, prefix) {
+				if unregister {
+					cat.vault[cat.getPhysicalPathNoLock(obj)] = obj.data
+				}
+				delete(cat.dataObjects, childPath)
+			}
+		}
+	}
+
+	if !force && !unregister && !cat.isTrashPath(collPath) {
+		cat.moveToTrashNoLock(collPath, username)
+		return 0
+	}
+
+	delete(cat.collections, collPath)
+	return 0
+}
+
+func (cat *catalog) renameCollection(srcPath string, destPath string) common.ErrorCode {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	if _, ok := cat.collections[srcPath]; !ok {
+		return common.CAT_UNKNOWN_COLLECTION
+	}
+
+	if cat.existsNoLock(destPath) {
+		return common.CATALOG_ALREADY_HAS_ITEM_BY_THAT_NAME
+	}
+
+	if _, ok := cat.collections[util.GetIRODSPathDirname(destPath)]; !ok {
+		return common.CAT_UNKNOWN_COLLECTION
+	}
+
+	cat.moveCollectionNoLock(srcPath, destPath)
+	return 0
+}
+
+// moveCollectionNoLock moves a collection and its children to the dest path
+func (cat *catalog) moveCollectionNoLock(srcPath string, destPath string) {
+	prefix := srcPath + "/"
+	for childPath, coll := range cat.collections {
+		if childPath == srcPath || strings.HasPrefix(childPath, prefix) {
+			delete(cat.collections, childPath)
+			coll.path = destPath + strings.TrimPrefix(childPath, srcPath)
+			cat.collections[coll.path] = coll
+		}
+	}
+
+	for childPath, obj := range cat.dataObjects {
+		if strings.HasPrefix(childPath, prefix) {
+			delete(cat.dataObjects, childPath)
+			obj.path = destPath + strings.TrimPrefix(childPath, srcPath)
+			cat.dataObjects[obj.path] = obj
+		}
+	}
+}
+
+// openDataObject returns a data object for the path, creates or truncates it following the open flags
+func (cat *catalog) openDataObject(objPath string, owner string, flags int, create bool, force bool) (*catalogDataObject, common.ErrorCode) {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	if _, ok := cat.collections[objPath]; ok {
+		return nil, common.CAT_NAME_EXISTS_AS_COLLECTION
+	}
+
+	obj, ok := cat.dataObjects[objPath]
+	if ok {
+		if create && !force {
+			return nil, common.OVERWRITE_WITHOUT_FORCE_FLAG
+		}
+
+		if flags&int(types.O_CREAT) != 0 && flags&int(types.O_EXCL) != 0 {
+			return nil, common.OVERWRITE_WITHOUT_FORCE_FLAG
+		}
+
+		if create || flags&int(types.O_TRUNC) != 0 {
+			obj.data = []byte{}
+			obj.modifyTime = time.Now()
+		}
+
+		return obj, 0
+	}
+
+	if !create && flags&int(types.O_CREAT) == 0 {
+		return nil, common.CAT_NO_ROWS_FOUND
+	}
+
+	if _, ok := cat.collections[util.GetIRODSPathDirname(objPath)]; !ok {
+		return nil, common.CAT_UNKNOWN_COLLECTION
+	}
+
+	now := time.Now()
+	obj = &catalogDataObject{
+		id:            cat.newID(),
+		path:          objPath,
+		owner:         owner,
+		data:          []byte{},
+		dataType:      string(types.GENERIC_DT),
+		replicaStatus: string(types.ReplicaStatusGood),
+		createTime:    now,
+		modifyTime:    now,
+	}
+
+	cat.dataObjects[objPath] = obj
+	return obj, 0
+}
+
+// removeDataObject removes a data object, unregister keeps its data in the vault
+// non-force removal moves the data object to the trash of the user
+// replicaNumber selects the replica to remove if not negative, ageMinutes keeps the data object modified less than ageMinutes ago
+func (cat *catalog) removeDataObject(objPath string, username string, force bool, unregister bool, replicaNumber int, ageMinutes int) common.ErrorCode {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	obj, ok := cat.dataObjects[objPath]
+	if !ok {
+		return common.CAT_NO_ROWS_FOUND
+	}
+
+	// there is only one replica
+	if replicaNumber > 0 {
+		return common.CAT_NO_ROWS_FOUND
+	}
+
+	if ageMinutes > 0 && !obj.modifyTime.Before(time.Now().Add(-time.Duration(ageMinutes)*time.Minute)) {
+		return 0
+	}
+
+	cat.removeDataObjectNoLock(objPath, username, force, unregister)
+	return 0
+}
+
+func (cat *catalog) removeDataObjectNoLock(objPath string, username string, force bool, unregister bool) {
+	obj := cat.dataObjects[objPath]
+
+	if unregister {
+		cat.vault[cat.getPhysicalPathNoLock(obj)] = obj.data
+	} else if !force && !cat.isTrashPath(objPath) {
+		cat.moveToTrashNoLock(objPath, username)
+		return
+	}
+
+	delete(cat.dataObjects, objPath)
+}
+
+func (cat *catalog) getTrashHomePath(username string) string {
+	return fmt.Sprintf("/%s/trash/home/%s", cat.zone, username)
+}
+
+// isTrashPath returns true if the path is in the trash area of the zone
+func (cat *catalog) isTrashPath(irodsPath string) bool {
+	trashPath := fmt.Sprintf("/%s/trash", cat.zone)
+	return irodsPath == trashPath || strings.HasPrefix(irodsPath, trashPath+"/")
+}
+
+// isTrashHomePath returns true if the path is the trash area of the zone or a trash home of a user, which are never removed
+func (cat *catalog) isTrashHomePath(irodsPath string) bool {
+	trashHomesPath := fmt.Sprintf("/%s/trash/home", cat.zone)
+	return irodsPath == util.GetIRODSPathDirname(trashHomesPath) || irodsPath == trashHomesPath || util.GetIRODSPathDirname(irodsPath) == trashHomesPath
+}
+
+// moveToTrashNoLock moves a data object or a collection to the trash of the user keeping its path relative to the home
+// a suffix is appended if the trash path is taken
+func (cat *catalog) moveToTrashNoLock(irodsPath string, username string) {
+	homePath := fmt.Sprintf("/%s/home/%s", cat.zone, username)
+	relPath := strings.TrimPrefix(irodsPath, fmt.Sprintf("/%s", cat.zone))
+	if strings.HasPrefix(irodsPath, homePath+"/") {
+		relPath = strings.TrimPrefix(irodsPath, homePath)
+	}
+
+	trashPath := cat.getTrashHomePath(username) + relPath
+	if cat.existsNoLock(trashPath) {
+		trashPath = fmt.Sprintf("%s.%d", trashPath, cat.newID())
+	}
+
+	// create parents
+	missingPaths := []string{}
+	for p := util.GetIRODSPathDirname(trashPath); p != "/"; p = util.GetIRODSPathDirname(p) {
+		if _, ok := cat.collections[p]; ok {
+			break
+		}
+
+		missingPaths = append(missingPaths, p)
+	}
+
+	for idx := len(missingPaths) - 1; idx >= 0; idx-- {
+		cat.addCollectionNoLock(missingPaths[idx], username)
+	}
+
+	if obj, ok := cat.dataObjects[irodsPath]; ok {
+		delete(cat.dataObjects, irodsPath)
+		obj.path = trashPath
+		cat.dataObjects[trashPath] = obj
+		return
+	}
+
+	cat.moveCollectionNoLock(irodsPath, trashPath)
+}
+
+// emptyTrash removes entries in the trash collection permanently, entries modified less than ageMinutes ago are kept
+func (cat *catalog) emptyTrash(collPath string, ageMinutes int) common.ErrorCode {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	if !cat.isTrashPath(collPath) {
+		return common.SYS_INVALID_INPUT_PARAM
+	}
+
+	if _, ok := cat.collections[collPath]; !ok {
+		return common.CAT_UNKNOWN_COLLECTION
+	}
+
+	cat.removeOldEntriesNoLock(collPath, "", true, false, ageMinutes)
+	return 0
+}
+
+// removeOldEntriesNoLock removes data objects in the collection modified before ageMinutes ago, then collections left empty
+// 0 ageMinutes removes all entries, trash homes are kept
+func (cat *catalog) removeOldEntriesNoLock(collPath string, username string, force bool, unregister bool, ageMinutes int) {
+	cutoff := time.Now().Add(-time.Duration(ageMinutes) * time.Minute)
+	isOld := func(modifyTime time.Time) bool {
+		return ageMinutes == 0 || modifyTime.Before(cutoff)
+	}
+
+	prefix := collPath + "/"
+	objPaths := []string{}
+	for objPath, obj := range cat.dataObjects {
+		if strings.HasPrefix(objPath, prefix) && isOld(obj.modifyTime) {
+			objPaths = append(objPaths, objPath)
+		}
+	}
+
+	for _, objPath := range objPaths {
+		cat.removeDataObjectNoLock(objPath, username, force, unregister)
+	}
+
+	// remove empty collections, children first
+	collPaths := []string{}
+	for childPath := range cat.collections {
+		if childPath == collPath || strings.HasPrefix(childPath, prefix) {
+			collPaths = append(collPaths, childPath)
+		}
+	}
+
+	sort.Slice(collPaths, func(i int, j int) bool {
+		return len(collPaths[i]) > len(collPaths[j])
+	})
+
+	for _, childPath := range collPaths {
+		if cat.isTrashHomePath(childPath) || cat.hasChildrenNoLock(childPath) || !isOld(cat.collections[childPath].modifyTime) {
+			continue
+		}
+
+		delete(cat.collections, childPath)
+	}
+}
+
+// registerDataObject registers data in the vault as a data object
+func (cat *catalog) registerDataObject(objPath string, owner string, physicalPath string) common.ErrorCode {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	data, ok := cat.vault[physicalPath]
+	if !ok {
+		return common.UNIX_FILE_STAT_ERR
+	}
+
+	errCode := cat.registerDataObjectNoLock(objPath, owner, physicalPath, data)
+	if errCode < 0 {
+		return errCode
+	}
+
+	delete(cat.vault, physicalPath)
+	return 0
+}
+
+// registerCollection registers data in the vault under the physical directory as a collection recursively
+func (cat *catalog) registerCollection(collPath string, owner string, physicalPath string) common.ErrorCode {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	if cat.existsNoLock(collPath) {
+		return common.CATALOG_ALREADY_HAS_ITEM_BY_THAT_NAME
+	}
+
+	if _, ok := cat.collections[util.GetIRODSPathDirname(collPath)]; !ok {
+		return common.CAT_UNKNOWN_COLLECTION
+	}
+
+	prefix := strings.TrimSuffix(physicalPath, "/") + "/"
+	physicalPaths := []string{}
+	for vaultPath := range cat.vault {
+		if strings.HasPrefix(vaultPath, prefix) {
+			physicalPaths = append(physicalPaths, vaultPath)
+		}
+	}
+
+	if len(physicalPaths) == 0 {
+		return common.UNIX_FILE_STAT_ERR
+	}
+
+	sort.Strings(physicalPaths)
+
+	cat.addCollectionNoLock(collPath, owner)
+	for _, vaultPath := range physicalPaths {
+		objPath := path.Join(collPath, strings.TrimPrefix(vaultPath, prefix))
+
+		// make parent collections
+		parentPath := util.GetIRODSPathDirname(objPath)
+		parentPaths := []string{}
+		for ; parentPath != collPath; parentPath = util.GetIRODSPathDirname(parentPath) {
+			parentPaths = append([]string{parentPath}, parentPaths...)
+		}
+
+		for _, parentPath := range parentPaths {
+			if _, ok := cat.collections[parentPath]; !ok {
+				cat.addCollectionNoLock(parentPath, owner)
+			}
+		}
+
+		errCode := cat.registerDataObjectNoLock(objPath, owner, vaultPath, cat.vault[vaultPath])
+		if errCode < 0 {
+			return errCode
+		}
+
+		delete(cat.vault, vaultPath)
+	}
+
+	return 0
+}
+
+// mountCollection marks a collection as a special collection, the collection is created if missing
+// contents of the source are not listed under the collection
+func (cat *catalog) mountCollection(collPath string, owner string, specialType string, source string, resource string) common.ErrorCode {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	coll, ok := cat.collections[collPath]
+	if ok {
+		if cat.hasChildrenNoLock(collPath) {
+			return common.CAT_COLLECTION_NOT_EMPTY
+		}
+	} else {
+		if _, ok := cat.dataObjects[collPath]; ok {
+			return common.CAT_NAME_EXISTS_AS_DATAOBJ
+		}
+
+		if _, ok := cat.collections[util.GetIRODSPathDirname(collPath)]; !ok {
+			return common.CAT_UNKNOWN_COLLECTION
+		}
+
+		coll = cat.addCollectionNoLock(collPath, owner)
+	}
+
+	if specialType == "tarStructFile" {
+		if _, ok := cat.dataObjects[source]; !ok {
+			return common.USER_FILE_DOES_NOT_EXIST
+		}
+	}
+
+	coll.specialType = specialType
+	coll.info1 = source
+	coll.info2 = resource
+	coll.modifyTime = time.Now()
+	return 0
+}
+
+// unmountCollection makes a special collection a normal collection
+func (cat *catalog) unmountCollection(collPath string) common.ErrorCode {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	coll, ok := cat.collections[collPath]
+	if !ok {
+		return common.CAT_UNKNOWN_COLLECTION
+	}
+
+	coll.specialType = ""
+	coll.info1 = ""
+	coll.info2 = ""
+	coll.modifyTime = time.Now()
+	return 0
+}
+
+func (cat *catalog) registerDataObjectNoLock(objPath string, owner string, physicalPath string, data []byte) common.ErrorCode {
+	if cat.existsNoLock(objPath) {
+		return common.CATALOG_ALREADY_HAS_ITEM_BY_THAT_NAME
+	}
+
+	if _, ok := cat.collections[util.GetIRODSPathDirname(objPath)]; !ok {
+		return common.CAT_UNKNOWN_COLLECTION
+	}
+
+	now := time.Now()
+	cat.dataObjects[objPath] = &catalogDataObject{
+		id:            cat.newID(),
+		path:          objPath,
+		owner:         owner,
+		data:          data,
+		physicalPath:  physicalPath,
+		dataType:      string(types.GENERIC_DT),
+		replicaStatus: string(types.ReplicaStatusGood),
+		createTime:    now,
+		modifyTime:    now,
+	}
+	return 0
+}
+
+// bundleStructFile creates a tar file of data objects in the source collection
+func (cat *catalog) bundleStructFile(tarPath string, sourceColl string, owner string, force bool, add bool) common.ErrorCode {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	if _, ok := cat.collections[sourceColl]; !ok {
+		return common.CAT_UNKNOWN_COLLECTION
+	}
+
+	if _, ok := cat.collections[util.GetIRODSPathDirname(tarPath)]; !ok {
+		return common.CAT_UNKNOWN_COLLECTION
+	}
+
+	entries := map[string][]byte{}
+
+	tarObj, ok := cat.dataObjects[tarPath]
+	if ok {
+		if !force && !add {
+			return common.OVERWRITE_WITHOUT_FORCE_FLAG
+		}
+
+		if add {
+			var errCode common.ErrorCode
+			entries, errCode = readTar(tarObj.data)
+			if errCode < 0 {
+				return errCode
+			}
+		}
+	}
+
+	prefix := sourceColl + "/"
+	for objPath, obj := range cat.dataObjects {
+		if strings.HasPrefix(objPath, prefix) && objPath != tarPath {
+			entries[strings.TrimPrefix(objPath, prefix)] = obj.data
+		}
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buffer := bytes.Buffer{}
+	tarWriter := tar.NewWriter(&buffer)
+	for _, name := range names {
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(entries[name])),
+			ModTime: time.Now(),
+		}
+
+		if tarWriter.WriteHeader(header) != nil {
+			return common.SYS_INTERNAL_ERR
+		}
+
+		if _, err := tarWriter.Write(entries[name]); err != nil {
+			return common.SYS_INTERNAL_ERR
+		}
+	}
+
+	if tarWriter.Close() != nil {
+		return common.SYS_INTERNAL_ERR
+	}
+
+	if tarObj == nil {
+		tarObj = &catalogDataObject{
+			id:            cat.newID(),
+			path:          tarPath,
+			owner:         owner,
+			replicaStatus: string(types.ReplicaStatusGood),
+			createTime:    time.Now(),
+		}
+		cat.dataObjects[tarPath] = tarObj
+	}
+
+	tarObj.data = buffer.Bytes()
+	tarObj.dataType = string(types.TAR_FILE_DT)
+	tarObj.modifyTime = time.Now()
+	return 0
+}
+
+// extractStructFile extracts a tar file to the target collection and registers its entries
+func (cat *catalog) extractStructFile(tarPath string, targetColl string, owner string, force bool) common.ErrorCode {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	tarObj, ok := cat.dataObjects[tarPath]
+	if !ok {
+		return common.CAT_NO_ROWS_FOUND
+	}
+
+	entries, errCode := readTar(tarObj.data)
+	if errCode < 0 {
+		return errCode
+	}
+
+	if _, ok := cat.collections[targetColl]; !ok {
+		if cat.existsNoLock(targetColl) {
+			return common.CAT_NAME_EXISTS_AS_DATAOBJ
+		}
+
+		if _, ok := cat.collections[util.GetIRODSPathDirname(targetColl)]; !ok {
+			return common.CAT_UNKNOWN_COLLECTION
+		}
+
+		cat.addCollectionNoLock(targetColl, owner)
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		if !force && cat.existsNoLock(path.Join(targetColl, name)) {
+			return common.CATALOG_ALREADY_HAS_ITEM_BY_THAT_NAME
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		objPath := path.Join(targetColl, name)
+
+		// make parent collections
+		parentPaths := []string{}
+		for parentPath := util.GetIRODSPathDirname(objPath); parentPath != targetColl; parentPath = util.GetIRODSPathDirname(parentPath) {
+			parentPaths = append([]string{parentPath}, parentPaths...)
+		}
+
+		for _, parentPath := range parentPaths {
+			if _, ok := cat.collections[parentPath]; !ok {
+				cat.addCollectionNoLock(parentPath, owner)
+			}
+		}
+
+		delete(cat.dataObjects, objPath)
+		errCode := cat.registerDataObjectNoLock(objPath, owner, "", entries[name])
+		if errCode < 0 {
+			return errCode
+		}
+	}
+
+	return 0
+}
+
+// readTar returns name-data mapping of files in the tar data
+// readSubStructFile returns data of a file inside a tar data object
+func (cat *catalog) readSubStructFile(tarPath string, subFilePath string) ([]byte, common.ErrorCode) {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	tarObj, ok := cat.dataObjects[tarPath]
+	if !ok {
+		return nil, common.CAT_NO_ROWS_FOUND
+	}
+
+	entries, errCode := readTar(tarObj.data)
+	if errCode < 0 {
+		return nil, common.SYS_OBJ_TYPE_NOT_STRUCT_FILE
+	}
+
+	data, ok := entries[path.Clean(subFilePath)]
+	if !ok {
+		return nil, common.UNIX_FILE_OPEN_ERR - common.ErrorCode(common.ENOENT)
+	}
+
+	return data, 0
+}
+
+func readTar(data []byte) (map[string][]byte, common.ErrorCode) {
+	entries := map[string][]byte{}
+
+	tarReader := tar.NewReader(bytes.NewReader(data))
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, common.SYS_INTERNAL_ERR
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		entryData, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, common.SYS_INTERNAL_ERR
+		}
+
+		entries[path.Clean(header.Name)] = entryData
+	}
+
+	return entries, 0
+}
+
+// getPhysicalPathNoLock returns the physical path of the data object
+func (cat *catalog) getPhysicalPathNoLock(obj *catalogDataObject) string {
+	if len(obj.physicalPath) > 0 {
+		return obj.physicalPath
+	}
+
+	return path.Join("/var/lib/irods/Vault", strings.TrimPrefix(obj.path, "/"+cat.zone))
+}
+
+// modifyDataObjectMeta modifies system metadata of the replica of a data object
+func (cat *catalog) modifyDataObjectMeta(objPath string, dataID int64, replicaNumber int, keyVals map[string]string) common.ErrorCode {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	obj, ok := cat.dataObjects[objPath]
+	if !ok || (dataID > 0 && obj.id != dataID) || replicaNumber != 0 {
+		return common.CAT_NO_ROWS_FOUND
+	}
+
+	if status, ok := keyVals[string(common.REPL_STATUS_KW)]; ok {
+		obj.replicaStatus = status
+	}
+
+	if dataType, ok := keyVals[string(common.DATA_TYPE_KW)]; ok {
+		obj.dataType = dataType
+	}
+
+	if modifyTime, ok := keyVals[string(common.DATA_MODIFY_KW)]; ok {
+		modifyTimeSec, err := strconv.ParseInt(modifyTime, 10, 64)
+		if err != nil {
+			return common.SYS_INVALID_INPUT_PARAM
+		}
+		obj.modifyTime = time.Unix(modifyTimeSec, 0)
+	}
+
+	return 0
+}
+
+// touch updates the modify time of a data object or a collection, creates an empty data object if missing
+func (cat *catalog) touch(irodsPath string, owner string, noCreate bool, replicaNumber *int, referencePath string, secondsSinceEpoch *int64) common.ErrorCode {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	modifyTime := time.Now()
+	if len(referencePath) > 0 {
+		if refObj, ok := cat.dataObjects[referencePath]; ok {
+			modifyTime = refObj.modifyTime
+		} else if refColl, ok := cat.collections[referencePath]; ok {
+			modifyTime = refColl.modifyTime
+		} else {
+			return common.OBJ_PATH_DOES_NOT_EXIST
+		}
+	} else if secondsSinceEpoch != nil {
+		modifyTime = time.Unix(*secondsSinceEpoch, 0)
+	}
+
+	if coll, ok := cat.collections[irodsPath]; ok {
+		coll.modifyTime = modifyTime
+		return 0
+	}
+
+	obj, ok := cat.dataObjects[irodsPath]
+	if !ok {
+		if noCreate {
+			return 0
+		}
+
+		if _, ok := cat.collections[util.GetIRODSPathDirname(irodsPath)]; !ok {
+			return common.CAT_UNKNOWN_COLLECTION
+		}
+
+		obj = &catalogDataObject{
+			id:            cat.newID(),
+			path:          irodsPath,
+			owner:         owner,
+			data:          []byte{},
+			dataType:      string(types.GENERIC_DT),
+			replicaStatus: string(types.ReplicaStatusGood),
+			createTime:    modifyTime,
+		}
+		cat.dataObjects[irodsPath] = obj
+	} else if replicaNumber != nil && *replicaNumber != 0 {
+		return common.SYS_REPLICA_DOES_NOT_EXIST
+	}
+
+	obj.modifyTime = modifyTime
+	return 0
+}
+
+// renameDataObject renames a data object, an existing dest data object is replaced only if force is set
+func (cat *catalog) renameDataObject(srcPath string, destPath string, force bool) common.ErrorCode {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	obj, ok := cat.dataObjects[srcPath]
+	if !ok {
+		return common.CAT_NO_ROWS_FOUND
+	}
+
+	if _, ok := cat.dataObjects[destPath]; ok && force {
+		if srcPath == destPath {
+			return common.SAME_SRC_DEST_PATHS_ERR
+		}
+		delete(cat.dataObjects, destPath)
+	}
+
+	if cat.existsNoLock(destPath) {
+		return common.CATALOG_ALREADY_HAS_ITEM_BY_THAT_NAME
+	}
+
+	if _, ok := cat.collections[util.GetIRODSPathDirname(destPath)]; !ok {
+		return common.CAT_UNKNOWN_COLLECTION
+	}
+
+	delete(cat.dataObjects, srcPath)
+	obj.path = destPath
+	cat.dataObjects[destPath] = obj
+	return 0
+}
+
+// copyDataObject copies a data object, an existing dest data object is overwritten only if force is set
+func (cat *catalog) copyDataObject(srcPath string, destPath string, owner string, force bool) common.ErrorCode {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	srcObj, ok := cat.dataObjects[srcPath]
+	if !ok {
+		return common.CAT_NO_ROWS_FOUND
+	}
+
+	if _, ok := cat.collections[destPath]; ok {
+		return common.CAT_NAME_EXISTS_AS_COLLECTION
+	}
+
+	if _, ok := cat.collections[util.GetIRODSPathDirname(destPath)]; !ok {
+		return common.CAT_UNKNOWN_COLLECTION
+	}
+
+	data := make([]byte, len(srcObj.data))
+	copy(data, srcObj.data)
+
+	now := time.Now()
+	if destObj, ok := cat.dataObjects[destPath]; ok {
+		if !force {
+			return common.OVERWRITE_WITHOUT_FORCE_FLAG
+		}
+
+		destObj.data = data
+		destObj.dataType = srcObj.dataType
+		destObj.modifyTime = now
+		return 0
+	}
+
+	cat.dataObjects[destPath] = &catalogDataObject{
+		id:            cat.newID(),
+		path:          destPath,
+		owner:         owner,
+		data:          data,
+		dataType:      srcObj.dataType,
+		replicaStatus: string(types.ReplicaStatusGood),
+		createTime:    now,
+		modifyTime:    now,
+	}
+	return 0
+}
+
+func (cat *catalog) truncateDataObject(objPath string, size int64) common.ErrorCode {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	obj, ok := cat.dataObjects[objPath]
+	if !ok {
+		return common.CAT_NO_ROWS_FOUND
+	}
+
+	obj.truncateNoLock(size)
+	return 0
+}
+
+func (obj *catalogDataObject) truncateNoLock(size int64) {
+	if size < int64(len(obj.data)) {
+		obj.data = obj.data[:size]
+	} else if size > int64(len(obj.data)) {
+		obj.data = append(obj.data, make([]byte, size-int64(len(obj.data)))...)
+	}
+
+	obj.modifyTime = time.Now()
+}
+
+func (cat *catalog) readDataObject(obj *catalogDataObject, offset int64, length int) []byte {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	if offset >= int64(len(obj.data)) {
+		return []byte{}
+	}
+
+	end := offset + int64(length)
+	if end > int64(len(obj.data)) {
+		end = int64(len(obj.data))
+	}
+
+	data := make([]byte, end-offset)
+	copy(data, obj.data[offset:end])
+	return data
+}
+
+func (cat *catalog) writeDataObject(obj *catalogDataObject, offset int64, data []byte) {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	end := offset + int64(len(data))
+	if end > int64(len(obj.data)) {
+		obj.truncateNoLock(end)
+	}
+
+	copy(obj.data[offset:end], data)
+	obj.modifyTime = time.Now()
+}
+
+// getDataObjectChecksum returns the SHA256 checksum string of the data object, checksums are not registered
+func (cat *catalog) getDataObjectChecksum(irodsPath string) (string, common.ErrorCode) {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	obj, ok := cat.dataObjects[irodsPath]
+	if !ok {
+		return "", common.CAT_NO_ROWS_FOUND
+	}
+
+	hash := sha256.Sum256(obj.data)
+	checksum, err := types.MakeIRODSChecksumString(types.ChecksumAlgorithmSHA256, hash[:])
+	if err != nil {
+		return "", common.SYS_INTERNAL_ERR
+	}
+
+	return checksum, 0
+}
+
+// physicalMoveDataObject moves the replica of the data object to the resource, resources can be hierarchies
+// the catalog has a single resource, so the replica can only be moved to the resource it is already in
+func (cat *catalog) physicalMoveDataObject(objPath string, replicaNumber int, srcResource string, destResource string) common.ErrorCode {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	if _, ok := cat.dataObjects[objPath]; !ok {
+		return common.CAT_NO_ROWS_FOUND
+	}
+
+	// there is only one replica
+	if replicaNumber > 0 {
+		return common.SYS_REPLICA_DOES_NOT_EXIST
+	}
+
+	for _, resource := range []string{srcResource, destResource} {
+		if len(resource) > 0 && resource != cat.resource {
+			return common.SYS_RESC_DOES_NOT_EXIST
+		}
+	}
+	return 0
+}
+
+// addStaleReplica adds a stale replica of the given size to the data object
+func (cat *catalog) addStaleReplica(objPath string, size int64) common.ErrorCode {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	obj, ok := cat.dataObjects[objPath]
+	if !ok {
+		return common.CAT_NO_ROWS_FOUND
+	}
+
+	obj.staleReplicas = append(obj.staleReplicas, size)
+	return 0
+}
+
+func (cat *catalog) getDataObjectSize(obj *catalogDataObject) int64 {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	return int64(len(obj.data))
+}
+
+// formatTime returns iRODS time string
+func formatTime(t time.Time) string {
+	return fmt.Sprintf("%011d", t.Unix())
+}
+
+// getCollectionRowsNoLock returns rows of collection columns
+func (cat *catalog) getCollectionRowsNoLock() []queryRow {
+	rows := make([]queryRow, 0, len(cat.collections))
+	for _, coll := range cat.collections {
+		rows = append(rows, cat.makeCollectionRow(coll, queryRow{}))
+	}
+
+	sortRows(rows, common.ICAT_COLUMN_COLL_NAME)
+	return rows
+}
+
+// getResourceRowsNoLock returns rows of resource columns, the catalog has a single unixfilesystem resource
+func (cat *catalog) getResourceRowsNoLock() []queryRow {
+	return []queryRow{
+		{
+			common.ICAT_COLUMN_R_RESC_ID:      "10000",
+			common.ICAT_COLUMN_R_RESC_NAME:    cat.resource,
+			common.ICAT_COLUMN_R_ZONE_NAME:    cat.zone,
+			common.ICAT_COLUMN_R_TYPE_NAME:    "unixfilesystem",
+			common.ICAT_COLUMN_R_CLASS_NAME:   "cache",
+			common.ICAT_COLUMN_R_LOC:          "localhost",
+			common.ICAT_COLUMN_R_VAULT_PATH:   "/var/lib/irods/Vault",
+			common.ICAT_COLUMN_R_RESC_CONTEXT: "",
+			common.ICAT_COLUMN_R_RESC_PARENT:  "",
+			common.ICAT_COLUMN_R_CREATE_TIME:  formatTime(time.Unix(0, 0)),
+			common.ICAT_COLUMN_R_MODIFY_TIME:  formatTime(time.Unix(0, 0)),
+		},
+	}
+}
+
+// getDataObjectRowsNoLock returns rows of data object columns joined with collection columns
+func (cat *catalog) getDataObjectRowsNoLock() []queryRow {
+	rows := make([]queryRow, 0, len(cat.dataObjects))
+	for _, obj := range cat.dataObjects {
+		row := queryRow{
+			common.ICAT_COLUMN_D_DATA_ID:       fmt.Sprintf("%d", obj.id),
+			common.ICAT_COLUMN_DATA_NAME:       util.GetIRODSPathFileName(obj.path),
+			common.ICAT_COLUMN_DATA_REPL_NUM:   "0",
+			common.ICAT_COLUMN_DATA_VERSION:    "",
+			common.ICAT_COLUMN_DATA_TYPE_NAME:  obj.dataType,
+			common.ICAT_COLUMN_DATA_SIZE:       fmt.Sprintf("%d", len(obj.data)),
+			common.ICAT_COLUMN_D_RESC_NAME:     cat.resource,
+			common.ICAT_COLUMN_D_DATA_PATH:     cat.getPhysicalPathNoLock(obj),
+			common.ICAT_COLUMN_D_OWNER_NAME:    obj.owner,
+			common.ICAT_COLUMN_D_OWNER_ZONE:    cat.zone,
+			common.ICAT_COLUMN_D_REPL_STATUS:   obj.replicaStatus,
+			common.ICAT_COLUMN_D_DATA_STATUS:   "",
+			common.ICAT_COLUMN_D_DATA_CHECKSUM: "",
+			common.ICAT_COLUMN_D_CREATE_TIME:   formatTime(obj.createTime),
+			common.ICAT_COLUMN_D_MODIFY_TIME:   formatTime(obj.modifyTime),
+			common.ICAT_COLUMN_D_ACCESS_TIME:   formatTime(obj.modifyTime),
+			common.ICAT_COLUMN_D_RESC_HIER:     cat.resource,
+			common.ICAT_COLUMN_D_RESC_ID:       "10000",
+		}
+
+		if coll, ok := cat.collections[util.GetIRODSPathDirname(obj.path)]; ok {
+			row[common.ICAT_COLUMN_D_COLL_ID] = fmt.Sprintf("%d", coll.id)
+			row = cat.makeCollectionRow(coll, row)
+		}
+
+		rows = append(rows, row)
+
+		for idx, size := range obj.staleReplicas {
+			staleRow := queryRow{}
+			for column, value := range row {
+				staleRow[column] = value
+			}
+
+			staleRow[common.ICAT_COLUMN_DATA_REPL_NUM] = fmt.Sprintf("%d", idx+1)
+			staleRow[common.ICAT_COLUMN_DATA_SIZE] = fmt.Sprintf("%d", size)
+			staleRow[common.ICAT_COLUMN_D_REPL_STATUS] = string(types.ReplicaStatusStale)
+			rows = append(rows, staleRow)
+		}
+	}
+
+	sortRows(rows, common.ICAT_COLUMN_COLL_NAME, common.ICAT_COLUMN_DATA_NAME, common.ICAT_COLUMN_DATA_REPL_NUM)
+	return rows
+}
+
+func (cat *catalog) makeCollectionRow(coll *catalogCollection, row queryRow) queryRow {
+	parentPath := ""
+	if coll.path != "/" {
+		parentPath = util.GetIRODSPathDirname(coll.path)
+	}
+
+	row[common.ICAT_COLUMN_COLL_ID] = fmt.Sprintf("%d", coll.id)
+	row[common.ICAT_COLUMN_COLL_NAME] = coll.path
+	row[common.ICAT_COLUMN_COLL_PARENT_NAME] = parentPath
+	row[common.ICAT_COLUMN_COLL_OWNER_NAME] = coll.owner
+	row[common.ICAT_COLUMN_COLL_OWNER_ZONE] = cat.zone
+	row[common.ICAT_COLUMN_COLL_INHERITANCE] = ""
+	row[common.ICAT_COLUMN_COLL_CREATE_TIME] = formatTime(coll.createTime)
+	row[common.ICAT_COLUMN_COLL_MODIFY_TIME] = formatTime(coll.modifyTime)
+	row[common.ICAT_COLUMN_COLL_TYPE] = coll.specialType
+	row[common.ICAT_COLUMN_COLL_INFO1] = coll.info1
+	row[common.ICAT_COLUMN_COLL_INFO2] = coll.info2
+	return row
+}
+
+func sortRows(rows []queryRow, keys ...common.ICATColumnNumber) {
+	sort.SliceStable(rows, func(i int, j int) bool {
+		for _, key := range keys {
+			if rows[i][key] != rows[j][key] {
+				return rows[i][key] < rows[j][key]
+			}
+		}
+		return false
+	})
+}
@@ -0,0 +1,216 @@
+package testserver
+
+import (
+	"encoding/xml"
+	"strconv"
+	"sync"
+
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+const (
+	// flock types returned for getLockCmd
+	flockTypeRead   int = 0
+	flockTypeWrite  int = 1
+	flockTypeUnlock int = 2
+)
+
+// lockOwner is a lock descriptor of a session
+type lockOwner struct {
+	session *serverSession
+	fd      int
+}
+
+// lockTable keeps advisory data object locks, locks of a session do not conflict with each other like fcntl locks of a process
+type lockTable struct {
+	locks    map[string]map[lockOwner]types.DataObjectLockType // path -> owner -> lock type
+	released map[*serverSession]bool                           // closed sessions, waiters of them give up
+	mutex    sync.Mutex
+	cond     *sync.Cond
+}
+
+func newLockTable() *lockTable {
+	table := &lockTable{
+		locks:    map[string]map[lockOwner]types.DataObjectLockType{},
+		released: map[*serverSession]bool{},
+	}
+	table.cond = sync.NewCond(&table.mutex)
+	return table
+}
+
+// getConflictNoLock returns the type of a lock held by other sessions that conflicts with the lock type, returns DataObjectLockTypeUnlock if there is no conflict
+func (table *lockTable) getConflictNoLock(sess *serverSession, objPath string, lockType types.DataObjectLockType) types.DataObjectLockType {
+	conflict := types.DataObjectLockTypeUnlock
+	for owner, ownerLockType := range table.locks[objPath] {
+		if owner.session == sess {
+			continue
+		}
+
+		if ownerLockType == types.DataObjectLockTypeWrite {
+			return types.DataObjectLockTypeWrite
+		}
+
+		if lockType == types.DataObjectLockTypeWrite {
+			conflict = ownerLockType
+		}
+	}
+	return conflict
+}
+
+// lock places a lock, waits until conflicting locks are released if wait is true
+func (table *lockTable) lock(sess *serverSession, fd int, objPath string, lockType types.DataObjectLockType, wait bool) common.ErrorCode {
+	table.mutex.Lock()
+	defer table.mutex.Unlock()
+
+	for table.getConflictNoLock(sess, objPath, lockType) != types.DataObjectLockTypeUnlock {
+		if !wait {
+			return common.SYS_FS_LOCK_ERR - common.ErrorCode(common.EAGAIN)
+		}
+
+		if table.released[sess] {
+			return common.SYS_FS_LOCK_ERR
+		}
+
+		table.cond.Wait()
+	}
+
+	if _, ok := table.locks[objPath]; !ok {
+		table.locks[objPath] = map[lockOwner]types.DataObjectLockType{}
+	}
+
+	table.locks[objPath][lockOwner{session: sess, fd: fd}] = lockType
+	return 0
+}
+
+// getLockType returns flock type of a lock conflicting with a write lock of the session
+func (table *lockTable) getLockType(sess *serverSession, objPath string) int {
+	table.mutex.Lock()
+	defer table.mutex.Unlock()
+
+	switch table.getConflictNoLock(sess, objPath, types.DataObjectLockTypeWrite) {
+	case types.DataObjectLockTypeRead:
+		return flockTypeRead
+	case types.DataObjectLockTypeWrite:
+		return flockTypeWrite
+	default:
+		return flockTypeUnlock
+	}
+}
+
+// unlock releases a lock of the session
+func (table *lockTable) unlock(sess *serverSession, fd int) bool {
+	table.mutex.Lock()
+	defer table.mutex.Unlock()
+
+	owner := lockOwner{session: sess, fd: fd}
+	for objPath, owners := range table.locks {
+		if _, ok := owners[owner]; ok {
+			delete(owners, owner)
+			if len(owners) == 0 {
+				delete(table.locks, objPath)
+			}
+
+			table.cond.Broadcast()
+			return true
+		}
+	}
+
+	return false
+}
+
+// releaseSession releases all locks of the session and wakes up its waiters
+func (table *lockTable) releaseSession(sess *serverSession) {
+	table.mutex.Lock()
+	defer table.mutex.Unlock()
+
+	for objPath, owners := range table.locks {
+		for owner := range owners {
+			if owner.session == sess {
+				delete(owners, owner)
+			}
+		}
+
+		if len(owners) == 0 {
+			delete(table.locks, objPath)
+		}
+	}
+
+	table.released[sess] = true
+	table.cond.Broadcast()
+}
+
+// forgetSession forgets the closed session after it stops serving
+func (table *lockTable) forgetSession(sess *serverSession) {
+	table.mutex.Lock()
+	defer table.mutex.Unlock()
+
+	delete(table.released, sess)
+}
+
+func (cat *catalog) hasDataObject(objPath string) bool {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	_, ok := cat.dataObjects[objPath]
+	return ok
+}
+
+func (sess *serverSession) handleLockDataObject(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageDataObjectRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	if !sess.server.catalog.hasDataObject(request.Path) {
+		return sess.replyError(common.CAT_NO_ROWS_FOUND)
+	}
+
+	lockType, _ := getKeyVal(&request.KeyVals, common.LOCK_TYPE_KW)
+	switch types.DataObjectLockType(lockType) {
+	case types.DataObjectLockTypeRead, types.DataObjectLockTypeWrite:
+	default:
+		return sess.replyError(common.SYS_LOCK_TYPE_INP_ERR)
+	}
+
+	lockCommand, _ := getKeyVal(&request.KeyVals, common.LOCK_CMD_KW)
+	switch types.DataObjectLockCommand(lockCommand) {
+	case types.DataObjectLockCommandGetLock:
+		return sess.reply(int32(sess.server.locks.getLockType(sess, request.Path)), nil, nil)
+	case types.DataObjectLockCommandSetLock, types.DataObjectLockCommandSetLockWait:
+		fd := sess.nextFileDescriptor
+		sess.nextFileDescriptor++
+
+		wait := types.DataObjectLockCommand(lockCommand) == types.DataObjectLockCommandSetLockWait
+		errCode := sess.server.locks.lock(sess, fd, request.Path, types.DataObjectLockType(lockType), wait)
+		if errCode < 0 {
+			return sess.replyError(errCode)
+		}
+
+		return sess.reply(int32(fd), nil, nil)
+	default:
+		return sess.replyError(common.SYS_LOCK_CMD_INP_ERR)
+	}
+}
+
+func (sess *serverSession) handleUnlockDataObject(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageDataObjectRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	lockFD, _ := getKeyVal(&request.KeyVals, common.LOCK_FD_KW)
+	fd, err := strconv.Atoi(lockFD)
+	if err != nil {
+		return sess.replyError(common.SYS_INVALID_INPUT_PARAM)
+	}
+
+	if !sess.server.locks.unlock(sess, fd) {
+		return sess.replyError(common.SYS_BAD_FILE_DESCRIPTOR)
+	}
+
+	return sess.replyError(0)
+}
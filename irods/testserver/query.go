@@ -0,0 +1,461 @@
+package testserver
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/message"
+)
+
+// queryRow is a row of columns
+type queryRow map[common.ICATColumnNumber]string
+
+// queryResponse is GenQueryOut_PI, message.IRODSMessageQueryResponse omits empty values so it cannot be used to send results
+type queryResponse struct {
+	XMLName        xml.Name         `xml:"GenQueryOut_PI"`
+	RowCount       int              `xml:"rowCnt"`
+	AttributeCount int              `xml:"attriCnt"`
+	ContinueIndex  int              `xml:"continueInx"`
+	TotalRowCount  int              `xml:"totalRowCount"`
+	SQLResult      []querySQLResult `xml:"SqlResult_PI"`
+}
+
+// querySQLResult is SqlResult_PI
+type querySQLResult struct {
+	AttributeIndex int      `xml:"attriInx"`
+	ResultLen      int      `xml:"reslen"`
+	Values         []string `xml:"value"`
+}
+
+// queryCondition is a condition parsed from GenQuery
+type queryCondition struct {
+	column   common.ICATColumnNumber
+	operator string
+	values   []string
+}
+
+var (
+	conditionRegex = regexp.MustCompile(`(?is)^\s*(=|<>|!=|>=|<=|>|<|not\s+like|like|not\s+in|in)\s*(.*)$`)
+	quotedRegex    = regexp.MustCompile(`'([^']*)'`)
+)
+
+func isResourceColumn(column common.ICATColumnNumber) bool {
+	return column >= common.ICAT_COLUMN_R_RESC_ID && column <= common.ICAT_COLUMN_R_RESC_PARENT_CONTEXT
+}
+
+func isDataObjectColumn(column common.ICATColumnNumber) bool {
+	return column >= common.ICAT_COLUMN_D_DATA_ID && column < common.ICAT_COLUMN_COLL_ID
+}
+
+func isCollectionColumn(column common.ICATColumnNumber) bool {
+	return column >= common.ICAT_COLUMN_COLL_ID && column <= common.ICAT_COLUMN_COLL_INFO2
+}
+
+// parseQueryCondition parses a condition value, e.g., "= 'abc'", "like '/zone/%'" or "in ('a', 'b')"
+func parseQueryCondition(column common.ICATColumnNumber, condition string) (*queryCondition, bool) {
+	// values are escaped by clients
+	condition = html.UnescapeString(condition)
+
+	matches := conditionRegex.FindStringSubmatch(condition)
+	if matches == nil {
+		return nil, false
+	}
+
+	operator := strings.ToLower(strings.Join(strings.Fields(matches[1]), " "))
+
+	values := []string{}
+	if operator == "in" || operator == "not in" {
+		// like iRODS, values in a list are split at quotes, quotes cannot be escaped
+		for _, quoted := range quotedRegex.FindAllStringSubmatch(matches[2], -1) {
+			values = append(values, quoted[1])
+		}
+	} else {
+		// like iRODS, the value bound is everything between the first and the last quotes, so it may have quotes
+		// quotes are not unescaped, "''" is bound as is
+		firstQuote := strings.Index(matches[2], "'")
+		lastQuote := strings.LastIndex(matches[2], "'")
+		if firstQuote >= 0 && lastQuote > firstQuote {
+			values = append(values, matches[2][firstQuote+1:lastQuote])
+		}
+	}
+
+	if len(values) == 0 {
+		// unquoted value
+		values = append(values, strings.TrimSpace(matches[2]))
+	}
+
+	return &queryCondition{
+		column:   column,
+		operator: operator,
+		values:   values,
+	}, true
+}
+
+// likeToRegex converts SQL LIKE pattern to regex
+func likeToRegex(pattern string) *regexp.Regexp {
+	sb := strings.Builder{}
+	sb.WriteString("(?s)^")
+
+	escaped := false
+	for _, r := range pattern {
+		if escaped {
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+			continue
+		}
+
+		switch r {
+		case '\\':
+			escaped = true
+		case '%':
+			sb.WriteString(".*")
+		case '_':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
+
+func compareValues(a string, b string) int {
+	ai, aErr := strconv.ParseInt(a, 10, 64)
+	bi, bErr := strconv.ParseInt(b, 10, 64)
+	if aErr == nil && bErr == nil {
+		switch {
+		case ai < bi:
+			return -1
+		case ai > bi:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return strings.Compare(a, b)
+}
+
+// match returns true if the row matches the condition, the column value is upper-cased if upperCase is true
+func (condition *queryCondition) match(row queryRow, upperCase bool) bool {
+	value, ok := row[condition.column]
+	if !ok {
+		return false
+	}
+
+	if upperCase {
+		value = strings.ToUpper(value)
+	}
+
+	switch condition.operator {
+	case "=":
+		return value == condition.values[0]
+	case "<>", "!=":
+		return value != condition.values[0]
+	case ">":
+		return compareValues(value, condition.values[0]) > 0
+	case ">=":
+		return compareValues(value, condition.values[0]) >= 0
+	case "<":
+		return compareValues(value, condition.values[0]) < 0
+	case "<=":
+		return compareValues(value, condition.values[0]) <= 0
+	case "like":
+		return likeToRegex(condition.values[0]).MatchString(value)
+	case "not like":
+		return !likeToRegex(condition.values[0]).MatchString(value)
+	case "in", "not in":
+		found := false
+		for _, v := range condition.values {
+			if value == v {
+				found = true
+				break
+			}
+		}
+		return found == (condition.operator == "in")
+	default:
+		return false
+	}
+}
+
+// runQuery runs GenQuery against the catalog
+// only collection, data object and resource columns are supported, queries with other columns return no rows
+func (cat *catalog) runQuery(request *message.IRODSMessageQueryRequest) (*queryResponse, common.ErrorCode) {
+	if request.MaxRows <= 0 {
+		// closing a query
+		return &queryResponse{}, 0
+	}
+
+	selects := make([]common.ICATColumnNumber, len(request.Selects.Keys))
+	selectFuncs := make([]int, len(request.Selects.Keys))
+	orderFlags := make([]int, len(request.Selects.Keys))
+	for idx, key := range request.Selects.Keys {
+		selects[idx] = common.ICATColumnNumber(key)
+		if idx < len(request.Selects.Values) {
+			// order flags are combined with select functions
+			orderMask := int(common.ICAT_SELECT_ORDER_BY | common.ICAT_SELECT_ORDER_BY_DESC)
+			selectFuncs[idx] = request.Selects.Values[idx] &^ orderMask
+			orderFlags[idx] = request.Selects.Values[idx] & orderMask
+		}
+	}
+
+	conditions := []*queryCondition{}
+	for idx, key := range request.Conditions.Keys {
+		if idx >= len(request.Conditions.Values) {
+			break
+		}
+
+		condition, ok := parseQueryCondition(common.ICATColumnNumber(key), request.Conditions.Values[idx].Value)
+		if !ok {
+			return nil, common.CAT_INVALID_ARGUMENT
+		}
+		conditions = append(conditions, condition)
+	}
+
+	// determine a table to look up
+	hasDataObjectColumn := false
+	hasCollectionColumn := false
+	hasResourceColumn := false
+	for _, column := range append(selects, conditionColumns(conditions)...) {
+		if isDataObjectColumn(column) {
+			hasDataObjectColumn = true
+		} else if isCollectionColumn(column) {
+			hasCollectionColumn = true
+		} else if isResourceColumn(column) {
+			hasResourceColumn = true
+		} else {
+			// unsupported
+			return nil, common.CAT_NO_ROWS_FOUND
+		}
+	}
+
+	if hasResourceColumn && (hasDataObjectColumn || hasCollectionColumn) {
+		// resource columns are not joined with other tables
+		return nil, common.CAT_NO_ROWS_FOUND
+	}
+
+	cat.mutex.Lock()
+	var rows []queryRow
+	if hasResourceColumn {
+		rows = cat.getResourceRowsNoLock()
+	} else if hasDataObjectColumn {
+		rows = cat.getDataObjectRowsNoLock()
+	} else {
+		rows = cat.getCollectionRowsNoLock()
+	}
+	cat.mutex.Unlock()
+
+	// filter
+	upperCase := request.HasOption(common.ICAT_QUERY_OPTION_UPPER_CASE_WHERE)
+	matchingRows := []queryRow{}
+	for _, row := range rows {
+		matched := true
+		for _, condition := range conditions {
+			if !condition.match(row, upperCase) {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			matchingRows = append(matchingRows, row)
+		}
+	}
+
+	orderRows(matchingRows, selects, orderFlags)
+
+	results := projectRows(matchingRows, selects, selectFuncs, !request.HasOption(common.ICAT_QUERY_OPTION_NO_DISTINCT))
+
+	// paging, continue index is used as an offset
+	offset := request.ContinueIndex
+	if offset > len(results) {
+		offset = len(results)
+	}
+
+	end := offset + request.MaxRows
+	continueIndex := end
+	if end >= len(results) {
+		end = len(results)
+		continueIndex = 0
+	}
+
+	page := results[offset:end]
+	if len(page) == 0 {
+		return nil, common.CAT_NO_ROWS_FOUND
+	}
+
+	response := &queryResponse{
+		RowCount:       len(page),
+		AttributeCount: len(selects),
+		ContinueIndex:  continueIndex,
+		TotalRowCount:  len(results),
+		SQLResult:      make([]querySQLResult, len(selects)),
+	}
+
+	for col, column := range selects {
+		values := make([]string, len(page))
+		resultLen := 1
+		for row := range page {
+			values[row] = page[row][col]
+			if len(values[row])+1 > resultLen {
+				resultLen = len(values[row]) + 1
+			}
+		}
+
+		response.SQLResult[col] = querySQLResult{
+			AttributeIndex: int(column),
+			ResultLen:      resultLen,
+			Values:         values,
+		}
+	}
+
+	return response, 0
+}
+
+func conditionColumns(conditions []*queryCondition) []common.ICATColumnNumber {
+	columns := make([]common.ICATColumnNumber, len(conditions))
+	for idx, condition := range conditions {
+		columns[idx] = condition.column
+	}
+	return columns
+}
+
+// orderRows sorts rows by selected columns having order flags, in the order of selects
+func orderRows(rows []queryRow, selects []common.ICATColumnNumber, orderFlags []int) {
+	hasOrder := false
+	for _, flag := range orderFlags {
+		if flag != 0 {
+			hasOrder = true
+			break
+		}
+	}
+
+	if !hasOrder {
+		return
+	}
+
+	sort.SliceStable(rows, func(i int, j int) bool {
+		for idx, column := range selects {
+			if orderFlags[idx] == 0 {
+				continue
+			}
+
+			cmp := compareValues(rows[i][column], rows[j][column])
+			if orderFlags[idx]&int(common.ICAT_SELECT_ORDER_BY_DESC) != 0 {
+				cmp = -cmp
+			}
+
+			if cmp != 0 {
+				return cmp < 0
+			}
+		}
+		return false
+	})
+}
+
+// projectRows selects columns from rows, applies aggregation functions and removes duplicates if distinct is true like iRODS does
+// with aggregation functions, rows are grouped by the other selected columns
+func projectRows(rows []queryRow, selects []common.ICATColumnNumber, selectFuncs []int, distinct bool) [][]string {
+	hasAggregation := false
+	for _, selectFunc := range selectFuncs {
+		if common.ICATSelectFunction(selectFunc).IsAggregate() {
+			hasAggregation = true
+			break
+		}
+	}
+
+	if hasAggregation {
+		groupKeys := []string{}
+		groups := map[string][]queryRow{}
+		for _, row := range rows {
+			keyValues := []string{}
+			for idx, column := range selects {
+				if !common.ICATSelectFunction(selectFuncs[idx]).IsAggregate() {
+					keyValues = append(keyValues, row[column])
+				}
+			}
+
+			key := strings.Join(keyValues, "\x00")
+			if _, ok := groups[key]; !ok {
+				groupKeys = append(groupKeys, key)
+			}
+			groups[key] = append(groups[key], row)
+		}
+
+		results := [][]string{}
+		for _, key := range groupKeys {
+			groupRows := groups[key]
+
+			result := make([]string, len(selects))
+			for idx, column := range selects {
+				result[idx] = aggregateValues(groupRows, column, selectFuncs[idx])
+			}
+
+			results = append(results, result)
+		}
+
+		return results
+	}
+
+	results := [][]string{}
+	seen := map[string]bool{}
+	for _, row := range rows {
+		result := make([]string, len(selects))
+		for idx, column := range selects {
+			result[idx] = row[column]
+		}
+
+		if distinct {
+			key := strings.Join(result, "\x00")
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// aggregateValues applies the select function to the column of rows, non-aggregation functions return the first value
+func aggregateValues(rows []queryRow, column common.ICATColumnNumber, selectFunc int) string {
+	switch common.ICATSelectFunction(selectFunc) {
+	case common.ICAT_SELECT_FUNC_COUNT:
+		return fmt.Sprintf("%d", len(rows))
+	case common.ICAT_SELECT_FUNC_SUM:
+		sum := int64(0)
+		for _, row := range rows {
+			v, _ := strconv.ParseInt(row[column], 10, 64)
+			sum += v
+		}
+		return fmt.Sprintf("%d", sum)
+	case common.ICAT_SELECT_FUNC_AVG:
+		sum := float64(0)
+		for _, row := range rows {
+			v, _ := strconv.ParseFloat(row[column], 64)
+			sum += v
+		}
+		return strconv.FormatFloat(sum/float64(len(rows)), 'f', -1, 64)
+	case common.ICAT_SELECT_FUNC_MIN, common.ICAT_SELECT_FUNC_MAX:
+		value := rows[0][column]
+		for _, row := range rows[1:] {
+			cmp := compareValues(row[column], value)
+			if (selectFunc == int(common.ICAT_SELECT_FUNC_MIN) && cmp < 0) || (selectFunc == int(common.ICAT_SELECT_FUNC_MAX) && cmp > 0) {
+				value = row[column]
+			}
+		}
+		return value
+	default:
+		return rows[0][column]
+	}
+}
@@ -0,0 +1,105 @@
+package testserver
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/message"
+)
+
+// addReplicaToken issues a replica token for the data object opened by a client
+func (cat *catalog) addReplicaToken(objPath string) (string, error) {
+	tokenBytes := make([]byte, 16)
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to generate replica token")
+	}
+
+	token := hex.EncodeToString(tokenBytes)
+
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	cat.replicaTokens[token] = objPath
+	return token, nil
+}
+
+// hasReplicaToken returns true if the replica token is issued for the data object and its handle is still open
+func (cat *catalog) hasReplicaToken(objPath string, token string) bool {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	tokenPath, ok := cat.replicaTokens[token]
+	return ok && tokenPath == objPath
+}
+
+func (cat *catalog) removeReplicaToken(token string) {
+	cat.mutex.Lock()
+	defer cat.mutex.Unlock()
+
+	delete(cat.replicaTokens, token)
+}
+
+func (sess *serverSession) handleGetDescriptorInfo(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageGetDescriptorInfoRequest{}
+	err := request.FromBytes(msg.Body.Message)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	fd, ok := sess.fileDescriptors[request.FileDescriptor]
+	if !ok {
+		return sess.replyError(common.SYS_BAD_FILE_DESCRIPTOR)
+	}
+
+	if len(fd.replicaToken) == 0 {
+		fd.replicaToken, err = sess.server.catalog.addReplicaToken(fd.dataObject.path)
+		if err != nil {
+			return err
+		}
+	}
+
+	info := map[string]interface{}{
+		"l3descInx": request.FileDescriptor,
+		"in_use":    true,
+		"open_type": fd.flags,
+		"data_size": sess.server.catalog.getDataObjectSize(fd.dataObject),
+		"data_object_info": map[string]interface{}{
+			"object_path":        fd.dataObject.path,
+			"resource_hierarchy": sess.server.config.Resource,
+		},
+		"replica_token": fd.replicaToken,
+	}
+
+	infoBytes, err := json.Marshal(info)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal descriptor info")
+	}
+
+	response := message.IRODSMessageBinBytesBuf{
+		Length: len(infoBytes),
+		Data:   base64.StdEncoding.EncodeToString(infoBytes),
+	}
+
+	return sess.reply(0, &response, nil)
+}
+
+func (sess *serverSession) handleCloseDataObjectReplica(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageCloseDataObjectReplicaRequest{}
+	err := request.FromBytes(msg.Body.Message)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	if _, ok := sess.fileDescriptors[request.FileDescriptor]; !ok {
+		return sess.replyError(common.SYS_BAD_FILE_DESCRIPTOR)
+	}
+
+	// closing a replica does not finalize the data object, so the token stays valid
+	delete(sess.fileDescriptors, request.FileDescriptor)
+	return sess.replyError(0)
+}
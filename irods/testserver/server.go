@@ -0,0 +1,341 @@
+package testserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	ZoneDefault           string = "tempZone"
+	ResourceDefault       string = "demoResc"
+	AdminUserDefault      string = "rods"
+	AdminPasswordDefault  string = "rods"
+	ReleaseVersionDefault string = "4.2.8"
+	APIVersionDefault     string = "d"
+	HashSchemeDefault     string = "SHA256"
+)
+
+// TestServerConfig is a configuration for TestServer
+type TestServerConfig struct {
+	Address        string // listen address, 127.0.0.1:0 is used if not set
+	Zone           string
+	Resource       string
+	AdminUser      string
+	AdminPassword  string
+	ReleaseVersion string // iRODS version reported to clients, must be 4.2.8 or lower as newer protocols are not implemented, 4.3.0 can be used for openid authentication
+	HashScheme     string // default hash scheme reported in client hints
+	SSL            bool   // negotiates SSL with a self-signed certificate, openid authentication requires it
+}
+
+// NewDefaultTestServerConfig creates a default TestServerConfig
+func NewDefaultTestServerConfig() *TestServerConfig {
+	return &TestServerConfig{
+		Address:        "127.0.0.1:0",
+		Zone:           ZoneDefault,
+		Resource:       ResourceDefault,
+		AdminUser:      AdminUserDefault,
+		AdminPassword:  AdminPasswordDefault,
+		ReleaseVersion: ReleaseVersionDefault,
+		HashScheme:     HashSchemeDefault,
+	}
+}
+
+func (config *TestServerConfig) fillDefaults() {
+	if len(config.Address) == 0 {
+		config.Address = "127.0.0.1:0"
+	}
+
+	if len(config.Zone) == 0 {
+		config.Zone = ZoneDefault
+	}
+
+	if len(config.Resource) == 0 {
+		config.Resource = ResourceDefault
+	}
+
+	if len(config.AdminUser) == 0 {
+		config.AdminUser = AdminUserDefault
+	}
+
+	if len(config.AdminPassword) == 0 {
+		config.AdminPassword = AdminPasswordDefault
+	}
+
+	if len(config.ReleaseVersion) == 0 {
+		config.ReleaseVersion = ReleaseVersionDefault
+	}
+
+	if len(config.HashScheme) == 0 {
+		config.HashScheme = HashSchemeDefault
+	}
+}
+
+// TestServer is an in-memory iRODS server for testing
+// It implements a small subset of the iRODS protocol (native and openid authentication, collections, data objects and GenQuery for them)
+// so applications can be tested without a running iRODS deployment
+type TestServer struct {
+	config   *TestServerConfig
+	catalog  *catalog
+	locks    *lockTable
+	listener net.Listener
+	bootTime time.Time
+
+	tlsConfig    *tls.Config
+	certPool     *x509.CertPool
+	openIDTokens map[string]string // access token to username
+
+	sessions     map[*serverSession]bool
+	sessionsWait sync.WaitGroup
+	mutex        sync.Mutex
+}
+
+// NewTestServer creates a new TestServer, the server does not listen until Start is called
+func NewTestServer(config *TestServerConfig) *TestServer {
+	if config == nil {
+		config = NewDefaultTestServerConfig()
+	}
+
+	config.fillDefaults()
+
+	server := &TestServer{
+		config:       config,
+		catalog:      newCatalog(config.Zone, config.Resource),
+		locks:        newLockTable(),
+		bootTime:     time.Now(),
+		openIDTokens: map[string]string{},
+		sessions:     map[*serverSession]bool{},
+	}
+
+	server.catalog.addUser(config.AdminUser, config.AdminPassword)
+
+	return server
+}
+
+// useNewXML returns true if clients use the XML dialect of the release version of the server
+func (server *TestServer) useNewXML() bool {
+	version := &types.IRODSVersion{
+		ReleaseVersion: "rods" + server.config.ReleaseVersion,
+		APIVersion:     APIVersionDefault,
+	}
+
+	return types.NewIRODSServerCapabilities(version).NewXML
+}
+
+// GetConfig returns the configuration
+func (server *TestServer) GetConfig() *TestServerConfig {
+	return server.config
+}
+
+// AddUser adds a user with its home collection
+func (server *TestServer) AddUser(username string, password string) {
+	server.catalog.addUser(username, password)
+}
+
+// AddTicket adds a ticket granting access to the path, tickets are only checked for existence when supplied
+func (server *TestServer) AddTicket(ticket string, irodsPath string) {
+	server.catalog.addTicket(ticket, irodsPath)
+}
+
+// AddOpenIDToken adds an access token accepted for the user in openid authentication
+func (server *TestServer) AddOpenIDToken(accessToken string, username string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.openIDTokens[accessToken] = username
+}
+
+// getOpenIDTokenUser returns the user of the access token
+func (server *TestServer) getOpenIDTokenUser(accessToken string) (string, bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	username, ok := server.openIDTokens[accessToken]
+	return username, ok
+}
+
+// getTLSConfig returns the TLS config, nil if SSL is not enabled
+func (server *TestServer) getTLSConfig() *tls.Config {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return server.tlsConfig
+}
+
+// AddStaleReplica adds a stale replica of the given size to the data object, replicas are only listed and have no data
+func (server *TestServer) AddStaleReplica(objPath string, size int64) error {
+	if errCode := server.catalog.addStaleReplica(objPath, size); errCode != 0 {
+		return types.NewIRODSError(errCode)
+	}
+	return nil
+}
+
+// Start starts listening
+func (server *TestServer) Start() error {
+	logger := log.WithFields(log.Fields{
+		"address": server.config.Address,
+	})
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if server.listener != nil {
+		return errors.Errorf("test server is already started")
+	}
+
+	if server.config.SSL && server.tlsConfig == nil {
+		tlsConfig, certPool, err := newSelfSignedTLSConfig()
+		if err != nil {
+			return errors.Wrapf(err, "failed to create a self-signed certificate")
+		}
+
+		server.tlsConfig = tlsConfig
+		server.certPool = certPool
+	}
+
+	listener, err := net.Listen("tcp", server.config.Address)
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen on %q", server.config.Address)
+	}
+
+	server.listener = listener
+
+	logger.Debugf("Test server is listening on %q", listener.Addr().String())
+
+	go server.acceptLoop(listener)
+
+	return nil
+}
+
+// Stop stops listening and closes all client connections
+func (server *TestServer) Stop() {
+	server.mutex.Lock()
+
+	if server.listener != nil {
+		_ = server.listener.Close()
+		server.listener = nil
+	}
+
+	for sess := range server.sessions {
+		sess.close()
+	}
+
+	server.mutex.Unlock()
+
+	server.sessionsWait.Wait()
+}
+
+// GetHost returns host of the listening address
+func (server *TestServer) GetHost() string {
+	host, _ := server.splitAddress()
+	return host
+}
+
+// GetPort returns port of the listening address
+func (server *TestServer) GetPort() int {
+	_, port := server.splitAddress()
+	return port
+}
+
+func (server *TestServer) splitAddress() (string, int) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if server.listener == nil {
+		return "", 0
+	}
+
+	host, portString, err := net.SplitHostPort(server.listener.Addr().String())
+	if err != nil {
+		return "", 0
+	}
+
+	port, err := strconv.Atoi(portString)
+	if err != nil {
+		return "", 0
+	}
+
+	return host, port
+}
+
+// GetAccount returns an account to connect to the server as the given user
+func (server *TestServer) GetAccount(username string) (*types.IRODSAccount, error) {
+	password, ok := server.catalog.getUserPassword(username)
+	if !ok {
+		return nil, errors.Errorf("failed to find user %q", username)
+	}
+
+	host, port := server.splitAddress()
+	if port == 0 {
+		return nil, errors.Errorf("test server is not started")
+	}
+
+	account, err := types.CreateIRODSAccount(host, port, username, server.config.Zone, types.AuthSchemeNative, password, server.config.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	if server.config.SSL {
+		server.mutex.Lock()
+		account.SetSSLConfiguration(newSSLConfiguration(server.certPool))
+		server.mutex.Unlock()
+
+		account.SetCSNegotiation(true, types.CSNegotiationPolicyRequestSSL)
+	}
+
+	return account, nil
+}
+
+// GetAdminAccount returns an account to connect to the server as the admin user
+func (server *TestServer) GetAdminAccount() (*types.IRODSAccount, error) {
+	return server.GetAccount(server.config.AdminUser)
+}
+
+func (server *TestServer) acceptLoop(listener net.Listener) {
+	logger := log.WithFields(log.Fields{})
+
+	for {
+		socket, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+
+			logger.WithError(err).Debug("failed to accept a connection")
+			time.Sleep(10 * time.Millisecond)
+			continue
+		}
+
+		sess := newServerSession(server, socket)
+
+		server.mutex.Lock()
+		if server.listener != listener {
+			// stopped
+			server.mutex.Unlock()
+			_ = socket.Close()
+			return
+		}
+
+		server.sessions[sess] = true
+		server.sessionsWait.Add(1)
+		server.mutex.Unlock()
+
+		go func() {
+			defer server.sessionsWait.Done()
+
+			sess.serve()
+			server.locks.forgetSession(sess)
+
+			server.mutex.Lock()
+			delete(server.sessions, sess)
+			server.mutex.Unlock()
+		}()
+	}
+}
@@ -0,0 +1,1140 @@
+package testserver
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/auth"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	challengeLen    int = 64
+	maxHeaderLength int = 1024 * 1024 // 1MB
+)
+
+// serverFileDescriptor is a data object opened by a client
+type serverFileDescriptor struct {
+	dataObject   *catalogDataObject
+	offset       int64
+	flags        int
+	replicaToken string // issued to other clients to open the same replica
+}
+
+// serverSubFileDescriptor is a file inside a struct file opened for read
+type serverSubFileDescriptor struct {
+	data   []byte
+	offset int
+}
+
+// renameRequest is DataObjCopyInp_PI used for rename and copy, message.IRODSMessageMoveDataObjectRequest has no tag for paths so it cannot be unmarshalled
+type renameRequest struct {
+	XMLName xml.Name                                `xml:"DataObjCopyInp_PI"`
+	Paths   []message.IRODSMessageDataObjectRequest `xml:"DataObjInp_PI"`
+}
+
+// serverSession handles a client connection
+type serverSession struct {
+	server *TestServer
+	socket net.Conn
+	stream net.Conn // socket, or TLS connection over the socket once SSL is negotiated
+
+	username   string
+	challenge  []byte
+	openIDUser string // user of the access token verified by the openid auth plugin
+	loggedIn   bool
+
+	fileDescriptors    map[int]*serverFileDescriptor
+	subFileDescriptors map[int]*serverSubFileDescriptor
+	nextFileDescriptor int
+
+	closeOnce sync.Once
+}
+
+func newServerSession(server *TestServer, socket net.Conn) *serverSession {
+	return &serverSession{
+		server:             server,
+		socket:             socket,
+		stream:             socket,
+		fileDescriptors:    map[int]*serverFileDescriptor{},
+		subFileDescriptors: map[int]*serverSubFileDescriptor{},
+		nextFileDescriptor: 3,
+	}
+}
+
+func (sess *serverSession) close() {
+	sess.closeOnce.Do(func() {
+		_ = sess.socket.Close()
+
+		// the server releases locks of a client when it disconnects
+		sess.server.locks.releaseSession(sess)
+	})
+}
+
+// serve handles requests until the client disconnects
+func (sess *serverSession) serve() {
+	logger := log.WithFields(log.Fields{
+		"client": sess.socket.RemoteAddr().String(),
+	})
+
+	defer sess.close()
+
+	err := sess.startup()
+	if err != nil {
+		logger.WithError(err).Debug("failed to start up a session")
+		return
+	}
+
+	for {
+		msg, err := sess.readMessage()
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				logger.WithError(err).Debug("failed to read a message")
+			}
+			return
+		}
+
+		switch msg.Body.Type {
+		case message.RODS_MESSAGE_DISCONNECT_TYPE:
+			return
+		case message.RODS_MESSAGE_API_REQ_TYPE:
+			err = sess.handleAPIRequest(msg)
+			if err != nil {
+				logger.WithError(err).Debug("failed to handle a request")
+				return
+			}
+		default:
+			logger.Debugf("unexpected message type %q", msg.Body.Type)
+			return
+		}
+	}
+}
+
+// readMessage reads a message from the client
+func (sess *serverSession) readMessage() (*message.IRODSMessage, error) {
+	msg, err := sess.readRawMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	// clients send iRODS dialect of XML, which changes for the release version
+	err = message.CorrectXMLResponseMessage(msg, sess.server.useNewXML())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to correct xml")
+	}
+
+	return msg, nil
+}
+
+// readRawMessage reads a message from the client without correcting xml, e.g., for binary messages
+func (sess *serverSession) readRawMessage() (*message.IRODSMessage, error) {
+	header, err := sess.readHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	bodyBuffer := make([]byte, int(header.MessageLen)+int(header.ErrorLen))
+	_, err = io.ReadFull(sess.stream, bodyBuffer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read body")
+	}
+
+	bsBuffer := make([]byte, int(header.BsLen))
+	_, err = io.ReadFull(sess.stream, bsBuffer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read body (BS)")
+	}
+
+	body := message.IRODSMessageBody{
+		Type:    header.Type,
+		IntInfo: header.IntInfo,
+	}
+
+	err = body.FromBytes(header, bodyBuffer, bsBuffer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &message.IRODSMessage{
+		Header: header,
+		Body:   &body,
+	}, nil
+}
+
+// readHeader reads a message header from the client
+func (sess *serverSession) readHeader() (*message.IRODSMessageHeader, error) {
+	headerLenBuffer := make([]byte, 4)
+	_, err := io.ReadFull(sess.stream, headerLenBuffer)
+	if err != nil {
+		return nil, err
+	}
+
+	headerLen := int(binary.BigEndian.Uint32(headerLenBuffer))
+	if headerLen <= 0 || headerLen > maxHeaderLength {
+		return nil, errors.Errorf("invalid header length %d", headerLen)
+	}
+
+	headerBuffer := make([]byte, headerLen)
+	_, err = io.ReadFull(sess.stream, headerBuffer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read header")
+	}
+
+	header := message.IRODSMessageHeader{}
+	err = header.FromBytes(headerBuffer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &header, nil
+}
+
+// writeMessage sends a message to the client
+func (sess *serverSession) writeMessage(messageType message.MessageType, intInfo int32, xmlMessage interface{}, bs []byte) error {
+	body := message.IRODSMessageBody{
+		Type:    messageType,
+		Bs:      bs,
+		IntInfo: intInfo,
+	}
+
+	if xmlMessage != nil {
+		xmlBytes, err := xml.Marshal(xmlMessage)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal message to xml")
+		}
+
+		body.Message = xmlBytes
+	}
+
+	header, err := body.BuildHeader()
+	if err != nil {
+		return err
+	}
+
+	headerBytes, err := header.GetBytes()
+	if err != nil {
+		return err
+	}
+
+	bodyBytes, err := body.GetBytes()
+	if err != nil {
+		return err
+	}
+
+	headerLenBuffer := make([]byte, 4)
+	binary.BigEndian.PutUint32(headerLenBuffer, uint32(len(headerBytes)))
+
+	buffer := make([]byte, 0, 4+len(headerBytes)+len(bodyBytes))
+	buffer = append(buffer, headerLenBuffer...)
+	buffer = append(buffer, headerBytes...)
+	buffer = append(buffer, bodyBytes...)
+
+	_, err = sess.stream.Write(buffer)
+	return err
+}
+
+// reply sends an API reply
+func (sess *serverSession) reply(intInfo int32, xmlMessage interface{}, bs []byte) error {
+	return sess.writeMessage(message.RODS_MESSAGE_API_REPLY_TYPE, intInfo, xmlMessage, bs)
+}
+
+// replyError sends an API reply with an error code
+func (sess *serverSession) replyError(errorCode common.ErrorCode) error {
+	return sess.reply(int32(errorCode), nil, nil)
+}
+
+func (sess *serverSession) startup() error {
+	msg, err := sess.readMessage()
+	if err != nil {
+		return err
+	}
+
+	if msg.Body.Type != message.RODS_MESSAGE_CONNECT_TYPE {
+		return errors.Errorf("unexpected message type %q, expected startup", msg.Body.Type)
+	}
+
+	startup := message.IRODSMessageStartupPack{}
+	err = xml.Unmarshal(msg.Body.Message, &startup)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal startup pack")
+	}
+
+	sess.username = startup.ProxyUser
+
+	tlsConfig := sess.server.getTLSConfig()
+	useSSL := false
+
+	if strings.Contains(startup.Option, message.RequestNegotiationOptionString) {
+		serverPolicy := types.CSNegotiationPolicyRequestTCP
+		if tlsConfig != nil {
+			serverPolicy = types.CSNegotiationPolicyRequestSSL
+		}
+
+		negotiation := message.IRODSMessageCSNegotiation{
+			Status: 1,
+			Result: string(serverPolicy),
+		}
+
+		err = sess.writeMessage(message.RODS_MESSAGE_CS_NEG_TYPE, 0, &negotiation, nil)
+		if err != nil {
+			return err
+		}
+
+		msg, err = sess.readMessage()
+		if err != nil {
+			return err
+		}
+
+		negotiationResult := message.IRODSMessageCSNegotiation{}
+		err = xml.Unmarshal(msg.Body.Message, &negotiationResult)
+		if err != nil {
+			return errors.Wrapf(err, "failed to unmarshal negotiation result")
+		}
+
+		expectedResult := types.CSNegotiationUseTCP
+		if tlsConfig != nil {
+			expectedResult = types.CSNegotiationUseSSL
+		}
+
+		if negotiationResult.Status != 1 || !strings.Contains(negotiationResult.Result, string(expectedResult)) {
+			return errors.Errorf("client-server negotiation failed, %q", negotiationResult.Result)
+		}
+
+		useSSL = tlsConfig != nil
+	}
+
+	version := message.IRODSMessageVersion{
+		Status:         0,
+		ReleaseVersion: "rods" + sess.server.config.ReleaseVersion,
+		APIVersion:     APIVersionDefault,
+	}
+
+	err = sess.writeMessage(message.RODS_MESSAGE_VERSION_TYPE, 0, &version, nil)
+	if err != nil {
+		return err
+	}
+
+	if useSSL {
+		return sess.sslStartup(tlsConfig)
+	}
+
+	return nil
+}
+
+// sslStartup switches to TLS and receives encryption settings and a shared secret from the client
+// the settings are only used for parallel transfers, which this server does not do over separate channels
+func (sess *serverSession) sslStartup(tlsConfig *tls.Config) error {
+	tlsSocket := tls.Server(sess.socket, tlsConfig)
+
+	err := tlsSocket.Handshake()
+	if err != nil {
+		return errors.Wrapf(err, "failed to perform TLS handshake")
+	}
+
+	sess.stream = tlsSocket
+
+	// ssl settings only have a header, the message type is the encryption algorithm and lengths are the parameters
+	_, err = sess.readHeader()
+	if err != nil {
+		return errors.Wrapf(err, "failed to read ssl settings")
+	}
+
+	msg, err := sess.readRawMessage()
+	if err != nil {
+		return errors.Wrapf(err, "failed to read ssl shared secret")
+	}
+
+	if msg.Body.Type != message.RODS_MESSAGE_SSL_SHARED_SECRET_TYPE {
+		return errors.Errorf("unexpected message type %q, expected ssl shared secret", msg.Body.Type)
+	}
+
+	return nil
+}
+
+func (sess *serverSession) handleAPIRequest(msg *message.IRODSMessage) error {
+	apiNumber := common.APINumber(msg.Body.IntInfo)
+
+	switch apiNumber {
+	case common.AUTH_REQUEST_AN:
+		return sess.handleAuthRequest()
+	case common.AUTH_RESPONSE_AN:
+		return sess.handleAuthResponse(msg)
+	case common.NEW_AUTH_PLUGIN_REQ_AN:
+		return sess.handleAuthPluginRequest(msg)
+	}
+
+	if !sess.loggedIn {
+		return sess.replyError(common.CAT_INVALID_AUTHENTICATION)
+	}
+
+	switch apiNumber {
+	case common.GET_MISC_SVR_INFO_AN:
+		return sess.handleMiscServerInfo()
+	case common.END_TRANSACTION_AN:
+		return sess.replyError(0)
+	case common.GEN_QUERY_AN:
+		return sess.handleQuery(msg)
+	case common.COLL_CREATE_AN:
+		return sess.handleMakeCollection(msg)
+	case common.RM_COLL_AN:
+		return sess.handleRemoveCollection(msg)
+	case common.DATA_OBJ_CREATE_AN, common.DATA_OBJ_OPEN_AN:
+		return sess.handleOpenDataObject(msg, apiNumber == common.DATA_OBJ_CREATE_AN)
+	case common.DATA_OBJ_READ_AN:
+		return sess.handleReadDataObject(msg)
+	case common.DATA_OBJ_WRITE_AN:
+		return sess.handleWriteDataObject(msg)
+	case common.DATA_OBJ_LSEEK_AN:
+		return sess.handleSeekDataObject(msg)
+	case common.DATA_OBJ_CLOSE_AN:
+		return sess.handleCloseDataObject(msg)
+	case common.DATA_OBJ_UNLINK_AN:
+		return sess.handleRemoveDataObject(msg)
+	case common.DATA_OBJ_RENAME_AN:
+		return sess.handleRename(msg)
+	case common.DATA_OBJ_COPY_AN:
+		return sess.handleCopyDataObject(msg)
+	case common.DATA_OBJ_TRUNCATE_AN:
+		return sess.handleTruncateDataObject(msg)
+	case common.DATA_OBJ_PHYMV_AN:
+		return sess.handlePhysicalMoveDataObject(msg)
+	case common.MOD_DATA_OBJ_META_AN:
+		return sess.handleModifyDataObjectMeta(msg)
+	case common.STRUCT_FILE_BUNDLE_AN:
+		return sess.handleBundleStructFile(msg)
+	case common.STRUCT_FILE_EXT_AND_REG_AN:
+		return sess.handleExtractStructFile(msg)
+	case common.SUB_STRUCT_FILE_OPEN_AN:
+		return sess.handleOpenSubStructFile(msg)
+	case common.SUB_STRUCT_FILE_READ_AN:
+		return sess.handleReadSubStructFile(msg)
+	case common.SUB_STRUCT_FILE_CLOSE_AN:
+		return sess.handleCloseSubStructFile(msg)
+	case common.PHY_PATH_REG_AN:
+		return sess.handleRegisterDataObject(msg)
+	case common.MOD_COLL_AN:
+		return sess.handleModifyCollection(msg)
+	case common.TOUCH_APN:
+		return sess.handleTouch(msg)
+	case common.DATA_OBJ_LOCK_AN:
+		return sess.handleLockDataObject(msg)
+	case common.DATA_OBJ_UNLOCK_AN:
+		return sess.handleUnlockDataObject(msg)
+	case common.GET_FILE_DESCRIPTOR_INFO_APN:
+		return sess.handleGetDescriptorInfo(msg)
+	case common.REPLICA_CLOSE_APN:
+		return sess.handleCloseDataObjectReplica(msg)
+	case common.CLIENT_HINTS_AN:
+		return sess.handleClientHints()
+	case common.TICKET_ADMIN_AN:
+		return sess.handleTicketAdmin(msg)
+	case common.DATA_OBJ_CHKSUM_AN:
+		return sess.handleChecksumDataObject(msg)
+	default:
+		return sess.replyError(common.SYS_UNMATCHED_API_NUM)
+	}
+}
+
+func (sess *serverSession) handleAuthRequest() error {
+	sess.challenge = make([]byte, challengeLen)
+	_, err := rand.Read(sess.challenge)
+	if err != nil {
+		return errors.Wrapf(err, "failed to generate challenge")
+	}
+
+	challenge := message.IRODSMessageAuthChallengeResponse{
+		Challenge: base64.StdEncoding.EncodeToString(sess.challenge),
+	}
+
+	return sess.reply(0, &challenge, nil)
+}
+
+func (sess *serverSession) handleAuthResponse(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageAuthResponse{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	username := request.Username
+	if idx := strings.Index(username, "#"); idx >= 0 {
+		username = username[:idx]
+	}
+
+	password, ok := sess.server.catalog.getUserPassword(username)
+	if !ok {
+		return sess.replyError(common.CAT_INVALID_USER)
+	}
+
+	if len(sess.challenge) != challengeLen || auth.GenerateAuthResponse(sess.challenge, password) != request.Response {
+		return sess.replyError(common.CAT_INVALID_AUTHENTICATION)
+	}
+
+	sess.username = username
+	sess.loggedIn = true
+
+	return sess.replyError(0)
+}
+
+// handleAuthPluginRequest handles the auth plugin framework of iRODS 4.3, only the openid scheme is implemented
+func (sess *serverSession) handleAuthPluginRequest(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageNewAuthPluginRequest{}
+	err := request.FromBytes(msg.Body.Message)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	scheme, _ := request.AuthContext["scheme"].(string)
+	if scheme != string(types.AuthSchemeOpenID) {
+		return sess.replyError(common.SYS_INVALID_INPUT_PARAM)
+	}
+
+	nextOperation, _ := request.AuthContext["next_operation"].(string)
+	responseContext := map[string]interface{}{}
+	for key, value := range request.AuthContext {
+		if key == "access_token" {
+			continue
+		}
+		responseContext[key] = value
+	}
+
+	switch nextOperation {
+	case "auth_agent_auth_request":
+		// access tokens must not be sent in plain text
+		if _, ok := sess.stream.(*tls.Conn); !ok {
+			return sess.replyError(common.SYS_NOT_ALLOWED)
+		}
+
+		accessToken, _ := request.AuthContext["access_token"].(string)
+		username, ok := sess.server.getOpenIDTokenUser(accessToken)
+		if !ok || len(accessToken) == 0 {
+			return sess.replyError(common.CAT_INVALID_AUTHENTICATION)
+		}
+
+		requestUser, _ := request.AuthContext["user_name"].(string)
+		if len(requestUser) > 0 && requestUser != username {
+			return sess.replyError(common.CAT_INVALID_AUTHENTICATION)
+		}
+
+		sess.openIDUser = username
+	case "auth_agent_auth_response":
+		if len(sess.openIDUser) == 0 {
+			return sess.replyError(common.CAT_INVALID_AUTHENTICATION)
+		}
+
+		sess.username = sess.openIDUser
+		sess.loggedIn = true
+	default:
+		return sess.replyError(common.SYS_INVALID_INPUT_PARAM)
+	}
+
+	responseJSON, err := json.Marshal(responseContext)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal auth context")
+	}
+
+	response := message.IRODSMessageBinBytesBuf{
+		Length: len(responseJSON),
+		Data:   base64.StdEncoding.EncodeToString(responseJSON),
+	}
+
+	return sess.reply(0, &response, nil)
+}
+
+func (sess *serverSession) handleChecksumDataObject(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageDataObjectRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	checksum, errorCode := sess.server.catalog.getDataObjectChecksum(request.Path)
+	if errorCode != 0 {
+		return sess.replyError(errorCode)
+	}
+
+	response := message.IRODSMessageChecksumResponse{
+		Checksum: checksum,
+	}
+
+	return sess.reply(0, &response, nil)
+}
+
+func (sess *serverSession) handleTicketAdmin(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageTicketAdminRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	// only supplying a ticket for the session is supported
+	if request.Action != "session" {
+		return sess.replyError(common.SYS_NOT_SUPPORTED)
+	}
+
+	if !sess.server.catalog.hasTicket(request.Ticket) {
+		return sess.replyError(common.CAT_TICKET_INVALID)
+	}
+
+	return sess.replyError(0)
+}
+
+func (sess *serverSession) handleMiscServerInfo() error {
+	info := message.IRODSMessageGetMiscServerInfoResponse{
+		ServerType:     1, // RCAT_ENABLED
+		ServerBootTime: uint32(sess.server.bootTime.Unix()),
+		ReleaseVersion: "rods" + sess.server.config.ReleaseVersion,
+		APIVersion:     APIVersionDefault,
+		RodsZone:       sess.server.config.Zone,
+	}
+
+	return sess.reply(0, &info, nil)
+}
+
+func (sess *serverSession) handleClientHints() error {
+	hints := types.IRODSClientHints{
+		HashScheme:      sess.server.config.HashScheme,
+		MatchHashPolicy: "compatible",
+		SpecificQueries: []string{},
+		Plugins:         []*types.IRODSClientHintsPlugin{},
+	}
+
+	hintsBytes, err := json.Marshal(hints)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal client hints")
+	}
+
+	response := message.IRODSMessageClientHintsResponse{
+		Length: len(hintsBytes),
+		Data:   string(hintsBytes),
+	}
+
+	return sess.reply(0, &response, nil)
+}
+
+func (sess *serverSession) handleQuery(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageQueryRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	response, errCode := sess.server.catalog.runQuery(&request)
+	if errCode < 0 {
+		return sess.replyError(errCode)
+	}
+
+	return sess.reply(0, response, nil)
+}
+
+func (sess *serverSession) handleMakeCollection(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageMakeCollectionRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	recurse := hasKeyVal(&request.KeyVals, common.RECURSIVE_OPR__KW)
+	return sess.replyError(sess.server.catalog.makeCollection(request.Name, sess.username, recurse))
+}
+
+func (sess *serverSession) handleRemoveCollection(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageRemoveCollectionRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	if hasKeyVal(&request.KeyVals, common.RMTRASH_KW) || hasKeyVal(&request.KeyVals, common.ADMIN_RMTRASH_KW) {
+		return sess.handleEmptyTrash(&request)
+	}
+
+	ageMinutes, ok := getAgeMinutes(&request.KeyVals)
+	if !ok {
+		return sess.replyError(common.SYS_INVALID_INPUT_PARAM)
+	}
+
+	recurse := hasKeyVal(&request.KeyVals, common.RECURSIVE_OPR__KW)
+	force := hasKeyVal(&request.KeyVals, common.FORCE_FLAG_KW)
+	unregister := common.OperationType(request.OperationType) == common.OPER_TYPE_UNREG
+	return sess.replyError(sess.server.catalog.removeCollection(request.Name, sess.username, recurse, force, unregister, ageMinutes))
+}
+
+func (sess *serverSession) handleEmptyTrash(request *message.IRODSMessageRemoveCollectionRequest) error {
+	if !hasKeyVal(&request.KeyVals, common.ADMIN_RMTRASH_KW) {
+		// users can empty only their own trash
+		trashHomePath := sess.server.catalog.getTrashHomePath(sess.username)
+		if request.Name != trashHomePath && !strings.HasPrefix(request.Name, trashHomePath+"/") {
+			return sess.replyError(common.CAT_NO_ACCESS_PERMISSION)
+		}
+	}
+
+	ageMinutes, ok := getAgeMinutes(&request.KeyVals)
+	if !ok {
+		return sess.replyError(common.SYS_INVALID_INPUT_PARAM)
+	}
+
+	return sess.replyError(sess.server.catalog.emptyTrash(request.Name, ageMinutes))
+}
+
+// getAgeMinutes returns the age in AGE_KW, 0 if not given, false if invalid
+func getAgeMinutes(keyVals *message.IRODSMessageSSKeyVal) (int, bool) {
+	age, ok := getKeyVal(keyVals, common.AGE_KW)
+	if !ok {
+		return 0, true
+	}
+
+	minutes, err := strconv.Atoi(age)
+	if err != nil || minutes < 0 {
+		return 0, false
+	}
+	return minutes, true
+}
+
+func (sess *serverSession) handleOpenDataObject(msg *message.IRODSMessage, create bool) error {
+	request := message.IRODSMessageDataObjectRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	force := hasKeyVal(&request.KeyVals, common.FORCE_FLAG_KW)
+	openFlags := request.OpenFlags
+	if replicaToken, ok := getKeyVal(&request.KeyVals, common.REPLICA_TOKEN_KW); ok {
+		// the replica is being written by another client, open it as it is
+		if !sess.server.catalog.hasReplicaToken(request.Path, replicaToken) {
+			return sess.replyError(common.SYS_INVALID_INPUT_PARAM)
+		}
+
+		create = false
+		openFlags &^= int(types.O_TRUNC) | int(types.O_CREAT)
+	}
+
+	obj, errCode := sess.server.catalog.openDataObject(request.Path, sess.username, openFlags, create, force)
+	if errCode < 0 {
+		return sess.replyError(errCode)
+	}
+
+	fd := sess.nextFileDescriptor
+	sess.nextFileDescriptor++
+
+	sess.fileDescriptors[fd] = &serverFileDescriptor{
+		dataObject: obj,
+		offset:     0,
+		flags:      request.OpenFlags,
+	}
+
+	return sess.reply(int32(fd), nil, nil)
+}
+
+func (sess *serverSession) getOpenedDataObjectRequest(msg *message.IRODSMessage) (*message.IRODSMessageOpenedDataObjectRequest, *serverFileDescriptor, common.ErrorCode) {
+	request := message.IRODSMessageOpenedDataObjectRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return nil, nil, common.SYS_API_INPUT_ERR
+	}
+
+	fd, ok := sess.fileDescriptors[request.FileDescriptor]
+	if !ok {
+		return nil, nil, common.SYS_BAD_FILE_DESCRIPTOR
+	}
+
+	return &request, fd, 0
+}
+
+func (sess *serverSession) handleReadDataObject(msg *message.IRODSMessage) error {
+	request, fd, errCode := sess.getOpenedDataObjectRequest(msg)
+	if errCode < 0 {
+		return sess.replyError(errCode)
+	}
+
+	data := sess.server.catalog.readDataObject(fd.dataObject, fd.offset, int(request.Size))
+	fd.offset += int64(len(data))
+
+	return sess.reply(int32(len(data)), nil, data)
+}
+
+func (sess *serverSession) handleWriteDataObject(msg *message.IRODSMessage) error {
+	_, fd, errCode := sess.getOpenedDataObjectRequest(msg)
+	if errCode < 0 {
+		return sess.replyError(errCode)
+	}
+
+	if fd.flags&(int(types.O_WRONLY)|int(types.O_RDWR)) == 0 {
+		return sess.replyError(common.SYS_BAD_FILE_DESCRIPTOR)
+	}
+
+	sess.server.catalog.writeDataObject(fd.dataObject, fd.offset, msg.Body.Bs)
+	fd.offset += int64(len(msg.Body.Bs))
+
+	return sess.reply(int32(len(msg.Body.Bs)), nil, nil)
+}
+
+func (sess *serverSession) handleSeekDataObject(msg *message.IRODSMessage) error {
+	request, fd, errCode := sess.getOpenedDataObjectRequest(msg)
+	if errCode < 0 {
+		return sess.replyError(errCode)
+	}
+
+	var newOffset int64
+	switch types.Whence(request.Whence) {
+	case types.SeekSet:
+		newOffset = request.Offset
+	case types.SeekCur:
+		newOffset = fd.offset + request.Offset
+	case types.SeekEnd:
+		newOffset = sess.server.catalog.getDataObjectSize(fd.dataObject) + request.Offset
+	default:
+		return sess.replyError(common.SYS_INVALID_INPUT_PARAM)
+	}
+
+	if newOffset < 0 {
+		return sess.replyError(common.SYS_INVALID_INPUT_PARAM)
+	}
+
+	fd.offset = newOffset
+
+	response := message.IRODSMessageSeekDataObjectResponse{
+		Offset: newOffset,
+	}
+
+	return sess.reply(0, &response, nil)
+}
+
+func (sess *serverSession) handleCloseDataObject(msg *message.IRODSMessage) error {
+	request, fd, errCode := sess.getOpenedDataObjectRequest(msg)
+	if errCode < 0 {
+		return sess.replyError(errCode)
+	}
+
+	if len(fd.replicaToken) > 0 {
+		sess.server.catalog.removeReplicaToken(fd.replicaToken)
+	}
+
+	delete(sess.fileDescriptors, request.FileDescriptor)
+	return sess.replyError(0)
+}
+
+func (sess *serverSession) handleOpenSubStructFile(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageOpenSubStructFileRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	specColl := request.SpecialCollectionPointer
+	if specColl == nil || common.StructFileType(specColl.Type) != common.TAR_STRUCT_FILE_T {
+		return sess.replyError(common.SYS_NOT_SUPPORTED)
+	}
+
+	prefix := strings.TrimSuffix(specColl.Collection, "/") + "/"
+	if !strings.HasPrefix(request.SubFilePath, prefix) {
+		return sess.replyError(common.SYS_STRUCT_FILE_PATH_ERR)
+	}
+
+	data, errCode := sess.server.catalog.readSubStructFile(specColl.ObjectPath, strings.TrimPrefix(request.SubFilePath, prefix))
+	if errCode < 0 {
+		return sess.replyError(errCode)
+	}
+
+	fd := sess.nextFileDescriptor
+	sess.nextFileDescriptor++
+
+	sess.subFileDescriptors[fd] = &serverSubFileDescriptor{
+		data: data,
+	}
+
+	return sess.reply(int32(fd), nil, nil)
+}
+
+func (sess *serverSession) handleReadSubStructFile(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageSubStructFileFDRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	fd, ok := sess.subFileDescriptors[request.FileDescriptor]
+	if !ok {
+		return sess.replyError(common.SYS_STRUCT_FILE_DESC_ERR)
+	}
+
+	end := fd.offset + request.Length
+	if end > len(fd.data) {
+		end = len(fd.data)
+	}
+
+	data := fd.data[fd.offset:end]
+	fd.offset = end
+
+	return sess.reply(int32(len(data)), nil, data)
+}
+
+func (sess *serverSession) handleCloseSubStructFile(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageSubStructFileFDRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	if _, ok := sess.subFileDescriptors[request.FileDescriptor]; !ok {
+		return sess.replyError(common.SYS_STRUCT_FILE_DESC_ERR)
+	}
+
+	delete(sess.subFileDescriptors, request.FileDescriptor)
+	return sess.replyError(0)
+}
+
+func (sess *serverSession) handleRemoveDataObject(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageDataObjectRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	ageMinutes, ok := getAgeMinutes(&request.KeyVals)
+	if !ok {
+		return sess.replyError(common.SYS_INVALID_INPUT_PARAM)
+	}
+
+	replicaNumber := -1
+	if replNum, ok := getKeyVal(&request.KeyVals, common.REPL_NUM_KW); ok {
+		replicaNumber, err = strconv.Atoi(replNum)
+		if err != nil || replicaNumber < 0 {
+			return sess.replyError(common.SYS_INVALID_INPUT_PARAM)
+		}
+	}
+
+	force := hasKeyVal(&request.KeyVals, common.FORCE_FLAG_KW)
+	unregister := common.OperationType(request.OperationType) == common.OPER_TYPE_UNREG
+	return sess.replyError(sess.server.catalog.removeDataObject(request.Path, sess.username, force, unregister, replicaNumber, ageMinutes))
+}
+
+func (sess *serverSession) handleRegisterDataObject(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageDataObjectRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	physicalPath, ok := getKeyVal(&request.KeyVals, common.FILE_PATH_KW)
+	if !ok {
+		return sess.replyError(common.SYS_INVALID_INPUT_PARAM)
+	}
+
+	if hasKeyVal(&request.KeyVals, common.REG_REPL_KW) {
+		// single replica only
+		return sess.replyError(common.SYS_NOT_SUPPORTED)
+	}
+
+	if specialType, ok := getKeyVal(&request.KeyVals, common.COLLECTION_TYPE_KW); ok {
+		resource, _ := getKeyVal(&request.KeyVals, common.DEST_RESC_NAME_KW)
+		return sess.replyError(sess.server.catalog.mountCollection(request.Path, sess.username, specialType, physicalPath, resource))
+	}
+
+	if hasKeyVal(&request.KeyVals, common.COLLECTION_KW) {
+		return sess.replyError(sess.server.catalog.registerCollection(request.Path, sess.username, physicalPath))
+	}
+
+	return sess.replyError(sess.server.catalog.registerDataObject(request.Path, sess.username, physicalPath))
+}
+
+func (sess *serverSession) handleModifyCollection(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageModifyCollectionRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	if request.KeyVals.Length == 0 {
+		// used for poor man's end transaction
+		return sess.replyError(common.CAT_INVALID_ARGUMENT)
+	}
+
+	specialType, ok := getKeyVal(&request.KeyVals, common.COLLECTION_TYPE_KW)
+	if !ok || specialType != "NULL_SPECIAL_VALUE" {
+		return sess.replyError(common.SYS_NOT_SUPPORTED)
+	}
+
+	return sess.replyError(sess.server.catalog.unmountCollection(request.Name))
+}
+
+func (sess *serverSession) handleTruncateDataObject(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageDataObjectRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	return sess.replyError(sess.server.catalog.truncateDataObject(request.Path, request.Size))
+}
+
+func (sess *serverSession) handlePhysicalMoveDataObject(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageDataObjectRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	if hasKeyVal(&request.KeyVals, common.ADMIN_KW) && sess.username != sess.server.config.AdminUser {
+		return sess.replyError(common.CAT_INSUFFICIENT_PRIVILEGE_LEVEL)
+	}
+
+	replicaNumber := -1
+	if replNum, ok := getKeyVal(&request.KeyVals, common.REPL_NUM_KW); ok {
+		replicaNumber, err = strconv.Atoi(replNum)
+		if err != nil || replicaNumber < 0 {
+			return sess.replyError(common.SYS_INVALID_INPUT_PARAM)
+		}
+	}
+
+	// hierarchies end with the leaf resource
+	srcResource, _ := getKeyVal(&request.KeyVals, common.RESC_NAME_KW)
+	if srcHier, ok := getKeyVal(&request.KeyVals, common.RESC_HIER_STR_KW); ok {
+		srcResource = srcHier[strings.LastIndex(srcHier, ";")+1:]
+	}
+
+	destResource, _ := getKeyVal(&request.KeyVals, common.DEST_RESC_NAME_KW)
+	if destHier, ok := getKeyVal(&request.KeyVals, common.DEST_RESC_HIER_STR_KW); ok {
+		destResource = destHier[strings.LastIndex(destHier, ";")+1:]
+	}
+
+	return sess.replyError(sess.server.catalog.physicalMoveDataObject(request.Path, replicaNumber, srcResource, destResource))
+}
+
+func (sess *serverSession) handleModifyDataObjectMeta(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageModifyDataObjectMetaRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	keyVals := map[string]string{}
+	for idx, key := range request.KeyVals.Keys {
+		if idx < len(request.KeyVals.Values) {
+			keyVals[key] = request.KeyVals.Values[idx].Value
+		}
+	}
+
+	info := request.DataObjectInfo
+	return sess.replyError(sess.server.catalog.modifyDataObjectMeta(info.Path, info.DataID, info.ReplicaNumber, keyVals))
+}
+
+func (sess *serverSession) handleTouch(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageTouchRequest{}
+	err := request.FromBytes(msg.Body.Message)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	// json numbers are decoded as float64
+	noCreate, _ := request.Options["no_create"].(bool)
+	referencePath, _ := request.Options["reference"].(string)
+
+	var replicaNumber *int
+	if val, ok := request.Options["replica_number"].(float64); ok {
+		replicaNumberVal := int(val)
+		replicaNumber = &replicaNumberVal
+	}
+
+	var secondsSinceEpoch *int64
+	if val, ok := request.Options["seconds_since_epoch"].(float64); ok {
+		secondsSinceEpochVal := int64(val)
+		secondsSinceEpoch = &secondsSinceEpochVal
+	}
+
+	return sess.replyError(sess.server.catalog.touch(request.Path, sess.username, noCreate, replicaNumber, referencePath, secondsSinceEpoch))
+}
+
+func (sess *serverSession) handleRename(msg *message.IRODSMessage) error {
+	request := renameRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil || len(request.Paths) != 2 {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	srcPath := request.Paths[0].Path
+	destPath := request.Paths[1].Path
+
+	if common.OperationType(request.Paths[0].OperationType) == common.OPER_TYPE_RENAME_COLL {
+		return sess.replyError(sess.server.catalog.renameCollection(srcPath, destPath))
+	}
+
+	force := hasKeyVal(&request.Paths[1].KeyVals, common.FORCE_FLAG_KW)
+	return sess.replyError(sess.server.catalog.renameDataObject(srcPath, destPath, force))
+}
+
+func (sess *serverSession) handleCopyDataObject(msg *message.IRODSMessage) error {
+	request := renameRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil || len(request.Paths) != 2 {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	force := hasKeyVal(&request.Paths[1].KeyVals, common.FORCE_FLAG_KW)
+	return sess.replyError(sess.server.catalog.copyDataObject(request.Paths[0].Path, request.Paths[1].Path, sess.username, force))
+}
+
+func (sess *serverSession) handleBundleStructFile(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageBundleStructFileRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	if dataType, _ := getKeyVal(&request.KeyVals, common.DATA_TYPE_KW); dataType != string(types.TAR_FILE_DT) {
+		// tar only
+		return sess.replyError(common.SYS_NOT_SUPPORTED)
+	}
+
+	force := hasKeyVal(&request.KeyVals, common.FORCE_FLAG_KW)
+	add := hasKeyVal(&request.KeyVals, common.ADD_KW)
+	return sess.replyError(sess.server.catalog.bundleStructFile(request.Path, request.SourceCollection, sess.username, force, add))
+}
+
+func (sess *serverSession) handleExtractStructFile(msg *message.IRODSMessage) error {
+	request := message.IRODSMessageExtractStructFileRequest{}
+	err := xml.Unmarshal(msg.Body.Message, &request)
+	if err != nil {
+		return sess.replyError(common.SYS_API_INPUT_ERR)
+	}
+
+	if dataType, _ := getKeyVal(&request.KeyVals, common.DATA_TYPE_KW); dataType != string(types.TAR_FILE_DT) {
+		// tar only
+		return sess.replyError(common.SYS_NOT_SUPPORTED)
+	}
+
+	force := hasKeyVal(&request.KeyVals, common.FORCE_FLAG_KW)
+	return sess.replyError(sess.server.catalog.extractStructFile(request.Path, request.TargetCollection, sess.username, force))
+}
+
+func getKeyVal(keyVals *message.IRODSMessageSSKeyVal, key common.KeyWord) (string, bool) {
+	for idx, k := range keyVals.Keys {
+		if k == string(key) && idx < len(keyVals.Values) {
+			return keyVals.Values[idx].Value, true
+		}
+	}
+	return "", false
+}
+
+func hasKeyVal(keyVals *message.IRODSMessageSSKeyVal, key common.KeyWord) bool {
+	for _, k := range keyVals.Keys {
+		if k == string(key) {
+			return true
+		}
+	}
+	return false
+}
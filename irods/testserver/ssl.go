@@ -0,0 +1,85 @@
+package testserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/types"
+)
+
+// newSelfSignedTLSConfig creates a TLS config with a self-signed certificate for the loopback addresses
+// the returned pool trusts the certificate, so clients can verify the server
+func newSelfSignedTLSConfig() (*tls.Config, *x509.CertPool, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to generate a key")
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 62))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to generate a serial number")
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "go-irodsclient test server"},
+		NotBefore:             now.Add(-1 * time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to create a certificate")
+	}
+
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to parse the certificate")
+	}
+
+	certPool := x509.NewCertPool()
+	certPool.AddCert(cert)
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{
+			{
+				Certificate: [][]byte{certBytes},
+				PrivateKey:  key,
+				Leaf:        cert,
+			},
+		},
+		MinVersion: tls.VersionTLS12,
+	}
+
+	return tlsConfig, certPool, nil
+}
+
+// newSSLConfiguration returns a client SSL configuration that trusts the certificate of the server
+func newSSLConfiguration(certPool *x509.CertPool) *types.IRODSSSLConfig {
+	return &types.IRODSSSLConfig{
+		EncryptionKeySize:       32,
+		EncryptionAlgorithm:     string(types.EncryptionAlgorithmAES256CBC),
+		EncryptionSaltSize:      8,
+		EncryptionNumHashRounds: 16,
+		VerifyServer:            types.SSLVerifyServerHostname,
+		TLSConfig: &tls.Config{
+			RootCAs:    certPool,
+			MinVersion: tls.VersionTLS12,
+		},
+	}
+}
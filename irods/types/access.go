@@ -106,6 +106,56 @@ func (accessType IRODSAccessLevelType) ChmodString() string {
 	}
 }
 
+// GetLevel returns the numeric rank of the access level, matching iRODS access type tokens
+// higher level includes all permissions of lower levels
+func (accessType IRODSAccessLevelType) GetLevel() int {
+	switch accessType {
+	case IRODSAccessLevelExecute:
+		return 1010
+	case IRODSAccessLevelReadAnnotation:
+		return 1020
+	case IRODSAccessLevelReadSystemMetadata:
+		return 1030
+	case IRODSAccessLevelReadMetadata:
+		return 1040
+	case IRODSAccessLevelReadObject:
+		return 1050
+	case IRODSAccessLevelWriteAnnotation:
+		return 1060
+	case IRODSAccessLevelCreateMetadata:
+		return 1070
+	case IRODSAccessLevelModifyMetadata:
+		return 1080
+	case IRODSAccessLevelDeleteMetadata:
+		return 1090
+	case IRODSAccessLevelAdministerObject:
+		return 1100
+	case IRODSAccessLevelCreateObject:
+		return 1110
+	case IRODSAccessLevelModifyObject:
+		return 1120
+	case IRODSAccessLevelDeleteObject:
+		return 1130
+	case IRODSAccessLevelCreateToken:
+		return 1140
+	case IRODSAccessLevelDeleteToken:
+		return 1150
+	case IRODSAccessLevelCurate:
+		return 1160
+	case IRODSAccessLevelOwner:
+		return 1200
+	case IRODSAccessLevelNull:
+		fallthrough
+	default:
+		return 1000
+	}
+}
+
+// IsHigherThan returns true if the access level is higher than the given access level
+func (accessType IRODSAccessLevelType) IsHigherThan(accessType2 IRODSAccessLevelType) bool {
+	return accessType.GetLevel() > accessType2.GetLevel()
+}
+
 // IRODSAccess contains irods access information
 type IRODSAccess struct {
 	Path        string               `json:"path"`
@@ -130,3 +180,24 @@ type IRODSAccessInheritance struct {
 func (inheritance *IRODSAccessInheritance) ToString() string {
 	return fmt.Sprintf("<IRODSAccessInheritance %s %t>", inheritance.Path, inheritance.Inheritance)
 }
+
+// IRODSAccessGrant contains irods access information annotated with how the access is granted
+type IRODSAccessGrant struct {
+	Path        string               `json:"path"`
+	UserName    string               `json:"user_name"`
+	UserZone    string               `json:"user_zone"`
+	UserType    IRODSUserType        `json:"user_type"`
+	AccessLevel IRODSAccessLevelType `json:"access_level"`
+	// ViaGroup is true if the access is granted through group membership
+	ViaGroup  bool   `json:"via_group"`
+	GroupName string `json:"group_name,omitempty"`
+	GroupZone string `json:"group_zone,omitempty"`
+}
+
+// ToString stringifies the object
+func (grant *IRODSAccessGrant) ToString() string {
+	if grant.ViaGroup {
+		return fmt.Sprintf("<IRODSAccessGrant %s %s %s %s %s via %s#%s>", grant.Path, grant.UserName, grant.UserZone, string(grant.UserType), string(grant.AccessLevel), grant.GroupName, grant.GroupZone)
+	}
+	return fmt.Sprintf("<IRODSAccessGrant %s %s %s %s %s>", grant.Path, grant.UserName, grant.UserZone, string(grant.UserType), string(grant.AccessLevel))
+}
@@ -0,0 +1,76 @@
+package types
+
+// IRODSAdminAction is an action of general admin request (arg0)
+type IRODSAdminAction string
+
+const (
+	// IRODSAdminActionAdd is for adding an object
+	IRODSAdminActionAdd IRODSAdminAction = "add"
+	// IRODSAdminActionModify is for modifying an object
+	IRODSAdminActionModify IRODSAdminAction = "modify"
+	// IRODSAdminActionRemove is for removing an object
+	IRODSAdminActionRemove IRODSAdminAction = "rm"
+	// IRODSAdminActionCalculateUsage is for calculating resource usage
+	IRODSAdminActionCalculateUsage IRODSAdminAction = "calculate-usage"
+	// IRODSAdminActionSetDelayServer is for setting the delay server, the host is given as target
+	IRODSAdminActionSetDelayServer IRODSAdminAction = "set_delay_server"
+)
+
+// IRODSAdminTarget is a target of general admin request (arg1)
+type IRODSAdminTarget string
+
+const (
+	// IRODSAdminTargetUser is for a user
+	IRODSAdminTargetUser IRODSAdminTarget = "user"
+	// IRODSAdminTargetGroup is for a group
+	IRODSAdminTargetGroup IRODSAdminTarget = "group"
+	// IRODSAdminTargetZone is for a zone
+	IRODSAdminTargetZone IRODSAdminTarget = "zone"
+	// IRODSAdminTargetResource is for a resource
+	IRODSAdminTargetResource IRODSAdminTarget = "resource"
+	// IRODSAdminTargetChildToResource is for a child of a resource
+	IRODSAdminTargetChildToResource IRODSAdminTarget = "childtoresc"
+	// IRODSAdminTargetChildFromResource is for a child of a resource, used with rm
+	IRODSAdminTargetChildFromResource IRODSAdminTarget = "childfromresc"
+	// IRODSAdminTargetToken is for a token
+	IRODSAdminTargetToken IRODSAdminTarget = "token"
+	// IRODSAdminTargetSpecificQuery is for a specific query
+	IRODSAdminTargetSpecificQuery IRODSAdminTarget = "specificQuery"
+	// IRODSAdminTargetLocalZoneName is for the name of local zone
+	IRODSAdminTargetLocalZoneName IRODSAdminTarget = "localzonename"
+)
+
+// IRODSTokenNamespace is a namespace of tokens in catalog
+type IRODSTokenNamespace string
+
+const (
+	// IRODSTokenNamespaceToken is for token namespaces
+	IRODSTokenNamespaceToken IRODSTokenNamespace = "token_namespace"
+	// IRODSTokenNamespaceUserType is for user types
+	IRODSTokenNamespaceUserType IRODSTokenNamespace = "user_type"
+	// IRODSTokenNamespaceZoneType is for zone types
+	IRODSTokenNamespaceZoneType IRODSTokenNamespace = "zone_type"
+	// IRODSTokenNamespaceResourceType is for resource types
+	IRODSTokenNamespaceResourceType IRODSTokenNamespace = "resc_type"
+	// IRODSTokenNamespaceResourceClass is for resource classes
+	IRODSTokenNamespaceResourceClass IRODSTokenNamespace = "resc_class"
+	// IRODSTokenNamespaceDataType is for data types
+	IRODSTokenNamespaceDataType IRODSTokenNamespace = "data_type"
+	// IRODSTokenNamespaceAccessType is for access types
+	IRODSTokenNamespaceAccessType IRODSTokenNamespace = "access_type"
+	// IRODSTokenNamespaceObjectType is for object types
+	IRODSTokenNamespaceObjectType IRODSTokenNamespace = "object_type"
+)
+
+// IRODSGridConfigurationNamespace is a namespace of grid configuration, used with GetGridConfigurationValue
+type IRODSGridConfigurationNamespace string
+
+const (
+	// IRODSGridConfigurationNamespaceDelayServer is for delay server options, e.g., leader and successor
+	IRODSGridConfigurationNamespaceDelayServer IRODSGridConfigurationNamespace = "delay_server"
+	// IRODSGridConfigurationNamespaceAuthentication is for authentication options, e.g., password_min_time
+	IRODSGridConfigurationNamespaceAuthentication IRODSGridConfigurationNamespace = "authentication"
+)
+
+// MaxAdminRequestParams is the max number of params of general admin request (arg2 ~ arg9)
+const MaxAdminRequestParams = 8
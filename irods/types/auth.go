@@ -16,13 +16,19 @@ const (
 	AuthSchemePAM AuthScheme = "pam"
 	// AuthSchemePAMPasswordAuthScheme uses PAM authentication scheme
 	AuthSchemePAMPassword AuthScheme = "pam_password"
+	// AuthSchemePAMInteractive uses PAM interactive authentication scheme (iRODS 4.3+)
+	AuthSchemePAMInteractive AuthScheme = "pam_interactive"
+	// AuthSchemeOpenID uses OpenID Connect authentication scheme (iRODS 4.3+)
+	AuthSchemeOpenID AuthScheme = "openid"
 	// AuthSchemeUnknown is unknown scheme
 	AuthSchemeUnknown AuthScheme = ""
 )
 
 // GetAuthScheme returns AuthScheme value from string
+// other schemes are returned as is, so schemes of registered auth plugins can be used
 func GetAuthScheme(authScheme string) AuthScheme {
-	switch strings.TrimSpace(strings.ToLower(authScheme)) {
+	scheme := strings.TrimSpace(strings.ToLower(authScheme))
+	switch scheme {
 	case string(AuthSchemeNative):
 		return AuthSchemeNative
 	case string(AuthSchemeGSI):
@@ -31,14 +37,18 @@ func GetAuthScheme(authScheme string) AuthScheme {
 		return AuthSchemePAM
 	case string(AuthSchemePAMPassword):
 		return AuthSchemePAMPassword
+	case string(AuthSchemePAMInteractive):
+		return AuthSchemePAMInteractive
+	case string(AuthSchemeOpenID):
+		return AuthSchemeOpenID
 	case string(AuthSchemeUnknown):
-		fallthrough
-	default:
 		return AuthSchemeUnknown
+	default:
+		return AuthScheme(scheme)
 	}
 }
 
-// IsPAM checks if the auth scheme is pam or pam_password
+// IsPAM checks if the auth scheme is pam, pam_password or pam_interactive
 func (authScheme AuthScheme) IsPAM() bool {
-	return authScheme == AuthSchemePAM || authScheme == AuthSchemePAMPassword
+	return authScheme == AuthSchemePAM || authScheme == AuthSchemePAMPassword || authScheme == AuthSchemePAMInteractive
 }
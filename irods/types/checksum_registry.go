@@ -0,0 +1,213 @@
+package types
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"hash/adler32"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+)
+
+// ChecksumAlgorithmDefinition describes how a checksum algorithm is computed and represented in iRODS checksum strings
+type ChecksumAlgorithmDefinition struct {
+	Algorithm  ChecksumAlgorithm // name of the algorithm, e.g., SHA-256
+	Aliases    []string          // other names of the algorithm, e.g., sha256
+	Prefixes   []string          // scheme prefixes in iRODS checksum strings, e.g., sha2, the first is used to make one, empty for no prefix
+	DigestSize int               // size of digest in bytes
+	Base64     bool              // digest is encoded with base64 in iRODS checksum strings, hex otherwise
+	NewHash    func() hash.Hash  // creates a new hash
+}
+
+// HasName returns true if the name is the algorithm or one of aliases, case-insensitive
+func (def *ChecksumAlgorithmDefinition) HasName(name string) bool {
+	if strings.EqualFold(string(def.Algorithm), name) {
+		return true
+	}
+
+	for _, alias := range def.Aliases {
+		if strings.EqualFold(alias, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPrefix returns true if the scheme prefix is used for the algorithm, case-insensitive
+func (def *ChecksumAlgorithmDefinition) HasPrefix(prefix string) bool {
+	for _, defPrefix := range def.Prefixes {
+		if strings.EqualFold(defPrefix, prefix) {
+			return true
+		}
+	}
+
+	// names are also accepted as prefix, e.g., sha256:...
+	return len(prefix) > 0 && def.HasName(prefix)
+}
+
+// EncodeDigest encodes the digest for iRODS checksum strings
+func (def *ChecksumAlgorithmDefinition) EncodeDigest(digest []byte) string {
+	if def.Base64 {
+		return base64.StdEncoding.EncodeToString(digest)
+	}
+	return hex.EncodeToString(digest)
+}
+
+// DecodeDigest decodes the digest in iRODS checksum strings
+func (def *ChecksumAlgorithmDefinition) DecodeDigest(digest string) ([]byte, error) {
+	if def.Base64 {
+		digestBytes, err := base64.StdEncoding.DecodeString(digest)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to base64 decode checksum")
+		}
+		return digestBytes, nil
+	}
+
+	digestBytes, err := hex.DecodeString(digest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to hex decode checksum")
+	}
+	return digestBytes, nil
+}
+
+// checksumAlgorithmRegistry holds checksum algorithms in the order of registration
+type checksumAlgorithmRegistry struct {
+	definitions []*ChecksumAlgorithmDefinition
+	mutex       sync.RWMutex
+}
+
+var checksumAlgorithms = &checksumAlgorithmRegistry{
+	definitions: []*ChecksumAlgorithmDefinition{
+		{
+			Algorithm:  ChecksumAlgorithmSHA256,
+			Aliases:    []string{"sha256"},
+			Prefixes:   []string{"sha2", "sha256"},
+			DigestSize: sha256.Size,
+			Base64:     true,
+			NewHash:    sha256.New,
+		},
+		{
+			Algorithm:  ChecksumAlgorithmSHA512,
+			Aliases:    []string{"sha512"},
+			Prefixes:   []string{"sha512", "sha2"},
+			DigestSize: sha512.Size,
+			Base64:     true,
+			NewHash:    sha512.New,
+		},
+		{
+			Algorithm:  ChecksumAlgorithmSHA1,
+			Aliases:    []string{"sha1"},
+			Prefixes:   []string{"sha1"},
+			DigestSize: sha1.Size,
+			Base64:     true,
+			NewHash:    sha1.New,
+		},
+		{
+			Algorithm:  ChecksumAlgorithmADLER32,
+			Aliases:    []string{"adler32"},
+			Prefixes:   []string{"adler32"},
+			DigestSize: adler32.Size,
+			Base64:     false,
+			NewHash: func() hash.Hash {
+				return adler32.New()
+			},
+		},
+		{
+			Algorithm:  ChecksumAlgorithmMD5,
+			Prefixes:   []string{"", "md5"},
+			DigestSize: md5.Size,
+			Base64:     false,
+			NewHash:    md5.New,
+		},
+	},
+}
+
+// RegisterChecksumAlgorithm registers a checksum algorithm, replaces the definition if the algorithm is already registered
+func RegisterChecksumAlgorithm(def *ChecksumAlgorithmDefinition) error {
+	if def == nil || len(def.Algorithm) == 0 {
+		return errors.New("checksum algorithm is not given")
+	}
+
+	if def.DigestSize <= 0 {
+		return errors.Errorf("invalid digest size %d for checksum algorithm %q", def.DigestSize, def.Algorithm)
+	}
+
+	if def.NewHash == nil {
+		return errors.Errorf("hash is not given for checksum algorithm %q", def.Algorithm)
+	}
+
+	if len(def.Prefixes) == 0 {
+		return errors.Errorf("scheme prefix is not given for checksum algorithm %q", def.Algorithm)
+	}
+
+	checksumAlgorithms.mutex.Lock()
+	defer checksumAlgorithms.mutex.Unlock()
+
+	for idx, registered := range checksumAlgorithms.definitions {
+		if registered.Algorithm == def.Algorithm {
+			checksumAlgorithms.definitions[idx] = def
+			return nil
+		}
+	}
+
+	checksumAlgorithms.definitions = append(checksumAlgorithms.definitions, def)
+	return nil
+}
+
+// UnregisterChecksumAlgorithm removes the checksum algorithm from registry
+func UnregisterChecksumAlgorithm(algorithm ChecksumAlgorithm) {
+	checksumAlgorithms.mutex.Lock()
+	defer checksumAlgorithms.mutex.Unlock()
+
+	for idx, registered := range checksumAlgorithms.definitions {
+		if registered.Algorithm == algorithm {
+			checksumAlgorithms.definitions = append(checksumAlgorithms.definitions[:idx], checksumAlgorithms.definitions[idx+1:]...)
+			return
+		}
+	}
+}
+
+// GetChecksumAlgorithmDefinition returns the definition of the checksum algorithm, matching the algorithm or its aliases
+func GetChecksumAlgorithmDefinition(algorithm string) (*ChecksumAlgorithmDefinition, bool) {
+	checksumAlgorithms.mutex.RLock()
+	defer checksumAlgorithms.mutex.RUnlock()
+
+	for _, def := range checksumAlgorithms.definitions {
+		if def.HasName(algorithm) {
+			return def, true
+		}
+	}
+	return nil, false
+}
+
+// GetChecksumAlgorithmDefinitionsForPrefix returns definitions of checksum algorithms using the scheme prefix
+func GetChecksumAlgorithmDefinitionsForPrefix(prefix string) []*ChecksumAlgorithmDefinition {
+	checksumAlgorithms.mutex.RLock()
+	defer checksumAlgorithms.mutex.RUnlock()
+
+	defs := []*ChecksumAlgorithmDefinition{}
+	for _, def := range checksumAlgorithms.definitions {
+		if def.HasPrefix(prefix) {
+			defs = append(defs, def)
+		}
+	}
+	return defs
+}
+
+// GetRegisteredChecksumAlgorithms returns checksum algorithms registered
+func GetRegisteredChecksumAlgorithms() []ChecksumAlgorithm {
+	checksumAlgorithms.mutex.RLock()
+	defer checksumAlgorithms.mutex.RUnlock()
+
+	algorithms := []ChecksumAlgorithm{}
+	for _, def := range checksumAlgorithms.definitions {
+		algorithms = append(algorithms, def.Algorithm)
+	}
+	return algorithms
+}
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"fmt"
+)
+
+// IRODSClientHintsPlugin contains information of a plugin installed on the server
+type IRODSClientHintsPlugin struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Version string `json:"version"`
+}
+
+// IRODSClientHints contains hints for clients reported by the server
+type IRODSClientHints struct {
+	HashScheme      string                    `json:"hash_scheme"`       // default hash scheme of the server, e.g., "SHA256"
+	MatchHashPolicy string                    `json:"match_hash_policy"` // "compatible" or "strict"
+	SpecificQueries []string                  `json:"specific_queries"`
+	Plugins         []*IRODSClientHintsPlugin `json:"plugins"`
+}
+
+// GetChecksumAlgorithm returns checksum algorithm the server uses by default, unknown if not reported
+func (hints *IRODSClientHints) GetChecksumAlgorithm() ChecksumAlgorithm {
+	return GetChecksumAlgorithm(hints.HashScheme)
+}
+
+// HasSpecificQuery returns true if the specific query is available
+func (hints *IRODSClientHints) HasSpecificQuery(name string) bool {
+	for _, query := range hints.SpecificQueries {
+		if query == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPlugins returns plugins of the type, e.g., "resource", "auth" or "network"
+func (hints *IRODSClientHints) GetPlugins(pluginType string) []*IRODSClientHintsPlugin {
+	plugins := []*IRODSClientHintsPlugin{}
+	for _, plugin := range hints.Plugins {
+		if plugin.Type == pluginType {
+			plugins = append(plugins, plugin)
+		}
+	}
+	return plugins
+}
+
+// ToString stringifies the object
+func (hints *IRODSClientHints) ToString() string {
+	return fmt.Sprintf("<IRODSClientHints %s %s %d specific queries %d plugins>", hints.HashScheme, hints.MatchHashPolicy, len(hints.SpecificQueries), len(hints.Plugins))
+}
@@ -5,6 +5,20 @@ import (
 	"time"
 )
 
+// SpecialCollectionType is a type of special collections, such as mounted collections
+type SpecialCollectionType string
+
+const (
+	// NormalCollectionType is a type of normal collections
+	NormalCollectionType SpecialCollectionType = ""
+	// MountPointCollectionType is a type of collections mounting a physical directory
+	MountPointCollectionType SpecialCollectionType = "mountPoint"
+	// LinkPointCollectionType is a type of collections linking another collection
+	LinkPointCollectionType SpecialCollectionType = "linkPoint"
+	// TarStructFileCollectionType is a type of collections mounting a tar struct file
+	TarStructFileCollectionType SpecialCollectionType = "tarStructFile"
+)
+
 // IRODSCollection contains irods collection information
 type IRODSCollection struct {
 	ID int64 `json:"id"`
@@ -18,9 +32,22 @@ type IRODSCollection struct {
 	CreateTime time.Time `json:"create_time"`
 	// ModifyTime has last modified time
 	ModifyTime time.Time `json:"modify_time"`
+	// Inheritance has ACL inheritance flag
+	Inheritance bool `json:"inheritance"`
+	// SpecialType has the type of special collection, empty for normal collections
+	SpecialType SpecialCollectionType `json:"special_type,omitempty"`
+	// SpecialInfo1 has the physical path for mount points, the struct file path for struct files or the linked collection for link points
+	SpecialInfo1 string `json:"special_info1,omitempty"`
+	// SpecialInfo2 has the resource for mount points or the cache info for struct files
+	SpecialInfo2 string `json:"special_info2,omitempty"`
+}
+
+// IsSpecial returns true if the collection is a special collection, such as mounted collections
+func (coll *IRODSCollection) IsSpecial() bool {
+	return coll.SpecialType != NormalCollectionType
 }
 
 // ToString stringifies the object
 func (coll *IRODSCollection) ToString() string {
-	return fmt.Sprintf("<IRODSCollection %d %s %s %s>", coll.ID, coll.Path, coll.CreateTime, coll.ModifyTime)
+	return fmt.Sprintf("<IRODSCollection %d %s %s %s %t>", coll.ID, coll.Path, coll.CreateTime, coll.ModifyTime, coll.Inheritance)
 }
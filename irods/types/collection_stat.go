@@ -8,9 +8,10 @@ import (
 type IRODSCollectionStat struct {
 	TotalSize       int64 `json:"total_size"`
 	DataObjectCount int64 `json:"data_object_count"`
+	CollectionCount int64 `json:"collection_count"` // number of sub-collections
 }
 
 // ToString stringifies the object
 func (obj *IRODSCollectionStat) ToString() string {
-	return fmt.Sprintf("<IRODSCollectionStat %d %d>", obj.TotalSize, obj.DataObjectCount)
+	return fmt.Sprintf("<IRODSCollectionStat %d %d %d>", obj.TotalSize, obj.DataObjectCount, obj.CollectionCount)
 }
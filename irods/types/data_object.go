@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"time"
 )
 
 // IRODSDataObject contains irods data object information
@@ -24,3 +25,17 @@ type IRODSDataObject struct {
 func (obj *IRODSDataObject) ToString() string {
 	return fmt.Sprintf("<IRODSDataObject %d %s %d %s>", obj.ID, obj.Path, obj.Size, obj.DataType)
 }
+
+// IRODSDataObjectSystemMetadata contains system metadata of a replica to modify, nil fields are not modified
+type IRODSDataObjectSystemMetadata struct {
+	Size       *int64     `json:"size,omitempty"`
+	DataType   *string    `json:"data_type,omitempty"`
+	Comments   *string    `json:"comments,omitempty"`
+	ModifyTime *time.Time `json:"modify_time,omitempty"`
+	ExpiryTime *time.Time `json:"expiry_time,omitempty"`
+}
+
+// IsEmpty returns true if no field is set
+func (meta *IRODSDataObjectSystemMetadata) IsEmpty() bool {
+	return meta.Size == nil && meta.DataType == nil && meta.Comments == nil && meta.ModifyTime == nil && meta.ExpiryTime == nil
+}
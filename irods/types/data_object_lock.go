@@ -8,7 +8,7 @@ const (
 	DataObjectLockTypeRead DataObjectLockType = "readLockType"
 	// DataObjectLockTypeWrite is for write lock
 	DataObjectLockTypeWrite DataObjectLockType = "writeLockType"
-	// DataObjectLockTypeWrite is for write lock
+	// DataObjectLockTypeUnlock is for unlock
 	DataObjectLockTypeUnlock DataObjectLockType = "unlockType"
 )
 
@@ -24,6 +24,18 @@ func (t DataObjectLockType) GetFileOpenMode() FileOpenMode {
 	}
 }
 
+// GetDataObjectLockTypeFromFlockType returns DataObjectLockType for l_type of flock returned by the server
+func GetDataObjectLockTypeFromFlockType(flockType int) DataObjectLockType {
+	switch flockType {
+	case 0: // F_RDLCK
+		return DataObjectLockTypeRead
+	case 1: // F_WRLCK
+		return DataObjectLockTypeWrite
+	default: // F_UNLCK
+		return DataObjectLockTypeUnlock
+	}
+}
+
 // DataObjectLockCommand is a type for data object lock command
 type DataObjectLockCommand string
 
@@ -1,8 +1,6 @@
 package types
 
 import (
-	"encoding/base64"
-	"encoding/hex"
 	"fmt"
 	"strings"
 
@@ -27,43 +25,25 @@ const (
 	ChecksumAlgorithmUnknown ChecksumAlgorithm = ""
 )
 
-// GetChecksumAlgorithm returns checksum algorithm from string
+// GetChecksumAlgorithm returns checksum algorithm from string, registered algorithms and their aliases are recognized
 func GetChecksumAlgorithm(checksumAlgorithm string) ChecksumAlgorithm {
-	switch strings.ToLower(checksumAlgorithm) {
-	case "sha256", strings.ToLower(string(ChecksumAlgorithmSHA256)):
-		return ChecksumAlgorithmSHA256
-	case "sha512", strings.ToLower(string(ChecksumAlgorithmSHA512)):
-		return ChecksumAlgorithmSHA512
-	case "sha1", strings.ToLower(string(ChecksumAlgorithmSHA1)):
-		return ChecksumAlgorithmSHA1
-	case "adler32", strings.ToLower(string(ChecksumAlgorithmADLER32)):
-		return ChecksumAlgorithmADLER32
-	case strings.ToLower(string(ChecksumAlgorithmMD5)):
-		return ChecksumAlgorithmMD5
-	default:
+	def, ok := GetChecksumAlgorithmDefinition(checksumAlgorithm)
+	if !ok {
 		return ChecksumAlgorithmUnknown
 	}
+	return def.Algorithm
 }
 
 // GetChecksumDigestSize returns checksum digest size
 func GetChecksumDigestSize(checksumAlgorithm ChecksumAlgorithm) int {
-	switch checksumAlgorithm {
-	case ChecksumAlgorithmSHA256:
-		return 256 / 8
-	case ChecksumAlgorithmSHA512:
-		return 512 / 8
-	case ChecksumAlgorithmSHA1:
-		return 160 / 8
-	case ChecksumAlgorithmADLER32:
-		return 32 / 8
-	case ChecksumAlgorithmMD5:
-		return 128 / 8
-	default:
+	def, ok := GetChecksumAlgorithmDefinition(string(checksumAlgorithm))
+	if !ok {
 		return 0
 	}
+	return def.DigestSize
 }
 
-// ParseIRODSChecksumString parses iRODS checksum string
+// ParseIRODSChecksumString parses iRODS checksum string, e.g., sha2:..., the scheme prefix is looked up in registered algorithms
 func ParseIRODSChecksumString(checksumString string) (ChecksumAlgorithm, []byte, error) {
 	sp := strings.Split(checksumString, ":")
 
@@ -71,87 +51,58 @@ func ParseIRODSChecksumString(checksumString string) (ChecksumAlgorithm, []byte,
 		return ChecksumAlgorithmUnknown, nil, errors.Errorf("unexpected checksum %q", string(checksumString))
 	}
 
-	algorithm := ""
-	checksum := ""
+	prefix := ""
+	checksum := checksumString
 
-	if len(sp) == 1 {
-		algorithm = string(ChecksumAlgorithmMD5)
-		checksum = checksumString
-	} else if len(sp) >= 2 {
-		algorithm = sp[0]
+	if len(sp) == 2 {
+		prefix = sp[0]
 		checksum = sp[1]
 	}
 
-	var checksumBytes []byte
-	var err error
-	if strings.HasPrefix(strings.ToLower(algorithm), "sha") {
-		// sha-x algorithms are encoded with base64
-		checksumBytes, err = base64.StdEncoding.DecodeString(checksum)
-		if err != nil {
-			return ChecksumAlgorithmUnknown, nil, errors.Wrapf(err, "failed to base64 decode checksum")
-		}
-	} else {
-		// hex encoded
-		checksumBytes, err = hex.DecodeString(checksum)
-		if err != nil {
-			return ChecksumAlgorithmUnknown, nil, errors.Wrapf(err, "failed to hex decode checksum")
-		}
+	defs := GetChecksumAlgorithmDefinitionsForPrefix(prefix)
+	if len(defs) == 0 {
+		return ChecksumAlgorithmUnknown, nil, errors.Errorf("unknown checksum algorithm %q", prefix)
 	}
 
-	if strings.ToLower(algorithm) == "sha2" {
-		// sha256
-		if len(checksumBytes) == GetChecksumDigestSize(ChecksumAlgorithmSHA256) {
-			return ChecksumAlgorithmSHA256, checksumBytes, nil
-		} else if len(checksumBytes) == GetChecksumDigestSize(ChecksumAlgorithmSHA512) {
-			return ChecksumAlgorithmSHA512, checksumBytes, nil
+	// algorithms sharing the prefix, e.g., sha2 for SHA-256 and SHA-512, are distinguished by digest size
+	var decodeErr error
+	checksumLen := 0
+	for _, def := range defs {
+		checksumBytes, err := def.DecodeDigest(checksum)
+		if err != nil {
+			decodeErr = err
+			continue
 		}
-	} else if strings.ToLower(algorithm) == "sha512" {
-		if len(checksumBytes) == GetChecksumDigestSize(ChecksumAlgorithmSHA512) {
-			return ChecksumAlgorithmSHA512, checksumBytes, nil
+
+		if len(checksumBytes) == def.DigestSize {
+			return def.Algorithm, checksumBytes, nil
 		}
-	}
 
-	checksumAlgorithm := GetChecksumAlgorithm(algorithm)
-	if checksumAlgorithm == ChecksumAlgorithmUnknown {
-		return ChecksumAlgorithmUnknown, nil, errors.Errorf("unknown checksum algorithm %q, len %d", algorithm, len(checksumBytes))
+		checksumLen = len(checksumBytes)
 	}
 
-	if len(checksumBytes) != GetChecksumDigestSize(checksumAlgorithm) {
-		return ChecksumAlgorithmUnknown, nil, errors.Errorf("unknown checksum algorithm %q, len %d", algorithm, len(checksumBytes))
+	if decodeErr != nil && checksumLen == 0 {
+		return ChecksumAlgorithmUnknown, nil, decodeErr
 	}
 
-	return checksumAlgorithm, checksumBytes, nil
+	return ChecksumAlgorithmUnknown, nil, errors.Errorf("unknown checksum algorithm %q, len %d", prefix, checksumLen)
 }
 
 // MakeIRODSChecksumString makes iRODS checksum string
 func MakeIRODSChecksumString(algorithm ChecksumAlgorithm, checksum []byte) (string, error) {
-	if strings.HasPrefix(strings.ToLower(string(algorithm)), "sha") {
-		// sha-x algorithms are encoded with base64
-		checksumString := base64.StdEncoding.EncodeToString(checksum)
-
-		switch algorithm {
-		case ChecksumAlgorithmSHA1:
-			return fmt.Sprintf("%s:%s", "sha1", checksumString), nil
-		case ChecksumAlgorithmSHA256:
-			return fmt.Sprintf("%s:%s", "sha2", checksumString), nil
-		case ChecksumAlgorithmSHA512:
-			return fmt.Sprintf("%s:%s", "sha512", checksumString), nil
-		default:
-			return "", errors.Errorf("unknown algorithm %q", algorithm)
-		}
+	def, ok := GetChecksumAlgorithmDefinition(string(algorithm))
+	if !ok {
+		return "", errors.Errorf("unknown algorithm %q", algorithm)
 	}
 
-	// hex encoded
-	checksumString := hex.EncodeToString(checksum)
+	checksumString := def.EncodeDigest(checksum)
 
-	switch algorithm {
-	case ChecksumAlgorithmADLER32:
-		return fmt.Sprintf("%s:%s", "adler32", checksumString), nil
-	case ChecksumAlgorithmMD5:
+	prefix := def.Prefixes[0]
+	if len(prefix) == 0 {
 		return checksumString, nil
-	default:
-		return "", errors.Errorf("unknown algorithm %q", algorithm)
 	}
+
+	return fmt.Sprintf("%s:%s", prefix, checksumString), nil
 }
 
 // EncryptionAlgorithm determines encryption algorithm
@@ -201,3 +152,13 @@ func GetEncryptionAlgorithm(encryptionAlgorithm string) EncryptionAlgorithm {
 		return EncryptionAlgorithmUnknown
 	}
 }
+
+// IsAES256 checks if the encryption algorithm is one of AES-256 algorithms
+func (algorithm EncryptionAlgorithm) IsAES256() bool {
+	switch algorithm {
+	case EncryptionAlgorithmAES256CBC, EncryptionAlgorithmAES256CTR, EncryptionAlgorithmAES256CFB, EncryptionAlgorithmAES256OFB:
+		return true
+	default:
+		return false
+	}
+}
@@ -164,6 +164,37 @@ func IsAuthError(err error) bool {
 	return errors.As(err, &authErr)
 }
 
+// SSLNegotiationError contains SSL negotiation error information
+type SSLNegotiationError struct {
+}
+
+// NewSSLNegotiationError creates an error for failures of client-server negotiation or SSL handshake
+func NewSSLNegotiationError() error {
+	return &SSLNegotiationError{}
+}
+
+// Error returns error message
+func (err *SSLNegotiationError) Error() string {
+	return "SSL negotiation error"
+}
+
+// Is tests type of error
+func (err *SSLNegotiationError) Is(other error) bool {
+	_, ok := other.(*SSLNegotiationError)
+	return ok
+}
+
+// ToString stringifies the object
+func (err *SSLNegotiationError) ToString() string {
+	return "<SSLNegotiationError>"
+}
+
+// IsSSLNegotiationError evaluates if the given error is SSL negotiation failure
+func IsSSLNegotiationError(err error) bool {
+	var sslErr *SSLNegotiationError
+	return errors.As(err, &sslErr)
+}
+
 // AuthOperationNotFoundError contains auth operation not found error information
 type AuthOperationNotFoundError struct {
 	OperationName string
@@ -268,6 +299,42 @@ func IsConnectionPoolFullError(err error) bool {
 	return errors.As(err, &connectionPoolFullErr)
 }
 
+// NotEnoughConnectionsError contains not enough connections error information
+type NotEnoughConnectionsError struct {
+	Requested int
+	Available int
+}
+
+// NewNotEnoughConnectionsError creates an error for not enough connections
+func NewNotEnoughConnectionsError(requested int, available int) error {
+	return &NotEnoughConnectionsError{
+		Requested: requested,
+		Available: available,
+	}
+}
+
+// Error returns error message
+func (err *NotEnoughConnectionsError) Error() string {
+	return fmt.Sprintf("not enough connections (requested: %d, available: %d)", err.Requested, err.Available)
+}
+
+// Is tests type of error
+func (err *NotEnoughConnectionsError) Is(other error) bool {
+	_, ok := other.(*NotEnoughConnectionsError)
+	return ok
+}
+
+// ToString stringifies the object
+func (err *NotEnoughConnectionsError) ToString() string {
+	return fmt.Sprintf("<NotEnoughConnectionsError %d/%d>", err.Available, err.Requested)
+}
+
+// IsNotEnoughConnectionsError evaluates if the given error is not enough connections error
+func IsNotEnoughConnectionsError(err error) bool {
+	var notEnoughConnectionsErr *NotEnoughConnectionsError
+	return errors.As(err, &notEnoughConnectionsErr)
+}
+
 // CollectionNotEmptyError contains collection not empty error information
 type CollectionNotEmptyError struct {
 	Path string
@@ -404,6 +471,78 @@ func IsFileAlreadyExistError(err error) bool {
 	return errors.As(err, &fileAlreadyExistErr)
 }
 
+// CrossCollectionRenameError contains error information of renaming an entry to another collection, e.g., across zones or into itself
+type CrossCollectionRenameError struct {
+	SourcePath string
+	DestPath   string
+	Reason     string
+}
+
+// NewCrossCollectionRenameError creates an error for renaming to another collection
+func NewCrossCollectionRenameError(sourcePath string, destPath string, reason string) error {
+	return &CrossCollectionRenameError{
+		SourcePath: sourcePath,
+		DestPath:   destPath,
+		Reason:     reason,
+	}
+}
+
+// Error returns error message
+func (err *CrossCollectionRenameError) Error() string {
+	return fmt.Sprintf("failed to rename %q to %q: %s", err.SourcePath, err.DestPath, err.Reason)
+}
+
+// Is tests type of error
+func (err *CrossCollectionRenameError) Is(other error) bool {
+	_, ok := other.(*CrossCollectionRenameError)
+	return ok
+}
+
+// ToString stringifies the object
+func (err *CrossCollectionRenameError) ToString() string {
+	return fmt.Sprintf("<CrossCollectionRenameError %q %q %q>", err.SourcePath, err.DestPath, err.Reason)
+}
+
+// IsCrossCollectionRenameError checks if the given error is CrossCollectionRenameError
+func IsCrossCollectionRenameError(err error) bool {
+	var crossCollectionRenameErr *CrossCollectionRenameError
+	return errors.As(err, &crossCollectionRenameErr)
+}
+
+// PathInUseError contains path in use error information
+type PathInUseError struct {
+	Path string
+}
+
+// NewPathInUseError creates an error for path in use
+func NewPathInUseError(p string) error {
+	return &PathInUseError{
+		Path: p,
+	}
+}
+
+// Error returns error message
+func (err *PathInUseError) Error() string {
+	return fmt.Sprintf("path %q is in use by another operation", err.Path)
+}
+
+// Is tests type of error
+func (err *PathInUseError) Is(other error) bool {
+	_, ok := other.(*PathInUseError)
+	return ok
+}
+
+// ToString stringifies the object
+func (err *PathInUseError) ToString() string {
+	return fmt.Sprintf("<PathInUseError %q>", err.Path)
+}
+
+// IsPathInUseError checks if the given error is PathInUseError
+func IsPathInUseError(err error) bool {
+	var pathInUseErr *PathInUseError
+	return errors.As(err, &pathInUseErr)
+}
+
 // TicketNotFoundError contains ticket not found error information
 type TicketNotFoundError struct {
 	Ticket string
@@ -540,9 +679,24 @@ func (err *IRODSError) Error() string {
 }
 
 // Is tests type of error
+// if other has an error code, the error code (without sub code) must match
+// if other is an IRODSErrorCategory, the error code must belong to the category
 func (err *IRODSError) Is(other error) bool {
-	_, ok := other.(*IRODSError)
-	return ok
+	switch otherErr := other.(type) {
+	case *IRODSError:
+		if otherErr.Code == 0 {
+			// any iRODS error
+			return true
+		}
+
+		mainErrCode, _ := common.SplitIRODSErrorCode(err.Code)
+		otherMainErrCode, _ := common.SplitIRODSErrorCode(otherErr.Code)
+		return mainErrCode == otherMainErrCode
+	case *IRODSErrorCategory:
+		return otherErr.Contains(err.Code)
+	default:
+		return false
+	}
 }
 
 // GetCode returns error code
@@ -555,6 +709,131 @@ func (err *IRODSError) ToString() string {
 	return fmt.Sprintf("<IRODSError %d %s %s>", err.Code, err.Message, err.ContextualMessage)
 }
 
+// IRODSCodedError is an interface for errors carrying an iRODS error code
+type IRODSCodedError interface {
+	error
+	GetCode() common.ErrorCode
+}
+
+// IRODSErrorCategory groups iRODS error codes, use with errors.Is to test if an error belongs to the category
+type IRODSErrorCategory struct {
+	Name  string
+	Codes []common.ErrorCode
+}
+
+// NewIRODSErrorCategory creates a new IRODSErrorCategory
+func NewIRODSErrorCategory(name string, codes ...common.ErrorCode) *IRODSErrorCategory {
+	return &IRODSErrorCategory{
+		Name:  name,
+		Codes: codes,
+	}
+}
+
+// Error returns error message
+func (category *IRODSErrorCategory) Error() string {
+	return category.Name
+}
+
+// Contains checks if the given error code belongs to the category
+func (category *IRODSErrorCategory) Contains(code common.ErrorCode) bool {
+	mainErrCode, _ := common.SplitIRODSErrorCode(code)
+	for _, categoryCode := range category.Codes {
+		if categoryCode == mainErrCode {
+			return true
+		}
+	}
+	return false
+}
+
+//go:generate go run gen_error_sentinel.go
+
+// sentinel errors for commonly used iRODS error codes, use with errors.Is
+// e.g., errors.Is(err, types.ErrNoAccessPermission)
+// use GetIRODSErrorSentinel for other error codes
+var (
+	ErrNoAPIPrivilege             = GetIRODSErrorSentinel(common.SYS_NO_API_PRIV)
+	ErrNoPathPermission           = GetIRODSErrorSentinel(common.SYS_NO_PATH_PERMISSION)
+	ErrProxyUserNoPrivilege       = GetIRODSErrorSentinel(common.SYS_PROXYUSER_NO_PRIV)
+	ErrNoDataObjectPermission     = GetIRODSErrorSentinel(common.SYS_NO_DATA_OBJ_PERMISSION)
+	ErrUserNoPermission           = GetIRODSErrorSentinel(common.SYS_USER_NO_PERMISSION)
+	ErrNoAccessPermission         = GetIRODSErrorSentinel(common.CAT_NO_ACCESS_PERMISSION)
+	ErrInsufficientPrivilegeLevel = GetIRODSErrorSentinel(common.CAT_INSUFFICIENT_PRIVILEGE_LEVEL)
+
+	ErrInvalidAuthentication = GetIRODSErrorSentinel(common.CAT_INVALID_AUTHENTICATION)
+	ErrInvalidUser           = GetIRODSErrorSentinel(common.CAT_INVALID_USER)
+	ErrPasswordExpired       = GetIRODSErrorSentinel(common.CAT_PASSWORD_EXPIRED)
+	ErrPAMPasswordFailed     = GetIRODSErrorSentinel(common.PAM_AUTH_PASSWORD_FAILED)
+
+	ErrQuotaExceeded = GetIRODSErrorSentinel(common.SYS_RESC_QUOTA_EXCEEDED)
+
+	ErrNoRowsFound            = GetIRODSErrorSentinel(common.CAT_NO_ROWS_FOUND)
+	ErrUnknownFile            = GetIRODSErrorSentinel(common.CAT_UNKNOWN_FILE)
+	ErrUnknownCollection      = GetIRODSErrorSentinel(common.CAT_UNKNOWN_COLLECTION)
+	ErrCollectionNotEmpty     = GetIRODSErrorSentinel(common.CAT_COLLECTION_NOT_EMPTY)
+	ErrNameExistsAsCollection = GetIRODSErrorSentinel(common.CAT_NAME_EXISTS_AS_COLLECTION)
+	ErrNameExistsAsDataObject = GetIRODSErrorSentinel(common.CAT_NAME_EXISTS_AS_DATAOBJ)
+	ErrOverwriteWithoutForce  = GetIRODSErrorSentinel(common.OVERWRITE_WITHOUT_FORCE_FLAG)
+
+	ErrHierarchy      = GetIRODSErrorSentinel(common.HIERARCHY_ERROR)
+	ErrNoNextResource = GetIRODSErrorSentinel(common.NO_NEXT_RESC_FOUND)
+	ErrChildNotFound  = GetIRODSErrorSentinel(common.CHILD_NOT_FOUND)
+)
+
+// GetIRODSErrorSentinel returns the sentinel error for the error code, sub codes are ignored
+// sentinels for all error codes in irods/common are generated in error_sentinel.go
+// returns nil for unknown error codes
+func GetIRODSErrorSentinel(code common.ErrorCode) *IRODSError {
+	mainErrCode, _ := common.SplitIRODSErrorCode(code)
+	if mainErrCode > 0 {
+		mainErrCode *= -1
+	}
+
+	return irodsErrorSentinels[mainErrCode]
+}
+
+// error categories, use with errors.Is
+// e.g., errors.Is(err, types.ErrPermissionDenied)
+var (
+	ErrPermissionDenied = NewIRODSErrorCategory("permission denied",
+		common.SYS_NO_API_PRIV,
+		common.SYS_NO_PATH_PERMISSION,
+		common.SYS_PROXYUSER_NO_PRIV,
+		common.SYS_NO_DATA_OBJ_PERMISSION,
+		common.SYS_USER_NO_PERMISSION,
+		common.CAT_NO_ACCESS_PERMISSION,
+		common.CAT_INSUFFICIENT_PRIVILEGE_LEVEL,
+	)
+
+	ErrAuthenticationFailed = NewIRODSErrorCategory("authentication failed",
+		common.CAT_INVALID_AUTHENTICATION,
+		common.CAT_INVALID_USER,
+		common.CAT_PASSWORD_EXPIRED,
+		common.PAM_AUTH_PASSWORD_FAILED,
+	)
+
+	ErrNotFound = NewIRODSErrorCategory("not found",
+		common.CAT_NO_ROWS_FOUND,
+		common.CAT_UNKNOWN_FILE,
+		common.CAT_UNKNOWN_COLLECTION,
+	)
+
+	ErrAlreadyExists = NewIRODSErrorCategory("already exists",
+		common.CAT_NAME_EXISTS_AS_COLLECTION,
+		common.CAT_NAME_EXISTS_AS_DATAOBJ,
+		common.OVERWRITE_WITHOUT_FORCE_FLAG,
+	)
+
+	ErrResourceHierarchy = NewIRODSErrorCategory("resource hierarchy error",
+		common.HIERARCHY_ERROR,
+		common.CHILD_EXISTS,
+		common.CHILD_NOT_FOUND,
+		common.CHILD_HAS_PARENT,
+		common.NO_NEXT_RESC_FOUND,
+		common.INVALID_RESC_CHILD_CONTEXT,
+		common.DIRECT_ARCHIVE_ACCESS,
+	)
+)
+
 // IsIRODSError checks if the given error is IRODSError
 func IsIRODSError(err error) bool {
 	var irodsErr *IRODSError
@@ -574,6 +853,139 @@ func GetIRODSErrorCode(err error) common.ErrorCode {
 	return common.ErrorCode(0)
 }
 
+// OperationError contains the context of a failed iRODS operation
+type OperationError struct {
+	Operation string
+	Path      string
+	Resource  string
+	APINumber common.APINumber
+	Err       error
+}
+
+// NewOperationError creates an operation error wrapping the given error
+func NewOperationError(operation string, path string, resource string, apiNumber common.APINumber, err error) error {
+	return &OperationError{
+		Operation: operation,
+		Path:      path,
+		Resource:  resource,
+		APINumber: apiNumber,
+		Err:       err,
+	}
+}
+
+// Error returns error message
+func (err *OperationError) Error() string {
+	target := ""
+	if len(err.Path) > 0 {
+		target += fmt.Sprintf(", path %q", err.Path)
+	}
+
+	if len(err.Resource) > 0 {
+		target += fmt.Sprintf(", resource %q", err.Resource)
+	}
+
+	return fmt.Sprintf("operation %s failed (api %d%s): %v", err.Operation, err.APINumber, target, err.Err)
+}
+
+// Is tests type of error
+func (err *OperationError) Is(other error) bool {
+	_, ok := other.(*OperationError)
+	return ok
+}
+
+// Unwrap returns the wrapped error
+func (err *OperationError) Unwrap() error {
+	return err.Err
+}
+
+// ToString stringifies the object
+func (err *OperationError) ToString() string {
+	return fmt.Sprintf("<OperationError %s %q %q %d %v>", err.Operation, err.Path, err.Resource, err.APINumber, err.Err)
+}
+
+// IsOperationError checks if the given error is OperationError
+func IsOperationError(err error) bool {
+	return errors.Is(err, &OperationError{})
+}
+
+// GetOperationError returns OperationError in the error chain, nil if not found
+func GetOperationError(err error) *OperationError {
+	var opErr *OperationError
+	if errors.As(err, &opErr) {
+		return opErr
+	}
+	return nil
+}
+
+// AccountValidationFailureReason is a reason of account validation failure
+type AccountValidationFailureReason string
+
+const (
+	// AccountValidationInvalidConfig is for accounts having invalid configuration, e.g., empty host or user
+	AccountValidationInvalidConfig AccountValidationFailureReason = "invalid_config"
+	// AccountValidationUnreachable is for servers not reachable
+	AccountValidationUnreachable AccountValidationFailureReason = "unreachable"
+	// AccountValidationHandshakeFailed is for servers rejecting the connection on startup
+	AccountValidationHandshakeFailed AccountValidationFailureReason = "handshake_failed"
+	// AccountValidationSSLNegotiationFailed is for client-server negotiation or SSL handshake failures
+	AccountValidationSSLNegotiationFailed AccountValidationFailureReason = "ssl_negotiation_failed"
+	// AccountValidationBadPassword is for wrong passwords or unknown users
+	AccountValidationBadPassword AccountValidationFailureReason = "bad_password"
+	// AccountValidationCredentialExpired is for expired passwords or PAM credentials
+	AccountValidationCredentialExpired AccountValidationFailureReason = "credential_expired"
+	// AccountValidationAuthFailed is for other authentication failures, e.g., invalid tickets
+	AccountValidationAuthFailed AccountValidationFailureReason = "auth_failed"
+)
+
+// AccountValidationError contains the reason why an account is not valid
+type AccountValidationError struct {
+	Reason AccountValidationFailureReason
+	Err    error
+}
+
+// NewAccountValidationError creates an account validation error wrapping the given error
+func NewAccountValidationError(reason AccountValidationFailureReason, err error) error {
+	return &AccountValidationError{
+		Reason: reason,
+		Err:    err,
+	}
+}
+
+// Error returns error message
+func (err *AccountValidationError) Error() string {
+	return fmt.Sprintf("account validation failed (%s): %v", err.Reason, err.Err)
+}
+
+// Is tests type of error
+func (err *AccountValidationError) Is(other error) bool {
+	_, ok := other.(*AccountValidationError)
+	return ok
+}
+
+// Unwrap returns the wrapped error
+func (err *AccountValidationError) Unwrap() error {
+	return err.Err
+}
+
+// ToString stringifies the object
+func (err *AccountValidationError) ToString() string {
+	return fmt.Sprintf("<AccountValidationError %s %v>", err.Reason, err.Err)
+}
+
+// IsAccountValidationError checks if the given error is AccountValidationError
+func IsAccountValidationError(err error) bool {
+	return errors.Is(err, &AccountValidationError{})
+}
+
+// GetAccountValidationFailureReason returns the reason of AccountValidationError in the error chain, empty if not found
+func GetAccountValidationFailureReason(err error) AccountValidationFailureReason {
+	var validationErr *AccountValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Reason
+	}
+	return ""
+}
+
 // IsPermanantFailure returns if given error is permanent failure
 func IsPermanantFailure(err error) bool {
 	if err == nil {
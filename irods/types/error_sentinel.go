@@ -0,0 +1,687 @@
+// Code generated by gen_error_sentinel.go; DO NOT EDIT.
+
+package types
+
+import "github.com/cyverse/go-irodsclient/irods/common"
+
+// irodsErrorSentinels maps error codes to sentinel errors, use GetIRODSErrorSentinel
+var irodsErrorSentinels = map[common.ErrorCode]*IRODSError{
+	common.SYS_SOCK_OPEN_ERR:                        NewIRODSError(common.SYS_SOCK_OPEN_ERR),
+	common.SYS_SOCK_LISTEN_ERR:                      NewIRODSError(common.SYS_SOCK_LISTEN_ERR),
+	common.SYS_SOCK_BIND_ERR:                        NewIRODSError(common.SYS_SOCK_BIND_ERR),
+	common.SYS_SOCK_ACCEPT_ERR:                      NewIRODSError(common.SYS_SOCK_ACCEPT_ERR),
+	common.SYS_HEADER_READ_LEN_ERR:                  NewIRODSError(common.SYS_HEADER_READ_LEN_ERR),
+	common.SYS_HEADER_WRITE_LEN_ERR:                 NewIRODSError(common.SYS_HEADER_WRITE_LEN_ERR),
+	common.SYS_HEADER_TPYE_LEN_ERR:                  NewIRODSError(common.SYS_HEADER_TPYE_LEN_ERR),
+	common.SYS_CAUGHT_SIGNAL:                        NewIRODSError(common.SYS_CAUGHT_SIGNAL),
+	common.SYS_GETSTARTUP_PACK_ERR:                  NewIRODSError(common.SYS_GETSTARTUP_PACK_ERR),
+	common.SYS_EXCEED_CONNECT_CNT:                   NewIRODSError(common.SYS_EXCEED_CONNECT_CNT),
+	common.SYS_USER_NOT_ALLOWED_TO_CONN:             NewIRODSError(common.SYS_USER_NOT_ALLOWED_TO_CONN),
+	common.SYS_READ_MSG_BODY_INPUT_ERR:              NewIRODSError(common.SYS_READ_MSG_BODY_INPUT_ERR),
+	common.SYS_UNMATCHED_API_NUM:                    NewIRODSError(common.SYS_UNMATCHED_API_NUM),
+	common.SYS_NO_API_PRIV:                          NewIRODSError(common.SYS_NO_API_PRIV),
+	common.SYS_API_INPUT_ERR:                        NewIRODSError(common.SYS_API_INPUT_ERR),
+	common.SYS_PACK_INSTRUCT_FORMAT_ERR:             NewIRODSError(common.SYS_PACK_INSTRUCT_FORMAT_ERR),
+	common.SYS_MALLOC_ERR:                           NewIRODSError(common.SYS_MALLOC_ERR),
+	common.SYS_GET_HOSTNAME_ERR:                     NewIRODSError(common.SYS_GET_HOSTNAME_ERR),
+	common.SYS_OUT_OF_FILE_DESC:                     NewIRODSError(common.SYS_OUT_OF_FILE_DESC),
+	common.SYS_FILE_DESC_OUT_OF_RANGE:               NewIRODSError(common.SYS_FILE_DESC_OUT_OF_RANGE),
+	common.SYS_UNRECOGNIZED_REMOTE_FLAG:             NewIRODSError(common.SYS_UNRECOGNIZED_REMOTE_FLAG),
+	common.SYS_INVALID_SERVER_HOST:                  NewIRODSError(common.SYS_INVALID_SERVER_HOST),
+	common.SYS_SVR_TO_SVR_CONNECT_FAILED:            NewIRODSError(common.SYS_SVR_TO_SVR_CONNECT_FAILED),
+	common.SYS_BAD_FILE_DESCRIPTOR:                  NewIRODSError(common.SYS_BAD_FILE_DESCRIPTOR),
+	common.SYS_INTERNAL_NULL_INPUT_ERR:              NewIRODSError(common.SYS_INTERNAL_NULL_INPUT_ERR),
+	common.SYS_CONFIG_FILE_ERR:                      NewIRODSError(common.SYS_CONFIG_FILE_ERR),
+	common.SYS_INVALID_ZONE_NAME:                    NewIRODSError(common.SYS_INVALID_ZONE_NAME),
+	common.SYS_COPY_LEN_ERR:                         NewIRODSError(common.SYS_COPY_LEN_ERR),
+	common.SYS_PORT_COOKIE_ERR:                      NewIRODSError(common.SYS_PORT_COOKIE_ERR),
+	common.SYS_KEY_VAL_TABLE_ERR:                    NewIRODSError(common.SYS_KEY_VAL_TABLE_ERR),
+	common.SYS_INVALID_RESC_TYPE:                    NewIRODSError(common.SYS_INVALID_RESC_TYPE),
+	common.SYS_INVALID_FILE_PATH:                    NewIRODSError(common.SYS_INVALID_FILE_PATH),
+	common.SYS_INVALID_RESC_INPUT:                   NewIRODSError(common.SYS_INVALID_RESC_INPUT),
+	common.SYS_INVALID_PORTAL_OPR:                   NewIRODSError(common.SYS_INVALID_PORTAL_OPR),
+	common.SYS_PARA_OPR_NO_SUPPORT:                  NewIRODSError(common.SYS_PARA_OPR_NO_SUPPORT),
+	common.SYS_INVALID_OPR_TYPE:                     NewIRODSError(common.SYS_INVALID_OPR_TYPE),
+	common.SYS_NO_PATH_PERMISSION:                   NewIRODSError(common.SYS_NO_PATH_PERMISSION),
+	common.SYS_NO_ICAT_SERVER_ERR:                   NewIRODSError(common.SYS_NO_ICAT_SERVER_ERR),
+	common.SYS_AGENT_INIT_ERR:                       NewIRODSError(common.SYS_AGENT_INIT_ERR),
+	common.SYS_PROXYUSER_NO_PRIV:                    NewIRODSError(common.SYS_PROXYUSER_NO_PRIV),
+	common.SYS_NO_DATA_OBJ_PERMISSION:               NewIRODSError(common.SYS_NO_DATA_OBJ_PERMISSION),
+	common.SYS_DELETE_DISALLOWED:                    NewIRODSError(common.SYS_DELETE_DISALLOWED),
+	common.SYS_OPEN_REI_FILE_ERR:                    NewIRODSError(common.SYS_OPEN_REI_FILE_ERR),
+	common.SYS_NO_RCAT_SERVER_ERR:                   NewIRODSError(common.SYS_NO_RCAT_SERVER_ERR),
+	common.SYS_UNMATCH_PACK_INSTRUCTI_NAME:          NewIRODSError(common.SYS_UNMATCH_PACK_INSTRUCTI_NAME),
+	common.SYS_SVR_TO_CLI_MSI_NO_EXIST:              NewIRODSError(common.SYS_SVR_TO_CLI_MSI_NO_EXIST),
+	common.SYS_COPY_ALREADY_IN_RESC:                 NewIRODSError(common.SYS_COPY_ALREADY_IN_RESC),
+	common.SYS_RECONN_OPR_MISMATCH:                  NewIRODSError(common.SYS_RECONN_OPR_MISMATCH),
+	common.SYS_INPUT_PERM_OUT_OF_RANGE:              NewIRODSError(common.SYS_INPUT_PERM_OUT_OF_RANGE),
+	common.SYS_FORK_ERROR:                           NewIRODSError(common.SYS_FORK_ERROR),
+	common.SYS_PIPE_ERROR:                           NewIRODSError(common.SYS_PIPE_ERROR),
+	common.SYS_EXEC_CMD_STATUS_SZ_ERROR:             NewIRODSError(common.SYS_EXEC_CMD_STATUS_SZ_ERROR),
+	common.SYS_PATH_IS_NOT_A_FILE:                   NewIRODSError(common.SYS_PATH_IS_NOT_A_FILE),
+	common.SYS_UNMATCHED_SPEC_COLL_TYPE:             NewIRODSError(common.SYS_UNMATCHED_SPEC_COLL_TYPE),
+	common.SYS_TOO_MANY_QUERY_RESULT:                NewIRODSError(common.SYS_TOO_MANY_QUERY_RESULT),
+	common.SYS_SPEC_COLL_NOT_IN_CACHE:               NewIRODSError(common.SYS_SPEC_COLL_NOT_IN_CACHE),
+	common.SYS_SPEC_COLL_OBJ_NOT_EXIST:              NewIRODSError(common.SYS_SPEC_COLL_OBJ_NOT_EXIST),
+	common.SYS_REG_OBJ_IN_SPEC_COLL:                 NewIRODSError(common.SYS_REG_OBJ_IN_SPEC_COLL),
+	common.SYS_DEST_SPEC_COLL_SUB_EXIST:             NewIRODSError(common.SYS_DEST_SPEC_COLL_SUB_EXIST),
+	common.SYS_SRC_DEST_SPEC_COLL_CONFLICT:          NewIRODSError(common.SYS_SRC_DEST_SPEC_COLL_CONFLICT),
+	common.SYS_UNKNOWN_SPEC_COLL_CLASS:              NewIRODSError(common.SYS_UNKNOWN_SPEC_COLL_CLASS),
+	common.SYS_DUPLICATE_XMSG_TICKET:                NewIRODSError(common.SYS_DUPLICATE_XMSG_TICKET),
+	common.SYS_UNMATCHED_XMSG_TICKET:                NewIRODSError(common.SYS_UNMATCHED_XMSG_TICKET),
+	common.SYS_NO_XMSG_FOR_MSG_NUMBER:               NewIRODSError(common.SYS_NO_XMSG_FOR_MSG_NUMBER),
+	common.SYS_COLLINFO_2_FORMAT_ERR:                NewIRODSError(common.SYS_COLLINFO_2_FORMAT_ERR),
+	common.SYS_CACHE_STRUCT_FILE_RESC_ERR:           NewIRODSError(common.SYS_CACHE_STRUCT_FILE_RESC_ERR),
+	common.SYS_NOT_SUPPORTED:                        NewIRODSError(common.SYS_NOT_SUPPORTED),
+	common.SYS_TAR_STRUCT_FILE_EXTRACT_ERR:          NewIRODSError(common.SYS_TAR_STRUCT_FILE_EXTRACT_ERR),
+	common.SYS_STRUCT_FILE_DESC_ERR:                 NewIRODSError(common.SYS_STRUCT_FILE_DESC_ERR),
+	common.SYS_TAR_OPEN_ERR:                         NewIRODSError(common.SYS_TAR_OPEN_ERR),
+	common.SYS_TAR_EXTRACT_ALL_ERR:                  NewIRODSError(common.SYS_TAR_EXTRACT_ALL_ERR),
+	common.SYS_TAR_CLOSE_ERR:                        NewIRODSError(common.SYS_TAR_CLOSE_ERR),
+	common.SYS_STRUCT_FILE_PATH_ERR:                 NewIRODSError(common.SYS_STRUCT_FILE_PATH_ERR),
+	common.SYS_MOUNT_MOUNTED_COLL_ERR:               NewIRODSError(common.SYS_MOUNT_MOUNTED_COLL_ERR),
+	common.SYS_COLL_NOT_MOUNTED_ERR:                 NewIRODSError(common.SYS_COLL_NOT_MOUNTED_ERR),
+	common.SYS_STRUCT_FILE_BUSY_ERR:                 NewIRODSError(common.SYS_STRUCT_FILE_BUSY_ERR),
+	common.SYS_STRUCT_FILE_INMOUNTED_COLL:           NewIRODSError(common.SYS_STRUCT_FILE_INMOUNTED_COLL),
+	common.SYS_COPY_NOT_EXIST_IN_RESC:               NewIRODSError(common.SYS_COPY_NOT_EXIST_IN_RESC),
+	common.SYS_RESC_DOES_NOT_EXIST:                  NewIRODSError(common.SYS_RESC_DOES_NOT_EXIST),
+	common.SYS_COLLECTION_NOT_EMPTY:                 NewIRODSError(common.SYS_COLLECTION_NOT_EMPTY),
+	common.SYS_OBJ_TYPE_NOT_STRUCT_FILE:             NewIRODSError(common.SYS_OBJ_TYPE_NOT_STRUCT_FILE),
+	common.SYS_WRONG_RESC_POLICY_FOR_BUN_OPR:        NewIRODSError(common.SYS_WRONG_RESC_POLICY_FOR_BUN_OPR),
+	common.SYS_DIR_IN_VAULT_NOT_EMPTY:               NewIRODSError(common.SYS_DIR_IN_VAULT_NOT_EMPTY),
+	common.SYS_OPR_FLAG_NOT_SUPPORT:                 NewIRODSError(common.SYS_OPR_FLAG_NOT_SUPPORT),
+	common.SYS_TAR_APPEND_ERR:                       NewIRODSError(common.SYS_TAR_APPEND_ERR),
+	common.SYS_INVALID_PROTOCOL_TYPE:                NewIRODSError(common.SYS_INVALID_PROTOCOL_TYPE),
+	common.SYS_UDP_CONNECT_ERR:                      NewIRODSError(common.SYS_UDP_CONNECT_ERR),
+	common.SYS_UDP_TRANSFER_ERR:                     NewIRODSError(common.SYS_UDP_TRANSFER_ERR),
+	common.SYS_UDP_NO_SUPPORT_ERR:                   NewIRODSError(common.SYS_UDP_NO_SUPPORT_ERR),
+	common.SYS_READ_MSG_BODY_LEN_ERR:                NewIRODSError(common.SYS_READ_MSG_BODY_LEN_ERR),
+	common.CROSS_ZONE_SOCK_CONNECT_ERR:              NewIRODSError(common.CROSS_ZONE_SOCK_CONNECT_ERR),
+	common.SYS_NO_FREE_RE_THREAD:                    NewIRODSError(common.SYS_NO_FREE_RE_THREAD),
+	common.SYS_BAD_RE_THREAD_INX:                    NewIRODSError(common.SYS_BAD_RE_THREAD_INX),
+	common.SYS_CANT_DIRECTLY_ACC_COMPOUND_RESC:      NewIRODSError(common.SYS_CANT_DIRECTLY_ACC_COMPOUND_RESC),
+	common.SYS_SRC_DEST_RESC_COMPOUND_TYPE:          NewIRODSError(common.SYS_SRC_DEST_RESC_COMPOUND_TYPE),
+	common.SYS_CACHE_RESC_NOT_ON_SAME_HOST:          NewIRODSError(common.SYS_CACHE_RESC_NOT_ON_SAME_HOST),
+	common.SYS_NO_CACHE_RESC_IN_GRP:                 NewIRODSError(common.SYS_NO_CACHE_RESC_IN_GRP),
+	common.SYS_UNMATCHED_RESC_IN_RESC_GRP:           NewIRODSError(common.SYS_UNMATCHED_RESC_IN_RESC_GRP),
+	common.SYS_CANT_MV_BUNDLE_DATA_TO_TRASH:         NewIRODSError(common.SYS_CANT_MV_BUNDLE_DATA_TO_TRASH),
+	common.SYS_CANT_MV_BUNDLE_DATA_BY_COPY:          NewIRODSError(common.SYS_CANT_MV_BUNDLE_DATA_BY_COPY),
+	common.SYS_EXEC_TAR_ERR:                         NewIRODSError(common.SYS_EXEC_TAR_ERR),
+	common.SYS_CANT_CHKSUM_COMP_RESC_DATA:           NewIRODSError(common.SYS_CANT_CHKSUM_COMP_RESC_DATA),
+	common.SYS_CANT_CHKSUM_BUNDLED_DATA:             NewIRODSError(common.SYS_CANT_CHKSUM_BUNDLED_DATA),
+	common.SYS_RESC_IS_DOWN:                         NewIRODSError(common.SYS_RESC_IS_DOWN),
+	common.SYS_UPDATE_REPL_INFO_ERR:                 NewIRODSError(common.SYS_UPDATE_REPL_INFO_ERR),
+	common.SYS_COLL_LINK_PATH_ERR:                   NewIRODSError(common.SYS_COLL_LINK_PATH_ERR),
+	common.SYS_LINK_CNT_EXCEEDED_ERR:                NewIRODSError(common.SYS_LINK_CNT_EXCEEDED_ERR),
+	common.SYS_CROSS_ZONE_MV_NOT_SUPPORTED:          NewIRODSError(common.SYS_CROSS_ZONE_MV_NOT_SUPPORTED),
+	common.SYS_RESC_QUOTA_EXCEEDED:                  NewIRODSError(common.SYS_RESC_QUOTA_EXCEEDED),
+	common.SYS_RENAME_STRUCT_COUNT_EXCEEDED:         NewIRODSError(common.SYS_RENAME_STRUCT_COUNT_EXCEEDED),
+	common.SYS_BULK_REG_COUNT_EXCEEDED:              NewIRODSError(common.SYS_BULK_REG_COUNT_EXCEEDED),
+	common.SYS_REQUESTED_BUF_TOO_LARGE:              NewIRODSError(common.SYS_REQUESTED_BUF_TOO_LARGE),
+	common.SYS_INVALID_RESC_FOR_BULK_OPR:            NewIRODSError(common.SYS_INVALID_RESC_FOR_BULK_OPR),
+	common.SYS_SOCK_READ_TIMEDOUT:                   NewIRODSError(common.SYS_SOCK_READ_TIMEDOUT),
+	common.SYS_SOCK_READ_ERR:                        NewIRODSError(common.SYS_SOCK_READ_ERR),
+	common.SYS_CONNECT_CONTROL_CONFIG_ERR:           NewIRODSError(common.SYS_CONNECT_CONTROL_CONFIG_ERR),
+	common.SYS_MAX_CONNECT_COUNT_EXCEEDED:           NewIRODSError(common.SYS_MAX_CONNECT_COUNT_EXCEEDED),
+	common.SYS_STRUCT_ELEMENT_MISMATCH:              NewIRODSError(common.SYS_STRUCT_ELEMENT_MISMATCH),
+	common.SYS_PHY_PATH_INUSE:                       NewIRODSError(common.SYS_PHY_PATH_INUSE),
+	common.SYS_USER_NO_PERMISSION:                   NewIRODSError(common.SYS_USER_NO_PERMISSION),
+	common.SYS_USER_RETRIEVE_ERR:                    NewIRODSError(common.SYS_USER_RETRIEVE_ERR),
+	common.SYS_FS_LOCK_ERR:                          NewIRODSError(common.SYS_FS_LOCK_ERR),
+	common.SYS_LOCK_TYPE_INP_ERR:                    NewIRODSError(common.SYS_LOCK_TYPE_INP_ERR),
+	common.SYS_LOCK_CMD_INP_ERR:                     NewIRODSError(common.SYS_LOCK_CMD_INP_ERR),
+	common.SYS_ZIP_FORMAT_NOT_SUPPORTED:             NewIRODSError(common.SYS_ZIP_FORMAT_NOT_SUPPORTED),
+	common.SYS_ADD_TO_ARCH_OPR_NOT_SUPPORTED:        NewIRODSError(common.SYS_ADD_TO_ARCH_OPR_NOT_SUPPORTED),
+	common.CANT_REG_IN_VAULT_FILE:                   NewIRODSError(common.CANT_REG_IN_VAULT_FILE),
+	common.PATH_REG_NOT_ALLOWED:                     NewIRODSError(common.PATH_REG_NOT_ALLOWED),
+	common.SYS_INVALID_INPUT_PARAM:                  NewIRODSError(common.SYS_INVALID_INPUT_PARAM),
+	common.SYS_GROUP_RETRIEVE_ERR:                   NewIRODSError(common.SYS_GROUP_RETRIEVE_ERR),
+	common.SYS_MSSO_APPEND_ERR:                      NewIRODSError(common.SYS_MSSO_APPEND_ERR),
+	common.SYS_MSSO_STRUCT_FILE_EXTRACT_ERR:         NewIRODSError(common.SYS_MSSO_STRUCT_FILE_EXTRACT_ERR),
+	common.SYS_MSSO_EXTRACT_ALL_ERR:                 NewIRODSError(common.SYS_MSSO_EXTRACT_ALL_ERR),
+	common.SYS_MSSO_OPEN_ERR:                        NewIRODSError(common.SYS_MSSO_OPEN_ERR),
+	common.SYS_MSSO_CLOSE_ERR:                       NewIRODSError(common.SYS_MSSO_CLOSE_ERR),
+	common.SYS_RULE_NOT_FOUND:                       NewIRODSError(common.SYS_RULE_NOT_FOUND),
+	common.SYS_NOT_IMPLEMENTED:                      NewIRODSError(common.SYS_NOT_IMPLEMENTED),
+	common.SYS_SIGNED_SID_NOT_MATCHED:               NewIRODSError(common.SYS_SIGNED_SID_NOT_MATCHED),
+	common.SYS_HASH_IMMUTABLE:                       NewIRODSError(common.SYS_HASH_IMMUTABLE),
+	common.SYS_UNINITIALIZED:                        NewIRODSError(common.SYS_UNINITIALIZED),
+	common.SYS_NEGATIVE_SIZE:                        NewIRODSError(common.SYS_NEGATIVE_SIZE),
+	common.SYS_ALREADY_INITIALIZED:                  NewIRODSError(common.SYS_ALREADY_INITIALIZED),
+	common.SYS_SETENV_ERR:                           NewIRODSError(common.SYS_SETENV_ERR),
+	common.SYS_GETENV_ERR:                           NewIRODSError(common.SYS_GETENV_ERR),
+	common.SYS_INTERNAL_ERR:                         NewIRODSError(common.SYS_INTERNAL_ERR),
+	common.SYS_SOCK_SELECT_ERR:                      NewIRODSError(common.SYS_SOCK_SELECT_ERR),
+	common.SYS_THREAD_ENCOUNTERED_INTERRUPT:         NewIRODSError(common.SYS_THREAD_ENCOUNTERED_INTERRUPT),
+	common.SYS_THREAD_RESOURCE_ERR:                  NewIRODSError(common.SYS_THREAD_RESOURCE_ERR),
+	common.SYS_BAD_INPUT:                            NewIRODSError(common.SYS_BAD_INPUT),
+	common.SYS_PORT_RANGE_EXHAUSTED:                 NewIRODSError(common.SYS_PORT_RANGE_EXHAUSTED),
+	common.SYS_SERVICE_ROLE_NOT_SUPPORTED:           NewIRODSError(common.SYS_SERVICE_ROLE_NOT_SUPPORTED),
+	common.SYS_SOCK_WRITE_ERR:                       NewIRODSError(common.SYS_SOCK_WRITE_ERR),
+	common.SYS_SOCK_CONNECT_ERR:                     NewIRODSError(common.SYS_SOCK_CONNECT_ERR),
+	common.SYS_OPERATION_IN_PROGRESS:                NewIRODSError(common.SYS_OPERATION_IN_PROGRESS),
+	common.SYS_REPLICA_DOES_NOT_EXIST:               NewIRODSError(common.SYS_REPLICA_DOES_NOT_EXIST),
+	common.SYS_UNKNOWN_ERROR:                        NewIRODSError(common.SYS_UNKNOWN_ERROR),
+	common.SYS_NO_GOOD_REPLICA:                      NewIRODSError(common.SYS_NO_GOOD_REPLICA),
+	common.SYS_LIBRARY_ERROR:                        NewIRODSError(common.SYS_LIBRARY_ERROR),
+	common.SYS_REPLICA_INACCESSIBLE:                 NewIRODSError(common.SYS_REPLICA_INACCESSIBLE),
+	common.SYS_NOT_ALLOWED:                          NewIRODSError(common.SYS_NOT_ALLOWED),
+	common.NOT_A_COLLECTION:                         NewIRODSError(common.NOT_A_COLLECTION),
+	common.NOT_A_DATA_OBJECT:                        NewIRODSError(common.NOT_A_DATA_OBJECT),
+	common.JSON_VALIDATION_ERROR:                    NewIRODSError(common.JSON_VALIDATION_ERROR),
+	common.USER_AUTH_SCHEME_ERR:                     NewIRODSError(common.USER_AUTH_SCHEME_ERR),
+	common.USER_AUTH_STRING_EMPTY:                   NewIRODSError(common.USER_AUTH_STRING_EMPTY),
+	common.USER_RODS_HOST_EMPTY:                     NewIRODSError(common.USER_RODS_HOST_EMPTY),
+	common.USER_RODS_HOSTNAME_ERR:                   NewIRODSError(common.USER_RODS_HOSTNAME_ERR),
+	common.USER_SOCK_OPEN_ERR:                       NewIRODSError(common.USER_SOCK_OPEN_ERR),
+	common.USER_SOCK_CONNECT_ERR:                    NewIRODSError(common.USER_SOCK_CONNECT_ERR),
+	common.USER_STRLEN_TOOLONG:                      NewIRODSError(common.USER_STRLEN_TOOLONG),
+	common.USER_API_INPUT_ERR:                       NewIRODSError(common.USER_API_INPUT_ERR),
+	common.USER_PACKSTRUCT_INPUT_ERR:                NewIRODSError(common.USER_PACKSTRUCT_INPUT_ERR),
+	common.USER_NO_SUPPORT_ERR:                      NewIRODSError(common.USER_NO_SUPPORT_ERR),
+	common.USER_FILE_DOES_NOT_EXIST:                 NewIRODSError(common.USER_FILE_DOES_NOT_EXIST),
+	common.USER_FILE_TOO_LARGE:                      NewIRODSError(common.USER_FILE_TOO_LARGE),
+	common.OVERWRITE_WITHOUT_FORCE_FLAG:             NewIRODSError(common.OVERWRITE_WITHOUT_FORCE_FLAG),
+	common.UNMATCHED_KEY_OR_INDEX:                   NewIRODSError(common.UNMATCHED_KEY_OR_INDEX),
+	common.USER_CHKSUM_MISMATCH:                     NewIRODSError(common.USER_CHKSUM_MISMATCH),
+	common.USER_BAD_KEYWORD_ERR:                     NewIRODSError(common.USER_BAD_KEYWORD_ERR),
+	common.USER__NULL_INPUT_ERR:                     NewIRODSError(common.USER__NULL_INPUT_ERR),
+	common.USER_INPUT_PATH_ERR:                      NewIRODSError(common.USER_INPUT_PATH_ERR),
+	common.USER_INPUT_OPTION_ERR:                    NewIRODSError(common.USER_INPUT_OPTION_ERR),
+	common.USER_INVALID_USERNAME_FORMAT:             NewIRODSError(common.USER_INVALID_USERNAME_FORMAT),
+	common.USER_DIRECT_RESC_INPUT_ERR:               NewIRODSError(common.USER_DIRECT_RESC_INPUT_ERR),
+	common.USER_NO_RESC_INPUT_ERR:                   NewIRODSError(common.USER_NO_RESC_INPUT_ERR),
+	common.USER_PARAM_LABEL_ERR:                     NewIRODSError(common.USER_PARAM_LABEL_ERR),
+	common.USER_PARAM_TYPE_ERR:                      NewIRODSError(common.USER_PARAM_TYPE_ERR),
+	common.BASE64_BUFFER_OVERFLOW:                   NewIRODSError(common.BASE64_BUFFER_OVERFLOW),
+	common.BASE64_INVALID_PACKET:                    NewIRODSError(common.BASE64_INVALID_PACKET),
+	common.USER_MSG_TYPE_NO_SUPPORT:                 NewIRODSError(common.USER_MSG_TYPE_NO_SUPPORT),
+	common.USER_RSYNC_NO_MODE_INPUT_ERR:             NewIRODSError(common.USER_RSYNC_NO_MODE_INPUT_ERR),
+	common.USER_OPTION_INPUT_ERR:                    NewIRODSError(common.USER_OPTION_INPUT_ERR),
+	common.SAME_SRC_DEST_PATHS_ERR:                  NewIRODSError(common.SAME_SRC_DEST_PATHS_ERR),
+	common.USER_RESTART_FILE_INPUT_ERR:              NewIRODSError(common.USER_RESTART_FILE_INPUT_ERR),
+	common.RESTART_OPR_FAILED:                       NewIRODSError(common.RESTART_OPR_FAILED),
+	common.BAD_EXEC_CMD_PATH:                        NewIRODSError(common.BAD_EXEC_CMD_PATH),
+	common.EXEC_CMD_OUTPUT_TOO_LARGE:                NewIRODSError(common.EXEC_CMD_OUTPUT_TOO_LARGE),
+	common.EXEC_CMD_ERROR:                           NewIRODSError(common.EXEC_CMD_ERROR),
+	common.BAD_INPUT_DESC_INDEX:                     NewIRODSError(common.BAD_INPUT_DESC_INDEX),
+	common.USER_PATH_EXCEEDS_MAX:                    NewIRODSError(common.USER_PATH_EXCEEDS_MAX),
+	common.USER_SOCK_CONNECT_TIMEDOUT:               NewIRODSError(common.USER_SOCK_CONNECT_TIMEDOUT),
+	common.USER_API_VERSION_MISMATCH:                NewIRODSError(common.USER_API_VERSION_MISMATCH),
+	common.USER_INPUT_FORMAT_ERR:                    NewIRODSError(common.USER_INPUT_FORMAT_ERR),
+	common.USER_ACCESS_DENIED:                       NewIRODSError(common.USER_ACCESS_DENIED),
+	common.CANT_RM_MV_BUNDLE_TYPE:                   NewIRODSError(common.CANT_RM_MV_BUNDLE_TYPE),
+	common.NO_MORE_RESULT:                           NewIRODSError(common.NO_MORE_RESULT),
+	common.NO_KEY_WD_IN_MS_INP_STR:                  NewIRODSError(common.NO_KEY_WD_IN_MS_INP_STR),
+	common.CANT_RM_NON_EMPTY_HOME_COLL:              NewIRODSError(common.CANT_RM_NON_EMPTY_HOME_COLL),
+	common.CANT_UNREG_IN_VAULT_FILE:                 NewIRODSError(common.CANT_UNREG_IN_VAULT_FILE),
+	common.NO_LOCAL_FILE_RSYNC_IN_MSI:               NewIRODSError(common.NO_LOCAL_FILE_RSYNC_IN_MSI),
+	common.BULK_OPR_MISMATCH_FOR_RESTART:            NewIRODSError(common.BULK_OPR_MISMATCH_FOR_RESTART),
+	common.OBJ_PATH_DOES_NOT_EXIST:                  NewIRODSError(common.OBJ_PATH_DOES_NOT_EXIST),
+	common.SYMLINKED_BUNFILE_NOT_ALLOWED:            NewIRODSError(common.SYMLINKED_BUNFILE_NOT_ALLOWED),
+	common.USER_INPUT_STRING_ERR:                    NewIRODSError(common.USER_INPUT_STRING_ERR),
+	common.USER_INVALID_RESC_INPUT:                  NewIRODSError(common.USER_INVALID_RESC_INPUT),
+	common.USER_NOT_ALLOWED_TO_EXEC_CMD:             NewIRODSError(common.USER_NOT_ALLOWED_TO_EXEC_CMD),
+	common.USER_HASH_TYPE_MISMATCH:                  NewIRODSError(common.USER_HASH_TYPE_MISMATCH),
+	common.USER_INVALID_CLIENT_ENVIRONMENT:          NewIRODSError(common.USER_INVALID_CLIENT_ENVIRONMENT),
+	common.USER_INSUFFICIENT_FREE_INODES:            NewIRODSError(common.USER_INSUFFICIENT_FREE_INODES),
+	common.USER_FILE_SIZE_MISMATCH:                  NewIRODSError(common.USER_FILE_SIZE_MISMATCH),
+	common.USER_INCOMPATIBLE_PARAMS:                 NewIRODSError(common.USER_INCOMPATIBLE_PARAMS),
+	common.USER_INVALID_REPLICA_INPUT:               NewIRODSError(common.USER_INVALID_REPLICA_INPUT),
+	common.USER_INCOMPATIBLE_OPEN_FLAGS:             NewIRODSError(common.USER_INCOMPATIBLE_OPEN_FLAGS),
+	common.INTERMEDIATE_REPLICA_ACCESS:              NewIRODSError(common.INTERMEDIATE_REPLICA_ACCESS),
+	common.LOCKED_DATA_OBJECT_ACCESS:                NewIRODSError(common.LOCKED_DATA_OBJECT_ACCESS),
+	common.CHECK_VERIFICATION_RESULTS:               NewIRODSError(common.CHECK_VERIFICATION_RESULTS),
+	common.FILE_INDEX_LOOKUP_ERR:                    NewIRODSError(common.FILE_INDEX_LOOKUP_ERR),
+	common.UNIX_FILE_OPEN_ERR:                       NewIRODSError(common.UNIX_FILE_OPEN_ERR),
+	common.UNIX_FILE_CREATE_ERR:                     NewIRODSError(common.UNIX_FILE_CREATE_ERR),
+	common.UNIX_FILE_READ_ERR:                       NewIRODSError(common.UNIX_FILE_READ_ERR),
+	common.UNIX_FILE_WRITE_ERR:                      NewIRODSError(common.UNIX_FILE_WRITE_ERR),
+	common.UNIX_FILE_CLOSE_ERR:                      NewIRODSError(common.UNIX_FILE_CLOSE_ERR),
+	common.UNIX_FILE_UNLINK_ERR:                     NewIRODSError(common.UNIX_FILE_UNLINK_ERR),
+	common.UNIX_FILE_STAT_ERR:                       NewIRODSError(common.UNIX_FILE_STAT_ERR),
+	common.UNIX_FILE_FSTAT_ERR:                      NewIRODSError(common.UNIX_FILE_FSTAT_ERR),
+	common.UNIX_FILE_LSEEK_ERR:                      NewIRODSError(common.UNIX_FILE_LSEEK_ERR),
+	common.UNIX_FILE_FSYNC_ERR:                      NewIRODSError(common.UNIX_FILE_FSYNC_ERR),
+	common.UNIX_FILE_MKDIR_ERR:                      NewIRODSError(common.UNIX_FILE_MKDIR_ERR),
+	common.UNIX_FILE_RMDIR_ERR:                      NewIRODSError(common.UNIX_FILE_RMDIR_ERR),
+	common.UNIX_FILE_OPENDIR_ERR:                    NewIRODSError(common.UNIX_FILE_OPENDIR_ERR),
+	common.UNIX_FILE_CLOSEDIR_ERR:                   NewIRODSError(common.UNIX_FILE_CLOSEDIR_ERR),
+	common.UNIX_FILE_READDIR_ERR:                    NewIRODSError(common.UNIX_FILE_READDIR_ERR),
+	common.UNIX_FILE_STAGE_ERR:                      NewIRODSError(common.UNIX_FILE_STAGE_ERR),
+	common.UNIX_FILE_GET_FS_FREESPACE_ERR:           NewIRODSError(common.UNIX_FILE_GET_FS_FREESPACE_ERR),
+	common.UNIX_FILE_CHMOD_ERR:                      NewIRODSError(common.UNIX_FILE_CHMOD_ERR),
+	common.UNIX_FILE_RENAME_ERR:                     NewIRODSError(common.UNIX_FILE_RENAME_ERR),
+	common.UNIX_FILE_TRUNCATE_ERR:                   NewIRODSError(common.UNIX_FILE_TRUNCATE_ERR),
+	common.UNIX_FILE_LINK_ERR:                       NewIRODSError(common.UNIX_FILE_LINK_ERR),
+	common.UNIX_FILE_OPR_TIMEOUT_ERR:                NewIRODSError(common.UNIX_FILE_OPR_TIMEOUT_ERR),
+	common.UNIV_MSS_SYNCTOARCH_ERR:                  NewIRODSError(common.UNIV_MSS_SYNCTOARCH_ERR),
+	common.UNIV_MSS_STAGETOCACHE_ERR:                NewIRODSError(common.UNIV_MSS_STAGETOCACHE_ERR),
+	common.UNIV_MSS_UNLINK_ERR:                      NewIRODSError(common.UNIV_MSS_UNLINK_ERR),
+	common.UNIV_MSS_MKDIR_ERR:                       NewIRODSError(common.UNIV_MSS_MKDIR_ERR),
+	common.UNIV_MSS_CHMOD_ERR:                       NewIRODSError(common.UNIV_MSS_CHMOD_ERR),
+	common.UNIV_MSS_STAT_ERR:                        NewIRODSError(common.UNIV_MSS_STAT_ERR),
+	common.UNIV_MSS_RENAME_ERR:                      NewIRODSError(common.UNIV_MSS_RENAME_ERR),
+	common.HPSS_AUTH_NOT_SUPPORTED:                  NewIRODSError(common.HPSS_AUTH_NOT_SUPPORTED),
+	common.HPSS_FILE_OPEN_ERR:                       NewIRODSError(common.HPSS_FILE_OPEN_ERR),
+	common.HPSS_FILE_CREATE_ERR:                     NewIRODSError(common.HPSS_FILE_CREATE_ERR),
+	common.HPSS_FILE_READ_ERR:                       NewIRODSError(common.HPSS_FILE_READ_ERR),
+	common.HPSS_FILE_WRITE_ERR:                      NewIRODSError(common.HPSS_FILE_WRITE_ERR),
+	common.HPSS_FILE_CLOSE_ERR:                      NewIRODSError(common.HPSS_FILE_CLOSE_ERR),
+	common.HPSS_FILE_UNLINK_ERR:                     NewIRODSError(common.HPSS_FILE_UNLINK_ERR),
+	common.HPSS_FILE_STAT_ERR:                       NewIRODSError(common.HPSS_FILE_STAT_ERR),
+	common.HPSS_FILE_FSTAT_ERR:                      NewIRODSError(common.HPSS_FILE_FSTAT_ERR),
+	common.HPSS_FILE_LSEEK_ERR:                      NewIRODSError(common.HPSS_FILE_LSEEK_ERR),
+	common.HPSS_FILE_FSYNC_ERR:                      NewIRODSError(common.HPSS_FILE_FSYNC_ERR),
+	common.HPSS_FILE_MKDIR_ERR:                      NewIRODSError(common.HPSS_FILE_MKDIR_ERR),
+	common.HPSS_FILE_RMDIR_ERR:                      NewIRODSError(common.HPSS_FILE_RMDIR_ERR),
+	common.HPSS_FILE_OPENDIR_ERR:                    NewIRODSError(common.HPSS_FILE_OPENDIR_ERR),
+	common.HPSS_FILE_CLOSEDIR_ERR:                   NewIRODSError(common.HPSS_FILE_CLOSEDIR_ERR),
+	common.HPSS_FILE_READDIR_ERR:                    NewIRODSError(common.HPSS_FILE_READDIR_ERR),
+	common.HPSS_FILE_STAGE_ERR:                      NewIRODSError(common.HPSS_FILE_STAGE_ERR),
+	common.HPSS_FILE_GET_FS_FREESPACE_ERR:           NewIRODSError(common.HPSS_FILE_GET_FS_FREESPACE_ERR),
+	common.HPSS_FILE_CHMOD_ERR:                      NewIRODSError(common.HPSS_FILE_CHMOD_ERR),
+	common.HPSS_FILE_RENAME_ERR:                     NewIRODSError(common.HPSS_FILE_RENAME_ERR),
+	common.HPSS_FILE_TRUNCATE_ERR:                   NewIRODSError(common.HPSS_FILE_TRUNCATE_ERR),
+	common.HPSS_FILE_LINK_ERR:                       NewIRODSError(common.HPSS_FILE_LINK_ERR),
+	common.HPSS_AUTH_ERR:                            NewIRODSError(common.HPSS_AUTH_ERR),
+	common.HPSS_WRITE_LIST_ERR:                      NewIRODSError(common.HPSS_WRITE_LIST_ERR),
+	common.HPSS_READ_LIST_ERR:                       NewIRODSError(common.HPSS_READ_LIST_ERR),
+	common.HPSS_TRANSFER_ERR:                        NewIRODSError(common.HPSS_TRANSFER_ERR),
+	common.HPSS_MOVER_PROT_ERR:                      NewIRODSError(common.HPSS_MOVER_PROT_ERR),
+	common.S3_INIT_ERROR:                            NewIRODSError(common.S3_INIT_ERROR),
+	common.S3_PUT_ERROR:                             NewIRODSError(common.S3_PUT_ERROR),
+	common.S3_GET_ERROR:                             NewIRODSError(common.S3_GET_ERROR),
+	common.S3_FILE_UNLINK_ERR:                       NewIRODSError(common.S3_FILE_UNLINK_ERR),
+	common.S3_FILE_STAT_ERR:                         NewIRODSError(common.S3_FILE_STAT_ERR),
+	common.S3_FILE_COPY_ERR:                         NewIRODSError(common.S3_FILE_COPY_ERR),
+	common.S3_FILE_OPEN_ERR:                         NewIRODSError(common.S3_FILE_OPEN_ERR),
+	common.S3_FILE_SEEK_ERR:                         NewIRODSError(common.S3_FILE_SEEK_ERR),
+	common.S3_FILE_RENAME_ERR:                       NewIRODSError(common.S3_FILE_RENAME_ERR),
+	common.REPLICA_IS_BEING_STAGED:                  NewIRODSError(common.REPLICA_IS_BEING_STAGED),
+	common.REPLICA_STAGING_FAILED:                   NewIRODSError(common.REPLICA_STAGING_FAILED),
+	common.WOS_PUT_ERR:                              NewIRODSError(common.WOS_PUT_ERR),
+	common.WOS_STREAM_PUT_ERR:                       NewIRODSError(common.WOS_STREAM_PUT_ERR),
+	common.WOS_STREAM_CLOSE_ERR:                     NewIRODSError(common.WOS_STREAM_CLOSE_ERR),
+	common.WOS_GET_ERR:                              NewIRODSError(common.WOS_GET_ERR),
+	common.WOS_STREAM_GET_ERR:                       NewIRODSError(common.WOS_STREAM_GET_ERR),
+	common.WOS_UNLINK_ERR:                           NewIRODSError(common.WOS_UNLINK_ERR),
+	common.WOS_STAT_ERR:                             NewIRODSError(common.WOS_STAT_ERR),
+	common.WOS_CONNECT_ERR:                          NewIRODSError(common.WOS_CONNECT_ERR),
+	common.HDFS_FILE_OPEN_ERR:                       NewIRODSError(common.HDFS_FILE_OPEN_ERR),
+	common.HDFS_FILE_CREATE_ERR:                     NewIRODSError(common.HDFS_FILE_CREATE_ERR),
+	common.HDFS_FILE_READ_ERR:                       NewIRODSError(common.HDFS_FILE_READ_ERR),
+	common.HDFS_FILE_WRITE_ERR:                      NewIRODSError(common.HDFS_FILE_WRITE_ERR),
+	common.HDFS_FILE_CLOSE_ERR:                      NewIRODSError(common.HDFS_FILE_CLOSE_ERR),
+	common.HDFS_FILE_UNLINK_ERR:                     NewIRODSError(common.HDFS_FILE_UNLINK_ERR),
+	common.HDFS_FILE_STAT_ERR:                       NewIRODSError(common.HDFS_FILE_STAT_ERR),
+	common.HDFS_FILE_FSTAT_ERR:                      NewIRODSError(common.HDFS_FILE_FSTAT_ERR),
+	common.HDFS_FILE_LSEEK_ERR:                      NewIRODSError(common.HDFS_FILE_LSEEK_ERR),
+	common.HDFS_FILE_FSYNC_ERR:                      NewIRODSError(common.HDFS_FILE_FSYNC_ERR),
+	common.HDFS_FILE_MKDIR_ERR:                      NewIRODSError(common.HDFS_FILE_MKDIR_ERR),
+	common.HDFS_FILE_RMDIR_ERR:                      NewIRODSError(common.HDFS_FILE_RMDIR_ERR),
+	common.HDFS_FILE_OPENDIR_ERR:                    NewIRODSError(common.HDFS_FILE_OPENDIR_ERR),
+	common.HDFS_FILE_CLOSEDIR_ERR:                   NewIRODSError(common.HDFS_FILE_CLOSEDIR_ERR),
+	common.HDFS_FILE_READDIR_ERR:                    NewIRODSError(common.HDFS_FILE_READDIR_ERR),
+	common.HDFS_FILE_STAGE_ERR:                      NewIRODSError(common.HDFS_FILE_STAGE_ERR),
+	common.HDFS_FILE_GET_FS_FREESPACE_ERR:           NewIRODSError(common.HDFS_FILE_GET_FS_FREESPACE_ERR),
+	common.HDFS_FILE_CHMOD_ERR:                      NewIRODSError(common.HDFS_FILE_CHMOD_ERR),
+	common.HDFS_FILE_RENAME_ERR:                     NewIRODSError(common.HDFS_FILE_RENAME_ERR),
+	common.HDFS_FILE_TRUNCATE_ERR:                   NewIRODSError(common.HDFS_FILE_TRUNCATE_ERR),
+	common.HDFS_FILE_LINK_ERR:                       NewIRODSError(common.HDFS_FILE_LINK_ERR),
+	common.HDFS_FILE_OPR_TIMEOUT_ERR:                NewIRODSError(common.HDFS_FILE_OPR_TIMEOUT_ERR),
+	common.DIRECT_ACCESS_FILE_USER_INVALID_ERR:      NewIRODSError(common.DIRECT_ACCESS_FILE_USER_INVALID_ERR),
+	common.CATALOG_NOT_CONNECTED:                    NewIRODSError(common.CATALOG_NOT_CONNECTED),
+	common.CAT_ENV_ERR:                              NewIRODSError(common.CAT_ENV_ERR),
+	common.CAT_CONNECT_ERR:                          NewIRODSError(common.CAT_CONNECT_ERR),
+	common.CAT_DISCONNECT_ERR:                       NewIRODSError(common.CAT_DISCONNECT_ERR),
+	common.CAT_CLOSE_ENV_ERR:                        NewIRODSError(common.CAT_CLOSE_ENV_ERR),
+	common.CAT_SQL_ERR:                              NewIRODSError(common.CAT_SQL_ERR),
+	common.CAT_GET_ROW_ERR:                          NewIRODSError(common.CAT_GET_ROW_ERR),
+	common.CAT_NO_ROWS_FOUND:                        NewIRODSError(common.CAT_NO_ROWS_FOUND),
+	common.CATALOG_ALREADY_HAS_ITEM_BY_THAT_NAME:    NewIRODSError(common.CATALOG_ALREADY_HAS_ITEM_BY_THAT_NAME),
+	common.CAT_INVALID_RESOURCE_TYPE:                NewIRODSError(common.CAT_INVALID_RESOURCE_TYPE),
+	common.CAT_INVALID_RESOURCE_CLASS:               NewIRODSError(common.CAT_INVALID_RESOURCE_CLASS),
+	common.CAT_INVALID_RESOURCE_NET_ADDR:            NewIRODSError(common.CAT_INVALID_RESOURCE_NET_ADDR),
+	common.CAT_INVALID_RESOURCE_VAULT_PATH:          NewIRODSError(common.CAT_INVALID_RESOURCE_VAULT_PATH),
+	common.CAT_UNKNOWN_COLLECTION:                   NewIRODSError(common.CAT_UNKNOWN_COLLECTION),
+	common.CAT_INVALID_DATA_TYPE:                    NewIRODSError(common.CAT_INVALID_DATA_TYPE),
+	common.CAT_INVALID_ARGUMENT:                     NewIRODSError(common.CAT_INVALID_ARGUMENT),
+	common.CAT_UNKNOWN_FILE:                         NewIRODSError(common.CAT_UNKNOWN_FILE),
+	common.CAT_NO_ACCESS_PERMISSION:                 NewIRODSError(common.CAT_NO_ACCESS_PERMISSION),
+	common.CAT_SUCCESS_BUT_WITH_NO_INFO:             NewIRODSError(common.CAT_SUCCESS_BUT_WITH_NO_INFO),
+	common.CAT_INVALID_USER_TYPE:                    NewIRODSError(common.CAT_INVALID_USER_TYPE),
+	common.CAT_COLLECTION_NOT_EMPTY:                 NewIRODSError(common.CAT_COLLECTION_NOT_EMPTY),
+	common.CAT_TOO_MANY_TABLES:                      NewIRODSError(common.CAT_TOO_MANY_TABLES),
+	common.CAT_UNKNOWN_TABLE:                        NewIRODSError(common.CAT_UNKNOWN_TABLE),
+	common.CAT_NOT_OPEN:                             NewIRODSError(common.CAT_NOT_OPEN),
+	common.CAT_FAILED_TO_LINK_TABLES:                NewIRODSError(common.CAT_FAILED_TO_LINK_TABLES),
+	common.CAT_INVALID_AUTHENTICATION:               NewIRODSError(common.CAT_INVALID_AUTHENTICATION),
+	common.CAT_INVALID_USER:                         NewIRODSError(common.CAT_INVALID_USER),
+	common.CAT_INVALID_ZONE:                         NewIRODSError(common.CAT_INVALID_ZONE),
+	common.CAT_INVALID_GROUP:                        NewIRODSError(common.CAT_INVALID_GROUP),
+	common.CAT_INSUFFICIENT_PRIVILEGE_LEVEL:         NewIRODSError(common.CAT_INSUFFICIENT_PRIVILEGE_LEVEL),
+	common.CAT_INVALID_RESOURCE:                     NewIRODSError(common.CAT_INVALID_RESOURCE),
+	common.CAT_INVALID_CLIENT_USER:                  NewIRODSError(common.CAT_INVALID_CLIENT_USER),
+	common.CAT_NAME_EXISTS_AS_COLLECTION:            NewIRODSError(common.CAT_NAME_EXISTS_AS_COLLECTION),
+	common.CAT_NAME_EXISTS_AS_DATAOBJ:               NewIRODSError(common.CAT_NAME_EXISTS_AS_DATAOBJ),
+	common.CAT_RESOURCE_NOT_EMPTY:                   NewIRODSError(common.CAT_RESOURCE_NOT_EMPTY),
+	common.CAT_NOT_A_DATAOBJ_AND_NOT_A_COLLECTION:   NewIRODSError(common.CAT_NOT_A_DATAOBJ_AND_NOT_A_COLLECTION),
+	common.CAT_RECURSIVE_MOVE:                       NewIRODSError(common.CAT_RECURSIVE_MOVE),
+	common.CAT_LAST_REPLICA:                         NewIRODSError(common.CAT_LAST_REPLICA),
+	common.CAT_OCI_ERROR:                            NewIRODSError(common.CAT_OCI_ERROR),
+	common.CAT_PASSWORD_EXPIRED:                     NewIRODSError(common.CAT_PASSWORD_EXPIRED),
+	common.CAT_PASSWORD_ENCODING_ERROR:              NewIRODSError(common.CAT_PASSWORD_ENCODING_ERROR),
+	common.CAT_TABLE_ACCESS_DENIED:                  NewIRODSError(common.CAT_TABLE_ACCESS_DENIED),
+	common.CAT_UNKNOWN_RESOURCE:                     NewIRODSError(common.CAT_UNKNOWN_RESOURCE),
+	common.CAT_UNKNOWN_SPECIFIC_QUERY:               NewIRODSError(common.CAT_UNKNOWN_SPECIFIC_QUERY),
+	common.CAT_PSEUDO_RESC_MODIFY_DISALLOWED:        NewIRODSError(common.CAT_PSEUDO_RESC_MODIFY_DISALLOWED),
+	common.CAT_HOSTNAME_INVALID:                     NewIRODSError(common.CAT_HOSTNAME_INVALID),
+	common.CAT_BIND_VARIABLE_LIMIT_EXCEEDED:         NewIRODSError(common.CAT_BIND_VARIABLE_LIMIT_EXCEEDED),
+	common.CAT_INVALID_CHILD:                        NewIRODSError(common.CAT_INVALID_CHILD),
+	common.CAT_INVALID_OBJ_COUNT:                    NewIRODSError(common.CAT_INVALID_OBJ_COUNT),
+	common.CAT_INVALID_RESOURCE_NAME:                NewIRODSError(common.CAT_INVALID_RESOURCE_NAME),
+	common.CAT_STATEMENT_TABLE_FULL:                 NewIRODSError(common.CAT_STATEMENT_TABLE_FULL),
+	common.CAT_RESOURCE_NAME_LENGTH_EXCEEDED:        NewIRODSError(common.CAT_RESOURCE_NAME_LENGTH_EXCEEDED),
+	common.CAT_NO_CHECKSUM_FOR_REPLICA:              NewIRODSError(common.CAT_NO_CHECKSUM_FOR_REPLICA),
+	common.CAT_TICKET_INVALID:                       NewIRODSError(common.CAT_TICKET_INVALID),
+	common.CAT_TICKET_EXPIRED:                       NewIRODSError(common.CAT_TICKET_EXPIRED),
+	common.CAT_TICKET_USES_EXCEEDED:                 NewIRODSError(common.CAT_TICKET_USES_EXCEEDED),
+	common.CAT_TICKET_USER_EXCLUDED:                 NewIRODSError(common.CAT_TICKET_USER_EXCLUDED),
+	common.CAT_TICKET_HOST_EXCLUDED:                 NewIRODSError(common.CAT_TICKET_HOST_EXCLUDED),
+	common.CAT_TICKET_GROUP_EXCLUDED:                NewIRODSError(common.CAT_TICKET_GROUP_EXCLUDED),
+	common.CAT_TICKET_WRITE_USES_EXCEEDED:           NewIRODSError(common.CAT_TICKET_WRITE_USES_EXCEEDED),
+	common.CAT_TICKET_WRITE_BYTES_EXCEEDED:          NewIRODSError(common.CAT_TICKET_WRITE_BYTES_EXCEEDED),
+	common.FILE_OPEN_ERR:                            NewIRODSError(common.FILE_OPEN_ERR),
+	common.FILE_READ_ERR:                            NewIRODSError(common.FILE_READ_ERR),
+	common.FILE_WRITE_ERR:                           NewIRODSError(common.FILE_WRITE_ERR),
+	common.PASSWORD_EXCEEDS_MAX_SIZE:                NewIRODSError(common.PASSWORD_EXCEEDS_MAX_SIZE),
+	common.ENVIRONMENT_VAR_HOME_NOT_DEFINED:         NewIRODSError(common.ENVIRONMENT_VAR_HOME_NOT_DEFINED),
+	common.UNABLE_TO_STAT_FILE:                      NewIRODSError(common.UNABLE_TO_STAT_FILE),
+	common.AUTH_FILE_NOT_ENCRYPTED:                  NewIRODSError(common.AUTH_FILE_NOT_ENCRYPTED),
+	common.AUTH_FILE_DOES_NOT_EXIST:                 NewIRODSError(common.AUTH_FILE_DOES_NOT_EXIST),
+	common.UNLINK_FAILED:                            NewIRODSError(common.UNLINK_FAILED),
+	common.NO_PASSWORD_ENTERED:                      NewIRODSError(common.NO_PASSWORD_ENTERED),
+	common.REMOTE_SERVER_AUTHENTICATION_FAILURE:     NewIRODSError(common.REMOTE_SERVER_AUTHENTICATION_FAILURE),
+	common.REMOTE_SERVER_AUTH_NOT_PROVIDED:          NewIRODSError(common.REMOTE_SERVER_AUTH_NOT_PROVIDED),
+	common.REMOTE_SERVER_AUTH_EMPTY:                 NewIRODSError(common.REMOTE_SERVER_AUTH_EMPTY),
+	common.REMOTE_SERVER_SID_NOT_DEFINED:            NewIRODSError(common.REMOTE_SERVER_SID_NOT_DEFINED),
+	common.GSI_NOT_COMPILED_IN:                      NewIRODSError(common.GSI_NOT_COMPILED_IN),
+	common.GSI_NOT_BUILT_INTO_CLIENT:                NewIRODSError(common.GSI_NOT_BUILT_INTO_CLIENT),
+	common.GSI_NOT_BUILT_INTO_SERVER:                NewIRODSError(common.GSI_NOT_BUILT_INTO_SERVER),
+	common.GSI_ERROR_IMPORT_NAME:                    NewIRODSError(common.GSI_ERROR_IMPORT_NAME),
+	common.GSI_ERROR_INIT_SECURITY_CONTEXT:          NewIRODSError(common.GSI_ERROR_INIT_SECURITY_CONTEXT),
+	common.GSI_ERROR_SENDING_TOKEN_LENGTH:           NewIRODSError(common.GSI_ERROR_SENDING_TOKEN_LENGTH),
+	common.GSI_ERROR_READING_TOKEN_LENGTH:           NewIRODSError(common.GSI_ERROR_READING_TOKEN_LENGTH),
+	common.GSI_ERROR_TOKEN_TOO_LARGE:                NewIRODSError(common.GSI_ERROR_TOKEN_TOO_LARGE),
+	common.GSI_ERROR_BAD_TOKEN_RCVED:                NewIRODSError(common.GSI_ERROR_BAD_TOKEN_RCVED),
+	common.GSI_SOCKET_READ_ERROR:                    NewIRODSError(common.GSI_SOCKET_READ_ERROR),
+	common.GSI_PARTIAL_TOKEN_READ:                   NewIRODSError(common.GSI_PARTIAL_TOKEN_READ),
+	common.GSI_SOCKET_WRITE_ERROR:                   NewIRODSError(common.GSI_SOCKET_WRITE_ERROR),
+	common.GSI_ERROR_FROM_GSI_LIBRARY:               NewIRODSError(common.GSI_ERROR_FROM_GSI_LIBRARY),
+	common.GSI_ERROR_IMPORTING_NAME:                 NewIRODSError(common.GSI_ERROR_IMPORTING_NAME),
+	common.GSI_ERROR_ACQUIRING_CREDS:                NewIRODSError(common.GSI_ERROR_ACQUIRING_CREDS),
+	common.GSI_ACCEPT_SEC_CONTEXT_ERROR:             NewIRODSError(common.GSI_ACCEPT_SEC_CONTEXT_ERROR),
+	common.GSI_ERROR_DISPLAYING_NAME:                NewIRODSError(common.GSI_ERROR_DISPLAYING_NAME),
+	common.GSI_ERROR_RELEASING_NAME:                 NewIRODSError(common.GSI_ERROR_RELEASING_NAME),
+	common.GSI_DN_DOES_NOT_MATCH_USER:               NewIRODSError(common.GSI_DN_DOES_NOT_MATCH_USER),
+	common.GSI_QUERY_INTERNAL_ERROR:                 NewIRODSError(common.GSI_QUERY_INTERNAL_ERROR),
+	common.GSI_NO_MATCHING_DN_FOUND:                 NewIRODSError(common.GSI_NO_MATCHING_DN_FOUND),
+	common.GSI_MULTIPLE_MATCHING_DN_FOUND:           NewIRODSError(common.GSI_MULTIPLE_MATCHING_DN_FOUND),
+	common.KRB_NOT_COMPILED_IN:                      NewIRODSError(common.KRB_NOT_COMPILED_IN),
+	common.KRB_NOT_BUILT_INTO_CLIENT:                NewIRODSError(common.KRB_NOT_BUILT_INTO_CLIENT),
+	common.KRB_NOT_BUILT_INTO_SERVER:                NewIRODSError(common.KRB_NOT_BUILT_INTO_SERVER),
+	common.KRB_ERROR_IMPORT_NAME:                    NewIRODSError(common.KRB_ERROR_IMPORT_NAME),
+	common.KRB_ERROR_INIT_SECURITY_CONTEXT:          NewIRODSError(common.KRB_ERROR_INIT_SECURITY_CONTEXT),
+	common.KRB_ERROR_SENDING_TOKEN_LENGTH:           NewIRODSError(common.KRB_ERROR_SENDING_TOKEN_LENGTH),
+	common.KRB_ERROR_READING_TOKEN_LENGTH:           NewIRODSError(common.KRB_ERROR_READING_TOKEN_LENGTH),
+	common.KRB_ERROR_TOKEN_TOO_LARGE:                NewIRODSError(common.KRB_ERROR_TOKEN_TOO_LARGE),
+	common.KRB_ERROR_BAD_TOKEN_RCVED:                NewIRODSError(common.KRB_ERROR_BAD_TOKEN_RCVED),
+	common.KRB_SOCKET_READ_ERROR:                    NewIRODSError(common.KRB_SOCKET_READ_ERROR),
+	common.KRB_PARTIAL_TOKEN_READ:                   NewIRODSError(common.KRB_PARTIAL_TOKEN_READ),
+	common.KRB_SOCKET_WRITE_ERROR:                   NewIRODSError(common.KRB_SOCKET_WRITE_ERROR),
+	common.KRB_ERROR_FROM_KRB_LIBRARY:               NewIRODSError(common.KRB_ERROR_FROM_KRB_LIBRARY),
+	common.KRB_ERROR_IMPORTING_NAME:                 NewIRODSError(common.KRB_ERROR_IMPORTING_NAME),
+	common.KRB_ERROR_ACQUIRING_CREDS:                NewIRODSError(common.KRB_ERROR_ACQUIRING_CREDS),
+	common.KRB_ACCEPT_SEC_CONTEXT_ERROR:             NewIRODSError(common.KRB_ACCEPT_SEC_CONTEXT_ERROR),
+	common.KRB_ERROR_DISPLAYING_NAME:                NewIRODSError(common.KRB_ERROR_DISPLAYING_NAME),
+	common.KRB_ERROR_RELEASING_NAME:                 NewIRODSError(common.KRB_ERROR_RELEASING_NAME),
+	common.KRB_USER_DN_NOT_FOUND:                    NewIRODSError(common.KRB_USER_DN_NOT_FOUND),
+	common.KRB_NAME_MATCHES_MULTIPLE_USERS:          NewIRODSError(common.KRB_NAME_MATCHES_MULTIPLE_USERS),
+	common.KRB_QUERY_INTERNAL_ERROR:                 NewIRODSError(common.KRB_QUERY_INTERNAL_ERROR),
+	common.OSAUTH_NOT_BUILT_INTO_CLIENT:             NewIRODSError(common.OSAUTH_NOT_BUILT_INTO_CLIENT),
+	common.OSAUTH_NOT_BUILT_INTO_SERVER:             NewIRODSError(common.OSAUTH_NOT_BUILT_INTO_SERVER),
+	common.PAM_AUTH_NOT_BUILT_INTO_CLIENT:           NewIRODSError(common.PAM_AUTH_NOT_BUILT_INTO_CLIENT),
+	common.PAM_AUTH_NOT_BUILT_INTO_SERVER:           NewIRODSError(common.PAM_AUTH_NOT_BUILT_INTO_SERVER),
+	common.PAM_AUTH_PASSWORD_FAILED:                 NewIRODSError(common.PAM_AUTH_PASSWORD_FAILED),
+	common.PAM_AUTH_PASSWORD_INVALID_TTL:            NewIRODSError(common.PAM_AUTH_PASSWORD_INVALID_TTL),
+	common.OBJPATH_EMPTY_IN_STRUCT_ERR:              NewIRODSError(common.OBJPATH_EMPTY_IN_STRUCT_ERR),
+	common.RESCNAME_EMPTY_IN_STRUCT_ERR:             NewIRODSError(common.RESCNAME_EMPTY_IN_STRUCT_ERR),
+	common.DATATYPE_EMPTY_IN_STRUCT_ERR:             NewIRODSError(common.DATATYPE_EMPTY_IN_STRUCT_ERR),
+	common.DATASIZE_EMPTY_IN_STRUCT_ERR:             NewIRODSError(common.DATASIZE_EMPTY_IN_STRUCT_ERR),
+	common.CHKSUM_EMPTY_IN_STRUCT_ERR:               NewIRODSError(common.CHKSUM_EMPTY_IN_STRUCT_ERR),
+	common.VERSION_EMPTY_IN_STRUCT_ERR:              NewIRODSError(common.VERSION_EMPTY_IN_STRUCT_ERR),
+	common.FILEPATH_EMPTY_IN_STRUCT_ERR:             NewIRODSError(common.FILEPATH_EMPTY_IN_STRUCT_ERR),
+	common.REPLNUM_EMPTY_IN_STRUCT_ERR:              NewIRODSError(common.REPLNUM_EMPTY_IN_STRUCT_ERR),
+	common.REPLSTATUS_EMPTY_IN_STRUCT_ERR:           NewIRODSError(common.REPLSTATUS_EMPTY_IN_STRUCT_ERR),
+	common.DATAOWNER_EMPTY_IN_STRUCT_ERR:            NewIRODSError(common.DATAOWNER_EMPTY_IN_STRUCT_ERR),
+	common.DATAOWNERZONE_EMPTY_IN_STRUCT_ERR:        NewIRODSError(common.DATAOWNERZONE_EMPTY_IN_STRUCT_ERR),
+	common.DATAEXPIRY_EMPTY_IN_STRUCT_ERR:           NewIRODSError(common.DATAEXPIRY_EMPTY_IN_STRUCT_ERR),
+	common.DATACOMMENTS_EMPTY_IN_STRUCT_ERR:         NewIRODSError(common.DATACOMMENTS_EMPTY_IN_STRUCT_ERR),
+	common.DATACREATE_EMPTY_IN_STRUCT_ERR:           NewIRODSError(common.DATACREATE_EMPTY_IN_STRUCT_ERR),
+	common.DATAMODIFY_EMPTY_IN_STRUCT_ERR:           NewIRODSError(common.DATAMODIFY_EMPTY_IN_STRUCT_ERR),
+	common.DATAACCESS_EMPTY_IN_STRUCT_ERR:           NewIRODSError(common.DATAACCESS_EMPTY_IN_STRUCT_ERR),
+	common.DATAACCESSINX_EMPTY_IN_STRUCT_ERR:        NewIRODSError(common.DATAACCESSINX_EMPTY_IN_STRUCT_ERR),
+	common.NO_RULE_FOUND_ERR:                        NewIRODSError(common.NO_RULE_FOUND_ERR),
+	common.NO_MORE_RULES_ERR:                        NewIRODSError(common.NO_MORE_RULES_ERR),
+	common.UNMATCHED_ACTION_ERR:                     NewIRODSError(common.UNMATCHED_ACTION_ERR),
+	common.RULES_FILE_READ_ERROR:                    NewIRODSError(common.RULES_FILE_READ_ERROR),
+	common.ACTION_ARG_COUNT_MISMATCH:                NewIRODSError(common.ACTION_ARG_COUNT_MISMATCH),
+	common.MAX_NUM_OF_ARGS_IN_ACTION_EXCEEDED:       NewIRODSError(common.MAX_NUM_OF_ARGS_IN_ACTION_EXCEEDED),
+	common.UNKNOWN_PARAM_IN_RULE_ERR:                NewIRODSError(common.UNKNOWN_PARAM_IN_RULE_ERR),
+	common.DESTRESCNAME_EMPTY_IN_STRUCT_ERR:         NewIRODSError(common.DESTRESCNAME_EMPTY_IN_STRUCT_ERR),
+	common.BACKUPRESCNAME_EMPTY_IN_STRUCT_ERR:       NewIRODSError(common.BACKUPRESCNAME_EMPTY_IN_STRUCT_ERR),
+	common.DATAID_EMPTY_IN_STRUCT_ERR:               NewIRODSError(common.DATAID_EMPTY_IN_STRUCT_ERR),
+	common.COLLID_EMPTY_IN_STRUCT_ERR:               NewIRODSError(common.COLLID_EMPTY_IN_STRUCT_ERR),
+	common.RESCGROUPNAME_EMPTY_IN_STRUCT_ERR:        NewIRODSError(common.RESCGROUPNAME_EMPTY_IN_STRUCT_ERR),
+	common.STATUSSTRING_EMPTY_IN_STRUCT_ERR:         NewIRODSError(common.STATUSSTRING_EMPTY_IN_STRUCT_ERR),
+	common.DATAMAPID_EMPTY_IN_STRUCT_ERR:            NewIRODSError(common.DATAMAPID_EMPTY_IN_STRUCT_ERR),
+	common.USERNAMECLIENT_EMPTY_IN_STRUCT_ERR:       NewIRODSError(common.USERNAMECLIENT_EMPTY_IN_STRUCT_ERR),
+	common.RODSZONECLIENT_EMPTY_IN_STRUCT_ERR:       NewIRODSError(common.RODSZONECLIENT_EMPTY_IN_STRUCT_ERR),
+	common.USERTYPECLIENT_EMPTY_IN_STRUCT_ERR:       NewIRODSError(common.USERTYPECLIENT_EMPTY_IN_STRUCT_ERR),
+	common.HOSTCLIENT_EMPTY_IN_STRUCT_ERR:           NewIRODSError(common.HOSTCLIENT_EMPTY_IN_STRUCT_ERR),
+	common.AUTHSTRCLIENT_EMPTY_IN_STRUCT_ERR:        NewIRODSError(common.AUTHSTRCLIENT_EMPTY_IN_STRUCT_ERR),
+	common.USERAUTHSCHEMECLIENT_EMPTY_IN_STRUCT_ERR: NewIRODSError(common.USERAUTHSCHEMECLIENT_EMPTY_IN_STRUCT_ERR),
+	common.USERINFOCLIENT_EMPTY_IN_STRUCT_ERR:       NewIRODSError(common.USERINFOCLIENT_EMPTY_IN_STRUCT_ERR),
+	common.USERCOMMENTCLIENT_EMPTY_IN_STRUCT_ERR:    NewIRODSError(common.USERCOMMENTCLIENT_EMPTY_IN_STRUCT_ERR),
+	common.USERCREATECLIENT_EMPTY_IN_STRUCT_ERR:     NewIRODSError(common.USERCREATECLIENT_EMPTY_IN_STRUCT_ERR),
+	common.USERMODIFYCLIENT_EMPTY_IN_STRUCT_ERR:     NewIRODSError(common.USERMODIFYCLIENT_EMPTY_IN_STRUCT_ERR),
+	common.USERNAMEPROXY_EMPTY_IN_STRUCT_ERR:        NewIRODSError(common.USERNAMEPROXY_EMPTY_IN_STRUCT_ERR),
+	common.RODSZONEPROXY_EMPTY_IN_STRUCT_ERR:        NewIRODSError(common.RODSZONEPROXY_EMPTY_IN_STRUCT_ERR),
+	common.USERTYPEPROXY_EMPTY_IN_STRUCT_ERR:        NewIRODSError(common.USERTYPEPROXY_EMPTY_IN_STRUCT_ERR),
+	common.HOSTPROXY_EMPTY_IN_STRUCT_ERR:            NewIRODSError(common.HOSTPROXY_EMPTY_IN_STRUCT_ERR),
+	common.AUTHSTRPROXY_EMPTY_IN_STRUCT_ERR:         NewIRODSError(common.AUTHSTRPROXY_EMPTY_IN_STRUCT_ERR),
+	common.USERAUTHSCHEMEPROXY_EMPTY_IN_STRUCT_ERR:  NewIRODSError(common.USERAUTHSCHEMEPROXY_EMPTY_IN_STRUCT_ERR),
+	common.USERINFOPROXY_EMPTY_IN_STRUCT_ERR:        NewIRODSError(common.USERINFOPROXY_EMPTY_IN_STRUCT_ERR),
+	common.USERCOMMENTPROXY_EMPTY_IN_STRUCT_ERR:     NewIRODSError(common.USERCOMMENTPROXY_EMPTY_IN_STRUCT_ERR),
+	common.USERCREATEPROXY_EMPTY_IN_STRUCT_ERR:      NewIRODSError(common.USERCREATEPROXY_EMPTY_IN_STRUCT_ERR),
+	common.USERMODIFYPROXY_EMPTY_IN_STRUCT_ERR:      NewIRODSError(common.USERMODIFYPROXY_EMPTY_IN_STRUCT_ERR),
+	common.COLLNAME_EMPTY_IN_STRUCT_ERR:             NewIRODSError(common.COLLNAME_EMPTY_IN_STRUCT_ERR),
+	common.COLLPARENTNAME_EMPTY_IN_STRUCT_ERR:       NewIRODSError(common.COLLPARENTNAME_EMPTY_IN_STRUCT_ERR),
+	common.COLLOWNERNAME_EMPTY_IN_STRUCT_ERR:        NewIRODSError(common.COLLOWNERNAME_EMPTY_IN_STRUCT_ERR),
+	common.COLLOWNERZONE_EMPTY_IN_STRUCT_ERR:        NewIRODSError(common.COLLOWNERZONE_EMPTY_IN_STRUCT_ERR),
+	common.COLLEXPIRY_EMPTY_IN_STRUCT_ERR:           NewIRODSError(common.COLLEXPIRY_EMPTY_IN_STRUCT_ERR),
+	common.COLLCOMMENTS_EMPTY_IN_STRUCT_ERR:         NewIRODSError(common.COLLCOMMENTS_EMPTY_IN_STRUCT_ERR),
+	common.COLLCREATE_EMPTY_IN_STRUCT_ERR:           NewIRODSError(common.COLLCREATE_EMPTY_IN_STRUCT_ERR),
+	common.COLLMODIFY_EMPTY_IN_STRUCT_ERR:           NewIRODSError(common.COLLMODIFY_EMPTY_IN_STRUCT_ERR),
+	common.COLLACCESS_EMPTY_IN_STRUCT_ERR:           NewIRODSError(common.COLLACCESS_EMPTY_IN_STRUCT_ERR),
+	common.COLLACCESSINX_EMPTY_IN_STRUCT_ERR:        NewIRODSError(common.COLLACCESSINX_EMPTY_IN_STRUCT_ERR),
+	common.COLLMAPID_EMPTY_IN_STRUCT_ERR:            NewIRODSError(common.COLLMAPID_EMPTY_IN_STRUCT_ERR),
+	common.COLLINHERITANCE_EMPTY_IN_STRUCT_ERR:      NewIRODSError(common.COLLINHERITANCE_EMPTY_IN_STRUCT_ERR),
+	common.RESCZONE_EMPTY_IN_STRUCT_ERR:             NewIRODSError(common.RESCZONE_EMPTY_IN_STRUCT_ERR),
+	common.RESCLOC_EMPTY_IN_STRUCT_ERR:              NewIRODSError(common.RESCLOC_EMPTY_IN_STRUCT_ERR),
+	common.RESCTYPE_EMPTY_IN_STRUCT_ERR:             NewIRODSError(common.RESCTYPE_EMPTY_IN_STRUCT_ERR),
+	common.RESCTYPEINX_EMPTY_IN_STRUCT_ERR:          NewIRODSError(common.RESCTYPEINX_EMPTY_IN_STRUCT_ERR),
+	common.RESCCLASS_EMPTY_IN_STRUCT_ERR:            NewIRODSError(common.RESCCLASS_EMPTY_IN_STRUCT_ERR),
+	common.RESCCLASSINX_EMPTY_IN_STRUCT_ERR:         NewIRODSError(common.RESCCLASSINX_EMPTY_IN_STRUCT_ERR),
+	common.RESCVAULTPATH_EMPTY_IN_STRUCT_ERR:        NewIRODSError(common.RESCVAULTPATH_EMPTY_IN_STRUCT_ERR),
+	common.NUMOPEN_ORTS_EMPTY_IN_STRUCT_ERR:         NewIRODSError(common.NUMOPEN_ORTS_EMPTY_IN_STRUCT_ERR),
+	common.PARAOPR_EMPTY_IN_STRUCT_ERR:              NewIRODSError(common.PARAOPR_EMPTY_IN_STRUCT_ERR),
+	common.RESCID_EMPTY_IN_STRUCT_ERR:               NewIRODSError(common.RESCID_EMPTY_IN_STRUCT_ERR),
+	common.GATEWAYADDR_EMPTY_IN_STRUCT_ERR:          NewIRODSError(common.GATEWAYADDR_EMPTY_IN_STRUCT_ERR),
+	common.RESCMAX_BJSIZE_EMPTY_IN_STRUCT_ERR:       NewIRODSError(common.RESCMAX_BJSIZE_EMPTY_IN_STRUCT_ERR),
+	common.FREESPACE_EMPTY_IN_STRUCT_ERR:            NewIRODSError(common.FREESPACE_EMPTY_IN_STRUCT_ERR),
+	common.FREESPACETIME_EMPTY_IN_STRUCT_ERR:        NewIRODSError(common.FREESPACETIME_EMPTY_IN_STRUCT_ERR),
+	common.FREESPACETIMESTAMP_EMPTY_IN_STRUCT_ERR:   NewIRODSError(common.FREESPACETIMESTAMP_EMPTY_IN_STRUCT_ERR),
+	common.RESCINFO_EMPTY_IN_STRUCT_ERR:             NewIRODSError(common.RESCINFO_EMPTY_IN_STRUCT_ERR),
+	common.RESCCOMMENTS_EMPTY_IN_STRUCT_ERR:         NewIRODSError(common.RESCCOMMENTS_EMPTY_IN_STRUCT_ERR),
+	common.RESCCREATE_EMPTY_IN_STRUCT_ERR:           NewIRODSError(common.RESCCREATE_EMPTY_IN_STRUCT_ERR),
+	common.RESCMODIFY_EMPTY_IN_STRUCT_ERR:           NewIRODSError(common.RESCMODIFY_EMPTY_IN_STRUCT_ERR),
+	common.INPUT_ARG_NOT_WELL_FORMED_ERR:            NewIRODSError(common.INPUT_ARG_NOT_WELL_FORMED_ERR),
+	common.INPUT_ARG_OUT_OF_ARGC_RANGE_ERR:          NewIRODSError(common.INPUT_ARG_OUT_OF_ARGC_RANGE_ERR),
+	common.INSUFFICIENT_INPUT_ARG_ERR:               NewIRODSError(common.INSUFFICIENT_INPUT_ARG_ERR),
+	common.INPUT_ARG_DOES_NOT_MATCH_ERR:             NewIRODSError(common.INPUT_ARG_DOES_NOT_MATCH_ERR),
+	common.RETRY_WITHOUT_RECOVERY_ERR:               NewIRODSError(common.RETRY_WITHOUT_RECOVERY_ERR),
+	common.CUT_ACTION_PROCESSED_ERR:                 NewIRODSError(common.CUT_ACTION_PROCESSED_ERR),
+	common.ACTION_FAILED_ERR:                        NewIRODSError(common.ACTION_FAILED_ERR),
+	common.FAIL_ACTION_ENCOUNTERED_ERR:              NewIRODSError(common.FAIL_ACTION_ENCOUNTERED_ERR),
+	common.VARIABLE_NAME_TOO_LONG_ERR:               NewIRODSError(common.VARIABLE_NAME_TOO_LONG_ERR),
+	common.UNKNOWN_VARIABLE_MAP_ERR:                 NewIRODSError(common.UNKNOWN_VARIABLE_MAP_ERR),
+	common.UNDEFINED_VARIABLE_MAP_ERR:               NewIRODSError(common.UNDEFINED_VARIABLE_MAP_ERR),
+	common.NULL_VALUE_ERR:                           NewIRODSError(common.NULL_VALUE_ERR),
+	common.DVARMAP_FILE_READ_ERROR:                  NewIRODSError(common.DVARMAP_FILE_READ_ERROR),
+	common.NO_RULE_OR_MSI_FUNCTION_FOUND_ERR:        NewIRODSError(common.NO_RULE_OR_MSI_FUNCTION_FOUND_ERR),
+	common.FILE_CREATE_ERROR:                        NewIRODSError(common.FILE_CREATE_ERROR),
+	common.FMAP_FILE_READ_ERROR:                     NewIRODSError(common.FMAP_FILE_READ_ERROR),
+	common.DATE_FORMAT_ERR:                          NewIRODSError(common.DATE_FORMAT_ERR),
+	common.RULE_FAILED_ERR:                          NewIRODSError(common.RULE_FAILED_ERR),
+	common.NO_MICROSERVICE_FOUND_ERR:                NewIRODSError(common.NO_MICROSERVICE_FOUND_ERR),
+	common.INVALID_REGEXP:                           NewIRODSError(common.INVALID_REGEXP),
+	common.INVALID_OBJECT_NAME:                      NewIRODSError(common.INVALID_OBJECT_NAME),
+	common.INVALID_OBJECT_TYPE:                      NewIRODSError(common.INVALID_OBJECT_TYPE),
+	common.NO_VALUES_FOUND:                          NewIRODSError(common.NO_VALUES_FOUND),
+	common.NO_COLUMN_NAME_FOUND:                     NewIRODSError(common.NO_COLUMN_NAME_FOUND),
+	common.BREAK_ACTION_ENCOUNTERED_ERR:             NewIRODSError(common.BREAK_ACTION_ENCOUNTERED_ERR),
+	common.CUT_ACTION_ON_SUCCESS_PROCESSED_ERR:      NewIRODSError(common.CUT_ACTION_ON_SUCCESS_PROCESSED_ERR),
+	common.MSI_OPERATION_NOT_ALLOWED:                NewIRODSError(common.MSI_OPERATION_NOT_ALLOWED),
+	common.MAX_NUM_OF_ACTION_IN_RULE_EXCEEDED:       NewIRODSError(common.MAX_NUM_OF_ACTION_IN_RULE_EXCEEDED),
+	common.MSRVC_FILE_READ_ERROR:                    NewIRODSError(common.MSRVC_FILE_READ_ERROR),
+	common.MSRVC_VERSION_MISMATCH:                   NewIRODSError(common.MSRVC_VERSION_MISMATCH),
+	common.MICRO_SERVICE_OBJECT_TYPE_UNDEFINED:      NewIRODSError(common.MICRO_SERVICE_OBJECT_TYPE_UNDEFINED),
+	common.MSO_OBJ_GET_FAILED:                       NewIRODSError(common.MSO_OBJ_GET_FAILED),
+	common.REMOTE_IRODS_CONNECT_ERR:                 NewIRODSError(common.REMOTE_IRODS_CONNECT_ERR),
+	common.REMOTE_SRB_CONNECT_ERR:                   NewIRODSError(common.REMOTE_SRB_CONNECT_ERR),
+	common.MSO_OBJ_PUT_FAILED:                       NewIRODSError(common.MSO_OBJ_PUT_FAILED),
+	common.RE_PARSER_ERROR:                          NewIRODSError(common.RE_PARSER_ERROR),
+	common.RE_UNPARSED_SUFFIX:                       NewIRODSError(common.RE_UNPARSED_SUFFIX),
+	common.RE_POINTER_ERROR:                         NewIRODSError(common.RE_POINTER_ERROR),
+	common.RE_RUNTIME_ERROR:                         NewIRODSError(common.RE_RUNTIME_ERROR),
+	common.RE_DIVISION_BY_ZERO:                      NewIRODSError(common.RE_DIVISION_BY_ZERO),
+	common.RE_BUFFER_OVERFLOW:                       NewIRODSError(common.RE_BUFFER_OVERFLOW),
+	common.RE_UNSUPPORTED_OP_OR_TYPE:                NewIRODSError(common.RE_UNSUPPORTED_OP_OR_TYPE),
+	common.RE_UNSUPPORTED_SESSION_VAR:               NewIRODSError(common.RE_UNSUPPORTED_SESSION_VAR),
+	common.RE_UNABLE_TO_WRITE_LOCAL_VAR:             NewIRODSError(common.RE_UNABLE_TO_WRITE_LOCAL_VAR),
+	common.RE_UNABLE_TO_READ_LOCAL_VAR:              NewIRODSError(common.RE_UNABLE_TO_READ_LOCAL_VAR),
+	common.RE_UNABLE_TO_WRITE_SESSION_VAR:           NewIRODSError(common.RE_UNABLE_TO_WRITE_SESSION_VAR),
+	common.RE_UNABLE_TO_READ_SESSION_VAR:            NewIRODSError(common.RE_UNABLE_TO_READ_SESSION_VAR),
+	common.RE_UNABLE_TO_WRITE_VAR:                   NewIRODSError(common.RE_UNABLE_TO_WRITE_VAR),
+	common.RE_UNABLE_TO_READ_VAR:                    NewIRODSError(common.RE_UNABLE_TO_READ_VAR),
+	common.RE_PATTERN_NOT_MATCHED:                   NewIRODSError(common.RE_PATTERN_NOT_MATCHED),
+	common.RE_STRING_OVERFLOW:                       NewIRODSError(common.RE_STRING_OVERFLOW),
+	common.RE_UNKNOWN_ERROR:                         NewIRODSError(common.RE_UNKNOWN_ERROR),
+	common.RE_OUT_OF_MEMORY:                         NewIRODSError(common.RE_OUT_OF_MEMORY),
+	common.RE_SHM_UNLINK_ERROR:                      NewIRODSError(common.RE_SHM_UNLINK_ERROR),
+	common.RE_FILE_STAT_ERROR:                       NewIRODSError(common.RE_FILE_STAT_ERROR),
+	common.RE_UNSUPPORTED_AST_NODE_TYPE:             NewIRODSError(common.RE_UNSUPPORTED_AST_NODE_TYPE),
+	common.RE_UNSUPPORTED_SESSION_VAR_TYPE:          NewIRODSError(common.RE_UNSUPPORTED_SESSION_VAR_TYPE),
+	common.RE_TYPE_ERROR:                            NewIRODSError(common.RE_TYPE_ERROR),
+	common.RE_FUNCTION_REDEFINITION:                 NewIRODSError(common.RE_FUNCTION_REDEFINITION),
+	common.RE_DYNAMIC_TYPE_ERROR:                    NewIRODSError(common.RE_DYNAMIC_TYPE_ERROR),
+	common.RE_DYNAMIC_COERCION_ERROR:                NewIRODSError(common.RE_DYNAMIC_COERCION_ERROR),
+	common.RE_PACKING_ERROR:                         NewIRODSError(common.RE_PACKING_ERROR),
+	common.PHP_EXEC_SCRIPT_ERR:                      NewIRODSError(common.PHP_EXEC_SCRIPT_ERR),
+	common.PHP_REQUEST_STARTUP_ERR:                  NewIRODSError(common.PHP_REQUEST_STARTUP_ERR),
+	common.PHP_OPEN_SCRIPT_FILE_ERR:                 NewIRODSError(common.PHP_OPEN_SCRIPT_FILE_ERR),
+	common.KEY_NOT_FOUND:                            NewIRODSError(common.KEY_NOT_FOUND),
+	common.KEY_TYPE_MISMATCH:                        NewIRODSError(common.KEY_TYPE_MISMATCH),
+	common.CHILD_EXISTS:                             NewIRODSError(common.CHILD_EXISTS),
+	common.HIERARCHY_ERROR:                          NewIRODSError(common.HIERARCHY_ERROR),
+	common.CHILD_NOT_FOUND:                          NewIRODSError(common.CHILD_NOT_FOUND),
+	common.NO_NEXT_RESC_FOUND:                       NewIRODSError(common.NO_NEXT_RESC_FOUND),
+	common.NO_PDMO_DEFINED:                          NewIRODSError(common.NO_PDMO_DEFINED),
+	common.INVALID_LOCATION:                         NewIRODSError(common.INVALID_LOCATION),
+	common.PLUGIN_ERROR:                             NewIRODSError(common.PLUGIN_ERROR),
+	common.INVALID_RESC_CHILD_CONTEXT:               NewIRODSError(common.INVALID_RESC_CHILD_CONTEXT),
+	common.INVALID_FILE_OBJECT:                      NewIRODSError(common.INVALID_FILE_OBJECT),
+	common.INVALID_OPERATION:                        NewIRODSError(common.INVALID_OPERATION),
+	common.CHILD_HAS_PARENT:                         NewIRODSError(common.CHILD_HAS_PARENT),
+	common.FILE_NOT_IN_VAULT:                        NewIRODSError(common.FILE_NOT_IN_VAULT),
+	common.DIRECT_ARCHIVE_ACCESS:                    NewIRODSError(common.DIRECT_ARCHIVE_ACCESS),
+	common.ADVANCED_NEGOTIATION_NOT_SUPPORTED:       NewIRODSError(common.ADVANCED_NEGOTIATION_NOT_SUPPORTED),
+	common.DIRECT_CHILD_ACCESS:                      NewIRODSError(common.DIRECT_CHILD_ACCESS),
+	common.INVALID_DYNAMIC_CAST:                     NewIRODSError(common.INVALID_DYNAMIC_CAST),
+	common.INVALID_ACCESS_TO_IMPOSTOR_RESOURCE:      NewIRODSError(common.INVALID_ACCESS_TO_IMPOSTOR_RESOURCE),
+	common.INVALID_LEXICAL_CAST:                     NewIRODSError(common.INVALID_LEXICAL_CAST),
+	common.CONTROL_PLANE_MESSAGE_ERROR:              NewIRODSError(common.CONTROL_PLANE_MESSAGE_ERROR),
+	common.REPLICA_NOT_IN_RESC:                      NewIRODSError(common.REPLICA_NOT_IN_RESC),
+	common.INVALID_ANY_CAST:                         NewIRODSError(common.INVALID_ANY_CAST),
+	common.BAD_FUNCTION_CALL:                        NewIRODSError(common.BAD_FUNCTION_CALL),
+	common.CLIENT_NEGOTIATION_ERROR:                 NewIRODSError(common.CLIENT_NEGOTIATION_ERROR),
+	common.SERVER_NEGOTIATION_ERROR:                 NewIRODSError(common.SERVER_NEGOTIATION_ERROR),
+	common.INVALID_KVP_STRING:                       NewIRODSError(common.INVALID_KVP_STRING),
+	common.PLUGIN_ERROR_MISSING_SHARED_OBJECT:       NewIRODSError(common.PLUGIN_ERROR_MISSING_SHARED_OBJECT),
+	common.RULE_ENGINE_ERROR:                        NewIRODSError(common.RULE_ENGINE_ERROR),
+	common.REBALANCE_ALREADY_ACTIVE_ON_RESOURCE:     NewIRODSError(common.REBALANCE_ALREADY_ACTIVE_ON_RESOURCE),
+	common.NETCDF_OPEN_ERR:                          NewIRODSError(common.NETCDF_OPEN_ERR),
+	common.NETCDF_CREATE_ERR:                        NewIRODSError(common.NETCDF_CREATE_ERR),
+	common.NETCDF_CLOSE_ERR:                         NewIRODSError(common.NETCDF_CLOSE_ERR),
+	common.NETCDF_INVALID_PARAM_TYPE:                NewIRODSError(common.NETCDF_INVALID_PARAM_TYPE),
+	common.NETCDF_INQ_ID_ERR:                        NewIRODSError(common.NETCDF_INQ_ID_ERR),
+	common.NETCDF_GET_VARS_ERR:                      NewIRODSError(common.NETCDF_GET_VARS_ERR),
+	common.NETCDF_INVALID_DATA_TYPE:                 NewIRODSError(common.NETCDF_INVALID_DATA_TYPE),
+	common.NETCDF_INQ_VARS_ERR:                      NewIRODSError(common.NETCDF_INQ_VARS_ERR),
+	common.NETCDF_VARS_DATA_TOO_BIG:                 NewIRODSError(common.NETCDF_VARS_DATA_TOO_BIG),
+	common.NETCDF_DIM_MISMATCH_ERR:                  NewIRODSError(common.NETCDF_DIM_MISMATCH_ERR),
+	common.NETCDF_INQ_ERR:                           NewIRODSError(common.NETCDF_INQ_ERR),
+	common.NETCDF_INQ_FORMAT_ERR:                    NewIRODSError(common.NETCDF_INQ_FORMAT_ERR),
+	common.NETCDF_INQ_DIM_ERR:                       NewIRODSError(common.NETCDF_INQ_DIM_ERR),
+	common.NETCDF_INQ_ATT_ERR:                       NewIRODSError(common.NETCDF_INQ_ATT_ERR),
+	common.NETCDF_GET_ATT_ERR:                       NewIRODSError(common.NETCDF_GET_ATT_ERR),
+	common.NETCDF_VAR_COUNT_OUT_OF_RANGE:            NewIRODSError(common.NETCDF_VAR_COUNT_OUT_OF_RANGE),
+	common.NETCDF_UNMATCHED_NAME_ERR:                NewIRODSError(common.NETCDF_UNMATCHED_NAME_ERR),
+	common.NETCDF_NO_UNLIMITED_DIM:                  NewIRODSError(common.NETCDF_NO_UNLIMITED_DIM),
+	common.NETCDF_PUT_ATT_ERR:                       NewIRODSError(common.NETCDF_PUT_ATT_ERR),
+	common.NETCDF_DEF_DIM_ERR:                       NewIRODSError(common.NETCDF_DEF_DIM_ERR),
+	common.NETCDF_DEF_VAR_ERR:                       NewIRODSError(common.NETCDF_DEF_VAR_ERR),
+	common.NETCDF_PUT_VARS_ERR:                      NewIRODSError(common.NETCDF_PUT_VARS_ERR),
+	common.NETCDF_AGG_INFO_FILE_ERR:                 NewIRODSError(common.NETCDF_AGG_INFO_FILE_ERR),
+	common.NETCDF_AGG_ELE_INX_OUT_OF_RANGE:          NewIRODSError(common.NETCDF_AGG_ELE_INX_OUT_OF_RANGE),
+	common.NETCDF_AGG_ELE_FILE_NOT_OPENED:           NewIRODSError(common.NETCDF_AGG_ELE_FILE_NOT_OPENED),
+	common.NETCDF_AGG_ELE_FILE_NO_TIME_DIM:          NewIRODSError(common.NETCDF_AGG_ELE_FILE_NO_TIME_DIM),
+	common.SSL_NOT_BUILT_INTO_CLIENT:                NewIRODSError(common.SSL_NOT_BUILT_INTO_CLIENT),
+	common.SSL_NOT_BUILT_INTO_SERVER:                NewIRODSError(common.SSL_NOT_BUILT_INTO_SERVER),
+	common.SSL_INIT_ERROR:                           NewIRODSError(common.SSL_INIT_ERROR),
+	common.SSL_HANDSHAKE_ERROR:                      NewIRODSError(common.SSL_HANDSHAKE_ERROR),
+	common.SSL_SHUTDOWN_ERROR:                       NewIRODSError(common.SSL_SHUTDOWN_ERROR),
+	common.SSL_CERT_ERROR:                           NewIRODSError(common.SSL_CERT_ERROR),
+	common.OOI_CURL_EASY_INIT_ERR:                   NewIRODSError(common.OOI_CURL_EASY_INIT_ERR),
+	common.OOI_JSON_OBJ_SET_ERR:                     NewIRODSError(common.OOI_JSON_OBJ_SET_ERR),
+	common.OOI_DICT_TYPE_NOT_SUPPORTED:              NewIRODSError(common.OOI_DICT_TYPE_NOT_SUPPORTED),
+	common.OOI_JSON_PACK_ERR:                        NewIRODSError(common.OOI_JSON_PACK_ERR),
+	common.OOI_JSON_DUMP_ERR:                        NewIRODSError(common.OOI_JSON_DUMP_ERR),
+	common.OOI_CURL_EASY_PERFORM_ERR:                NewIRODSError(common.OOI_CURL_EASY_PERFORM_ERR),
+	common.OOI_JSON_LOAD_ERR:                        NewIRODSError(common.OOI_JSON_LOAD_ERR),
+	common.OOI_JSON_GET_ERR:                         NewIRODSError(common.OOI_JSON_GET_ERR),
+	common.OOI_JSON_NO_ANSWER_ERR:                   NewIRODSError(common.OOI_JSON_NO_ANSWER_ERR),
+	common.OOI_JSON_TYPE_ERR:                        NewIRODSError(common.OOI_JSON_TYPE_ERR),
+	common.OOI_JSON_INX_OUT_OF_RANGE:                NewIRODSError(common.OOI_JSON_INX_OUT_OF_RANGE),
+	common.OOI_REVID_NOT_FOUND:                      NewIRODSError(common.OOI_REVID_NOT_FOUND),
+	common.DEPRECATED_PARAMETER:                     NewIRODSError(common.DEPRECATED_PARAMETER),
+	common.XML_PARSING_ERR:                          NewIRODSError(common.XML_PARSING_ERR),
+	common.OUT_OF_URL_PATH:                          NewIRODSError(common.OUT_OF_URL_PATH),
+	common.URL_PATH_INX_OUT_OF_RANGE:                NewIRODSError(common.URL_PATH_INX_OUT_OF_RANGE),
+	common.SYS_NULL_INPUT:                           NewIRODSError(common.SYS_NULL_INPUT),
+	common.SYS_HANDLER_DONE_WITH_ERROR:              NewIRODSError(common.SYS_HANDLER_DONE_WITH_ERROR),
+	common.SYS_HANDLER_DONE_NO_ERROR:                NewIRODSError(common.SYS_HANDLER_DONE_NO_ERROR),
+	common.SYS_NO_HANDLER_REPLY_MSG:                 NewIRODSError(common.SYS_NO_HANDLER_REPLY_MSG),
+}
@@ -0,0 +1,82 @@
+//go:build ignore
+
+// gen_error_sentinel generates error_sentinel.go, a table of sentinel errors for all error codes in irods/common/error_code.go
+// the table is unexported, use GetIRODSErrorSentinel or the hand-picked sentinels in error.go
+// run with go generate ./irods/types/
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+)
+
+const (
+	errorCodeFile = "../common/error_code.go"
+	outputFile    = "error_sentinel.go"
+)
+
+// getErrorCodeNames returns names of error code constants in the order of declaration
+func getErrorCodeNames() ([]string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, errorCodeFile, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			ident, ok := valueSpec.Type.(*ast.Ident)
+			if !ok || ident.Name != "ErrorCode" {
+				continue
+			}
+
+			for _, name := range valueSpec.Names {
+				names = append(names, name.Name)
+			}
+		}
+	}
+	return names, nil
+}
+
+func main() {
+	names, err := getErrorCodeNames()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read error codes: %v\n", err)
+		os.Exit(1)
+	}
+
+	buf := bytes.Buffer{}
+	buf.WriteString("// Code generated by gen_error_sentinel.go; DO NOT EDIT.\n\n")
+	buf.WriteString("package types\n\n")
+	buf.WriteString("import \"github.com/cyverse/go-irodsclient/irods/common\"\n\n")
+	buf.WriteString("// irodsErrorSentinels maps error codes to sentinel errors, use GetIRODSErrorSentinel\n")
+	buf.WriteString("var irodsErrorSentinels = map[common.ErrorCode]*IRODSError{\n")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "\tcommon.%s: NewIRODSError(common.%s),\n", name, name)
+	}
+	buf.WriteString("}\n")
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to format generated code: %v\n", err)
+		os.Exit(1)
+	}
+
+	err = os.WriteFile(outputFile, source, 0o644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write %s: %v\n", outputFile, err)
+		os.Exit(1)
+	}
+}
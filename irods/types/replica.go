@@ -5,6 +5,22 @@ import (
 	"time"
 )
 
+// ReplicaStatus is a status of a replica
+type ReplicaStatus string
+
+const (
+	// ReplicaStatusStale is a status of a replica not up to date
+	ReplicaStatusStale ReplicaStatus = "0"
+	// ReplicaStatusGood is a status of an up to date replica
+	ReplicaStatusGood ReplicaStatus = "1"
+	// ReplicaStatusIntermediate is a status of a replica being written
+	ReplicaStatusIntermediate ReplicaStatus = "2"
+	// ReplicaStatusReadLocked is a status of a replica locked for read
+	ReplicaStatusReadLocked ReplicaStatus = "3"
+	// ReplicaStatusWriteLocked is a status of a replica locked for write
+	ReplicaStatusWriteLocked ReplicaStatus = "4"
+)
+
 // IRODSReplica contains irods data object replication information
 type IRODSReplica struct {
 	Number int64 `json:"number"`
@@ -28,6 +44,11 @@ type IRODSReplica struct {
 	AccessTime time.Time `json:"access_time"` // iRODS 5+
 }
 
+// GetStatus returns the status of the replica
+func (obj *IRODSReplica) GetStatus() ReplicaStatus {
+	return ReplicaStatus(obj.Status)
+}
+
 // ToString stringifies the object
 func (obj *IRODSReplica) ToString() string {
 	return fmt.Sprintf("<IRODSReplica %d %s %s %s %s %s>", obj.Number, obj.Status, obj.ResourceName, obj.CreateTime, obj.ModifyTime, obj.AccessTime)
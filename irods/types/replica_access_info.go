@@ -0,0 +1,97 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+)
+
+// IRODSReplicaAccessInfo contains information to open a replica being written by another client
+// it is serializable, so other processes can open the replica and write distinct ranges of it in parallel
+type IRODSReplicaAccessInfo struct {
+	Path              string `json:"path"`
+	Resource          string `json:"resource,omitempty"`
+	ResourceHierarchy string `json:"resource_hierarchy"`
+	ReplicaToken      string `json:"replica_token"`
+	ThreadNum         int    `json:"thread_num,omitempty"` // number of clients writing the replica
+	DataSize          int64  `json:"data_size,omitempty"`  // final size of the data object
+}
+
+// NewIRODSReplicaAccessInfoFromJSON creates IRODSReplicaAccessInfo from JSON
+func NewIRODSReplicaAccessInfoFromJSON(jsonBytes []byte) (*IRODSReplicaAccessInfo, error) {
+	accessInfo := &IRODSReplicaAccessInfo{}
+	err := json.Unmarshal(jsonBytes, accessInfo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal json to replica access info")
+	}
+
+	err = accessInfo.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return accessInfo, nil
+}
+
+// NewIRODSReplicaAccessInfoFromToken creates IRODSReplicaAccessInfo from a token string made by GetToken
+func NewIRODSReplicaAccessInfoFromToken(token string) (*IRODSReplicaAccessInfo, error) {
+	jsonBytes, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode replica access token")
+	}
+
+	return NewIRODSReplicaAccessInfoFromJSON(jsonBytes)
+}
+
+// Validate validates the replica access info
+func (info *IRODSReplicaAccessInfo) Validate() error {
+	if info == nil {
+		return errors.Errorf("replica access info is not set")
+	}
+
+	if len(info.Path) == 0 {
+		return errors.Errorf("empty path in replica access info")
+	}
+
+	if len(info.ReplicaToken) == 0 {
+		return errors.Errorf("empty replica token in replica access info")
+	}
+
+	if len(info.ResourceHierarchy) == 0 {
+		return errors.Errorf("empty resource hierarchy in replica access info")
+	}
+
+	return nil
+}
+
+// ToJSON returns JSON of the replica access info
+func (info *IRODSReplicaAccessInfo) ToJSON() ([]byte, error) {
+	jsonBytes, err := json.Marshal(info)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal replica access info to json")
+	}
+
+	return jsonBytes, nil
+}
+
+// GetToken returns an opaque string of the replica access info that can be passed to other processes, e.g., via environment variables
+func (info *IRODSReplicaAccessInfo) GetToken() (string, error) {
+	jsonBytes, err := info.ToJSON()
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(jsonBytes), nil
+}
+
+// ToString stringifies the object, the replica token is redacted as it grants write access to the replica
+func (info *IRODSReplicaAccessInfo) ToString() string {
+	replicaToken := ""
+	if len(info.ReplicaToken) > 0 {
+		replicaToken = "REDACTED"
+	}
+
+	return fmt.Sprintf("<IRODSReplicaAccessInfo %s %s %s %d %d>", info.Path, info.ResourceHierarchy, replicaToken, info.ThreadNum, info.DataSize)
+}
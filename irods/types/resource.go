@@ -2,9 +2,15 @@ package types
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
+const (
+	// ResourceHierarchySeparator is a separator of resource names in a resource hierarchy, e.g., root;child;leaf
+	ResourceHierarchySeparator string = ";"
+)
+
 // IRODSResource describes a resource host
 type IRODSResource struct {
 	RescID   int64  `json:"resc_id"`
@@ -20,6 +26,9 @@ type IRODSResource struct {
 	// Context has the context string
 	Context string `json:"context"`
 
+	// Parent has the parent resource, ID for iRODS 4.2 or higher, empty for root resources
+	Parent string `json:"parent"`
+
 	// CreateTime has creation time
 	CreateTime time.Time `json:"create_time"`
 	// ModifyTime has last modified time
@@ -30,3 +39,20 @@ type IRODSResource struct {
 func (res *IRODSResource) ToString() string {
 	return fmt.Sprintf("<IRODSResource %s: %v>", res.Name, res)
 }
+
+// IsResourceHierarchy returns true if the resource is a resource hierarchy having multiple levels, e.g., root;child;leaf
+func IsResourceHierarchy(resource string) bool {
+	return strings.Contains(resource, ResourceHierarchySeparator)
+}
+
+// GetResourceHierarchyRoot returns the root resource of the resource hierarchy
+func GetResourceHierarchyRoot(resourceHierarchy string) string {
+	root, _, _ := strings.Cut(resourceHierarchy, ResourceHierarchySeparator)
+	return root
+}
+
+// GetResourceHierarchyLeaf returns the leaf resource of the resource hierarchy
+func GetResourceHierarchyLeaf(resourceHierarchy string) string {
+	idx := strings.LastIndex(resourceHierarchy, ResourceHierarchySeparator)
+	return resourceHierarchy[idx+1:]
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+)
+
+// IRODSServerCapabilities contains features supported by the server, computed when a connection is made
+type IRODSServerCapabilities struct {
+	ParallelUpload       bool `json:"parallel_upload"`         // parallel upload with multiple connections, available from 4.2.9
+	NewXML               bool `json:"new_xml"`                 // XML messages are escaped in the new way, used from 4.2.9
+	NewAuthFramework     bool `json:"new_auth_framework"`      // authentication plugin framework, available from 4.3.0
+	LibraryFeatures      bool `json:"library_features"`        // get_library_features API, available from 4.3.1
+	GridConfiguration    bool `json:"grid_configuration"`      // get_grid_configuration_value API, available from 4.3.1
+	ReplicaTruncate      bool `json:"replica_truncate"`        // replica_truncate API, available from 4.3.2
+	DataObjectAccessTime bool `json:"data_object_access_time"` // DATA_ACCESS_TIME column, available from 5.0.0
+
+	// features reported by get_library_features, the value is a version of the feature, e.g., 202208
+	Features map[string]int `json:"features"`
+}
+
+// NewIRODSServerCapabilities returns capabilities derived from the server version, features are not set
+// a nil version returns no capabilities except NewXML which is the default
+func NewIRODSServerCapabilities(version *IRODSVersion) *IRODSServerCapabilities {
+	if version == nil {
+		return &IRODSServerCapabilities{
+			NewXML:   true,
+			Features: map[string]int{},
+		}
+	}
+
+	return &IRODSServerCapabilities{
+		ParallelUpload:       version.HasHigherVersionThan(4, 2, 9),
+		NewXML:               version.HasHigherVersionThan(4, 2, 9),
+		NewAuthFramework:     version.HasHigherVersionThan(4, 3, 0),
+		LibraryFeatures:      version.HasHigherVersionThan(4, 3, 1),
+		GridConfiguration:    version.HasHigherVersionThan(4, 3, 1),
+		ReplicaTruncate:      version.HasHigherVersionThan(4, 3, 2),
+		DataObjectAccessTime: version.HasHigherVersionThan(5, 0, 0),
+		Features:             map[string]int{},
+	}
+}
+
+// HasFeature returns true if get_library_features reported the feature, e.g., "IRODS_HAS_LIBRARY_TICKET_ADMINISTRATION"
+func (capabilities *IRODSServerCapabilities) HasFeature(name string) bool {
+	_, ok := capabilities.Features[name]
+	return ok
+}
+
+// GetFeatureVersion returns the version of the feature reported by get_library_features, 0 if not reported
+func (capabilities *IRODSServerCapabilities) GetFeatureVersion(name string) int {
+	return capabilities.Features[name]
+}
+
+// GetFeatureNames returns names of features reported by get_library_features, sorted
+func (capabilities *IRODSServerCapabilities) GetFeatureNames() []string {
+	names := make([]string, 0, len(capabilities.Features))
+	for name := range capabilities.Features {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// ToString stringifies the object
+func (capabilities *IRODSServerCapabilities) ToString() string {
+	return fmt.Sprintf("<IRODSServerCapabilities parallel_upload=%t new_xml=%t new_auth_framework=%t library_features=%t grid_configuration=%t replica_truncate=%t data_object_access_time=%t features=%v>", capabilities.ParallelUpload, capabilities.NewXML, capabilities.NewAuthFramework, capabilities.LibraryFeatures, capabilities.GridConfiguration, capabilities.ReplicaTruncate, capabilities.DataObjectAccessTime, capabilities.GetFeatureNames())
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// IRODSServerType is a type of iRODS server
+type IRODSServerType int
+
+const (
+	// IRODSServerTypeCatalogProvider is a server connected to the catalog database, RCAT_ENABLED
+	IRODSServerTypeCatalogProvider IRODSServerType = 1
+	// IRODSServerTypeCatalogConsumer is a server not connected to the catalog database, RCAT_NOT_ENABLED
+	IRODSServerTypeCatalogConsumer IRODSServerType = 2
+)
+
+// ToString stringifies the server type
+func (serverType IRODSServerType) ToString() string {
+	switch serverType {
+	case IRODSServerTypeCatalogProvider:
+		return "provider"
+	case IRODSServerTypeCatalogConsumer:
+		return "consumer"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(serverType))
+	}
+}
+
+// IRODSServerInfo contains information of the iRODS server a connection is made to
+type IRODSServerInfo struct {
+	Type           IRODSServerType `json:"type"`
+	ReleaseVersion string          `json:"release_version"` // e.g., "rods4.2.8"
+	APIVersion     string          `json:"api_version"`
+	BootTime       time.Time       `json:"boot_time"`
+	Zone           string          `json:"zone"`
+}
+
+// IsCatalogProvider returns true if the server is connected to the catalog database
+func (info *IRODSServerInfo) IsCatalogProvider() bool {
+	return info.Type == IRODSServerTypeCatalogProvider
+}
+
+// GetVersion returns the version info to compare release versions
+func (info *IRODSServerInfo) GetVersion() *IRODSVersion {
+	return &IRODSVersion{
+		ReleaseVersion: info.ReleaseVersion,
+		APIVersion:     info.APIVersion,
+	}
+}
+
+// GetUptime returns time passed since the server booted
+func (info *IRODSServerInfo) GetUptime() time.Duration {
+	if info.BootTime.IsZero() {
+		return 0
+	}
+
+	return time.Since(info.BootTime)
+}
+
+// ToString stringifies the object
+func (info *IRODSServerInfo) ToString() string {
+	return fmt.Sprintf("<IRODSServerInfo %s %s %s %s %s>", info.Type.ToString(), info.ReleaseVersion, info.APIVersion, info.BootTime, info.Zone)
+}
@@ -3,11 +3,12 @@ package types
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/cockroachdb/errors"
-	"github.com/hashicorp/go-rootcerts"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -19,7 +20,7 @@ const (
 	SSLVerifyServerCert SSLVerifyServer = "cert"
 	// SSLVerifyServerHostname verifies server by hostname
 	SSLVerifyServerHostname SSLVerifyServer = "hostname"
-	// SSLVerifyServerNone does not verify server
+	// SSLVerifyServerNone does not verify server, this is insecure and should be used only for development
 	SSLVerifyServerNone SSLVerifyServer = "none"
 )
 
@@ -58,76 +59,171 @@ type IRODSSSLConfig struct {
 	VerifyServer            SSLVerifyServer
 	DHParamsFile            string
 	ServerName              string // optional server name for verification
+	ClientCertificateFile   string // optional client certificate for mutual TLS
+	ClientKeyFile           string // optional client private key for mutual TLS
+	MinTLSVersion           uint16 // optional minimum TLS version, e.g., tls.VersionTLS12
+
+	// TLSConfig is an optional custom TLS config, if set, it is used instead of building one from fields above
+	// use this to supply client certificates or custom verification callbacks
+	TLSConfig *tls.Config
 }
 
 // LoadCACert loads CA Cert
+// CA certificates are loaded from CACertificateFile and all files in CACertificatePath (CA bundle dir)
+// returns nil if no CA certificate is found, which makes TLS to use system roots
 func (config *IRODSSSLConfig) LoadCACert(ignoreWrongFile bool) (*x509.CertPool, error) {
 	logger := log.WithFields(log.Fields{
 		"ignore_wrong_file": ignoreWrongFile,
 	})
 
+	certPool := x509.NewCertPool()
+	loaded := false
+
 	if len(config.CACertificateFile) > 0 {
-		// check file exists
-		_, err := os.Stat(config.CACertificateFile)
+		pemBytes, err := os.ReadFile(config.CACertificateFile)
 		if err != nil {
-			if os.IsNotExist(err) {
-				if ignoreWrongFile {
-					logger.Debugf("CA Certificate File %q does not exist, ignoring.", config.CACertificateFile)
-				} else {
-					newErr := NewFileNotFoundError(config.CACertificateFile)
-					return nil, errors.Wrapf(newErr, "CA Certificate File %q error", config.CACertificateFile)
-				}
+			if os.IsNotExist(err) && ignoreWrongFile {
+				logger.Debugf("CA Certificate File %q does not exist, ignoring.", config.CACertificateFile)
+			} else if os.IsNotExist(err) {
+				newErr := NewFileNotFoundError(config.CACertificateFile)
+				return nil, errors.Wrapf(newErr, "CA Certificate File %q error", config.CACertificateFile)
 			} else {
 				return nil, errors.Wrapf(err, "CA Certificate File %q error", config.CACertificateFile)
 			}
+		} else {
+			if !certPool.AppendCertsFromPEM(pemBytes) {
+				return nil, errors.Errorf("failed to load CA Certificate File %q, no valid certificate found", config.CACertificateFile)
+			}
+			loaded = true
 		}
 	}
 
 	if len(config.CACertificatePath) > 0 {
-		// check file exists
-		_, err := os.Stat(config.CACertificatePath)
+		dirEntries, err := os.ReadDir(config.CACertificatePath)
 		if err != nil {
-			if os.IsNotExist(err) {
-				if ignoreWrongFile {
-					newErr := NewFileNotFoundError(config.CACertificatePath)
-					return nil, errors.Wrapf(newErr, "CA Certificate Path %q error", config.CACertificatePath)
-				} else {
-					logger.Debugf("CA Certificate Path %q does not exist, ignoring.", config.CACertificatePath)
-				}
+			if os.IsNotExist(err) && ignoreWrongFile {
+				logger.Debugf("CA Certificate Path %q does not exist, ignoring.", config.CACertificatePath)
+			} else if os.IsNotExist(err) {
+				newErr := NewFileNotFoundError(config.CACertificatePath)
+				return nil, errors.Wrapf(newErr, "CA Certificate Path %q error", config.CACertificatePath)
 			} else {
 				return nil, errors.Wrapf(err, "CA Certificate Path %q error", config.CACertificatePath)
 			}
 		}
+
+		for _, dirEntry := range dirEntries {
+			if dirEntry.IsDir() {
+				continue
+			}
+
+			certPath := filepath.Join(config.CACertificatePath, dirEntry.Name())
+			pemBytes, err := os.ReadFile(certPath)
+			if err != nil {
+				logger.WithError(err).Debugf("failed to read CA Certificate %q, ignoring.", certPath)
+				continue
+			}
+
+			// the dir may contain non-certificate files, e.g., hash symlinks or CRLs
+			if certPool.AppendCertsFromPEM(pemBytes) {
+				loaded = true
+			}
+		}
+	}
+
+	if !loaded {
+		// fall back to system roots
+		return nil, nil
 	}
 
-	certConfig := &rootcerts.Config{
-		CAFile: config.CACertificateFile,
-		CAPath: config.CACertificatePath,
+	return certPool, nil
+}
+
+// GetTrustDescription returns a human-readable description of how the server is trusted
+// this is used in connection errors to help diagnosing handshake failures
+func (config *IRODSSSLConfig) GetTrustDescription() string {
+	if config.TLSConfig != nil {
+		return "custom TLS config"
 	}
 
-	certPool, err := rootcerts.LoadCACerts(certConfig)
+	trustSources := []string{}
+	if len(config.CACertificateFile) > 0 {
+		trustSources = append(trustSources, fmt.Sprintf("CA file %q", config.CACertificateFile))
+	}
+	if len(config.CACertificatePath) > 0 {
+		trustSources = append(trustSources, fmt.Sprintf("CA dir %q", config.CACertificatePath))
+	}
+	trustSources = append(trustSources, "system roots as fallback")
+
+	switch config.VerifyServer {
+	case SSLVerifyServerHostname:
+		return fmt.Sprintf("verify server certificate and hostname using %s", strings.Join(trustSources, ", "))
+	case SSLVerifyServerCert:
+		return fmt.Sprintf("verify server certificate without hostname using %s", strings.Join(trustSources, ", "))
+	default:
+		return "insecure, server certificate is not verified"
+	}
+}
+
+// LoadClientCertificate loads client certificate and key for mutual TLS
+// returns nil if client certificate is not configured
+func (config *IRODSSSLConfig) LoadClientCertificate() ([]tls.Certificate, error) {
+	if len(config.ClientCertificateFile) == 0 && len(config.ClientKeyFile) == 0 {
+		return nil, nil
+	}
+
+	if len(config.ClientCertificateFile) == 0 || len(config.ClientKeyFile) == 0 {
+		newErr := NewConnectionConfigError(nil)
+		return nil, errors.Wrapf(newErr, "both client certificate file and key file must be given")
+	}
+
+	cert, err := tls.LoadX509KeyPair(config.ClientCertificateFile, config.ClientKeyFile)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to load CA Certificate file")
+		return nil, errors.Wrapf(err, "failed to load client certificate %q and key %q", config.ClientCertificateFile, config.ClientKeyFile)
 	}
 
-	return certPool, nil
+	return []tls.Certificate{cert}, nil
 }
 
 // GetTLSConfig returns TLS Config
 func (config *IRODSSSLConfig) GetTLSConfig(serverName string, ignoreCertFileError bool) (*tls.Config, error) {
+	if len(config.ServerName) > 0 {
+		serverName = config.ServerName
+	}
+
+	if config.TLSConfig != nil {
+		// use custom TLS config, clone it as it may be shared by connections
+		tlsConfig := config.TLSConfig.Clone()
+		if len(tlsConfig.ServerName) == 0 {
+			tlsConfig.ServerName = serverName
+		}
+		return tlsConfig, nil
+	}
+
 	caCertPool, err := config.LoadCACert(ignoreCertFileError)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(config.ServerName) > 0 {
-		serverName = config.ServerName
+	clientCerts, err := config.LoadClientCertificate()
+	if err != nil {
+		return nil, err
+	}
+
+	var verifyPeerCertificate func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error
+	if config.VerifyServer == SSLVerifyServerCert {
+		// verify certificate chain only, hostname is not checked
+		verifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+			return verifyCertificateChain(rawCerts, caCertPool)
+		}
 	}
 
 	return &tls.Config{
-		RootCAs:            caCertPool,
-		ServerName:         serverName,
-		InsecureSkipVerify: !config.VerifyServer.IsVerificationRequired(),
+		RootCAs:               caCertPool,
+		Certificates:          clientCerts,
+		MinVersion:            config.MinTLSVersion,
+		ServerName:            serverName,
+		InsecureSkipVerify:    !config.VerifyServer.IsVerificationRequired(),
+		VerifyPeerCertificate: verifyPeerCertificate,
 		CipherSuites: []uint16{
 			//cipherSuitesPreferenceOrder
 			// AEADs w/ ECDHE
@@ -180,6 +276,38 @@ func (config *IRODSSSLConfig) GetTLSConfig(serverName string, ignoreCertFileErro
 	}, nil
 }
 
+// verifyCertificateChain verifies the certificate chain given by server without checking hostname
+// if roots is nil, system roots are used
+func verifyCertificateChain(rawCerts [][]byte, roots *x509.CertPool) error {
+	if len(rawCerts) == 0 {
+		return errors.New("server did not present a certificate")
+	}
+
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, rawCert := range rawCerts {
+		cert, err := x509.ParseCertificate(rawCert)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse server certificate")
+		}
+		certs = append(certs, cert)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to verify server certificate")
+	}
+
+	return nil
+}
+
 func (config *IRODSSSLConfig) Validate() error {
 	// icommands ignores non-existing ca cert files and paths
 	//if len(config.CACertificateFile) > 0 {
@@ -208,6 +336,17 @@ func (config *IRODSSSLConfig) Validate() error {
 		return errors.Wrapf(newErr, "empty encryption algorithm")
 	}
 
+	encryptionAlgorithm := GetEncryptionAlgorithm(config.EncryptionAlgorithm)
+	if encryptionAlgorithm == EncryptionAlgorithmUnknown {
+		newErr := NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "unsupported encryption algorithm %q", config.EncryptionAlgorithm)
+	}
+
+	if encryptionAlgorithm.IsAES256() && config.EncryptionKeySize != 32 {
+		newErr := NewConnectionConfigError(nil)
+		return errors.Wrapf(newErr, "invalid encryption key size %d for %q, must be 32", config.EncryptionKeySize, config.EncryptionAlgorithm)
+	}
+
 	if config.EncryptionSaltSize <= 0 {
 		newErr := NewConnectionConfigError(nil)
 		return errors.Wrapf(newErr, "invalid encryption salt size")
@@ -0,0 +1,21 @@
+package types
+
+import (
+	"fmt"
+)
+
+// IRODSSubStructFileHandle contains a handle of a file inside a struct file (e.g., tar)
+type IRODSSubStructFileHandle struct {
+	FileDescriptor int
+	// StructFilePath has an absolute path to the struct file data object
+	StructFilePath string
+	// SubFilePath has a relative path of the file in the struct file
+	SubFilePath string
+	// Resource has the resource storing the replica of the struct file
+	Resource *IRODSResource
+}
+
+// ToString stringifies the object
+func (handle *IRODSSubStructFileHandle) ToString() string {
+	return fmt.Sprintf("<IRODSSubStructFileHandle %d %s %s>", handle.FileDescriptor, handle.StructFilePath, handle.SubFilePath)
+}
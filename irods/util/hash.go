@@ -2,73 +2,53 @@ package util
 
 import (
 	"bytes"
-	"crypto/md5"
-	"crypto/sha1"
-	"crypto/sha256"
-	"crypto/sha512"
 	"hash"
-	"hash/adler32"
 	"io"
 	"os"
-	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/cyverse/go-irodsclient/irods/common"
 	"github.com/cyverse/go-irodsclient/irods/types"
 )
 
+// NewHashAlgorithm returns a new hash for the hash algorithm, registered checksum algorithms are supported
+func NewHashAlgorithm(hashAlg string) (hash.Hash, error) {
+	def, ok := types.GetChecksumAlgorithmDefinition(hashAlg)
+	if !ok {
+		return nil, errors.Errorf("unknown hash algorithm %q", hashAlg)
+	}
+
+	return def.NewHash(), nil
+}
+
 // HashStrings calculates hash of strings
 func HashStrings(strs []string, hashAlg string) ([]byte, error) {
-	switch strings.ToLower(hashAlg) {
-	case strings.ToLower(string(types.ChecksumAlgorithmMD5)):
-		return HashStringsWithAlgorithm(strs, md5.New())
-	case strings.ToLower(string(types.ChecksumAlgorithmADLER32)):
-		return HashStringsWithAlgorithm(strs, adler32.New())
-	case strings.ToLower(string(types.ChecksumAlgorithmSHA1)):
-		return HashStringsWithAlgorithm(strs, sha1.New())
-	case strings.ToLower(string(types.ChecksumAlgorithmSHA256)):
-		return HashStringsWithAlgorithm(strs, sha256.New())
-	case strings.ToLower(string(types.ChecksumAlgorithmSHA512)):
-		return HashStringsWithAlgorithm(strs, sha512.New())
-	default:
-		return nil, errors.Errorf("unknown hash algorithm %q", hashAlg)
+	hashAlgorithm, err := NewHashAlgorithm(hashAlg)
+	if err != nil {
+		return nil, err
 	}
+
+	return HashStringsWithAlgorithm(strs, hashAlgorithm)
 }
 
 // HashLocalFile calculates hash of local file
 func HashLocalFile(sourcePath string, hashAlg string, processCallback common.TransferTrackerCallback) ([]byte, error) {
-	switch strings.ToLower(hashAlg) {
-	case strings.ToLower(string(types.ChecksumAlgorithmMD5)):
-		return HashLocalFileWithAlgorithm(sourcePath, md5.New(), processCallback)
-	case strings.ToLower(string(types.ChecksumAlgorithmADLER32)):
-		return HashLocalFileWithAlgorithm(sourcePath, adler32.New(), processCallback)
-	case strings.ToLower(string(types.ChecksumAlgorithmSHA1)):
-		return HashLocalFileWithAlgorithm(sourcePath, sha1.New(), processCallback)
-	case strings.ToLower(string(types.ChecksumAlgorithmSHA256)):
-		return HashLocalFileWithAlgorithm(sourcePath, sha256.New(), processCallback)
-	case strings.ToLower(string(types.ChecksumAlgorithmSHA512)):
-		return HashLocalFileWithAlgorithm(sourcePath, sha512.New(), processCallback)
-	default:
-		return nil, errors.Errorf("unknown hash algorithm %q", hashAlg)
+	hashAlgorithm, err := NewHashAlgorithm(hashAlg)
+	if err != nil {
+		return nil, err
 	}
+
+	return HashLocalFileWithAlgorithm(sourcePath, hashAlgorithm, processCallback)
 }
 
 // HashBuffer calculates hash of buffer data
 func HashBuffer(buffer *bytes.Buffer, hashAlg string, processCallback common.TransferTrackerCallback) ([]byte, error) {
-	switch strings.ToLower(hashAlg) {
-	case strings.ToLower(string(types.ChecksumAlgorithmMD5)):
-		return HashBufferWithAlgorithm(buffer, md5.New(), processCallback)
-	case strings.ToLower(string(types.ChecksumAlgorithmADLER32)):
-		return HashBufferWithAlgorithm(buffer, adler32.New(), processCallback)
-	case strings.ToLower(string(types.ChecksumAlgorithmSHA1)):
-		return HashBufferWithAlgorithm(buffer, sha1.New(), processCallback)
-	case strings.ToLower(string(types.ChecksumAlgorithmSHA256)):
-		return HashBufferWithAlgorithm(buffer, sha256.New(), processCallback)
-	case strings.ToLower(string(types.ChecksumAlgorithmSHA512)):
-		return HashBufferWithAlgorithm(buffer, sha512.New(), processCallback)
-	default:
-		return nil, errors.Errorf("unknown hash algorithm %q", hashAlg)
+	hashAlgorithm, err := NewHashAlgorithm(hashAlg)
+	if err != nil {
+		return nil, err
 	}
+
+	return HashBufferWithAlgorithm(buffer, hashAlgorithm, processCallback)
 }
 
 // HashStringsWithAlgorithm calculates hash of strings
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
@@ -29,3 +30,12 @@ func GetIRODSDateTimeStringForTicket(t time.Time) string {
 
 	return t.UTC().Format("2006-01-02.15:04:05")
 }
+
+// GetIRODSDateTimeString returns IRODS time string from time struct
+func GetIRODSDateTimeString(t time.Time) string {
+	if t.IsZero() {
+		return "0"
+	}
+
+	return fmt.Sprintf("%011d", t.Unix())
+}
@@ -0,0 +1,207 @@
+package path
+
+import (
+	"fmt"
+	gopath "path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/irods/common"
+)
+
+// Clean returns the shortest absolute path equivalent to p, relative paths are made absolute from the root
+func Clean(p string) string {
+	if len(p) == 0 {
+		return "/"
+	}
+
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+
+	return gopath.Clean(p)
+}
+
+// Join joins path elements and cleans the result
+func Join(elem ...string) string {
+	return Clean(gopath.Join(elem...))
+}
+
+// Split splits the path into the parent dir and the name, "/" returns "/" and ""
+func Split(p string) (string, string) {
+	cleanPath := Clean(p)
+	if cleanPath == "/" {
+		return "/", ""
+	}
+
+	return gopath.Dir(cleanPath), gopath.Base(cleanPath)
+}
+
+// Dir returns the parent dir of the path, "/" returns "/"
+func Dir(p string) string {
+	dir, _ := Split(p)
+	return dir
+}
+
+// Base returns the last component of the path, "/" returns ""
+func Base(p string) string {
+	_, name := Split(p)
+	return name
+}
+
+// Components returns components of the path, "/" has no components
+func Components(p string) []string {
+	cleanPath := Clean(p)
+	if cleanPath == "/" {
+		return []string{}
+	}
+
+	return strings.Split(cleanPath[1:], "/")
+}
+
+// Depth returns the number of components of the path, "/" returns 0, "/zone" returns 1
+func Depth(p string) int {
+	return len(Components(p))
+}
+
+// GetZone returns the zone of the path
+func GetZone(p string) (string, error) {
+	if !strings.HasPrefix(p, "/") {
+		return "", errors.Errorf("path %q is not absolute", p)
+	}
+
+	components := Components(p)
+	if len(components) == 0 {
+		return "", errors.Errorf("path %q has no zone", p)
+	}
+
+	return components[0], nil
+}
+
+// IsUnder returns true if target is base or a descendant of base
+func IsUnder(base string, target string) bool {
+	cleanBase := Clean(base)
+	cleanTarget := Clean(target)
+
+	if cleanBase == "/" || cleanBase == cleanTarget {
+		return true
+	}
+
+	return strings.HasPrefix(cleanTarget, cleanBase+"/")
+}
+
+// Rel returns the relative path of target from base, ".." is used if target is not under base
+func Rel(base string, target string) string {
+	baseComponents := Components(base)
+	targetComponents := Components(target)
+
+	shared := 0
+	for shared < len(baseComponents) && shared < len(targetComponents) && baseComponents[shared] == targetComponents[shared] {
+		shared++
+	}
+
+	relComponents := []string{}
+	for i := shared; i < len(baseComponents); i++ {
+		relComponents = append(relComponents, "..")
+	}
+	relComponents = append(relComponents, targetComponents[shared:]...)
+
+	if len(relComponents) == 0 {
+		return "."
+	}
+
+	return strings.Join(relComponents, "/")
+}
+
+// GetHomeDirPath returns the home dir path of the user
+func GetHomeDirPath(zone string, user string) string {
+	return fmt.Sprintf("/%s/home/%s", zone, user)
+}
+
+// GetTrashHomeDirPath returns the trash home dir path of the user
+func GetTrashHomeDirPath(zone string, user string) string {
+	return fmt.Sprintf("/%s/trash/home/%s", zone, user)
+}
+
+// IsTrashPath returns true if the path is in trash of any user
+func IsTrashPath(p string) bool {
+	components := Components(p)
+	return len(components) >= 2 && components[1] == "trash"
+}
+
+// GetTrashPath returns the path that the entry at p is moved to when the user deletes it without force
+// entries in the home of the user keep their relative path in the trash home, others keep their path without the zone
+func GetTrashPath(p string, user string) (string, error) {
+	zone, err := GetZone(p)
+	if err != nil {
+		return "", err
+	}
+
+	cleanPath := Clean(p)
+	if IsTrashPath(cleanPath) {
+		return "", errors.Errorf("path %q is already in trash", cleanPath)
+	}
+
+	homePath := GetHomeDirPath(zone, user)
+	relPath := strings.TrimPrefix(cleanPath, "/"+zone)
+	if strings.HasPrefix(cleanPath, homePath+"/") {
+		relPath = strings.TrimPrefix(cleanPath, homePath)
+	}
+
+	return GetTrashHomeDirPath(zone, user) + relPath, nil
+}
+
+// GetOriginalPathFromTrash returns the path in the home of the user that the entry in the trash home was deleted from
+func GetOriginalPathFromTrash(trashPath string, user string) (string, error) {
+	zone, err := GetZone(trashPath)
+	if err != nil {
+		return "", err
+	}
+
+	cleanPath := Clean(trashPath)
+	trashHomePath := GetTrashHomeDirPath(zone, user)
+	if !strings.HasPrefix(cleanPath, trashHomePath+"/") {
+		return "", errors.Errorf("path %q is not in trash %q", cleanPath, trashHomePath)
+	}
+
+	return GetHomeDirPath(zone, user) + strings.TrimPrefix(cleanPath, trashHomePath), nil
+}
+
+// Validate returns an error if the path cannot be stored in iRODS
+// the path must be absolute, valid UTF-8, within the length limit, and must not have empty, "." or ".." components
+// or control characters, which cannot be carried in iRODS XML messages
+func Validate(p string) error {
+	if !strings.HasPrefix(p, "/") {
+		return errors.Errorf("path %q is not absolute", p)
+	}
+
+	if len(p) > common.MaxPathLength {
+		return errors.Errorf("path %q is longer than %d bytes", p, common.MaxPathLength)
+	}
+
+	if !utf8.ValidString(p) {
+		return errors.Errorf("path %q is not valid UTF-8", p)
+	}
+
+	for _, r := range p {
+		if r < 0x20 || r == 0x7f {
+			return errors.Errorf("path %q has an illegal character %q", p, r)
+		}
+	}
+
+	if p == "/" {
+		return nil
+	}
+
+	for _, component := range strings.Split(strings.TrimSuffix(p[1:], "/"), "/") {
+		switch component {
+		case "":
+			return errors.Errorf("path %q has an empty component", p)
+		case ".", "..":
+			return errors.Errorf("path %q has an illegal component %q", p, component)
+		}
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"strings"
 
+	"github.com/cockroachdb/errors"
 	"github.com/cyverse/go-irodsclient/irods/common"
 )
 
@@ -48,26 +49,54 @@ func GetPasswordPadded(newPassword string) string {
 	return newPassword
 }
 
+// GetPasswordUnpadded returns password without padding added by GetPasswordPadded
+func GetPasswordUnpadded(paddedPassword string) string {
+	// padding is at least 16 chars long, as the server checks
+	for i := 0; i < len(paddedPassword)-15; i++ {
+		if strings.HasPrefix(scramblePadding, paddedPassword[i:]) {
+			return paddedPassword[:i]
+		}
+	}
+
+	return paddedPassword
+}
+
 // ObfuscateNewPassword obfuscates new password for changing
 func ObfuscateNewPassword(newPassword string, oldPassword string, signature string) string {
 	// copy the behaviour from setScrambledPw
-	pwdLen := len(newPassword)
-	if pwdLen > common.MaxPasswordLength {
-		newPassword = newPassword[:common.MaxPasswordLength]
+	return ScrambleV2(GetPasswordPadded(newPassword), oldPassword, signature)
+}
+
+// DeobfuscateNewPassword de-obfuscates new password obfuscated by ObfuscateNewPassword
+func DeobfuscateNewPassword(obfuscatedPassword string, oldPassword string, signature string) (string, error) {
+	paddedPassword, err := UnscrambleV2(obfuscatedPassword, oldPassword, signature)
+	if err != nil {
+		return "", err
 	}
 
-	lencopy := common.MaxPasswordLength - 10 - pwdLen
+	return GetPasswordUnpadded(paddedPassword), nil
+}
 
-	if lencopy > 15 {
-		// protection
-		if lencopy > len(scramblePadding) {
-			lencopy = len(scramblePadding)
-		}
+// getScrambleV2Key returns the key for ScrambleV2, a hash of old password and signature
+func getScrambleV2Key(oldPassword string, signature string) string {
+	if len(oldPassword) > 90 {
+		oldPassword = oldPassword[:90]
+	}
 
-		newPassword = newPassword + scramblePadding[:lencopy]
+	if len(signature) > 100 {
+		signature = signature[:100]
+	}
+
+	keyBuf := bytes.Buffer{}
+	keyBuf.WriteString(oldPassword)
+	keyBuf.WriteString(signature)
+
+	for i := len(oldPassword) + len(signature); i < 100; i++ {
+		keyBuf.WriteByte(0)
 	}
 
-	return ScrambleV2(newPassword, oldPassword, signature)
+	hashKeyBytes := md5.Sum(keyBuf.Bytes())
+	return hex.EncodeToString(hashKeyBytes[:])
 }
 
 // ScrambleV2 scrambles string (ver2)
@@ -81,28 +110,26 @@ func ScrambleV2(newPassword string, oldPassword string, signature string) string
 		newPassword = newPassword[:150]
 	}
 
-	if len(oldPassword) > 90 {
-		oldPassword = oldPassword[:90]
-	}
-
-	if len(signature) > 100 {
-		signature = signature[:100]
-	}
-
 	toScramble := MakeRandomString(1) + v2Prefix[1:v2prefixLen] + newPassword
 
-	keyBuf := bytes.Buffer{}
-	keyBuf.WriteString(oldPassword)
-	keyBuf.WriteString(signature)
+	return Scramble(toScramble, getScrambleV2Key(oldPassword, signature), "", true)
+}
 
-	for i := len(oldPassword) + len(signature); i < 100; i++ {
-		keyBuf.WriteByte(0)
+// UnscrambleV2 unscrambles string scrambled by ScrambleV2
+func UnscrambleV2(scrambledPassword string, oldPassword string, signature string) (string, error) {
+	v2prefixLen := len(v2Prefix)
+	if v2prefixLen > 10 {
+		v2prefixLen = 10
 	}
 
-	hashKeyBytes := md5.Sum(keyBuf.Bytes())
-	hashedKey := hex.EncodeToString(hashKeyBytes[:])
+	unscrambled := Unscramble(scrambledPassword, getScrambleV2Key(oldPassword, signature), "", true)
 
-	return Scramble(toScramble, hashedKey, "", true)
+	// the first char is random
+	if len(unscrambled) < v2prefixLen || unscrambled[1:v2prefixLen] != v2Prefix[1:v2prefixLen] {
+		return "", errors.Errorf("failed to unscramble, invalid key or scrambled string")
+	}
+
+	return unscrambled[v2prefixLen:], nil
 }
 
 // Scramble scrambles string
@@ -145,6 +172,51 @@ func Scramble(toScramble string, key string, scramblePrefix string, blockChainin
 	return scramblePrefix + scrambledStr.String()
 }
 
+// Unscramble unscrambles string scrambled by Scramble with the same key
+func Unscramble(scrambled string, key string, scramblePrefix string, blockChaining bool) string {
+	if key == "" {
+		key = defaultPasswordKey
+	}
+
+	scrambled = strings.TrimPrefix(scrambled, scramblePrefix)
+
+	encoderRing := GetEncoderRing(key)
+	chain := 0
+
+	unscrambledStr := strings.Builder{}
+
+	for p := 0; p < len(scrambled); p++ {
+		encoderRingIndex := p % 61
+		k := int(encoderRing[encoderRingIndex])
+
+		// The character is only encoded if it's one of the ones in wheel
+		foundInWheel := false
+		for wheelIndex, wheelChar := range wheel {
+			if wheelChar == scrambled[p] {
+				// index of the original character in wheel
+				newWheelIndex := (wheelIndex - k - chain) % len(wheel)
+				if newWheelIndex < 0 {
+					newWheelIndex += len(wheel)
+				}
+				unscrambledStr.WriteByte(wheel[newWheelIndex])
+
+				if blockChaining {
+					chain = int(wheelChar) & 0xff
+				}
+
+				foundInWheel = true
+				break
+			}
+		}
+
+		if !foundInWheel {
+			unscrambledStr.WriteByte(scrambled[p])
+		}
+	}
+
+	return unscrambledStr.String()
+}
+
 // GetEncoderRing returns encoder ring
 func GetEncoderRing(key string) []byte {
 	keyBuf := make([]byte, 100)
@@ -19,9 +19,8 @@ func UnixWildcardsToSQLWildcards(input string) string {
 	length := len(input)
 	// Use regexp2 rather than regexp here in order to be able to use lookbehind assertions
 	//
-	// Escape SQL wildcard characters
-	output = strings.ReplaceAll(output, "%", `\%`)
-	output = strings.ReplaceAll(output, "_", `\_`)
+	// Escape SQL wildcard characters, backslashes are kept as they escape unix wildcards
+	output = escapeSQLChars(output, "%_")
 	// Replace ranges with a wildcard
 	output, _ = regexp2.MustCompile(`(?<!\\)(?:\\\\)*\[.*?(?<!\\)(?:\\\\)*\]`, regexp2.RE2).Replace(output, `_`, 0, length)
 	// Replace non-escaped regular wildcard characters with SQL equivalents
@@ -29,3 +28,21 @@ func UnixWildcardsToSQLWildcards(input string) string {
 	output, _ = regexp2.MustCompile(`(?<!\\)(?:\\\\)*(\?)`, regexp2.RE2).Replace(output, `_`, 0, length)
 	return output
 }
+
+// EscapeSQLWildcards escapes SQL LIKE wildcards (% and _) and the escape character in the input,
+// so the input matches literally when it is a part of a LIKE pattern, e.g., a collection path prefix
+func EscapeSQLWildcards(input string) string {
+	return escapeSQLChars(input, `\%_`)
+}
+
+// escapeSQLChars escapes the chars in the input with backslashes
+func escapeSQLChars(input string, chars string) string {
+	sb := strings.Builder{}
+	for _, r := range input {
+		if strings.ContainsRune(chars, r) {
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -11,15 +11,14 @@ import (
 	irods_fs "github.com/cyverse/go-irodsclient/fs"
 	"github.com/cyverse/go-irodsclient/irods/connection"
 	"github.com/cyverse/go-irodsclient/irods/session"
+	"github.com/cyverse/go-irodsclient/irods/testharness"
 	"github.com/cyverse/go-irodsclient/irods/types"
-	"github.com/docker/compose/v2/pkg/api"
 	log "github.com/sirupsen/logrus"
-	"github.com/testcontainers/testcontainers-go/modules/compose"
 )
 
 type IRODSServer struct {
-	serverInfo    IRODSServerInfo
-	dockerCompose *compose.DockerCompose
+	serverInfo IRODSServerInfo
+	harness    *testharness.TestHarness
 }
 
 func GetTestIRODSServerInfos() []IRODSServerInfo {
@@ -39,7 +38,7 @@ func NewIRODSServer(serverInfo IRODSServerInfo) *IRODSServer {
 func (server *IRODSServer) Start() error {
 	logger := log.WithFields(log.Fields{})
 
-	if !server.serverInfo.RequireCompose() {
+	if !server.serverInfo.RequireHarness() {
 		// Production server
 		err := server.waitForPortToOpen(60 * time.Second)
 		if err != nil {
@@ -56,33 +55,23 @@ func (server *IRODSServer) Start() error {
 		return errors.Wrapf(err, "failed to set environment variable TESTCONTAINERS_RYUK_DISABLED")
 	}
 
-	composeFilePath, err := server.serverInfo.GetComposeFilePath()
+	harness, err := testharness.NewTestHarness(server.serverInfo.GetHarnessConfig())
 	if err != nil {
-		return errors.Wrapf(err, "failed to get compose file path")
-	}
-
-	logger.Infof("Using compose file: %s", composeFilePath)
-
-	comp, err := compose.NewDockerCompose(composeFilePath)
-	if err != nil {
-		return errors.Wrapf(err, "failed to create docker compose")
+		return errors.Wrapf(err, "failed to create test harness")
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	err = comp.Up(ctx, compose.WithRecreate(api.RecreateForce), compose.Wait(true))
+	// the harness waits until the server accepts iRODS connections
+	err = harness.Start(ctx)
 	if err != nil {
 		return errors.Wrapf(err, "failed to start local iRODS server %q", server.serverInfo.Name)
 	}
 
-	server.dockerCompose = comp
-
-	// wait
-	err = server.waitForPortToOpen(60 * time.Second)
-	if err != nil {
-		return errors.Wrapf(err, "failed while waiting for port to open for iRODS server %q", server.serverInfo.Name)
-	}
+	server.harness = harness
+	server.serverInfo.Host = harness.GetHost()
+	server.serverInfo.Port = harness.GetPort()
 
 	logger.Infof("Started local iRODS server %q", server.serverInfo.Name)
 
@@ -92,17 +81,19 @@ func (server *IRODSServer) Start() error {
 func (server *IRODSServer) Stop() error {
 	logger := log.WithFields(log.Fields{})
 
-	if server.dockerCompose != nil {
+	if server.harness != nil {
 		logger.Infof("Stopping local iRODS server %q", server.serverInfo.Name)
 
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
-		err := server.dockerCompose.Down(ctx, compose.RemoveOrphans(true), compose.RemoveVolumes(true))
+		err := server.harness.Stop(ctx)
 		if err != nil {
 			logger.Error(errors.Wrapf(err, "failed to stop local iRODS server %q", server.serverInfo.Name))
 		}
 
+		server.harness = nil
+
 		// wait
 		err = server.waitForPortToClose(60 * time.Second)
 		if err != nil {
@@ -2,9 +2,8 @@ package server
 
 import (
 	"fmt"
-	"path/filepath"
-	"runtime"
 
+	"github.com/cyverse/go-irodsclient/irods/testharness"
 	"github.com/cyverse/go-irodsclient/irods/types"
 	"github.com/pkg/errors"
 )
@@ -32,7 +31,7 @@ type IRODSServerInfo struct {
 	CSNegotiation       bool
 	CSNegotiationPolicy types.CSNegotiationPolicyRequest
 
-	ComposeFile        string
+	ImageTag           string // tag of test docker images run by testharness, empty for servers not run locally
 	UseAddressResolver bool
 
 	Host     string
@@ -43,15 +42,14 @@ type IRODSServerInfo struct {
 	Resource string
 }
 
-func (info *IRODSServerInfo) GetComposeFilePath() (string, error) {
-	_, currentFile, _, ok := runtime.Caller(0)
-	if !ok {
-		return "", errors.Errorf("failed to get current file path")
-	}
-
-	currentDir := filepath.Dir(currentFile)
-
-	return fmt.Sprintf("%s/%s", currentDir, info.ComposeFile), nil
+func (info *IRODSServerInfo) GetHarnessConfig() *testharness.TestHarnessConfig {
+	config := testharness.NewDefaultTestHarnessConfig()
+	config.Version = info.Version
+	config.CatalogImage = fmt.Sprintf("cyverse/irods-test-docker-catalog:v%s", info.ImageTag)
+	config.ProviderImage = fmt.Sprintf("cyverse/irods-test-docker-catalog-provider:v%s", info.ImageTag)
+	// parallel transfers are redirected to data transfer ports
+	config.BindFixedPorts = true
+	return config
 }
 
 func (info *IRODSServerInfo) GetAccount() (*types.IRODSAccount, error) {
@@ -88,8 +86,8 @@ func (info *IRODSServerInfo) AddressResolver(address string) string {
 	return address
 }
 
-func (info *IRODSServerInfo) RequireCompose() bool {
-	return len(info.ComposeFile) > 0
+func (info *IRODSServerInfo) RequireHarness() bool {
+	return len(info.ImageTag) > 0
 }
 
 var (
@@ -100,7 +98,7 @@ var (
 			AuthScheme:          types.AuthSchemeNative,
 			CSNegotiation:       false,
 			CSNegotiationPolicy: types.CSNegotiationPolicyRequestTCP,
-			ComposeFile:         "irods_4.2.8/docker-compose.yml",
+			ImageTag:            "4.2.8",
 
 			Host:               testServerHost,
 			Port:               testServerPort,
@@ -116,7 +114,7 @@ var (
 			AuthScheme:          types.AuthSchemeNative,
 			CSNegotiation:       false,
 			CSNegotiationPolicy: types.CSNegotiationPolicyRequestTCP,
-			ComposeFile:         "irods_4.2.11/docker-compose.yml",
+			ImageTag:            "4.2.11",
 
 			Host:               testServerHost,
 			Port:               testServerPort,
@@ -132,7 +130,7 @@ var (
 			AuthScheme:          types.AuthSchemeNative,
 			CSNegotiation:       false,
 			CSNegotiationPolicy: types.CSNegotiationPolicyRequestTCP,
-			ComposeFile:         "irods_4.3.3/docker-compose.yml",
+			ImageTag:            "4.3.3",
 
 			Host:               testServerHost,
 			Port:               testServerPort,
@@ -148,7 +146,7 @@ var (
 			AuthScheme:          types.AuthSchemeNative,
 			CSNegotiation:       true,
 			CSNegotiationPolicy: types.CSNegotiationPolicyRequestSSL,
-			ComposeFile:         "irods_4.3.3_pam/docker-compose.yml",
+			ImageTag:            "4.3.3_pam",
 
 			Host:               testServerHost,
 			Port:               testServerPort,
@@ -167,7 +165,7 @@ var (
 			AuthScheme:          types.AuthSchemeNative,
 			CSNegotiation:       false,
 			CSNegotiationPolicy: types.CSNegotiationPolicyRequestTCP,
-			ComposeFile:         "", // no compose file for production server
+			ImageTag:            "", // production server is not run locally
 
 			Host:               productionServerHost,
 			Port:               productionServerPort,
@@ -2,9 +2,13 @@ package testcases
 
 import (
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/cyverse/go-irodsclient/fs"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,6 +21,11 @@ func getHighlevelFilesystemCacheTest() Test {
 
 func highlevelFilesystemCacheTest(t *testing.T, test *Test) {
 	t.Run("MakeDirCacheEvent", testMakeDirCacheEvent)
+	t.Run("InvalidateCache", testInvalidateCache)
+	t.Run("NoCachePath", testNoCachePath)
+	t.Run("CacheEntryEvent", testCacheEntryEvent)
+	t.Run("SharedCache", testSharedCache)
+	t.Run("NegativeCache", testNegativeCache)
 }
 
 func testMakeDirCacheEvent(t *testing.T) {
@@ -65,3 +74,336 @@ func testMakeDirCacheEvent(t *testing.T) {
 		eventPathsReceived = []string{}
 	}
 }
+
+func testInvalidateCache(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	testDir := fmt.Sprintf("%s/cache_invalidate_test_dir", homeDir)
+	subDir := fmt.Sprintf("%s/subdir", testDir)
+	subDir2 := fmt.Sprintf("%s/subdir2", testDir)
+
+	err = filesystem.MakeDir(subDir, true)
+	FailError(t, err)
+
+	// fill cache
+	_, err = filesystem.Stat(subDir)
+	FailError(t, err)
+
+	entries, err := filesystem.List(testDir)
+	FailError(t, err)
+	assert.Len(t, entries, 1)
+
+	// get side connection
+	conn, err := filesystem.GetMetadataConnection(true)
+	FailError(t, err)
+	defer func() {
+		_ = filesystem.ReturnMetadataConnection(conn)
+	}()
+
+	// update using the side connection without cache update, like external writers
+	err = irods_fs.CreateCollection(conn, subDir2, false)
+	FailError(t, err)
+
+	err = irods_fs.DeleteCollection(conn, subDir, true, true)
+	FailError(t, err)
+
+	// cached
+	assert.True(t, filesystem.ExistsDir(subDir))
+
+	entries, err = filesystem.List(testDir)
+	FailError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, subDir, entries[0].Path)
+
+	// no cache for a call, cache is refreshed
+	entries, err = filesystem.ListNoCache(testDir)
+	FailError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, subDir2, entries[0].Path)
+
+	assert.False(t, filesystem.ExistsDir(subDir))
+
+	_, err = filesystem.StatNoCache(subDir)
+	assert.Error(t, err)
+
+	// invalidate recursively
+	err = irods_fs.CreateCollection(conn, subDir, false)
+	FailError(t, err)
+
+	assert.False(t, filesystem.ExistsDir(subDir))
+
+	filesystem.InvalidateCache(testDir, true)
+	assert.True(t, filesystem.ExistsDir(subDir))
+
+	err = filesystem.RemoveDir(testDir, true, true)
+	FailError(t, err)
+}
+
+func testNoCachePath(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	noCacheDir := fmt.Sprintf("%s/cache_nocache_test_dir", homeDir)
+	subDir := fmt.Sprintf("%s/subdir", noCacheDir)
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	fsConfig := server.GetFileSystemConfig()
+	fsConfig.Cache.MetadataTimeoutSettings = []fs.MetadataCacheTimeoutSetting{
+		{
+			Path:    noCacheDir,
+			Inherit: true,
+			NoCache: true,
+		},
+	}
+
+	filesystem, err := fs.NewFileSystem(account, fsConfig)
+	FailError(t, err)
+	defer filesystem.Release()
+
+	assert.Equal(t, time.Duration(0), filesystem.GetCacheTimeoutForPath(subDir))
+	assert.Equal(t, time.Duration(fsConfig.Cache.Timeout), filesystem.GetCacheTimeoutForPath(homeDir))
+
+	err = filesystem.MakeDir(subDir, true)
+	FailError(t, err)
+	assert.True(t, filesystem.ExistsDir(subDir))
+
+	// get side connection
+	conn, err := filesystem.GetMetadataConnection(true)
+	FailError(t, err)
+	defer func() {
+		_ = filesystem.ReturnMetadataConnection(conn)
+	}()
+
+	err = irods_fs.DeleteCollection(conn, subDir, true, true)
+	FailError(t, err)
+
+	// not cached
+	assert.False(t, filesystem.ExistsDir(subDir))
+
+	err = filesystem.RemoveDir(noCacheDir, true, true)
+	FailError(t, err)
+}
+
+func testCacheEntryEvent(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	fsConfig := server.GetFileSystemConfig()
+	fsConfig.Cache.Timeout = types.Duration(500 * time.Millisecond)
+	fsConfig.Cache.CleanupTime = types.Duration(100 * time.Millisecond)
+
+	filesystem, err := fs.NewFileSystem(account, fsConfig)
+	FailError(t, err)
+	defer filesystem.Release()
+
+	eventMutex := sync.Mutex{}
+	eventsReceived := map[fs.CacheEntryEventType][]string{}
+	eventHandler := func(kind fs.CacheKind, path string, eventType fs.CacheEntryEventType) {
+		if kind != fs.EntryCacheKind {
+			return
+		}
+
+		eventMutex.Lock()
+		defer eventMutex.Unlock()
+
+		eventsReceived[eventType] = append(eventsReceived[eventType], path)
+	}
+
+	getEvents := func(eventType fs.CacheEntryEventType) []string {
+		eventMutex.Lock()
+		defer eventMutex.Unlock()
+
+		return eventsReceived[eventType]
+	}
+
+	handlerID := filesystem.AddCacheEntryEventHandler(eventHandler)
+	defer filesystem.RemoveCacheEntryEventHandler(handlerID)
+
+	// add
+	_, err = filesystem.Stat(homeDir)
+	FailError(t, err)
+	assert.Contains(t, getEvents(fs.CacheEntryAddEvent), homeDir)
+
+	// invalidate
+	filesystem.InvalidateCache(homeDir, false)
+	assert.Contains(t, getEvents(fs.CacheEntryInvalidateEvent), homeDir)
+	assert.NotContains(t, getEvents(fs.CacheEntryExpireEvent), homeDir)
+
+	// expire
+	_, err = filesystem.Stat(homeDir)
+	FailError(t, err)
+
+	assert.Eventually(t, func() bool {
+		for _, path := range getEvents(fs.CacheEntryExpireEvent) {
+			if path == homeDir {
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func testSharedCache(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	sharedCacheConfig := fs.NewDefaultSharedCacheConfig()
+	sharedCacheConfig.MaxEntries = 10
+	sharedCache := fs.NewSharedCache(&sharedCacheConfig)
+
+	fsConfig := server.GetFileSystemConfig()
+	fsConfig.SharedCache = sharedCache
+
+	filesystem1, err := fs.NewFileSystem(account, fsConfig)
+	FailError(t, err)
+	defer filesystem1.Release()
+
+	filesystem2, err := fs.NewFileSystem(account, fsConfig)
+	FailError(t, err)
+	defer filesystem2.Release()
+
+	testDir := fmt.Sprintf("%s/shared_cache_test_dir", homeDir)
+	err = filesystem1.MakeDir(testDir, false)
+	FailError(t, err)
+
+	_, err = filesystem1.Stat(testDir)
+	FailError(t, err)
+
+	// get side connection
+	conn, err := filesystem1.GetMetadataConnection(true)
+	FailError(t, err)
+	defer func() {
+		_ = filesystem1.ReturnMetadataConnection(conn)
+	}()
+
+	err = irods_fs.DeleteCollection(conn, testDir, true, true)
+	FailError(t, err)
+
+	// cached by filesystem1
+	assert.True(t, filesystem2.ExistsDir(testDir))
+
+	sharedCache.Clear()
+	assert.False(t, filesystem2.ExistsDir(testDir))
+
+	// size-bounded
+	for i := 0; i < 20; i++ {
+		newDir := fmt.Sprintf("%s/shared_cache_test_dir_%d", homeDir, i)
+		err = irods_fs.CreateCollection(conn, newDir, false)
+		FailError(t, err)
+
+		_, err = filesystem1.Stat(newDir)
+		FailError(t, err)
+	}
+
+	assert.LessOrEqual(t, sharedCache.GetEntryCount(), 5*sharedCacheConfig.MaxEntries)
+
+	for i := 0; i < 20; i++ {
+		newDir := fmt.Sprintf("%s/shared_cache_test_dir_%d", homeDir, i)
+		err = filesystem1.RemoveDir(newDir, true, true)
+		FailError(t, err)
+	}
+}
+
+func testNegativeCache(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	fsConfig := server.GetFileSystemConfig()
+	fsConfig.Cache.NegativeEntryTimeout = types.Duration(1 * time.Second)
+
+	filesystem, err := fs.NewFileSystem(account, fsConfig)
+	FailError(t, err)
+	defer filesystem.Release()
+
+	// get side connection
+	conn, err := filesystem.GetMetadataConnection(true)
+	FailError(t, err)
+	defer func() {
+		_ = filesystem.ReturnMetadataConnection(conn)
+	}()
+
+	testDir := fmt.Sprintf("%s/negative_cache_test_dir", homeDir)
+
+	_, err = filesystem.Stat(testDir)
+	assert.True(t, types.IsFileNotFoundError(err))
+
+	err = irods_fs.CreateCollection(conn, testDir, false)
+	FailError(t, err)
+
+	// negative cache
+	_, err = filesystem.Stat(testDir)
+	assert.True(t, types.IsFileNotFoundError(err))
+
+	// bypass
+	entry, err := filesystem.StatNoNegativeCache(testDir)
+	FailError(t, err)
+	assert.True(t, entry.IsDir())
+
+	err = filesystem.RemoveDir(testDir, true, true)
+	FailError(t, err)
+
+	// expired
+	_, err = filesystem.Stat(testDir)
+	assert.True(t, types.IsFileNotFoundError(err))
+
+	err = irods_fs.CreateCollection(conn, testDir, false)
+	FailError(t, err)
+
+	time.Sleep(2 * time.Second)
+
+	_, err = filesystem.Stat(testDir)
+	FailError(t, err)
+
+	err = filesystem.RemoveDir(testDir, true, true)
+	FailError(t, err)
+
+	// disabled
+	fsConfig.Cache.NoNegativeCache = true
+
+	filesystem2, err := fs.NewFileSystem(account, fsConfig)
+	FailError(t, err)
+	defer filesystem2.Release()
+
+	_, err = filesystem2.Stat(testDir)
+	assert.True(t, types.IsFileNotFoundError(err))
+
+	err = irods_fs.CreateCollection(conn, testDir, false)
+	FailError(t, err)
+
+	_, err = filesystem2.Stat(testDir)
+	FailError(t, err)
+
+	err = filesystem2.RemoveDir(testDir, true, true)
+	FailError(t, err)
+}
@@ -1,16 +1,32 @@
 package testcases
 
 import (
+	"bytes"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/cyverse/go-irodsclient/fs"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
 	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/metrics"
+	"github.com/cyverse/go-irodsclient/irods/system"
+	"github.com/cyverse/go-irodsclient/irods/testserver"
 	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,15 +40,68 @@ func getHighlevelFilesystemTest() Test {
 func highlevelFilesystemTest(t *testing.T, test *Test) {
 	t.Run("MakeDir", testMakeDir)
 	t.Run("MakeDirRecurse", testMakeDirRecurse)
+	t.Run("MakeDirWithOptions", testMakeDirWithOptions)
 	t.Run("UploadAndDeleteDir", testUploadAndDeleteDir)
 	t.Run("ListDirectory", testListDirectory)
 	t.Run("SearchByMeta", testSearchByMeta)
+	t.Run("StatWithMetadata", testStatWithMetadata)
 	t.Run("ListACLs", testListACLs)
+	t.Run("ListAccessWithGroups", testListAccessWithGroups)
+	t.Run("SetAccessRecursive", testSetAccessRecursive)
+	t.Run("DirACLInheritance", testDirACLInheritance)
 	t.Run("CreateStat", testCreateStat)
 	t.Run("SpecialCharInFilename", testSpecialCharInFilename)
 	t.Run("WriteRename", testWriteRename)
 	t.Run("WriteRenameDir", testWriteRenameDir)
 	t.Run("RemoveClose", testRemoveClose)
+	t.Run("Watcher", testWatcher)
+	t.Run("AuditSink", testAuditSink)
+	t.Run("StatReplicas", testStatReplicas)
+	t.Run("SetReplicaStatus", testSetReplicaStatus)
+	t.Run("ModifyFileSystemMetadata", testModifyFileSystemMetadata)
+	t.Run("Touch", testTouch)
+	t.Run("TruncateFile", testTruncateFile)
+	t.Run("RegisterFile", testRegisterFile)
+	t.Run("BundleStructFile", testBundleStructFile)
+	t.Run("MountDir", testMountDir)
+	t.Run("OpenSubStructFile", testOpenSubStructFile)
+	t.Run("CopyDir", testCopyDir)
+	t.Run("Trash", testTrash)
+	t.Run("DeletionPolicy", testDeletionPolicy)
+	t.Run("GetCollectionSize", testGetCollectionSize)
+	t.Run("ListIterator", testListIterator)
+	t.Run("ListWithCallback", testListWithCallback)
+	t.Run("ListWithOptions", testListWithOptions)
+	t.Run("Glob", testGlob)
+	t.Run("Walk", testWalk)
+	t.Run("SpecialCharacters", testSpecialCharacters)
+	t.Run("SearchWithOptions", testSearchWithOptions)
+	t.Run("Aggregate", testAggregate)
+	t.Run("QueryScan", testQueryScan)
+	t.Run("ServerInfo", testServerInfo)
+	t.Run("ServerCapabilities", testServerCapabilities)
+	t.Run("ClientHints", testClientHints)
+	t.Run("DataObjectLock", testDataObjectLock)
+	t.Run("ReplicaAccessToken", testReplicaAccessToken)
+	t.Run("CreateFileExclusive", testCreateFileExclusive)
+	t.Run("Append", testAppend)
+	t.Run("UploadStreamingChecksum", testUploadStreamingChecksum)
+	t.Run("LocalChecksumCache", testLocalChecksumCache)
+	t.Run("PreserveModifyTime", testPreserveModifyTime)
+	t.Run("UploadDirSymlinkPolicy", testUploadDirSymlinkPolicy)
+	t.Run("MapXattrs", testMapXattrs)
+	t.Run("ChunkedUpload", testChunkedUpload)
+	t.Run("PathLock", testPathLock)
+	t.Run("OperationMetrics", testOperationMetrics)
+	t.Run("TransferReport", testTransferReport)
+	t.Run("FileSystemWithTicket", testFileSystemWithTicket)
+	t.Run("CrossTransferFile", testCrossTransferFile)
+	t.Run("CompressedTransfer", testCompressedTransfer)
+	t.Run("ResourceHierarchy", testResourceHierarchy)
+	t.Run("ExportListing", testExportListing)
+	t.Run("ReplicateFileWithOptions", testReplicateFileWithOptions)
+	t.Run("RenameWithOverwrite", testRenameWithOverwrite)
+	t.Run("ListSortByReplicaSize", testListSortByReplicaSize)
 }
 
 func testMakeDir(t *testing.T) {
@@ -141,6 +210,78 @@ func testMakeDirRecurse(t *testing.T) {
 	FailError(t, err)
 }
 
+func testMakeDirWithOptions(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	newDir := fmt.Sprintf("%s/make_dir_options/project", homeDir)
+
+	options := &fs.MakeDirOptions{
+		Recurse: true,
+		Inherit: true,
+		ACLs: []*types.IRODSAccess{
+			{
+				UserName:    "public",
+				AccessLevel: types.IRODSAccessLevelReadObject,
+			},
+		},
+		Metadata: []*types.IRODSMeta{
+			{
+				Name:  "project",
+				Value: "make_dir_options",
+			},
+		},
+	}
+
+	err = filesystem.MakeDirWithOptions(newDir, options)
+	FailError(t, err)
+
+	inheritance, err := filesystem.GetDirACLInheritance(newDir)
+	FailError(t, err)
+	assert.True(t, inheritance.Inheritance)
+
+	accesses, err := filesystem.ListDirACLs(newDir)
+	FailError(t, err)
+
+	found := false
+	for _, access := range accesses {
+		if access.UserName == "public" {
+			assert.Equal(t, types.IRODSAccessLevelReadObject, access.AccessLevel)
+			found = true
+		}
+	}
+	assert.True(t, found)
+
+	metas, err := filesystem.ListMetadata(newDir)
+	FailError(t, err)
+	assert.Len(t, metas, 1)
+	assert.Equal(t, "project", metas[0].Name)
+	assert.Equal(t, "make_dir_options", metas[0].Value)
+
+	// existing dir is not modified with recurse
+	err = filesystem.MakeDirWithOptions(newDir, options)
+	FailError(t, err)
+
+	metas, err = filesystem.ListMetadata(newDir)
+	FailError(t, err)
+	assert.Len(t, metas, 1)
+
+	// existing dir is an error without recurse
+	err = filesystem.MakeDirWithOptions(newDir, &fs.MakeDirOptions{})
+	assert.True(t, types.IsFileAlreadyExistError(err))
+
+	// remove
+	err = filesystem.RemoveDir(fmt.Sprintf("%s/make_dir_options", homeDir), true, true)
+	FailError(t, err)
+}
+
 func testUploadAndDeleteDir(t *testing.T) {
 	test := GetCurrentTest()
 	server := test.GetCurrentServer()
@@ -311,6 +452,74 @@ func testSearchByMeta(t *testing.T) {
 	assert.Equal(t, len(files1), numFiles)
 }
 
+func testStatWithMetadata(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	files, dirs, err := CreateSampleFilesAndDirs(t, server, homeDir, 3, 3)
+	FailError(t, err)
+	defer func() {
+		for _, file := range files {
+			err = filesystem.RemoveFile(file, true)
+			FailError(t, err)
+		}
+
+		for _, dir := range dirs {
+			err = filesystem.RemoveDir(dir, true, true)
+			FailError(t, err)
+		}
+	}()
+
+	for _, file := range files {
+		err = filesystem.AddMetadata(file, "stat_key", file, "")
+		FailError(t, err)
+	}
+	for _, dir := range dirs {
+		err = filesystem.AddMetadata(dir, "stat_key", dir, "")
+		FailError(t, err)
+	}
+
+	// stat
+	entry, err := filesystem.StatWithMetadata(files[0])
+	FailError(t, err)
+	assert.Len(t, entry.Metadata, 1)
+	assert.Equal(t, "stat_key", entry.Metadata[0].Name)
+	assert.Equal(t, files[0], entry.Metadata[0].Value)
+
+	// cached entry is not modified
+	entry, err = filesystem.Stat(files[0])
+	FailError(t, err)
+	assert.Nil(t, entry.Metadata)
+
+	// list, small pages
+	entries, err := filesystem.ListWithOptions(homeDir, &fs.ListOptions{
+		PageSize:     2,
+		WithMetadata: true,
+	})
+	FailError(t, err)
+
+	numEntries := 0
+	for _, entry := range entries {
+		assert.NotNil(t, entry.Metadata)
+
+		if !slices.Contains(files, entry.Path) && !slices.Contains(dirs, entry.Path) {
+			continue
+		}
+
+		assert.Len(t, entry.Metadata, 1)
+		assert.Equal(t, entry.Path, entry.Metadata[0].Value)
+		numEntries++
+	}
+	assert.Equal(t, len(files)+len(dirs), numEntries)
+}
+
 func testListACLs(t *testing.T) {
 	test := GetCurrentTest()
 	server := test.GetCurrentServer()
@@ -358,6 +567,191 @@ func testListACLs(t *testing.T) {
 	}
 }
 
+func testListAccessWithGroups(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	files, _, err := CreateSampleFilesAndDirs(t, server, homeDir, 1, 0)
+	FailError(t, err)
+	defer func() {
+		for _, file := range files {
+			err = filesystem.RemoveFile(file, true)
+			FailError(t, err)
+		}
+	}()
+
+	file := files[0]
+	testUsername := "testaclgroupuser1"
+	testGroupName := "testaclgroup1"
+
+	_, err = filesystem.CreateUser(testUsername, account.ClientZone, types.IRODSUserRodsUser)
+	FailError(t, err)
+	defer func() {
+		err = filesystem.RemoveUser(testUsername, account.ClientZone, types.IRODSUserRodsUser)
+		FailError(t, err)
+	}()
+
+	_, err = filesystem.CreateUser(testGroupName, account.ClientZone, types.IRODSUserRodsGroup)
+	FailError(t, err)
+	defer func() {
+		err = filesystem.RemoveUser(testGroupName, account.ClientZone, types.IRODSUserRodsGroup)
+		FailError(t, err)
+	}()
+
+	err = filesystem.AddGroupMember(testGroupName, testUsername, account.ClientZone)
+	FailError(t, err)
+	defer func() {
+		err = filesystem.RemoveGroupMember(testGroupName, testUsername, account.ClientZone)
+		FailError(t, err)
+	}()
+
+	access, err := filesystem.GetEffectiveAccess(file, testUsername, account.ClientZone)
+	FailError(t, err)
+	assert.Equal(t, types.IRODSAccessLevelNull, access)
+
+	// via group
+	err = filesystem.ChangeACLs(file, types.IRODSAccessLevelReadObject, testGroupName, account.ClientZone, false, false)
+	FailError(t, err)
+
+	grants, err := filesystem.ListAccessWithGroups(file)
+	FailError(t, err)
+
+	foundOwn := false
+	foundGroupMember := false
+	for _, grant := range grants {
+		assert.Equal(t, file, grant.Path)
+
+		if grant.UserName == account.ClientUser && grant.UserZone == account.ClientZone {
+			assert.False(t, grant.ViaGroup)
+			assert.Equal(t, types.IRODSAccessLevelOwner, grant.AccessLevel)
+			foundOwn = true
+		}
+
+		if grant.UserName == testUsername {
+			assert.True(t, grant.ViaGroup)
+			assert.Equal(t, testGroupName, grant.GroupName)
+			assert.Equal(t, account.ClientZone, grant.GroupZone)
+			assert.Equal(t, types.IRODSAccessLevelReadObject, grant.AccessLevel)
+			foundGroupMember = true
+		}
+	}
+
+	assert.True(t, foundOwn)
+	assert.True(t, foundGroupMember)
+
+	access, err = filesystem.GetEffectiveAccess(file, testUsername, account.ClientZone)
+	FailError(t, err)
+	assert.Equal(t, types.IRODSAccessLevelReadObject, access)
+
+	// a direct grant higher than the group's
+	err = filesystem.ChangeACLs(file, types.IRODSAccessLevelModifyObject, testUsername, account.ClientZone, false, false)
+	FailError(t, err)
+
+	access, err = filesystem.GetEffectiveAccess(file, testUsername, account.ClientZone)
+	FailError(t, err)
+	assert.Equal(t, types.IRODSAccessLevelModifyObject, access)
+
+	// the highest one wins regardless of the order
+	assert.True(t, types.IRODSAccessLevelOwner.IsHigherThan(types.IRODSAccessLevelModifyObject))
+	assert.False(t, types.IRODSAccessLevelReadObject.IsHigherThan(types.IRODSAccessLevelModifyObject))
+	assert.False(t, types.IRODSAccessLevelNull.IsHigherThan(types.IRODSAccessLevelNull))
+}
+
+func testSetAccessRecursive(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	dirPath := homeDir + "/test_acl_recursive"
+	err = filesystem.MakeDir(dirPath, true)
+	FailError(t, err)
+	defer func() {
+		err = filesystem.RemoveDir(dirPath, true, true)
+		FailError(t, err)
+	}()
+
+	files, _, err := CreateSampleFilesAndDirs(t, server, dirPath, 3, 0)
+	FailError(t, err)
+
+	// populate acl cache first
+	for _, file := range files {
+		_, err = filesystem.ListACLs(file)
+		FailError(t, err)
+	}
+
+	err = filesystem.SetAccessRecursive(dirPath, "public", types.IRODSAccessLevelReadObject, false)
+	FailError(t, err)
+
+	for _, file := range files {
+		acls, err := filesystem.ListACLs(file)
+		FailError(t, err)
+
+		foundPublic := false
+		for _, acl := range acls {
+			if acl.UserName == "public" {
+				assert.Equal(t, types.IRODSAccessLevelReadObject, acl.AccessLevel)
+				foundPublic = true
+			}
+		}
+
+		assert.True(t, foundPublic)
+	}
+}
+
+func testDirACLInheritance(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	dirPath := homeDir + "/test_acl_inheritance"
+	subDirPath := dirPath + "/subdir"
+	err = filesystem.MakeDir(subDirPath, true)
+	FailError(t, err)
+	defer func() {
+		err = filesystem.RemoveDir(dirPath, true, true)
+		FailError(t, err)
+	}()
+
+	entry, err := filesystem.Stat(dirPath)
+	FailError(t, err)
+	assert.False(t, entry.Inheritance)
+
+	err = filesystem.SetDirACLInheritanceRecursive(dirPath, true, false)
+	FailError(t, err)
+
+	for _, p := range []string{dirPath, subDirPath} {
+		entry, err = filesystem.Stat(p)
+		FailError(t, err)
+		assert.True(t, entry.Inheritance)
+
+		inheritance, err := filesystem.GetDirACLInheritance(p)
+		FailError(t, err)
+		assert.True(t, inheritance.Inheritance)
+	}
+}
+
 func testCreateStat(t *testing.T) {
 	test := GetCurrentTest()
 	server := test.GetCurrentServer()
@@ -666,3 +1060,3401 @@ func testRemoveClose(t *testing.T) {
 
 	assert.False(t, filesystem.Exists(irodsPath))
 }
+
+func waitWatchEvent(t *testing.T, watcher *fs.Watcher, eventType fs.WatchEventType, irodsPath string) {
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case event, ok := <-watcher.Events():
+			if !ok {
+				assert.FailNow(t, "watcher stopped")
+			}
+
+			if event.Type == eventType && event.Path == irodsPath {
+				return
+			}
+		case <-timeout:
+			assert.FailNow(t, fmt.Sprintf("no %s event for %q", eventType, irodsPath))
+		}
+	}
+}
+
+func testWatcher(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	watchDir := fmt.Sprintf("%s/watch_test_dir", homeDir)
+	err = filesystem.MakeDir(watchDir, false)
+	FailError(t, err)
+
+	watcher, err := filesystem.Watch(watchDir, &fs.WatcherConfig{
+		Interval:  100 * time.Millisecond,
+		Recursive: true,
+	})
+	FailError(t, err)
+	defer watcher.Stop()
+
+	// created
+	subDir := fmt.Sprintf("%s/subdir", watchDir)
+	err = filesystem.MakeDir(subDir, false)
+	FailError(t, err)
+
+	waitWatchEvent(t, watcher, fs.WatchCreatedEvent, subDir)
+
+	irodsPath := fmt.Sprintf("%s/test.txt", subDir)
+	fileHandle, err := filesystem.CreateFile(irodsPath, "", "w")
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	waitWatchEvent(t, watcher, fs.WatchCreatedEvent, irodsPath)
+
+	// modified
+	fileHandle, err = filesystem.OpenFile(irodsPath, "", "w")
+	FailError(t, err)
+
+	_, err = fileHandle.Write([]byte("hello world"))
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	waitWatchEvent(t, watcher, fs.WatchModifiedEvent, irodsPath)
+
+	// removed
+	err = filesystem.RemoveDir(subDir, true, true)
+	FailError(t, err)
+
+	waitWatchEvent(t, watcher, fs.WatchRemovedEvent, subDir)
+	waitWatchEvent(t, watcher, fs.WatchRemovedEvent, irodsPath)
+
+	watcher.Stop()
+
+	_, ok := <-watcher.Events()
+	assert.False(t, ok)
+
+	err = filesystem.RemoveDir(watchDir, true, true)
+	FailError(t, err)
+}
+
+func testAuditSink(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	records := []*fs.AuditRecord{}
+	recordsMutex := sync.Mutex{}
+
+	fsConfig := server.GetFileSystemConfig()
+	fsConfig.AuditSink = fs.AuditSinkFunc(func(record *fs.AuditRecord) {
+		recordsMutex.Lock()
+		defer recordsMutex.Unlock()
+
+		records = append(records, record)
+	})
+
+	filesystem, err := fs.NewFileSystem(account, fsConfig)
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	auditDir := fmt.Sprintf("%s/audit_test_dir", homeDir)
+	err = filesystem.MakeDir(auditDir, false)
+	FailError(t, err)
+
+	// failure
+	err = filesystem.MakeDir(auditDir, false)
+	assert.Error(t, err)
+
+	irodsPath := fmt.Sprintf("%s/test.txt", auditDir)
+	fileHandle, err := filesystem.CreateFile(irodsPath, "", "w")
+	FailError(t, err)
+
+	_, err = fileHandle.Write([]byte("hello world"))
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	newIrodsPath := fmt.Sprintf("%s/test2.txt", auditDir)
+	err = filesystem.RenameFile(irodsPath, newIrodsPath)
+	FailError(t, err)
+
+	err = filesystem.RemoveDir(auditDir, true, true)
+	FailError(t, err)
+
+	recordsMutex.Lock()
+	defer recordsMutex.Unlock()
+
+	operations := []fs.AuditOperation{}
+	for _, record := range records {
+		operations = append(operations, record.Operation)
+
+		assert.Equal(t, account.ClientUser, record.User)
+		assert.Equal(t, account.ClientZone, record.Zone)
+		assert.Equal(t, filesystem.GetID(), record.FileSystemID)
+	}
+
+	assert.Equal(t, []fs.AuditOperation{fs.AuditMakeDir, fs.AuditMakeDir, fs.AuditCreateFile, fs.AuditWriteFile, fs.AuditRenameFile, fs.AuditRemoveDir}, operations)
+
+	assert.Equal(t, auditDir, records[0].Path)
+	assert.True(t, records[0].Success)
+
+	assert.False(t, records[1].Success)
+	assert.NotEmpty(t, records[1].Error)
+
+	assert.Equal(t, int64(11), records[3].Bytes)
+
+	assert.Equal(t, irodsPath, records[4].Path)
+	assert.Equal(t, newIrodsPath, records[4].DestPath)
+}
+
+func testStatReplicas(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	irodsPath := fmt.Sprintf("%s/replica_test.txt", homeDir)
+	fileHandle, err := filesystem.CreateFile(irodsPath, "", "w")
+	FailError(t, err)
+
+	_, err = fileHandle.Write([]byte("hello world"))
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	replicas, err := filesystem.StatReplicas(irodsPath)
+	FailError(t, err)
+	assert.Len(t, replicas, 1)
+
+	replica := replicas[0]
+	assert.Equal(t, int64(0), replica.Number)
+	assert.True(t, replica.IsGood())
+	assert.NotEmpty(t, replica.ResourceName)
+	assert.NotEmpty(t, replica.ResourceHierarchy)
+	assert.NotEmpty(t, replica.PhysicalPath)
+
+	entry, err := filesystem.Stat(irodsPath)
+	FailError(t, err)
+	assert.Equal(t, replicas, entry.GetReplicas())
+
+	// collections have no replicas
+	_, err = filesystem.StatReplicas(homeDir)
+	assert.Error(t, err)
+
+	dirEntry, err := filesystem.Stat(homeDir)
+	FailError(t, err)
+	assert.Empty(t, dirEntry.GetReplicas())
+
+	err = filesystem.RemoveFile(irodsPath, true)
+	FailError(t, err)
+}
+
+func testSetReplicaStatus(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	irodsPath := fmt.Sprintf("%s/replica_status_test.txt", homeDir)
+	fileHandle, err := filesystem.CreateFile(irodsPath, "", "w")
+	FailError(t, err)
+
+	_, err = fileHandle.Write([]byte("hello world"))
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	err = filesystem.SetReplicaStatus(irodsPath, 0, types.ReplicaStatusStale, true)
+	FailError(t, err)
+
+	replicas, err := filesystem.StatReplicas(irodsPath)
+	FailError(t, err)
+	assert.Len(t, replicas, 1)
+	assert.Equal(t, types.ReplicaStatusStale, replicas[0].Status)
+
+	err = filesystem.SetReplicaStatus(irodsPath, 0, types.ReplicaStatusGood, true)
+	FailError(t, err)
+
+	entry, err := filesystem.Stat(irodsPath)
+	FailError(t, err)
+	assert.True(t, entry.GetReplicas()[0].IsGood())
+
+	// no such replica
+	err = filesystem.SetReplicaStatus(irodsPath, 10, types.ReplicaStatusStale, true)
+	assert.True(t, types.IsFileNotFoundError(err))
+
+	err = filesystem.RemoveFile(irodsPath, true)
+	FailError(t, err)
+}
+
+func testModifyFileSystemMetadata(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	irodsPath := fmt.Sprintf("%s/system_metadata_test.txt", homeDir)
+	fileHandle, err := filesystem.CreateFile(irodsPath, "", "w")
+	FailError(t, err)
+
+	_, err = fileHandle.Write([]byte("hello world"))
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	// cache the entry to check invalidation
+	_, err = filesystem.Stat(irodsPath)
+	FailError(t, err)
+
+	modifyTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	dataType := "text"
+	err = filesystem.ModifyFileSystemMetadata(irodsPath, 0, &types.IRODSDataObjectSystemMetadata{
+		ModifyTime: &modifyTime,
+		DataType:   &dataType,
+	}, true)
+	FailError(t, err)
+
+	entry, err := filesystem.Stat(irodsPath)
+	FailError(t, err)
+	assert.True(t, modifyTime.Equal(entry.ModifyTime))
+	assert.Equal(t, dataType, entry.DataType)
+	assert.Equal(t, int64(11), entry.Size)
+
+	// nothing to modify
+	err = filesystem.ModifyFileSystemMetadata(irodsPath, 0, &types.IRODSDataObjectSystemMetadata{}, true)
+	assert.Error(t, err)
+
+	err = filesystem.RemoveFile(irodsPath, true)
+	FailError(t, err)
+}
+
+func testTouch(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	irodsPath := fmt.Sprintf("%s/touch_test.txt", homeDir)
+	refPath := fmt.Sprintf("%s/touch_ref_test.txt", homeDir)
+
+	// no create
+	err = filesystem.TouchWithOptions(irodsPath, &fs.TouchOptions{
+		NoCreate: true,
+	})
+	FailError(t, err)
+	assert.False(t, filesystem.ExistsFile(irodsPath))
+
+	// create
+	err = filesystem.TouchWithOptions(irodsPath, nil)
+	FailError(t, err)
+	assert.True(t, filesystem.ExistsFile(irodsPath))
+
+	// set modify time
+	modifyTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	err = filesystem.TouchWithOptions(irodsPath, &fs.TouchOptions{
+		NoCreate:   true,
+		ModifyTime: &modifyTime,
+	})
+	FailError(t, err)
+
+	entry, err := filesystem.Stat(irodsPath)
+	FailError(t, err)
+	assert.True(t, modifyTime.Equal(entry.ModifyTime))
+	assert.Equal(t, int64(0), entry.Size)
+
+	// use reference
+	err = filesystem.TouchWithOptions(refPath, nil)
+	FailError(t, err)
+
+	err = filesystem.TouchWithOptions(refPath, &fs.TouchOptions{
+		ReferencePath: irodsPath,
+	})
+	FailError(t, err)
+
+	refEntry, err := filesystem.Stat(refPath)
+	FailError(t, err)
+	assert.True(t, modifyTime.Equal(refEntry.ModifyTime))
+
+	err = filesystem.RemoveFile(irodsPath, true)
+	FailError(t, err)
+
+	err = filesystem.RemoveFile(refPath, true)
+	FailError(t, err)
+}
+
+func testTruncateFile(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	irodsPath := fmt.Sprintf("%s/truncate_test.txt", homeDir)
+	fileHandle, err := filesystem.CreateFile(irodsPath, "", "w")
+	FailError(t, err)
+
+	_, err = fileHandle.Write([]byte("hello world"))
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	// shrink
+	err = filesystem.TruncateFile(irodsPath, 5)
+	FailError(t, err)
+
+	entry, err := filesystem.Stat(irodsPath)
+	FailError(t, err)
+	assert.Equal(t, int64(5), entry.Size)
+
+	buffer := bytes.Buffer{}
+	_, err = filesystem.DownloadFileToBuffer(irodsPath, "", &buffer, false, nil)
+	FailError(t, err)
+	assert.Equal(t, "hello", buffer.String())
+
+	// extend
+	err = filesystem.TruncateFile(irodsPath, 8)
+	FailError(t, err)
+
+	entry, err = filesystem.Stat(irodsPath)
+	FailError(t, err)
+	assert.Equal(t, int64(8), entry.Size)
+
+	// replica
+	version, err := filesystem.GetServerVersion()
+	FailError(t, err)
+
+	replicaNumber := 0
+	err = filesystem.TruncateFileReplica(irodsPath, 3, "", &replicaNumber, false)
+	if version.HasHigherVersionThan(4, 3, 2) {
+		FailError(t, err)
+
+		entry, err = filesystem.Stat(irodsPath)
+		FailError(t, err)
+		assert.Equal(t, int64(3), entry.Size)
+	} else {
+		assert.True(t, types.IsAPINotSupportedError(err))
+	}
+
+	err = filesystem.RemoveFile(irodsPath, true)
+	FailError(t, err)
+}
+
+func testRegisterFile(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	// file
+	irodsPath := fmt.Sprintf("%s/register_test.txt", homeDir)
+	fileHandle, err := filesystem.CreateFile(irodsPath, "", "w")
+	FailError(t, err)
+
+	_, err = fileHandle.Write([]byte("hello world"))
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	replicas, err := filesystem.StatReplicas(irodsPath)
+	FailError(t, err)
+	assert.Len(t, replicas, 1)
+
+	physicalPath := replicas[0].PhysicalPath
+	resource := replicas[0].ResourceName
+
+	err = filesystem.UnregisterFile(irodsPath)
+	FailError(t, err)
+	assert.False(t, filesystem.ExistsFile(irodsPath))
+
+	err = filesystem.RegisterFile(irodsPath, physicalPath, resource, false, false, false)
+	FailError(t, err)
+
+	buffer := bytes.Buffer{}
+	_, err = filesystem.DownloadFileToBuffer(irodsPath, "", &buffer, false, nil)
+	FailError(t, err)
+	assert.Equal(t, "hello world", buffer.String())
+
+	// already exists
+	err = filesystem.RegisterFile(irodsPath, physicalPath, resource, false, false, false)
+	assert.Error(t, err)
+
+	err = filesystem.RemoveFile(irodsPath, true)
+	FailError(t, err)
+
+	// dir
+	dirPath := fmt.Sprintf("%s/register_test_dir", homeDir)
+	err = filesystem.MakeDir(dirPath+"/sub", true)
+	FailError(t, err)
+
+	filePath := dirPath + "/sub/test.txt"
+	fileHandle, err = filesystem.CreateFile(filePath, "", "w")
+	FailError(t, err)
+
+	_, err = fileHandle.Write([]byte("hello world"))
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	replicas, err = filesystem.StatReplicas(filePath)
+	FailError(t, err)
+	assert.Len(t, replicas, 1)
+
+	assert.True(t, strings.HasSuffix(replicas[0].PhysicalPath, "/sub/test.txt"))
+	dirPhysicalPath := strings.TrimSuffix(replicas[0].PhysicalPath, "/sub/test.txt")
+
+	err = filesystem.UnregisterDir(dirPath, true)
+	FailError(t, err)
+	assert.False(t, filesystem.ExistsDir(dirPath))
+
+	err = filesystem.RegisterDir(dirPath, dirPhysicalPath, resource, false, false, false)
+	FailError(t, err)
+
+	assert.True(t, filesystem.ExistsDir(dirPath+"/sub"))
+
+	entry, err := filesystem.Stat(filePath)
+	FailError(t, err)
+	assert.Equal(t, int64(11), entry.Size)
+
+	err = filesystem.RemoveDir(dirPath, true, true)
+	FailError(t, err)
+}
+
+func testBundleStructFile(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	sourceDir := fmt.Sprintf("%s/bundle_test_source", homeDir)
+	bundlePath := fmt.Sprintf("%s/bundle_test.tar", homeDir)
+	targetDir := fmt.Sprintf("%s/bundle_test_target", homeDir)
+
+	err = filesystem.MakeDir(sourceDir+"/sub", true)
+	FailError(t, err)
+
+	files := []string{"test1.txt", "sub/test2.txt"}
+	for _, file := range files {
+		fileHandle, err := filesystem.CreateFile(path.Join(sourceDir, file), "", "w")
+		FailError(t, err)
+
+		_, err = fileHandle.Write([]byte(file))
+		FailError(t, err)
+
+		err = fileHandle.Close()
+		FailError(t, err)
+	}
+
+	err = filesystem.BundleStructFile(bundlePath, sourceDir, "", types.TAR_FILE_DT, false, false)
+	FailError(t, err)
+	assert.True(t, filesystem.ExistsFile(bundlePath))
+
+	// already exists
+	err = filesystem.BundleStructFile(bundlePath, sourceDir, "", types.TAR_FILE_DT, false, false)
+	assert.Error(t, err)
+
+	err = filesystem.ExtractStructFile(bundlePath, targetDir, "", types.TAR_FILE_DT, false, false)
+	FailError(t, err)
+
+	for _, file := range files {
+		entry, err := filesystem.Stat(path.Join(targetDir, file))
+		FailError(t, err)
+		assert.Equal(t, int64(len(file)), entry.Size)
+	}
+
+	err = filesystem.RemoveFile(bundlePath, true)
+	FailError(t, err)
+
+	err = filesystem.RemoveDir(sourceDir, true, true)
+	FailError(t, err)
+
+	err = filesystem.RemoveDir(targetDir, true, true)
+	FailError(t, err)
+}
+
+func testMountDir(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	sourceDir := fmt.Sprintf("%s/mount_test_source", homeDir)
+	bundlePath := fmt.Sprintf("%s/mount_test.tar", homeDir)
+	mountDir := fmt.Sprintf("%s/mount_test_dir", homeDir)
+
+	err = filesystem.MakeDir(sourceDir, true)
+	FailError(t, err)
+
+	fileHandle, err := filesystem.CreateFile(path.Join(sourceDir, "test.txt"), "", "w")
+	FailError(t, err)
+
+	_, err = fileHandle.Write([]byte("mount test"))
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	err = filesystem.BundleStructFile(bundlePath, sourceDir, "", types.TAR_FILE_DT, false, false)
+	FailError(t, err)
+
+	// normal type is not mountable
+	err = filesystem.MountDir(mountDir, types.NormalCollectionType, bundlePath, "")
+	assert.Error(t, err)
+
+	err = filesystem.MountDir(mountDir, types.TarStructFileCollectionType, bundlePath, "")
+	FailError(t, err)
+
+	entry, err := filesystem.Stat(mountDir)
+	FailError(t, err)
+	assert.True(t, entry.IsSpecialDir())
+	assert.Equal(t, types.TarStructFileCollectionType, entry.SpecialType)
+	assert.Equal(t, bundlePath, entry.SpecialInfo1)
+
+	entries, err := filesystem.List(homeDir)
+	FailError(t, err)
+
+	found := false
+	for _, listedEntry := range entries {
+		if listedEntry.Path == mountDir {
+			found = true
+			assert.True(t, listedEntry.IsSpecialDir())
+		} else {
+			assert.False(t, listedEntry.IsSpecialDir())
+		}
+	}
+	assert.True(t, found)
+
+	err = filesystem.UnmountDir(mountDir, "")
+	FailError(t, err)
+
+	entry, err = filesystem.Stat(mountDir)
+	FailError(t, err)
+	assert.False(t, entry.IsSpecialDir())
+
+	err = filesystem.RemoveDir(mountDir, true, true)
+	FailError(t, err)
+
+	err = filesystem.RemoveFile(bundlePath, true)
+	FailError(t, err)
+
+	err = filesystem.RemoveDir(sourceDir, true, true)
+	FailError(t, err)
+}
+
+func testOpenSubStructFile(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	sourceDir := fmt.Sprintf("%s/subfile_test_source", homeDir)
+	bundlePath := fmt.Sprintf("%s/subfile_test.tar", homeDir)
+
+	err = filesystem.MakeDir(sourceDir+"/sub", true)
+	FailError(t, err)
+
+	files := map[string][]byte{
+		"test1.txt":     []byte("hello world"),
+		"sub/test2.txt": bytes.Repeat([]byte("0123456789"), 1000),
+	}
+
+	for file, data := range files {
+		fileHandle, err := filesystem.CreateFile(path.Join(sourceDir, file), "", "w")
+		FailError(t, err)
+
+		_, err = fileHandle.Write(data)
+		FailError(t, err)
+
+		err = fileHandle.Close()
+		FailError(t, err)
+	}
+
+	err = filesystem.BundleStructFile(bundlePath, sourceDir, "", types.TAR_FILE_DT, false, false)
+	FailError(t, err)
+
+	for file, data := range files {
+		subFileHandle, err := filesystem.OpenSubStructFile(bundlePath, file, "")
+		FailError(t, err)
+
+		readData, err := io.ReadAll(subFileHandle)
+		FailError(t, err)
+		assert.Equal(t, data, readData)
+		assert.Equal(t, int64(len(data)), subFileHandle.GetOffset())
+
+		err = subFileHandle.Close()
+		FailError(t, err)
+	}
+
+	_, err = filesystem.OpenSubStructFile(bundlePath, "no_such_file.txt", "")
+	assert.Error(t, err)
+	assert.True(t, types.IsFileNotFoundError(err))
+
+	err = filesystem.RemoveFile(bundlePath, true)
+	FailError(t, err)
+
+	err = filesystem.RemoveDir(sourceDir, true, true)
+	FailError(t, err)
+}
+
+func testCopyDir(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	sourceDir := fmt.Sprintf("%s/copydir_test_source", homeDir)
+	targetDir := fmt.Sprintf("%s/copydir_test_target", homeDir)
+
+	err = filesystem.MakeDir(sourceDir+"/sub1/sub2", true)
+	FailError(t, err)
+
+	err = filesystem.MakeDir(sourceDir+"/empty", true)
+	FailError(t, err)
+
+	files := []string{"test1.txt", "test2.txt", "sub1/test3.txt", "sub1/sub2/test4.txt"}
+	var totalBytes int64
+	for _, file := range files {
+		fileHandle, err := filesystem.CreateFile(path.Join(sourceDir, file), "", "w")
+		FailError(t, err)
+
+		_, err = fileHandle.Write([]byte(file))
+		FailError(t, err)
+
+		err = fileHandle.Close()
+		FailError(t, err)
+
+		totalBytes += int64(len(file))
+	}
+
+	// copy into a sub dir
+	_, err = filesystem.CopyDir(sourceDir, sourceDir+"/sub1", nil)
+	assert.Error(t, err)
+
+	progressCalls := 0
+	lastProgress := fs.CopyDirProgress{}
+	options := &fs.CopyDirOptions{
+		Concurrency: 2,
+		ProgressCallback: func(progress *fs.CopyDirProgress) {
+			progressCalls++
+			lastProgress = *progress
+		},
+	}
+
+	result, err := filesystem.CopyDirToDir(sourceDir, targetDir, options)
+	FailError(t, err)
+	assert.Equal(t, 4, result.Dirs)
+	assert.Equal(t, len(files), result.Files)
+	assert.Equal(t, totalBytes, result.Bytes)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 4+len(files), progressCalls)
+	assert.Equal(t, len(files), lastProgress.ProcessedFiles)
+	assert.Equal(t, totalBytes, lastProgress.ProcessedBytes)
+
+	for _, file := range files {
+		entry, err := filesystem.Stat(path.Join(targetDir, file))
+		FailError(t, err)
+		assert.Equal(t, int64(len(file)), entry.Size)
+	}
+	assert.True(t, filesystem.ExistsDir(targetDir+"/empty"))
+
+	// copy again without force, existing files fail
+	result, err = filesystem.CopyDirToDir(sourceDir, targetDir, nil)
+	assert.Error(t, err)
+	assert.Equal(t, len(files), len(result.Errors))
+
+	result, err = filesystem.CopyDirToDir(sourceDir, targetDir, &fs.CopyDirOptions{Force: true})
+	FailError(t, err)
+	assert.Equal(t, len(files), result.Files)
+
+	err = filesystem.RemoveDir(sourceDir, true, true)
+	FailError(t, err)
+
+	err = filesystem.RemoveDir(targetDir, true, true)
+	FailError(t, err)
+}
+
+func testTrash(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	trashTestDir := fmt.Sprintf("%s/trash_test", homeDir)
+	newFilePath := fmt.Sprintf("%s/test.txt", trashTestDir)
+
+	err = filesystem.MakeDir(trashTestDir, true)
+	FailError(t, err)
+
+	fileHandle, err := filesystem.CreateFile(newFilePath, "", "w")
+	FailError(t, err)
+
+	_, err = fileHandle.Write([]byte("trash"))
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	// remove without force moves the file to trash
+	err = filesystem.RemoveFile(newFilePath, false)
+	FailError(t, err)
+	assert.False(t, filesystem.ExistsFile(newFilePath))
+
+	findTrashEntry := func() *fs.TrashEntry {
+		trashEntries, err := filesystem.ListTrash(true)
+		FailError(t, err)
+
+		for _, trashEntry := range trashEntries {
+			if trashEntry.OriginalPath == newFilePath && !trashEntry.Entry.IsDir() {
+				return trashEntry
+			}
+		}
+		return nil
+	}
+
+	trashEntry := findTrashEntry()
+	if !assert.NotNil(t, trashEntry) {
+		return
+	}
+	assert.True(t, strings.HasPrefix(trashEntry.Entry.Path, filesystem.GetTrashHomeDirPath()+"/"))
+
+	// restore to the original path
+	err = filesystem.RestoreTrash(trashEntry.Entry.Path, "")
+	FailError(t, err)
+	assert.True(t, filesystem.ExistsFile(newFilePath))
+	assert.Nil(t, findTrashEntry())
+
+	// restoring a path outside of trash fails
+	err = filesystem.RestoreTrash(newFilePath, "")
+	assert.Error(t, err)
+
+	err = filesystem.RemoveFile(newFilePath, false)
+	FailError(t, err)
+	assert.NotNil(t, findTrashEntry())
+
+	// recent entries are kept
+	err = filesystem.EmptyTrash(&fs.EmptyTrashOptions{MinAge: time.Hour})
+	FailError(t, err)
+	assert.NotNil(t, findTrashEntry())
+
+	err = filesystem.EmptyTrash(nil)
+	FailError(t, err)
+	assert.Nil(t, findTrashEntry())
+	assert.True(t, filesystem.ExistsDir(filesystem.GetTrashHomeDirPath()))
+
+	err = filesystem.RemoveDir(trashTestDir, true, true)
+	FailError(t, err)
+}
+
+func testDeletionPolicy(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	policyTestDir := fmt.Sprintf("%s/deletion_policy_test", homeDir)
+
+	err = filesystem.MakeDir(policyTestDir+"/sub", true)
+	FailError(t, err)
+
+	files := []string{"test1.txt", "test2.txt", "sub/test3.txt"}
+	for _, file := range files {
+		fileHandle, err := filesystem.CreateFile(path.Join(policyTestDir, file), "", "w")
+		FailError(t, err)
+
+		_, err = fileHandle.Write([]byte(file))
+		FailError(t, err)
+
+		err = fileHandle.Close()
+		FailError(t, err)
+	}
+
+	// invalid policies
+	negativeReplica := -1
+	err = filesystem.RemoveFileWithPolicy(path.Join(policyTestDir, "test1.txt"), &fs.DeletionPolicy{ReplicaNumber: &negativeReplica})
+	assert.Error(t, err)
+
+	replica := 0
+	err = filesystem.RemoveDirWithPolicy(policyTestDir, true, &fs.DeletionPolicy{ReplicaNumber: &replica})
+	assert.Error(t, err)
+
+	err = filesystem.RemoveFileWithPolicy(path.Join(policyTestDir, "test1.txt"), &fs.DeletionPolicy{Mode: "unknown"})
+	assert.Error(t, err)
+
+	// recently modified entries are kept
+	err = filesystem.RemoveFileWithPolicy(path.Join(policyTestDir, "test1.txt"), &fs.DeletionPolicy{Mode: fs.DeletionModeForce, MinAge: time.Hour})
+	FailError(t, err)
+	assert.True(t, filesystem.ExistsFile(path.Join(policyTestDir, "test1.txt")))
+
+	err = filesystem.RemoveDirWithPolicy(policyTestDir, true, &fs.DeletionPolicy{Mode: fs.DeletionModeForce, MinAge: time.Hour})
+	FailError(t, err)
+	assert.True(t, filesystem.ExistsFile(path.Join(policyTestDir, "sub/test3.txt")))
+
+	// force deletion does not use trash
+	err = filesystem.RemoveFileWithPolicy(path.Join(policyTestDir, "test1.txt"), &fs.DeletionPolicy{Mode: fs.DeletionModeForce})
+	FailError(t, err)
+	assert.False(t, filesystem.ExistsFile(path.Join(policyTestDir, "test1.txt")))
+
+	// default mode moves to trash
+	err = filesystem.RemoveFileWithPolicy(path.Join(policyTestDir, "test2.txt"), &fs.DeletionPolicy{})
+	FailError(t, err)
+	assert.False(t, filesystem.ExistsFile(path.Join(policyTestDir, "test2.txt")))
+
+	trashEntries, err := filesystem.ListTrash(true)
+	FailError(t, err)
+
+	trashedFiles := map[string]bool{}
+	for _, trashEntry := range trashEntries {
+		trashedFiles[trashEntry.OriginalPath] = true
+		if trashEntry.OriginalPath == path.Join(policyTestDir, "test2.txt") {
+			err = filesystem.RemoveFileWithPolicy(trashEntry.Entry.Path, fs.NewDeletionPolicy(true))
+			FailError(t, err)
+		}
+	}
+
+	assert.False(t, trashedFiles[path.Join(policyTestDir, "test1.txt")])
+	assert.True(t, trashedFiles[path.Join(policyTestDir, "test2.txt")])
+
+	err = filesystem.RemoveDirWithPolicy(policyTestDir, true, fs.NewDeletionPolicy(true))
+	FailError(t, err)
+	assert.False(t, filesystem.ExistsDir(policyTestDir))
+}
+
+func testGetCollectionSize(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	sizeTestDir := fmt.Sprintf("%s/collection_size_test", homeDir)
+
+	err = filesystem.MakeDir(sizeTestDir+"/sub1/sub2", true)
+	FailError(t, err)
+
+	files := []string{"test1.txt", "test2.txt", "sub1/test3.txt", "sub1/sub2/test4.txt"}
+	var rootBytes int64
+	var totalBytes int64
+	for _, file := range files {
+		fileHandle, err := filesystem.CreateFile(path.Join(sizeTestDir, file), "", "w")
+		FailError(t, err)
+
+		_, err = fileHandle.Write([]byte(file))
+		FailError(t, err)
+
+		err = fileHandle.Close()
+		FailError(t, err)
+
+		if !strings.Contains(file, "/") {
+			rootBytes += int64(len(file))
+		}
+		totalBytes += int64(len(file))
+	}
+
+	stat, err := filesystem.GetCollectionSize(sizeTestDir, false)
+	FailError(t, err)
+	assert.Equal(t, rootBytes, stat.TotalSize)
+	assert.Equal(t, int64(2), stat.FileCount)
+	assert.Equal(t, int64(1), stat.DirCount)
+	assert.False(t, stat.Recursive)
+
+	stat, err = filesystem.GetCollectionSize(sizeTestDir, true)
+	FailError(t, err)
+	assert.Equal(t, totalBytes, stat.TotalSize)
+	assert.Equal(t, int64(len(files)), stat.FileCount)
+	assert.Equal(t, int64(2), stat.DirCount)
+	assert.True(t, stat.Recursive)
+
+	_, err = filesystem.GetCollectionSize(sizeTestDir+"/missing", true)
+	assert.Error(t, err)
+	assert.True(t, types.IsFileNotFoundError(err))
+
+	err = filesystem.RemoveDir(sizeTestDir, true, true)
+	FailError(t, err)
+}
+
+func testListIterator(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	iteratorTestDir := fmt.Sprintf("%s/list_iterator_test", homeDir)
+
+	expectedPaths := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		dirPath := fmt.Sprintf("%s/dir%d", iteratorTestDir, i)
+		err = filesystem.MakeDir(dirPath, true)
+		FailError(t, err)
+
+		expectedPaths[dirPath] = true
+	}
+
+	for i := 0; i < 7; i++ {
+		filePath := fmt.Sprintf("%s/file%d.txt", iteratorTestDir, i)
+		fileHandle, err := filesystem.CreateFile(filePath, "", "w")
+		FailError(t, err)
+
+		err = fileHandle.Close()
+		FailError(t, err)
+
+		expectedPaths[filePath] = true
+	}
+
+	iterator, err := filesystem.ListIteratorWithPageSize(iteratorTestDir, 2)
+	FailError(t, err)
+
+	listedPaths := map[string]bool{}
+	pages := 0
+	listingFiles := false
+	for {
+		entries, err := iterator.Next()
+		if err == io.EOF {
+			break
+		}
+		FailError(t, err)
+
+		pages++
+		assert.LessOrEqual(t, len(entries), 2)
+
+		for _, entry := range entries {
+			// dirs come first
+			if entry.IsDir() {
+				assert.False(t, listingFiles)
+			} else {
+				listingFiles = true
+			}
+
+			assert.False(t, listedPaths[entry.Path])
+			listedPaths[entry.Path] = true
+		}
+	}
+
+	assert.Equal(t, expectedPaths, listedPaths)
+	assert.GreaterOrEqual(t, pages, 5)
+
+	// next after the end
+	_, err = iterator.Next()
+	assert.Equal(t, io.EOF, err)
+
+	err = iterator.Close()
+	FailError(t, err)
+
+	// stop early
+	iterator, err = filesystem.ListIteratorWithPageSize(iteratorTestDir, 2)
+	FailError(t, err)
+
+	entries, err := iterator.Next()
+	FailError(t, err)
+	assert.Len(t, entries, 2)
+
+	err = iterator.Close()
+	FailError(t, err)
+
+	_, err = iterator.Next()
+	assert.Error(t, err)
+
+	// listing a file fails
+	_, err = filesystem.ListIterator(fmt.Sprintf("%s/file0.txt", iteratorTestDir))
+	assert.Error(t, err)
+
+	err = filesystem.RemoveDir(iteratorTestDir, true, true)
+	FailError(t, err)
+}
+
+func testListWithCallback(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	callbackTestDir := fmt.Sprintf("%s/list_callback_test", homeDir)
+
+	err = filesystem.MakeDir(callbackTestDir+"/dir", true)
+	FailError(t, err)
+
+	for i := 0; i < 5; i++ {
+		fileHandle, err := filesystem.CreateFile(fmt.Sprintf("%s/file%d.txt", callbackTestDir, i), "", "w")
+		FailError(t, err)
+
+		err = fileHandle.Close()
+		FailError(t, err)
+	}
+
+	listed := []string{}
+	err = filesystem.ListWithCallback(callbackTestDir, func(entry *fs.Entry) bool {
+		listed = append(listed, entry.Name)
+		return true
+	})
+	FailError(t, err)
+	assert.Len(t, listed, 6)
+
+	// stop at the first file
+	var found *fs.Entry
+	calls := 0
+	err = filesystem.ListWithCallback(callbackTestDir, func(entry *fs.Entry) bool {
+		calls++
+		if !entry.IsDir() {
+			found = entry
+			return false
+		}
+		return true
+	})
+	FailError(t, err)
+	assert.NotNil(t, found)
+	assert.Equal(t, 2, calls)
+
+	err = filesystem.ListWithCallback(callbackTestDir+"/missing", func(entry *fs.Entry) bool {
+		return true
+	})
+	assert.Error(t, err)
+
+	err = filesystem.RemoveDir(callbackTestDir, true, true)
+	FailError(t, err)
+}
+
+func testListWithOptions(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	optionsTestDir := fmt.Sprintf("%s/list_options_test", homeDir)
+
+	err = filesystem.MakeDir(optionsTestDir+"/dir1", true)
+	FailError(t, err)
+
+	err = filesystem.MakeDir(optionsTestDir+"/dir2", true)
+	FailError(t, err)
+
+	fileSizes := map[string]int{
+		"a.txt": 30,
+		"b.txt": 10,
+		"c.dat": 20,
+	}
+
+	for name, size := range fileSizes {
+		fileHandle, err := filesystem.CreateFile(fmt.Sprintf("%s/%s", optionsTestDir, name), "", "w")
+		FailError(t, err)
+
+		_, err = fileHandle.Write(make([]byte, size))
+		FailError(t, err)
+
+		err = fileHandle.Close()
+		FailError(t, err)
+	}
+
+	getNames := func(entries []*fs.Entry) []string {
+		names := []string{}
+		for _, entry := range entries {
+			names = append(names, entry.Name)
+		}
+		return names
+	}
+
+	entries, err := filesystem.ListWithOptions(optionsTestDir, &fs.ListOptions{
+		SortBy:         fs.ListSortByName,
+		SortDescending: true,
+	})
+	FailError(t, err)
+	assert.Equal(t, []string{"dir2", "dir1", "c.dat", "b.txt", "a.txt"}, getNames(entries))
+
+	entries, err = filesystem.ListWithOptions(optionsTestDir, &fs.ListOptions{
+		SortBy:   fs.ListSortBySize,
+		Type:     fs.FileEntry,
+		PageSize: 1,
+	})
+	FailError(t, err)
+	assert.Equal(t, []string{"b.txt", "c.dat", "a.txt"}, getNames(entries))
+
+	entries, err = filesystem.ListWithOptions(optionsTestDir, &fs.ListOptions{
+		SortBy:      fs.ListSortByName,
+		NamePattern: "%.txt",
+	})
+	FailError(t, err)
+	assert.Equal(t, []string{"a.txt", "b.txt"}, getNames(entries))
+
+	entries, err = filesystem.ListWithOptions(optionsTestDir, &fs.ListOptions{
+		NamePattern: "dir%",
+		Type:        fs.DirectoryEntry,
+	})
+	FailError(t, err)
+	assert.ElementsMatch(t, []string{"dir1", "dir2"}, getNames(entries))
+
+	_, err = filesystem.ListWithOptions(optionsTestDir, &fs.ListOptions{
+		SortBy: "owner",
+	})
+	assert.Error(t, err)
+
+	err = filesystem.RemoveDir(optionsTestDir, true, true)
+	FailError(t, err)
+}
+
+func testGlob(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	globTestDir := fmt.Sprintf("%s/glob_test", homeDir)
+
+	err = filesystem.MakeDir(globTestDir+"/sub/deep", true)
+	FailError(t, err)
+
+	for _, name := range []string{"a.txt", "b.dat", "x_y.txt", "sub/c.txt", "sub/deep/d.txt", "sub/deep/e.dat"} {
+		fileHandle, err := filesystem.CreateFile(fmt.Sprintf("%s/%s", globTestDir, name), "", "w")
+		FailError(t, err)
+
+		err = fileHandle.Close()
+		FailError(t, err)
+	}
+
+	globRelPaths := func(pattern string) []string {
+		entries, err := filesystem.Glob(globTestDir + "/" + pattern)
+		FailError(t, err)
+
+		relPaths := []string{}
+		for _, entry := range entries {
+			relPaths = append(relPaths, strings.TrimPrefix(entry.Path, globTestDir+"/"))
+		}
+		return relPaths
+	}
+
+	assert.Equal(t, []string{"a.txt", "x_y.txt"}, globRelPaths("*.txt"))
+	assert.Equal(t, []string{"a.txt", "sub/c.txt", "sub/deep/d.txt", "x_y.txt"}, globRelPaths("**/*.txt"))
+	assert.Equal(t, []string{"sub/c.txt", "sub/deep"}, globRelPaths("sub/*"))
+	assert.Equal(t, []string{"b.dat"}, globRelPaths("?.dat"))
+	assert.Equal(t, []string{"a.txt", "b.dat"}, globRelPaths("[ab].*"))
+	assert.Equal(t, []string{"sub", "sub/c.txt", "sub/deep", "sub/deep/d.txt", "sub/deep/e.dat"}, globRelPaths("sub/**"))
+	assert.Equal(t, []string{"a.txt"}, globRelPaths("a.txt"))
+	assert.Empty(t, globRelPaths("missing.txt"))
+
+	_, err = filesystem.Glob(globTestDir + "/[a")
+	assert.Error(t, err)
+
+	err = filesystem.RemoveDir(globTestDir, true, true)
+	FailError(t, err)
+}
+
+func testWalk(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	walkTestDir := fmt.Sprintf("%s/walk_test", homeDir)
+
+	err = filesystem.MakeDir(walkTestDir+"/d1/d2", true)
+	FailError(t, err)
+
+	err = filesystem.MakeDir(walkTestDir+"/d3", true)
+	FailError(t, err)
+
+	for _, name := range []string{"a.txt", "d1/b.txt", "d1/d2/c.txt"} {
+		fileHandle, err := filesystem.CreateFile(fmt.Sprintf("%s/%s", walkTestDir, name), "", "w")
+		FailError(t, err)
+
+		err = fileHandle.Close()
+		FailError(t, err)
+	}
+
+	walkRelPaths := func(options *fs.WalkOptions, skipPath string) []string {
+		relPaths := []string{}
+		err := filesystem.Walk(walkTestDir, func(irodsPath string, entry *fs.Entry, err error) error {
+			FailError(t, err)
+
+			relPath := strings.TrimPrefix(strings.TrimPrefix(irodsPath, walkTestDir), "/")
+			if len(relPath) > 0 {
+				// parents are visited first
+				parentPath := path.Dir(relPath)
+				if parentPath != "." {
+					assert.Contains(t, relPaths, parentPath)
+				}
+			}
+
+			relPaths = append(relPaths, relPath)
+			if len(skipPath) > 0 && relPath == skipPath {
+				return fs.SkipDir
+			}
+			return nil
+		}, options)
+		FailError(t, err)
+		return relPaths
+	}
+
+	allPaths := []string{"", "a.txt", "d1", "d1/b.txt", "d1/d2", "d1/d2/c.txt", "d3"}
+	assert.ElementsMatch(t, allPaths, walkRelPaths(nil, ""))
+	assert.ElementsMatch(t, allPaths, walkRelPaths(&fs.WalkOptions{Concurrency: 4}, ""))
+	assert.ElementsMatch(t, []string{"", "a.txt", "d1", "d3"}, walkRelPaths(&fs.WalkOptions{MaxDepth: 1}, ""))
+	assert.ElementsMatch(t, []string{"", "a.txt", "d1", "d3"}, walkRelPaths(&fs.WalkOptions{Concurrency: 2}, "d1"))
+
+	// stop at the first file
+	files := 0
+	err = filesystem.Walk(walkTestDir, func(irodsPath string, entry *fs.Entry, err error) error {
+		if !entry.IsDir() {
+			files++
+			return fs.SkipAll
+		}
+		return nil
+	}, &fs.WalkOptions{Concurrency: 4})
+	FailError(t, err)
+	assert.Equal(t, 1, files)
+
+	walkErr := fmt.Errorf("walk error")
+	err = filesystem.Walk(walkTestDir, func(irodsPath string, entry *fs.Entry, err error) error {
+		if irodsPath == walkTestDir+"/d1" {
+			return walkErr
+		}
+		return nil
+	}, nil)
+	assert.ErrorIs(t, err, walkErr)
+
+	err = filesystem.Walk(walkTestDir+"/missing", func(irodsPath string, entry *fs.Entry, err error) error {
+		return nil
+	}, nil)
+	assert.Error(t, err)
+
+	err = filesystem.Walk(walkTestDir, func(irodsPath string, entry *fs.Entry, err error) error {
+		return nil
+	}, &fs.WalkOptions{ErrorPolicy: "retry"})
+	assert.Error(t, err)
+
+	err = filesystem.RemoveDir(walkTestDir, true, true)
+	FailError(t, err)
+}
+
+func testSpecialCharacters(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	specialTestDir := fmt.Sprintf("%s/special_characters_test", homeDir)
+
+	// control characters such as newlines are rejected by path.Validate, so they are not tested
+	names := []string{"100%", "a_b", "it's", "back`tick", "double\"quote", "amp&lt;", "back\\slash"}
+	for _, name := range names {
+		dirPath := fmt.Sprintf("%s/%s", specialTestDir, name)
+		filePath := fmt.Sprintf("%s/%s.txt", dirPath, name)
+
+		err = filesystem.MakeDir(dirPath, true)
+		FailError(t, err)
+
+		fileHandle, err := filesystem.CreateFile(filePath, "", "w")
+		FailError(t, err)
+
+		_, err = fileHandle.Write([]byte("hello"))
+		FailError(t, err)
+
+		err = fileHandle.Close()
+		FailError(t, err)
+
+		entry, err := filesystem.StatNoCache(filePath)
+		FailError(t, err)
+		assert.Equal(t, name+".txt", entry.Name)
+		assert.Equal(t, int64(5), entry.Size)
+
+		entries, err := filesystem.ListNoCache(dirPath)
+		FailError(t, err)
+		assert.Len(t, entries, 1)
+	}
+
+	// wildcards in paths must match literally
+	for _, name := range []string{"aXb", "1000"} {
+		err = filesystem.MakeDir(fmt.Sprintf("%s/%s/sub", specialTestDir, name), true)
+		FailError(t, err)
+	}
+
+	for _, name := range []string{"a_b", "100%"} {
+		dirStat, err := filesystem.GetCollectionSize(fmt.Sprintf("%s/%s", specialTestDir, name), true)
+		FailError(t, err)
+		assert.Equal(t, int64(0), dirStat.DirCount)
+		assert.Equal(t, int64(1), dirStat.FileCount)
+	}
+
+	entries, err := filesystem.ListWithOptions(fmt.Sprintf("%s/a_b", specialTestDir), &fs.ListOptions{
+		NamePattern: "%",
+	})
+	FailError(t, err)
+	assert.Len(t, entries, 1)
+
+	entries, err = filesystem.ListNoCache(specialTestDir)
+	FailError(t, err)
+	assert.Len(t, entries, len(names)+2)
+
+	err = filesystem.RemoveDir(specialTestDir, true, true)
+	FailError(t, err)
+}
+
+func testSearchWithOptions(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	searchTestDir := fmt.Sprintf("%s/search_options_test", homeDir)
+
+	err = filesystem.MakeDir(searchTestDir+"/SubDir", true)
+	FailError(t, err)
+
+	for _, name := range []string{"lower.txt", "Upper.TXT"} {
+		fileHandle, err := filesystem.CreateFile(fmt.Sprintf("%s/%s", searchTestDir, name), "", "w")
+		FailError(t, err)
+
+		err = fileHandle.Close()
+		FailError(t, err)
+	}
+
+	getNames := func(entries []*fs.Entry) []string {
+		names := []string{}
+		for _, entry := range entries {
+			names = append(names, entry.Name)
+		}
+		return names
+	}
+
+	entries, err := filesystem.SearchUnixWildcard(searchTestDir + "/*.txt")
+	FailError(t, err)
+	assert.ElementsMatch(t, []string{"lower.txt"}, getNames(entries))
+
+	entries, err = filesystem.SearchUnixWildcardWithOptions(searchTestDir+"/*.txt", &fs.SearchOptions{
+		CaseInsensitive: true,
+	})
+	FailError(t, err)
+	assert.ElementsMatch(t, []string{"lower.txt", "Upper.TXT"}, getNames(entries))
+
+	entries, err = filesystem.SearchUnixWildcardWithOptions(searchTestDir+"/sub*", &fs.SearchOptions{
+		CaseInsensitive: true,
+		NoDistinct:      true,
+	})
+	FailError(t, err)
+	assert.ElementsMatch(t, []string{"SubDir"}, getNames(entries))
+
+	// condition values are upper-cased for case-insensitive queries
+	query := message.NewIRODSMessageQueryRequest(common.MaxQueryRows, 0, 0, (&irods_fs.SearchOptions{CaseInsensitive: true}).GetQueryOptions())
+	query.AddEqualStringCondition(common.ICAT_COLUMN_DATA_NAME, "lower.txt")
+	assert.True(t, query.HasOption(common.ICAT_QUERY_OPTION_UPPER_CASE_WHERE))
+	assert.Contains(t, query.Conditions.Values[0].Value, "LOWER.TXT")
+
+	err = filesystem.RemoveDir(searchTestDir, true, true)
+	FailError(t, err)
+}
+
+func testAggregate(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	aggregateTestDir := fmt.Sprintf("%s/aggregate_test", homeDir)
+
+	err = filesystem.MakeDir(aggregateTestDir+"/sub1/sub2", true)
+	FailError(t, err)
+
+	files := map[string]int{
+		"test1.txt":           1000,
+		"test2.txt":           2000,
+		"sub1/test3.txt":      3000,
+		"sub1/sub2/test4.txt": 4000,
+	}
+
+	for file, mtime := range files {
+		filePath := path.Join(aggregateTestDir, file)
+
+		fileHandle, err := filesystem.CreateFile(filePath, "", "w")
+		FailError(t, err)
+
+		_, err = fileHandle.Write(make([]byte, mtime/1000))
+		FailError(t, err)
+
+		err = fileHandle.Close()
+		FailError(t, err)
+
+		secondsSinceEpoch := mtime
+		err = filesystem.Touch(filePath, "", true, nil, "", &secondsSinceEpoch)
+		FailError(t, err)
+	}
+
+	count, err := filesystem.CountFiles(aggregateTestDir, false)
+	FailError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	count, err = filesystem.CountFiles(aggregateTestDir, true)
+	FailError(t, err)
+	assert.Equal(t, int64(4), count)
+
+	maxTime, err := filesystem.GetMaxFileModifyTime(aggregateTestDir, false)
+	FailError(t, err)
+	assert.Equal(t, int64(2000), maxTime.Unix())
+
+	maxTime, err = filesystem.GetMaxFileModifyTime(aggregateTestDir, true)
+	FailError(t, err)
+	assert.Equal(t, int64(4000), maxTime.Unix())
+
+	minTime, err := filesystem.GetMinFileModifyTime(aggregateTestDir+"/sub1", true)
+	FailError(t, err)
+	assert.Equal(t, int64(3000), minTime.Unix())
+
+	avgSize, err := filesystem.GetAverageFileSize(aggregateTestDir, true)
+	FailError(t, err)
+	assert.Equal(t, 2.5, avgSize)
+
+	_, err = filesystem.CountFiles(aggregateTestDir+"/missing", true)
+	assert.Error(t, err)
+	assert.True(t, types.IsFileNotFoundError(err))
+
+	err = filesystem.MakeDir(aggregateTestDir+"/empty", false)
+	FailError(t, err)
+
+	count, err = filesystem.CountFiles(aggregateTestDir+"/empty", true)
+	FailError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	maxTime, err = filesystem.GetMaxFileModifyTime(aggregateTestDir+"/empty", true)
+	FailError(t, err)
+	assert.True(t, maxTime.IsZero())
+
+	err = filesystem.RemoveDir(aggregateTestDir, true, true)
+	FailError(t, err)
+}
+
+type queryScanTestCollection struct {
+	ID   int64  `irods:"COLL_ID"`
+	Path string `irods:"COLL_NAME"`
+}
+
+type queryScanTestDataObject struct {
+	queryScanTestCollection
+
+	Name       string    `irods:"DATA_NAME"`
+	Size       int64     `irods:"407"`
+	ModifyTime time.Time `irods:"D_MODIFY_TIME"`
+	Checksum   string    `irods:"-"`
+	Missing    string    `irods:"D_COMMENTS"`
+}
+
+func testQueryScan(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	scanTestDir := fmt.Sprintf("%s/query_scan_test", homeDir)
+
+	err = filesystem.MakeDir(scanTestDir, true)
+	FailError(t, err)
+
+	for _, name := range []string{"a.txt", "bb.txt", "ccc.txt"} {
+		fileHandle, err := filesystem.CreateFile(path.Join(scanTestDir, name), "", "w")
+		FailError(t, err)
+
+		_, err = fileHandle.Write([]byte(name))
+		FailError(t, err)
+
+		err = fileHandle.Close()
+		FailError(t, err)
+	}
+
+	conn, err := filesystem.GetMetadataConnection(true)
+	FailError(t, err)
+	defer filesystem.ReturnMetadataConnection(conn) //nolint
+
+	columns, err := message.GetQueryStructColumns(&queryScanTestDataObject{})
+	FailError(t, err)
+	assert.Equal(t, []common.ICATColumnNumber{common.ICAT_COLUMN_COLL_ID, common.ICAT_COLUMN_COLL_NAME, common.ICAT_COLUMN_DATA_NAME, common.ICAT_COLUMN_DATA_SIZE, common.ICAT_COLUMN_D_MODIFY_TIME, common.ICAT_COLUMN_D_COMMENTS}, columns)
+
+	// small pages to test paging
+	query := message.NewIRODSMessageQueryRequest(2, 0, 0, 0)
+	query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
+	query.AddSelect(common.ICAT_COLUMN_COLL_ID)
+	query.AddSelect(common.ICAT_COLUMN_COLL_NAME)
+	query.AddSelect(common.ICAT_COLUMN_DATA_NAME)
+	query.AddSelect(common.ICAT_COLUMN_DATA_SIZE)
+	query.AddSelect(common.ICAT_COLUMN_D_MODIFY_TIME)
+	query.AddEqualStringCondition(common.ICAT_COLUMN_COLL_NAME, scanTestDir)
+
+	dataObjects := []*queryScanTestDataObject{}
+	err = irods_fs.QueryAndScan(conn, query, &dataObjects)
+	FailError(t, err)
+	assert.Len(t, dataObjects, 3)
+
+	for _, dataObject := range dataObjects {
+		assert.Greater(t, dataObject.ID, int64(0))
+		assert.Equal(t, scanTestDir, dataObject.Path)
+		assert.Equal(t, int64(len(dataObject.Name)), dataObject.Size)
+		assert.False(t, dataObject.ModifyTime.IsZero())
+		assert.Empty(t, dataObject.Missing)
+	}
+
+	collections := []queryScanTestCollection{}
+	query = message.NewIRODSMessageQueryRequest(common.MaxQueryRows, 0, 0, 0)
+	query.AddKeyVal(common.ZONE_KW, conn.GetAccount().ClientZone)
+	err = query.AddSelectsForStruct(collections)
+	FailError(t, err)
+	query.AddEqualStringCondition(common.ICAT_COLUMN_COLL_NAME, scanTestDir)
+
+	err = irods_fs.QueryAndScan(conn, query, &collections)
+	FailError(t, err)
+	assert.Len(t, collections, 1)
+	assert.Equal(t, scanTestDir, collections[0].Path)
+
+	err = irods_fs.QueryAndScan(conn, query, collections)
+	assert.Error(t, err)
+
+	err = filesystem.RemoveDir(scanTestDir, true, true)
+	FailError(t, err)
+}
+
+func testServerInfo(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	serverInfo, err := filesystem.GetServerInfo()
+	FailError(t, err)
+
+	assert.True(t, serverInfo.IsCatalogProvider())
+	assert.Equal(t, filesystem.GetAccount().ClientZone, serverInfo.Zone)
+	assert.False(t, serverInfo.BootTime.IsZero())
+	assert.False(t, serverInfo.BootTime.After(time.Now()))
+	assert.GreaterOrEqual(t, serverInfo.GetUptime(), time.Duration(0))
+
+	version, err := filesystem.GetServerVersion()
+	FailError(t, err)
+
+	assert.Equal(t, version.ReleaseVersion, serverInfo.ReleaseVersion)
+	assert.Equal(t, version.APIVersion, serverInfo.APIVersion)
+
+	major, minor, patch := version.GetReleaseVersion()
+	assert.True(t, serverInfo.GetVersion().HasHigherVersionThan(major, minor, patch))
+}
+
+func testServerCapabilities(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	capabilities, err := filesystem.GetServerCapabilities()
+	FailError(t, err)
+
+	version, err := filesystem.GetServerVersion()
+	FailError(t, err)
+
+	assert.Equal(t, version.HasHigherVersionThan(4, 2, 9), capabilities.ParallelUpload)
+	assert.Equal(t, capabilities.ParallelUpload, filesystem.SupportParallelUpload())
+	assert.Equal(t, version.HasHigherVersionThan(4, 3, 0), capabilities.NewAuthFramework)
+	assert.Equal(t, version.HasHigherVersionThan(4, 3, 1), capabilities.LibraryFeatures)
+
+	if !capabilities.LibraryFeatures {
+		assert.Empty(t, capabilities.GetFeatureNames())
+	}
+
+	if capabilities.LibraryFeatures {
+		// features are cached, connections made later do not ask again
+		account, err := server.GetAccount()
+		FailError(t, err)
+
+		wireDebug := &bytes.Buffer{}
+		connConfig := server.GetConnectionConfig()
+		connConfig.WireDebugWriter = wireDebug
+
+		conn, err := connection.NewIRODSConnection(account, connConfig)
+		FailError(t, err)
+
+		err = conn.Connect()
+		FailError(t, err)
+		defer conn.Disconnect()
+
+		assert.Equal(t, capabilities.GetFeatureNames(), conn.GetServerCapabilities().GetFeatureNames())
+		assert.NotContains(t, wireDebug.String(), fmt.Sprintf("<intInfo>%d</intInfo>", common.GET_LIBRARY_FEATURES_AN))
+	}
+
+	if !capabilities.GridConfiguration {
+		_, err = filesystem.GetGridConfigurationValue("delay_server", "leader")
+		assert.Error(t, err)
+		assert.True(t, types.IsAPINotSupportedError(err))
+	}
+
+	// capabilities derived from versions
+	oldCapabilities := types.NewIRODSServerCapabilities(&types.IRODSVersion{ReleaseVersion: "rods4.2.8"})
+	assert.False(t, oldCapabilities.ParallelUpload)
+	assert.False(t, oldCapabilities.NewXML)
+	assert.False(t, oldCapabilities.ReplicaTruncate)
+
+	newCapabilities := types.NewIRODSServerCapabilities(&types.IRODSVersion{ReleaseVersion: "rods5.0.1"})
+	assert.True(t, newCapabilities.ParallelUpload)
+	assert.True(t, newCapabilities.GridConfiguration)
+	assert.True(t, newCapabilities.ReplicaTruncate)
+	assert.True(t, newCapabilities.DataObjectAccessTime)
+
+	newCapabilities.Features["IRODS_HAS_LIBRARY_TICKET_ADMINISTRATION"] = 202208
+	assert.True(t, newCapabilities.HasFeature("IRODS_HAS_LIBRARY_TICKET_ADMINISTRATION"))
+	assert.Equal(t, 202208, newCapabilities.GetFeatureVersion("IRODS_HAS_LIBRARY_TICKET_ADMINISTRATION"))
+	assert.False(t, newCapabilities.HasFeature("IRODS_HAS_MISSING_FEATURE"))
+}
+
+func testClientHints(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	hints, err := filesystem.GetClientHints()
+	FailError(t, err)
+
+	assert.NotEmpty(t, hints.HashScheme)
+	assert.NotEqual(t, types.ChecksumAlgorithmUnknown, hints.GetChecksumAlgorithm())
+	assert.False(t, hints.HasSpecificQuery("missing_specific_query"))
+
+	// cached
+	cachedHints, err := filesystem.GetClientHints()
+	FailError(t, err)
+	assert.Same(t, hints, cachedHints)
+
+	hints = &types.IRODSClientHints{
+		HashScheme: "MD5",
+		Plugins: []*types.IRODSClientHintsPlugin{
+			{Name: "unixfilesystem", Type: "resource"},
+			{Name: "native", Type: "auth"},
+		},
+	}
+	assert.Equal(t, types.ChecksumAlgorithmMD5, hints.GetChecksumAlgorithm())
+	assert.Len(t, hints.GetPlugins("resource"), 1)
+	assert.Empty(t, hints.GetPlugins("network"))
+}
+
+func testDataObjectLock(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	lockTestPath := fmt.Sprintf("%s/lock_test.txt", homeDir)
+
+	fileHandle, err := filesystem.CreateFile(lockTestPath, "", "w")
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	entry, err := filesystem.StatExtended(lockTestPath)
+	FailError(t, err)
+	assert.Equal(t, types.DataObjectLockTypeUnlock, entry.LockType)
+
+	dirEntry, err := filesystem.StatExtended(homeDir)
+	FailError(t, err)
+	assert.Empty(t, dirEntry.LockType)
+
+	// read locks are shared
+	readLock1, err := filesystem.LockFile(lockTestPath, types.DataObjectLockTypeRead, false)
+	FailError(t, err)
+
+	readLock2, err := filesystem.LockFile(lockTestPath, types.DataObjectLockTypeRead, false)
+	FailError(t, err)
+	assert.True(t, readLock2.IsLocked())
+
+	entry, err = filesystem.StatExtended(lockTestPath)
+	FailError(t, err)
+	assert.Equal(t, types.DataObjectLockTypeRead, entry.LockType)
+
+	// write lock conflicts
+	_, err = filesystem.LockFile(lockTestPath, types.DataObjectLockTypeWrite, false)
+	assert.Error(t, err)
+	lockErrCode, _ := common.SplitIRODSErrorCode(types.GetIRODSErrorCode(err))
+	assert.Equal(t, common.SYS_FS_LOCK_ERR, lockErrCode)
+
+	// wait for read locks to be released
+	writeLockCh := make(chan *fs.DataObjectLock)
+	go func() {
+		writeLock, err := filesystem.LockFile(lockTestPath, types.DataObjectLockTypeWrite, true)
+		assert.NoError(t, err)
+		writeLockCh <- writeLock
+	}()
+
+	select {
+	case <-writeLockCh:
+		assert.Fail(t, "write lock is granted while read locks are held")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	err = readLock1.Unlock()
+	FailError(t, err)
+
+	err = readLock2.Unlock()
+	FailError(t, err)
+	assert.False(t, readLock2.IsLocked())
+
+	// unlocking twice does nothing
+	err = readLock2.Unlock()
+	FailError(t, err)
+
+	writeLock := <-writeLockCh
+	if writeLock == nil {
+		t.FailNow()
+	}
+	assert.Equal(t, types.DataObjectLockTypeWrite, writeLock.GetLockType())
+
+	entry, err = filesystem.StatExtended(lockTestPath)
+	FailError(t, err)
+	assert.Equal(t, types.DataObjectLockTypeWrite, entry.LockType)
+
+	err = writeLock.Unlock()
+	FailError(t, err)
+
+	lockType, err := filesystem.GetFileLockType(lockTestPath)
+	FailError(t, err)
+	assert.Equal(t, types.DataObjectLockTypeUnlock, lockType)
+
+	err = filesystem.RemoveFile(lockTestPath, true)
+	FailError(t, err)
+}
+
+func testReplicaAccessToken(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	replicaTestPath := fmt.Sprintf("%s/replica_token_test.bin", homeDir)
+
+	numWorkers := 4
+	partLength := 1024
+	data := MakeFixedContentDataBuf(int64(numWorkers * partLength))
+
+	fileHandle, err := filesystem.CreateFile(replicaTestPath, "", "w")
+	FailError(t, err)
+
+	accessInfo, err := fileHandle.GetReplicaAccessInfo(numWorkers, int64(len(data)))
+	FailError(t, err)
+	assert.Equal(t, replicaTestPath, accessInfo.Path)
+	assert.NotEmpty(t, accessInfo.ReplicaToken)
+	assert.NotEmpty(t, accessInfo.ResourceHierarchy)
+
+	// pass the token to workers as other processes do
+	token, err := accessInfo.GetToken()
+	FailError(t, err)
+
+	wg := sync.WaitGroup{}
+	for worker := 0; worker < numWorkers; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+
+			workerAccessInfo, err := types.NewIRODSReplicaAccessInfoFromToken(token)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, accessInfo, workerAccessInfo)
+
+			workerHandle, err := filesystem.OpenDataObjectWithReplicaToken(workerAccessInfo, "w")
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			offset := worker * partLength
+			_, err = workerHandle.WriteAt(data[offset:offset+partLength], int64(offset))
+			assert.NoError(t, err)
+
+			err = workerHandle.Close()
+			assert.NoError(t, err)
+		}(worker)
+	}
+
+	wg.Wait()
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	// the token is invalid after the file is closed
+	_, err = filesystem.OpenDataObjectWithReplicaToken(accessInfo, "w")
+	assert.Error(t, err)
+
+	_, err = types.NewIRODSReplicaAccessInfoFromToken("invalid token")
+	assert.Error(t, err)
+
+	buffer := bytes.Buffer{}
+	_, err = filesystem.DownloadFileToBuffer(replicaTestPath, "", &buffer, false, nil)
+	FailError(t, err)
+	assert.Equal(t, data, buffer.Bytes())
+
+	err = filesystem.RemoveFile(replicaTestPath, true)
+	FailError(t, err)
+}
+
+func testCreateFileExclusive(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	exclusiveTestPath := fmt.Sprintf("%s/exclusive_create_test.txt", homeDir)
+	exclusiveOpts := &fs.CreateFileOptions{
+		Exclusive: true,
+	}
+
+	fileHandle, err := filesystem.CreateFileWithOptions(exclusiveTestPath, "", "w", exclusiveOpts)
+	FailError(t, err)
+
+	_, err = fileHandle.Write([]byte("first"))
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	// exists
+	_, err = filesystem.CreateFileWithOptions(exclusiveTestPath, "", "w", exclusiveOpts)
+	assert.Error(t, err)
+	assert.True(t, types.IsFileAlreadyExistError(err))
+
+	// a dir
+	_, err = filesystem.CreateFileWithOptions(homeDir, "", "w", exclusiveOpts)
+	assert.Error(t, err)
+	assert.True(t, types.IsFileAlreadyExistError(err))
+
+	// content is not truncated
+	entry, err := filesystem.Stat(exclusiveTestPath)
+	FailError(t, err)
+	assert.Equal(t, int64(len("first")), entry.Size)
+
+	// only one of concurrent creations succeeds
+	err = filesystem.RemoveFile(exclusiveTestPath, true)
+	FailError(t, err)
+
+	numCreators := 5
+	created := int32(0)
+	wg := sync.WaitGroup{}
+	for creator := 0; creator < numCreators; creator++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			handle, err := filesystem.CreateFileWithOptions(exclusiveTestPath, "", "w", exclusiveOpts)
+			if err != nil {
+				assert.True(t, types.IsFileAlreadyExistError(err))
+				return
+			}
+
+			atomic.AddInt32(&created, 1)
+			err = handle.Close()
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, int32(1), created)
+
+	// overwrite without the option
+	fileHandle, err = filesystem.CreateFile(exclusiveTestPath, "", "w")
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	err = filesystem.RemoveFile(exclusiveTestPath, true)
+	FailError(t, err)
+}
+
+func testAppend(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	appendTestPath := fmt.Sprintf("%s/append_test.txt", homeDir)
+
+	// creates
+	size, err := filesystem.Append(appendTestPath, "", []byte("hello"))
+	FailError(t, err)
+	assert.Equal(t, int64(5), size)
+
+	size, err = filesystem.Append(appendTestPath, "", []byte(" world"))
+	FailError(t, err)
+	assert.Equal(t, int64(11), size)
+
+	assert.True(t, filesystem.ExistsFile(appendTestPath))
+
+	// append mode writes at the end after seek
+	fileHandle, err := filesystem.OpenFile(appendTestPath, "", "a")
+	FailError(t, err)
+	assert.Equal(t, int64(11), fileHandle.GetOffset())
+
+	_, err = fileHandle.Seek(0, io.SeekStart)
+	FailError(t, err)
+
+	_, err = fileHandle.Write([]byte("!"))
+	FailError(t, err)
+	assert.Equal(t, int64(12), fileHandle.GetOffset())
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	// read and append
+	fileHandle, err = filesystem.OpenFile(appendTestPath, "", "a+")
+	FailError(t, err)
+	assert.Equal(t, int64(12), fileHandle.GetOffset())
+
+	readBuffer := make([]byte, 12)
+	readLen, err := fileHandle.ReadAt(readBuffer, 0)
+	if err != io.EOF {
+		FailError(t, err)
+	}
+	assert.Equal(t, "hello world!", string(readBuffer[:readLen]))
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	// replica opened via token
+	fileHandle, err = filesystem.OpenFile(appendTestPath, "", "r+")
+	FailError(t, err)
+
+	accessInfo, err := fileHandle.GetReplicaAccessInfo(1, 0)
+	FailError(t, err)
+
+	appendHandle, err := filesystem.OpenDataObjectWithReplicaToken(accessInfo, "a")
+	FailError(t, err)
+	assert.Equal(t, int64(12), appendHandle.GetOffset())
+
+	_, err = appendHandle.Write([]byte("?"))
+	FailError(t, err)
+
+	err = appendHandle.Close()
+	FailError(t, err)
+
+	err = fileHandle.Close()
+	FailError(t, err)
+
+	buffer := bytes.Buffer{}
+	_, err = filesystem.DownloadFileToBuffer(appendTestPath, "", &buffer, false, nil)
+	FailError(t, err)
+	assert.Equal(t, "hello world!?", buffer.String())
+
+	err = filesystem.RemoveFile(appendTestPath, true)
+	FailError(t, err)
+}
+
+func testUploadStreamingChecksum(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	fileSize := int64(5 * 1024 * 1024) // 5MB
+	localPath, err := CreateLocalTestFile(t, "test_file_", fileSize)
+	FailError(t, err)
+	defer func() {
+		err = os.Remove(localPath)
+		FailError(t, err)
+	}()
+
+	iRODSPath := fmt.Sprintf("%s/%s", homeDir, path.Base(localPath))
+
+	// the local file must not be read again to calculate its hash
+	hashCalled := false
+	callback := func(taskName string, processed int64, total int64) {
+		if taskName == "checksum" {
+			hashCalled = true
+		}
+	}
+
+	result, err := filesystem.UploadFile(localPath, iRODSPath, "", false, true, callback)
+	FailError(t, err)
+	assert.False(t, hashCalled)
+	assert.Equal(t, fileSize, result.IRODSSize)
+
+	expectedHash, err := util.HashLocalFile(localPath, string(result.LocalCheckSumAlgorithm), nil)
+	FailError(t, err)
+	assert.Equal(t, expectedHash, result.LocalCheckSum)
+
+	if len(result.IRODSCheckSum) > 0 {
+		assert.Equal(t, result.IRODSCheckSum, result.LocalCheckSum)
+	}
+
+	err = filesystem.RemoveFile(iRODSPath, true)
+	FailError(t, err)
+}
+
+func testLocalChecksumCache(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	checksumCache := fs.NewLocalChecksumCache()
+
+	fsConfig := server.GetFileSystemConfig()
+	fsConfig.LocalChecksumCache = checksumCache
+
+	filesystem, err := fs.NewFileSystem(account, fsConfig)
+	FailError(t, err)
+	defer filesystem.Release()
+
+	fileSize := int64(1024 * 1024) // 1MB
+	localPath, err := CreateLocalTestFile(t, "test_file_", fileSize)
+	FailError(t, err)
+	defer func() {
+		err = os.Remove(localPath)
+		FailError(t, err)
+	}()
+
+	hashCalled := 0
+	callback := func(taskName string, processed int64, total int64) {
+		if taskName == "checksum" && processed == total {
+			hashCalled++
+		}
+	}
+
+	algorithm, hash, err := filesystem.GetLocalFileHash(localPath, types.ChecksumAlgorithmMD5, callback)
+	FailError(t, err)
+	assert.Equal(t, types.ChecksumAlgorithmMD5, algorithm)
+	assert.Equal(t, 1, hashCalled)
+	assert.Equal(t, 1, checksumCache.Len())
+
+	// cached
+	_, cachedHash, err := filesystem.GetLocalFileHash(localPath, types.ChecksumAlgorithmMD5, callback)
+	FailError(t, err)
+	assert.Equal(t, 1, hashCalled)
+	assert.Equal(t, hash, cachedHash)
+
+	// other algorithm
+	_, _, err = filesystem.GetLocalFileHash(localPath, types.ChecksumAlgorithmSHA256, callback)
+	FailError(t, err)
+	assert.Equal(t, 2, hashCalled)
+
+	// save and load
+	cachePath := localPath + ".checksums"
+	err = checksumCache.Save(cachePath)
+	FailError(t, err)
+	defer func() {
+		err = os.Remove(cachePath)
+		FailError(t, err)
+	}()
+
+	loadedCache, err := fs.NewLocalChecksumCacheFromFile(cachePath)
+	FailError(t, err)
+	assert.Equal(t, 1, loadedCache.Len())
+
+	stat, err := os.Stat(localPath)
+	FailError(t, err)
+
+	loadedHash, ok := loadedCache.Get(localPath, stat.Size(), stat.ModTime(), types.ChecksumAlgorithmSHA256)
+	assert.True(t, ok)
+
+	_, sha256Hash, err := filesystem.GetLocalFileHash(localPath, types.ChecksumAlgorithmSHA256, nil)
+	FailError(t, err)
+	assert.Equal(t, sha256Hash, loadedHash)
+
+	// changed file is hashed again
+	f, err := os.OpenFile(localPath, os.O_WRONLY|os.O_APPEND, 0)
+	FailError(t, err)
+	_, err = f.Write([]byte("changed"))
+	FailError(t, err)
+	err = f.Close()
+	FailError(t, err)
+
+	err = os.Chtimes(localPath, time.Now(), stat.ModTime().Add(time.Second))
+	FailError(t, err)
+
+	_, changedHash, err := filesystem.GetLocalFileHash(localPath, types.ChecksumAlgorithmSHA256, callback)
+	FailError(t, err)
+	assert.Equal(t, 3, hashCalled)
+	assert.NotEqual(t, sha256Hash, changedHash)
+
+	// not existing cache file
+	emptyCache, err := fs.NewLocalChecksumCacheFromFile(localPath + ".not_exist")
+	FailError(t, err)
+	assert.Equal(t, 0, emptyCache.Len())
+
+	// downloaded files are verified with the bytes on disk, not with the cached hash
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	iRODSPath := path.Join(homeDir, "checksum_cache_test_file")
+	_, err = filesystem.UploadFile(localPath, iRODSPath, "", false, true, nil)
+	FailError(t, err)
+	defer func() {
+		err = filesystem.RemoveFile(iRODSPath, true)
+		FailError(t, err)
+	}()
+
+	downloadPath := localPath + ".download"
+	_, err = filesystem.DownloadFile(iRODSPath, "", downloadPath, true, nil)
+	FailError(t, err)
+	defer func() {
+		err = os.Remove(downloadPath)
+		FailError(t, err)
+	}()
+
+	downloadStat, err := os.Stat(downloadPath)
+	FailError(t, err)
+
+	entry, err := filesystem.StatNoCache(iRODSPath)
+	FailError(t, err)
+
+	checksumCache.Put(downloadPath, downloadStat.Size(), downloadStat.ModTime(), entry.CheckSumAlgorithm, []byte("wrong hash"))
+
+	result, err := filesystem.DownloadFile(iRODSPath, "", downloadPath, true, nil)
+	FailError(t, err)
+	assert.Equal(t, entry.CheckSum, result.LocalCheckSum)
+}
+
+func testPreserveModifyTime(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	fsConfig := server.GetFileSystemConfig()
+	fsConfig.PreserveUploadModifyTime = fs.UploadModifyTimeModeTouch
+	fsConfig.PreserveDownloadModifyTime = true
+
+	filesystem, err := fs.NewFileSystem(account, fsConfig)
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	fileSize := int64(1024)
+	localPath, err := CreateLocalTestFile(t, "test_file_", fileSize)
+	FailError(t, err)
+	defer func() {
+		err = os.Remove(localPath)
+		FailError(t, err)
+	}()
+
+	modifyTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	err = os.Chtimes(localPath, modifyTime, modifyTime)
+	FailError(t, err)
+
+	// upload
+	iRODSPath := fmt.Sprintf("%s/%s", homeDir, path.Base(localPath))
+	_, err = filesystem.UploadFile(localPath, iRODSPath, "", false, false, nil)
+	FailError(t, err)
+
+	entry, err := filesystem.Stat(iRODSPath)
+	FailError(t, err)
+	assert.Equal(t, modifyTime.Unix(), entry.ModifyTime.Unix())
+
+	// download
+	downloadPath := localPath + ".download"
+	_, err = filesystem.DownloadFile(iRODSPath, "", downloadPath, false, nil)
+	FailError(t, err)
+	defer func() {
+		err = os.Remove(downloadPath)
+		FailError(t, err)
+	}()
+
+	stat, err := os.Stat(downloadPath)
+	FailError(t, err)
+	assert.Equal(t, modifyTime.Unix(), stat.ModTime().Unix())
+
+	err = filesystem.RemoveFile(iRODSPath, true)
+	FailError(t, err)
+}
+
+func testUploadDirSymlinkPolicy(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	// local_dir/a.txt, local_dir/link_a -> a.txt, local_dir/sub/b.txt, local_dir/sub/loop -> local_dir
+	localDir := path.Join(t.TempDir(), "local_dir")
+	err = os.MkdirAll(path.Join(localDir, "sub"), 0o755)
+	FailError(t, err)
+
+	err = os.WriteFile(path.Join(localDir, "a.txt"), []byte("hello"), 0o644)
+	FailError(t, err)
+
+	err = os.WriteFile(path.Join(localDir, "sub", "b.txt"), []byte("world!"), 0o644)
+	FailError(t, err)
+
+	err = os.Symlink("a.txt", path.Join(localDir, "link_a"))
+	FailError(t, err)
+
+	err = os.Symlink(localDir, path.Join(localDir, "sub", "loop"))
+	FailError(t, err)
+
+	// follow
+	followDir := fmt.Sprintf("%s/upload_dir_follow", homeDir)
+	result, err := filesystem.UploadDir(localDir, followDir, &fs.UploadDirOptions{
+		SymlinkPolicy: fs.SymlinkPolicyFollow,
+	})
+	FailError(t, err)
+	assert.Equal(t, 2, result.Dirs)
+	assert.Equal(t, 3, result.Files)
+	assert.Equal(t, int64(16), result.Bytes)
+	assert.Equal(t, 1, result.SkippedSymlinks)
+
+	linkEntry, err := filesystem.Stat(followDir + "/link_a")
+	FailError(t, err)
+	assert.Equal(t, int64(5), linkEntry.Size)
+	assert.True(t, filesystem.ExistsFile(followDir+"/sub/b.txt"))
+	assert.False(t, filesystem.Exists(followDir+"/sub/loop"))
+
+	// skip
+	skipDir := fmt.Sprintf("%s/upload_dir_skip", homeDir)
+	result, err = filesystem.UploadDir(localDir, skipDir, &fs.UploadDirOptions{
+		SymlinkPolicy: fs.SymlinkPolicySkip,
+	})
+	FailError(t, err)
+	assert.Equal(t, 2, result.Files)
+	assert.Equal(t, 2, result.SkippedSymlinks)
+	assert.False(t, filesystem.Exists(skipDir+"/link_a"))
+
+	// error
+	errorDir := fmt.Sprintf("%s/upload_dir_error", homeDir)
+	_, err = filesystem.UploadDir(localDir, errorDir, &fs.UploadDirOptions{
+		SymlinkPolicy: fs.SymlinkPolicyError,
+	})
+	assert.Error(t, err)
+	assert.False(t, filesystem.Exists(errorDir))
+
+	// upload into an existing dir
+	result, err = filesystem.UploadDir(localDir, skipDir, &fs.UploadDirOptions{
+		SymlinkPolicy: fs.SymlinkPolicySkip,
+	})
+	FailError(t, err)
+	assert.Equal(t, 2, result.Files)
+	assert.True(t, filesystem.ExistsFile(skipDir+"/local_dir/sub/b.txt"))
+
+	for _, dir := range []string{followDir, skipDir} {
+		err = filesystem.RemoveDir(dir, true, true)
+		FailError(t, err)
+	}
+}
+
+func testMapXattrs(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	fsConfig := server.GetFileSystemConfig()
+	fsConfig.MapXattrs = true
+
+	filesystem, err := fs.NewFileSystem(account, fsConfig)
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	localPath, err := CreateLocalTestFile(t, "test_file_", 1024)
+	FailError(t, err)
+	defer func() {
+		err = os.Remove(localPath)
+		FailError(t, err)
+	}()
+
+	xattrs := map[string][]byte{
+		"user.app.tag":   []byte("hello"),
+		"user.app.bin":   {0, 1, 2, 255},
+		"user.app.empty": {},
+	}
+
+	for name, value := range xattrs {
+		err = system.SetXattr(localPath, name, value)
+		if err != nil {
+			t.Skipf("local filesystem does not support extended attributes: %v", err)
+		}
+	}
+
+	// upload
+	iRODSPath := fmt.Sprintf("%s/%s", homeDir, path.Base(localPath))
+	_, err = filesystem.UploadFile(localPath, iRODSPath, "", false, false, nil)
+	FailError(t, err)
+
+	metas, err := filesystem.ListMetadata(iRODSPath)
+	FailError(t, err)
+
+	avus := map[string]*types.IRODSMeta{}
+	for _, meta := range metas {
+		avus[meta.Name] = meta
+	}
+
+	assert.Len(t, avus, 3)
+	assert.Equal(t, "hello", avus[fs.XattrAVUPrefixDefault+"user.app.tag"].Value)
+	assert.Equal(t, fs.XattrAVUUnitsBase64, avus[fs.XattrAVUPrefixDefault+"user.app.bin"].Units)
+	assert.Equal(t, fs.XattrAVUUnitsEmpty, avus[fs.XattrAVUPrefixDefault+"user.app.empty"].Units)
+
+	avuXattrs, err := filesystem.ListXattrAVUs(iRODSPath)
+	FailError(t, err)
+	assert.Equal(t, xattrs, avuXattrs)
+
+	// upload again after removing an xattr
+	err = system.RemoveXattr(localPath, "user.app.empty")
+	FailError(t, err)
+	delete(xattrs, "user.app.empty")
+
+	_, err = filesystem.UploadFile(localPath, iRODSPath, "", false, false, nil)
+	FailError(t, err)
+
+	avuXattrs, err = filesystem.ListXattrAVUs(iRODSPath)
+	FailError(t, err)
+	assert.Equal(t, xattrs, avuXattrs)
+
+	// download
+	downloadPath := localPath + ".download"
+	_, err = filesystem.DownloadFile(iRODSPath, "", downloadPath, false, nil)
+	FailError(t, err)
+	defer func() {
+		err = os.Remove(downloadPath)
+		FailError(t, err)
+	}()
+
+	localXattrs, err := system.ListXattrs(downloadPath)
+	FailError(t, err)
+	assert.Equal(t, xattrs, localXattrs)
+
+	err = filesystem.RemoveFile(iRODSPath, true)
+	FailError(t, err)
+}
+
+func testChunkedUpload(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	chunkSize := 1024 * 1024 // 1MB
+	data := make([]byte, 4*chunkSize+100)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+
+	iRODSPath := fmt.Sprintf("%s/test_chunked_upload.bin", homeDir)
+
+	upload, err := filesystem.StartUpload(iRODSPath, &fs.StartUploadOptions{
+		Size: int64(len(data)),
+	})
+	FailError(t, err)
+
+	// upload chunks in reverse order concurrently
+	wg := sync.WaitGroup{}
+	errs := make(chan error, len(data)/chunkSize+1)
+	for offset := (len(data) / chunkSize) * chunkSize; offset >= 0; offset -= chunkSize {
+		end := offset + chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+
+		wg.Add(1)
+		go func(offset int, end int) {
+			defer wg.Done()
+			errs <- upload.UploadChunk(int64(offset), data[offset:end])
+		}(offset, end)
+	}
+
+	wg.Wait()
+	close(errs)
+	for chunkErr := range errs {
+		FailError(t, chunkErr)
+	}
+
+	assert.Equal(t, int64(len(data)), upload.GetUploadedBytes())
+
+	entry, err := upload.CompleteUpload()
+	FailError(t, err)
+	assert.Equal(t, int64(len(data)), entry.Size)
+
+	// finished
+	err = upload.UploadChunk(0, data[:10])
+	assert.Error(t, err)
+
+	handle, err := filesystem.OpenFile(iRODSPath, "", "r")
+	FailError(t, err)
+
+	readData, err := io.ReadAll(handle)
+	FailError(t, err)
+	err = handle.Close()
+	FailError(t, err)
+
+	assert.True(t, bytes.Equal(data, readData))
+
+	// abort removes the file
+	abortedPath := fmt.Sprintf("%s/test_chunked_upload_aborted.bin", homeDir)
+
+	upload, err = filesystem.StartUpload(abortedPath, nil)
+	FailError(t, err)
+
+	err = upload.UploadChunk(0, data[:chunkSize])
+	FailError(t, err)
+
+	err = upload.AbortUpload()
+	FailError(t, err)
+	assert.False(t, filesystem.ExistsFile(abortedPath))
+
+	err = filesystem.RemoveFile(iRODSPath, true)
+	FailError(t, err)
+}
+
+func testPathLock(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	iRODSPath := fmt.Sprintf("%s/test_path_lock.txt", homeDir)
+
+	// the path is locked while the upload is in progress
+	upload, err := filesystem.StartUpload(iRODSPath, nil)
+	FailError(t, err)
+
+	err = upload.UploadChunk(0, []byte("chunked upload"))
+	FailError(t, err)
+
+	// reject
+	filesystem.GetConfig().RejectConcurrentPathOperations = true
+
+	_, err = filesystem.UploadFileFromBuffer(bytes.NewBufferString("buffer upload"), iRODSPath, "", false, false, nil)
+	assert.Error(t, err)
+	assert.True(t, types.IsPathInUseError(err))
+
+	err = filesystem.RemoveFile(iRODSPath, true)
+	assert.True(t, types.IsPathInUseError(err))
+
+	// wait
+	filesystem.GetConfig().RejectConcurrentPathOperations = false
+
+	uploadDone := make(chan error, 1)
+	go func() {
+		_, uploadErr := filesystem.UploadFileFromBuffer(bytes.NewBufferString("buffer upload"), iRODSPath, "", false, false, nil)
+		uploadDone <- uploadErr
+	}()
+
+	select {
+	case <-uploadDone:
+		assert.Fail(t, "upload must wait for the chunked upload")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	_, err = upload.CompleteUpload()
+	FailError(t, err)
+
+	err = <-uploadDone
+	FailError(t, err)
+
+	buffer := &bytes.Buffer{}
+	_, err = filesystem.DownloadFileToBuffer(iRODSPath, "", buffer, false, nil)
+	FailError(t, err)
+	assert.Equal(t, "buffer upload", buffer.String())
+
+	err = filesystem.RemoveFile(iRODSPath, true)
+	FailError(t, err)
+}
+
+func testOperationMetrics(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	iRODSPath := fmt.Sprintf("%s/test_operation_metrics.txt", homeDir)
+	data := []byte("hello operation metrics")
+
+	_, err = filesystem.UploadFileFromBuffer(bytes.NewBuffer(data), iRODSPath, "", false, false, nil)
+	FailError(t, err)
+
+	buffer := &bytes.Buffer{}
+	_, err = filesystem.DownloadFileToBuffer(iRODSPath, "", buffer, false, nil)
+	FailError(t, err)
+	assert.Equal(t, data, buffer.Bytes())
+
+	snapshot := filesystem.GetMetrics().GetOperationMetrics()
+
+	for _, operation := range []metrics.OperationType{metrics.OperationOpen, metrics.OperationRead, metrics.OperationWrite, metrics.OperationQuery, metrics.OperationAuth} {
+		operationMetrics := snapshot.Get(operation)
+		assert.Greater(t, operationMetrics.GetCount(), uint64(0), "operation %q", operation)
+		assert.Equal(t, operationMetrics.GetCount(), operationMetrics.Latency.GetCount())
+		assert.GreaterOrEqual(t, operationMetrics.Latency.Max, operationMetrics.Latency.Min)
+		assert.LessOrEqual(t, operationMetrics.Latency.GetPercentile(50), operationMetrics.Latency.Max)
+	}
+
+	assert.GreaterOrEqual(t, snapshot.Get(metrics.OperationWrite).BytesSent, uint64(len(data)))
+	assert.GreaterOrEqual(t, snapshot.Get(metrics.OperationRead).BytesReceived, uint64(len(data)))
+
+	err = filesystem.RemoveFile(iRODSPath, true)
+	FailError(t, err)
+}
+
+func testTransferReport(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	filesystem, err := server.GetFileSystem()
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	filename := "test_transfer_report.bin"
+	fileSize := int64(10 * 1024 * 1024)
+
+	localPath, err := CreateLocalTestFile(t, filename, fileSize)
+	FailError(t, err)
+	defer func() {
+		err = os.Remove(localPath)
+		FailError(t, err)
+	}()
+
+	iRODSPath := homeDir + "/" + filename
+
+	// disabled
+	result, err := filesystem.UploadFile(localPath, iRODSPath, "", false, false, nil)
+	FailError(t, err)
+	assert.Nil(t, result.Report)
+
+	filesystem.GetConfig().TransferReport = true
+
+	result, err = filesystem.UploadFile(localPath, iRODSPath, "", false, false, nil)
+	FailError(t, err)
+	assert.NotNil(t, result.Report)
+	assert.Equal(t, fileSize, result.Report.Bytes)
+	assert.Equal(t, 1, result.Report.TaskNum)
+	assert.Equal(t, 0, result.Report.Retries)
+	assert.Equal(t, fs.TransferVerificationSkipped, result.Report.Verification)
+
+	newLocalPath := t.TempDir() + "/new_" + filename
+
+	result, err = filesystem.DownloadFileParallel(iRODSPath, "", newLocalPath, 4, false, nil)
+	FailError(t, err)
+	assert.NotNil(t, result.Report)
+	assert.Equal(t, fileSize, result.Report.Bytes)
+	assert.Equal(t, 4, result.Report.TaskNum)
+	assert.Greater(t, result.Report.Duration, time.Duration(0))
+	assert.Greater(t, result.Report.Throughput, float64(0))
+
+	err = filesystem.RemoveFile(iRODSPath, true)
+	FailError(t, err)
+}
+
+func testFileSystemWithTicket(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	adminFilesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer adminFilesystem.Release()
+
+	iRODSPath := account.GetHomeDirPath() + "/public_data.txt"
+	content := []byte("public data")
+
+	_, err = adminFilesystem.UploadFileFromBuffer(bytes.NewBuffer(content), iRODSPath, "", false, false, nil)
+	FailError(t, err)
+
+	testServer.AddUser(types.AnonymousUser, "")
+	testServer.AddTicket("public_ticket", iRODSPath)
+
+	filesystem, err := fs.NewFileSystemWithTicket(account.Host, account.Port, account.ClientZone, "public_ticket")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	assert.True(t, filesystem.IsTicketAccess())
+	assert.True(t, filesystem.GetAccount().IsAnonymousUser())
+	assert.False(t, filesystem.GetConfig().Cache.StartNewTransaction)
+
+	buffer := &bytes.Buffer{}
+	_, err = filesystem.DownloadFileToBuffer(iRODSPath, "", buffer, false, nil)
+	FailError(t, err)
+	assert.Equal(t, content, buffer.Bytes())
+
+	// invalid ticket
+	invalidFilesystem, err := fs.NewFileSystemWithTicket(account.Host, account.Port, account.ClientZone, "invalid_ticket")
+	if err == nil {
+		defer invalidFilesystem.Release()
+
+		_, err = invalidFilesystem.Stat(iRODSPath)
+	}
+	assert.Error(t, err)
+
+	// empty ticket
+	_, err = fs.NewFileSystemWithTicket(account.Host, account.Port, account.ClientZone, "")
+	assert.Error(t, err)
+}
+
+func testCrossTransferFile(t *testing.T) {
+	newZoneFileSystem := func(zone string) (*fs.FileSystem, *testserver.TestServer) {
+		config := testserver.NewDefaultTestServerConfig()
+		config.Zone = zone
+
+		testServer := testserver.NewTestServer(config)
+		err := testServer.Start()
+		FailError(t, err)
+
+		account, err := testServer.GetAdminAccount()
+		FailError(t, err)
+
+		filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+		FailError(t, err)
+
+		return filesystem, testServer
+	}
+
+	srcFS, srcServer := newZoneFileSystem("zoneA")
+	defer srcServer.Stop()
+	defer srcFS.Release()
+
+	destFS, destServer := newZoneFileSystem("zoneB")
+	defer destServer.Stop()
+	defer destFS.Release()
+
+	content := bytes.Repeat([]byte("cross zone data "), 1024)
+	srcPath := srcFS.GetHomeDirPath() + "/cross_transfer.txt"
+
+	_, err := srcFS.UploadFileFromBuffer(bytes.NewBuffer(content), srcPath, "", false, false, nil)
+	FailError(t, err)
+
+	// the destination can't see the source, stream through the client
+	var lastProgress int64
+	options := &fs.CrossTransferOptions{
+		VerifyChecksum: true,
+		BufferSize:     1000,
+		TransferCallback: func(name string, processed int64, total int64) {
+			lastProgress = processed
+		},
+	}
+
+	result, err := fs.CrossTransferFile(srcFS, srcPath, destFS, destFS.GetHomeDirPath(), options)
+	FailError(t, err)
+	assert.Equal(t, fs.CrossTransferMethodStream, result.Method)
+	assert.Equal(t, destFS.GetHomeDirPath()+"/cross_transfer.txt", result.DestPath)
+	assert.Equal(t, int64(len(content)), result.Size)
+	assert.Equal(t, int64(len(content)), lastProgress)
+	assert.NotEmpty(t, result.SourceCheckSum)
+	assert.Equal(t, result.SourceCheckSum, result.DestCheckSum)
+	assert.False(t, result.Moved)
+
+	buffer := &bytes.Buffer{}
+	_, err = destFS.DownloadFileToBuffer(result.DestPath, "", buffer, false, nil)
+	FailError(t, err)
+	assert.Equal(t, content, buffer.Bytes())
+
+	// no overwrite without force
+	_, err = fs.CrossTransferFile(srcFS, srcPath, destFS, result.DestPath, nil)
+	assert.True(t, types.IsFileAlreadyExistError(err))
+
+	// another FileSystem of the same zone can copy on the server side
+	srcAccount := srcFS.GetAccount()
+	sameZoneFS, err := fs.NewFileSystemWithDefault(srcAccount, "go-irodsclient-test")
+	FailError(t, err)
+	defer sameZoneFS.Release()
+
+	copyPath := srcFS.GetHomeDirPath() + "/cross_transfer_copy.txt"
+	result, err = fs.CrossTransferFile(srcFS, srcPath, sameZoneFS, copyPath, &fs.CrossTransferOptions{VerifyChecksum: true})
+	FailError(t, err)
+	assert.Equal(t, fs.CrossTransferMethodServerSide, result.Method)
+	assert.True(t, sameZoneFS.ExistsFile(copyPath))
+
+	// move
+	movedPath := destFS.GetHomeDirPath() + "/cross_transfer_moved.txt"
+	result, err = fs.CrossTransferFile(srcFS, srcPath, destFS, movedPath, &fs.CrossTransferOptions{
+		Method: fs.CrossTransferMethodStream,
+		Move:   true,
+	})
+	FailError(t, err)
+	assert.True(t, result.Moved)
+	assert.False(t, srcFS.ExistsFile(srcPath))
+	assert.True(t, destFS.ExistsFile(movedPath))
+
+	// separate grids with the same zone name have different files at the same path, stream rather than copy the destination's own file
+	gridAFS, gridAServer := newZoneFileSystem(testserver.ZoneDefault)
+	defer gridAServer.Stop()
+	defer gridAFS.Release()
+
+	gridBFS, gridBServer := newZoneFileSystem(testserver.ZoneDefault)
+	defer gridBServer.Stop()
+	defer gridBFS.Release()
+
+	gridPath := gridAFS.GetHomeDirPath() + "/same_path.txt"
+	_, err = gridAFS.UploadFileFromBuffer(bytes.NewBufferString("grid A data"), gridPath, "", false, false, nil)
+	FailError(t, err)
+	_, err = gridBFS.UploadFileFromBuffer(bytes.NewBufferString("grid B data"), gridPath, "", false, false, nil)
+	FailError(t, err)
+
+	result, err = fs.CrossTransferFile(gridAFS, gridPath, gridBFS, gridPath, &fs.CrossTransferOptions{Force: true})
+	FailError(t, err)
+	assert.Equal(t, fs.CrossTransferMethodStream, result.Method)
+
+	buffer.Reset()
+	_, err = gridBFS.DownloadFileToBuffer(gridPath, "", buffer, false, nil)
+	FailError(t, err)
+	assert.Equal(t, "grid A data", buffer.String())
+
+	// the stream is written to a temporary file, which is removed after replacing the destination
+	gridEntries, err := gridBFS.List(gridBFS.GetHomeDirPath())
+	FailError(t, err)
+	assert.Len(t, gridEntries, 1)
+}
+
+func testCompressedTransfer(t *testing.T) {
+	testServer := testserver.NewTestServer(testserver.NewDefaultTestServerConfig())
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir := filesystem.GetHomeDirPath()
+	localDir := t.TempDir()
+
+	// text compresses well
+	content := []byte(strings.Repeat("id,name,value\n1,alpha,0.5\n", 4096))
+	assert.True(t, fs.IsCompressible(content))
+
+	localPath := path.Join(localDir, "table.csv")
+	err = os.WriteFile(localPath, content, 0o644)
+	FailError(t, err)
+
+	var lastProgress int64
+	options := &fs.CompressedTransferOptions{
+		Compression:    fs.TransferCompressionAuto,
+		VerifyChecksum: true,
+		TransferCallback: func(name string, processed int64, total int64) {
+			lastProgress = processed
+		},
+	}
+
+	result, err := filesystem.UploadFileCompressed(localPath, homeDir, options)
+	FailError(t, err)
+	assert.Equal(t, fs.TransferCompressionGzip, result.Compression)
+	assert.Equal(t, homeDir+"/table.csv.gz", result.IRODSPath)
+	assert.Equal(t, int64(len(content)), result.LocalSize)
+	assert.Equal(t, int64(len(content)), lastProgress)
+	assert.Less(t, result.IRODSSize, result.LocalSize)
+	assert.Equal(t, result.IRODSCheckSum, result.LocalCheckSum)
+
+	// no overwrite without force
+	_, err = filesystem.UploadFileCompressed(localPath, homeDir, options)
+	assert.True(t, types.IsFileAlreadyExistError(err))
+
+	downloadDir := path.Join(localDir, "download")
+	err = os.Mkdir(downloadDir, 0o755)
+	FailError(t, err)
+
+	result, err = filesystem.DownloadFileCompressed(homeDir+"/table.csv.gz", downloadDir, options)
+	FailError(t, err)
+	assert.Equal(t, fs.TransferCompressionGzip, result.Compression)
+	assert.Equal(t, path.Join(downloadDir, "table.csv"), result.LocalPath)
+	assert.Equal(t, int64(len(content)), result.LocalSize)
+	assert.Equal(t, result.IRODSCheckSum, result.LocalCheckSum)
+
+	downloaded, err := os.ReadFile(result.LocalPath)
+	FailError(t, err)
+	assert.Equal(t, content, downloaded)
+
+	// random data does not compress, auto uploads as is
+	random := make([]byte, 128*1024)
+	_, err = rand.Read(random)
+	FailError(t, err)
+	assert.False(t, fs.IsCompressible(random))
+
+	randomPath := path.Join(localDir, "random.bin")
+	err = os.WriteFile(randomPath, random, 0o644)
+	FailError(t, err)
+
+	result, err = filesystem.UploadFileCompressed(randomPath, homeDir, &fs.CompressedTransferOptions{
+		Compression: fs.TransferCompressionAuto,
+	})
+	FailError(t, err)
+	assert.Equal(t, fs.TransferCompressionNone, result.Compression)
+	assert.Equal(t, homeDir+"/random.bin", result.IRODSPath)
+	assert.Equal(t, int64(len(random)), result.IRODSSize)
+
+	// gzip is required but the data object is not compressed
+	_, err = filesystem.DownloadFileCompressed(homeDir+"/random.bin", path.Join(localDir, "random.out"), &fs.CompressedTransferOptions{
+		Compression: fs.TransferCompressionGzip,
+	})
+	assert.Error(t, err)
+
+	// auto downloads uncompressed data as is
+	result, err = filesystem.DownloadFileCompressed(homeDir+"/random.bin", path.Join(localDir, "random.out"), &fs.CompressedTransferOptions{
+		Compression: fs.TransferCompressionAuto,
+	})
+	FailError(t, err)
+	assert.Equal(t, fs.TransferCompressionNone, result.Compression)
+
+	downloaded, err = os.ReadFile(path.Join(localDir, "random.out"))
+	FailError(t, err)
+	assert.Equal(t, random, downloaded)
+}
+
+func testResourceHierarchy(t *testing.T) {
+	config := testserver.NewDefaultTestServerConfig()
+
+	testServer := testserver.NewTestServer(config)
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	// hierarchy helpers
+	assert.True(t, types.IsResourceHierarchy("root;child;leaf"))
+	assert.False(t, types.IsResourceHierarchy("leaf"))
+	assert.Equal(t, "root", types.GetResourceHierarchyRoot("root;child;leaf"))
+	assert.Equal(t, "leaf", types.GetResourceHierarchyLeaf("root;child;leaf"))
+	assert.Equal(t, "leaf", types.GetResourceHierarchyRoot("leaf"))
+
+	// a hierarchy is given with resc_hier, the root with destRescName
+	request := message.NewIRODSMessageOpenDataObjectRequest("/zone/file", "root;child;leaf", types.FileOpenModeReadOnly)
+	assert.Equal(t, []string{string(common.DEST_RESC_NAME_KW), string(common.RESC_HIER_STR_KW)}, request.KeyVals.Keys)
+	assert.Equal(t, "root", request.KeyVals.Values[0].Value)
+	assert.Equal(t, "root;child;leaf", request.KeyVals.Values[1].Value)
+
+	request = message.NewIRODSMessageOpenDataObjectRequest("/zone/file", "leaf", types.FileOpenModeReadOnly)
+	assert.Equal(t, []string{string(common.DEST_RESC_NAME_KW)}, request.KeyVals.Keys)
+
+	// resolve
+	hierarchy, err := filesystem.ResolveResourceHierarchy("")
+	FailError(t, err)
+	assert.Empty(t, hierarchy)
+
+	hierarchy, err = filesystem.ResolveResourceHierarchy("root;leaf")
+	FailError(t, err)
+	assert.Equal(t, "root;leaf", hierarchy)
+
+	hierarchy, err = filesystem.ResolveResourceHierarchy(config.Resource)
+	FailError(t, err)
+	assert.Equal(t, config.Resource, hierarchy)
+
+	_, err = filesystem.ResolveResourceHierarchy("no_such_resource")
+	assert.True(t, types.IsResourceNotFoundError(err))
+
+	// the hierarchy the server voted for is returned in the handle
+	filePath := filesystem.GetHomeDirPath() + "/resource_hierarchy.txt"
+
+	handle, err := filesystem.CreateFile(filePath, config.Resource, "w")
+	FailError(t, err)
+
+	_, err = handle.Write([]byte("hello"))
+	FailError(t, err)
+
+	hierarchy, err = handle.GetResourceHierarchy()
+	FailError(t, err)
+	assert.Equal(t, config.Resource, hierarchy)
+
+	err = handle.Close()
+	FailError(t, err)
+
+	_, err = filesystem.UploadFileFromBuffer(bytes.NewBufferString("hello"), filePath, config.Resource, false, false, nil)
+	FailError(t, err)
+
+	entry, err := filesystem.StatNoCache(filePath)
+	FailError(t, err)
+	assert.Equal(t, config.Resource, entry.IRODSReplicas[0].ResourceHierarchy)
+}
+
+func testExportListing(t *testing.T) {
+	config := testserver.NewDefaultTestServerConfig()
+
+	testServer := testserver.NewTestServer(config)
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	rootPath := filesystem.GetHomeDirPath() + "/export"
+	err = filesystem.MakeDir(rootPath+"/sub1/sub2", true)
+	FailError(t, err)
+
+	filePaths := []string{
+		rootPath + "/file1.txt",
+		rootPath + "/sub1/file2.txt",
+		rootPath + "/sub1/sub2/file3.txt",
+		rootPath + "/sub1/sub2/file4.txt",
+	}
+
+	for _, filePath := range filePaths {
+		_, err = filesystem.UploadFileFromBuffer(bytes.NewBufferString("hello"), filePath, "", false, false, nil)
+		FailError(t, err)
+	}
+
+	// json lines, small pages
+	buffer := &bytes.Buffer{}
+	result, err := filesystem.ExportListing(rootPath, buffer, &fs.ListingExportOptions{
+		PageSize: 1,
+	})
+	FailError(t, err)
+	assert.Equal(t, int64(3), result.Dirs)
+	assert.Equal(t, int64(4), result.Files)
+	assert.Equal(t, int64(20), result.TotalSize)
+
+	exportedPaths := []string{}
+	decoder := json.NewDecoder(buffer)
+	for decoder.More() {
+		record := fs.ListingExportRecord{}
+		err = decoder.Decode(&record)
+		FailError(t, err)
+
+		exportedPaths = append(exportedPaths, record.Path)
+	}
+	assert.Equal(t, rootPath, exportedPaths[0])
+	assert.ElementsMatch(t, append([]string{rootPath, rootPath + "/sub1", rootPath + "/sub1/sub2"}, filePaths...), exportedPaths)
+
+	// csv, files only, limited depth
+	buffer = &bytes.Buffer{}
+	result, err = filesystem.ExportListing(rootPath, buffer, &fs.ListingExportOptions{
+		Format:    fs.ListingExportCSV,
+		FilesOnly: true,
+		MaxDepth:  2,
+	})
+	FailError(t, err)
+	assert.Equal(t, int64(0), result.Dirs)
+	assert.Equal(t, int64(2), result.Files)
+
+	rows, err := csv.NewReader(buffer).ReadAll()
+	FailError(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, "path", rows[0][0])
+	assert.ElementsMatch(t, []string{filePaths[0], filePaths[1]}, []string{rows[1][0], rows[2][0]})
+	assert.Equal(t, "5", rows[1][2])
+
+	// the test server has no metadata, AVUs are listed per page
+	buffer = &bytes.Buffer{}
+	result, err = filesystem.ExportListing(rootPath, buffer, &fs.ListingExportOptions{
+		IncludeMetadata: true,
+		PageSize:        1,
+		ErrorPolicy:     fs.WalkErrorSkip,
+	})
+	FailError(t, err)
+	assert.Equal(t, int64(4), result.Files)
+	assert.Equal(t, int64(0), result.Errors)
+
+	entries, err := filesystem.ListWithOptions(rootPath, &fs.ListOptions{
+		WithMetadata: true,
+	})
+	FailError(t, err)
+	assert.Len(t, entries, 2)
+	for _, entry := range entries {
+		assert.NotNil(t, entry.Metadata)
+		assert.Empty(t, entry.Metadata)
+	}
+
+	entry, err := filesystem.StatWithMetadata(filePaths[0])
+	FailError(t, err)
+	assert.NotNil(t, entry.Metadata)
+
+	_, err = filesystem.ExportListing(rootPath, buffer, &fs.ListingExportOptions{
+		Format: "xml",
+	})
+	assert.Error(t, err)
+}
+
+func testReplicateFileWithOptions(t *testing.T) {
+	config := testserver.NewDefaultTestServerConfig()
+
+	testServer := testserver.NewTestServer(config)
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	filePath := filesystem.GetHomeDirPath() + "/replicate.txt"
+	_, err = filesystem.UploadFileFromBuffer(bytes.NewBufferString("hello"), filePath, "", false, false, nil)
+	FailError(t, err)
+
+	// a hierarchy is given with dest_resc_hier
+	request := message.NewIRODSMessageReplicateDataObjectRequest(filePath, "root;leaf")
+	assert.Equal(t, []string{string(common.DEST_RESC_HIER_STR_KW)}, request.KeyVals.Keys)
+
+	// the test server does not support replication, so all attempts fail
+	resources := []string{"resc1", "resc2", "resc3"}
+
+	outcomes, err := filesystem.ReplicateFileWithOptions(filePath, &fs.ReplicateFileOptions{
+		Resources: resources,
+	})
+	assert.Error(t, err)
+	assert.Len(t, outcomes, 3)
+	assert.True(t, outcomes[0].Attempted)
+	assert.Error(t, outcomes[0].Error)
+	assert.False(t, outcomes[1].Attempted)
+	assert.False(t, outcomes[2].Attempted)
+	assert.False(t, outcomes[2].IsSucceeded())
+
+	outcomes, err = filesystem.ReplicateFileWithOptions(filePath, &fs.ReplicateFileOptions{
+		Resources: resources,
+		Policy:    fs.ReplicationBestEffort,
+		AdminFlag: true,
+	})
+	assert.Error(t, err)
+	assert.Len(t, outcomes, 3)
+	for idx, outcome := range outcomes {
+		assert.Equal(t, resources[idx], outcome.Resource)
+		assert.True(t, outcome.Attempted)
+		assert.Error(t, outcome.Error)
+	}
+
+	_, err = filesystem.ReplicateFileWithOptions(filePath, &fs.ReplicateFileOptions{
+		Policy: "unknown",
+	})
+	assert.Error(t, err)
+}
+
+func testRenameWithOverwrite(t *testing.T) {
+	config := testserver.NewDefaultTestServerConfig()
+
+	testServer := testserver.NewTestServer(config)
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir := filesystem.GetHomeDirPath()
+	srcPath := homeDir + "/rename_src.txt"
+	destPath := homeDir + "/rename_dest.txt"
+
+	_, err = filesystem.UploadFileFromBuffer(bytes.NewBufferString("source"), srcPath, "", false, false, nil)
+	FailError(t, err)
+
+	_, err = filesystem.UploadFileFromBuffer(bytes.NewBufferString("dest"), destPath, "", false, false, nil)
+	FailError(t, err)
+
+	// dest exists
+	err = filesystem.RenameFile(srcPath, destPath)
+	assert.True(t, types.IsFileAlreadyExistError(err))
+	assert.False(t, types.IsFileNotFoundError(err))
+	assert.True(t, filesystem.ExistsFile(srcPath))
+
+	// overwrite
+	err = filesystem.RenameFileWithOptions(srcPath, destPath, &fs.RenameOptions{
+		Overwrite: true,
+	})
+	FailError(t, err)
+	assert.False(t, filesystem.ExistsFile(srcPath))
+
+	entry, err := filesystem.Stat(destPath)
+	FailError(t, err)
+	assert.Equal(t, int64(len("source")), entry.Size)
+
+	entries, err := filesystem.List(homeDir)
+	FailError(t, err)
+	numDest := 0
+	for _, entry := range entries {
+		if entry.Path == destPath {
+			numDest++
+		}
+	}
+	assert.Equal(t, 1, numDest)
+
+	// dest dir does not exist
+	err = filesystem.RenameFileToFile(destPath, homeDir+"/no_such_dir/rename_dest.txt")
+	assert.True(t, types.IsFileNotFoundError(err))
+	assert.False(t, types.IsCrossCollectionRenameError(err))
+
+	var notFoundErr *types.FileNotFoundError
+	if assert.True(t, errors.As(err, &notFoundErr)) {
+		assert.Equal(t, homeDir+"/no_such_dir", notFoundErr.Path)
+	}
+
+	// src does not exist
+	err = filesystem.RenameFileToFile(srcPath, homeDir+"/rename_other.txt")
+	assert.True(t, types.IsFileNotFoundError(err))
+	assert.False(t, types.IsCrossCollectionRenameError(err))
+
+	// dir into itself
+	dirPath := homeDir + "/rename_dir"
+	err = filesystem.MakeDir(dirPath+"/sub", true)
+	FailError(t, err)
+
+	err = filesystem.RenameDirToDir(dirPath, dirPath+"/sub/moved")
+	assert.True(t, types.IsCrossCollectionRenameError(err))
+
+	// overwrite dirs
+	destDirPath := homeDir + "/rename_dest_dir"
+	err = filesystem.MakeDir(destDirPath+"/rename_dir", true)
+	FailError(t, err)
+
+	err = filesystem.RenameDirToDir(dirPath, destDirPath)
+	assert.True(t, types.IsFileAlreadyExistError(err))
+
+	// moved into dest, replacing its empty dir
+	err = filesystem.RenameDirWithOptions(dirPath, destDirPath, &fs.RenameOptions{
+		Overwrite: true,
+	})
+	FailError(t, err)
+	assert.False(t, filesystem.ExistsDir(dirPath))
+	assert.True(t, filesystem.ExistsDir(destDirPath+"/rename_dir/sub"))
+
+	// non-empty dirs are not replaced
+	err = filesystem.MakeDir(dirPath, true)
+	FailError(t, err)
+
+	err = filesystem.RenameDirWithOptions(dirPath, destDirPath, &fs.RenameOptions{
+		Overwrite: true,
+	})
+	assert.True(t, types.IsFileAlreadyExistError(err))
+	assert.True(t, filesystem.ExistsDir(dirPath))
+	assert.True(t, filesystem.ExistsDir(destDirPath+"/rename_dir/sub"))
+
+	// the empty dest is kept if the move fails
+	err = filesystem.MakeDir(destDirPath+"/no_such_src_dir", true)
+	FailError(t, err)
+
+	err = filesystem.RenameDirWithOptions(homeDir+"/no_such_src_dir", destDirPath, &fs.RenameOptions{
+		Overwrite: true,
+	})
+	assert.True(t, types.IsFileNotFoundError(err))
+	assert.True(t, filesystem.ExistsDir(destDirPath+"/no_such_src_dir"))
+}
+
+func testListSortByReplicaSize(t *testing.T) {
+	config := testserver.NewDefaultTestServerConfig()
+
+	testServer := testserver.NewTestServer(config)
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	files := map[string]int{"a.txt": 5, "b.txt": 50, "c.txt": 10}
+	for name, size := range files {
+		_, err = filesystem.UploadFileFromBuffer(bytes.NewBufferString(strings.Repeat("a", size)), filesystem.GetHomeDirPath()+"/"+name, "", false, false, nil)
+		FailError(t, err)
+	}
+
+	// replicas of a and c differ in size, so their rows are not adjacent when sorted by size
+	err = testServer.AddStaleReplica(filesystem.GetHomeDirPath()+"/a.txt", 100)
+	FailError(t, err)
+	err = testServer.AddStaleReplica(filesystem.GetHomeDirPath()+"/c.txt", 1)
+	FailError(t, err)
+
+	for _, pageSize := range []int{1, 2, 100} {
+		entries, err := filesystem.ListWithOptions(filesystem.GetHomeDirPath(), &fs.ListOptions{
+			SortBy:   fs.ListSortBySize,
+			Type:     fs.FileEntry,
+			PageSize: pageSize,
+		})
+		FailError(t, err)
+
+		names := []string{}
+		for _, entry := range entries {
+			names = append(names, entry.Name)
+		}
+
+		// the smallest replica decides the position of a data object
+		assert.Equal(t, []string{"c.txt", "a.txt", "b.txt"}, names, "page size %d", pageSize)
+	}
+}
@@ -1,10 +1,18 @@
 package testcases
 
 import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/testserver"
+	"github.com/cyverse/go-irodsclient/irods/types"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 func getLowlevelConnectionTest() Test {
@@ -19,6 +27,9 @@ func lowlevelConnectionTest(t *testing.T, test *Test) {
 	t.Run("Connection", testConnection)
 	t.Run("InvalidUsername", testInvalidUsername)
 	t.Run("ManyConnections", testManyConnections)
+	t.Run("IPv6", testIPv6Connection)
+	t.Run("SRVDiscovery", testSRVDiscovery)
+	t.Run("ValidateAccount", testValidateAccount)
 }
 
 func testConnection(t *testing.T) {
@@ -80,3 +91,254 @@ func testManyConnections(t *testing.T) {
 		t.Logf("Connection %d: %s %s", i, conn.GetVersion().ReleaseVersion, conn.GetVersion().APIVersion)
 	}
 }
+
+func testIPv6Connection(t *testing.T) {
+	listener, err := net.Listen("tcp", "[::1]:0")
+	if err != nil {
+		t.Skipf("IPv6 is not available: %v", err)
+	}
+	_ = listener.Close()
+
+	config := testserver.NewDefaultTestServerConfig()
+	config.Address = "[::1]:0"
+
+	testServer := testserver.NewTestServer(config)
+	err = testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	port := testServer.GetPort()
+	expectedAddress := net.JoinHostPort("::1", strconv.Itoa(port))
+
+	for _, host := range []string{"::1", "[::1]", fmt.Sprintf("[::1]:%d", port)} {
+		account, err := testServer.GetAdminAccount()
+		FailError(t, err)
+		account.Host = host
+
+		conn, err := connection.NewIRODSConnection(account, &connection.IRODSConnectionConfig{
+			ApplicationName: "go-irodsclient-test",
+			AddressFamily:   connection.AddressFamilyPreferIPv6,
+		})
+		FailError(t, err)
+
+		err = conn.Connect()
+		FailError(t, err)
+
+		assert.Equal(t, expectedAddress, conn.GetServerAddress())
+		_ = conn.Disconnect()
+	}
+
+	// IPv4 only never connects to IPv6 addresses
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	conn, err := connection.NewIRODSConnection(account, &connection.IRODSConnectionConfig{
+		ApplicationName: "go-irodsclient-test",
+		AddressFamily:   connection.AddressFamilyIPv4Only,
+	})
+	FailError(t, err)
+
+	err = conn.Connect()
+	assert.Error(t, err)
+
+	// unknown address family
+	_, err = connection.NewIRODSConnection(account, &connection.IRODSConnectionConfig{
+		ApplicationName: "go-irodsclient-test",
+		AddressFamily:   "ipv5",
+	})
+	assert.Error(t, err)
+}
+
+// startTestDNSServer starts a DNS server answering SRV records of _irods._tcp.<domain> pointing to the target and port,
+// and A records of the target resolved to 127.0.0.1, returns a resolver using the server
+func startTestDNSServer(t *testing.T, domain string, target string, port int) *net.Resolver {
+	socket, err := net.ListenPacket("udp", "127.0.0.1:0")
+	FailError(t, err)
+	t.Cleanup(func() {
+		_ = socket.Close()
+	})
+
+	srvName := fmt.Sprintf("_irods._tcp.%s.", domain)
+	targetName := dnsmessage.MustNewName(target + ".")
+
+	go func() {
+		buffer := make([]byte, 512)
+		for {
+			n, addr, err := socket.ReadFrom(buffer)
+			if err != nil {
+				return
+			}
+
+			var parser dnsmessage.Parser
+			header, err := parser.Start(buffer[:n])
+			if err != nil {
+				continue
+			}
+
+			question, err := parser.Question()
+			if err != nil {
+				continue
+			}
+
+			builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{
+				ID:            header.ID,
+				Response:      true,
+				Authoritative: true,
+			})
+			builder.EnableCompression()
+			_ = builder.StartQuestions()
+			_ = builder.Question(question)
+			_ = builder.StartAnswers()
+
+			name := strings.ToLower(question.Name.String())
+			answerHeader := dnsmessage.ResourceHeader{
+				Name:  question.Name,
+				Class: dnsmessage.ClassINET,
+				TTL:   60,
+			}
+
+			switch {
+			case question.Type == dnsmessage.TypeSRV && name == srvName:
+				_ = builder.SRVResource(answerHeader, dnsmessage.SRVResource{
+					Priority: 10,
+					Weight:   10,
+					Port:     uint16(port),
+					Target:   targetName,
+				})
+			case question.Type == dnsmessage.TypeA && name == targetName.String():
+				_ = builder.AResource(answerHeader, dnsmessage.AResource{
+					A: [4]byte{127, 0, 0, 1},
+				})
+			}
+
+			response, err := builder.Finish()
+			if err != nil {
+				continue
+			}
+
+			_, _ = socket.WriteTo(response, addr)
+		}
+	}()
+
+	dnsAddress := socket.LocalAddr().String()
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network string, address string) (net.Conn, error) {
+			dialer := net.Dialer{}
+			return dialer.DialContext(ctx, "udp", dnsAddress)
+		},
+	}
+}
+
+func testSRVDiscovery(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	port := testServer.GetPort()
+	resolver := startTestDNSServer(t, "irods.test", "provider.irods.test", port)
+
+	// discover the host from SRV records
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+	account.Host = ""
+	account.Port = 0
+	account.SRVDomain = "irods.test"
+
+	conn, err := connection.NewIRODSConnection(account, &connection.IRODSConnectionConfig{
+		ApplicationName: "go-irodsclient-test",
+		Resolver:        resolver,
+	})
+	FailError(t, err)
+
+	err = conn.Connect()
+	FailError(t, err)
+
+	assert.Equal(t, net.JoinHostPort("127.0.0.1", strconv.Itoa(port)), conn.GetServerAddress())
+	_ = conn.Disconnect()
+
+	// fall back to the host if no SRV record is found
+	account, err = testServer.GetAdminAccount()
+	FailError(t, err)
+	account.SRVDomain = "unknown.irods.test"
+
+	conn, err = connection.NewIRODSConnection(account, &connection.IRODSConnectionConfig{
+		ApplicationName: "go-irodsclient-test",
+		Resolver:        resolver,
+	})
+	FailError(t, err)
+
+	err = conn.Connect()
+	FailError(t, err)
+	_ = conn.Disconnect()
+
+	// neither SRV records nor the host
+	account.Host = ""
+	conn, err = connection.NewIRODSConnection(account, &connection.IRODSConnectionConfig{
+		ApplicationName: "go-irodsclient-test",
+		Resolver:        resolver,
+	})
+	FailError(t, err)
+
+	err = conn.Connect()
+	assert.Error(t, err)
+}
+
+func testValidateAccount(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	config := &connection.IRODSConnectionConfig{
+		ApplicationName: "go-irodsclient-test",
+	}
+
+	// valid
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	err = connection.ValidateAccount(account, config)
+	assert.NoError(t, err)
+
+	// bad password
+	account, err = testServer.GetAdminAccount()
+	FailError(t, err)
+	account.Password = "wrong_password"
+
+	err = connection.ValidateAccount(account, config)
+	assert.True(t, types.IsAccountValidationError(err))
+	assert.Equal(t, types.AccountValidationBadPassword, types.GetAccountValidationFailureReason(err))
+
+	// SSL negotiation failure, the server only allows TCP
+	account, err = testServer.GetAdminAccount()
+	FailError(t, err)
+	account.ClientServerNegotiation = true
+	account.CSNegotiationPolicy = types.CSNegotiationPolicyRequestSSL
+	account.SetEncryption(types.EncryptionAlgorithmAES256CBC, 32, 8, 16)
+
+	err = connection.ValidateAccount(account, config)
+	assert.Equal(t, types.AccountValidationSSLNegotiationFailed, types.GetAccountValidationFailureReason(err))
+
+	// invalid config
+	account, err = testServer.GetAdminAccount()
+	FailError(t, err)
+	account.ProxyUser = ""
+	account.ClientUser = ""
+
+	err = connection.ValidateAccount(account, config)
+	assert.Equal(t, types.AccountValidationInvalidConfig, types.GetAccountValidationFailureReason(err))
+
+	// unreachable
+	stoppedServer := testserver.NewTestServer(nil)
+	err = stoppedServer.Start()
+	FailError(t, err)
+
+	account, err = stoppedServer.GetAdminAccount()
+	FailError(t, err)
+	stoppedServer.Stop()
+
+	err = connection.ValidateAccount(account, config)
+	assert.Equal(t, types.AccountValidationUnreachable, types.GetAccountValidationFailureReason(err))
+}
@@ -1,10 +1,14 @@
 package testcases
 
 import (
+	"bytes"
+	"io"
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/system"
 	"github.com/cyverse/go-irodsclient/irods/types"
 	"github.com/cyverse/go-irodsclient/irods/util"
 	"github.com/stretchr/testify/assert"
@@ -21,6 +25,9 @@ func lowlevelFileTransferTest(t *testing.T, test *Test) {
 	t.Run("Upload", testUpload)
 	t.Run("ParallelUploadAndDownload", testParallelUploadAndDownload)
 	t.Run("ParallelUploadAndDownloadWithConnections", testParallelUploadAndDownloadWithConnections)
+	t.Run("PositionalReadWrite", testPositionalReadWrite)
+	t.Run("RepairDataObjectRanges", testRepairDataObjectRanges)
+	t.Run("DownloadPreallocate", testDownloadPreallocate)
 }
 
 func testUpload(t *testing.T) {
@@ -241,3 +248,248 @@ func testParallelUploadAndDownloadWithConnections(t *testing.T) {
 	err = fs.DeleteDataObject(conn, irodsPath, true)
 	FailError(t, err)
 }
+
+func testPositionalReadWrite(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	sess, err := server.GetSession()
+	FailError(t, err)
+	defer sess.Release()
+
+	conn, err := sess.AcquireConnection(true)
+	FailError(t, err)
+	defer func() {
+		_ = sess.ReturnConnection(conn)
+	}()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	irodsPath := homeDir + "/positional_read_write_test.bin"
+
+	numChunks := 16
+	chunkSize := 4096
+
+	// chunks have distinct content to detect interleaving
+	chunks := make([][]byte, numChunks)
+	for chunk := 0; chunk < numChunks; chunk++ {
+		chunks[chunk] = bytes.Repeat([]byte{byte('a' + chunk)}, chunkSize)
+	}
+
+	handle, err := fs.CreateDataObject(conn, irodsPath, "", "w+", true, nil)
+	FailError(t, err)
+
+	// goroutines share the connection and the handle
+	wg := sync.WaitGroup{}
+	for chunk := numChunks - 1; chunk >= 0; chunk-- {
+		wg.Add(1)
+		go func(chunk int) {
+			defer wg.Done()
+
+			err := fs.WriteAtDataObject(conn, handle, chunks[chunk], int64(chunk*chunkSize))
+			assert.NoError(t, err)
+		}(chunk)
+	}
+
+	wg.Wait()
+
+	err = fs.CloseDataObject(conn, handle)
+	FailError(t, err)
+
+	handle, _, err = fs.OpenDataObject(conn, irodsPath, "", "r", nil)
+	FailError(t, err)
+
+	for chunk := 0; chunk < numChunks; chunk++ {
+		wg.Add(1)
+		go func(chunk int) {
+			defer wg.Done()
+
+			buffer := make([]byte, chunkSize)
+			readLen, err := fs.ReadAtDataObject(conn, handle, buffer, int64(chunk*chunkSize))
+			assert.NoError(t, err)
+			assert.Equal(t, chunks[chunk], buffer[:readLen])
+		}(chunk)
+	}
+
+	wg.Wait()
+
+	// read beyond the end
+	buffer := make([]byte, chunkSize)
+	readLen, err := fs.ReadAtDataObject(conn, handle, buffer, int64((numChunks-1)*chunkSize+1))
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, chunkSize-1, readLen)
+
+	err = fs.CloseDataObject(conn, handle)
+	FailError(t, err)
+
+	err = fs.DeleteDataObject(conn, irodsPath, true)
+	FailError(t, err)
+}
+
+func testRepairDataObjectRanges(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	session, err := server.GetSession()
+	FailError(t, err)
+	defer session.Release()
+
+	conn, err := session.AcquireConnection(true)
+	FailError(t, err)
+	defer func() {
+		_ = session.ReturnConnection(conn)
+	}()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	// the last range is shorter than others
+	filename := "test_repair_file.bin"
+	fileSize := int64(10*1024*1024 + 1)
+
+	localPath, err := CreateLocalTestFile(t, filename, fileSize)
+	FailError(t, err)
+	defer func() {
+		err = os.Remove(localPath)
+		FailError(t, err)
+	}()
+
+	irodsPath := homeDir + "/" + filename
+
+	err = fs.UploadDataObjectParallel(session, localPath, irodsPath, "", 4, false, nil, nil)
+	FailError(t, err)
+
+	obj, err := fs.GetDataObject(conn, irodsPath)
+	FailError(t, err)
+
+	// download
+	newLocalPath := t.TempDir() + "/new_test_repair_file.bin"
+	rangeStatuses, err := fs.DownloadDataObjectParallelResumableWithRanges(session, obj, "", newLocalPath, 4, nil, nil)
+	FailError(t, err)
+
+	assert.Len(t, rangeStatuses, 4)
+	for _, rangeStatus := range rangeStatuses {
+		assert.NotEmpty(t, rangeStatus.Checksum)
+	}
+
+	_, err = os.Stat(fs.GetDataObjectTransferStatusFilePath(newLocalPath))
+	assert.True(t, os.IsNotExist(err))
+
+	// nothing to repair
+	repairedRanges, err := fs.RepairDataObjectRanges(session, obj, "", newLocalPath, rangeStatuses, nil, nil)
+	FailError(t, err)
+	assert.Empty(t, repairedRanges)
+
+	// corrupt a range
+	corruptedRange := rangeStatuses[2]
+
+	f, err := os.OpenFile(newLocalPath, os.O_WRONLY, 0)
+	FailError(t, err)
+	_, err = f.WriteAt([]byte("corrupted"), corruptedRange.StartOffset+100)
+	FailError(t, err)
+	err = f.Close()
+	FailError(t, err)
+
+	repairedBytes := int64(0)
+	transferCallBack := func(taskName string, current int64, total int64) {
+		if taskName == "download" {
+			repairedBytes = current
+		}
+	}
+
+	repairedRanges, err = fs.RepairDataObjectRanges(session, obj, "", newLocalPath, rangeStatuses, nil, transferCallBack)
+	FailError(t, err)
+	assert.Len(t, repairedRanges, 1)
+	assert.Equal(t, corruptedRange.StartOffset, repairedRanges[0].StartOffset)
+	// all ranges are read again to compare
+	assert.Equal(t, obj.Size, repairedBytes)
+
+	originalData, err := os.ReadFile(localPath)
+	FailError(t, err)
+
+	repairedData, err := os.ReadFile(newLocalPath)
+	FailError(t, err)
+	assert.True(t, bytes.Equal(originalData, repairedData))
+
+	// delete
+	err = fs.DeleteDataObject(conn, irodsPath, true)
+	FailError(t, err)
+}
+
+func testDownloadPreallocate(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	// preallocate sets the size
+	preallocPath := t.TempDir() + "/test_prealloc_file.bin"
+	f, err := os.Create(preallocPath)
+	FailError(t, err)
+
+	err = system.PreallocateFile(f, 1024*1024)
+	FailError(t, err)
+
+	err = f.Close()
+	FailError(t, err)
+
+	st, err := os.Stat(preallocPath)
+	FailError(t, err)
+	assert.Equal(t, int64(1024*1024), st.Size())
+
+	session, err := server.GetSession()
+	FailError(t, err)
+	defer session.Release()
+
+	conn, err := session.AcquireConnection(true)
+	FailError(t, err)
+	defer func() {
+		_ = session.ReturnConnection(conn)
+	}()
+
+	homeDir, err := test.GetTestHomeDir()
+	FailError(t, err)
+
+	filename := "test_prealloc_file.bin"
+	fileSize := int64(10 * 1024 * 1024)
+
+	localPath, err := CreateLocalTestFile(t, filename, fileSize)
+	FailError(t, err)
+	defer func() {
+		err = os.Remove(localPath)
+		FailError(t, err)
+	}()
+
+	irodsPath := homeDir + "/" + filename
+
+	err = fs.UploadDataObjectParallel(session, localPath, irodsPath, "", 4, false, nil, nil)
+	FailError(t, err)
+
+	obj, err := fs.GetDataObject(conn, irodsPath)
+	FailError(t, err)
+
+	// the existing file is larger than the data object, it must not have trailing data after download
+	newLocalPath := t.TempDir() + "/new_test_prealloc_file.bin"
+	err = os.WriteFile(newLocalPath, make([]byte, 2*fileSize), 0o644)
+	FailError(t, err)
+
+	err = fs.DownloadDataObjectParallelResumable(session, obj, "", newLocalPath, 4, nil, nil)
+	FailError(t, err)
+
+	originalData, err := os.ReadFile(localPath)
+	FailError(t, err)
+
+	newData, err := os.ReadFile(newLocalPath)
+	FailError(t, err)
+	assert.True(t, bytes.Equal(originalData, newData))
+
+	err = fs.DownloadDataObjectParallel(session, obj, "", newLocalPath, 4, nil, nil)
+	FailError(t, err)
+
+	newData, err = os.ReadFile(newLocalPath)
+	FailError(t, err)
+	assert.True(t, bytes.Equal(originalData, newData))
+
+	// delete
+	err = fs.DeleteDataObject(conn, irodsPath, true)
+	FailError(t, err)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/cyverse/go-irodsclient/irods/connection"
 	"github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/session"
 	"github.com/cyverse/go-irodsclient/irods/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -20,6 +21,7 @@ func lowlevelSessionTest(t *testing.T, test *Test) {
 	t.Run("Session", testSession)
 	t.Run("testMaxConnectionsShared", testMaxConnectionsShared)
 	t.Run("testMaxConnectionsNotShared", testMaxConnectionsNotShared)
+	t.Run("testMaxConnectionsStrict", testMaxConnectionsStrict)
 	t.Run("ConnectionMetrics", testConnectionMetrics)
 }
 
@@ -125,6 +127,53 @@ func testMaxConnectionsNotShared(t *testing.T) {
 	}
 }
 
+func testMaxConnectionsStrict(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	sess, err := server.GetSession()
+	FailError(t, err)
+	defer sess.Release()
+
+	config := sess.GetConfig()
+
+	// more than max
+	connections, err := sess.AcquireConnectionsMultiWithOptions(config.ConnectionMaxNumber+5, &session.AcquireConnectionsMultiOptions{
+		AllowShared: true,
+		Strict:      true,
+	})
+	assert.Error(t, err)
+	assert.True(t, types.IsNotEnoughConnectionsError(err))
+	assert.Empty(t, connections)
+
+	// exactly max
+	connections, err = sess.AcquireConnectionsMultiWithOptions(config.ConnectionMaxNumber, &session.AcquireConnectionsMultiOptions{
+		Strict: true,
+	})
+	FailError(t, err)
+	assert.Equal(t, config.ConnectionMaxNumber, len(connections))
+
+	connMap := map[*connection.IRODSConnection]bool{}
+	for _, conn := range connections {
+		assert.True(t, conn.IsConnected())
+		connMap[conn] = true
+	}
+	assert.Equal(t, config.ConnectionMaxNumber, len(connMap))
+
+	// all in use
+	moreConnections, err := sess.AcquireConnectionsMultiWithOptions(1, &session.AcquireConnectionsMultiOptions{
+		Strict: true,
+	})
+	assert.Error(t, err)
+	assert.True(t, types.IsNotEnoughConnectionsError(err))
+	assert.Empty(t, moreConnections)
+
+	for _, conn := range connections {
+		err = sess.ReturnConnection(conn)
+		FailError(t, err)
+	}
+}
+
 func testConnectionMetrics(t *testing.T) {
 	test := GetCurrentTest()
 	server := test.GetCurrentServer()
@@ -23,6 +23,7 @@ func lowlevelUserTest(t *testing.T, test *Test) {
 	t.Run("CreateUserWithSpecialCharacterPasswords", testCreateUserWithSpecialCharacterPasswords)
 	t.Run("ListUsersByType", testListUsersByType)
 	t.Run("AddAndRemoveGroupMembers", testAddAndRemoveGroupMembers)
+	t.Run("GeneralAdmin", testGeneralAdmin)
 }
 
 func testCreateAndRemoveUser(t *testing.T) {
@@ -388,3 +389,44 @@ func testListUsersByType(t *testing.T) {
 	}
 	assert.True(t, found)
 }
+
+func testGeneralAdmin(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	session, err := server.GetSession()
+	FailError(t, err)
+	defer session.Release()
+
+	conn, err := session.AcquireConnection(true)
+	FailError(t, err)
+	defer func() {
+		_ = session.ReturnConnection(conn)
+	}()
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	testUsername := "testgeneraladminuser1"
+
+	// create
+	err = fs.GeneralAdmin(conn, types.IRODSAdminActionAdd, types.IRODSAdminTargetUser, fmt.Sprintf("%s#%s", testUsername, account.ClientZone), string(types.IRODSUserRodsUser), account.ClientZone)
+	FailError(t, err)
+
+	myUser, err := fs.GetUser(conn, testUsername, account.ClientZone)
+	FailError(t, err)
+
+	assert.Equal(t, testUsername, myUser.Name)
+	assert.Equal(t, types.IRODSUserRodsUser, myUser.Type)
+
+	// too many params
+	err = fs.GeneralAdmin(conn, types.IRODSAdminActionModify, types.IRODSAdminTargetUser, "1", "2", "3", "4", "5", "6", "7", "8", "9")
+	assert.Error(t, err)
+
+	// delete
+	err = fs.GeneralAdmin(conn, types.IRODSAdminActionRemove, types.IRODSAdminTargetUser, testUsername, account.ClientZone)
+	FailError(t, err)
+
+	_, err = fs.GetUser(conn, testUsername, account.ClientZone)
+	assert.True(t, types.IsUserNotFoundError(err))
+}
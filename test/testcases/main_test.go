@@ -147,6 +147,10 @@ func TestLocalMain(t *testing.T) {
 	tests = append(tests, getUtilErrorTest())
 	tests = append(tests, getUtilEnvironmentTest())
 	tests = append(tests, getUtilPasswordObfuscationTest())
+	tests = append(tests, getUtilTestServerTest())
+	tests = append(tests, getUtilFaultInjectionTest())
+	tests = append(tests, getUtilPathTest())
+	tests = append(tests, getUtilChecksumTest())
 	tests = append(tests, getLowlevelConnectionTest())
 	tests = append(tests, getLowlevelSessionTest())
 	tests = append(tests, getLowlevelProcessTest())
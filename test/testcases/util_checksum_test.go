@@ -0,0 +1,114 @@
+package testcases
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/go-irodsclient/irods/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func getUtilChecksumTest() Test {
+	return Test{
+		Name: "Util_Checksum",
+		Func: utilChecksumTest,
+	}
+}
+
+func utilChecksumTest(t *testing.T, test *Test) {
+	t.Run("ChecksumString", testChecksumString)
+	t.Run("RegisterChecksumAlgorithm", testRegisterChecksumAlgorithm)
+}
+
+func testChecksumString(t *testing.T) {
+	data := bytes.NewBufferString("hello iRODS")
+
+	checksumStrings := map[types.ChecksumAlgorithm]string{
+		types.ChecksumAlgorithmMD5:     "",
+		types.ChecksumAlgorithmSHA1:    "sha1:",
+		types.ChecksumAlgorithmSHA256:  "sha2:",
+		types.ChecksumAlgorithmSHA512:  "sha512:",
+		types.ChecksumAlgorithmADLER32: "adler32:",
+	}
+
+	for algorithm, prefix := range checksumStrings {
+		digest, err := util.HashBuffer(bytes.NewBuffer(data.Bytes()), string(algorithm), nil)
+		FailError(t, err)
+		assert.Equal(t, types.GetChecksumDigestSize(algorithm), len(digest))
+
+		checksumString, err := types.MakeIRODSChecksumString(algorithm, digest)
+		FailError(t, err)
+		assert.Contains(t, checksumString, prefix)
+
+		parsedAlgorithm, parsedDigest, err := types.ParseIRODSChecksumString(checksumString)
+		FailError(t, err)
+		assert.Equal(t, algorithm, parsedAlgorithm)
+		assert.Equal(t, digest, parsedDigest)
+	}
+
+	// sha2 prefix is shared by SHA-256 and SHA-512, distinguished by digest size
+	sha512Digest, err := util.HashBuffer(bytes.NewBuffer(data.Bytes()), string(types.ChecksumAlgorithmSHA512), nil)
+	FailError(t, err)
+
+	sha512String, err := types.MakeIRODSChecksumString(types.ChecksumAlgorithmSHA512, sha512Digest)
+	FailError(t, err)
+
+	algorithm, _, err := types.ParseIRODSChecksumString("sha2:" + sha512String[len("sha512:"):])
+	FailError(t, err)
+	assert.Equal(t, types.ChecksumAlgorithmSHA512, algorithm)
+
+	assert.Equal(t, types.ChecksumAlgorithmSHA256, types.GetChecksumAlgorithm("sha256"))
+	assert.Equal(t, types.ChecksumAlgorithmSHA256, types.GetChecksumAlgorithm("SHA-256"))
+	assert.Equal(t, types.ChecksumAlgorithmUnknown, types.GetChecksumAlgorithm("crc32"))
+
+	_, _, err = types.ParseIRODSChecksumString("crc32:00000000")
+	assert.Error(t, err)
+
+	_, _, err = types.ParseIRODSChecksumString("sha2:AAAA")
+	assert.Error(t, err)
+}
+
+func testRegisterChecksumAlgorithm(t *testing.T) {
+	algorithmSHA224 := types.ChecksumAlgorithm("SHA-224")
+
+	err := types.RegisterChecksumAlgorithm(&types.ChecksumAlgorithmDefinition{
+		Algorithm: algorithmSHA224,
+	})
+	assert.Error(t, err)
+
+	err = types.RegisterChecksumAlgorithm(&types.ChecksumAlgorithmDefinition{
+		Algorithm:  algorithmSHA224,
+		Aliases:    []string{"sha224"},
+		Prefixes:   []string{"sha224"},
+		DigestSize: sha256.Size224,
+		Base64:     true,
+		NewHash:    sha256.New224,
+	})
+	FailError(t, err)
+	defer types.UnregisterChecksumAlgorithm(algorithmSHA224)
+
+	assert.Contains(t, types.GetRegisteredChecksumAlgorithms(), algorithmSHA224)
+	assert.Equal(t, algorithmSHA224, types.GetChecksumAlgorithm("sha224"))
+
+	digest, err := util.HashStrings([]string{"hello", "iRODS"}, "sha224")
+	FailError(t, err)
+
+	expected := sha256.Sum224([]byte("helloiRODS"))
+	assert.Equal(t, expected[:], digest)
+
+	checksumString, err := types.MakeIRODSChecksumString(algorithmSHA224, digest)
+	FailError(t, err)
+
+	algorithm, parsedDigest, err := types.ParseIRODSChecksumString(checksumString)
+	FailError(t, err)
+	assert.Equal(t, algorithmSHA224, algorithm)
+	assert.Equal(t, digest, parsedDigest)
+
+	types.UnregisterChecksumAlgorithm(algorithmSHA224)
+	assert.Equal(t, types.ChecksumAlgorithmUnknown, types.GetChecksumAlgorithm("sha224"))
+
+	_, _, err = types.ParseIRODSChecksumString(checksumString)
+	assert.Error(t, err)
+}
@@ -18,6 +18,8 @@ func getUtilEncodingTest() Test {
 func utilEncodingTest(t *testing.T, test *Test) {
 	t.Run("EncoderRing", testEncoderRing)
 	t.Run("Scramble", testScramble)
+	t.Run("Unscramble", testUnscramble)
+	t.Run("ObfuscateNewPassword", testObfuscateNewPassword)
 	t.Run("ClientSignature", testClientSignature)
 }
 
@@ -35,6 +37,29 @@ func testScramble(t *testing.T) {
 	assert.Equal(t, ";E3O&GDl4!&_$3GBd+B\"", scrPass2)
 }
 
+func testUnscramble(t *testing.T) {
+	pass1 := irods_util.Unscramble(";EBo$tJuoAY_RigHonj-", "06fed401fb79f864272a421835486736", "", false)
+	assert.Equal(t, ";.ObfV2test_password", pass1)
+
+	pass2 := irods_util.Unscramble(";E3O&GDl4!&_$3GBd+B\"", "06fed401fb79f864272a421835486736", "", true)
+	assert.Equal(t, ";.ObfV2test_password", pass2)
+}
+
+func testObfuscateNewPassword(t *testing.T) {
+	signature := "0123456789abcdef0123456789abcdef"
+
+	for _, newPassword := range []string{"new_password", "a", "long_password_0123456789_0123456789"} {
+		obfuscated := irods_util.ObfuscateNewPassword(newPassword, "old_password", signature)
+
+		deobfuscated, err := irods_util.DeobfuscateNewPassword(obfuscated, "old_password", signature)
+		FailError(t, err)
+		assert.Equal(t, newPassword, deobfuscated)
+
+		_, err = irods_util.DeobfuscateNewPassword(obfuscated, "wrong_password", signature)
+		assert.Error(t, err)
+	}
+}
+
 func testClientSignature(t *testing.T) {
 	test := GetCurrentTest()
 	server := test.GetCurrentServer()
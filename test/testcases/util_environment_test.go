@@ -20,6 +20,7 @@ func utilEnvironmentTest(t *testing.T, test *Test) {
 	t.Run("SaveAndLoadEnvironment", testSaveAndLoadEnvironment)
 	t.Run("SaveAndLoadSession", testSaveAndLoadSession)
 	t.Run("LoadFilePaths", testLoadFilePaths)
+	t.Run("EnvironmentVariableOverrides", testEnvironmentVariableOverrides)
 }
 
 func testSaveAndLoadEnvironment(t *testing.T) {
@@ -154,3 +155,69 @@ func testLoadFilePaths(t *testing.T) {
 	assert.Equal(t, envMgr.Environment.AuthenticationFile, envMgr2.Environment.AuthenticationFile)
 	assert.Equal(t, account.Password, envMgr2.Environment.Password)
 }
+
+func testEnvironmentVariableOverrides(t *testing.T) {
+	test := GetCurrentTest()
+	server := test.GetCurrentServer()
+
+	account, err := server.GetAccount()
+	FailError(t, err)
+
+	// save
+	envMgr, err := config.NewICommandsEnvironmentManager()
+	FailError(t, err)
+
+	envMgr.FromIRODSAccount(account)
+
+	tempPath := t.TempDir()
+	envFilePath := filepath.Join(tempPath, "irods_environment.json")
+
+	err = envMgr.SetEnvironmentFilePath(envFilePath)
+	FailError(t, err)
+
+	err = envMgr.SaveEnvironment()
+	FailError(t, err)
+
+	// password file at other path
+	passwordFilePath := filepath.Join(tempPath, "other_irodsA")
+	err = config.WritePasswordFile(passwordFilePath, "other_password")
+	FailError(t, err)
+
+	t.Setenv("IRODS_ENVIRONMENT_FILE", envFilePath)
+	t.Setenv("IRODS_HOST", "other.host.com")
+	t.Setenv("IRODS_PORT", "2247")
+	t.Setenv("IRODS_USER_NAME", "other_user")
+	t.Setenv("IRODS_AUTHENTICATION_FILE", passwordFilePath)
+
+	// load
+	envMgr2, err := config.NewICommandsEnvironmentManager()
+	FailError(t, err)
+	assert.Equal(t, envFilePath, envMgr2.EnvironmentFilePath)
+
+	err = envMgr2.Load()
+	FailError(t, err)
+
+	env2 := envMgr2.Environment
+	assert.Equal(t, "other.host.com", env2.Host)
+	assert.Equal(t, 2247, env2.Port)
+	assert.Equal(t, "other_user", env2.Username)
+	assert.Equal(t, account.ClientZone, env2.ZoneName)
+	assert.Equal(t, passwordFilePath, envMgr2.PasswordFilePath)
+	assert.Equal(t, "other_password", env2.Password)
+
+	// password given by environmental variable takes precedence over the password file
+	t.Setenv("IRODS_USER_PASSWORD", "env_password")
+
+	envMgr3, err := config.NewICommandsEnvironmentManager()
+	FailError(t, err)
+
+	err = envMgr3.Load()
+	FailError(t, err)
+
+	assert.Equal(t, "env_password", envMgr3.Environment.Password)
+
+	account3, err := envMgr3.ToIRODSAccount()
+	FailError(t, err)
+	assert.Equal(t, "other.host.com", account3.Host)
+	assert.Equal(t, "other_user", account3.ProxyUser)
+}
@@ -3,7 +3,9 @@ package testcases
 import (
 	"testing"
 
+	"github.com/cockroachdb/errors"
 	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -16,6 +18,8 @@ func getUtilErrorTest() Test {
 
 func utilErrorTest(t *testing.T, test *Test) {
 	t.Run("ErrorCode", testErrorCode)
+	t.Run("TypedError", testTypedError)
+	t.Run("OperationError", testOperationError)
 }
 
 func testErrorCode(t *testing.T) {
@@ -42,3 +46,60 @@ func testErrorCode(t *testing.T) {
 	assert.Contains(t, errstr, "I/O error")
 
 }
+
+func testTypedError(t *testing.T) {
+	errcode := common.ErrorCode(int(common.CAT_NO_ACCESS_PERMISSION) - int(common.EACCES))
+	err := errors.Wrapf(types.NewIRODSError(errcode), "failed to open data object")
+
+	// test sentinel, sub code is ignored
+	assert.True(t, errors.Is(err, types.ErrNoAccessPermission))
+	assert.False(t, errors.Is(err, types.ErrPasswordExpired))
+	assert.True(t, errors.Is(err, types.NewIRODSError(common.CAT_NO_ACCESS_PERMISSION)))
+
+	// test any iRODS error
+	assert.True(t, errors.Is(err, &types.IRODSError{}))
+	assert.True(t, types.IsIRODSError(err))
+
+	// test category
+	assert.True(t, errors.Is(err, types.ErrPermissionDenied))
+	assert.False(t, errors.Is(err, types.ErrResourceHierarchy))
+
+	hierErr := types.NewIRODSError(common.CHILD_NOT_FOUND)
+	assert.True(t, errors.Is(hierErr, types.ErrResourceHierarchy))
+
+	// test sentinels
+	assert.True(t, errors.Is(hierErr, types.ErrChildNotFound))
+	assert.True(t, errors.Is(types.NewIRODSError(common.SYS_RESC_QUOTA_EXCEEDED), types.ErrQuotaExceeded))
+	assert.Same(t, types.ErrNoAccessPermission, types.GetIRODSErrorSentinel(errcode))
+	assert.Same(t, types.ErrNoAPIPrivilege, types.GetIRODSErrorSentinel(common.SYS_NO_API_PRIV))
+	assert.Nil(t, types.GetIRODSErrorSentinel(common.ErrorCode(-1)))
+
+	// error codes without hand-picked sentinels are found in the generated table
+	headerErr := types.NewIRODSError(common.SYS_HEADER_TPYE_LEN_ERR)
+	assert.NotNil(t, types.GetIRODSErrorSentinel(common.SYS_HEADER_TPYE_LEN_ERR))
+	assert.True(t, errors.Is(headerErr, types.GetIRODSErrorSentinel(common.SYS_HEADER_TPYE_LEN_ERR)))
+
+	// test interface
+	var codedErr types.IRODSCodedError
+	assert.True(t, errors.As(err, &codedErr))
+	assert.Equal(t, errcode, codedErr.GetCode())
+}
+
+func testOperationError(t *testing.T) {
+	irodsErr := types.NewIRODSError(common.CAT_NO_ACCESS_PERMISSION)
+	err := errors.Wrapf(types.NewOperationError("RemoveDataObject", "/zone/home/user/file.txt", "demoResc", common.DATA_OBJ_UNLINK_AN, irodsErr), "failed to delete data object")
+
+	assert.True(t, types.IsOperationError(err))
+	assert.True(t, errors.Is(err, types.ErrNoAccessPermission))
+	assert.Equal(t, common.CAT_NO_ACCESS_PERMISSION, types.GetIRODSErrorCode(err))
+
+	opErr := types.GetOperationError(err)
+	assert.NotNil(t, opErr)
+	assert.Equal(t, "RemoveDataObject", opErr.Operation)
+	assert.Equal(t, "/zone/home/user/file.txt", opErr.Path)
+	assert.Equal(t, "demoResc", opErr.Resource)
+	assert.Equal(t, common.DATA_OBJ_UNLINK_AN, opErr.APINumber)
+	assert.Contains(t, err.Error(), "/zone/home/user/file.txt")
+
+	assert.Nil(t, types.GetOperationError(irodsErr))
+}
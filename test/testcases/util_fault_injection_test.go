@@ -0,0 +1,531 @@
+package testcases
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	"github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/metrics"
+	"github.com/cyverse/go-irodsclient/irods/session"
+	"github.com/cyverse/go-irodsclient/irods/testserver"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func getUtilFaultInjectionTest() Test {
+	return Test{
+		Name: "Util_FaultInjection",
+		Func: utilFaultInjectionTest,
+	}
+}
+
+func utilFaultInjectionTest(t *testing.T, test *Test) {
+	t.Run("DropConnection", testFaultInjectionDropConnection)
+	t.Run("DelayResponse", testFaultInjectionDelayResponse)
+	t.Run("CorruptData", testFaultInjectionCorruptData)
+	t.Run("MaxFaultyConnections", testFaultInjectionMaxFaultyConnections)
+	t.Run("ParallelUploadRetry", testFaultInjectionParallelUploadRetry)
+	t.Run("UnlimitedRetry", testFaultInjectionUnlimitedRetry)
+	t.Run("IdempotentOperationRetry", testFaultInjectionIdempotentOperationRetry)
+	t.Run("ControlKeepAlive", testFaultInjectionControlKeepAlive)
+	t.Run("RepairCorruptedDownload", testFaultInjectionRepairCorruptedDownload)
+	t.Run("PoolValidationPing", testFaultInjectionPoolValidationPing)
+	t.Run("PoolLimitBackoff", testFaultInjectionPoolLimitBackoff)
+}
+
+func connectWithFaultInjection(t *testing.T, testServer *testserver.TestServer, policy *connection.FaultInjectionPolicy) (*connection.IRODSConnection, error) {
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	conn, err := connection.NewIRODSConnection(account, &connection.IRODSConnectionConfig{
+		ApplicationName:      "go-irodsclient-test",
+		RetryPolicy:          connection.NewNoRetryPolicy(),
+		FaultInjectionPolicy: policy,
+	})
+	FailError(t, err)
+
+	return conn, conn.Connect()
+}
+
+func testFaultInjectionDropConnection(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	// drop while sending the startup pack
+	_, err = connectWithFaultInjection(t, testServer, &connection.FaultInjectionPolicy{
+		DropAfterSentBytes: 10,
+	})
+	assert.Error(t, err)
+	assert.True(t, types.IsConnectionError(err))
+
+	// drop while receiving the version
+	_, err = connectWithFaultInjection(t, testServer, &connection.FaultInjectionPolicy{
+		DropAfterReceivedBytes: 10,
+	})
+	assert.Error(t, err)
+	assert.True(t, types.IsConnectionError(err))
+}
+
+func testFaultInjectionDelayResponse(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	delay := 50 * time.Millisecond
+
+	startTime := time.Now()
+	conn, err := connectWithFaultInjection(t, testServer, &connection.FaultInjectionPolicy{
+		ResponseDelay: delay,
+	})
+	FailError(t, err)
+	defer conn.Disconnect()
+
+	assert.GreaterOrEqual(t, time.Since(startTime), delay)
+}
+
+func testFaultInjectionCorruptData(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	// corrupt the header of the first response, following the 4 bytes header length
+	_, err = connectWithFaultInjection(t, testServer, &connection.FaultInjectionPolicy{
+		CorruptOffset: 4,
+		CorruptLength: 8,
+	})
+	assert.Error(t, err)
+}
+
+func testFaultInjectionMaxFaultyConnections(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	policy := &connection.FaultInjectionPolicy{
+		DropAfterReceivedBytes: 10,
+		MaxFaultyConnections:   1,
+	}
+
+	_, err = connectWithFaultInjection(t, testServer, policy)
+	assert.Error(t, err)
+	assert.Equal(t, 1, policy.GetFaultyConnections())
+
+	// only the first connection fails
+	conn, err := connectWithFaultInjection(t, testServer, policy)
+	FailError(t, err)
+	defer conn.Disconnect()
+
+	policy.Reset()
+
+	_, err = connectWithFaultInjection(t, testServer, policy)
+	assert.Error(t, err)
+}
+
+func testFaultInjectionUnlimitedRetry(t *testing.T) {
+	failures := 2 * connection.RetryMaxAttemptsDefault
+
+	attempts := 0
+	failingOperation := func(attempt int) error {
+		attempts++
+		if attempt < failures {
+			return types.NewConnectionError()
+		}
+		return nil
+	}
+
+	// default policy gives up
+	policy := connection.NewDefaultRetryPolicy()
+	policy.InitialBackoff = 0
+
+	err := policy.Run(failingOperation)
+	assert.Error(t, err)
+	assert.True(t, types.IsConnectionError(err))
+	assert.Equal(t, connection.RetryMaxAttemptsDefault, attempts)
+
+	// data transfers retry without limit by default
+	attempts = 0
+	err = policy.GetTransferPolicy().Run(failingOperation)
+	assert.NoError(t, err)
+	assert.Equal(t, failures+1, attempts)
+	assert.Equal(t, connection.RetryMaxAttemptsDefault, policy.GetMaxAttempts())
+	assert.False(t, connection.NewNoRetryPolicy().GetTransferPolicy().IsUnlimited())
+
+	// 0 retries without limit
+	attempts = 0
+	policy.MaxAttempts = connection.RetryMaxAttemptsUnlimited
+	assert.NoError(t, policy.Validate())
+
+	err = policy.Run(failingOperation)
+	assert.NoError(t, err)
+	assert.Equal(t, failures+1, attempts)
+
+	// non-retryable errors are not retried
+	attempts = 0
+	err = policy.Run(func(attempt int) error {
+		attempts++
+		return types.NewFileNotFoundError("/nowhere")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func testFaultInjectionIdempotentOperationRetry(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	retryPolicy := &connection.RetryPolicy{
+		MaxAttempts: 2,
+	}
+
+	sess, err := session.NewIRODSSession(account, &session.IRODSSessionConfig{
+		ApplicationName: "go-irodsclient-test",
+		RetryPolicy:     retryPolicy,
+	})
+	FailError(t, err)
+	defer sess.Release()
+
+	// the session and its connections share the policy
+	assert.Same(t, retryPolicy, sess.GetRetryPolicy())
+
+	conn, err := sess.AcquireConnection(true)
+	FailError(t, err)
+	assert.Same(t, retryPolicy, conn.GetRetryPolicy())
+	sess.ReturnConnection(conn)
+
+	// replayed once on a fresh connection
+	conns := []*connection.IRODSConnection{}
+	err = sess.RunIdempotentOperation(true, func(conn *connection.IRODSConnection) error {
+		conns = append(conns, conn)
+		if len(conns) == 1 {
+			conn.Disconnect()
+			return types.NewConnectionError()
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(conns))
+	assert.NotSame(t, conns[0], conns[1])
+
+	// default policy is used if not set
+	defaultSess, err := session.NewIRODSSession(account, &session.IRODSSessionConfig{
+		ApplicationName: "go-irodsclient-test",
+	})
+	FailError(t, err)
+	defer defaultSess.Release()
+
+	assert.Equal(t, connection.RetryMaxAttemptsDefault, defaultSess.GetRetryPolicy().GetMaxAttempts())
+}
+
+func testFaultInjectionParallelUploadRetry(t *testing.T) {
+	// parallel upload with replica tokens requires 4.2.9 or higher
+	config := testserver.NewDefaultTestServerConfig()
+	config.ReleaseVersion = "4.2.9"
+
+	testServer := testserver.NewTestServer(config)
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	controlConn, err := connectWithFaultInjection(t, testServer, nil)
+	FailError(t, err)
+	defer controlConn.Disconnect()
+
+	// drop one of transfer connections in the middle of its task
+	policy := &connection.FaultInjectionPolicy{
+		DropAfterSentBytes:   3 * 1024 * 1024,
+		MaxFaultyConnections: 1,
+	}
+
+	conns := []*connection.IRODSConnection{controlConn}
+	for i := 0; i < 2; i++ {
+		conn, err := connection.NewIRODSConnection(account, &connection.IRODSConnectionConfig{
+			ApplicationName:      "go-irodsclient-test",
+			RetryPolicy:          connection.NewDefaultRetryPolicy(),
+			FaultInjectionPolicy: policy,
+		})
+		FailError(t, err)
+
+		err = conn.Connect()
+		FailError(t, err)
+		defer conn.Disconnect()
+
+		conns = append(conns, conn)
+	}
+
+	data := make([]byte, 10*1024*1024)
+	_, err = rand.Read(data)
+	FailError(t, err)
+
+	localPath := filepath.Join(t.TempDir(), "test_retry_file.bin")
+	err = os.WriteFile(localPath, data, 0o644)
+	FailError(t, err)
+
+	irodsPath := fmt.Sprintf("/%s/home/%s/test_retry_file.bin", account.ClientZone, account.ClientUser)
+
+	stats := &fs.DataObjectTransferStats{}
+	err = fs.UploadDataObjectParallelWithConnectionsAndStats(conns, localPath, irodsPath, "", false, nil, nil, stats)
+	FailError(t, err)
+	assert.Equal(t, 1, policy.GetFaultyConnections())
+	assert.Equal(t, 2, stats.GetTaskNum())
+	assert.Equal(t, 1, stats.GetRetries())
+
+	obj, err := fs.GetDataObject(controlConn, irodsPath)
+	FailError(t, err)
+	assert.Equal(t, int64(len(data)), obj.Size)
+
+	newLocalPath := filepath.Join(t.TempDir(), "new_test_retry_file.bin")
+	err = fs.DownloadDataObjectWithConnection(controlConn, obj, "", newLocalPath, nil, nil)
+	FailError(t, err)
+
+	newData, err := os.ReadFile(newLocalPath)
+	FailError(t, err)
+	assert.True(t, bytes.Equal(data, newData))
+}
+
+func testFaultInjectionControlKeepAlive(t *testing.T) {
+	// parallel upload with replica tokens requires 4.2.9 or higher
+	config := testserver.NewDefaultTestServerConfig()
+	config.ReleaseVersion = "4.2.9"
+
+	testServer := testserver.NewTestServer(config)
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	controlMetrics := &metrics.IRODSMetrics{}
+	controlConn, err := connection.NewIRODSConnection(account, &connection.IRODSConnectionConfig{
+		ApplicationName:          "go-irodsclient-test",
+		ControlKeepAliveInterval: 10 * time.Millisecond,
+		Metrics:                  controlMetrics,
+	})
+	FailError(t, err)
+
+	err = controlConn.Connect()
+	FailError(t, err)
+	defer controlConn.Disconnect()
+
+	// slow transfer connections, so the control connection stays idle for a while
+	policy := &connection.FaultInjectionPolicy{
+		ResponseDelay: 50 * time.Millisecond,
+	}
+
+	conns := []*connection.IRODSConnection{controlConn}
+	for i := 0; i < 2; i++ {
+		conn, err := connection.NewIRODSConnection(account, &connection.IRODSConnectionConfig{
+			ApplicationName:      "go-irodsclient-test",
+			FaultInjectionPolicy: policy,
+		})
+		FailError(t, err)
+
+		err = conn.Connect()
+		FailError(t, err)
+		defer conn.Disconnect()
+
+		conns = append(conns, conn)
+	}
+
+	data := make([]byte, 2*1024*1024)
+	_, err = rand.Read(data)
+	FailError(t, err)
+
+	localPath := filepath.Join(t.TempDir(), "test_keepalive_file.bin")
+	err = os.WriteFile(localPath, data, 0o644)
+	FailError(t, err)
+
+	irodsPath := fmt.Sprintf("/%s/home/%s/test_keepalive_file.bin", account.ClientZone, account.ClientUser)
+
+	controlMetrics.GetAndClearOperationMetrics()
+
+	err = fs.UploadDataObjectParallelWithConnections(conns, localPath, irodsPath, "", false, nil, nil)
+	FailError(t, err)
+
+	// pings are counted as other operations
+	snapshot := controlMetrics.GetOperationMetrics()
+	assert.Greater(t, snapshot.Get(metrics.OperationOther).GetCount(), uint64(0))
+	assert.Equal(t, uint64(0), snapshot.Get(metrics.OperationOther).Failures)
+
+	obj, err := fs.GetDataObject(controlConn, irodsPath)
+	FailError(t, err)
+	assert.Equal(t, int64(len(data)), obj.Size)
+}
+
+func testFaultInjectionRepairCorruptedDownload(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	conn, err := connectWithFaultInjection(t, testServer, nil)
+	FailError(t, err)
+	defer conn.Disconnect()
+
+	data := make([]byte, 3*1024*1024)
+	_, err = rand.Read(data)
+	FailError(t, err)
+
+	localPath := filepath.Join(t.TempDir(), "test_repair_file.bin")
+	err = os.WriteFile(localPath, data, 0o644)
+	FailError(t, err)
+
+	irodsPath := fmt.Sprintf("/%s/home/%s/test_repair_file.bin", account.ClientZone, account.ClientUser)
+
+	err = fs.UploadDataObjectWithConnection(conn, localPath, irodsPath, "", false, nil, nil)
+	FailError(t, err)
+
+	obj, err := fs.GetDataObject(conn, irodsPath)
+	FailError(t, err)
+
+	// corrupt data in transit, hashes of ranges computed while downloading match the corrupted data
+	faultyConn, err := connectWithFaultInjection(t, testServer, &connection.FaultInjectionPolicy{
+		CorruptOffset: 2 * 1024 * 1024,
+		CorruptLength: 16,
+	})
+	FailError(t, err)
+	defer faultyConn.Disconnect()
+
+	newLocalPath := filepath.Join(t.TempDir(), "new_test_repair_file.bin")
+	rangeStatuses, err := fs.DownloadDataObjectParallelResumableWithConnectionsAndRanges([]*connection.IRODSConnection{faultyConn}, obj, "", newLocalPath, nil, nil)
+	FailError(t, err)
+
+	newData, err := os.ReadFile(newLocalPath)
+	FailError(t, err)
+	assert.False(t, bytes.Equal(data, newData))
+
+	// ranges are compared with the data object
+	repairedRanges, err := fs.RepairDataObjectRangesWithConnection(conn, obj, "", newLocalPath, rangeStatuses, nil, nil)
+	FailError(t, err)
+	assert.Len(t, repairedRanges, 1)
+
+	newData, err = os.ReadFile(newLocalPath)
+	FailError(t, err)
+	assert.True(t, bytes.Equal(data, newData))
+
+	// nothing to repair, the whole data object is compared without ranges
+	repairedRanges, err = fs.RepairDataObjectRangesWithConnection(conn, obj, "", newLocalPath, nil, nil, nil)
+	FailError(t, err)
+	assert.Empty(t, repairedRanges)
+}
+
+func testFaultInjectionPoolValidationPing(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	delay := 100 * time.Millisecond
+	pool, err := session.NewConnectionPool(account, &session.ConnectionPoolConfig{
+		ApplicationName:         "go-irodsclient-test",
+		MaxCap:                  2,
+		MaxIdle:                 2,
+		LazyInit:                true,
+		ValidationMode:          session.ConnectionValidationPing,
+		ValidationIdleThreshold: time.Millisecond,
+		FaultInjectionPolicy: &connection.FaultInjectionPolicy{
+			ResponseDelay: delay,
+		},
+	})
+	FailError(t, err)
+	defer pool.Release()
+
+	conn, _, err := pool.Get(false, false, false)
+	FailError(t, err)
+
+	err = pool.Return(conn)
+	FailError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+
+	// checkout pings the idle connection
+	getDone := make(chan error, 1)
+	go func() {
+		pingedConn, isNew, getErr := pool.Get(false, false, false)
+		if getErr == nil {
+			assert.False(t, isNew)
+			assert.Same(t, conn, pingedConn)
+		}
+		getDone <- getErr
+	}()
+
+	// the pool is not locked while pinging
+	time.Sleep(delay / 2)
+	startTime := time.Now()
+	assert.Equal(t, 1, pool.GetOccupiedConnections())
+	assert.Less(t, time.Since(startTime), delay/2)
+
+	err = <-getDone
+	FailError(t, err)
+}
+
+func testFaultInjectionPoolLimitBackoff(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	pool, err := session.NewConnectionPool(account, &session.ConnectionPoolConfig{
+		ApplicationName:     "go-irodsclient-test",
+		MaxCap:              3,
+		MaxIdle:             3,
+		LazyInit:            true,
+		MaintenanceInterval: 10 * time.Millisecond,
+	})
+	FailError(t, err)
+	defer pool.Release()
+
+	resets := int32(0)
+	pool.AddScalingCallback(func(event *session.ConnectionPoolScalingEvent) {
+		if event.Action == session.ConnectionPoolScalingResetLimit {
+			atomic.AddInt32(&resets, 1)
+		}
+	})
+
+	conn, _, err := pool.Get(false, false, false)
+	FailError(t, err)
+
+	// the server rejects new connections
+	testServer.Stop()
+
+	_, _, err = pool.Get(true, false, false)
+	assert.True(t, types.IsConnectionPoolFullError(err))
+	assert.Equal(t, 1, pool.GetMaxConnections())
+
+	// the limit is kept over maintenance ticks until the backoff passes
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, pool.GetMaxConnections())
+	assert.Equal(t, int32(0), atomic.LoadInt32(&resets))
+
+	err = pool.Return(conn)
+	FailError(t, err)
+}
@@ -2,6 +2,8 @@ package testcases
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/cyverse/go-irodsclient/config"
@@ -19,7 +21,8 @@ func getUtilPasswordObfuscationTest() Test {
 func utilPasswordObfuscation(t *testing.T, test *Test) {
 	t.Run("StaticPasswords", testStaticPasswords)
 	t.Run("RandomPasswords", testRandomPasswords)
-
+	t.Run("PasswordFile", testPasswordFile)
+	t.Run("PasswordForOtherUID", testPasswordForOtherUID)
 }
 
 func testStaticPasswords(t *testing.T) {
@@ -60,3 +63,52 @@ func testRandomPasswords(t *testing.T) {
 		assert.Equal(t, mypassword, string(decodedPassword))
 	}
 }
+
+func testPasswordFile(t *testing.T) {
+	passwordFilePath := filepath.Join(t.TempDir(), ".irodsA")
+
+	mypassword := "mypassword_1234_!@#$"
+	err := config.WritePasswordFile(passwordFilePath, mypassword)
+	FailError(t, err)
+
+	st, err := os.Stat(passwordFilePath)
+	FailError(t, err)
+	assert.Equal(t, os.FileMode(0600), st.Mode().Perm())
+
+	decodedPassword, err := config.ReadPasswordFile(passwordFilePath)
+	FailError(t, err)
+	assert.Equal(t, mypassword, decodedPassword)
+
+	// malformed
+	err = os.WriteFile(passwordFilePath, []byte("abc"), 0600)
+	FailError(t, err)
+
+	_, err = config.ReadPasswordFile(passwordFilePath)
+	assert.Error(t, err)
+}
+
+func testPasswordForOtherUID(t *testing.T) {
+	mypassword := "mypassword_1234_!@#$"
+	uid := os.Getuid() + 1000
+
+	obfuscatedPassword := config.ObfuscatePassword(mypassword, uid)
+	decodedPassword, err := config.DeobfuscatePassword(obfuscatedPassword, uid)
+	FailError(t, err)
+	assert.Equal(t, mypassword, decodedPassword)
+
+	// UID is part of the key
+	decodedPassword, err = config.DeobfuscatePassword(obfuscatedPassword, os.Getuid())
+	FailError(t, err)
+	assert.NotEqual(t, mypassword, decodedPassword)
+
+	_, err = config.DeobfuscatePassword("abc", uid)
+	assert.Error(t, err)
+
+	passwordFilePath := filepath.Join(t.TempDir(), ".irodsA")
+	err = config.WritePasswordFileWithUID(passwordFilePath, mypassword, uid)
+	FailError(t, err)
+
+	decodedPassword, err = config.ReadPasswordFileWithUID(passwordFilePath, uid)
+	FailError(t, err)
+	assert.Equal(t, mypassword, decodedPassword)
+}
@@ -0,0 +1,116 @@
+package testcases
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cyverse/go-irodsclient/irods/util"
+	irods_path "github.com/cyverse/go-irodsclient/irods/util/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func getUtilPathTest() Test {
+	return Test{
+		Name: "Util_Path",
+		Func: utilPathTest,
+	}
+}
+
+func utilPathTest(t *testing.T, test *Test) {
+	t.Run("PathManipulation", testPathManipulation)
+	t.Run("PathZone", testPathZone)
+	t.Run("PathRel", testPathRel)
+	t.Run("PathTrash", testPathTrash)
+	t.Run("PathValidate", testPathValidate)
+	t.Run("PathSQLWildcards", testPathSQLWildcards)
+}
+
+func testPathManipulation(t *testing.T) {
+	assert.Equal(t, "/", irods_path.Clean(""))
+	assert.Equal(t, "/zone/home", irods_path.Clean("zone//home/./user/.."))
+	assert.Equal(t, "/zone/home/user/a.txt", irods_path.Join("/zone/home", "user/", "a.txt"))
+	assert.Equal(t, "/zone", irods_path.Join("zone"))
+
+	dir, name := irods_path.Split("/zone/home/user/")
+	assert.Equal(t, "/zone/home", dir)
+	assert.Equal(t, "user", name)
+
+	dir, name = irods_path.Split("/")
+	assert.Equal(t, "/", dir)
+	assert.Equal(t, "", name)
+
+	assert.Equal(t, "/zone", irods_path.Dir("/zone/home"))
+	assert.Equal(t, "home", irods_path.Base("/zone/home"))
+	assert.Equal(t, []string{"zone", "home"}, irods_path.Components("/zone/home/"))
+	assert.Equal(t, 0, irods_path.Depth("/"))
+	assert.Equal(t, 3, irods_path.Depth("/zone/home/user"))
+}
+
+func testPathZone(t *testing.T) {
+	zone, err := irods_path.GetZone("/zone/home/user")
+	FailError(t, err)
+	assert.Equal(t, "zone", zone)
+
+	_, err = irods_path.GetZone("/")
+	assert.Error(t, err)
+
+	_, err = irods_path.GetZone("zone/home")
+	assert.Error(t, err)
+}
+
+func testPathRel(t *testing.T) {
+	assert.Equal(t, "a/b.txt", irods_path.Rel("/zone/home", "/zone/home/a/b.txt"))
+	assert.Equal(t, ".", irods_path.Rel("/zone/home", "/zone/home/"))
+	assert.Equal(t, "../other/c", irods_path.Rel("/zone/home/user", "/zone/home/other/c"))
+
+	assert.True(t, irods_path.IsUnder("/zone/home", "/zone/home/user"))
+	assert.True(t, irods_path.IsUnder("/zone/home", "/zone/home"))
+	assert.True(t, irods_path.IsUnder("/", "/zone"))
+	assert.False(t, irods_path.IsUnder("/zone/home", "/zone/homework"))
+}
+
+func testPathTrash(t *testing.T) {
+	assert.Equal(t, "/zone/home/user", irods_path.GetHomeDirPath("zone", "user"))
+	assert.Equal(t, "/zone/trash/home/user", irods_path.GetTrashHomeDirPath("zone", "user"))
+
+	trashPath, err := irods_path.GetTrashPath("/zone/home/user/dir/a.txt", "user")
+	FailError(t, err)
+	assert.Equal(t, "/zone/trash/home/user/dir/a.txt", trashPath)
+	assert.True(t, irods_path.IsTrashPath(trashPath))
+
+	trashPath, err = irods_path.GetTrashPath("/zone/home/shared/a.txt", "user")
+	FailError(t, err)
+	assert.Equal(t, "/zone/trash/home/user/home/shared/a.txt", trashPath)
+
+	_, err = irods_path.GetTrashPath("/zone/trash/home/user/a.txt", "user")
+	assert.Error(t, err)
+
+	originalPath, err := irods_path.GetOriginalPathFromTrash("/zone/trash/home/user/dir/a.txt", "user")
+	FailError(t, err)
+	assert.Equal(t, "/zone/home/user/dir/a.txt", originalPath)
+
+	_, err = irods_path.GetOriginalPathFromTrash("/zone/home/user/a.txt", "user")
+	assert.Error(t, err)
+}
+
+func testPathValidate(t *testing.T) {
+	assert.NoError(t, irods_path.Validate("/"))
+	assert.NoError(t, irods_path.Validate("/zone/home/user/file with spaces & ünicode.txt"))
+	assert.NoError(t, irods_path.Validate("/zone/home/user/"))
+
+	assert.Error(t, irods_path.Validate("zone/home"))
+	assert.Error(t, irods_path.Validate("/zone//home"))
+	assert.Error(t, irods_path.Validate("/zone/home/../other"))
+	assert.Error(t, irods_path.Validate("/zone/home/a\nb"))
+	assert.Error(t, irods_path.Validate("/zone/home/a\x00b"))
+	assert.Error(t, irods_path.Validate("/zone/home/\xff"))
+	assert.Error(t, irods_path.Validate("/zone/"+strings.Repeat("a", 1024)))
+}
+
+func testPathSQLWildcards(t *testing.T) {
+	assert.Equal(t, `/zone/home/100\%/a\_b/back\\slash`, util.EscapeSQLWildcards(`/zone/home/100%/a_b/back\slash`))
+	assert.Equal(t, `/zone/it's`, util.EscapeSQLWildcards(`/zone/it's`))
+
+	// backslashes escape unix wildcards, so they are kept
+	assert.Equal(t, `100\%_a\_b%\*`, util.UnixWildcardsToSQLWildcards(`100%?a_b*\*`))
+}
@@ -0,0 +1,1036 @@
+package testcases
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cyverse/go-irodsclient/fs"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	"github.com/cyverse/go-irodsclient/irods/connection"
+	irods_fs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/cyverse/go-irodsclient/irods/message"
+	"github.com/cyverse/go-irodsclient/irods/metrics"
+	"github.com/cyverse/go-irodsclient/irods/testserver"
+	"github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func getUtilTestServerTest() Test {
+	return Test{
+		Name: "Util_TestServer",
+		Func: utilTestServerTest,
+	}
+}
+
+func utilTestServerTest(t *testing.T, test *Test) {
+	t.Run("FileSystem", testTestServerFileSystem)
+	t.Run("Authentication", testTestServerAuthentication)
+	t.Run("PhysicalMove", testTestServerPhysicalMove)
+	t.Run("UnregisteredAuthScheme", testTestServerUnregisteredAuthScheme)
+	t.Run("OpenIDAuthentication", testTestServerOpenIDAuthentication)
+	t.Run("WireDebugRedaction", testTestServerWireDebugRedaction)
+	t.Run("UploadChecksumNotRegistered", testTestServerUploadChecksumNotRegistered)
+	t.Run("SpecialCharacters", testTestServerSpecialCharacters)
+	t.Run("Append", testTestServerAppend)
+	t.Run("ChunkedUpload", testTestServerChunkedUpload)
+	t.Run("ChunkedUploadKeepAlive", testTestServerChunkedUploadKeepAlive)
+	t.Run("CrossTransferLockOrder", testTestServerCrossTransferLockOrder)
+	t.Run("PathLock", testTestServerPathLock)
+	t.Run("DefaultChecksumAlgorithm", testTestServerDefaultChecksumAlgorithm)
+	t.Run("ResourceHierarchyCache", testTestServerResourceHierarchyCache)
+	t.Run("DirCacheNegativeEntry", testTestServerDirCacheNegativeEntry)
+	t.Run("ReplicaAccessInfo", testTestServerReplicaAccessInfo)
+	t.Run("EmptyTrashMinAge", testTestServerEmptyTrashMinAge)
+	t.Run("QueryOperationError", testTestServerQueryOperationError)
+}
+
+func testTestServerFileSystem(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	testServer.AddUser("testuser", "testpassword")
+
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAccount("testuser")
+	FailError(t, err)
+
+	filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir := "/" + testserver.ZoneDefault + "/home/testuser"
+
+	// mkdir
+	dirPath := homeDir + "/dir1/dir2"
+	err = filesystem.MakeDir(dirPath, true)
+	FailError(t, err)
+	assert.True(t, filesystem.ExistsDir(dirPath))
+
+	// put
+	content := []byte("hello 'test' server")
+	localPath := filepath.Join(t.TempDir(), "test.txt")
+	err = os.WriteFile(localPath, content, 0644)
+	FailError(t, err)
+
+	filePath := homeDir + "/dir1/test.txt"
+	_, err = filesystem.UploadFile(localPath, filePath, "", false, false, nil)
+	FailError(t, err)
+
+	// stat
+	entry, err := filesystem.Stat(filePath)
+	FailError(t, err)
+	assert.Equal(t, int64(len(content)), entry.Size)
+	assert.Equal(t, "testuser", entry.Owner)
+
+	// list
+	entries, err := filesystem.List(homeDir + "/dir1")
+	FailError(t, err)
+	assert.Len(t, entries, 2)
+
+	// get
+	downloadPath := filepath.Join(t.TempDir(), "downloaded.txt")
+	_, err = filesystem.DownloadFile(filePath, "", downloadPath, false, nil)
+	FailError(t, err)
+
+	downloaded, err := os.ReadFile(downloadPath)
+	FailError(t, err)
+	assert.Equal(t, content, downloaded)
+
+	// rename and remove
+	newFilePath := dirPath + "/test_renamed.txt"
+	err = filesystem.RenameFile(filePath, newFilePath)
+	FailError(t, err)
+	assert.False(t, filesystem.ExistsFile(filePath))
+	assert.True(t, filesystem.ExistsFile(newFilePath))
+
+	err = filesystem.RemoveDir(homeDir+"/dir1", true, true)
+	FailError(t, err)
+	assert.False(t, filesystem.ExistsDir(homeDir+"/dir1"))
+	assert.False(t, filesystem.ExistsFile(newFilePath))
+}
+
+func testTestServerAuthentication(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	account.Password = "wrong_password"
+
+	_, err = fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	assert.Error(t, err)
+}
+
+func testTestServerUnregisteredAuthScheme(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	// unregister the native auth plugin, legacy native auth must not be used either
+	factory, ok := connection.GetAuthPluginFactory(types.AuthSchemeNative)
+	assert.True(t, ok)
+
+	connection.RegisterAuthPlugin(types.AuthSchemeNative, nil)
+	defer connection.RegisterAuthPlugin(types.AuthSchemeNative, factory)
+
+	conn, err := connection.NewIRODSConnection(account, nil)
+	FailError(t, err)
+
+	err = conn.Connect()
+	assert.Error(t, err)
+	assert.True(t, types.IsConnectionConfigError(err))
+	assert.Contains(t, err.Error(), "no auth plugin is registered")
+	assert.False(t, conn.IsLoggedIn())
+
+	// custom schemes are kept, so their auth plugins can be found
+	assert.Equal(t, types.AuthScheme("custom"), types.GetAuthScheme(" Custom "))
+	assert.Equal(t, types.AuthSchemeUnknown, types.GetAuthScheme(""))
+}
+
+// testOpenIDTokenSource returns the token or the error, and counts calls
+type testOpenIDTokenSource struct {
+	token string
+	err   error
+	calls int
+}
+
+func (source *testOpenIDTokenSource) Token() (string, error) {
+	source.calls++
+	return source.token, source.err
+}
+
+func testTestServerOpenIDAuthentication(t *testing.T) {
+	// openid requires the auth plugin framework of iRODS 4.3 and SSL
+	config := testserver.NewDefaultTestServerConfig()
+	config.ReleaseVersion = "4.3.0"
+	config.SSL = true
+
+	testServer := testserver.NewTestServer(config)
+	testServer.AddUser("testuser", "testpassword")
+	testServer.AddOpenIDToken("fresh_token", "testuser")
+
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAccount("testuser")
+	FailError(t, err)
+
+	account.AuthenticationScheme = types.AuthSchemeOpenID
+	account.Password = ""
+
+	// success, the token source is asked for a token on every login
+	tokenSource := &testOpenIDTokenSource{token: "fresh_token"}
+	conn, err := connection.NewIRODSConnection(account, &connection.IRODSConnectionConfig{
+		ApplicationName:   "go-irodsclient-test",
+		OpenIDTokenSource: tokenSource,
+	})
+	FailError(t, err)
+
+	err = conn.Connect()
+	FailError(t, err)
+	defer conn.Disconnect()
+
+	assert.True(t, conn.IsLoggedIn())
+	assert.True(t, conn.IsSSL())
+	assert.Equal(t, 1, tokenSource.calls)
+
+	homePath := "/" + testserver.ZoneDefault + "/home/testuser"
+	collection, err := irods_fs.GetCollection(conn, homePath)
+	FailError(t, err)
+	assert.Equal(t, homePath, collection.Path)
+
+	// token refresh failure
+	refreshErr := errors.New("failed to refresh token")
+	failingTokenSource := &testOpenIDTokenSource{err: refreshErr}
+	failingConn, err := connection.NewIRODSConnection(account, &connection.IRODSConnectionConfig{
+		ApplicationName:   "go-irodsclient-test",
+		OpenIDTokenSource: failingTokenSource,
+	})
+	FailError(t, err)
+
+	err = failingConn.Connect()
+	assert.Error(t, err)
+	assert.True(t, types.IsAuthError(err))
+	assert.True(t, errors.Is(err, refreshErr))
+	assert.False(t, failingConn.IsLoggedIn())
+	assert.Equal(t, 1, failingTokenSource.calls)
+
+	// token not accepted by the server
+	rejectedConn, err := connection.NewIRODSConnection(account, &connection.IRODSConnectionConfig{
+		ApplicationName:   "go-irodsclient-test",
+		OpenIDTokenSource: &testOpenIDTokenSource{token: "expired_token"},
+	})
+	FailError(t, err)
+
+	err = rejectedConn.Connect()
+	assert.Error(t, err)
+	assert.True(t, types.IsAuthError(err))
+	assert.False(t, rejectedConn.IsLoggedIn())
+}
+
+func testTestServerWireDebugRedaction(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	wireDebug := &bytes.Buffer{}
+	conn, err := connection.NewIRODSConnection(account, &connection.IRODSConnectionConfig{
+		ApplicationName: "go-irodsclient-test",
+		WireDebugWriter: wireDebug,
+	})
+	FailError(t, err)
+
+	err = conn.Connect()
+	FailError(t, err)
+	defer conn.Disconnect()
+
+	secrets := []string{"pam_secret_password", "openid_secret_token", "native_secret_result", "pam_interactive_secret_input"}
+
+	// pam_password auth request
+	pamRequest := message.NewIRODSMessageNewAuthPluginRequest(map[string]interface{}{
+		"scheme":          "pam_password",
+		"next_operation":  "auth_client_start",
+		"user_name":       account.ClientUser,
+		"zone_name":       account.ClientZone,
+		"password":        "pam_secret_password",
+		"a_pw":            "pam_secret_password",
+		"a_ttl":           "0",
+		"force_password":  false,
+		"access_token":    "openid_secret_token",
+		"session_context": map[string]interface{}{"password": "pam_secret_password"},
+	})
+	// native auth request carrying the response to a challenge
+	nativeRequest := message.NewIRODSMessageNewAuthPluginRequest(map[string]interface{}{
+		"scheme":         "native",
+		"next_operation": "native_auth_client_response",
+		"user_name":      account.ClientUser,
+		"zone_name":      account.ClientZone,
+		"request_result": "native_secret_result",
+	})
+	// pam_interactive auth request carrying user input in resp, state patches and pstate
+	pamInteractiveRequest := message.NewIRODSMessageNewAuthPluginRequest(map[string]interface{}{
+		"scheme":         "pam_interactive",
+		"next_operation": "waiting_pw",
+		"user_name":      account.ClientUser,
+		"zone_name":      account.ClientZone,
+		"resp":           "pam_interactive_secret_input",
+		"patch": []interface{}{
+			map[string]interface{}{"op": "add", "path": "/password", "value": "pam_interactive_secret_input"},
+			map[string]interface{}{"op": "remove", "path": "/otp"},
+		},
+		"pstate": map[string]interface{}{"password": "pam_interactive_secret_input"},
+		"pdirty": true,
+	})
+
+	for _, request := range []*message.IRODSMessageNewAuthPluginRequest{pamRequest, nativeRequest, pamInteractiveRequest} {
+		// the test server may not support the api, only the dump matters
+		conn.Lock()
+		conn.RequestAndCheck(request, &message.IRODSMessageNewAuthPluginResponse{}, nil, conn.GetOperationTimeout()) //nolint
+		conn.Unlock()
+	}
+
+	dump := wireDebug.String()
+	assert.Contains(t, dump, "pam_password")
+	assert.Contains(t, dump, "native_auth_client_response")
+	assert.Contains(t, dump, "waiting_pw")
+	// patch operations and paths are kept
+	assert.Contains(t, dump, "/otp")
+
+	for _, secret := range secrets {
+		assert.NotContains(t, dump, secret)
+		assert.NotContains(t, dump, base64.StdEncoding.EncodeToString([]byte(secret)))
+	}
+}
+
+func testTestServerUploadChecksumNotRegistered(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	testServer.AddUser("testuser", "testpassword")
+
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAccount("testuser")
+	FailError(t, err)
+
+	filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir := "/" + testserver.ZoneDefault + "/home/testuser"
+
+	localPath := filepath.Join(t.TempDir(), "checksum_test.txt")
+	err = os.WriteFile(localPath, []byte("checksum is not registered by the test server"), 0644)
+	FailError(t, err)
+
+	// the test server does not register checksums, so verification fails and the data object is removed
+	filePath := homeDir + "/checksum_test.txt"
+	_, err = filesystem.UploadFile(localPath, filePath, "", false, true, nil)
+	assert.Error(t, err)
+	assert.False(t, filesystem.ExistsFile(filePath))
+
+	parallelFilePath := homeDir + "/checksum_test_parallel.txt"
+	_, err = filesystem.UploadFileParallel(localPath, parallelFilePath, "", 2, false, true, nil)
+	assert.Error(t, err)
+	assert.False(t, filesystem.ExistsFile(parallelFilePath))
+}
+
+func testTestServerSpecialCharacters(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	testServer.AddUser("testuser", "testpassword")
+
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAccount("testuser")
+	FailError(t, err)
+
+	filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir := "/" + testserver.ZoneDefault + "/home/testuser"
+
+	// wildcards and quotes in paths must match literally
+	for _, name := range []string{"a_b", "aXb", "100%", "1000", "it's", "it''s"} {
+		err = filesystem.MakeDir(homeDir+"/"+name+"/sub", true)
+		FailError(t, err)
+	}
+
+	for _, name := range []string{"a_b", "100%", "it's"} {
+		dirStat, err := filesystem.GetCollectionSize(homeDir+"/"+name, true)
+		FailError(t, err)
+		assert.Equal(t, int64(1), dirStat.DirCount, name)
+
+		entries, err := filesystem.ListWithOptions(homeDir+"/"+name, &fs.ListOptions{
+			NamePattern: "%",
+		})
+		FailError(t, err)
+		assert.Len(t, entries, 1, name)
+	}
+}
+
+func testTestServerAppend(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	testServer.AddUser("testuser", "testpassword")
+
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAccount("testuser")
+	FailError(t, err)
+
+	filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	appendTestPath := "/" + testserver.ZoneDefault + "/home/testuser/append_test.txt"
+
+	size, err := filesystem.Append(appendTestPath, "", []byte("hello"))
+	FailError(t, err)
+	assert.Equal(t, int64(5), size)
+	assert.True(t, filesystem.ExistsFile(appendTestPath))
+
+	// writes by other handles are not overwritten
+	handle1, err := filesystem.OpenFile(appendTestPath, "", "a")
+	FailError(t, err)
+
+	handle2, err := filesystem.OpenFile(appendTestPath, "", "a")
+	FailError(t, err)
+
+	_, err = handle1.Write([]byte(" world"))
+	FailError(t, err)
+
+	_, err = handle2.Write([]byte("!"))
+	FailError(t, err)
+
+	_, err = handle1.Write([]byte("?"))
+	FailError(t, err)
+
+	err = handle1.Close()
+	FailError(t, err)
+
+	err = handle2.Close()
+	FailError(t, err)
+
+	buffer := bytes.Buffer{}
+	_, err = filesystem.DownloadFileToBuffer(appendTestPath, "", &buffer, false, nil)
+	FailError(t, err)
+	assert.Equal(t, "hello world!?", buffer.String())
+}
+
+func testTestServerChunkedUpload(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	testServer.AddUser("testuser", "testpassword")
+
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAccount("testuser")
+	FailError(t, err)
+
+	filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir := "/" + testserver.ZoneDefault + "/home/testuser"
+	uploadPath := homeDir + "/chunked_upload.txt"
+
+	_, err = filesystem.UploadFileFromBuffer(bytes.NewBufferString("existing"), uploadPath, "", false, false, nil)
+	FailError(t, err)
+
+	readFile := func() string {
+		buffer := bytes.Buffer{}
+		_, err := filesystem.DownloadFileToBuffer(uploadPath, "", &buffer, false, nil)
+		FailError(t, err)
+		return buffer.String()
+	}
+
+	// the existing file is kept until the upload is completed
+	upload, err := filesystem.StartUpload(uploadPath, &fs.StartUploadOptions{
+		Size: 11,
+	})
+	FailError(t, err)
+
+	err = upload.UploadChunk(6, []byte("world"))
+	FailError(t, err)
+
+	err = upload.UploadChunk(0, []byte("hello "))
+	FailError(t, err)
+
+	entries, err := filesystem.List(homeDir)
+	FailError(t, err)
+	assert.Len(t, entries, 2)
+
+	entry, err := filesystem.StatNoCache(uploadPath)
+	FailError(t, err)
+	assert.Equal(t, int64(8), entry.Size)
+
+	entry, err = upload.CompleteUpload()
+	FailError(t, err)
+	assert.Equal(t, int64(11), entry.Size)
+	assert.Equal(t, "hello world", readFile())
+
+	// size mismatch fails without replacing the file
+	upload, err = filesystem.StartUpload(uploadPath, &fs.StartUploadOptions{
+		Size: 100,
+	})
+	FailError(t, err)
+
+	err = upload.UploadChunk(0, []byte("short"))
+	FailError(t, err)
+
+	_, err = upload.CompleteUpload()
+	assert.Error(t, err)
+	assert.Equal(t, "hello world", readFile())
+
+	// abort keeps the existing file
+	upload, err = filesystem.StartUpload(uploadPath, nil)
+	FailError(t, err)
+
+	err = upload.UploadChunk(0, []byte("aborted"))
+	FailError(t, err)
+
+	err = upload.AbortUpload()
+	FailError(t, err)
+	assert.Equal(t, "hello world", readFile())
+
+	// exclusive upload fails if the file exists
+	_, err = filesystem.StartUpload(uploadPath, &fs.StartUploadOptions{
+		Exclusive: true,
+	})
+	assert.True(t, types.IsFileAlreadyExistError(err))
+
+	// temporary files are removed
+	entries, err = filesystem.ListNoCache(homeDir)
+	FailError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func testTestServerChunkedUploadKeepAlive(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	testServer.AddUser("testuser", "testpassword")
+
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAccount("testuser")
+	FailError(t, err)
+
+	config := fs.NewFileSystemConfig("go-irodsclient-test")
+	config.IOConnection.ControlKeepAlive = types.Duration(10 * time.Millisecond)
+
+	filesystem, err := fs.NewFileSystem(account, config)
+	FailError(t, err)
+	defer filesystem.Release()
+
+	uploadPath := "/" + testserver.ZoneDefault + "/home/testuser/chunked_upload_keepalive.txt"
+
+	upload, err := filesystem.StartUpload(uploadPath, nil)
+	FailError(t, err)
+
+	err = upload.UploadChunk(0, []byte("hello "))
+	FailError(t, err)
+
+	// the connection is pinged while the upload is idle, pings are counted as other operations
+	pings := filesystem.GetMetrics().GetOperationMetrics().Get(metrics.OperationOther).GetCount()
+	time.Sleep(100 * time.Millisecond)
+	assert.Greater(t, filesystem.GetMetrics().GetOperationMetrics().Get(metrics.OperationOther).GetCount(), pings)
+
+	err = upload.UploadChunk(6, []byte("world"))
+	FailError(t, err)
+
+	entry, err := upload.CompleteUpload()
+	FailError(t, err)
+	assert.Equal(t, int64(11), entry.Size)
+
+	// pings stop when the upload is completed
+	pings = filesystem.GetMetrics().GetOperationMetrics().Get(metrics.OperationOther).GetCount()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, pings, filesystem.GetMetrics().GetOperationMetrics().Get(metrics.OperationOther).GetCount())
+}
+
+func testTestServerCrossTransferLockOrder(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	testServer.AddUser("testuser", "testpassword")
+
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAccount("testuser")
+	FailError(t, err)
+
+	filesystem1, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem1.Release()
+
+	filesystem2, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem2.Release()
+
+	// paths of the FileSystem with the lower ID are locked first
+	lowFS, highFS := filesystem1, filesystem2
+	if highFS.GetID() < lowFS.GetID() {
+		lowFS, highFS = highFS, lowFS
+	}
+
+	homeDir := "/" + testserver.ZoneDefault + "/home/testuser"
+	lowPath := homeDir + "/cross_transfer_low.txt"
+	highPath := homeDir + "/cross_transfer_high.txt"
+
+	_, err = highFS.UploadFileFromBuffer(bytes.NewBufferString("cross transfer"), highPath, "", false, false, nil)
+	FailError(t, err)
+
+	// hold the lock of the destination
+	upload, err := lowFS.StartUpload(lowPath, nil)
+	FailError(t, err)
+
+	transferDone := make(chan error, 1)
+	go func() {
+		_, transferErr := fs.CrossTransferFile(highFS, highPath, lowFS, lowPath, &fs.CrossTransferOptions{
+			Force: true,
+		})
+		transferDone <- transferErr
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+
+	// the transfer waiting for the destination must not hold the source, a transfer in the opposite direction would deadlock
+	highFS.GetConfig().RejectConcurrentPathOperations = true
+	_, err = highFS.UploadFileFromBuffer(bytes.NewBufferString("cross transfer"), highPath, "", false, false, nil)
+	FailError(t, err)
+	highFS.GetConfig().RejectConcurrentPathOperations = false
+
+	err = upload.UploadChunk(0, []byte("upload"))
+	FailError(t, err)
+
+	_, err = upload.CompleteUpload()
+	FailError(t, err)
+
+	select {
+	case err = <-transferDone:
+		FailError(t, err)
+	case <-time.After(30 * time.Second):
+		assert.FailNow(t, "cross transfer is not finished")
+	}
+}
+
+func testTestServerPathLock(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	testServer.AddUser("testuser", "testpassword")
+
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAccount("testuser")
+	FailError(t, err)
+
+	filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir := "/" + testserver.ZoneDefault + "/home/testuser"
+	dirPath := homeDir + "/path_lock"
+	filePath := dirPath + "/path_lock.txt"
+
+	err = filesystem.MakeDir(dirPath, false)
+	FailError(t, err)
+
+	filesystem.GetConfig().RejectConcurrentPathOperations = true
+
+	// uploads wait for write file handles
+	handle, err := filesystem.CreateFile(filePath, "", "w")
+	FailError(t, err)
+
+	_, err = handle.Write([]byte("file handle"))
+	FailError(t, err)
+
+	_, err = filesystem.UploadFileFromBuffer(bytes.NewBufferString("buffer upload"), filePath, "", false, false, nil)
+	assert.True(t, types.IsPathInUseError(err))
+
+	err = filesystem.TruncateFile(filePath, 0)
+	assert.True(t, types.IsPathInUseError(err))
+
+	// file handles are moved by rename
+	renamedFilePath := dirPath + "/path_lock_renamed.txt"
+	err = filesystem.RenameFile(filePath, renamedFilePath)
+	FailError(t, err)
+
+	err = handle.Close()
+	FailError(t, err)
+
+	_, err = filesystem.UploadFileFromBuffer(bytes.NewBufferString("buffer upload"), renamedFilePath, "", false, false, nil)
+	FailError(t, err)
+
+	// dirs are locked with their entries
+	upload, err := filesystem.StartUpload(filePath, nil)
+	FailError(t, err)
+
+	err = filesystem.RenameDirToDir(dirPath, homeDir+"/path_lock_renamed")
+	assert.True(t, types.IsPathInUseError(err))
+
+	err = filesystem.RemoveDir(dirPath, true, true)
+	assert.True(t, types.IsPathInUseError(err))
+
+	_, err = filesystem.OpenFile(filePath, "", "w")
+	assert.True(t, types.IsPathInUseError(err))
+
+	// other entries in the dir are not locked
+	err = filesystem.MakeDir(dirPath+"/subdir", false)
+	FailError(t, err)
+
+	_, err = upload.CompleteUpload()
+	FailError(t, err)
+
+	err = filesystem.RenameDirToDir(dirPath, homeDir+"/path_lock_renamed")
+	FailError(t, err)
+
+	filesystem.GetConfig().RejectConcurrentPathOperations = false
+}
+
+func testTestServerDefaultChecksumAlgorithm(t *testing.T) {
+	config := testserver.NewDefaultTestServerConfig()
+	config.HashScheme = "MD5"
+
+	testServer := testserver.NewTestServer(config)
+	testServer.AddUser("testuser", "testpassword")
+
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAccount("testuser")
+	FailError(t, err)
+
+	// the hash scheme of the account is preferred
+	account.DefaultHashScheme = "SHA256"
+
+	filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	assert.Equal(t, types.ChecksumAlgorithmSHA256, filesystem.GetDefaultChecksumAlgorithm())
+
+	// the hash scheme of the server is used if the account does not have it
+	account, err = testServer.GetAccount("testuser")
+	FailError(t, err)
+
+	account.DefaultHashScheme = ""
+
+	hintsFilesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer hintsFilesystem.Release()
+
+	assert.Equal(t, types.ChecksumAlgorithmMD5, hintsFilesystem.GetDefaultChecksumAlgorithm())
+}
+
+func testTestServerResourceHierarchyCache(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	testServer.AddUser("testuser", "testpassword")
+
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAccount("testuser")
+	FailError(t, err)
+
+	filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	resource := testServer.GetConfig().Resource
+
+	hierarchy, err := filesystem.ResolveResourceHierarchy(resource)
+	FailError(t, err)
+	assert.Equal(t, resource, hierarchy)
+
+	_, err = filesystem.ResolveResourceHierarchy("no_such_resource")
+	assert.True(t, types.IsResourceNotFoundError(err))
+
+	// resources found and not found are cached
+	testServer.Stop()
+
+	hierarchy, err = filesystem.ResolveResourceHierarchy(resource)
+	FailError(t, err)
+	assert.Equal(t, resource, hierarchy)
+
+	_, err = filesystem.ResolveResourceHierarchy("no_such_resource")
+	assert.True(t, types.IsResourceNotFoundError(err))
+
+	// resolved again after invalidation
+	filesystem.InvalidateResourceHierarchyCache()
+
+	_, err = filesystem.ResolveResourceHierarchy("no_such_resource")
+	assert.Error(t, err)
+	assert.False(t, types.IsResourceNotFoundError(err))
+}
+
+func testTestServerDirCacheNegativeEntry(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	testServer.AddUser("testuser", "testpassword")
+
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAccount("testuser")
+	FailError(t, err)
+
+	homeDir := "/" + testserver.ZoneDefault + "/home/testuser"
+
+	for _, negativeEntryTimeout := range []time.Duration{0, 1 * time.Second} {
+		fsConfig := fs.NewFileSystemConfig("go-irodsclient-test")
+		fsConfig.Cache.NegativeEntryTimeout = types.Duration(negativeEntryTimeout)
+
+		filesystem, err := fs.NewFileSystem(account, fsConfig)
+		FailError(t, err)
+
+		// cache the dir
+		_, err = filesystem.List(homeDir)
+		FailError(t, err)
+
+		// create a dir like other clients
+		conn, err := filesystem.GetMetadataConnection(true)
+		FailError(t, err)
+
+		dirPath := fmt.Sprintf("%s/dir_cache_negative_entry_%d", homeDir, negativeEntryTimeout)
+		err = irods_fs.CreateCollection(conn, dirPath, false)
+		FailError(t, err)
+
+		err = filesystem.ReturnMetadataConnection(conn)
+		FailError(t, err)
+
+		_, err = filesystem.Stat(dirPath)
+		if negativeEntryTimeout == 0 {
+			// the dir cache tells absence until it expires
+			assert.True(t, types.IsFileNotFoundError(err))
+		} else {
+			// negative entries expire earlier than the dir cache, the server is asked
+			FailError(t, err)
+		}
+
+		filesystem.Release()
+	}
+}
+
+func testTestServerReplicaAccessInfo(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	testServer.AddUser("testuser", "testpassword")
+
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAccount("testuser")
+	FailError(t, err)
+
+	filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	accessInfo := &types.IRODSReplicaAccessInfo{
+		Path:              "/" + testserver.ZoneDefault + "/home/testuser/replica.bin",
+		ResourceHierarchy: testServer.GetConfig().Resource,
+		ReplicaToken:      "a7a8b52e-8d8e-4b4c-b7a2-6fd1f0e4e5a1",
+		ThreadNum:         1,
+	}
+
+	// the replica token grants write access, so it is not printed
+	assert.NotContains(t, accessInfo.ToString(), accessInfo.ReplicaToken)
+	assert.Contains(t, accessInfo.ToString(), accessInfo.Path)
+
+	// nil access info and handles fail instead of panicking
+	_, err = filesystem.OpenDataObjectWithReplicaToken(nil, "w")
+	assert.Error(t, err)
+
+	var nilAccessInfo *types.IRODSReplicaAccessInfo
+	assert.Error(t, nilAccessInfo.Validate())
+
+	conn, err := filesystem.GetIOConnection(false)
+	FailError(t, err)
+	defer filesystem.ReturnIOConnection(conn)
+
+	err = irods_fs.CloseDataObjectReplica(conn, nil)
+	assert.Error(t, err)
+}
+
+func testTestServerEmptyTrashMinAge(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	testServer.AddUser("testuser", "testpassword")
+
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAccount("testuser")
+	FailError(t, err)
+
+	filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	homeDir := "/" + testserver.ZoneDefault + "/home/testuser"
+
+	err = filesystem.MakeDir(homeDir+"/dir1", true)
+	FailError(t, err)
+
+	_, err = filesystem.UploadFileFromBuffer(bytes.NewBufferString("hello"), homeDir+"/dir1/file1", "", false, false, nil)
+	FailError(t, err)
+
+	err = filesystem.RemoveDir(homeDir+"/dir1", true, false)
+	FailError(t, err)
+
+	trashEntries, err := filesystem.ListTrash(true)
+	FailError(t, err)
+	assert.Len(t, trashEntries, 2)
+
+	// sub-minute ages are not truncated to 0, which removes everything
+	err = filesystem.EmptyTrash(&fs.EmptyTrashOptions{MinAge: 30 * time.Second})
+	FailError(t, err)
+
+	trashEntries, err = filesystem.ListTrash(true)
+	FailError(t, err)
+	assert.Len(t, trashEntries, 2)
+
+	// modify times are in seconds
+	time.Sleep(2 * time.Second)
+
+	err = filesystem.EmptyTrash(&fs.EmptyTrashOptions{MinAge: time.Second})
+	FailError(t, err)
+
+	trashEntries, err = filesystem.ListTrash(true)
+	FailError(t, err)
+	assert.Empty(t, trashEntries)
+	assert.True(t, filesystem.ExistsDir(filesystem.GetTrashHomeDirPath()))
+}
+
+func testTestServerQueryOperationError(t *testing.T) {
+	testServer := testserver.NewTestServer(nil)
+	testServer.AddUser("testuser", "testpassword")
+
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAccount("testuser")
+	FailError(t, err)
+
+	filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	conn, err := filesystem.GetIOConnection(false)
+	FailError(t, err)
+	defer filesystem.ReturnIOConnection(conn)
+
+	// queries check the error in the response separately from the request
+	_, err = irods_fs.GetCollection(conn, "/"+testserver.ZoneDefault+"/home/testuser/nonexistent")
+	assert.Error(t, err)
+	assert.True(t, types.IsFileNotFoundError(err))
+
+	opErr := types.GetOperationError(err)
+	if assert.NotNil(t, opErr) {
+		assert.Equal(t, "Query", opErr.Operation)
+		assert.Equal(t, common.GEN_QUERY_AN, opErr.APINumber)
+		assert.Equal(t, common.CAT_NO_ROWS_FOUND, types.GetIRODSErrorCode(err))
+	}
+
+	// requests checked on the connection have the context too
+	err = irods_fs.MoveDataObject(conn, "/"+testserver.ZoneDefault+"/home/testuser/nonexistent.txt", "/"+testserver.ZoneDefault+"/home/testuser/moved.txt")
+	assert.Error(t, err)
+
+	opErr = types.GetOperationError(err)
+	if assert.NotNil(t, opErr) {
+		assert.Equal(t, common.DATA_OBJ_RENAME_AN, opErr.APINumber)
+	}
+}
+
+func testTestServerPhysicalMove(t *testing.T) {
+	config := testserver.NewDefaultTestServerConfig()
+
+	testServer := testserver.NewTestServer(config)
+	testServer.AddUser("testuser", "testpassword")
+	err := testServer.Start()
+	FailError(t, err)
+	defer testServer.Stop()
+
+	account, err := testServer.GetAdminAccount()
+	FailError(t, err)
+
+	filesystem, err := fs.NewFileSystemWithDefault(account, "go-irodsclient-test")
+	FailError(t, err)
+	defer filesystem.Release()
+
+	filePath := filesystem.GetHomeDirPath() + "/phymv.txt"
+	_, err = filesystem.UploadFileFromBuffer(bytes.NewBufferString("hello"), filePath, "", false, false, nil)
+	FailError(t, err)
+
+	// the test server has a single resource, so replicas are moved to where they are
+	err = filesystem.PhysicalMoveFile(filePath, "", config.Resource, nil, false)
+	FailError(t, err)
+
+	// hierarchies are sent with hierarchy keywords
+	err = filesystem.PhysicalMoveFile(filePath, "root;"+config.Resource, "root;"+config.Resource, nil, true)
+	FailError(t, err)
+
+	replicaNumber := 0
+	err = filesystem.PhysicalMoveFile(filePath, "", config.Resource, &replicaNumber, false)
+	FailError(t, err)
+
+	replicaNumber = 1
+	err = filesystem.PhysicalMoveFile(filePath, "", config.Resource, &replicaNumber, false)
+	assert.Equal(t, common.SYS_REPLICA_DOES_NOT_EXIST, types.GetIRODSErrorCode(err))
+
+	err = filesystem.PhysicalMoveFile(filePath, "", "otherResc", nil, false)
+	assert.Equal(t, common.SYS_RESC_DOES_NOT_EXIST, types.GetIRODSErrorCode(err))
+
+	err = filesystem.PhysicalMoveFile(filesystem.GetHomeDirPath()+"/no_such_file.txt", "", config.Resource, nil, false)
+	assert.True(t, types.IsFileNotFoundError(err))
+
+	// admin mode requires rodsadmin
+	userAccount, err := testServer.GetAccount("testuser")
+	FailError(t, err)
+
+	userFilesystem, err := fs.NewFileSystemWithDefault(userAccount, "go-irodsclient-test")
+	FailError(t, err)
+	defer userFilesystem.Release()
+
+	userFilePath := userFilesystem.GetHomeDirPath() + "/phymv.txt"
+	_, err = userFilesystem.UploadFileFromBuffer(bytes.NewBufferString("hello"), userFilePath, "", false, false, nil)
+	FailError(t, err)
+
+	err = userFilesystem.PhysicalMoveFile(userFilePath, "", config.Resource, nil, false)
+	FailError(t, err)
+
+	err = userFilesystem.PhysicalMoveFile(userFilePath, "", config.Resource, nil, true)
+	assert.True(t, errors.Is(err, types.ErrInsufficientPrivilegeLevel))
+}